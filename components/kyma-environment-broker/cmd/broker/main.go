@@ -21,6 +21,7 @@ import (
 	gardenerclient "github.com/gardener/gardener/pkg/client/core/clientset/versioned/typed/core/v1beta1"
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -39,27 +40,37 @@ import (
 	orchestrationExt "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/appinfo"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/archive"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/auditlog"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/avs"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/binding"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/cls"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/edp"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/expiration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/health"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ias"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/lms"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/metrics"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/middleware"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/operationlog"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/orchestration/cluster"
 	orchestrate "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/orchestration/handlers"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/orchestration/kyma"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/deprovisioning"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/input"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/pipeline"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/provisioning"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/update"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/upgrade_cluster"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/upgrade_kyma"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provider"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/quota"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtime"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtime/components"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtimeoverrides"
@@ -67,6 +78,7 @@ import (
 	uaa "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/servicemanager/xsuaa"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/webhook"
 )
 
 // Config holds configuration for the whole application
@@ -92,6 +104,9 @@ type Config struct {
 	// because some data must not be visible in the log file.
 	DumpProvisionerRequests bool `envconfig:"default=false"`
 
+	// EnableParallelProvisioningSteps enables running provisioning steps with the same weight concurrently.
+	EnableParallelProvisioningSteps bool `envconfig:"default=false"`
+
 	// OperationTimeout is used to check on a top-level if any operation didn't exceed the time for processing.
 	// It is used for provisioning and deprovisioning operations.
 	OperationTimeout time.Duration `envconfig:"default=24h"`
@@ -120,6 +135,21 @@ type Config struct {
 	IAS ias.Config
 	EDP edp.Config
 
+	// Binding configures the credentials issued on the runtimes for service bindings
+	Binding binding.Config
+
+	// TrialExpiration configures the suspension and the deprovisioning of the expired trial instances
+	TrialExpiration expiration.Config
+
+	// Outbox configures the delivery of the application events stored in the database
+	Outbox event.OutboxConfig
+
+	// Webhook configures the delivery of the notifications to the webhook subscriptions
+	Webhook webhook.Config
+
+	// Archive configures the retention of the operations of the deleted instances kept in the instance archive
+	Archive archive.Config
+
 	// Service Manager services
 	XSUAA struct {
 		Disabled bool `envconfig:"default=true"`
@@ -127,6 +157,12 @@ type Config struct {
 	Ems struct {
 		Disabled bool `envconfig:"default=true"`
 	}
+	Cls struct {
+		Disabled bool `envconfig:"default=true"`
+		// ConfigFilePath points to the YAML file with the Service Manager credentials and the parameters of the CLS instances,
+		// required if the CLS steps are enabled
+		ConfigFilePath string `envconfig:"optional"`
+	}
 
 	AuditLog auditlog.Config
 
@@ -137,6 +173,18 @@ type Config struct {
 
 	TrialRegionMappingFilePath string
 	MaxPaginationPage          int `envconfig:"default=100"`
+
+	// PipelineConfigFilePath points to the YAML file which defines steps of processes,
+	// the default steps are used if not set
+	PipelineConfigFilePath string `envconfig:"optional"`
+
+	// PlansConfigFilePath points to the YAML file which defines plans, regions and machine types offered in the platform regions,
+	// all plans are offered in all platform regions if not set
+	PlansConfigFilePath string `envconfig:"optional"`
+
+	// QuotasConfigFilePath points to the YAML file which defines the quotas of the global accounts,
+	// the number of instances is not limited if not set
+	QuotasConfigFilePath string `envconfig:"optional"`
 }
 
 func main() {
@@ -212,6 +260,15 @@ func main() {
 	lmsClient := lms.NewClient(cfg.LMS, logs.WithField("service", "lmsClient"))
 	lmsTenantManager := lms.NewTenantManager(db.LMSTenants(), lmsClient, logs.WithField("service", "lmsTenantManager"))
 
+	// CLS
+	var clsConfig *cls.Config
+	if !cfg.Cls.Disabled {
+		clsConfig, err = cls.ReadConfigFromFile(cfg.Cls.ConfigFilePath)
+		fatalOnError(err)
+	}
+	clsClient := cls.NewClient(clsConfig, logs.WithField("service", "clsClient"))
+	clsProvisioner := cls.NewProvisioner(db.CLSInstances(), clsClient, logs.WithField("service", "clsProvisioner"))
+
 	// Register disabler. Convention:
 	// {component-name} : {component-disabler-service}
 	//
@@ -262,17 +319,34 @@ func main() {
 	bundleBuilder := ias.NewBundleBuilder(clientHTTPForIAS, cfg.IAS)
 	iasTypeSetter := provisioning.NewIASType(bundleBuilder, cfg.IAS.Disabled)
 
-	// application event broker
-	eventBroker := event.NewPubSub(logs)
+	// application event broker, the events are stored in the database and delivered after the restart
+	eventBroker := event.NewOutbox(db.OutboxEvents(), cfg.Outbox, logs)
 
 	// metrics collectors
 	metrics.RegisterAll(eventBroker, db.Operations(), db.Instances())
 
+	// the processed steps are stored in the event log of the operations
+	operationlog.NewRecorder(db.OperationEvents()).Subscribe(eventBroker)
+
+	// the finished operations and the orchestration state changes are sent to the webhook subscriptions
+	webhook.NewNotifier(db.WebhookSubscriptions(), eventBroker).Subscribe(eventBroker)
+	webhook.NewSender(db.WebhookSubscriptions(), db.WebhookDeliveries(), cfg.Webhook, logs).Subscribe(eventBroker)
+
+	// the delivery starts when all subscribers are registered
+	go eventBroker.Run(ctx.Done())
+
 	//setup runtime overrides appender
 	runtimeOverrides := runtimeoverrides.NewRuntimeOverrides(ctx, cli)
 
 	// setup operation managers
-	provisionManager := provisioning.NewManager(db.Operations(), eventBroker, logs.WithField("provisioning", "manager"))
+	provisioningOperations := db.Operations()
+	if cfg.EnableParallelProvisioningSteps {
+		provisioningOperations = provisioning.NewMergingOperationStorage(db.Operations())
+	}
+	provisionManager := provisioning.NewManager(provisioningOperations, eventBroker, logs.WithField("provisioning", "manager"))
+	if cfg.EnableParallelProvisioningSteps {
+		provisionManager.EnableParallelSteps()
+	}
 	deprovisionManager := deprovisioning.NewManager(db.Operations(), eventBroker, logs.WithField("deprovisioning", "manager"))
 
 	serviceManagerClientFactory := servicemanager.NewClientFactory(cfg.ServiceManager)
@@ -280,171 +354,144 @@ func main() {
 	// define steps
 	accountVersionMapping := runtimeversion.NewAccountVersionMapping(ctx, cli, cfg.VersionConfig.Namespace, cfg.VersionConfig.Name, logs)
 	runtimeVerConfigurator := runtimeversion.NewRuntimeVersionConfigurator(cfg.KymaVersion, accountVersionMapping)
-	provisioningInit := provisioning.NewInitialisationStep(db.Operations(), db.Instances(),
+	provisioningInit := provisioning.NewInitialisationStep(provisioningOperations, db.Instances(),
 		provisionerClient, directorClient, inputFactory, externalEvalCreator, internalEvalUpdater, iasTypeSetter,
 		cfg.Provisioning.Timeout, cfg.OperationTimeout, runtimeVerConfigurator, serviceManagerClientFactory)
 	provisionManager.InitStep(provisioningInit)
 
-	provisioningSteps := []struct {
-		disabled bool
-		weight   int
-		step     provisioning.Step
-	}{
-		{
-			weight: 1,
-			step: provisioning.NewServiceManagerOfferingStep("XSUAA_Offering",
+	steps, err := loadPipeline(&cfg)
+	fatalOnError(err)
+
+	provisioningSteps := pipeline.Registry{
+		"XSUAA_Offering": func() process.NamedStep {
+			return provisioning.NewServiceManagerOfferingStep("XSUAA_Offering",
 				"xsuaa", "application", func(op *internal.ProvisioningOperation) *internal.ServiceManagerInstanceInfo {
 					return &op.XSUAA.Instance
-				}, db.Operations()),
-			disabled: cfg.XSUAA.Disabled,
+				}, provisioningOperations)
 		},
-		{
-			weight: 1,
-			step: provisioning.NewServiceManagerOfferingStep("EMS_Offering",
+		"EMS_Offering": func() process.NamedStep {
+			return provisioning.NewServiceManagerOfferingStep("EMS_Offering",
 				provisioning.EmsOfferingName, provisioning.EmsPlanName, func(op *internal.ProvisioningOperation) *internal.ServiceManagerInstanceInfo {
 					return &op.Ems.Instance
-				}, db.Operations()),
-			disabled: cfg.Ems.Disabled,
+				}, provisioningOperations)
 		},
-		{
-			weight: 2,
-			step:   provisioning.NewResolveCredentialsStep(db.Operations(), accountProvider),
+		"CLS_Offering": func() process.NamedStep {
+			return provisioning.NewClsOfferingStep(clsConfig, provisioningOperations)
 		},
-		{
-			weight: 2,
-			step: provisioning.NewXSUAAProvisioningStep(db.Operations(), uaa.Config{
+		"Resolve_Target_Secret": func() process.NamedStep {
+			return provisioning.NewResolveCredentialsStep(provisioningOperations, accountProvider)
+		},
+		"XSUAA_Provisioning": func() process.NamedStep {
+			return provisioning.NewXSUAAProvisioningStep(provisioningOperations, uaa.Config{
 				// todo: set correct values from env variables
 				DeveloperGroup:      "devGroup",
 				DeveloperRole:       "devRole",
 				NamespaceAdminGroup: "nag",
 				NamespaceAdminRole:  "nar",
-			}),
-			disabled: cfg.XSUAA.Disabled,
-		},
-		{
-			weight:   2,
-			step:     provisioning.NewEmsProvisionStep(db.Operations()),
-			disabled: cfg.Ems.Disabled,
-		},
-		{
-			weight:   2,
-			step:     provisioning.NewInternalEvaluationStep(avsDel, internalEvalAssistant),
-			disabled: cfg.Avs.Disabled,
-		},
-		{
-			weight: 2,
-			step:   provisioning.NewLmsActivationStep(cfg.LMS, provisioning.NewProvideLmsTenantStep(lmsTenantManager, db.Operations(), cfg.LMS.Region, cfg.LMS.Mandatory)),
-		},
-		{
-			weight:   2,
-			step:     provisioning.NewEDPRegistrationStep(db.Operations(), edpClient, cfg.EDP),
-			disabled: cfg.EDP.Disabled,
-		},
-		{
-			weight: 3,
-			step:   provisioning.NewSkipForTrialPlanStep(provisioning.NewProvisionAzureEventHubStep(db.Operations(), azure.NewAzureProvider(), accountProvider, ctx)),
-		},
-		{
-			weight: 3,
-			step:   provisioning.NewEnableForTrialPlanStep(provisioning.NewNatsStreamingOverridesStep()),
-		},
-		{
-			weight: 3,
-			step:   provisioning.NewOverridesFromSecretsAndConfigStep(db.Operations(), runtimeOverrides, runtimeVerConfigurator),
-		},
-		{
-			weight: 3,
-			step:   provisioning.NewServiceManagerOverridesStep(db.Operations()),
-		},
-		{
-			weight: 3,
-			step:   provisioning.NewAuditLogOverridesStep(db.Operations(), cfg.AuditLog),
-		},
-		{
-			weight: 5,
-			step:   provisioning.NewLmsActivationStep(cfg.LMS, provisioning.NewLmsCertificatesStep(lmsClient, db.Operations(), cfg.LMS.Mandatory)),
-		},
-		{
-			weight:   6,
-			step:     provisioning.NewIASRegistrationStep(db.Operations(), bundleBuilder),
-			disabled: cfg.IAS.Disabled,
-		},
-		{
-			weight:   7,
-			step:     provisioning.NewXSUAABindingStep(db.Operations()),
-			disabled: cfg.XSUAA.Disabled,
-		},
-		{
-			weight:   7,
-			step:     provisioning.NewEmsBindStep(db.Operations(), cfg.Database.SecretKey),
-			disabled: cfg.Ems.Disabled,
-		},
-		{
-			weight: 10,
-			step:   provisioning.NewCreateRuntimeStep(db.Operations(), db.RuntimeStates(), db.Instances(), provisionerClient),
-		},
-	}
-	for _, step := range provisioningSteps {
-		if !step.disabled {
-			provisionManager.AddStep(step.weight, step.step)
-		}
+			})
+		},
+		"EMS_Provision": func() process.NamedStep {
+			return provisioning.NewEmsProvisionStep(provisioningOperations)
+		},
+		"CLS_Provision": func() process.NamedStep {
+			return provisioning.NewClsProvisionStep(clsConfig, clsProvisioner, provisioningOperations)
+		},
+		"AVS_Create_Internal_Eval_Step": func() process.NamedStep {
+			return provisioning.NewInternalEvaluationStep(avsDel, internalEvalAssistant)
+		},
+		"Create_LMS_Tenant": func() process.NamedStep {
+			return provisioning.NewLmsActivationStep(cfg.LMS, provisioning.NewProvideLmsTenantStep(lmsTenantManager, provisioningOperations, cfg.LMS.Region))
+		},
+		"EDP_Registration": func() process.NamedStep {
+			return provisioning.NewEDPRegistrationStep(provisioningOperations, edpClient, cfg.EDP)
+		},
+		"Provision Azure Event Hubs": func() process.NamedStep {
+			return provisioning.NewSkipForTrialPlanStep(provisioning.NewProvisionAzureEventHubStep(provisioningOperations, azure.NewAzureProvider(), accountProvider, ctx, cfg.Database.SecretKey))
+		},
+		"Provision Nats Streaming": func() process.NamedStep {
+			return provisioning.NewEnableForTrialPlanStep(provisioning.NewNatsStreamingOverridesStep())
+		},
+		"Overrides_From_Secrets_And_Config_Step": func() process.NamedStep {
+			return provisioning.NewOverridesFromSecretsAndConfigStep(provisioningOperations, runtimeOverrides, runtimeVerConfigurator)
+		},
+		"ServiceManagerOverrides": func() process.NamedStep {
+			return provisioning.NewServiceManagerOverridesStep(provisioningOperations)
+		},
+		"Audit_Log_Overrides": func() process.NamedStep {
+			return provisioning.NewAuditLogOverridesStep(provisioningOperations, cfg.AuditLog)
+		},
+		"Request_LMS_Certificates": func() process.NamedStep {
+			return provisioning.NewLmsActivationStep(cfg.LMS, provisioning.NewLmsCertificatesStep(lmsClient, provisioningOperations, cfg.Database.SecretKey))
+		},
+		"IAS_Registration": func() process.NamedStep {
+			return provisioning.NewIASRegistrationStep(provisioningOperations, bundleBuilder, cfg.Database.SecretKey)
+		},
+		"XSUAA_Binding": func() process.NamedStep {
+			return provisioning.NewXSUAABindingStep(provisioningOperations)
+		},
+		"EMS_Bind": func() process.NamedStep {
+			return provisioning.NewEmsBindStep(provisioningOperations, cfg.Database.SecretKey)
+		},
+		"Stored_Overrides": func() process.NamedStep {
+			return provisioning.NewStoredOverridesStep(provisioningOperations, cfg.Database.SecretKey)
+		},
+		"Create_Runtime": func() process.NamedStep {
+			return provisioning.NewCreateRuntimeStep(provisioningOperations, db.RuntimeStates(), db.Instances(), provisionerClient)
+		},
 	}
+	err = pipeline.AddSteps(provisionManager, steps.Provisioning, provisioningSteps)
+	fatalOnError(errors.Wrap(err, "while adding provisioning steps"))
+
+	bindingManager := binding.NewManager(db.Bindings(), db.Instances(), provisionerClient,
+		binding.NewServiceAccountCredentials(cfg.Binding, binding.NewClient), cfg.Binding.Timeout, logs.WithField("service", "bindingManager"))
 
-	deprovisioningInit := deprovisioning.NewInitialisationStep(db.Operations(), db.Instances(), provisionerClient, accountProvider, serviceManagerClientFactory, cfg.OperationTimeout)
+	// the deleted instances are archived together with their operations and runtime states
+	instanceArchiver := archive.NewArchiver(db.Operations(), db.RuntimeStates(), db.InstanceArchives())
+	archivingInstances := archive.NewArchivingStorage(db.Instances(), instanceArchiver)
+	deprovisioningInit := deprovisioning.NewInitialisationStep(db.Operations(), archivingInstances, provisionerClient, accountProvider, serviceManagerClientFactory, cfg.OperationTimeout)
 	deprovisionManager.InitStep(deprovisioningInit)
-	deprovisioningSteps := []struct {
-		disabled bool
-		weight   int
-		step     deprovisioning.Step
-	}{
-		{
-			weight: 1,
-			step:   deprovisioning.NewAvsEvaluationsRemovalStep(avsDel, db.Operations(), externalEvalAssistant, internalEvalAssistant),
-		},
-		{
-			weight: 1,
-			step:   deprovisioning.NewSkipForTrialPlanStep(deprovisioning.NewDeprovisionAzureEventHubStep(db.Operations(), azure.NewAzureProvider(), accountProvider, ctx)),
-		},
-		{
-			weight:   1,
-			step:     deprovisioning.NewEDPDeregistrationStep(edpClient, cfg.EDP),
-			disabled: cfg.EDP.Disabled,
-		},
-		{
-			weight:   1,
-			step:     deprovisioning.NewIASDeregistrationStep(db.Operations(), bundleBuilder),
-			disabled: cfg.IAS.Disabled,
-		},
-		{
-			weight:   1,
-			step:     deprovisioning.NewXSUAAUnbindStep(db.Operations()),
-			disabled: cfg.XSUAA.Disabled,
-		},
-		{
-			weight:   1,
-			step:     deprovisioning.NewEmsUnbindStep(db.Operations()),
-			disabled: cfg.Ems.Disabled,
-		},
-		{
-			weight:   2,
-			step:     deprovisioning.NewXSUAADeprovisionStep(db.Operations()),
-			disabled: cfg.XSUAA.Disabled,
-		},
-		{
-			weight:   2,
-			step:     deprovisioning.NewEmsDeprovisionStep(db.Operations()),
-			disabled: cfg.Ems.Disabled,
-		},
-		{
-			weight: 10,
-			step:   deprovisioning.NewRemoveRuntimeStep(db.Operations(), db.Instances(), provisionerClient),
-		},
-	}
-	for _, step := range deprovisioningSteps {
-		if !step.disabled {
-			deprovisionManager.AddStep(step.weight, step.step)
-		}
+	deprovisioningSteps := pipeline.Registry{
+		"De-provision_AVS_Evaluations": func() process.NamedStep {
+			return deprovisioning.NewSkipForHibernationStep(deprovisioning.NewAvsEvaluationsRemovalStep(avsDel, db.Operations(), externalEvalAssistant, internalEvalAssistant))
+		},
+		"Deprovision Azure Event Hubs": func() process.NamedStep {
+			return deprovisioning.NewSkipForHibernationStep(deprovisioning.NewSkipForTrialPlanStep(deprovisioning.NewDeprovisionAzureEventHubStep(db.Operations(), azure.NewAzureProvider(), accountProvider, ctx)))
+		},
+		"EDP_Deregistration": func() process.NamedStep {
+			return deprovisioning.NewSkipForHibernationStep(deprovisioning.NewEDPDeregistrationStep(edpClient, cfg.EDP))
+		},
+		"IAS_Deregistration": func() process.NamedStep {
+			return deprovisioning.NewSkipForHibernationStep(deprovisioning.NewIASDeregistrationStep(db.Operations(), bundleBuilder))
+		},
+		"XSUAA_Unbind": func() process.NamedStep {
+			return deprovisioning.NewSkipForHibernationStep(deprovisioning.NewXSUAAUnbindStep(db.Operations()))
+		},
+		"EMS_Unbind": func() process.NamedStep {
+			return deprovisioning.NewSkipForHibernationStep(deprovisioning.NewEmsUnbindStep(db.Operations()))
+		},
+		"XSUAA_Deprovision": func() process.NamedStep {
+			return deprovisioning.NewSkipForHibernationStep(deprovisioning.NewXSUAADeprovisionStep(db.Operations()))
+		},
+		"EMS_Deprovision": func() process.NamedStep {
+			return deprovisioning.NewSkipForHibernationStep(deprovisioning.NewEmsDeprovisionStep(db.Operations()))
+		},
+		"Remove_Bindings": func() process.NamedStep {
+			return deprovisioning.NewSkipForHibernationStep(deprovisioning.NewRemoveBindingsStep(db.Instances(), db.Bindings(), bindingManager))
+		},
+		"Remove_Runtime": func() process.NamedStep {
+			return deprovisioning.NewRemoveRuntimeStep(db.Operations(), db.Instances(), provisionerClient)
+		},
 	}
+	err = pipeline.AddSteps(deprovisionManager, steps.Deprovisioning, deprovisioningSteps)
+	fatalOnError(errors.Wrap(err, "while adding deprovisioning steps"))
+
+	updateManager := update.NewManager(db.Operations(), eventBroker, logs.WithField("update", "manager"))
+	updateManager.InitStep(update.NewInitialisationStep(db.Operations(), db.Instances(), provisionerClient, nil))
+	updateManager.AddStep(1, update.NewWakeUpStep(db.Operations(), provisionerClient, nil))
+	updateManager.AddStep(1, update.NewResolveCredentialsStep(db.Operations(), accountProvider))
+	updateManager.AddStep(2, update.NewUpgradeShootStep(db.Operations(), provisionerClient, inputFactory, nil))
+	updateManager.AddStep(3, update.NewExternalEvalStep(avsDel, externalEvalAssistant, db.Instances(), cfg.Avs.Disabled))
+	updateManager.AddStep(4, update.NewUpgradeKymaStep(db.Operations(), db.RuntimeStates(), provisionerClient, inputFactory, runtimeOverrides, nil))
 
 	// run queues
 	const workersAmount = 5
@@ -454,23 +501,63 @@ func main() {
 	deprovisionQueue := process.NewQueue(deprovisionManager, logs)
 	deprovisionQueue.Run(ctx.Done(), workersAmount)
 
-	plansValidator, err := broker.NewPlansSchemaValidator()
+	bindingQueue := process.NewQueue(bindingManager, logs)
+	bindingQueue.Run(ctx.Done(), workersAmount)
+
+	updateQueue := process.NewQueue(updateManager, logs)
+	updateQueue.Run(ctx.Done(), workersAmount)
+
+	var plansConfig broker.PlansConfig
+	if cfg.PlansConfigFilePath != "" {
+		plansConfig, err = broker.ReadPlansConfigFromFile(cfg.PlansConfigFilePath)
+		fatalOnError(err)
+	}
+	plansValidator, err := broker.NewPlansSchemaValidator(plansConfig)
+	fatalOnError(err)
+	plansUpdateValidator, err := broker.NewPlansUpdateSchemaValidator(plansConfig)
+	fatalOnError(err)
+
+	var quotasConfig quota.Config
+	if cfg.QuotasConfigFilePath != "" {
+		quotasConfig, err = quota.ReadConfigFromFile(cfg.QuotasConfigFilePath)
+		fatalOnError(err)
+	}
+	quotaService := quota.NewService(db.Instances(), quotasConfig, logs)
+
+	upgradeKymaManager, err := NewUpgradeKymaManager(ctx, db, runtimeOverrides, provisionerClient, eventBroker, inputFactory, nil,
+		runtimeVerConfigurator, upgradeEvalManager, &cfg, accountProvider, serviceManagerClientFactory, logs)
 	fatalOnError(err)
+	// the queue processes the upgrade kyma operations which are not a part of any orchestration
+	upgradeKymaQueue := process.NewQueue(upgradeKymaManager, logs)
+	upgradeKymaQueue.Run(ctx.Done(), workersAmount)
+
+	suspensionCtxHandler := suspension.NewContextUpdateHandler(db.Operations(), provisionQueue, deprovisionQueue, updateQueue, logs)
+
+	// the expired trial instances are suspended and deprovisioned after the grace period
+	if !cfg.TrialExpiration.Disabled {
+		trialExpiration := expiration.NewService(db.Instances(), db.Operations(), suspensionCtxHandler, deprovisionQueue, eventBroker, cfg.TrialExpiration, logs)
+		go trialExpiration.Run(ctx.Done())
+	}
 
-	suspensionCtxHandler := suspension.NewContextUpdateHandler(db.Operations(), provisionQueue, deprovisionQueue, logs)
+	// the old operations of the archived instances are removed from the operations table
+	if !cfg.Archive.PruningDisabled {
+		pruner := archive.NewPruner(db.InstanceArchives(), db.Operations(), db.RuntimeStates(), db.OperationEvents(), cfg.Archive, logs)
+		go pruner.Run(ctx.Done())
+	}
 
 	// create KymaEnvironmentBroker endpoints
 	kymaEnvBroker := &broker.KymaEnvironmentBroker{
-		broker.NewServices(cfg.Broker, logs),
-		broker.NewProvision(cfg.Broker, cfg.Gardener, db.Operations(), db.Instances(), provisionQueue, inputFactory, plansValidator, cfg.EnableOnDemandVersion, logs),
+		broker.NewServices(cfg.Broker, plansConfig, cfg.KymaVersion, logs),
+		broker.NewProvision(cfg.Broker, cfg.Gardener, db.Operations(), db.Instances(), provisionQueue, inputFactory, plansValidator, quotaService, cfg.EnableOnDemandVersion, logs),
 		broker.NewDeprovision(db.Instances(), db.Operations(), deprovisionQueue, logs),
-		broker.NewUpdate(db.Instances(), db.Operations(), suspensionCtxHandler, cfg.UpdateProcessingEnabled, logs),
+		broker.NewUpdate(db.Instances(), db.Operations(), suspensionCtxHandler, cfg.UpdateProcessingEnabled, updateQueue, upgradeKymaQueue,
+			cfg.KymaVersion, runtimeVerConfigurator, plansUpdateValidator, logs),
 		broker.NewGetInstance(db.Instances(), logs),
 		broker.NewLastOperation(db.Operations(), db.Instances(), logs),
-		broker.NewBind(logs),
-		broker.NewUnbind(logs),
-		broker.NewGetBinding(logs),
-		broker.NewLastBindingOperation(logs),
+		broker.NewBind(db.Instances(), db.Operations(), db.Bindings(), bindingQueue, logs),
+		broker.NewUnbind(db.Bindings(), bindingQueue, logs),
+		broker.NewGetBinding(db.Bindings(), logs),
+		broker.NewLastBindingOperation(db.Bindings(), logs),
 	}
 
 	// create server
@@ -485,20 +572,31 @@ func main() {
 	router.Handle("/metrics", promhttp.Handler())
 
 	gardenerNamespace := fmt.Sprintf("garden-%s", cfg.Gardener.Project)
-	kymaQueue, err := NewOrchestrationProcessingQueue(ctx, db, runtimeOverrides, provisionerClient, gardenerClient,
-		gardenerNamespace, eventBroker, inputFactory, nil, time.Minute, runtimeVerConfigurator, cfg.DefaultRequestRegion, upgradeEvalManager,
-		&cfg, accountProvider, serviceManagerClientFactory, logs)
+	kymaQueue, err := NewOrchestrationProcessingQueue(ctx, db, upgradeKymaManager, gardenerClient, gardenerNamespace, eventBroker, time.Minute,
+		cfg.DefaultRequestRegion, serviceManagerClientFactory, logs)
 	fatalOnError(err)
 
 	// TODO: in case of cluster upgrade the same Azure Zones must be send to the Provisioner
-	orchestrationHandler := orchestrate.NewOrchestrationHandler(db, kymaQueue, cfg.MaxPaginationPage, logs)
+	clusterQueue, err := NewClusterOrchestrationProcessingQueue(ctx, db, provisionerClient, gardenerClient,
+		gardenerNamespace, eventBroker, inputFactory, nil, time.Minute, cfg.DefaultRequestRegion, &cfg, logs)
+	fatalOnError(err)
+
+	orchestrationHandler := orchestrate.NewOrchestrationHandler(db, kymaQueue, clusterQueue, cfg.MaxPaginationPage, logs)
 
 	if !cfg.DisableProcessOperationsInProgress {
 		err = processOperationsInProgressByType(dbmodel.OperationTypeProvision, db.Operations(), provisionQueue, logs)
 		fatalOnError(err)
 		err = processOperationsInProgressByType(dbmodel.OperationTypeDeprovision, db.Operations(), deprovisionQueue, logs)
 		fatalOnError(err)
-		err = reprocessOrchestrations(db.Orchestrations(), db.Operations(), kymaQueue, logs)
+		err = processOperationsInProgressByType(dbmodel.OperationTypeUpdate, db.Operations(), updateQueue, logs)
+		fatalOnError(err)
+		err = processUpgradeKymaOperationsInProgress(db.Operations(), upgradeKymaQueue, logs)
+		fatalOnError(err)
+		err = processBindingsInProgress(db.Bindings(), bindingQueue, logs)
+		fatalOnError(err)
+		err = reprocessOrchestrations(orchestrationExt.UpgradeKymaOrchestration, db.Orchestrations(), db.Operations(), kymaQueue, logs)
+		fatalOnError(err)
+		err = reprocessOrchestrations(orchestrationExt.UpgradeClusterOrchestration, db.Orchestrations(), db.Operations(), clusterQueue, logs)
 		fatalOnError(err)
 	} else {
 		logger.Info("Skipping processing operation in progress on start")
@@ -518,9 +616,29 @@ func main() {
 	orchestrationHandler.AttachRoutes(router)
 
 	// create list runtimes endpoint
-	runtimeHandler := runtime.NewHandler(db.Instances(), db.Operations(), cfg.MaxPaginationPage, cfg.DefaultRequestRegion)
+	runtimeHandler := runtime.NewHandler(db.Instances(), db.Operations(), db.InstanceArchives(), cfg.MaxPaginationPage, cfg.DefaultRequestRegion)
 	runtimeHandler.AttachRoutes(router)
 
+	// create trial extension endpoint
+	trialHandler := expiration.NewHandler(db.Instances(), db.Operations(), cfg.TrialExpiration, logs)
+	trialHandler.AttachRoutes(router)
+
+	// create hibernated runtime wake-up endpoint
+	wakeUpHandler := suspension.NewWakeUpHandler(db.Instances(), db.Operations(), suspensionCtxHandler, logs)
+	wakeUpHandler.AttachRoutes(router)
+
+	// create quota usage endpoint
+	quotaHandler := quota.NewHandler(quotaService)
+	quotaHandler.AttachRoutes(router)
+
+	// create operation event log endpoints
+	operationHandler := operationlog.NewHandler(db.Operations(), db.OperationEvents())
+	operationHandler.AttachRoutes(router)
+
+	// create webhook subscription endpoints
+	webhookHandler := webhook.NewHandler(db.WebhookSubscriptions(), db.WebhookDeliveries(), logs)
+	webhookHandler.AttachRoutes(router)
+
 	router.StrictSlash(true).PathPrefix("/").Handler(http.StripPrefix("/", http.FileServer(http.Dir("/swagger"))))
 	svr := handlers.CustomLoggingHandler(os.Stdout, router, func(writer io.Writer, params handlers.LogFormatterParams) {
 		logs.Infof("Call handled: method=%s url=%s statusCode=%d size=%d", params.Request.Method, params.URL.Path, params.StatusCode, params.Size)
@@ -542,20 +660,50 @@ func processOperationsInProgressByType(opType dbmodel.OperationType, op storage.
 	return nil
 }
 
-func reprocessOrchestrations(orchestrationsStorage storage.Orchestrations, operationsStorage storage.Operations, queue *process.Queue, log logrus.FieldLogger) error {
-	if err := processCancelingOrchestrations(orchestrationsStorage, operationsStorage, queue, log); err != nil {
-		return errors.Wrap(err, "while processing canceled orchestrations")
+// queues the upgrade kyma operations in progress which are not a part of any orchestration,
+// the operations of the orchestrations are resumed by the orchestrations
+func processUpgradeKymaOperationsInProgress(op storage.Operations, queue *process.Queue, log logrus.FieldLogger) error {
+	operations, err := op.GetNotFinishedOperationsByType(dbmodel.OperationTypeUpgradeKyma)
+	if err != nil {
+		return errors.Wrap(err, "while getting in progress operations from storage")
 	}
-	if err := processOrchestration(orchestrationExt.InProgress, orchestrationsStorage, queue, log); err != nil {
-		return errors.Wrap(err, "while processing in progress orchestrations")
+	for _, operation := range operations {
+		if operation.OrchestrationID != "" {
+			continue
+		}
+		queue.Add(operation.ID)
+		log.Infof("Resuming the processing of %s operation ID: %s", dbmodel.OperationTypeUpgradeKyma, operation.ID)
+	}
+	return nil
+}
+
+// queues all bindings with bind or unbind operation in progress
+func processBindingsInProgress(bindings storage.Bindings, queue *process.Queue, log logrus.FieldLogger) error {
+	inProgress, err := bindings.ListByState(domain.InProgress)
+	if err != nil {
+		return errors.Wrap(err, "while getting in progress bindings from storage")
+	}
+	for _, binding := range inProgress {
+		queue.Add(binding.BindingID)
+		log.Infof("Resuming the processing of %s operation of binding ID: %s", binding.Operation, binding.BindingID)
+	}
+	return nil
+}
+
+func reprocessOrchestrations(orchestrationType orchestrationExt.Type, orchestrationsStorage storage.Orchestrations, operationsStorage storage.Operations, queue *process.Queue, log logrus.FieldLogger) error {
+	if err := processCancelingOrchestrations(orchestrationType, orchestrationsStorage, operationsStorage, queue, log); err != nil {
+		return errors.Wrapf(err, "while processing canceled %s orchestrations", orchestrationType)
+	}
+	if err := processOrchestration(orchestrationType, orchestrationExt.InProgress, orchestrationsStorage, queue, log); err != nil {
+		return errors.Wrapf(err, "while processing in progress %s orchestrations", orchestrationType)
 	}
-	if err := processOrchestration(orchestrationExt.Pending, orchestrationsStorage, queue, log); err != nil {
-		return errors.Wrap(err, "while processing pending orchestrations")
+	if err := processOrchestration(orchestrationType, orchestrationExt.Pending, orchestrationsStorage, queue, log); err != nil {
+		return errors.Wrapf(err, "while processing pending %s orchestrations", orchestrationType)
 	}
 	return nil
 }
 
-func processOrchestration(state string, orchestrationsStorage storage.Orchestrations, queue *process.Queue, log logrus.FieldLogger) error {
+func processOrchestration(orchestrationType orchestrationExt.Type, state string, orchestrationsStorage storage.Orchestrations, queue *process.Queue, log logrus.FieldLogger) error {
 	orchestrations, err := orchestrationsStorage.ListByState(state)
 	if err != nil {
 		return errors.Wrapf(err, "while getting %s orchestrations from storage", state)
@@ -565,15 +713,24 @@ func processOrchestration(state string, orchestrationsStorage storage.Orchestrat
 	})
 
 	for _, o := range orchestrations {
+		if o.Type != orchestrationType {
+			continue
+		}
+		// scheduled orchestrations are processed at their start time
+		if until := o.Parameters.Strategy.StartsIn(time.Now()); until > 0 {
+			queue.AddAfter(o.OrchestrationID, until)
+			log.Infof("Scheduling the processing of %s %s orchestration ID: %s in %v", state, orchestrationType, o.OrchestrationID, until)
+			continue
+		}
 		queue.Add(o.OrchestrationID)
-		log.Infof("Resuming the processing of %s orchestration ID: %s", state, o.OrchestrationID)
+		log.Infof("Resuming the processing of %s %s orchestration ID: %s", state, orchestrationType, o.OrchestrationID)
 	}
 	return nil
 }
 
 // processCancelingOrchestrations reprocess orchestrations with canceling state only when some in progress operations exists
 // reprocess only one orchestration to not clog up the orchestration queue on start
-func processCancelingOrchestrations(orchestrationsStorage storage.Orchestrations, operationsStorage storage.Operations, queue *process.Queue, log logrus.FieldLogger) error {
+func processCancelingOrchestrations(orchestrationType orchestrationExt.Type, orchestrationsStorage storage.Orchestrations, operationsStorage storage.Operations, queue *process.Queue, log logrus.FieldLogger) error {
 	orchestrations, err := orchestrationsStorage.ListByState(orchestrationExt.Canceling)
 	if err != nil {
 		return errors.Wrap(err, "while getting canceling orchestrations from storage")
@@ -583,12 +740,26 @@ func processCancelingOrchestrations(orchestrationsStorage storage.Orchestrations
 	})
 
 	for _, o := range orchestrations {
-		ops, _, _, err := operationsStorage.ListUpgradeKymaOperationsByOrchestrationID(o.OrchestrationID, dbmodel.OperationFilter{States: []string{orchestrationExt.InProgress}})
-		if err != nil {
-			return errors.Wrapf(err, "while listing upgrade kyma operations for orchestration %s", o.OrchestrationID)
+		if o.Type != orchestrationType {
+			continue
 		}
-		if len(ops) > 0 {
-			log.Infof("Resuming the processing of %s orchestration ID: %s", orchestrationExt.Canceling, o.OrchestrationID)
+		var count int
+		switch orchestrationType {
+		case orchestrationExt.UpgradeClusterOrchestration:
+			ops, _, _, err := operationsStorage.ListUpgradeClusterOperationsByOrchestrationID(o.OrchestrationID, dbmodel.OperationFilter{States: []string{orchestrationExt.InProgress}})
+			if err != nil {
+				return errors.Wrapf(err, "while listing upgrade cluster operations for orchestration %s", o.OrchestrationID)
+			}
+			count = len(ops)
+		default:
+			ops, _, _, err := operationsStorage.ListUpgradeKymaOperationsByOrchestrationID(o.OrchestrationID, dbmodel.OperationFilter{States: []string{orchestrationExt.InProgress}})
+			if err != nil {
+				return errors.Wrapf(err, "while listing upgrade kyma operations for orchestration %s", o.OrchestrationID)
+			}
+			count = len(ops)
+		}
+		if count > 0 {
+			log.Infof("Resuming the processing of %s %s orchestration ID: %s", orchestrationExt.Canceling, orchestrationType, o.OrchestrationID)
 			queue.Add(o.OrchestrationID)
 			return nil
 		}
@@ -623,67 +794,64 @@ func fatalOnError(err error) {
 	}
 }
 
-func NewOrchestrationProcessingQueue(ctx context.Context, db storage.BrokerStorage,
-	runtimeOverrides upgrade_kyma.RuntimeOverridesAppender, provisionerClient provisioner.Client,
-	gardenerClient gardenerclient.CoreV1beta1Interface, gardenerNamespace string, pub event.Publisher,
-	inputFactory input.CreatorForPlan, icfg *upgrade_kyma.TimeSchedule,
-	pollingInterval time.Duration, runtimeVerConfigurator *runtimeversion.RuntimeVersionConfigurator,
-	defaultRegion string, upgradeEvalManager *upgrade_kyma.EvaluationManager,
-	cfg *Config, accountProvider hyperscaler.AccountProvider, smcf *servicemanager.ClientFactory, logs logrus.FieldLogger) (*process.Queue, error) {
+// NewUpgradeKymaManager creates the manager which processes the upgrade kyma operations, the operations
+// are created by the orchestrations or by the maintenance info of the OSB update
+func NewUpgradeKymaManager(ctx context.Context, db storage.BrokerStorage,
+	runtimeOverrides upgrade_kyma.RuntimeOverridesAppender, provisionerClient provisioner.Client, pub event.Publisher,
+	inputFactory input.CreatorForPlan, icfg *upgrade_kyma.TimeSchedule, runtimeVerConfigurator *runtimeversion.RuntimeVersionConfigurator,
+	upgradeEvalManager *upgrade_kyma.EvaluationManager, cfg *Config, accountProvider hyperscaler.AccountProvider,
+	smcf *servicemanager.ClientFactory, logs logrus.FieldLogger) (*upgrade_kyma.Manager, error) {
 
 	upgradeKymaManager := upgrade_kyma.NewManager(db.Operations(), pub, logs.WithField("upgradeKyma", "manager"))
 	upgradeKymaInit := upgrade_kyma.NewInitialisationStep(db.Operations(), db.Orchestrations(), db.Instances(),
 		provisionerClient, inputFactory, upgradeEvalManager, icfg, runtimeVerConfigurator, smcf)
 
 	upgradeKymaManager.InitStep(upgradeKymaInit)
-	upgradeKymaSteps := []struct {
-		disabled bool
-		weight   int
-		step     upgrade_kyma.Step
-	}{
-		{
-			weight: 1,
-			step: upgrade_kyma.NewServiceManagerOfferingStep("EMS_Offering",
+	upgradeKymaSteps := pipeline.Registry{
+		"EMS_Offering": func() process.NamedStep {
+			return upgrade_kyma.NewServiceManagerOfferingStep("EMS_Offering",
 				provisioning.EmsOfferingName, provisioning.EmsPlanName, func(op *internal.UpgradeKymaOperation) *internal.ServiceManagerInstanceInfo {
 					return &op.Ems.Instance
-				}, db.Operations()),
-			disabled: cfg.Ems.Disabled,
+				}, db.Operations())
 		},
-		{
-			weight: 2,
-			step:   upgrade_kyma.NewOverridesFromSecretsAndConfigStep(db.Operations(), runtimeOverrides, runtimeVerConfigurator),
+		"Overrides_From_Secrets_And_Config_Step": func() process.NamedStep {
+			return upgrade_kyma.NewOverridesFromSecretsAndConfigStep(db.Operations(), runtimeOverrides, runtimeVerConfigurator)
 		},
-		{
-			weight:   3,
-			step:     upgrade_kyma.NewDeprovisionAzureEventHubStep(db.Operations(), azure.NewAzureProvider(), accountProvider, ctx),
-			disabled: cfg.Ems.Disabled,
+		"Deprovision Azure Event Hubs": func() process.NamedStep {
+			return upgrade_kyma.NewDeprovisionAzureEventHubStep(db.Operations(), azure.NewAzureProvider(), accountProvider, ctx)
 		},
-		{
-			weight:   4,
-			step:     upgrade_kyma.NewEmsUpgradeProvisionStep(db.Operations()),
-			disabled: cfg.Ems.Disabled,
+		"EMS_UpgradeProvision": func() process.NamedStep {
+			return upgrade_kyma.NewEmsUpgradeProvisionStep(db.Operations())
 		},
-		{
-			weight:   7,
-			step:     upgrade_kyma.NewEmsUpgradeBindStep(db.Operations(), cfg.Database.SecretKey),
-			disabled: cfg.Ems.Disabled,
+		"EMS_UpgradeBind": func() process.NamedStep {
+			return upgrade_kyma.NewEmsUpgradeBindStep(db.Operations(), cfg.Database.SecretKey)
 		},
-
-		{
-			weight: 10,
-			step:   upgrade_kyma.NewUpgradeKymaStep(db.Operations(), db.RuntimeStates(), provisionerClient, icfg),
+		"Stored_Overrides": func() process.NamedStep {
+			return upgrade_kyma.NewStoredOverridesStep(db.Operations(), cfg.Database.SecretKey)
+		},
+		"Upgrade_Kyma": func() process.NamedStep {
+			return upgrade_kyma.NewUpgradeKymaStep(db.Operations(), db.RuntimeStates(), provisionerClient, icfg)
 		},
 	}
-	for _, step := range upgradeKymaSteps {
-		if !step.disabled {
-			upgradeKymaManager.AddStep(step.weight, step.step)
-		}
+	steps, err := loadPipeline(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading the pipeline config")
+	}
+	if err := pipeline.AddSteps(upgradeKymaManager, steps.UpgradeKyma, upgradeKymaSteps); err != nil {
+		return nil, errors.Wrap(err, "while adding upgrade kyma steps")
 	}
 
+	return upgradeKymaManager, nil
+}
+
+func NewOrchestrationProcessingQueue(ctx context.Context, db storage.BrokerStorage, upgradeKymaManager *upgrade_kyma.Manager,
+	gardenerClient gardenerclient.CoreV1beta1Interface, gardenerNamespace string, pub event.Publisher, pollingInterval time.Duration,
+	defaultRegion string, smcf *servicemanager.ClientFactory, logs logrus.FieldLogger) (*process.Queue, error) {
+
 	runtimeLister := orchestration.NewRuntimeLister(db.Instances(), db.Operations(), runtime.NewConverter(defaultRegion), logs)
 	runtimeResolver := orchestrationExt.NewGardenerRuntimeResolver(gardenerClient, gardenerNamespace, runtimeLister, logs)
 
-	orchestrateKymaManager := kyma.NewUpgradeKymaManager(db.Orchestrations(), db.Operations(), db.Instances(),
+	orchestrateKymaManager := kyma.NewUpgradeKymaManager(orchestration.NewPublishingStorage(db.Orchestrations(), pub), db.Operations(), db.Instances(),
 		upgradeKymaManager, runtimeResolver, pollingInterval, smcf, logs)
 	queue := process.NewQueue(orchestrateKymaManager, logs)
 
@@ -692,3 +860,39 @@ func NewOrchestrationProcessingQueue(ctx context.Context, db storage.BrokerStora
 
 	return queue, nil
 }
+
+func NewClusterOrchestrationProcessingQueue(ctx context.Context, db storage.BrokerStorage, provisionerClient provisioner.Client,
+	gardenerClient gardenerclient.CoreV1beta1Interface, gardenerNamespace string, pub event.Publisher,
+	inputFactory input.CreatorForPlan, icfg *upgrade_cluster.TimeSchedule, pollingInterval time.Duration,
+	defaultRegion string, cfg *Config, logs logrus.FieldLogger) (*process.Queue, error) {
+
+	upgradeClusterManager := upgrade_cluster.NewManager(db.Operations(), pub, logs.WithField("upgradeCluster", "manager"))
+	upgradeClusterInit := upgrade_cluster.NewInitialisationStep(db.Operations(), db.Orchestrations(), db.Instances(),
+		provisionerClient, inputFactory, icfg)
+
+	upgradeClusterManager.InitStep(upgradeClusterInit)
+	upgradeClusterSteps := pipeline.Registry{
+		"Upgrade_Cluster": func() process.NamedStep {
+			return upgrade_cluster.NewUpgradeClusterStep(db.Operations(), provisionerClient, icfg)
+		},
+	}
+	steps, err := loadPipeline(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading the pipeline config")
+	}
+	if err := pipeline.AddSteps(upgradeClusterManager, steps.UpgradeCluster, upgradeClusterSteps); err != nil {
+		return nil, errors.Wrap(err, "while adding upgrade cluster steps")
+	}
+
+	runtimeLister := orchestration.NewRuntimeLister(db.Instances(), db.Operations(), runtime.NewConverter(defaultRegion), logs)
+	runtimeResolver := orchestrationExt.NewGardenerRuntimeResolver(gardenerClient, gardenerNamespace, runtimeLister, logs)
+
+	orchestrateClusterManager := cluster.NewUpgradeClusterManager(orchestration.NewPublishingStorage(db.Orchestrations(), pub), db.Operations(), db.Instances(),
+		upgradeClusterManager, runtimeResolver, pollingInterval, logs)
+	queue := process.NewQueue(orchestrateClusterManager, logs)
+
+	// only one orchestration can be processed at the same time
+	queue.Run(ctx.Done(), 1)
+
+	return queue, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/pipeline"
+)
+
+// defaultPipeline returns steps of processes used when the pipeline is not defined in the configuration file
+func defaultPipeline(cfg *Config) pipeline.Config {
+	return pipeline.Config{
+		Provisioning: []pipeline.StepDefinition{
+			{Name: "XSUAA_Offering", Weight: 1, Disabled: cfg.XSUAA.Disabled},
+			{Name: "EMS_Offering", Weight: 1, Disabled: cfg.Ems.Disabled},
+			{Name: "CLS_Offering", Weight: 1, Disabled: cfg.Cls.Disabled},
+			{Name: "Resolve_Target_Secret", Weight: 2},
+			{Name: "XSUAA_Provisioning", Weight: 2, Disabled: cfg.XSUAA.Disabled},
+			{Name: "EMS_Provision", Weight: 2, Disabled: cfg.Ems.Disabled},
+			{Name: "CLS_Provision", Weight: 2, Disabled: cfg.Cls.Disabled},
+			{Name: "AVS_Create_Internal_Eval_Step", Weight: 2, Disabled: cfg.Avs.Disabled},
+			{Name: "Create_LMS_Tenant", Weight: 2, Optional: !cfg.LMS.Mandatory},
+			{Name: "EDP_Registration", Weight: 2, Disabled: cfg.EDP.Disabled},
+			{Name: "Provision Azure Event Hubs", Weight: 3},
+			{Name: "Provision Nats Streaming", Weight: 3},
+			{Name: "Overrides_From_Secrets_And_Config_Step", Weight: 3},
+			{Name: "ServiceManagerOverrides", Weight: 3},
+			{Name: "Audit_Log_Overrides", Weight: 3},
+			{Name: "Request_LMS_Certificates", Weight: 5, Optional: !cfg.LMS.Mandatory},
+			{Name: "IAS_Registration", Weight: 6, Disabled: cfg.IAS.Disabled},
+			{Name: "XSUAA_Binding", Weight: 7, Disabled: cfg.XSUAA.Disabled},
+			{Name: "EMS_Bind", Weight: 7, Disabled: cfg.Ems.Disabled},
+			{Name: "Stored_Overrides", Weight: 9},
+			{Name: "Create_Runtime", Weight: 10},
+		},
+		Deprovisioning: []pipeline.StepDefinition{
+			{Name: "De-provision_AVS_Evaluations", Weight: 1},
+			{Name: "Deprovision Azure Event Hubs", Weight: 1},
+			{Name: "EDP_Deregistration", Weight: 1, Disabled: cfg.EDP.Disabled},
+			{Name: "IAS_Deregistration", Weight: 1, Disabled: cfg.IAS.Disabled},
+			{Name: "XSUAA_Unbind", Weight: 1, Disabled: cfg.XSUAA.Disabled},
+			{Name: "EMS_Unbind", Weight: 1, Disabled: cfg.Ems.Disabled},
+			{Name: "Remove_Bindings", Weight: 1},
+			{Name: "XSUAA_Deprovision", Weight: 2, Disabled: cfg.XSUAA.Disabled},
+			{Name: "EMS_Deprovision", Weight: 2, Disabled: cfg.Ems.Disabled},
+			{Name: "Remove_Runtime", Weight: 10},
+		},
+		UpgradeKyma: []pipeline.StepDefinition{
+			{Name: "EMS_Offering", Weight: 1, Disabled: cfg.Ems.Disabled},
+			{Name: "Overrides_From_Secrets_And_Config_Step", Weight: 2},
+			{Name: "Deprovision Azure Event Hubs", Weight: 3, Disabled: cfg.Ems.Disabled},
+			{Name: "EMS_UpgradeProvision", Weight: 4, Disabled: cfg.Ems.Disabled},
+			{Name: "EMS_UpgradeBind", Weight: 7, Disabled: cfg.Ems.Disabled},
+			{Name: "Stored_Overrides", Weight: 9},
+			{Name: "Upgrade_Kyma", Weight: 10},
+		},
+		UpgradeCluster: []pipeline.StepDefinition{
+			{Name: "Upgrade_Cluster", Weight: 10},
+		},
+	}
+}
+
+// loadPipeline returns the default pipeline overridden by the pipeline defined in the configuration file
+func loadPipeline(cfg *Config) (pipeline.Config, error) {
+	steps := defaultPipeline(cfg)
+	if cfg.PipelineConfigFilePath == "" {
+		return steps, nil
+	}
+
+	fromFile, err := pipeline.ReadConfigFromFile(cfg.PipelineConfigFilePath)
+	if err != nil {
+		return pipeline.Config{}, err
+	}
+	return steps.Override(fromFile), nil
+}
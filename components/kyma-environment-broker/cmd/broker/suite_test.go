@@ -106,13 +106,16 @@ func NewOrchestrationSuite(t *testing.T) *OrchestrationSuite {
 	avsDel := avs.NewDelegator(avsClient, avs.Config{}, db.Operations())
 	upgradeEvaluationManager := upgrade_kyma.NewEvaluationManager(avsDel, avs.Config{})
 
-	kymaQueue, err := NewOrchestrationProcessingQueue(ctx, db, runtimeOverrides, provisionerClient, gardenerClient.CoreV1beta1(),
-		gardenerNamespace, eventBroker, inputFactory, &upgrade_kyma.TimeSchedule{
+	upgradeKymaManager, err := NewUpgradeKymaManager(ctx, db, runtimeOverrides, provisionerClient, eventBroker, inputFactory,
+		&upgrade_kyma.TimeSchedule{
 			Retry:              10 * time.Millisecond,
 			StatusCheck:        100 * time.Millisecond,
 			UpgradeKymaTimeout: 4 * time.Second,
-		}, 250*time.Millisecond, runtimeVerConfigurator, defaultRegion, upgradeEvaluationManager,
-		&cfg, hyperscaler.NewAccountProvider(nil, nil), nil, logs)
+		}, runtimeVerConfigurator, upgradeEvaluationManager, &cfg, hyperscaler.NewAccountProvider(nil, nil), nil, logs)
+	require.NoError(t, err)
+
+	kymaQueue, err := NewOrchestrationProcessingQueue(ctx, db, upgradeKymaManager, gardenerClient.CoreV1beta1(),
+		gardenerNamespace, eventBroker, 250*time.Millisecond, defaultRegion, nil, logs)
 
 	return &OrchestrationSuite{
 		gardenerNamespace:  gardenerNamespace,
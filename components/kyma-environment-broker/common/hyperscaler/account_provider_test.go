@@ -3,6 +3,7 @@ package hyperscaler
 import (
 	"testing"
 
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
 	machineryv1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/stretchr/testify/assert"
@@ -19,6 +20,23 @@ func TestGardenerSharedCredentials_Error(t *testing.T) {
 	assert.Contains(t, err.Error(), "Gardener Shared Account pool is not configured")
 }
 
+func TestHyperscalerTypeForPlanID(t *testing.T) {
+	for planID, expected := range map[string]Type{
+		broker.GCPPlanID:       GCP,
+		broker.AzurePlanID:     Azure,
+		broker.AzureLitePlanID: Azure,
+		broker.AWSPlanID:       AWS,
+	} {
+		hyperscalerType, err := HyperscalerTypeForPlanID(planID)
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, hyperscalerType)
+	}
+
+	_, err := HyperscalerTypeForPlanID(broker.TrialPlanID)
+	assert.Error(t, err)
+}
+
 func TestMarkUnusedGardenerSecretAsDirty(t *testing.T) {
 	t.Run("should mark secret as dirty if unused", func(t *testing.T) {
 		//given
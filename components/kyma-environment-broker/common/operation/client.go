@@ -0,0 +1,89 @@
+package operation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+)
+
+// Client is the interface to interact with the KEB /operations API as an HTTP client using OIDC ID token in JWT format.
+type Client interface {
+	GetOperation(operationID string) (OperationDTO, error)
+	ListEvents(operationID string) (EventList, error)
+}
+
+type client struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewClient constructs and returns new Client for KEB /operations API
+// It takes the following arguments:
+//   - ctx  : context in which the http request will be executed
+//   - url  : base url of all KEB APIs, e.g. https://kyma-env-broker.kyma.local
+//   - auth : TokenSource object which provides the ID token for the HTTP request
+func NewClient(ctx context.Context, url string, auth oauth2.TokenSource) Client {
+	return &client{
+		url:        url,
+		httpClient: oauth2.NewClient(ctx, auth),
+	}
+}
+
+// GetOperation fetches one operation by the given ID.
+func (c *client) GetOperation(operationID string) (OperationDTO, error) {
+	operation := OperationDTO{}
+	err := c.get(fmt.Sprintf("%s/operations/%s", c.url, operationID), &operation)
+	return operation, err
+}
+
+// ListEvents fetches the events recorded for the steps of the given operation.
+func (c *client) ListEvents(operationID string) (EventList, error) {
+	events := EventList{}
+	err := c.get(fmt.Sprintf("%s/operations/%s/events", c.url, operationID), &events)
+	return events, err
+}
+
+func (c *client) get(url string, obj interface{}) (err error) {
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return errors.Wrapf(err, "while calling %s", url)
+	}
+
+	// Drain response body and close, return error to context if there isn't any.
+	defer func() {
+		derr := drainResponseBody(resp.Body)
+		if err == nil {
+			err = derr
+		}
+		cerr := resp.Body.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("calling %s returned %s status", url, resp.Status)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	err = decoder.Decode(obj)
+	if err != nil {
+		return errors.Wrap(err, "while decoding response body")
+	}
+
+	return nil
+}
+
+func drainResponseBody(body io.Reader) error {
+	if body == nil {
+		return nil
+	}
+	_, err := io.Copy(ioutil.Discard, io.LimitReader(body, 4096))
+	return err
+}
@@ -0,0 +1,91 @@
+package operation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+type FakeTokenSource string
+
+var fixToken FakeTokenSource = "fake-token-1234"
+
+func (t FakeTokenSource) Token() (*oauth2.Token, error) {
+	return &oauth2.Token{
+		AccessToken: string(t),
+		Expiry:      time.Now().Add(time.Duration(12 * time.Hour)),
+	}, nil
+}
+
+func TestClient_GetOperation(t *testing.T) {
+	// given
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/operations/op-id", r.URL.Path)
+		assert.Equal(t, r.Header.Get("Authorization"), fmt.Sprintf("Bearer %s", fixToken))
+
+		err := json.NewEncoder(w).Encode(OperationDTO{OperationID: "op-id", State: "in progress"})
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+	client := NewClient(context.TODO(), ts.URL, fixToken)
+
+	// when
+	operation, err := client.GetOperation("op-id")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "op-id", operation.OperationID)
+	assert.Equal(t, "in progress", operation.State)
+}
+
+func TestClient_ListEvents(t *testing.T) {
+	t.Run("should return the events of the operation", func(t *testing.T) {
+		// given
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/operations/op-id/events", r.URL.Path)
+
+			err := json.NewEncoder(w).Encode(EventList{
+				Data:  []EventDTO{{StepName: "Create_Runtime"}, {StepName: "Check_Runtime", RequeueDelay: "1m0s"}},
+				Count: 2,
+			})
+			require.NoError(t, err)
+		}))
+		defer ts.Close()
+		client := NewClient(context.TODO(), ts.URL, fixToken)
+
+		// when
+		events, err := client.ListEvents("op-id")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 2, events.Count)
+		require.Len(t, events.Data, 2)
+		assert.Equal(t, "Check_Runtime", events.Data[1].StepName)
+		assert.Equal(t, "1m0s", events.Data[1].RequeueDelay)
+	})
+
+	t.Run("should return error when operation does not exist", func(t *testing.T) {
+		// given
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer ts.Close()
+		client := NewClient(context.TODO(), ts.URL, fixToken)
+
+		// when
+		_, err := client.ListEvents("op-id")
+
+		// then
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,35 @@
+package operation
+
+import (
+	"time"
+)
+
+// OperationDTO describes the operation processed by KEB
+type OperationDTO struct {
+	OperationID     string    `json:"operationID"`
+	InstanceID      string    `json:"instanceID"`
+	OrchestrationID string    `json:"orchestrationID,omitempty"`
+	State           string    `json:"state"`
+	Description     string    `json:"description"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+// EventDTO describes a single processing of the step of the operation and the transition of the operation state caused by it
+type EventDTO struct {
+	StepName     string    `json:"stepName"`
+	StartedAt    time.Time `json:"startedAt"`
+	FinishedAt   time.Time `json:"finishedAt"`
+	Duration     string    `json:"duration"`
+	RequeueDelay string    `json:"requeueDelay,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	OldState     string    `json:"oldState"`
+	NewState     string    `json:"newState"`
+	Description  string    `json:"description"`
+}
+
+// EventList contains the events of the operation ordered by the time the steps finished
+type EventList struct {
+	Data  []EventDTO `json:"data"`
+	Count int        `json:"count"`
+}
@@ -26,7 +26,10 @@ type Client interface {
 	ListOperations(orchestrationID string, params ListParameters) (OperationResponseList, error)
 	GetOperation(orchestrationID, operationID string) (OperationDetailResponse, error)
 	UpgradeKyma(params Parameters) (UpgradeResponse, error)
+	UpgradeCluster(params Parameters) (UpgradeResponse, error)
 	CancelOrchestration(orchestrationID string) error
+	ResumeOrchestration(orchestrationID string) error
+	RetryOrchestration(orchestrationID string, operationIDs []string) (UpgradeResponse, error)
 }
 
 type client struct {
@@ -244,15 +247,25 @@ func (c client) GetOperation(orchestrationID, operationID string) (OperationDeta
 // UpgradeKyma creates a new Kyma upgrade orchestration according to the given orchestration parameters.
 // If successful, the UpgradeResponse returned contains the ID of the newly created orchestration.
 func (c client) UpgradeKyma(params Parameters) (UpgradeResponse, error) {
+	return c.upgrade("kyma", params)
+}
+
+// UpgradeCluster creates a new cluster upgrade orchestration according to the given orchestration parameters.
+// If successful, the UpgradeResponse returned contains the ID of the newly created orchestration.
+func (c client) UpgradeCluster(params Parameters) (UpgradeResponse, error) {
+	return c.upgrade("cluster", params)
+}
+
+func (c client) upgrade(kind string, params Parameters) (UpgradeResponse, error) {
 	ur := UpgradeResponse{}
 	blob, err := json.Marshal(params)
 	if err != nil {
 		return ur, errors.Wrap(err, "while converting upgrade parameters to JSON")
 	}
 
-	resp, err := c.httpClient.Post(fmt.Sprintf("%s/upgrade/kyma", c.url), "application/json", bytes.NewBuffer(blob))
+	resp, err := c.httpClient.Post(fmt.Sprintf("%s/upgrade/%s", c.url, kind), "application/json", bytes.NewBuffer(blob))
 	if err != nil {
-		return ur, errors.Wrapf(err, "while calling %s/upgrade/kyma", c.url)
+		return ur, errors.Wrapf(err, "while calling %s/upgrade/%s", c.url, kind)
 	}
 
 	// Drain response body and close, return error to context if there isn't any.
@@ -268,7 +281,7 @@ func (c client) UpgradeKyma(params Parameters) (UpgradeResponse, error) {
 	}()
 
 	if resp.StatusCode != http.StatusAccepted {
-		return ur, fmt.Errorf("calling %s/upgrade/kyma returned %s status", c.url, resp.Status)
+		return ur, fmt.Errorf("calling %s/upgrade/%s returned %s status", c.url, kind, resp.Status)
 	}
 
 	decoder := json.NewDecoder(resp.Body)
@@ -281,11 +294,60 @@ func (c client) UpgradeKyma(params Parameters) (UpgradeResponse, error) {
 }
 
 func (c client) CancelOrchestration(orchestrationID string) error {
-	url := fmt.Sprintf("%s/orchestrations/%s/cancel", c.url, orchestrationID)
+	return c.orchestrationAction(orchestrationID, "cancel")
+}
+
+func (c client) ResumeOrchestration(orchestrationID string) error {
+	return c.orchestrationAction(orchestrationID, "resume")
+}
+
+// RetryOrchestration schedules a new orchestration which retries the failed and canceled operations of the given orchestration.
+// If operationIDs is empty, all failed and canceled operations are retried.
+func (c client) RetryOrchestration(orchestrationID string, operationIDs []string) (UpgradeResponse, error) {
+	ur := UpgradeResponse{}
+	blob, err := json.Marshal(RetryRequest{OperationIDs: operationIDs})
+	if err != nil {
+		return ur, errors.Wrap(err, "while converting retry request to JSON")
+	}
+
+	url := fmt.Sprintf("%s/orchestrations/%s/retry", c.url, orchestrationID)
+	resp, err := c.httpClient.Post(url, "application/json", bytes.NewBuffer(blob))
+	if err != nil {
+		return ur, errors.Wrapf(err, "while calling %s", url)
+	}
+
+	// Drain response body and close, return error to context if there isn't any.
+	defer func() {
+		derr := drainResponseBody(resp.Body)
+		if err == nil {
+			err = derr
+		}
+		cerr := resp.Body.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return ur, fmt.Errorf("calling %s returned %s status", url, resp.Status)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	err = decoder.Decode(&ur)
+	if err != nil {
+		return ur, errors.Wrap(err, "while decoding response body")
+	}
+
+	return ur, nil
+}
+
+// orchestrationAction calls the given action endpoint of the orchestration, e.g. cancel or resume
+func (c client) orchestrationAction(orchestrationID, action string) error {
+	url := fmt.Sprintf("%s/orchestrations/%s/%s", c.url, orchestrationID, action)
 
 	req, err := http.NewRequest(http.MethodPut, url, nil)
 	if err != nil {
-		return errors.Wrap(err, "while creating cancel request")
+		return errors.Wrapf(err, "while creating %s request", action)
 	}
 
 	resp, err := c.httpClient.Do(req)
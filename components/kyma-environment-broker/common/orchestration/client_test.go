@@ -226,6 +226,58 @@ func TestClient_UpgradeKyma(t *testing.T) {
 	})
 }
 
+func TestClient_UpgradeCluster(t *testing.T) {
+	t.Run("test_URL_request_body_NoError_path", func(t *testing.T) {
+		// given
+		called := 0
+		params := Parameters{
+			Targets: TargetSpec{
+				Include: []RuntimeTarget{
+					{
+						Target: TargetAll,
+					},
+				},
+				Exclude: []RuntimeTarget{
+					{
+						GlobalAccount: "GA",
+					},
+				},
+			},
+			Strategy: StrategySpec{
+				Type:     ParallelStrategy,
+				Schedule: MaintenanceWindow,
+				Parallel: ParallelStrategySpec{
+					Workers: 2,
+				},
+			},
+		}
+		orchestrationID := orch1.OrchestrationID
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called++
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/upgrade/cluster", r.URL.Path)
+			assert.Equal(t, fmt.Sprintf("Bearer %s", fixToken), r.Header.Get("Authorization"))
+			reqBody := Parameters{}
+			err := json.NewDecoder(r.Body).Decode(&reqBody)
+			require.NoError(t, err)
+			assert.True(t, reflect.DeepEqual(params, reqBody))
+
+			err = respondUpgrade(w, orchestrationID)
+			require.NoError(t, err)
+		}))
+		defer ts.Close()
+		client := NewClient(context.TODO(), ts.URL, fixToken)
+
+		// when
+		ur, err := client.UpgradeCluster(params)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, called)
+		assert.Equal(t, orchestrationID, ur.OrchestrationID)
+	})
+}
+
 func TestClient_CancelOrchestration(t *testing.T) {
 	t.Run("test_URL__NoError_path", func(t *testing.T) {
 		// given
@@ -251,6 +303,64 @@ func TestClient_CancelOrchestration(t *testing.T) {
 	})
 }
 
+func TestClient_ResumeOrchestration(t *testing.T) {
+	t.Run("test_URL__NoError_path", func(t *testing.T) {
+		// given
+		called := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called++
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, fmt.Sprintf("/orchestrations/%s/resume", orch1.OrchestrationID), r.URL.Path)
+			assert.Equal(t, fmt.Sprintf("Bearer %s", fixToken), r.Header.Get("Authorization"))
+
+			err := respondStatus(w, orch1)
+			require.NoError(t, err)
+		}))
+		defer ts.Close()
+		client := NewClient(context.TODO(), ts.URL, fixToken)
+
+		// when
+		err := client.ResumeOrchestration(orch1.OrchestrationID)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, called)
+	})
+}
+
+func TestClient_RetryOrchestration(t *testing.T) {
+	t.Run("test_URL__NoError_path", func(t *testing.T) {
+		// given
+		called := 0
+		retryID := "retry-id"
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called++
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, fmt.Sprintf("/orchestrations/%s/retry", orch1.OrchestrationID), r.URL.Path)
+			assert.Equal(t, fmt.Sprintf("Bearer %s", fixToken), r.Header.Get("Authorization"))
+
+			req := RetryRequest{}
+			err := json.NewDecoder(r.Body).Decode(&req)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"op-1"}, req.OperationIDs)
+
+			w.WriteHeader(http.StatusAccepted)
+			err = json.NewEncoder(w).Encode(UpgradeResponse{OrchestrationID: retryID})
+			require.NoError(t, err)
+		}))
+		defer ts.Close()
+		client := NewClient(context.TODO(), ts.URL, fixToken)
+
+		// when
+		ur, err := client.RetryOrchestration(orch1.OrchestrationID, []string{"op-1"})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, called)
+		assert.Equal(t, retryID, ur.OrchestrationID)
+	})
+}
+
 func fixStatusResponse(id string) StatusResponse {
 	return StatusResponse{
 		OrchestrationID: id,
@@ -14,6 +14,14 @@ type Parameters struct {
 	DryRun   bool         `json:"dryRun,omitempty"`
 }
 
+// Type defines the kind of operations performed by an orchestration
+type Type string
+
+const (
+	UpgradeKymaOrchestration    Type = "upgradeKyma"
+	UpgradeClusterOrchestration Type = "upgradeCluster"
+)
+
 const (
 	// StateParam parameter used in list orchestrations / operations queries to filter by state
 	StateParam = "state"
@@ -25,6 +33,7 @@ const (
 	InProgress = "in progress"
 	Canceling  = "canceling"
 	Canceled   = "canceled"
+	Paused     = "paused"
 	Succeeded  = "succeeded"
 	Failed     = "failed"
 )
@@ -62,6 +71,7 @@ type StrategyType string
 
 const (
 	ParallelStrategy StrategyType = "parallel"
+	StagedStrategy   StrategyType = "staged"
 )
 
 type ScheduleType string
@@ -76,11 +86,47 @@ type ParallelStrategySpec struct {
 	Workers int `json:"workers"`
 }
 
+// StagedStrategySpec defines parameters for the staged orchestration strategy.
+// The operations are executed in stages: a canary stage followed by waves, each stage is executed in parallel
+// according to the ParallelStrategySpec and the next stage starts after all operations of the previous one are finished
+// and the soak time has passed.
+type StagedStrategySpec struct {
+	// CanarySize is the number of runtimes in the canary stage
+	CanarySize int `json:"canarySize,omitempty"`
+	// CanaryPercentage is the percentage of runtimes in the canary stage, used when CanarySize is not set.
+	// If none of them is set, the canary stage contains one runtime
+	CanaryPercentage int `json:"canaryPercentage,omitempty"`
+	// WaveSize is the number of runtimes in every stage following the canary stage
+	WaveSize int `json:"waveSize,omitempty"`
+	// WavePercentage is the percentage of runtimes in every stage following the canary stage, used when WaveSize is not set.
+	// If none of them is set, all remaining runtimes are processed in a single wave
+	WavePercentage int `json:"wavePercentage,omitempty"`
+	// SoakTime is the time to wait after a stage finishes before the next stage starts, e.g. "30m"
+	SoakTime string `json:"soakTime,omitempty"`
+}
+
 // StrategySpec is the strategy part common for all orchestration trigger/status API
 type StrategySpec struct {
 	Type     StrategyType         `json:"type"`
 	Schedule ScheduleType         `json:"schedule,omitempty"`
 	Parallel ParallelStrategySpec `json:"parallel,omitempty"`
+	Staged   StagedStrategySpec   `json:"staged,omitempty"`
+	// MaxFailures is the number of failed operations above which the orchestration is paused, not limited if empty.
+	// The staged strategy is paused on the first failure if neither MaxFailures nor MaxFailureRatio is set
+	MaxFailures int `json:"maxFailures,omitempty"`
+	// MaxFailureRatio is the ratio (0-1) of failed to finished operations above which the orchestration is paused, not limited if empty
+	MaxFailureRatio float64 `json:"maxFailureRatio,omitempty"`
+	// StartTime is the time before which the orchestration stays pending, the orchestration starts immediately if empty
+	StartTime *time.Time `json:"startTime,omitempty"`
+	// Blackouts are the periods in which no operations are started
+	Blackouts []BlackoutPeriod `json:"blackouts,omitempty"`
+}
+
+// BlackoutPeriod defines a period of time in which the orchestration does not start any operations,
+// operations which are already in progress are not interrupted
+type BlackoutPeriod struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
 }
 
 // TargetSpec is the targets part common for all orchestration trigger/status API
@@ -91,6 +137,7 @@ type TargetSpec struct {
 
 type StatusResponse struct {
 	OrchestrationID string         `json:"orchestrationID"`
+	Type            Type           `json:"type"`
 	State           string         `json:"state"`
 	Description     string         `json:"description"`
 	CreatedAt       time.Time      `json:"createdAt"`
@@ -113,6 +160,8 @@ type OperationResponse struct {
 	MaintenanceWindowEnd   time.Time `json:"maintenanceWindowEnd"`
 	State                  string    `json:"state"`
 	Description            string    `json:"description"`
+	// RetryOf is the ID of the operation retried by this operation
+	RetryOf string `json:"retryOf,omitempty"`
 }
 
 type OperationResponseList struct {
@@ -126,6 +175,8 @@ type OperationDetailResponse struct {
 
 	KymaConfig    gqlschema.KymaConfigInput     `json:"kymaConfig"`
 	ClusterConfig gqlschema.GardenerConfigInput `json:"clusterConfig"`
+	// KymaConfigDiff is set for dry run Kyma upgrade operations
+	KymaConfigDiff *KymaConfigDiff `json:"kymaConfigDiff,omitempty"`
 }
 
 type StatusResponseList struct {
@@ -137,3 +188,8 @@ type StatusResponseList struct {
 type UpgradeResponse struct {
 	OrchestrationID string `json:"orchestrationID"`
 }
+
+// RetryRequest is the request body of the orchestration retry API, all failed and canceled operations are retried if no operation IDs are given
+type RetryRequest struct {
+	OperationIDs []string `json:"operationIDs,omitempty"`
+}
@@ -23,6 +23,8 @@ type RuntimeOperation struct {
 	Runtime `json:""`
 	ID      string `json:"-"`
 	DryRun  bool   `json:"dryRun"`
+	// RetryOf is the ID of the failed or canceled operation which is retried by this operation
+	RetryOf string `json:"retryOf,omitempty"`
 }
 
 //go:generate mockery --name=RuntimeResolver --output=automock --outpkg=automock --case=underscore
@@ -42,3 +44,9 @@ type Strategy interface {
 	// Cancel shutdowns a given execution.
 	Cancel(executionID string)
 }
+
+// OperationStates gives strategies access to the state of the orchestrated operations.
+type OperationStates interface {
+	// State returns the current state of the operation with the given ID.
+	State(operationID string) (string, error)
+}
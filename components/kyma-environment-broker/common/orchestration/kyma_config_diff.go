@@ -0,0 +1,160 @@
+package orchestration
+
+import (
+	"sort"
+
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+)
+
+// Kinds of changes in the Kyma configuration diff
+const (
+	DiffAdded    = "added"
+	DiffRemoved  = "removed"
+	DiffModified = "modified"
+)
+
+// MaskedValue replaces values of secret configuration entries in the Kyma configuration diff
+const MaskedValue = "*****"
+
+// KymaConfigDiff holds the changes between the Kyma configuration of the runtime and the configuration sent by the upgrade
+type KymaConfigDiff struct {
+	CurrentVersion string            `json:"currentVersion"`
+	TargetVersion  string            `json:"targetVersion"`
+	Components     []ComponentDiff   `json:"components,omitempty"`
+	Configuration  []ConfigEntryDiff `json:"configuration,omitempty"`
+}
+
+// ComponentDiff holds the change of a single Kyma component and its overrides
+type ComponentDiff struct {
+	Component     string            `json:"component"`
+	Change        string            `json:"change"`
+	Configuration []ConfigEntryDiff `json:"configuration,omitempty"`
+}
+
+// ConfigEntryDiff holds the change of a single override, values of secret overrides are masked
+type ConfigEntryDiff struct {
+	Key     string `json:"key"`
+	Change  string `json:"change"`
+	Current string `json:"current,omitempty"`
+	Target  string `json:"target,omitempty"`
+}
+
+// IsEmpty returns true if the upgrade does not change the Kyma configuration
+func (d KymaConfigDiff) IsEmpty() bool {
+	return d.CurrentVersion == d.TargetVersion && len(d.Components) == 0 && len(d.Configuration) == 0
+}
+
+// NewKymaConfigDiff compares the current Kyma configuration of the runtime with the target configuration.
+// Components are compared by name and overrides by key, the order of the result is stable.
+func NewKymaConfigDiff(current, target gqlschema.KymaConfigInput) KymaConfigDiff {
+	diff := KymaConfigDiff{
+		CurrentVersion: current.Version,
+		TargetVersion:  target.Version,
+		Configuration:  configurationDiff(current.Configuration, target.Configuration),
+	}
+
+	currentComponents := map[string]*gqlschema.ComponentConfigurationInput{}
+	for _, c := range current.Components {
+		if c != nil {
+			currentComponents[c.Component] = c
+		}
+	}
+	for _, t := range target.Components {
+		if t == nil {
+			continue
+		}
+		c, found := currentComponents[t.Component]
+		delete(currentComponents, t.Component)
+		if !found {
+			diff.Components = append(diff.Components, ComponentDiff{
+				Component:     t.Component,
+				Change:        DiffAdded,
+				Configuration: configurationDiff(nil, t.Configuration),
+			})
+			continue
+		}
+		entries := configurationDiff(c.Configuration, t.Configuration)
+		if len(entries) > 0 || c.Namespace != t.Namespace || stringValue(c.SourceURL) != stringValue(t.SourceURL) {
+			diff.Components = append(diff.Components, ComponentDiff{
+				Component:     t.Component,
+				Change:        DiffModified,
+				Configuration: entries,
+			})
+		}
+	}
+	for name := range currentComponents {
+		diff.Components = append(diff.Components, ComponentDiff{
+			Component: name,
+			Change:    DiffRemoved,
+		})
+	}
+	sort.SliceStable(diff.Components, func(i, j int) bool {
+		return diff.Components[i].Component < diff.Components[j].Component
+	})
+
+	return diff
+}
+
+func configurationDiff(current, target []*gqlschema.ConfigEntryInput) []ConfigEntryDiff {
+	var result []ConfigEntryDiff
+
+	currentEntries := map[string]*gqlschema.ConfigEntryInput{}
+	for _, e := range current {
+		if e != nil {
+			currentEntries[e.Key] = e
+		}
+	}
+	for _, t := range target {
+		if t == nil {
+			continue
+		}
+		c, found := currentEntries[t.Key]
+		delete(currentEntries, t.Key)
+		switch {
+		case !found:
+			result = append(result, ConfigEntryDiff{
+				Key:    t.Key,
+				Change: DiffAdded,
+				Target: maskedValue(t.Value, isSecret(t)),
+			})
+		case c.Value != t.Value || isSecret(c) != isSecret(t):
+			secret := isSecret(c) || isSecret(t)
+			result = append(result, ConfigEntryDiff{
+				Key:     t.Key,
+				Change:  DiffModified,
+				Current: maskedValue(c.Value, secret),
+				Target:  maskedValue(t.Value, secret),
+			})
+		}
+	}
+	for key, c := range currentEntries {
+		result = append(result, ConfigEntryDiff{
+			Key:     key,
+			Change:  DiffRemoved,
+			Current: maskedValue(c.Value, isSecret(c)),
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+
+	return result
+}
+
+func isSecret(e *gqlschema.ConfigEntryInput) bool {
+	return e.Secret != nil && *e.Secret
+}
+
+func maskedValue(value string, secret bool) string {
+	if secret && value != "" {
+		return MaskedValue
+	}
+	return value
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,103 @@
+package orchestration
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewKymaConfigDiff(t *testing.T) {
+	secret := true
+	current := gqlschema.KymaConfigInput{
+		Version: "1.18.0",
+		Components: []*gqlschema.ComponentConfigurationInput{
+			{Component: "istio", Namespace: "istio-system"},
+			{
+				Component: "serverless",
+				Namespace: "kyma-system",
+				Configuration: []*gqlschema.ConfigEntryInput{
+					{Key: "replicas", Value: "1"},
+					{Key: "password", Value: "old", Secret: &secret},
+					{Key: "obsolete", Value: "true"},
+				},
+			},
+			{Component: "legacy", Namespace: "kyma-system"},
+		},
+		Configuration: []*gqlschema.ConfigEntryInput{
+			{Key: "global.domain", Value: "example.com"},
+		},
+	}
+	target := gqlschema.KymaConfigInput{
+		Version: "1.19.0",
+		Components: []*gqlschema.ComponentConfigurationInput{
+			{Component: "istio", Namespace: "istio-system"},
+			{
+				Component: "serverless",
+				Namespace: "kyma-system",
+				Configuration: []*gqlschema.ConfigEntryInput{
+					{Key: "replicas", Value: "2"},
+					{Key: "password", Value: "new", Secret: &secret},
+				},
+			},
+			{
+				Component: "eventing",
+				Namespace: "kyma-system",
+				Configuration: []*gqlschema.ConfigEntryInput{
+					{Key: "token", Value: "abc", Secret: &secret},
+				},
+			},
+		},
+		Configuration: []*gqlschema.ConfigEntryInput{
+			{Key: "global.domain", Value: "example.com"},
+			{Key: "global.disableLegacyConnectivity", Value: "true"},
+		},
+	}
+
+	// when
+	diff := NewKymaConfigDiff(current, target)
+
+	// then
+	assert.Equal(t, KymaConfigDiff{
+		CurrentVersion: "1.18.0",
+		TargetVersion:  "1.19.0",
+		Components: []ComponentDiff{
+			{
+				Component: "eventing",
+				Change:    DiffAdded,
+				Configuration: []ConfigEntryDiff{
+					{Key: "token", Change: DiffAdded, Target: MaskedValue},
+				},
+			},
+			{Component: "legacy", Change: DiffRemoved},
+			{
+				Component: "serverless",
+				Change:    DiffModified,
+				Configuration: []ConfigEntryDiff{
+					{Key: "obsolete", Change: DiffRemoved, Current: "true"},
+					{Key: "password", Change: DiffModified, Current: MaskedValue, Target: MaskedValue},
+					{Key: "replicas", Change: DiffModified, Current: "1", Target: "2"},
+				},
+			},
+		},
+		Configuration: []ConfigEntryDiff{
+			{Key: "global.disableLegacyConnectivity", Change: DiffAdded, Target: "true"},
+		},
+	}, diff)
+	assert.False(t, diff.IsEmpty())
+}
+
+func TestNewKymaConfigDiff_NoChanges(t *testing.T) {
+	config := gqlschema.KymaConfigInput{
+		Version: "1.18.0",
+		Components: []*gqlschema.ComponentConfigurationInput{
+			{Component: "istio", Namespace: "istio-system"},
+		},
+	}
+
+	// when
+	diff := NewKymaConfigDiff(config, config)
+
+	// then
+	assert.True(t, diff.IsEmpty())
+}
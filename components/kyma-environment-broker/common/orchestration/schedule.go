@@ -0,0 +1,35 @@
+package orchestration
+
+import (
+	"time"
+)
+
+// StartsIn returns the time left until the start time of the orchestration, zero if the orchestration can start now
+func (s StrategySpec) StartsIn(now time.Time) time.Duration {
+	if s.StartTime == nil || !s.StartTime.After(now) {
+		return 0
+	}
+	return s.StartTime.Sub(now)
+}
+
+// NextAllowedTime returns the given time, or the end of the blackout periods it falls into.
+// Overlapping and adjacent blackout periods are treated as a single one.
+func (s StrategySpec) NextAllowedTime(t time.Time) time.Time {
+	for {
+		moved := false
+		for _, b := range s.Blackouts {
+			if b.Contains(t) {
+				t = b.End
+				moved = true
+			}
+		}
+		if !moved {
+			return t
+		}
+	}
+}
+
+// Contains returns true if the given time is within the blackout period, the end of the period is excluded
+func (b BlackoutPeriod) Contains(t time.Time) bool {
+	return !t.Before(b.Start) && t.Before(b.End)
+}
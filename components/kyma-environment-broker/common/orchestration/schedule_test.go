@@ -0,0 +1,51 @@
+package orchestration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrategySpec_StartsIn(t *testing.T) {
+	now := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	future := now.Add(2 * time.Hour)
+	past := now.Add(-time.Hour)
+
+	assert.Zero(t, StrategySpec{}.StartsIn(now))
+	assert.Zero(t, StrategySpec{StartTime: &past}.StartsIn(now))
+	assert.Zero(t, StrategySpec{StartTime: &now}.StartsIn(now))
+	assert.Equal(t, 2*time.Hour, StrategySpec{StartTime: &future}.StartsIn(now))
+}
+
+func TestStrategySpec_NextAllowedTime(t *testing.T) {
+	base := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time {
+		return base.Add(time.Duration(hour) * time.Hour)
+	}
+	spec := StrategySpec{
+		Blackouts: []BlackoutPeriod{
+			{Start: at(10), End: at(12)},
+			{Start: at(20), End: at(22)},
+			{Start: at(11), End: at(14)},
+			{Start: at(14), End: at(15)},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		given    time.Time
+		expected time.Time
+	}{
+		"before blackouts":           {given: at(1), expected: at(1)},
+		"start of blackout":          {given: at(20), expected: at(22)},
+		"end of blackout":            {given: at(22), expected: at(22)},
+		"overlapping blackouts":      {given: at(10), expected: at(15)},
+		"adjacent blackouts":         {given: at(13), expected: at(15)},
+		"between blackouts":          {given: at(16), expected: at(16)},
+		"no blackouts after the end": {given: at(23), expected: at(23)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, spec.NextAllowedTime(tc.given))
+		})
+	}
+}
@@ -20,17 +20,21 @@ type ParallelOrchestrationStrategy struct {
 	executor Executor
 	dq       map[string]workqueue.DelayingInterface
 	wg       map[string]*sync.WaitGroup
+	states   orchestration.OperationStates
 	mux      sync.RWMutex
 	log      logrus.FieldLogger
 }
 
 // NewParallelOrchestrationStrategy returns a new parallel orchestration strategy, which
 // executes operations in parallel using a pool of workers and a delaying queue to support time-based scheduling.
-func NewParallelOrchestrationStrategy(executor Executor, log logrus.FieldLogger) orchestration.Strategy {
+// Operations are not started within the blackout periods of the strategy spec, the stored state of the operation
+// decides if the operation was already started.
+func NewParallelOrchestrationStrategy(executor Executor, states orchestration.OperationStates, log logrus.FieldLogger) orchestration.Strategy {
 	return &ParallelOrchestrationStrategy{
 		executor: executor,
 		dq:       map[string]workqueue.DelayingInterface{},
 		wg:       map[string]*sync.WaitGroup{},
+		states:   states,
 		log:      log,
 	}
 }
@@ -79,8 +83,13 @@ func (p *ParallelOrchestrationStrategy) Wait(executionID string) {
 func (p *ParallelOrchestrationStrategy) Cancel(executionID string) {
 	p.mux.Lock()
 	defer p.mux.Unlock()
+	dq, found := p.dq[executionID]
+	if !found {
+		// nothing was executed, e.g. the orchestration had no operations or was paused before the execution started
+		return
+	}
 	p.log.Infof("Cancelling strategy execution %s", executionID)
-	p.dq[executionID].ShutDown()
+	dq.ShutDown()
 }
 
 func (p *ParallelOrchestrationStrategy) createWorker(execID string, ops <-chan orchestration.RuntimeOperation, strategy orchestration.StrategySpec) {
@@ -128,6 +137,12 @@ func (p *ParallelOrchestrationStrategy) processOperation(op orchestration.Runtim
 				p.dq[executionID].Done(key)
 			}()
 
+			if wait := p.blackoutDelay(id, strategy, log); wait > 0 {
+				log.Infof("Operation is within a blackout period, it will be scheduled in %v", wait)
+				p.dq[executionID].AddAfter(key, wait)
+				return false
+			}
+
 			when, err := p.executor.Execute(id)
 			if err == nil && when != 0 {
 				log.Infof("Adding %q item after %s", id, when)
@@ -143,3 +158,21 @@ func (p *ParallelOrchestrationStrategy) processOperation(op orchestration.Runtim
 	log.Info("Finishing processing operation")
 	return nil
 }
+
+// blackoutDelay returns the time to wait before the operation can be started,
+// operations which are not pending anymore were already started and are not delayed by blackout periods
+func (p *ParallelOrchestrationStrategy) blackoutDelay(operationID string, strategy orchestration.StrategySpec, log logrus.FieldLogger) time.Duration {
+	now := time.Now()
+	next := strategy.NextAllowedTime(now)
+	if !next.After(now) {
+		return 0
+	}
+	state, err := p.states.State(operationID)
+	if err != nil {
+		log.Errorf("while getting state of operation: %v", err)
+	}
+	if err == nil && state != orchestration.Pending {
+		return 0
+	}
+	return next.Sub(now)
+}
@@ -31,10 +31,19 @@ func (t *testExecutor) Execute(opID string) (time.Duration, error) {
 	}
 }
 
+func (t *testExecutor) State(opID string) (string, error) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	if t.opCalled[opID] {
+		return orchestration.InProgress, nil
+	}
+	return orchestration.Pending, nil
+}
+
 func TestNewParallelOrchestrationStrategy_Immediate(t *testing.T) {
 	// given
 	executor := &testExecutor{opCalled: map[string]bool{}}
-	s := NewParallelOrchestrationStrategy(executor, logrus.New())
+	s := NewParallelOrchestrationStrategy(executor, executor, logrus.New())
 
 	ops := make([]orchestration.RuntimeOperation, 3)
 	for i := range ops {
@@ -54,7 +63,7 @@ func TestNewParallelOrchestrationStrategy_Immediate(t *testing.T) {
 func TestNewParallelOrchestrationStrategy_MaintenanceWindow(t *testing.T) {
 	// given
 	executor := &testExecutor{opCalled: map[string]bool{}}
-	s := NewParallelOrchestrationStrategy(executor, logrus.New())
+	s := NewParallelOrchestrationStrategy(executor, executor, logrus.New())
 
 	start := time.Now().Add(5 * time.Second)
 
@@ -77,7 +86,7 @@ func TestNewParallelOrchestrationStrategy_MaintenanceWindow(t *testing.T) {
 func TestNewParallelOrchestrationStrategy_Canceled(t *testing.T) {
 	// given
 	executor := &testExecutor{opCalled: map[string]bool{}}
-	s := NewParallelOrchestrationStrategy(executor, logrus.New())
+	s := NewParallelOrchestrationStrategy(executor, executor, logrus.New())
 
 	start := time.Now().Add(15 * time.Second)
 
@@ -100,3 +109,75 @@ func TestNewParallelOrchestrationStrategy_Canceled(t *testing.T) {
 	assert.NoError(t, err)
 	s.Wait(id)
 }
+
+func TestNewParallelOrchestrationStrategy_CancelNotExecuted(t *testing.T) {
+	// given
+	executor := &testExecutor{opCalled: map[string]bool{}}
+	s := NewParallelOrchestrationStrategy(executor, executor, logrus.New())
+
+	// when
+	id, err := s.Execute(nil, orchestration.StrategySpec{
+		Type:     orchestration.ParallelStrategy,
+		Schedule: orchestration.Immediate,
+		Parallel: orchestration.ParallelStrategySpec{Workers: 2},
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.NotPanics(t, func() {
+		s.Cancel(id)
+		s.Cancel("not-existing")
+	})
+}
+
+func TestNewParallelOrchestrationStrategy_Blackout(t *testing.T) {
+	// given
+	executor := newStagedTestExecutor()
+	s := NewParallelOrchestrationStrategy(executor, executor, logrus.New())
+
+	now := time.Now()
+	end := now.Add(2 * time.Second)
+
+	// when
+	id, err := s.Execute(fixRuntimeOperations(3), orchestration.StrategySpec{
+		Schedule:  orchestration.Immediate,
+		Parallel:  orchestration.ParallelStrategySpec{Workers: 2},
+		Blackouts: []orchestration.BlackoutPeriod{{Start: now.Add(-time.Hour), End: end}},
+	})
+
+	// then
+	assert.NoError(t, err)
+	time.Sleep(time.Second)
+	assert.Empty(t, executor.executed)
+	s.Wait(id)
+	assert.Len(t, executor.executed, 3)
+	assert.False(t, time.Now().Before(end))
+}
+
+func TestNewParallelOrchestrationStrategy_BlackoutStartedOperation(t *testing.T) {
+	// given
+	executor := newStagedTestExecutor()
+	ops := fixRuntimeOperations(2)
+	executor.states[ops[0].ID] = orchestration.InProgress
+	s := NewParallelOrchestrationStrategy(executor, executor, logrus.New())
+
+	now := time.Now()
+	end := now.Add(2 * time.Second)
+
+	// when
+	id, err := s.Execute(ops, orchestration.StrategySpec{
+		Schedule:  orchestration.Immediate,
+		Parallel:  orchestration.ParallelStrategySpec{Workers: 2},
+		Blackouts: []orchestration.BlackoutPeriod{{Start: now.Add(-time.Hour), End: end}},
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		executor.mux.Lock()
+		defer executor.mux.Unlock()
+		return len(executor.executed) == 1 && executor.executed[0] == ops[0].ID
+	}, time.Second, 10*time.Millisecond)
+	s.Wait(id)
+	assert.Len(t, executor.executed, 2)
+}
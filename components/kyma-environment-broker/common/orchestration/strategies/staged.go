@@ -0,0 +1,221 @@
+package strategies
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+type StagedOrchestrationStrategy struct {
+	parallel   orchestration.Strategy
+	states     orchestration.OperationStates
+	executions map[string]*stagedExecution
+	mux        sync.Mutex
+	log        logrus.FieldLogger
+}
+
+type stagedExecution struct {
+	done     chan struct{}
+	canceled chan struct{}
+	// stageID is the ID of the parallel execution of the current stage
+	stageID string
+}
+
+// NewStagedOrchestrationStrategy returns a new staged orchestration strategy, which executes a canary stage of operations first
+// and then the remaining operations in waves. Every stage is executed by the parallel strategy and no further stage is started
+// when the failure threshold of the strategy spec is exceeded, the remaining operations stay pending and the orchestration is paused.
+func NewStagedOrchestrationStrategy(executor Executor, states orchestration.OperationStates, log logrus.FieldLogger) orchestration.Strategy {
+	return &StagedOrchestrationStrategy{
+		parallel:   NewParallelOrchestrationStrategy(executor, states, log),
+		states:     states,
+		executions: map[string]*stagedExecution{},
+		log:        log,
+	}
+}
+
+// Execute starts the staged execution of operations.
+func (s *StagedOrchestrationStrategy) Execute(operations []orchestration.RuntimeOperation, strategySpec orchestration.StrategySpec) (string, error) {
+	if len(operations) == 0 {
+		return "", nil
+	}
+	soakTime, err := ParseSoakTime(strategySpec.Staged.SoakTime)
+	if err != nil {
+		return "", err
+	}
+
+	ops := make([]orchestration.RuntimeOperation, len(operations))
+	copy(ops, operations)
+	if strategySpec.Schedule == orchestration.MaintenanceWindow {
+		sort.Slice(ops, func(i, j int) bool {
+			return ops[i].MaintenanceWindowBegin.Before(ops[j].MaintenanceWindowBegin)
+		})
+	}
+
+	execID := uuid.New().String()
+	exec := &stagedExecution{
+		done:     make(chan struct{}),
+		canceled: make(chan struct{}),
+	}
+	s.mux.Lock()
+	s.executions[execID] = exec
+	s.mux.Unlock()
+
+	go s.run(exec, SplitStages(ops, strategySpec.Staged), strategySpec, soakTime, s.log.WithField("executionID", execID))
+
+	return execID, nil
+}
+
+func (s *StagedOrchestrationStrategy) Wait(executionID string) {
+	s.mux.Lock()
+	exec := s.executions[executionID]
+	s.mux.Unlock()
+	if exec != nil {
+		<-exec.done
+	}
+}
+
+func (s *StagedOrchestrationStrategy) Cancel(executionID string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	exec := s.executions[executionID]
+	if exec == nil {
+		return
+	}
+	s.log.Infof("Cancelling strategy execution %s", executionID)
+	select {
+	case <-exec.canceled:
+	default:
+		close(exec.canceled)
+	}
+	if exec.stageID != "" {
+		s.parallel.Cancel(exec.stageID)
+	}
+}
+
+func (s *StagedOrchestrationStrategy) run(exec *stagedExecution, stages [][]orchestration.RuntimeOperation, strategySpec orchestration.StrategySpec, soakTime time.Duration, log logrus.FieldLogger) {
+	defer close(exec.done)
+
+	executed, failed := 0, 0
+	for i, stage := range stages {
+		if i > 0 && soakTime > 0 {
+			log.Infof("Waiting %v before starting stage %d", soakTime, i+1)
+			select {
+			case <-exec.canceled:
+				return
+			case <-time.After(soakTime):
+			}
+		}
+
+		stageID, err := s.executeStage(exec, stage, strategySpec)
+		if err != nil {
+			log.Errorf("while executing stage %d: %v", i+1, err)
+			return
+		}
+		if stageID == "" {
+			return
+		}
+		log.Infof("Started stage %d of %d with %d operations", i+1, len(stages), len(stage))
+		s.parallel.Wait(stageID)
+
+		executed += len(stage)
+		failed += s.countFailed(stage, log)
+		stats := map[string]int{orchestration.Failed: failed, orchestration.Succeeded: executed - failed}
+		if FailureThresholdExceeded(strategySpec, nil, stats) {
+			log.Errorf("Stopping strategy execution, %d of %d operations failed, the orchestration is paused", failed, executed)
+			return
+		}
+	}
+}
+
+// executeStage starts the parallel execution of the stage, returns empty ID if the execution was canceled
+func (s *StagedOrchestrationStrategy) executeStage(exec *stagedExecution, stage []orchestration.RuntimeOperation, strategySpec orchestration.StrategySpec) (string, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	select {
+	case <-exec.canceled:
+		return "", nil
+	default:
+	}
+
+	stageID, err := s.parallel.Execute(stage, strategySpec)
+	if err != nil {
+		return "", err
+	}
+	exec.stageID = stageID
+	return stageID, nil
+}
+
+func (s *StagedOrchestrationStrategy) countFailed(stage []orchestration.RuntimeOperation, log logrus.FieldLogger) int {
+	failed := 0
+	for _, op := range stage {
+		state, err := s.states.State(op.ID)
+		if err != nil {
+			log.Errorf("while getting state of operation %s: %v", op.ID, err)
+			continue
+		}
+		if state == orchestration.Failed {
+			failed++
+		}
+	}
+	return failed
+}
+
+// SplitStages splits the operations into the canary stage and the following waves according to the staged strategy spec
+func SplitStages(operations []orchestration.RuntimeOperation, spec orchestration.StagedStrategySpec) [][]orchestration.RuntimeOperation {
+	total := len(operations)
+	if total == 0 {
+		return nil
+	}
+	canary := stageSize(total, spec.CanarySize, spec.CanaryPercentage, 1)
+	wave := stageSize(total, spec.WaveSize, spec.WavePercentage, total)
+
+	var stages [][]orchestration.RuntimeOperation
+	size := canary
+	for start := 0; start < total; start += size {
+		if start > 0 {
+			size = wave
+		}
+		end := start + size
+		if end > total {
+			end = total
+		}
+		stages = append(stages, operations[start:end])
+	}
+	return stages
+}
+
+func stageSize(total, size, percentage, defaultSize int) int {
+	switch {
+	case size > 0:
+		return size
+	case percentage > 0:
+		n := (total*percentage + 99) / 100
+		if n < 1 {
+			n = 1
+		}
+		return n
+	default:
+		return defaultSize
+	}
+}
+
+// ParseSoakTime parses the soak time of the staged strategy spec, empty soak time means no waiting between stages
+func ParseSoakTime(soakTime string) (time.Duration, error) {
+	if soakTime == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(soakTime)
+	if err != nil {
+		return 0, errors.Wrapf(err, "while parsing soak time %q", soakTime)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("soak time %q must not be negative", soakTime)
+	}
+	return d, nil
+}
@@ -0,0 +1,223 @@
+package strategies
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stagedTestExecutor struct {
+	mux      sync.Mutex
+	failed   map[string]bool
+	executed []string
+	states   map[string]string
+}
+
+func newStagedTestExecutor(failed ...string) *stagedTestExecutor {
+	e := &stagedTestExecutor{
+		failed: map[string]bool{},
+		states: map[string]string{},
+	}
+	for _, id := range failed {
+		e.failed[id] = true
+	}
+	return e
+}
+
+func (e *stagedTestExecutor) Execute(opID string) (time.Duration, error) {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	e.executed = append(e.executed, opID)
+	if e.failed[opID] {
+		e.states[opID] = orchestration.Failed
+	} else {
+		e.states[opID] = orchestration.Succeeded
+	}
+	return 0, nil
+}
+
+func (e *stagedTestExecutor) State(opID string) (string, error) {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	state, found := e.states[opID]
+	if !found {
+		return orchestration.Pending, nil
+	}
+	return state, nil
+}
+
+func (e *stagedTestExecutor) countStates(state string) int {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	count := 0
+	for _, s := range e.states {
+		if s == state {
+			count++
+		}
+	}
+	return count
+}
+
+func fixRuntimeOperations(n int) []orchestration.RuntimeOperation {
+	ops := make([]orchestration.RuntimeOperation, n)
+	for i := range ops {
+		ops[i] = orchestration.RuntimeOperation{ID: fmt.Sprintf("op-%d", i)}
+	}
+	return ops
+}
+
+func TestSplitStages(t *testing.T) {
+	for name, tc := range map[string]struct {
+		total    int
+		spec     orchestration.StagedStrategySpec
+		expected []int
+	}{
+		"default canary and single wave": {
+			total:    5,
+			expected: []int{1, 4},
+		},
+		"canary size and wave size": {
+			total:    7,
+			spec:     orchestration.StagedStrategySpec{CanarySize: 2, WaveSize: 2},
+			expected: []int{2, 2, 2, 1},
+		},
+		"canary and wave percentage": {
+			total:    10,
+			spec:     orchestration.StagedStrategySpec{CanaryPercentage: 10, WavePercentage: 50},
+			expected: []int{1, 5, 4},
+		},
+		"canary percentage rounded up": {
+			total:    3,
+			spec:     orchestration.StagedStrategySpec{CanaryPercentage: 10},
+			expected: []int{1, 2},
+		},
+		"canary bigger than operations": {
+			total:    2,
+			spec:     orchestration.StagedStrategySpec{CanarySize: 5},
+			expected: []int{2},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			stages := SplitStages(fixRuntimeOperations(tc.total), tc.spec)
+
+			// then
+			var sizes []int
+			for _, s := range stages {
+				sizes = append(sizes, len(s))
+			}
+			assert.Equal(t, tc.expected, sizes)
+		})
+	}
+}
+
+func TestStagedOrchestrationStrategy_AllSucceeded(t *testing.T) {
+	// given
+	executor := newStagedTestExecutor()
+	s := NewStagedOrchestrationStrategy(executor, executor, logrus.New())
+
+	// when
+	id, err := s.Execute(fixRuntimeOperations(5), orchestration.StrategySpec{
+		Type:     orchestration.StagedStrategy,
+		Schedule: orchestration.Immediate,
+		Parallel: orchestration.ParallelStrategySpec{Workers: 2},
+		Staged:   orchestration.StagedStrategySpec{CanarySize: 1, WaveSize: 2, SoakTime: "10ms"},
+	})
+
+	// then
+	require.NoError(t, err)
+	s.Wait(id)
+	assert.Equal(t, 5, executor.countStates(orchestration.Succeeded))
+	assert.Equal(t, "op-0", executor.executed[0])
+}
+
+func TestStagedOrchestrationStrategy_CanaryFailed(t *testing.T) {
+	// given
+	executor := newStagedTestExecutor("op-0")
+	s := NewStagedOrchestrationStrategy(executor, executor, logrus.New())
+
+	// when
+	id, err := s.Execute(fixRuntimeOperations(5), orchestration.StrategySpec{
+		Type:     orchestration.StagedStrategy,
+		Schedule: orchestration.Immediate,
+		Parallel: orchestration.ParallelStrategySpec{Workers: 2},
+		Staged:   orchestration.StagedStrategySpec{CanarySize: 1},
+	})
+
+	// then
+	require.NoError(t, err)
+	s.Wait(id)
+	assert.Equal(t, []string{"op-0"}, executor.executed)
+	assert.Equal(t, 1, executor.countStates(orchestration.Failed))
+	// the remaining operations stay pending until the paused orchestration is resumed
+	assert.Len(t, executor.states, 1)
+}
+
+func TestStagedOrchestrationStrategy_FailuresBelowThreshold(t *testing.T) {
+	// given
+	executor := newStagedTestExecutor("op-1")
+	s := NewStagedOrchestrationStrategy(executor, executor, logrus.New())
+
+	// when
+	id, err := s.Execute(fixRuntimeOperations(4), orchestration.StrategySpec{
+		Type:            orchestration.StagedStrategy,
+		Schedule:        orchestration.Immediate,
+		Parallel:        orchestration.ParallelStrategySpec{Workers: 2},
+		Staged:          orchestration.StagedStrategySpec{CanarySize: 2, WaveSize: 1},
+		MaxFailureRatio: 0.5,
+	})
+
+	// then
+	require.NoError(t, err)
+	s.Wait(id)
+	assert.Len(t, executor.executed, 4)
+	assert.Equal(t, 1, executor.countStates(orchestration.Failed))
+	assert.Equal(t, 3, executor.countStates(orchestration.Succeeded))
+}
+
+func TestStagedOrchestrationStrategy_CanceledDuringSoak(t *testing.T) {
+	// given
+	executor := newStagedTestExecutor()
+	s := NewStagedOrchestrationStrategy(executor, executor, logrus.New())
+
+	id, err := s.Execute(fixRuntimeOperations(3), orchestration.StrategySpec{
+		Type:     orchestration.StagedStrategy,
+		Schedule: orchestration.Immediate,
+		Parallel: orchestration.ParallelStrategySpec{Workers: 1},
+		Staged:   orchestration.StagedStrategySpec{SoakTime: "1h"},
+	})
+	require.NoError(t, err)
+
+	// when
+	require.Eventually(t, func() bool {
+		return executor.countStates(orchestration.Succeeded) == 1
+	}, time.Second, 10*time.Millisecond)
+	s.Cancel(id)
+
+	// then
+	s.Wait(id)
+	assert.Equal(t, []string{"op-0"}, executor.executed)
+}
+
+func TestParseSoakTime(t *testing.T) {
+	d, err := ParseSoakTime("")
+	require.NoError(t, err)
+	assert.Zero(t, d)
+
+	d, err = ParseSoakTime("30m")
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, d)
+
+	_, err = ParseSoakTime("thirty")
+	assert.Error(t, err)
+
+	_, err = ParseSoakTime("-1m")
+	assert.Error(t, err)
+}
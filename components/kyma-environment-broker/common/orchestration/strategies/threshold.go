@@ -0,0 +1,27 @@
+package strategies
+
+import (
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+)
+
+// FailureThresholdExceeded checks the operation statistics of the orchestration against the limits of failed operations
+// given in the strategy spec. Only operations finished since the baseline statistics are taken into account,
+// so that the failures from before the orchestration was resumed do not pause it again. The staged strategy
+// without any limit is paused on the first failure, the parallel strategy is not limited.
+func FailureThresholdExceeded(spec orchestration.StrategySpec, baseline, stats map[string]int) bool {
+	failed := stats[orchestration.Failed] - baseline[orchestration.Failed]
+	if failed <= 0 {
+		return false
+	}
+	if spec.Type == orchestration.StagedStrategy && spec.MaxFailures == 0 && spec.MaxFailureRatio == 0 {
+		return true
+	}
+	if spec.MaxFailures > 0 && failed > spec.MaxFailures {
+		return true
+	}
+	if spec.MaxFailureRatio > 0 {
+		finished := failed + stats[orchestration.Succeeded] - baseline[orchestration.Succeeded]
+		return float64(failed) > spec.MaxFailureRatio*float64(finished)
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package strategies
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailureThresholdExceeded(t *testing.T) {
+	for name, tc := range map[string]struct {
+		spec     orchestration.StrategySpec
+		baseline map[string]int
+		stats    map[string]int
+		expected bool
+	}{
+		"no limits": {
+			stats:    map[string]int{orchestration.Failed: 10},
+			expected: false,
+		},
+		"staged strategy without limits": {
+			spec:     orchestration.StrategySpec{Type: orchestration.StagedStrategy},
+			stats:    map[string]int{orchestration.Failed: 1, orchestration.Succeeded: 10},
+			expected: true,
+		},
+		"staged strategy with max failures": {
+			spec:     orchestration.StrategySpec{Type: orchestration.StagedStrategy, MaxFailures: 1},
+			stats:    map[string]int{orchestration.Failed: 1, orchestration.Succeeded: 10},
+			expected: false,
+		},
+		"max failures not exceeded": {
+			spec:     orchestration.StrategySpec{MaxFailures: 2},
+			stats:    map[string]int{orchestration.Failed: 2},
+			expected: false,
+		},
+		"max failures exceeded": {
+			spec:     orchestration.StrategySpec{MaxFailures: 2},
+			stats:    map[string]int{orchestration.Failed: 3},
+			expected: true,
+		},
+		"max failures exceeded before the baseline": {
+			spec:     orchestration.StrategySpec{MaxFailures: 2},
+			baseline: map[string]int{orchestration.Failed: 3},
+			stats:    map[string]int{orchestration.Failed: 4, orchestration.Succeeded: 5},
+			expected: false,
+		},
+		"max failure ratio not exceeded": {
+			spec:     orchestration.StrategySpec{MaxFailureRatio: 0.5},
+			stats:    map[string]int{orchestration.Failed: 2, orchestration.Succeeded: 2, orchestration.Pending: 10},
+			expected: false,
+		},
+		"max failure ratio exceeded": {
+			spec:     orchestration.StrategySpec{MaxFailureRatio: 0.5},
+			stats:    map[string]int{orchestration.Failed: 3, orchestration.Succeeded: 2},
+			expected: true,
+		},
+		"max failure ratio exceeded since the baseline": {
+			spec:     orchestration.StrategySpec{MaxFailureRatio: 0.5},
+			baseline: map[string]int{orchestration.Succeeded: 10},
+			stats:    map[string]int{orchestration.Failed: 2, orchestration.Succeeded: 11},
+			expected: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, FailureThresholdExceeded(tc.spec, tc.baseline, tc.stats))
+		})
+	}
+}
@@ -20,6 +20,7 @@ const defaultPageSize = 100
 // Client is the interface to interact with the KEB /runtimes API as an HTTP client using OIDC ID token in JWT format.
 type Client interface {
 	ListRuntimes(params ListParameters) (RuntimesPage, error)
+	WakeUpRuntime(instanceID string) (WakeUpResponse, error)
 }
 
 type client struct {
@@ -100,6 +101,40 @@ func (c *client) ListRuntimes(params ListParameters) (RuntimesPage, error) {
 	return runtimes, nil
 }
 
+// WakeUpRuntime schedules the operation which wakes up the hibernated runtime of the given trial instance.
+func (c *client) WakeUpRuntime(instanceID string) (WakeUpResponse, error) {
+	wr := WakeUpResponse{}
+	url := fmt.Sprintf("%s/trials/%s/wakeup", c.url, instanceID)
+	resp, err := c.httpClient.Post(url, "application/json", nil)
+	if err != nil {
+		return wr, errors.Wrapf(err, "while calling %s", url)
+	}
+
+	// Drain response body and close, return error to context if there isn't any.
+	defer func() {
+		derr := drainResponseBody(resp.Body)
+		if err == nil {
+			err = derr
+		}
+		cerr := resp.Body.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return wr, fmt.Errorf("calling %s returned %s status", url, resp.Status)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	err = decoder.Decode(&wr)
+	if err != nil {
+		return wr, errors.Wrap(err, "while decoding response body")
+	}
+
+	return wr, nil
+}
+
 func setQuery(url *url.URL, params ListParameters) {
 	query := url.Query()
 	query.Add(pagination.PageParam, strconv.Itoa(params.Page))
@@ -111,6 +146,9 @@ func setQuery(url *url.URL, params ListParameters) {
 	setParamList(query, RegionParam, params.Regions)
 	setParamList(query, ShootParam, params.Shoots)
 	setParamList(query, PlanParam, params.Plans)
+	if params.IncludeDeleted {
+		query.Add(IncludeDeletedParam, "true")
+	}
 	url.RawQuery = query.Encode()
 }
 
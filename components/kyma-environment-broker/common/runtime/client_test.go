@@ -107,6 +107,49 @@ func TestClient_ListRuntimes(t *testing.T) {
 	})
 }
 
+func TestClient_WakeUpRuntime(t *testing.T) {
+	t.Run("test request URL and response are correct", func(t *testing.T) {
+		//given
+		called := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called++
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/trials/instance-id/wakeup", r.URL.Path)
+			assert.Equal(t, r.Header.Get("Authorization"), fmt.Sprintf("Bearer %s", fixToken))
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusAccepted)
+			err := json.NewEncoder(w).Encode(WakeUpResponse{OperationID: "operation-id"})
+			require.NoError(t, err)
+		}))
+		defer ts.Close()
+		client := NewClient(context.TODO(), ts.URL, fixToken)
+
+		//when
+		wr, err := client.WakeUpRuntime("instance-id")
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, 1, called)
+		assert.Equal(t, "operation-id", wr.OperationID)
+	})
+
+	t.Run("test conflict", func(t *testing.T) {
+		//given
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+		}))
+		defer ts.Close()
+		client := NewClient(context.TODO(), ts.URL, fixToken)
+
+		//when
+		_, err := client.WakeUpRuntime("instance-id")
+
+		//then
+		require.Error(t, err)
+	})
+}
+
 func fixRuntimeDTO(id string) RuntimeDTO {
 	return RuntimeDTO{
 		InstanceID:       id,
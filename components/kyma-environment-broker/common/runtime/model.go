@@ -29,6 +29,10 @@ type RuntimeStatus struct {
 
 	Suspension   OperationsData `json:"suspension,omitempty"`
 	Unsuspension OperationsData `json:"unsuspension,omitempty"`
+	WakeUp       OperationsData `json:"wakeUp,omitempty"`
+
+	// ArchivedAt is set only for the deleted runtimes returned from the instance archive
+	ArchivedAt *time.Time `json:"archivedAt,omitempty"`
 }
 
 type OperationsData struct {
@@ -45,6 +49,11 @@ type Operation struct {
 	OrchestrationID string    `json:"orchestrationID,omitempty"`
 }
 
+// WakeUpResponse is returned when the wake-up of the hibernated runtime is scheduled
+type WakeUpResponse struct {
+	OperationID string `json:"operationID"`
+}
+
 type RuntimesPage struct {
 	Data       []RuntimeDTO `json:"data"`
 	Count      int          `json:"count"`
@@ -59,6 +68,7 @@ const (
 	RegionParam          = "region"
 	ShootParam           = "shoot"
 	PlanParam            = "plan"
+	IncludeDeletedParam  = "includeDeleted"
 )
 
 type ListParameters struct {
@@ -71,6 +81,7 @@ type ListParameters struct {
 	Regions          []string
 	Shoots           []string
 	Plans            []string
+	IncludeDeleted   bool
 }
 
 type OperationType string
@@ -81,6 +92,7 @@ const (
 	UpgradeKyma  OperationType = "kyma upgrade"
 	Suspension   OperationType = "suspension"
 	Unsuspension OperationType = "unsuspension"
+	WakeUp       OperationType = "wake up"
 )
 
 func FindLastOperation(rt RuntimeDTO) (Operation, OperationType) {
@@ -98,6 +110,12 @@ func FindLastOperation(rt RuntimeDTO) (Operation, OperationType) {
 		opType = Unsuspension
 	}
 
+	// Take the first wake up operation, assuming that Data is sorted by CreatedAt DESC.
+	if rt.Status.WakeUp.Count > 0 && rt.Status.WakeUp.Data[0].CreatedAt.After(op.CreatedAt) {
+		op = rt.Status.WakeUp.Data[0]
+		opType = WakeUp
+	}
+
 	// Take the first suspension operation, assuming that Data is sorted by CreatedAt DESC.
 	if rt.Status.Suspension.Count > 0 && rt.Status.Suspension.Data[0].CreatedAt.After(op.CreatedAt) {
 		op = rt.Status.Suspension.Data[0]
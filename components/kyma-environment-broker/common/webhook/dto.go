@@ -0,0 +1,134 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+)
+
+// Types of the notifications sent to the webhooks
+const (
+	ProvisioningSucceeded   = "provisioning.succeeded"
+	ProvisioningFailed      = "provisioning.failed"
+	DeprovisioningSucceeded = "deprovisioning.succeeded"
+	DeprovisioningFailed    = "deprovisioning.failed"
+	SuspensionSucceeded     = "suspension.succeeded"
+	SuspensionFailed        = "suspension.failed"
+	UpgradeKymaSucceeded    = "upgradeKyma.succeeded"
+	UpgradeKymaFailed       = "upgradeKyma.failed"
+	UpgradeClusterSucceeded = "upgradeCluster.succeeded"
+	UpgradeClusterFailed    = "upgradeCluster.failed"
+	UpdateSucceeded         = "update.succeeded"
+	UpdateFailed            = "update.failed"
+
+	OrchestrationStarted   = "orchestration.started"
+	OrchestrationPaused    = "orchestration.paused"
+	OrchestrationSucceeded = "orchestration.succeeded"
+	OrchestrationFailed    = "orchestration.failed"
+	OrchestrationCanceled  = "orchestration.canceled"
+)
+
+// EventTypes contains all types of the notifications
+var EventTypes = []string{
+	ProvisioningSucceeded, ProvisioningFailed,
+	DeprovisioningSucceeded, DeprovisioningFailed,
+	SuspensionSucceeded, SuspensionFailed,
+	UpgradeKymaSucceeded, UpgradeKymaFailed,
+	UpgradeClusterSucceeded, UpgradeClusterFailed,
+	UpdateSucceeded, UpdateFailed,
+	OrchestrationStarted, OrchestrationPaused, OrchestrationSucceeded, OrchestrationFailed, OrchestrationCanceled,
+}
+
+// Headers of the notification requests
+const (
+	EventTypeHeader = "X-KEB-Event"
+	DeliveryHeader  = "X-KEB-Delivery"
+	// SignatureHeader contains the HMAC SHA256 signature of the request body, see Signature
+	SignatureHeader = "X-KEB-Signature"
+)
+
+// Filter specifies which notifications are sent to the webhook, empty lists match all values
+type Filter struct {
+	EventTypes []string `json:"eventTypes,omitempty"`
+	// Plans contains the names of the plans, e.g. azure, trial
+	Plans            []string `json:"plans,omitempty"`
+	GlobalAccountIDs []string `json:"globalAccountIDs,omitempty"`
+}
+
+type SubscriptionRequest struct {
+	URL string `json:"url"`
+	// Secret is used to sign the notifications, it is generated if empty
+	Secret string `json:"secret,omitempty"`
+	Filter Filter `json:"filter"`
+}
+
+type SubscriptionDTO struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// Secret is returned only when the subscription is created
+	Secret    string    `json:"secret,omitempty"`
+	Filter    Filter    `json:"filter"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type SubscriptionList struct {
+	Data  []SubscriptionDTO `json:"data"`
+	Count int               `json:"count"`
+}
+
+type DeliveryDTO struct {
+	ID             string    `json:"id"`
+	NotificationID string    `json:"notificationID"`
+	EventType      string    `json:"eventType"`
+	StatusCode     int       `json:"statusCode,omitempty"`
+	Error          string    `json:"error,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type DeliveryList struct {
+	Data  []DeliveryDTO `json:"data"`
+	Count int           `json:"count"`
+}
+
+// Notification is the body of the request sent to the webhook. The ID is the same for every delivery attempt
+// of the notification, so the receiver can skip the notifications which were already processed.
+type Notification struct {
+	ID            string             `json:"id"`
+	Type          string             `json:"type"`
+	CreatedAt     time.Time          `json:"createdAt"`
+	Runtime       *RuntimeData       `json:"runtime,omitempty"`
+	Orchestration *OrchestrationData `json:"orchestration,omitempty"`
+}
+
+type RuntimeData struct {
+	InstanceID      string `json:"instanceID"`
+	RuntimeID       string `json:"runtimeID"`
+	GlobalAccountID string `json:"globalAccountID"`
+	SubAccountID    string `json:"subAccountID"`
+	PlanID          string `json:"planID"`
+	PlanName        string `json:"planName"`
+	OperationID     string `json:"operationID"`
+	State           string `json:"state"`
+	Description     string `json:"description"`
+	OrchestrationID string `json:"orchestrationID,omitempty"`
+	// Parameters are the provisioning parameters of the instance with the secret values masked
+	Parameters internal.ProvisioningParameters `json:"parameters"`
+}
+
+type OrchestrationData struct {
+	OrchestrationID string `json:"orchestrationID"`
+	Type            string `json:"type"`
+	State           string `json:"state"`
+	Description     string `json:"description"`
+}
+
+// Signature returns the value of the SignatureHeader for the given request body. The receiver verifies the notification
+// by computing the signature of the received body with the secret of the subscription.
+func Signature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
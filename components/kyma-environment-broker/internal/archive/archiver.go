@@ -0,0 +1,103 @@
+package archive
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/pkg/errors"
+)
+
+// Archiver stores the read-only snapshot of the instance together with its operations and runtime states
+type Archiver struct {
+	operations    storage.Operations
+	runtimeStates storage.RuntimeStates
+	archives      storage.InstanceArchives
+}
+
+func NewArchiver(operations storage.Operations, runtimeStates storage.RuntimeStates, archives storage.InstanceArchives) *Archiver {
+	return &Archiver{
+		operations:    operations,
+		runtimeStates: runtimeStates,
+		archives:      archives,
+	}
+}
+
+// Archive stores the snapshot of the instance with the secrets removed, the instance which is already archived is skipped
+func (a *Archiver) Archive(instance internal.Instance) error {
+	archive := internal.InstanceArchive{
+		Instance:   redactInstance(instance),
+		ArchivedAt: time.Now(),
+	}
+	var operationIDs []string
+
+	provisioning, err := a.operations.ListProvisioningOperationsByInstanceID(instance.InstanceID)
+	if err != nil && !dberr.IsNotFound(err) {
+		return errors.Wrap(err, "while listing provisioning operations")
+	}
+	for _, op := range provisioning {
+		op.Operation = redactOperation(op.Operation)
+		archive.ProvisioningOperations = append(archive.ProvisioningOperations, op)
+		operationIDs = append(operationIDs, op.Operation.ID)
+	}
+
+	deprovisioning, err := a.operations.ListDeprovisioningOperationsByInstanceID(instance.InstanceID)
+	if err != nil && !dberr.IsNotFound(err) {
+		return errors.Wrap(err, "while listing deprovisioning operations")
+	}
+	for _, op := range deprovisioning {
+		op.Operation = redactOperation(op.Operation)
+		archive.DeprovisioningOperations = append(archive.DeprovisioningOperations, op)
+		operationIDs = append(operationIDs, op.Operation.ID)
+	}
+
+	upgradeKyma, err := a.operations.ListUpgradeKymaOperationsByInstanceID(instance.InstanceID)
+	if err != nil && !dberr.IsNotFound(err) {
+		return errors.Wrap(err, "while listing upgrade kyma operations")
+	}
+	for _, op := range upgradeKyma {
+		op.Operation = redactOperation(op.Operation)
+		archive.UpgradeKymaOperations = append(archive.UpgradeKymaOperations, op)
+		operationIDs = append(operationIDs, op.Operation.ID)
+	}
+
+	upgradeCluster, err := a.operations.ListUpgradeClusterOperationsByInstanceID(instance.InstanceID)
+	if err != nil && !dberr.IsNotFound(err) {
+		return errors.Wrap(err, "while listing upgrade cluster operations")
+	}
+	for _, op := range upgradeCluster {
+		op.Operation = redactOperation(op.Operation)
+		archive.UpgradeClusterOperations = append(archive.UpgradeClusterOperations, op)
+		operationIDs = append(operationIDs, op.Operation.ID)
+	}
+
+	updating, err := a.operations.ListUpdatingOperationsByInstanceID(instance.InstanceID)
+	if err != nil && !dberr.IsNotFound(err) {
+		return errors.Wrap(err, "while listing updating operations")
+	}
+	for _, op := range updating {
+		op.Operation = redactOperation(op.Operation)
+		archive.UpdatingOperations = append(archive.UpdatingOperations, op)
+		operationIDs = append(operationIDs, op.Operation.ID)
+	}
+
+	for _, id := range operationIDs {
+		state, err := a.runtimeStates.GetByOperationID(id)
+		switch {
+		case err == nil:
+			archive.RuntimeStates = append(archive.RuntimeStates, redactRuntimeState(state))
+		case dberr.IsNotFound(err):
+		default:
+			return errors.Wrapf(err, "while getting runtime state of the operation %s", id)
+		}
+	}
+
+	err = a.archives.Insert(archive)
+	if dbErr, ok := err.(dberr.Error); ok && dbErr.Code() == dberr.CodeAlreadyExists {
+		// the instance was archived before its removal failed
+		return nil
+	}
+	return err
+}
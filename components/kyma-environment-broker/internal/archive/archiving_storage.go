@@ -0,0 +1,37 @@
+package archive
+
+import (
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/pkg/errors"
+)
+
+// ArchivingStorage archives the instance with its operations and runtime states before the instance is deleted
+type ArchivingStorage struct {
+	storage.Instances
+	archiver *Archiver
+}
+
+func NewArchivingStorage(instances storage.Instances, archiver *Archiver) *ArchivingStorage {
+	return &ArchivingStorage{
+		Instances: instances,
+		archiver:  archiver,
+	}
+}
+
+func (s *ArchivingStorage) Delete(instanceID string) error {
+	instance, err := s.Instances.GetByID(instanceID)
+	switch {
+	case err == nil:
+	case dberr.IsNotFound(err):
+		return s.Instances.Delete(instanceID)
+	default:
+		return errors.Wrapf(err, "while getting instance %s", instanceID)
+	}
+
+	if err := s.archiver.Archive(*instance); err != nil {
+		return errors.Wrapf(err, "while archiving instance %s", instanceID)
+	}
+	return s.Instances.Delete(instanceID)
+}
@@ -0,0 +1,132 @@
+package archive
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	instanceID = "instance-id"
+	runtimeID  = "runtime-id"
+)
+
+func TestArchivingStorage_Delete(t *testing.T) {
+	t.Run("should archive the instance with its operations and runtime states without secrets", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		instance := fixture.FixInstance(instanceID)
+		instance.InstanceDetails.Ems.Overrides = "ems-overrides"
+		require.NoError(t, st.Instances().Insert(instance))
+		provisioning := fixture.FixProvisioningOperation("provisioning-id", instanceID)
+		provisioning.InstanceDetails.Cls.Overrides = "cls-overrides"
+		require.NoError(t, st.Operations().InsertProvisioningOperation(provisioning))
+		deprovisioning := fixture.FixDeprovisioningOperation("deprovisioning-id", instanceID)
+		require.NoError(t, st.Operations().InsertDeprovisioningOperation(deprovisioning))
+		require.NoError(t, st.RuntimeStates().Insert(fixRuntimeState("state-id", "provisioning-id")))
+
+		svc := NewArchivingStorage(st.Instances(), NewArchiver(st.Operations(), st.RuntimeStates(), st.InstanceArchives()))
+
+		// when
+		err := svc.Delete(instanceID)
+
+		// then
+		require.NoError(t, err)
+		_, err = st.Instances().GetByID(instanceID)
+		assert.True(t, dberr.IsNotFound(err))
+
+		archive, err := st.InstanceArchives().GetByInstanceID(instanceID)
+		require.NoError(t, err)
+		assert.Equal(t, instance.RuntimeID, archive.Instance.RuntimeID)
+		assert.False(t, archive.ArchivedAt.IsZero())
+		assert.Nil(t, archive.Instance.Parameters.Parameters.TargetSecret)
+		assert.Empty(t, archive.Instance.Parameters.ErsContext.ServiceManager.Credentials.BasicAuth.Password)
+		assert.Empty(t, archive.Instance.Parameters.ErsContext.UserID)
+		assert.Empty(t, archive.Instance.InstanceDetails.Ems.Overrides)
+
+		require.Len(t, archive.ProvisioningOperations, 1)
+		assert.Equal(t, "provisioning-id", archive.ProvisioningOperations[0].ID)
+		assert.Nil(t, archive.ProvisioningOperations[0].ProvisioningParameters.Parameters.TargetSecret)
+		assert.Empty(t, archive.ProvisioningOperations[0].InstanceDetails.Cls.Overrides)
+		require.Len(t, archive.DeprovisioningOperations, 1)
+		assert.Equal(t, "deprovisioning-id", archive.DeprovisioningOperations[0].ID)
+
+		require.Len(t, archive.RuntimeStates, 1)
+		state := archive.RuntimeStates[0]
+		assert.Empty(t, state.ClusterConfig.TargetSecret)
+		assert.Empty(t, state.KymaConfig.Configuration[0].Value)
+		assert.Equal(t, "plain", state.KymaConfig.Configuration[1].Value)
+		assert.Empty(t, state.KymaConfig.Components[0].Configuration[0].Value)
+
+		// the stored runtime state is not modified
+		stored, err := st.RuntimeStates().GetByOperationID("provisioning-id")
+		require.NoError(t, err)
+		assert.Equal(t, "secret-value", stored.KymaConfig.Configuration[0].Value)
+		assert.Equal(t, "secret-value", stored.KymaConfig.Components[0].Configuration[0].Value)
+	})
+
+	t.Run("should delete the instance which is already archived", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		require.NoError(t, st.Instances().Insert(fixture.FixInstance(instanceID)))
+		require.NoError(t, st.InstanceArchives().Insert(internal.InstanceArchive{Instance: fixture.FixInstance(instanceID)}))
+
+		svc := NewArchivingStorage(st.Instances(), NewArchiver(st.Operations(), st.RuntimeStates(), st.InstanceArchives()))
+
+		// when
+		err := svc.Delete(instanceID)
+
+		// then
+		require.NoError(t, err)
+		_, err = st.Instances().GetByID(instanceID)
+		assert.True(t, dberr.IsNotFound(err))
+	})
+
+	t.Run("should not fail for the not existing instance", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		svc := NewArchivingStorage(st.Instances(), NewArchiver(st.Operations(), st.RuntimeStates(), st.InstanceArchives()))
+
+		// when
+		err := svc.Delete(instanceID)
+
+		// then
+		require.NoError(t, err)
+		_, err = st.InstanceArchives().GetByInstanceID(instanceID)
+		assert.True(t, dberr.IsNotFound(err))
+	})
+}
+
+func fixRuntimeState(id, operationID string) internal.RuntimeState {
+	return internal.RuntimeState{
+		ID:          id,
+		RuntimeID:   runtimeID,
+		OperationID: operationID,
+		KymaConfig: gqlschema.KymaConfigInput{
+			Version: "1.21.0",
+			Components: []*gqlschema.ComponentConfigurationInput{
+				{
+					Component: "compass-runtime-agent",
+					Configuration: []*gqlschema.ConfigEntryInput{
+						{Key: "token", Value: "secret-value", Secret: ptr.Bool(true)},
+					},
+				},
+			},
+			Configuration: []*gqlschema.ConfigEntryInput{
+				{Key: "password", Value: "secret-value", Secret: ptr.Bool(true)},
+				{Key: "domain", Value: "plain"},
+			},
+		},
+		ClusterConfig: gqlschema.GardenerConfigInput{
+			TargetSecret: "target-secret",
+		},
+	}
+}
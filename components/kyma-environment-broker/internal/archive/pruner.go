@@ -0,0 +1,92 @@
+package archive
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Config defines the retention of the operations of the archived instances
+type Config struct {
+	// PruningDisabled turns off the removal of the old operations from the operations table
+	PruningDisabled bool `envconfig:"default=true"`
+	// OperationsRetention is the age after which the finished operations of the archived instances are removed,
+	// the operations stay available in the instance archive
+	OperationsRetention time.Duration `envconfig:"default=2160h"`
+	// PruningInterval between the removals of the old operations
+	PruningInterval time.Duration `envconfig:"default=1h"`
+	// PruningBatchSize is the number of the operations removed at once
+	PruningBatchSize int `envconfig:"default=100"`
+}
+
+// Pruner removes the old operations of the archived instances together with their runtime states and events
+type Pruner struct {
+	archives        storage.InstanceArchives
+	operations      storage.Operations
+	runtimeStates   storage.RuntimeStates
+	operationEvents storage.OperationEvents
+
+	cfg Config
+	log logrus.FieldLogger
+}
+
+func NewPruner(archives storage.InstanceArchives, operations storage.Operations, runtimeStates storage.RuntimeStates,
+	operationEvents storage.OperationEvents, cfg Config, log logrus.FieldLogger) *Pruner {
+	return &Pruner{
+		archives:        archives,
+		operations:      operations,
+		runtimeStates:   runtimeStates,
+		operationEvents: operationEvents,
+		cfg:             cfg,
+		log:             log.WithField("service", "operationsPruner"),
+	}
+}
+
+// Run prunes the operations periodically until the channel is closed
+func (p *Pruner) Run(stopCh <-chan struct{}) {
+	wait.Until(func() {
+		if err := p.PruneOperations(); err != nil {
+			p.log.Errorf("while pruning operations: %s", err)
+		}
+	}, p.cfg.PruningInterval, stopCh)
+}
+
+// PruneOperations removes in batches the finished operations of the archived instances older than the retention,
+// the runtime states and events are removed first so the interrupted batch is removed again in the next run
+func (p *Pruner) PruneOperations() error {
+	createdBefore := time.Now().Add(-p.cfg.OperationsRetention)
+	pruned := 0
+	for {
+		ids, err := p.archives.ListOperationIDsToPrune(createdBefore, p.cfg.PruningBatchSize)
+		if err != nil {
+			return errors.Wrap(err, "while listing operations to prune")
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		if err := p.runtimeStates.DeleteByOperationIDs(ids); err != nil {
+			return errors.Wrap(err, "while deleting runtime states")
+		}
+		if err := p.operationEvents.DeleteByOperationIDs(ids); err != nil {
+			return errors.Wrap(err, "while deleting operation events")
+		}
+		if err := p.operations.DeleteOperationsByIDs(ids); err != nil {
+			return errors.Wrap(err, "while deleting operations")
+		}
+		pruned += len(ids)
+
+		if len(ids) < p.cfg.PruningBatchSize {
+			break
+		}
+	}
+
+	if pruned > 0 {
+		p.log.Infof("pruned %d operations created before %s", pruned, createdBefore.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package archive
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruner_PruneOperations(t *testing.T) {
+	t.Run("should remove the old operations of the archived instances in batches", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		old := time.Now().Add(-100 * 24 * time.Hour)
+
+		provisioning := fixture.FixProvisioningOperation("provisioning-id", instanceID)
+		provisioning.CreatedAt = old
+		require.NoError(t, st.Operations().InsertProvisioningOperation(provisioning))
+		deprovisioning := fixture.FixDeprovisioningOperation("deprovisioning-id", instanceID)
+		deprovisioning.CreatedAt = old.Add(time.Hour)
+		require.NoError(t, st.Operations().InsertDeprovisioningOperation(deprovisioning))
+		upgrade := fixture.FixUpgradeKymaOperation("upgrade-id", instanceID)
+		upgrade.CreatedAt = old.Add(2 * time.Hour)
+		require.NoError(t, st.Operations().InsertUpgradeKymaOperation(upgrade))
+		require.NoError(t, st.RuntimeStates().Insert(fixRuntimeState("state-id", "provisioning-id")))
+		require.NoError(t, st.OperationEvents().Insert(internal.OperationEvent{ID: "event-id", OperationID: "provisioning-id", InstanceID: instanceID}))
+		require.NoError(t, st.InstanceArchives().Insert(internal.InstanceArchive{Instance: fixture.FixInstance(instanceID)}))
+
+		pruner := fixPruner(st, 2)
+
+		// when
+		err := pruner.PruneOperations()
+
+		// then
+		require.NoError(t, err)
+		_, err = st.Operations().GetOperationByID("provisioning-id")
+		assert.True(t, dberr.IsNotFound(err))
+		_, err = st.Operations().GetOperationByID("deprovisioning-id")
+		assert.True(t, dberr.IsNotFound(err))
+		_, err = st.Operations().GetOperationByID("upgrade-id")
+		assert.True(t, dberr.IsNotFound(err))
+		_, err = st.RuntimeStates().GetByOperationID("provisioning-id")
+		assert.True(t, dberr.IsNotFound(err))
+		events, err := st.OperationEvents().ListByOperationID("provisioning-id")
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("should keep the operations of the not archived instances and the recent and unfinished ones", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		old := time.Now().Add(-100 * 24 * time.Hour)
+
+		live := fixture.FixProvisioningOperation("live-id", "live-instance-id")
+		live.CreatedAt = old
+		require.NoError(t, st.Operations().InsertProvisioningOperation(live))
+		recent := fixture.FixProvisioningOperation("recent-id", instanceID)
+		require.NoError(t, st.Operations().InsertProvisioningOperation(recent))
+		inProgress := fixture.FixDeprovisioningOperation("in-progress-id", instanceID)
+		inProgress.CreatedAt = old
+		inProgress.State = domain.InProgress
+		require.NoError(t, st.Operations().InsertDeprovisioningOperation(inProgress))
+		require.NoError(t, st.InstanceArchives().Insert(internal.InstanceArchive{Instance: fixture.FixInstance(instanceID)}))
+
+		pruner := fixPruner(st, 100)
+
+		// when
+		err := pruner.PruneOperations()
+
+		// then
+		require.NoError(t, err)
+		for _, id := range []string{"live-id", "recent-id", "in-progress-id"} {
+			_, err = st.Operations().GetOperationByID(id)
+			assert.NoError(t, err, id)
+		}
+	})
+}
+
+func fixPruner(st storage.BrokerStorage, batchSize int) *Pruner {
+	return NewPruner(st.InstanceArchives(), st.Operations(), st.RuntimeStates(), st.OperationEvents(), Config{
+		OperationsRetention: 90 * 24 * time.Hour,
+		PruningInterval:     time.Hour,
+		PruningBatchSize:    batchSize,
+	}, logrus.New())
+}
@@ -0,0 +1,66 @@
+package archive
+
+import (
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+)
+
+// redactInstance removes the secret values from the instance, the archive must not keep any credentials
+func redactInstance(instance internal.Instance) internal.Instance {
+	instance.Parameters = redactParameters(instance.Parameters)
+	instance.InstanceDetails = redactDetails(instance.InstanceDetails)
+	return instance
+}
+
+func redactOperation(op internal.Operation) internal.Operation {
+	op.ProvisioningParameters = redactParameters(op.ProvisioningParameters)
+	op.InstanceDetails = redactDetails(op.InstanceDetails)
+	return op
+}
+
+func redactParameters(pp internal.ProvisioningParameters) internal.ProvisioningParameters {
+	if pp.ErsContext.ServiceManager != nil {
+		sm := *pp.ErsContext.ServiceManager
+		sm.Credentials.BasicAuth.Username = ""
+		sm.Credentials.BasicAuth.Password = ""
+		pp.ErsContext.ServiceManager = &sm
+	}
+	pp.ErsContext.UserID = ""
+	pp.Parameters.TargetSecret = nil
+	return pp
+}
+
+// redactDetails removes the overrides which contain the credentials of the EMS and CLS service bindings
+func redactDetails(details internal.InstanceDetails) internal.InstanceDetails {
+	details.Ems.Overrides = ""
+	details.Cls.Overrides = ""
+	return details
+}
+
+// redactRuntimeState removes the values of the secret Kyma configuration entries and the target secret,
+// the state is copied so the stored runtime state is not modified
+func redactRuntimeState(state internal.RuntimeState) internal.RuntimeState {
+	state.KymaConfig.Components = internal.ComponentConfigurationInputList(state.KymaConfig.Components).DeepCopy()
+	for _, component := range state.KymaConfig.Components {
+		redactConfigEntries(component.Configuration)
+	}
+
+	configuration := make([]*gqlschema.ConfigEntryInput, 0, len(state.KymaConfig.Configuration))
+	for _, entry := range state.KymaConfig.Configuration {
+		copied := *entry
+		configuration = append(configuration, &copied)
+	}
+	redactConfigEntries(configuration)
+	state.KymaConfig.Configuration = configuration
+
+	state.ClusterConfig.TargetSecret = ""
+	return state
+}
+
+func redactConfigEntries(entries []*gqlschema.ConfigEntryInput) {
+	for _, entry := range entries {
+		if entry.Secret != nil && *entry.Secret {
+			entry.Value = ""
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 type Delegator struct {
 	provisionManager  *process.ProvisionOperationManager
 	upgradeManager    *process.UpgradeKymaOperationManager
+	updateManager     *process.UpdateOperationManager
 	avsConfig         Config
 	client            *Client
 	operationsStorage storage.Operations
@@ -28,6 +29,7 @@ func NewDelegator(client *Client, avsConfig Config, os storage.Operations) *Dele
 	return &Delegator{
 		provisionManager:  process.NewProvisionOperationManager(os),
 		upgradeManager:    process.NewUpgradeKymaOperationManager(os),
+		updateManager:     process.NewUpdateOperationManager(os),
 		avsConfig:         avsConfig,
 		client:            client,
 		operationsStorage: os,
@@ -75,6 +77,42 @@ func (del *Delegator) CreateEvaluation(logger logrus.FieldLogger, operation inte
 	return updatedOperation, d, nil
 }
 
+// CreateUpdateEvaluation creates the evaluation for the runtime which was not monitored before the update,
+// for example a trial runtime upgraded to a paid plan
+func (del *Delegator) CreateUpdateEvaluation(logger logrus.FieldLogger, operation internal.UpdatingOperation, evalAssistant EvalAssistant, url string) (internal.UpdatingOperation, time.Duration, error) {
+	if evalAssistant.IsAlreadyCreated(operation.Avs) {
+		logger.Infof("evaluation has already been created")
+		return operation, 0, nil
+	}
+
+	logger.Infof("making avs calls to create the Evaluation")
+	evaluationObject, err := evalAssistant.CreateBasicEvaluationRequest(internal.ProvisioningOperation{Operation: operation.Operation}, url)
+	if err != nil {
+		logger.Errorf("step failed with error %v", err)
+		return operation, 5 * time.Second, nil
+	}
+
+	evalResp, err := del.client.CreateEvaluation(evaluationObject)
+	switch {
+	case err == nil:
+	case kebError.IsTemporaryError(err):
+		errMsg := "cannot create AVS evaluation (temporary)"
+		logger.Errorf("%s: %s", errMsg, err)
+		retryConfig := evalAssistant.provideRetryConfig()
+		return del.updateManager.RetryOperation(operation, errMsg, retryConfig.retryInterval, retryConfig.maxTime, logger)
+	default:
+		errMsg := "cannot create AVS evaluation"
+		logger.Errorf("%s: %s", errMsg, err)
+		return del.updateManager.OperationFailed(operation, errMsg)
+	}
+
+	evalAssistant.SetEvalId(&operation.Avs, evalResp.Id)
+
+	updatedOperation, d := del.updateManager.UpdateOperation(operation)
+
+	return updatedOperation, d, nil
+}
+
 func (del *Delegator) AddTags(logger logrus.FieldLogger, operation internal.ProvisioningOperation, evalAssistant EvalAssistant, tags []*Tag) (internal.ProvisioningOperation, time.Duration, error) {
 	logger.Infof("starting the AddTag to avs internal id [%d]", operation.Avs.AvsEvaluationInternalId)
 	var updatedOperation internal.ProvisioningOperation
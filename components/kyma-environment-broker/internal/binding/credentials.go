@@ -0,0 +1,230 @@
+package binding
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	resourcePrefix = "kcp-binding-"
+	// the label values are limited to 63 characters, so the label holds the hash of the binding ID
+	// and the binding ID itself is stored in the annotation
+	bindingIDHashLabel  = "kcp.kyma-project.io/binding-id-hash"
+	bindingIDAnnotation = "kcp.kyma-project.io/binding-id"
+	// bindingIDHashLength keeps the resource names and the label values within the 63 characters limit
+	bindingIDHashLength = 40
+
+	tokenPollInterval = time.Second
+	tokenPollTimeout  = 30 * time.Second
+)
+
+type Config struct {
+	// Namespace on the SKR in which the ServiceAccounts of the bindings are created
+	Namespace string `envconfig:"default=kyma-system"`
+	// ClusterRole bound to the ServiceAccounts of the bindings, the default role does not allow to manage RBAC and namespaces
+	ClusterRole string `envconfig:"default=edit"`
+	// Timeout of the bind and unbind operations
+	Timeout time.Duration `envconfig:"default=10m"`
+}
+
+var kubeconfigTemplate = template.Must(template.New("kubeconfig").Parse(`---
+apiVersion: v1
+kind: Config
+current-context: {{ .ContextName }}
+clusters:
+- name: {{ .ContextName }}
+  cluster:
+    certificate-authority-data: {{ .CAData }}
+    server: {{ .ServerURL }}
+contexts:
+- name: {{ .ContextName }}
+  context:
+    cluster: {{ .ContextName }}
+    user: {{ .ContextName }}
+users:
+- name: {{ .ContextName }}
+  user:
+    token: {{ .Token }}
+`))
+
+// ClientFactory creates the client of the SKR from its admin kubeconfig
+type ClientFactory func(kubeconfig string) (kubernetes.Interface, error)
+
+// NewClient is the ClientFactory which connects to the cluster defined in the kubeconfig
+func NewClient(kubeconfig string) (kubernetes.Interface, error) {
+	cfg, err := clientcmd.RESTConfigFromKubeConfig([]byte(kubeconfig))
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating REST config from kubeconfig")
+	}
+	return kubernetes.NewForConfig(cfg)
+}
+
+// ServiceAccountCredentials issues the credentials of a binding as a dedicated ServiceAccount on the SKR
+type ServiceAccountCredentials struct {
+	namespace   string
+	clusterRole string
+	newClient   ClientFactory
+
+	pollInterval time.Duration
+	pollTimeout  time.Duration
+}
+
+func NewServiceAccountCredentials(cfg Config, newClient ClientFactory) *ServiceAccountCredentials {
+	return &ServiceAccountCredentials{
+		namespace:    cfg.Namespace,
+		clusterRole:  cfg.ClusterRole,
+		newClient:    newClient,
+		pollInterval: tokenPollInterval,
+		pollTimeout:  tokenPollTimeout,
+	}
+}
+
+// Create creates the ServiceAccount of the binding bound to the configured ClusterRole
+// and returns the kubeconfig which authenticates with the token of the ServiceAccount.
+// Resources which already exist are reused, so Create can be retried.
+// The token of the ServiceAccount token Secret does not expire, the binding credentials
+// are valid until Revoke is called by the unbind or the deprovisioning of the instance.
+func (c *ServiceAccountCredentials) Create(adminKubeconfig, bindingID string) (string, error) {
+	cli, err := c.newClient(adminKubeconfig)
+	if err != nil {
+		return "", errors.Wrap(err, "while creating SKR client")
+	}
+	name := resourceName(bindingID)
+	meta := metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   c.namespace,
+		Labels:      map[string]string{bindingIDHashLabel: hashBindingID(bindingID)},
+		Annotations: map[string]string{bindingIDAnnotation: bindingID},
+	}
+
+	_, err = cli.CoreV1().ServiceAccounts(c.namespace).Create(&corev1.ServiceAccount{ObjectMeta: meta})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return "", errors.Wrapf(err, "while creating service account %s", name)
+	}
+
+	_, err = cli.RbacV1().ClusterRoleBindings().Create(&rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      meta.Labels,
+			Annotations: meta.Annotations,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     c.clusterRole,
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      name,
+			Namespace: c.namespace,
+		}},
+	})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return "", errors.Wrapf(err, "while creating cluster role binding %s", name)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: meta,
+		Type:       corev1.SecretTypeServiceAccountToken,
+	}
+	secret.Annotations = map[string]string{
+		corev1.ServiceAccountNameKey: name,
+		bindingIDAnnotation:          bindingID,
+	}
+	_, err = cli.CoreV1().Secrets(c.namespace).Create(secret)
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return "", errors.Wrapf(err, "while creating token secret %s", name)
+	}
+
+	var token []byte
+	err = wait.PollImmediate(c.pollInterval, c.pollTimeout, func() (bool, error) {
+		secret, err := cli.CoreV1().Secrets(c.namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return false, nil
+		}
+		token = secret.Data[corev1.ServiceAccountTokenKey]
+		return len(token) > 0, nil
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "while waiting for the token of service account %s", name)
+	}
+
+	return serviceAccountKubeconfig(adminKubeconfig, name, string(token))
+}
+
+// Revoke deletes the ServiceAccount of the binding together with its token and cluster role binding
+func (c *ServiceAccountCredentials) Revoke(adminKubeconfig, bindingID string) error {
+	cli, err := c.newClient(adminKubeconfig)
+	if err != nil {
+		return errors.Wrap(err, "while creating SKR client")
+	}
+	name := resourceName(bindingID)
+
+	err = cli.RbacV1().ClusterRoleBindings().Delete(name, &metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "while deleting cluster role binding %s", name)
+	}
+	err = cli.CoreV1().Secrets(c.namespace).Delete(name, &metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "while deleting token secret %s", name)
+	}
+	err = cli.CoreV1().ServiceAccounts(c.namespace).Delete(name, &metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "while deleting service account %s", name)
+	}
+
+	return nil
+}
+
+// resourceName returns the DNS-1123 compliant name of the resources of the binding,
+// the OSB binding ID can contain any characters so only its hash is used
+func resourceName(bindingID string) string {
+	return resourcePrefix + hashBindingID(bindingID)
+}
+
+func hashBindingID(bindingID string) string {
+	sum := sha256.Sum256([]byte(bindingID))
+	return hex.EncodeToString(sum[:])[:bindingIDHashLength]
+}
+
+// serviceAccountKubeconfig builds the kubeconfig for the cluster of the current context of the admin kubeconfig
+func serviceAccountKubeconfig(adminKubeconfig, name, token string) (string, error) {
+	admin, err := clientcmd.Load([]byte(adminKubeconfig))
+	if err != nil {
+		return "", errors.Wrap(err, "while loading admin kubeconfig")
+	}
+	current, found := admin.Contexts[admin.CurrentContext]
+	if !found {
+		return "", fmt.Errorf("current context %q not found in admin kubeconfig", admin.CurrentContext)
+	}
+	cluster, found := admin.Clusters[current.Cluster]
+	if !found {
+		return "", fmt.Errorf("cluster %q not found in admin kubeconfig", current.Cluster)
+	}
+
+	var kubeconfig bytes.Buffer
+	err = kubeconfigTemplate.Execute(&kubeconfig, map[string]string{
+		"ContextName": name,
+		"CAData":      base64.StdEncoding.EncodeToString(cluster.CertificateAuthorityData),
+		"ServerURL":   cluster.Server,
+		"Token":       token,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "while rendering kubeconfig")
+	}
+	return kubeconfig.String(), nil
+}
@@ -0,0 +1,138 @@
+package binding
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	fixBindingID       = "Binding-ID"
+	fixServiceAccount  = "kcp-binding-45ba4519a38bc6630d3b327e2e883934a8c192ff"
+	fixNamespace       = "kyma-system"
+	fixAdminKubeconfig = `apiVersion: v1
+kind: Config
+current-context: shoot
+clusters:
+- name: shoot
+  cluster:
+    server: https://api.shoot.example.com
+    certificate-authority-data: Y2EtZGF0YQ==
+contexts:
+- name: shoot
+  context:
+    cluster: shoot
+    user: admin
+users:
+- name: admin
+  user:
+    token: admin-token
+`
+)
+
+func TestServiceAccountCredentials_Create(t *testing.T) {
+	// given
+	cli := fixClientWithTokenController()
+	credentials := NewServiceAccountCredentials(Config{Namespace: fixNamespace, ClusterRole: "edit"}, fixClientFactory(cli))
+
+	// when
+	kubeconfig, err := credentials.Create(fixAdminKubeconfig, fixBindingID)
+
+	// then
+	require.NoError(t, err)
+
+	_, err = cli.CoreV1().ServiceAccounts(fixNamespace).Get(fixServiceAccount, metav1.GetOptions{})
+	assert.NoError(t, err)
+	crb, err := cli.RbacV1().ClusterRoleBindings().Get(fixServiceAccount, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "edit", crb.RoleRef.Name)
+	assert.Equal(t, fixServiceAccount, crb.Subjects[0].Name)
+
+	cfg, err := clientcmd.Load([]byte(kubeconfig))
+	require.NoError(t, err)
+	assert.Equal(t, fixServiceAccount, cfg.CurrentContext)
+	assert.Equal(t, "https://api.shoot.example.com", cfg.Clusters[fixServiceAccount].Server)
+	assert.Equal(t, []byte("ca-data"), cfg.Clusters[fixServiceAccount].CertificateAuthorityData)
+	assert.Equal(t, "sa-token", cfg.AuthInfos[fixServiceAccount].Token)
+
+	// when
+	_, err = credentials.Create(fixAdminKubeconfig, fixBindingID)
+
+	// then
+	assert.NoError(t, err)
+}
+
+func TestServiceAccountCredentials_CreateWithNotDNSCompliantBindingID(t *testing.T) {
+	// given
+	bindingID := "Binding_ID:" + strings.Repeat("x", 100)
+	cli := fixClientWithTokenController()
+	credentials := NewServiceAccountCredentials(Config{Namespace: fixNamespace, ClusterRole: "edit"}, fixClientFactory(cli))
+
+	// when
+	_, err := credentials.Create(fixAdminKubeconfig, bindingID)
+
+	// then
+	require.NoError(t, err)
+	name := resourceName(bindingID)
+	assert.Empty(t, validation.IsDNS1123Label(name))
+	sa, err := cli.CoreV1().ServiceAccounts(fixNamespace).Get(name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, validation.IsValidLabelValue(sa.Labels[bindingIDHashLabel]))
+	assert.Equal(t, bindingID, sa.Annotations[bindingIDAnnotation])
+}
+
+func TestServiceAccountCredentials_Revoke(t *testing.T) {
+	// given
+	cli := fixClientWithTokenController()
+	credentials := NewServiceAccountCredentials(Config{Namespace: fixNamespace, ClusterRole: "edit"}, fixClientFactory(cli))
+	_, err := credentials.Create(fixAdminKubeconfig, fixBindingID)
+	require.NoError(t, err)
+
+	// when
+	err = credentials.Revoke(fixAdminKubeconfig, fixBindingID)
+
+	// then
+	require.NoError(t, err)
+	_, err = cli.CoreV1().ServiceAccounts(fixNamespace).Get(fixServiceAccount, metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = cli.CoreV1().Secrets(fixNamespace).Get(fixServiceAccount, metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = cli.RbacV1().ClusterRoleBindings().Get(fixServiceAccount, metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	// when
+	err = credentials.Revoke(fixAdminKubeconfig, fixBindingID)
+
+	// then
+	assert.NoError(t, err)
+}
+
+// fixClientWithTokenController returns the fake client which populates the tokens of the created service account secrets
+func fixClientWithTokenController() *fake.Clientset {
+	cli := fake.NewSimpleClientset()
+	cli.PrependReactor("create", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		secret := action.(k8stesting.CreateAction).GetObject().(*corev1.Secret)
+		if secret.Type == corev1.SecretTypeServiceAccountToken {
+			secret.Data = map[string][]byte{corev1.ServiceAccountTokenKey: []byte("sa-token")}
+		}
+		return false, nil, nil
+	})
+	return cli
+}
+
+func fixClientFactory(cli kubernetes.Interface) ClientFactory {
+	return func(string) (kubernetes.Interface, error) {
+		return cli, nil
+	}
+}
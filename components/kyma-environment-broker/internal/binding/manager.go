@@ -0,0 +1,186 @@
+package binding
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const retryInterval = 10 * time.Second
+
+// Credentials issues and revokes the credentials of the bindings on the SKR
+type Credentials interface {
+	Create(adminKubeconfig, bindingID string) (string, error)
+	Revoke(adminKubeconfig, bindingID string) error
+}
+
+// Manager processes the asynchronous bind and unbind operations of the bindings
+type Manager struct {
+	bindings          storage.Bindings
+	instances         storage.Instances
+	provisionerClient provisioner.Client
+	credentials       Credentials
+	timeout           time.Duration
+	log               logrus.FieldLogger
+}
+
+func NewManager(bindings storage.Bindings, instances storage.Instances, provisionerClient provisioner.Client, credentials Credentials, timeout time.Duration, log logrus.FieldLogger) *Manager {
+	return &Manager{
+		bindings:          bindings,
+		instances:         instances,
+		provisionerClient: provisionerClient,
+		credentials:       credentials,
+		timeout:           timeout,
+		log:               log,
+	}
+}
+
+// Execute processes the operation in progress of the binding with the given ID
+func (m *Manager) Execute(bindingID string) (time.Duration, error) {
+	log := m.log.WithField("bindingID", bindingID)
+
+	binding, err := m.bindings.GetByID(bindingID)
+	switch {
+	case err == nil:
+	case dberr.IsNotFound(err):
+		log.Infof("binding does not exist, skipping")
+		return 0, nil
+	default:
+		log.Errorf("unable to get binding: %s", err)
+		return retryInterval, nil
+	}
+	if binding.State != domain.InProgress {
+		return 0, nil
+	}
+	log = log.WithFields(logrus.Fields{"instanceID": binding.InstanceID, "operation": binding.Operation})
+
+	if time.Since(binding.UpdatedAt) > m.timeout {
+		log.Errorf("%s operation has reached the time limit %s", binding.Operation, m.timeout)
+		if binding.Operation == internal.BindingOperationBind {
+			m.revokeAfterFailure(binding, log)
+		}
+		return m.update(binding, domain.Failed, fmt.Sprintf("%s operation has reached the time limit", binding.Operation), log)
+	}
+
+	instance, err := m.instances.GetByID(binding.InstanceID)
+	switch {
+	case err == nil:
+	case dberr.IsNotFound(err) && binding.Operation == internal.BindingOperationUnbind:
+		log.Infof("instance does not exist, removing binding")
+		return m.delete(binding, log)
+	default:
+		log.Errorf("unable to get instance: %s", err)
+		return retryInterval, nil
+	}
+
+	kubeconfig, err := m.adminKubeconfig(instance)
+	if err != nil {
+		log.Errorf("unable to get kubeconfig of the runtime: %s", err)
+		return retryInterval, nil
+	}
+
+	switch binding.Operation {
+	case internal.BindingOperationBind:
+		credentials, err := m.credentials.Create(kubeconfig, binding.BindingID)
+		if err != nil {
+			log.Errorf("unable to create credentials: %s", err)
+			return retryInterval, nil
+		}
+		binding.Kubeconfig = credentials
+		log.Infof("credentials created")
+		return m.update(binding, domain.Succeeded, "binding created", log)
+	case internal.BindingOperationUnbind:
+		if err := m.credentials.Revoke(kubeconfig, binding.BindingID); err != nil {
+			log.Errorf("unable to revoke credentials: %s", err)
+			return retryInterval, nil
+		}
+		log.Infof("credentials revoked")
+		return m.delete(binding, log)
+	default:
+		log.Errorf("unknown binding operation")
+		return m.update(binding, domain.Failed, fmt.Sprintf("unknown binding operation %q", binding.Operation), log)
+	}
+}
+
+// RemoveBindings revokes the credentials of all bindings of the instance and removes the bindings
+func (m *Manager) RemoveBindings(instance *internal.Instance) error {
+	bindings, err := m.bindings.ListByInstanceID(instance.InstanceID)
+	if err != nil {
+		return errors.Wrap(err, "while listing bindings")
+	}
+	if len(bindings) == 0 {
+		return nil
+	}
+
+	kubeconfig, err := m.adminKubeconfig(instance)
+	if err != nil {
+		return errors.Wrap(err, "while getting kubeconfig of the runtime")
+	}
+	for _, binding := range bindings {
+		if err := m.credentials.Revoke(kubeconfig, binding.BindingID); err != nil {
+			return errors.Wrapf(err, "while revoking credentials of binding %s", binding.BindingID)
+		}
+		if err := m.bindings.Delete(binding.BindingID); err != nil {
+			return errors.Wrapf(err, "while deleting binding %s", binding.BindingID)
+		}
+	}
+	return nil
+}
+
+func (m *Manager) adminKubeconfig(instance *internal.Instance) (string, error) {
+	if instance.RuntimeID == "" {
+		return "", errors.New("runtime ID of the instance is empty")
+	}
+	status, err := m.provisionerClient.RuntimeStatus(instance.GlobalAccountID, instance.RuntimeID)
+	if err != nil {
+		return "", errors.Wrap(err, "while getting runtime status")
+	}
+	if status.RuntimeConfiguration == nil || status.RuntimeConfiguration.Kubeconfig == nil || *status.RuntimeConfiguration.Kubeconfig == "" {
+		return "", errors.New("kubeconfig of the runtime is empty")
+	}
+	return *status.RuntimeConfiguration.Kubeconfig, nil
+}
+
+// revokeAfterFailure removes the credentials which could be partially created by the failed bind operation
+func (m *Manager) revokeAfterFailure(binding *internal.Binding, log logrus.FieldLogger) {
+	instance, err := m.instances.GetByID(binding.InstanceID)
+	if err != nil {
+		log.Warnf("unable to get instance to revoke credentials: %s", err)
+		return
+	}
+	kubeconfig, err := m.adminKubeconfig(instance)
+	if err != nil {
+		log.Warnf("unable to get kubeconfig of the runtime to revoke credentials: %s", err)
+		return
+	}
+	if err := m.credentials.Revoke(kubeconfig, binding.BindingID); err != nil {
+		log.Warnf("unable to revoke credentials: %s", err)
+	}
+}
+
+func (m *Manager) update(binding *internal.Binding, state domain.LastOperationState, description string, log logrus.FieldLogger) (time.Duration, error) {
+	binding.State = state
+	binding.Description = description
+	binding.UpdatedAt = time.Now()
+	if err := m.bindings.Update(*binding); err != nil {
+		log.Errorf("unable to update binding: %s", err)
+		return time.Second, nil
+	}
+	return 0, nil
+}
+
+func (m *Manager) delete(binding *internal.Binding, log logrus.FieldLogger) (time.Duration, error) {
+	if err := m.bindings.Delete(binding.BindingID); err != nil {
+		log.Errorf("unable to delete binding: %s", err)
+		return time.Second, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,170 @@
+package binding
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
+	provisionerAutomock "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner/automock"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fixInstanceID = "instance-id"
+
+func TestManager_Execute(t *testing.T) {
+	t.Run("should create credentials", func(t *testing.T) {
+		// given
+		db := fixStorage(t, internal.BindingOperationBind)
+		credentials := newFakeCredentials()
+		manager := NewManager(db.Bindings(), db.Instances(), fixProvisionerClient(), credentials, time.Minute, logrus.New())
+
+		// when
+		when, err := manager.Execute(fixBindingID)
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, when)
+		binding, err := db.Bindings().GetByID(fixBindingID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.Succeeded, binding.State)
+		assert.Equal(t, "kubeconfig-"+fixBindingID, binding.Kubeconfig)
+	})
+	t.Run("should retry when credentials cannot be created", func(t *testing.T) {
+		// given
+		db := fixStorage(t, internal.BindingOperationBind)
+		credentials := newFakeCredentials()
+		credentials.err = errors.New("connection refused")
+		manager := NewManager(db.Bindings(), db.Instances(), fixProvisionerClient(), credentials, time.Minute, logrus.New())
+
+		// when
+		when, err := manager.Execute(fixBindingID)
+
+		// then
+		require.NoError(t, err)
+		assert.NotZero(t, when)
+		binding, err := db.Bindings().GetByID(fixBindingID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.InProgress, binding.State)
+	})
+	t.Run("should fail bind after timeout", func(t *testing.T) {
+		// given
+		db := fixStorage(t, internal.BindingOperationBind)
+		credentials := newFakeCredentials()
+		credentials.err = errors.New("connection refused")
+		manager := NewManager(db.Bindings(), db.Instances(), fixProvisionerClient(), credentials, -time.Minute, logrus.New())
+
+		// when
+		when, err := manager.Execute(fixBindingID)
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, when)
+		binding, err := db.Bindings().GetByID(fixBindingID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.Failed, binding.State)
+	})
+	t.Run("should revoke credentials and remove binding", func(t *testing.T) {
+		// given
+		db := fixStorage(t, internal.BindingOperationUnbind)
+		credentials := newFakeCredentials()
+		manager := NewManager(db.Bindings(), db.Instances(), fixProvisionerClient(), credentials, time.Minute, logrus.New())
+
+		// when
+		when, err := manager.Execute(fixBindingID)
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, when)
+		assert.True(t, credentials.revoked[fixBindingID])
+		_, err = db.Bindings().GetByID(fixBindingID)
+		assert.True(t, dberr.IsNotFound(err))
+	})
+}
+
+func TestManager_RemoveBindings(t *testing.T) {
+	// given
+	db := fixStorage(t, internal.BindingOperationBind)
+	err := db.Bindings().Insert(internal.Binding{
+		BindingID:  "other-binding",
+		InstanceID: fixInstanceID,
+		Operation:  internal.BindingOperationBind,
+		State:      domain.Succeeded,
+	})
+	require.NoError(t, err)
+	credentials := newFakeCredentials()
+	manager := NewManager(db.Bindings(), db.Instances(), fixProvisionerClient(), credentials, time.Minute, logrus.New())
+	instance, err := db.Instances().GetByID(fixInstanceID)
+	require.NoError(t, err)
+
+	// when
+	err = manager.RemoveBindings(instance)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, credentials.revoked[fixBindingID])
+	assert.True(t, credentials.revoked["other-binding"])
+	bindings, err := db.Bindings().ListByInstanceID(fixInstanceID)
+	require.NoError(t, err)
+	assert.Empty(t, bindings)
+}
+
+func fixStorage(t *testing.T, operation string) storage.BrokerStorage {
+	db := storage.NewMemoryStorage()
+	err := db.Instances().Insert(fixture.FixInstance(fixInstanceID))
+	require.NoError(t, err)
+	err = db.Bindings().Insert(internal.Binding{
+		BindingID:  fixBindingID,
+		InstanceID: fixInstanceID,
+		Operation:  operation,
+		State:      domain.InProgress,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	})
+	require.NoError(t, err)
+	return db
+}
+
+func fixProvisionerClient() *provisionerAutomock.Client {
+	client := &provisionerAutomock.Client{}
+	client.On("RuntimeStatus", fixture.FixInstance(fixInstanceID).GlobalAccountID, fixture.FixInstance(fixInstanceID).RuntimeID).
+		Return(gqlschema.RuntimeStatus{
+			RuntimeConfiguration: &gqlschema.RuntimeConfig{
+				Kubeconfig: ptr.String(fixAdminKubeconfig),
+			},
+		}, nil)
+	return client
+}
+
+type fakeCredentials struct {
+	err     error
+	revoked map[string]bool
+}
+
+func newFakeCredentials() *fakeCredentials {
+	return &fakeCredentials{revoked: map[string]bool{}}
+}
+
+func (c *fakeCredentials) Create(_, bindingID string) (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+	return "kubeconfig-" + bindingID, nil
+}
+
+func (c *fakeCredentials) Revoke(_, bindingID string) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.revoked[bindingID] = true
+	return nil
+}
@@ -2,27 +2,138 @@ package broker
 
 import (
 	"context"
-	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 
 	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pivotal-cf/brokerapi/v7/domain/apiresponses"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
 type BindEndpoint struct {
 	log logrus.FieldLogger
+
+	instancesStorage  storage.Instances
+	operationsStorage storage.Provisioning
+	bindingsStorage   storage.Bindings
+
+	queue Queue
 }
 
-func NewBind(log logrus.FieldLogger) *BindEndpoint {
-	return &BindEndpoint{log: log.WithField("service", "BindEndpoint")}
+func NewBind(instancesStorage storage.Instances, operationsStorage storage.Operations, bindingsStorage storage.Bindings, q Queue, log logrus.FieldLogger) *BindEndpoint {
+	return &BindEndpoint{
+		log:               log.WithField("service", "BindEndpoint"),
+		instancesStorage:  instancesStorage,
+		operationsStorage: operationsStorage,
+		bindingsStorage:   bindingsStorage,
+
+		queue: q,
+	}
 }
 
 // Bind creates a new service binding
 //   PUT /v2/service_instances/{instance_id}/service_bindings/{binding_id}
 func (b *BindEndpoint) Bind(ctx context.Context, instanceID, bindingID string, details domain.BindDetails, asyncAllowed bool) (domain.Binding, error) {
-	b.log.Infof("Bind instanceID:", instanceID)
-	b.log.Infof("Bind parameters: %s", string(details.RawParameters))
-	b.log.Infof("Bind context: %s", string(details.RawContext))
-	b.log.Infof("Bind asyncAllowed:", asyncAllowed)
+	logger := b.log.WithFields(logrus.Fields{"instanceID": instanceID, "bindingID": bindingID})
+	logger.Infof("Bind triggered, asyncAllowed: %v", asyncAllowed)
+
+	// credentials are issued on the runtime, which always takes some time
+	if !asyncAllowed {
+		return domain.Binding{}, apiresponses.ErrAsyncRequired
+	}
+
+	instance, err := b.instancesStorage.GetByID(instanceID)
+	switch {
+	case err == nil:
+	case dberr.IsNotFound(err):
+		return domain.Binding{}, apiresponses.ErrInstanceDoesNotExist
+	default:
+		logger.Errorf("unable to get instance from the storage: %s", err)
+		return domain.Binding{}, errors.New("unable to get instance from the storage")
+	}
+
+	provisioning, err := b.operationsStorage.GetProvisioningOperationByInstanceID(instance.InstanceID)
+	if err != nil {
+		logger.Errorf("unable to get provisioning operation from the storage: %s", err)
+		return domain.Binding{}, errors.New("unable to get provisioning operation from the storage")
+	}
+	if provisioning.State != domain.Succeeded || instance.RuntimeID == "" {
+		return domain.Binding{}, apiresponses.NewFailureResponse(fmt.Errorf("instance %s is not provisioned", instanceID), http.StatusUnprocessableEntity, "binding")
+	}
+
+	existing, err := b.bindingsStorage.GetByID(bindingID)
+	switch {
+	case err == nil:
+		return b.existingBinding(existing, instanceID, logger)
+	case dberr.IsNotFound(err):
+	default:
+		logger.Errorf("unable to get binding from the storage: %s", err)
+		return domain.Binding{}, errors.New("unable to get binding from the storage")
+	}
+
+	now := time.Now()
+	binding := internal.Binding{
+		BindingID:   bindingID,
+		InstanceID:  instanceID,
+		Operation:   internal.BindingOperationBind,
+		State:       domain.InProgress,
+		Description: "binding created",
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := b.bindingsStorage.Insert(binding); err != nil {
+		logger.Errorf("unable to save binding: %s", err)
+		return domain.Binding{}, errors.New("unable to save binding")
+	}
+	b.queue.Add(bindingID)
+	logger.Info("Binding queued")
+
+	return domain.Binding{
+		IsAsync:       true,
+		OperationData: internal.BindingOperationBind,
+	}, nil
+}
+
+func (b *BindEndpoint) existingBinding(binding *internal.Binding, instanceID string, logger logrus.FieldLogger) (domain.Binding, error) {
+	if binding.InstanceID != instanceID {
+		return domain.Binding{}, apiresponses.ErrBindingAlreadyExists
+	}
+	if binding.Operation == internal.BindingOperationUnbind {
+		return domain.Binding{}, apiresponses.NewFailureResponse(fmt.Errorf("binding %s is being deleted", binding.BindingID), http.StatusUnprocessableEntity, "binding")
+	}
+
+	switch binding.State {
+	case domain.Succeeded:
+		return domain.Binding{
+			AlreadyExists: true,
+			Credentials:   bindingCredentials(binding),
+		}, nil
+	case domain.Failed:
+		binding.State = domain.InProgress
+		binding.Description = "binding reprocessed"
+		binding.UpdatedAt = time.Now()
+		if err := b.bindingsStorage.Update(*binding); err != nil {
+			logger.Errorf("unable to update binding: %s", err)
+			return domain.Binding{}, errors.New("unable to update binding")
+		}
+		b.queue.Add(binding.BindingID)
+		logger.Info("Reprocessing failed binding")
+	}
+
+	return domain.Binding{
+		IsAsync:       true,
+		OperationData: internal.BindingOperationBind,
+	}, nil
+}
 
-	return domain.Binding{}, errors.New("not supported")
+func bindingCredentials(binding *internal.Binding) map[string]interface{} {
+	return map[string]interface{}{
+		"kubeconfig": binding.Kubeconfig,
+	}
 }
@@ -0,0 +1,177 @@
+package broker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker/automock"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pivotal-cf/brokerapi/v7/domain/apiresponses"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const bindingID = "binding-001"
+
+func TestBindEndpoint_Bind(t *testing.T) {
+	t.Run("should queue new binding", func(t *testing.T) {
+		// given
+		memoryStorage := fixBindingStorage(t, domain.Succeeded)
+		queue := &automock.Queue{}
+		queue.On("Add", bindingID).Once()
+
+		svc := NewBind(memoryStorage.Instances(), memoryStorage.Operations(), memoryStorage.Bindings(), queue, logrus.StandardLogger())
+
+		// when
+		resp, err := svc.Bind(context.TODO(), instanceID, bindingID, domain.BindDetails{}, true)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, resp.IsAsync)
+		assert.Equal(t, internal.BindingOperationBind, resp.OperationData)
+		binding, err := memoryStorage.Bindings().GetByID(bindingID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.InProgress, binding.State)
+		queue.AssertExpectations(t)
+	})
+	t.Run("should require asynchronous operation", func(t *testing.T) {
+		// given
+		memoryStorage := fixBindingStorage(t, domain.Succeeded)
+		svc := NewBind(memoryStorage.Instances(), memoryStorage.Operations(), memoryStorage.Bindings(), &automock.Queue{}, logrus.StandardLogger())
+
+		// when
+		_, err := svc.Bind(context.TODO(), instanceID, bindingID, domain.BindDetails{}, false)
+
+		// then
+		assert.Equal(t, apiresponses.ErrAsyncRequired, err)
+	})
+	t.Run("should return error when instance does not exist", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		svc := NewBind(memoryStorage.Instances(), memoryStorage.Operations(), memoryStorage.Bindings(), &automock.Queue{}, logrus.StandardLogger())
+
+		// when
+		_, err := svc.Bind(context.TODO(), instanceID, bindingID, domain.BindDetails{}, true)
+
+		// then
+		assert.Equal(t, apiresponses.ErrInstanceDoesNotExist, err)
+	})
+	t.Run("should return error when instance is not provisioned", func(t *testing.T) {
+		// given
+		memoryStorage := fixBindingStorage(t, domain.InProgress)
+		svc := NewBind(memoryStorage.Instances(), memoryStorage.Operations(), memoryStorage.Bindings(), &automock.Queue{}, logrus.StandardLogger())
+
+		// when
+		_, err := svc.Bind(context.TODO(), instanceID, bindingID, domain.BindDetails{}, true)
+
+		// then
+		assert.Error(t, err)
+	})
+	t.Run("should return existing binding", func(t *testing.T) {
+		// given
+		memoryStorage := fixBindingStorage(t, domain.Succeeded)
+		err := memoryStorage.Bindings().Insert(fixBinding(internal.BindingOperationBind, domain.Succeeded))
+		require.NoError(t, err)
+		svc := NewBind(memoryStorage.Instances(), memoryStorage.Operations(), memoryStorage.Bindings(), &automock.Queue{}, logrus.StandardLogger())
+
+		// when
+		resp, err := svc.Bind(context.TODO(), instanceID, bindingID, domain.BindDetails{}, true)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, resp.AlreadyExists)
+		assert.Equal(t, map[string]interface{}{"kubeconfig": "kubeconfig"}, resp.Credentials)
+	})
+	t.Run("should return conflict when binding exists for another instance", func(t *testing.T) {
+		// given
+		memoryStorage := fixBindingStorage(t, domain.Succeeded)
+		binding := fixBinding(internal.BindingOperationBind, domain.Succeeded)
+		binding.InstanceID = "other-instance"
+		err := memoryStorage.Bindings().Insert(binding)
+		require.NoError(t, err)
+		svc := NewBind(memoryStorage.Instances(), memoryStorage.Operations(), memoryStorage.Bindings(), &automock.Queue{}, logrus.StandardLogger())
+
+		// when
+		_, err = svc.Bind(context.TODO(), instanceID, bindingID, domain.BindDetails{}, true)
+
+		// then
+		assert.Equal(t, apiresponses.ErrBindingAlreadyExists, err)
+	})
+	t.Run("should reprocess failed binding", func(t *testing.T) {
+		// given
+		memoryStorage := fixBindingStorage(t, domain.Succeeded)
+		err := memoryStorage.Bindings().Insert(fixBinding(internal.BindingOperationBind, domain.Failed))
+		require.NoError(t, err)
+		queue := &automock.Queue{}
+		queue.On("Add", bindingID).Once()
+		svc := NewBind(memoryStorage.Instances(), memoryStorage.Operations(), memoryStorage.Bindings(), queue, logrus.StandardLogger())
+
+		// when
+		resp, err := svc.Bind(context.TODO(), instanceID, bindingID, domain.BindDetails{}, true)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, resp.IsAsync)
+		binding, err := memoryStorage.Bindings().GetByID(bindingID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.InProgress, binding.State)
+		queue.AssertExpectations(t)
+	})
+}
+
+func TestGetBindingEndpoint_GetBinding(t *testing.T) {
+	for name, tc := range map[string]struct {
+		operation string
+		state     domain.LastOperationState
+		found     bool
+	}{
+		"succeeded bind":   {operation: internal.BindingOperationBind, state: domain.Succeeded, found: true},
+		"in progress bind": {operation: internal.BindingOperationBind, state: domain.InProgress},
+		"unbind":           {operation: internal.BindingOperationUnbind, state: domain.InProgress},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			memoryStorage := storage.NewMemoryStorage()
+			err := memoryStorage.Bindings().Insert(fixBinding(tc.operation, tc.state))
+			require.NoError(t, err)
+			svc := NewGetBinding(memoryStorage.Bindings(), logrus.StandardLogger())
+
+			// when
+			resp, err := svc.GetBinding(context.TODO(), instanceID, bindingID)
+
+			// then
+			if tc.found {
+				require.NoError(t, err)
+				assert.Equal(t, map[string]interface{}{"kubeconfig": "kubeconfig"}, resp.Credentials)
+			} else {
+				assert.Equal(t, apiresponses.ErrBindingNotFound, err)
+			}
+		})
+	}
+}
+
+func fixBindingStorage(t *testing.T, provisioningState domain.LastOperationState) storage.BrokerStorage {
+	memoryStorage := storage.NewMemoryStorage()
+	err := memoryStorage.Instances().Insert(fixInstance())
+	require.NoError(t, err)
+	provisioning := fixture.FixProvisioningOperation(operationID, instanceID)
+	provisioning.State = provisioningState
+	err = memoryStorage.Operations().InsertProvisioningOperation(provisioning)
+	require.NoError(t, err)
+	return memoryStorage
+}
+
+func fixBinding(operation string, state domain.LastOperationState) internal.Binding {
+	return internal.Binding{
+		BindingID:  bindingID,
+		InstanceID: instanceID,
+		Operation:  operation,
+		State:      state,
+		Kubeconfig: "kubeconfig",
+	}
+}
@@ -2,26 +2,71 @@ package broker
 
 import (
 	"context"
-	"errors"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 
 	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pivotal-cf/brokerapi/v7/domain/apiresponses"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
 type UnbindEndpoint struct {
 	log logrus.FieldLogger
+
+	bindingsStorage storage.Bindings
+
+	queue Queue
 }
 
-func NewUnbind(log logrus.FieldLogger) *UnbindEndpoint {
-	return &UnbindEndpoint{log: log.WithField("service", "UnbindEndpoint")}
+func NewUnbind(bindingsStorage storage.Bindings, q Queue, log logrus.FieldLogger) *UnbindEndpoint {
+	return &UnbindEndpoint{
+		log:             log.WithField("service", "UnbindEndpoint"),
+		bindingsStorage: bindingsStorage,
+
+		queue: q,
+	}
 }
 
 // Unbind deletes an existing service binding
 //   DELETE /v2/service_instances/{instance_id}/service_bindings/{binding_id}
 func (b *UnbindEndpoint) Unbind(ctx context.Context, instanceID, bindingID string, details domain.UnbindDetails, asyncAllowed bool) (domain.UnbindSpec, error) {
-	b.log.Infof("Unbind instanceID: %s", instanceID)
-	b.log.Infof("Unbind details: %+v", details)
-	b.log.Infof("Unbind asyncAllowed: %v", asyncAllowed)
+	logger := b.log.WithFields(logrus.Fields{"instanceID": instanceID, "bindingID": bindingID})
+	logger.Infof("Unbind triggered, asyncAllowed: %v", asyncAllowed)
+
+	// credentials are revoked on the runtime, which always takes some time
+	if !asyncAllowed {
+		return domain.UnbindSpec{}, apiresponses.ErrAsyncRequired
+	}
+
+	binding, err := b.bindingsStorage.GetByID(bindingID)
+	switch {
+	case err == nil && binding.InstanceID == instanceID:
+	case err == nil || dberr.IsNotFound(err):
+		return domain.UnbindSpec{}, apiresponses.ErrBindingDoesNotExist
+	default:
+		logger.Errorf("unable to get binding from the storage: %s", err)
+		return domain.UnbindSpec{}, errors.New("unable to get binding from the storage")
+	}
+
+	if binding.Operation != internal.BindingOperationUnbind || binding.State != domain.InProgress {
+		binding.Operation = internal.BindingOperationUnbind
+		binding.State = domain.InProgress
+		binding.Description = "binding deletion triggered"
+		binding.UpdatedAt = time.Now()
+		if err := b.bindingsStorage.Update(*binding); err != nil {
+			logger.Errorf("unable to update binding: %s", err)
+			return domain.UnbindSpec{}, errors.New("unable to update binding")
+		}
+		b.queue.Add(bindingID)
+		logger.Info("Binding deletion queued")
+	}
 
-	return domain.UnbindSpec{}, errors.New("not supported")
+	return domain.UnbindSpec{
+		IsAsync:       true,
+		OperationData: internal.BindingOperationUnbind,
+	}, nil
 }
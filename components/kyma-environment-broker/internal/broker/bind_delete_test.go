@@ -0,0 +1,79 @@
+package broker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker/automock"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pivotal-cf/brokerapi/v7/domain/apiresponses"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnbindEndpoint_Unbind(t *testing.T) {
+	t.Run("should queue binding deletion", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		err := memoryStorage.Bindings().Insert(fixBinding(internal.BindingOperationBind, domain.Succeeded))
+		require.NoError(t, err)
+		queue := &automock.Queue{}
+		queue.On("Add", bindingID).Once()
+		svc := NewUnbind(memoryStorage.Bindings(), queue, logrus.StandardLogger())
+
+		// when
+		resp, err := svc.Unbind(context.TODO(), instanceID, bindingID, domain.UnbindDetails{}, true)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, resp.IsAsync)
+		binding, err := memoryStorage.Bindings().GetByID(bindingID)
+		require.NoError(t, err)
+		assert.Equal(t, internal.BindingOperationUnbind, binding.Operation)
+		assert.Equal(t, domain.InProgress, binding.State)
+		queue.AssertExpectations(t)
+	})
+	t.Run("should return gone when binding does not exist", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		svc := NewUnbind(memoryStorage.Bindings(), &automock.Queue{}, logrus.StandardLogger())
+
+		// when
+		_, err := svc.Unbind(context.TODO(), instanceID, bindingID, domain.UnbindDetails{}, true)
+
+		// then
+		assert.Equal(t, apiresponses.ErrBindingDoesNotExist, err)
+	})
+}
+
+func TestLastBindingOperationEndpoint_LastBindingOperation(t *testing.T) {
+	t.Run("should return state of the binding", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		err := memoryStorage.Bindings().Insert(fixBinding(internal.BindingOperationUnbind, domain.InProgress))
+		require.NoError(t, err)
+		svc := NewLastBindingOperation(memoryStorage.Bindings(), logrus.StandardLogger())
+
+		// when
+		resp, err := svc.LastBindingOperation(context.TODO(), instanceID, bindingID, domain.PollDetails{})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, domain.InProgress, resp.State)
+	})
+	t.Run("should return gone when binding was removed", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		svc := NewLastBindingOperation(memoryStorage.Bindings(), logrus.StandardLogger())
+
+		// when
+		_, err := svc.LastBindingOperation(context.TODO(), instanceID, bindingID, domain.PollDetails{})
+
+		// then
+		assert.Equal(t, apiresponses.ErrBindingDoesNotExist, err)
+	})
+}
@@ -2,25 +2,51 @@ package broker
 
 import (
 	"context"
-	"errors"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 
 	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pivotal-cf/brokerapi/v7/domain/apiresponses"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
 type GetBindingEndpoint struct {
 	log logrus.FieldLogger
+
+	bindingsStorage storage.Bindings
 }
 
-func NewGetBinding(log logrus.FieldLogger) *GetBindingEndpoint {
-	return &GetBindingEndpoint{log: log.WithField("service", "GetBindingEndpoint")}
+func NewGetBinding(bindingsStorage storage.Bindings, log logrus.FieldLogger) *GetBindingEndpoint {
+	return &GetBindingEndpoint{
+		log:             log.WithField("service", "GetBindingEndpoint"),
+		bindingsStorage: bindingsStorage,
+	}
 }
 
 // GetBinding fetches an existing service binding
 //   GET /v2/service_instances/{instance_id}/service_bindings/{binding_id}
 func (b *GetBindingEndpoint) GetBinding(ctx context.Context, instanceID, bindingID string) (domain.GetBindingSpec, error) {
-	b.log.Infof("GetBinding instanceID: %s", instanceID)
-	b.log.Infof("GetBinding bindingID: %s", bindingID)
+	logger := b.log.WithFields(logrus.Fields{"instanceID": instanceID, "bindingID": bindingID})
+
+	binding, err := b.bindingsStorage.GetByID(bindingID)
+	switch {
+	case err == nil && binding.InstanceID == instanceID:
+	case err == nil || dberr.IsNotFound(err):
+		return domain.GetBindingSpec{}, apiresponses.ErrBindingNotFound
+	default:
+		logger.Errorf("unable to get binding from the storage: %s", err)
+		return domain.GetBindingSpec{}, errors.New("unable to get binding from the storage")
+	}
+
+	// the binding is not available until the bind operation succeeds
+	if binding.Operation != internal.BindingOperationBind || binding.State != domain.Succeeded {
+		return domain.GetBindingSpec{}, apiresponses.ErrBindingNotFound
+	}
 
-	return domain.GetBindingSpec{}, errors.New("not supported")
+	return domain.GetBindingSpec{
+		Credentials: bindingCredentials(binding),
+	}, nil
 }
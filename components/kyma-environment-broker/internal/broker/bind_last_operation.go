@@ -2,26 +2,47 @@ package broker
 
 import (
 	"context"
-	"errors"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 
 	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pivotal-cf/brokerapi/v7/domain/apiresponses"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
 type LastBindingOperationEndpoint struct {
 	log logrus.FieldLogger
+
+	bindingsStorage storage.Bindings
 }
 
-func NewLastBindingOperation(log logrus.FieldLogger) *LastBindingOperationEndpoint {
-	return &LastBindingOperationEndpoint{log: log.WithField("service", "LastBindingOperationEndpoint")}
+func NewLastBindingOperation(bindingsStorage storage.Bindings, log logrus.FieldLogger) *LastBindingOperationEndpoint {
+	return &LastBindingOperationEndpoint{
+		log:             log.WithField("service", "LastBindingOperationEndpoint"),
+		bindingsStorage: bindingsStorage,
+	}
 }
 
 // LastBindingOperation fetches last operation state for a service binding
 //   GET /v2/service_instances/{instance_id}/service_bindings/{binding_id}/last_operation
 func (b *LastBindingOperationEndpoint) LastBindingOperation(ctx context.Context, instanceID, bindingID string, details domain.PollDetails) (domain.LastOperation, error) {
-	b.log.Infof("LastBindingOperation instanceID: %s", instanceID)
-	b.log.Infof("LastBindingOperation bindingID: %s", bindingID)
-	b.log.Infof("LastBindingOperation details: %+v", details)
+	logger := b.log.WithFields(logrus.Fields{"instanceID": instanceID, "bindingID": bindingID})
+
+	binding, err := b.bindingsStorage.GetByID(bindingID)
+	switch {
+	case err == nil && binding.InstanceID == instanceID:
+	case err == nil || dberr.IsNotFound(err):
+		// the binding is removed when the unbind operation succeeds
+		return domain.LastOperation{}, apiresponses.ErrBindingDoesNotExist
+	default:
+		logger.Errorf("unable to get binding from the storage: %s", err)
+		return domain.LastOperation{}, errors.New("unable to get binding from the storage")
+	}
 
-	return domain.LastOperation{}, errors.New("not supported")
+	return domain.LastOperation{
+		State:       binding.State,
+		Description: binding.Description,
+	}, nil
 }
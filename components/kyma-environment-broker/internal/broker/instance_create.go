@@ -23,6 +23,7 @@ import (
 
 //go:generate mockery -name=Queue -output=automock -outpkg=automock -case=underscore
 //go:generate mockery -name=PlanValidator -output=automock -outpkg=automock -case=underscore
+//go:generate mockery -name=QuotaChecker -output=automock -outpkg=automock -case=underscore
 
 type (
 	Queue interface {
@@ -32,6 +33,12 @@ type (
 	PlanValidator interface {
 		IsPlanSupport(planID string) bool
 	}
+
+	// QuotaChecker returns an error which has the QuotaExceeded method returning true
+	// if the global account with the given instances cannot create another instance of the plan
+	QuotaChecker interface {
+		CheckQuota(globalAccountID, planName string, instances []internal.Instance) error
+	}
 )
 
 type ProvisionEndpoint struct {
@@ -41,7 +48,8 @@ type ProvisionEndpoint struct {
 	builderFactory       PlanValidator
 	enabledPlanIDs       map[string]struct{}
 	onlySingleTrialPerGA bool
-	plansSchemaValidator PlansSchemaValidator
+	plansSchemaValidator RegionalPlansSchemaValidator
+	quotaChecker         QuotaChecker
 	kymaVerOnDemand      bool
 
 	shootDomain  string
@@ -56,7 +64,8 @@ func NewProvision(cfg Config,
 	instanceStorage storage.Instances,
 	queue Queue,
 	builderFactory PlanValidator,
-	validator PlansSchemaValidator,
+	validator RegionalPlansSchemaValidator,
+	quotaChecker QuotaChecker,
 	kvod bool,
 	log logrus.FieldLogger) *ProvisionEndpoint {
 	enabledPlanIDs := map[string]struct{}{}
@@ -67,6 +76,7 @@ func NewProvision(cfg Config,
 
 	return &ProvisionEndpoint{
 		plansSchemaValidator: validator,
+		quotaChecker:         quotaChecker,
 		operationsStorage:    operationsStorage,
 		instanceStorage:      instanceStorage,
 		queue:                queue,
@@ -86,14 +96,15 @@ func (b *ProvisionEndpoint) Provision(ctx context.Context, instanceID string, de
 	operationID := uuid.New().String()
 	logger := b.log.WithFields(logrus.Fields{"instanceID": instanceID, "operationID": operationID, "planID": details.PlanID})
 	logger.Info("Provision called")
+	region, found := middleware.RegionFromContext(ctx)
+
 	// validation of incoming input
-	ersContext, parameters, err := b.validateAndExtract(details, logger)
+	ersContext, parameters, err := b.validateAndExtract(details, region, logger)
 	if err != nil {
 		errMsg := fmt.Sprintf("[instanceID: %s] %s", instanceID, err)
 		return domain.ProvisionedServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, errMsg)
 	}
 
-	region, found := middleware.RegionFromContext(ctx)
 	if !found {
 		err := errors.New("No region specified in request.")
 		return domain.ProvisionedServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusInternalServerError, "provisioning")
@@ -133,13 +144,8 @@ func (b *ProvisionEndpoint) Provision(ctx context.Context, instanceID string, de
 	operation.ShootName = shootName
 	operation.ShootDomain = fmt.Sprintf("%s.%s.%s", shootName, b.shootProject, strings.Trim(b.shootDomain, "."))
 
-	err = b.operationsStorage.InsertProvisioningOperation(operation)
-	if err != nil {
-		logger.Errorf("cannot save operation: %s", err)
-		return domain.ProvisionedServiceSpec{}, errors.New("cannot save operation")
-	}
-
-	err = b.instanceStorage.Insert(internal.Instance{
+	// the quota of the global account is checked when the instance is saved, so that the concurrent requests cannot exceed it
+	err = b.instanceStorage.InsertChecked(internal.Instance{
 		InstanceID:      instanceID,
 		GlobalAccountID: ersContext.GlobalAccountID,
 		SubAccountID:    ersContext.SubAccountID,
@@ -149,12 +155,28 @@ func (b *ProvisionEndpoint) Provision(ctx context.Context, instanceID string, de
 		ServicePlanName: Plans[provisioningParameters.PlanID].PlanDefinition.Name,
 		DashboardURL:    dashboardURL,
 		Parameters:      operation.ProvisioningParameters,
+	}, func(instances []internal.Instance) error {
+		return b.quotaChecker.CheckQuota(ersContext.GlobalAccountID, PlanNamesMapping[details.PlanID], instances)
 	})
-	if err != nil {
+	switch {
+	case isQuotaExceeded(err):
+		return domain.ProvisionedServiceSpec{}, apiresponses.NewFailureResponseBuilder(err, http.StatusUnprocessableEntity, "provisioning").
+			WithErrorKey("QuotaExceeded").Build()
+	case err != nil:
 		logger.Errorf("cannot save instance in storage: %s", err)
 		return domain.ProvisionedServiceSpec{}, errors.New("cannot save instance")
 	}
 
+	err = b.operationsStorage.InsertProvisioningOperation(operation)
+	if err != nil {
+		logger.Errorf("cannot save operation: %s", err)
+		// the instance without the operation would count to the quota and reject the retries of the request
+		if err := b.instanceStorage.Delete(instanceID); err != nil {
+			logger.Errorf("cannot remove the instance without the operation: %s", err)
+		}
+		return domain.ProvisionedServiceSpec{}, errors.New("cannot save operation")
+	}
+
 	logger.Info("Adding operation to provisioning queue")
 	b.queue.Add(operation.ID)
 
@@ -168,7 +190,7 @@ func (b *ProvisionEndpoint) Provision(ctx context.Context, instanceID string, de
 	}, nil
 }
 
-func (b *ProvisionEndpoint) validateAndExtract(details domain.ProvisionDetails, l logrus.FieldLogger) (internal.ERSContext, internal.ProvisioningParametersDTO, error) {
+func (b *ProvisionEndpoint) validateAndExtract(details domain.ProvisionDetails, platformRegion string, l logrus.FieldLogger) (internal.ERSContext, internal.ProvisioningParametersDTO, error) {
 	var ersContext internal.ERSContext
 	var parameters internal.ProvisioningParametersDTO
 
@@ -179,7 +201,12 @@ func (b *ProvisionEndpoint) validateAndExtract(details domain.ProvisionDetails,
 		return ersContext, parameters, errors.Errorf("plan ID %q is not recognized", details.PlanID)
 	}
 
-	result, err := b.plansSchemaValidator[details.PlanID].ValidateString(string(details.RawParameters))
+	validator, available := b.plansSchemaValidator.ForRegion(platformRegion)[details.PlanID]
+	if !available {
+		return ersContext, parameters, errors.Errorf("plan %s is not available in the region %s", PlanNamesMapping[details.PlanID], platformRegion)
+	}
+
+	result, err := validator.ValidateString(string(details.RawParameters))
 	if err != nil {
 		return ersContext, parameters, errors.Wrap(err, "while executing JSON schema validator")
 	}
@@ -281,3 +308,11 @@ func (b *ProvisionEndpoint) responseLabels(parameters internal.ProvisioningParam
 
 	return responseLabels
 }
+
+func isQuotaExceeded(err error) bool {
+	cause := errors.Cause(err)
+	qe, ok := cause.(interface {
+		QuotaExceeded() bool
+	})
+	return ok && qe.QuotaExceeded()
+}
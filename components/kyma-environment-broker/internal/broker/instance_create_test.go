@@ -16,10 +16,12 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker/automock"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/middleware"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/quota"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 
 	"github.com/kyma-incubator/compass/components/director/pkg/jsonschema"
 	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pivotal-cf/brokerapi/v7/domain/apiresponses"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -60,6 +62,7 @@ func TestProvision_Provision(t *testing.T) {
 			queue,
 			factoryBuilder,
 			fixAlwaysPassJSONValidator(),
+			fixAlwaysPassQuotaChecker(),
 			false,
 			logrus.StandardLogger(),
 		)
@@ -115,6 +118,7 @@ func TestProvision_Provision(t *testing.T) {
 			nil,
 			factoryBuilder,
 			fixAlwaysPassJSONValidator(),
+			fixAlwaysPassQuotaChecker(),
 			false,
 			logrus.StandardLogger(),
 		)
@@ -157,6 +161,7 @@ func TestProvision_Provision(t *testing.T) {
 			nil,
 			factoryBuilder,
 			fixAlwaysPassJSONValidator(),
+			fixAlwaysPassQuotaChecker(),
 			false,
 			logrus.StandardLogger(),
 		)
@@ -200,6 +205,7 @@ func TestProvision_Provision(t *testing.T) {
 			queue,
 			factoryBuilder,
 			fixAlwaysPassJSONValidator(),
+			fixAlwaysPassQuotaChecker(),
 			false,
 			logrus.StandardLogger(),
 		)
@@ -256,6 +262,7 @@ func TestProvision_Provision(t *testing.T) {
 			queue,
 			factoryBuilder,
 			fixAlwaysPassJSONValidator(),
+			fixAlwaysPassQuotaChecker(),
 			false,
 			logrus.StandardLogger(),
 		)
@@ -309,6 +316,7 @@ func TestProvision_Provision(t *testing.T) {
 			nil,
 			factoryBuilder,
 			fixAlwaysPassJSONValidator(),
+			fixAlwaysPassQuotaChecker(),
 			false,
 			logrus.StandardLogger(),
 		)
@@ -333,7 +341,7 @@ func TestProvision_Provision(t *testing.T) {
 		factoryBuilder := &automock.PlanValidator{}
 		factoryBuilder.On("IsPlanSupport", planID).Return(true)
 
-		fixValidator, err := broker.NewPlansSchemaValidator()
+		fixValidator, err := broker.NewPlansSchemaValidator(nil)
 		require.NoError(t, err)
 
 		queue := &automock.Queue{}
@@ -347,6 +355,7 @@ func TestProvision_Provision(t *testing.T) {
 			queue,
 			factoryBuilder,
 			fixValidator,
+			fixAlwaysPassQuotaChecker(),
 			true,
 			logrus.StandardLogger(),
 		)
@@ -375,7 +384,7 @@ func TestProvision_Provision(t *testing.T) {
 		factoryBuilder := &automock.PlanValidator{}
 		factoryBuilder.On("IsPlanSupport", planID).Return(true)
 
-		fixValidator, err := broker.NewPlansSchemaValidator()
+		fixValidator, err := broker.NewPlansSchemaValidator(nil)
 		require.NoError(t, err)
 
 		provisionEndpoint := broker.NewProvision(
@@ -386,6 +395,7 @@ func TestProvision_Provision(t *testing.T) {
 			nil,
 			factoryBuilder,
 			fixValidator,
+			fixAlwaysPassQuotaChecker(),
 			true,
 			logrus.StandardLogger(),
 		)
@@ -402,6 +412,144 @@ func TestProvision_Provision(t *testing.T) {
 		require.EqualError(t, provisionErr, "No region specified in request.")
 	})
 
+	t.Run("should return error when plan is not available in the region", func(t *testing.T) {
+		// given
+		factoryBuilder := &automock.PlanValidator{}
+		factoryBuilder.On("IsPlanSupport", planID).Return(true)
+
+		fixValidator, err := broker.NewPlansSchemaValidator(broker.PlansConfig{
+			"cf-us10": {"aws": {}},
+		})
+		require.NoError(t, err)
+
+		provisionEndpoint := broker.NewProvision(
+			broker.Config{EnablePlans: []string{"gcp", "azure", "aws"}, OnlySingleTrialPerGA: true},
+			gardener.Config{Project: "test", ShootDomain: "example.com"},
+			nil,
+			nil,
+			nil,
+			factoryBuilder,
+			fixValidator,
+			fixAlwaysPassQuotaChecker(),
+			true,
+			logrus.StandardLogger(),
+		)
+
+		// when
+		_, provisionErr := provisionEndpoint.Provision(fixReqCtxWithRegion(t, "cf-us10"), instanceID, domain.ProvisionDetails{
+			ServiceID:     serviceID,
+			PlanID:        planID,
+			RawParameters: json.RawMessage(fmt.Sprintf(`{"name": "%s"}`, clusterName)),
+			RawContext:    json.RawMessage(fmt.Sprintf(`{"globalaccount_id": "%s", "subaccount_id": "%s"}`, globalAccountID, subAccountID)),
+		}, true)
+
+		// then
+		require.Error(t, provisionErr)
+		apiErr, ok := provisionErr.(*apiresponses.FailureResponse)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, apiErr.ValidatedStatusCode(nil))
+		assert.Contains(t, provisionErr.Error(), "plan azure is not available in the region cf-us10")
+	})
+
+	t.Run("should return error when quota of the global account is exceeded", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		err := memoryStorage.Instances().Insert(fixInstance())
+		require.NoError(t, err)
+
+		factoryBuilder := &automock.PlanValidator{}
+		factoryBuilder.On("IsPlanSupport", planID).Return(true)
+
+		quotaService := quota.NewService(memoryStorage.Instances(), quota.Config{
+			Default: quota.Quota{Plans: map[string]int{broker.AzurePlanName: 1}},
+		}, logrus.StandardLogger())
+
+		provisionEndpoint := broker.NewProvision(
+			broker.Config{EnablePlans: []string{"gcp", "azure"}, OnlySingleTrialPerGA: true},
+			gardener.Config{Project: "test", ShootDomain: "example.com"},
+			memoryStorage.Operations(),
+			memoryStorage.Instances(),
+			nil,
+			factoryBuilder,
+			fixAlwaysPassJSONValidator(),
+			quotaService,
+			false,
+			logrus.StandardLogger(),
+		)
+
+		// when
+		_, provisionErr := provisionEndpoint.Provision(fixReqCtxWithRegion(t, "req-region"), otherInstanceID, domain.ProvisionDetails{
+			ServiceID:     serviceID,
+			PlanID:        planID,
+			RawParameters: json.RawMessage(fmt.Sprintf(`{"name": "%s"}`, clusterName)),
+			RawContext:    json.RawMessage(fmt.Sprintf(`{"globalaccount_id": "%s", "subaccount_id": "%s"}`, globalAccountID, subAccountID)),
+		}, true)
+
+		// then
+		require.Error(t, provisionErr)
+		apiErr, ok := provisionErr.(*apiresponses.FailureResponse)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, apiErr.ValidatedStatusCode(nil))
+		assert.Contains(t, provisionErr.Error(), "reached the quota of 1 instances of the plan azure")
+		_, err = memoryStorage.Instances().GetByID(otherInstanceID)
+		assert.Error(t, err)
+	})
+
+	t.Run("should not exceed quota of the global account by concurrent requests", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+
+		factoryBuilder := &automock.PlanValidator{}
+		factoryBuilder.On("IsPlanSupport", planID).Return(true)
+
+		queue := &automock.Queue{}
+		queue.On("Add", mock.AnythingOfType("string"))
+
+		quotaService := quota.NewService(memoryStorage.Instances(), quota.Config{
+			Default: quota.Quota{Plans: map[string]int{broker.AzurePlanName: 1}},
+		}, logrus.StandardLogger())
+
+		provisionEndpoint := broker.NewProvision(
+			broker.Config{EnablePlans: []string{"gcp", "azure"}, OnlySingleTrialPerGA: true},
+			gardener.Config{Project: "test", ShootDomain: "example.com"},
+			memoryStorage.Operations(),
+			memoryStorage.Instances(),
+			queue,
+			factoryBuilder,
+			fixAlwaysPassJSONValidator(),
+			quotaService,
+			false,
+			logrus.StandardLogger(),
+		)
+
+		// when
+		const requests = 5
+		errs := make(chan error, requests)
+		for i := 0; i < requests; i++ {
+			go func(id string) {
+				_, err := provisionEndpoint.Provision(fixReqCtxWithRegion(t, "req-region"), id, domain.ProvisionDetails{
+					ServiceID:     serviceID,
+					PlanID:        planID,
+					RawParameters: json.RawMessage(fmt.Sprintf(`{"name": "%s"}`, clusterName)),
+					RawContext:    json.RawMessage(fmt.Sprintf(`{"globalaccount_id": "%s", "subaccount_id": "%s"}`, globalAccountID, subAccountID)),
+				}, true)
+				errs <- err
+			}(fmt.Sprintf("instance-%d", i))
+		}
+
+		// then
+		var provisioned int
+		for i := 0; i < requests; i++ {
+			if err := <-errs; err == nil {
+				provisioned++
+			}
+		}
+		assert.Equal(t, 1, provisioned)
+		instances, err := memoryStorage.Instances().GetNumberOfInstancesForGlobalAccountID(globalAccountID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, instances)
+	})
+
 	t.Run("kyma version parameters should NOT be saved", func(t *testing.T) {
 		// given
 		memoryStorage := storage.NewMemoryStorage()
@@ -409,7 +557,7 @@ func TestProvision_Provision(t *testing.T) {
 		factoryBuilder := &automock.PlanValidator{}
 		factoryBuilder.On("IsPlanSupport", planID).Return(true)
 
-		fixValidator, err := broker.NewPlansSchemaValidator()
+		fixValidator, err := broker.NewPlansSchemaValidator(nil)
 		require.NoError(t, err)
 
 		queue := &automock.Queue{}
@@ -423,6 +571,7 @@ func TestProvision_Provision(t *testing.T) {
 			queue,
 			factoryBuilder,
 			fixValidator,
+			fixAlwaysPassQuotaChecker(),
 			false,
 			logrus.StandardLogger(),
 		)
@@ -453,7 +602,7 @@ func TestProvision_Provision(t *testing.T) {
 		factoryBuilder := &automock.PlanValidator{}
 		factoryBuilder.On("IsPlanSupport", broker.AzureLitePlanID).Return(true)
 
-		fixValidator, err := broker.NewPlansSchemaValidator()
+		fixValidator, err := broker.NewPlansSchemaValidator(nil)
 		require.NoError(t, err)
 
 		queue := &automock.Queue{}
@@ -467,6 +616,7 @@ func TestProvision_Provision(t *testing.T) {
 			queue,
 			factoryBuilder,
 			fixValidator,
+			fixAlwaysPassQuotaChecker(),
 			false,
 			logrus.StandardLogger(),
 		)
@@ -494,7 +644,7 @@ func TestProvision_Provision(t *testing.T) {
 		factoryBuilder := &automock.PlanValidator{}
 		factoryBuilder.On("IsPlanSupport", broker.TrialPlanID).Return(true)
 
-		fixValidator, err := broker.NewPlansSchemaValidator()
+		fixValidator, err := broker.NewPlansSchemaValidator(nil)
 		require.NoError(t, err)
 
 		queue := &automock.Queue{}
@@ -508,6 +658,7 @@ func TestProvision_Provision(t *testing.T) {
 			queue,
 			factoryBuilder,
 			fixValidator,
+			fixAlwaysPassQuotaChecker(),
 			false,
 			logrus.StandardLogger(),
 		)
@@ -548,19 +699,28 @@ func fixExistOperation() internal.ProvisioningOperation {
 	}
 }
 
-func fixAlwaysPassJSONValidator() broker.PlansSchemaValidator {
+func fixAlwaysPassJSONValidator() broker.RegionalPlansSchemaValidator {
 	validatorMock := &automock.JSONSchemaValidator{}
 	validatorMock.On("ValidateString", mock.Anything).Return(jsonschema.ValidationResult{Valid: true}, nil)
 
-	fixValidator := broker.PlansSchemaValidator{
-		broker.GCPPlanID:   validatorMock,
-		broker.AzurePlanID: validatorMock,
-		broker.TrialPlanID: validatorMock,
+	fixValidator := broker.RegionalPlansSchemaValidator{
+		"": {
+			broker.GCPPlanID:   validatorMock,
+			broker.AzurePlanID: validatorMock,
+			broker.TrialPlanID: validatorMock,
+		},
 	}
 
 	return fixValidator
 }
 
+func fixAlwaysPassQuotaChecker() broker.QuotaChecker {
+	quotaChecker := &automock.QuotaChecker{}
+	quotaChecker.On("CheckQuota", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	return quotaChecker
+}
+
 func fixInstance() internal.Instance {
 	instance := fixture.FixInstance(instanceID)
 	instance.GlobalAccountID = globalAccountID
@@ -4,12 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
 
+	"github.com/google/uuid"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pivotal-cf/brokerapi/v7/domain/apiresponses"
 	"github.com/sirupsen/logrus"
 )
 
@@ -24,16 +29,28 @@ type UpdateEndpoint struct {
 	contextUpdateHandler ContextUpdateHandler
 	processingEnabled    bool
 
-	operationStorage storage.Operations
+	operationStorage   storage.Operations
+	updatingQueue      Queue
+	upgradeKymaQueue   Queue
+	runtimeVersionConf RuntimeVersionConfigurator
+	maintenanceInfo    *domain.MaintenanceInfo
+
+	updateSchemaValidator RegionalPlansSchemaValidator
 }
 
-func NewUpdate(instanceStorage storage.Instances, operationStorage storage.Operations, ctxUpdateHandler ContextUpdateHandler, processingEnabled bool, log logrus.FieldLogger) *UpdateEndpoint {
+func NewUpdate(instanceStorage storage.Instances, operationStorage storage.Operations, ctxUpdateHandler ContextUpdateHandler, processingEnabled bool, queue Queue,
+	upgradeKymaQueue Queue, kymaVersion string, rvc RuntimeVersionConfigurator, validator RegionalPlansSchemaValidator, log logrus.FieldLogger) *UpdateEndpoint {
 	return &UpdateEndpoint{
-		log:                  log.WithField("service", "UpdateEndpoint"),
-		instanceStorage:      instanceStorage,
-		operationStorage:     operationStorage,
-		contextUpdateHandler: ctxUpdateHandler,
-		processingEnabled:    processingEnabled,
+		log:                   log.WithField("service", "UpdateEndpoint"),
+		instanceStorage:       instanceStorage,
+		operationStorage:      operationStorage,
+		contextUpdateHandler:  ctxUpdateHandler,
+		processingEnabled:     processingEnabled,
+		updatingQueue:         queue,
+		upgradeKymaQueue:      upgradeKymaQueue,
+		runtimeVersionConf:    rvc,
+		maintenanceInfo:       MaintenanceInfoForVersion(kymaVersion),
+		updateSchemaValidator: validator,
 	}
 }
 
@@ -51,6 +68,36 @@ func (b *UpdateEndpoint) Update(ctx context.Context, instanceID string, details
 	}
 	logger.Infof("Plan ID/Name: %s/%s", instance.ServicePlanID, PlanNamesMapping[instance.ServicePlanID])
 
+	planID, err := b.extractPlanID(instance, details)
+	if err != nil {
+		logger.Errorf("invalid plan change: %s", err.Error())
+		return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, "validating plan change")
+	}
+	planChange := planID != instance.ServicePlanID
+	if planChange {
+		logger.Infof("Plan change to ID/Name: %s/%s", planID, PlanNamesMapping[planID])
+	}
+
+	parameters, err := b.extractUpdatingParameters(instance, planID, details)
+	if err != nil {
+		logger.Errorf("invalid update parameters: %s", err.Error())
+		return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, "validating update parameters")
+	}
+	upgradeVersion, err := b.extractKymaUpgrade(instance, details)
+	if err != nil {
+		return domain.UpdateServiceSpec{}, err
+	}
+	if upgradeVersion != nil {
+		logger.Infof("Kyma upgrade to the version %s", upgradeVersion.Version)
+	}
+	if upgradeVersion != nil && (planChange || !parameters.IsEmpty()) {
+		err := errors.New("maintenance_info cannot be changed together with the plan or the parameters")
+		return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, "validating maintenance info")
+	}
+	if (planChange || !parameters.IsEmpty() || upgradeVersion != nil) && !asyncAllowed {
+		return domain.UpdateServiceSpec{}, apiresponses.ErrAsyncRequired
+	}
+
 	var ersContext internal.ERSContext
 	err = json.Unmarshal(details.RawContext, &ersContext)
 	if err != nil {
@@ -91,7 +138,17 @@ func (b *UpdateEndpoint) Update(ctx context.Context, instanceID string, details
 				OperationData: "",
 			}, errors.New("unable to process the update")
 		}
+	}
 
+	// the request is rejected before the context is processed, so the rejected request does not change the instance
+	if planChange || !parameters.IsEmpty() || upgradeVersion != nil {
+		err := b.checkInstanceCanBeChanged(instance, ersContext)
+		if err != nil {
+			return domain.UpdateServiceSpec{}, err
+		}
+	}
+
+	if b.processingEnabled {
 		err = b.contextUpdateHandler.Handle(instance, ersContext)
 		if err != nil {
 			logger.Errorf("processing context updated failed: %s", err.Error())
@@ -118,6 +175,13 @@ func (b *UpdateEndpoint) Update(ctx context.Context, instanceID string, details
 		}
 	}
 
+	if planChange || !parameters.IsEmpty() {
+		return b.updateRuntime(instance, planID, parameters, logger)
+	}
+	if upgradeVersion != nil {
+		return b.upgradeKyma(instance, *upgradeVersion, logger)
+	}
+
 	return domain.UpdateServiceSpec{
 		IsAsync:       false,
 		DashboardURL:  instance.DashboardURL,
@@ -125,6 +189,224 @@ func (b *UpdateEndpoint) Update(ctx context.Context, instanceID string, details
 	}, nil
 }
 
+// extractPlanID returns the plan of the instance after the update, the plan can be changed only
+// to the plans defined in the plan-transition matrix which are offered in the platform region of the instance
+func (b *UpdateEndpoint) extractPlanID(instance *internal.Instance, details domain.UpdateDetails) (string, error) {
+	if details.PlanID == "" || details.PlanID == instance.ServicePlanID {
+		return instance.ServicePlanID, nil
+	}
+	if _, found := Plans[details.PlanID]; !found {
+		return "", fmt.Errorf("plan %s does not exist", details.PlanID)
+	}
+	if !IsPlanTransitionAllowed(instance.Parameters, details.PlanID) {
+		return "", fmt.Errorf("plan %s cannot be changed to the plan %s", PlanNamesMapping[instance.ServicePlanID], PlanNamesMapping[details.PlanID])
+	}
+	platformRegion := instance.Parameters.PlatformRegion
+	if _, available := b.updateSchemaValidator.ForRegion(platformRegion)[details.PlanID]; !available {
+		return "", fmt.Errorf("plan %s is not available in the region %s", PlanNamesMapping[details.PlanID], platformRegion)
+	}
+
+	return details.PlanID, nil
+}
+
+// extractUpdatingParameters validates the parameters of the request against the update schema of the plan of the instance after the update
+func (b *UpdateEndpoint) extractUpdatingParameters(instance *internal.Instance, planID string, details domain.UpdateDetails) (internal.UpdatingParametersDTO, error) {
+	var parameters internal.UpdatingParametersDTO
+	if len(details.RawParameters) == 0 {
+		return parameters, nil
+	}
+
+	validator, found := b.updateSchemaValidator.ForRegion(instance.Parameters.PlatformRegion)[planID]
+	if !found {
+		return parameters, fmt.Errorf("parameters of the plan %s cannot be updated", planID)
+	}
+	result, err := validator.ValidateString(string(details.RawParameters))
+	if err != nil {
+		return parameters, fmt.Errorf("while executing JSON schema validator: %s", err)
+	}
+	if !result.Valid {
+		return parameters, fmt.Errorf("while validating update parameters: %s", result.Error)
+	}
+
+	err = json.Unmarshal(details.RawParameters, &parameters)
+	if err != nil {
+		return parameters, fmt.Errorf("while unmarshalling update parameters: %s", err)
+	}
+
+	// the auto scaler limits are verified together with the current values of the instance
+	current := instance.Parameters.Parameters
+	parameters.UpdateProvisioningParameters(&current)
+	if current.AutoScalerMin != nil && current.AutoScalerMax != nil && *current.AutoScalerMin > *current.AutoScalerMax {
+		return parameters, fmt.Errorf("autoScalerMin %d cannot be greater than autoScalerMax %d", *current.AutoScalerMin, *current.AutoScalerMax)
+	}
+
+	return parameters, nil
+}
+
+// extractKymaUpgrade returns the Kyma version the instance must be upgraded to when the request carries a newer maintenance info.
+// The maintenance info must match the one advertised in the catalog, the instance is upgraded to the version configured
+// for its accounts.
+func (b *UpdateEndpoint) extractKymaUpgrade(instance *internal.Instance, details domain.UpdateDetails) (*internal.RuntimeVersionData, error) {
+	if details.MaintenanceInfo == nil {
+		return nil, nil
+	}
+	switch {
+	case b.maintenanceInfo == nil:
+		return nil, apiresponses.ErrMaintenanceInfoNilConflict
+	case !b.maintenanceInfo.Equals(*details.MaintenanceInfo):
+		return nil, apiresponses.ErrMaintenanceInfoConflict
+	}
+
+	target, err := b.runtimeVersionConf.ForAccount(instance.GlobalAccountID, instance.SubAccountID)
+	if err != nil {
+		b.log.Errorf("cannot get the Kyma version of the instance %s: %s", instance.InstanceID, err)
+		return nil, apiresponses.NewFailureResponse(errors.New("unable to get the Kyma version"), http.StatusInternalServerError, "validating maintenance info")
+	}
+
+	current, err := b.currentKymaVersion(instance.InstanceID)
+	if err != nil {
+		b.log.Errorf("cannot get the current Kyma version of the instance %s: %s", instance.InstanceID, err)
+		return nil, apiresponses.NewFailureResponse(errors.New("unable to get the Kyma version"), http.StatusInternalServerError, "validating maintenance info")
+	}
+	if current == target.Version {
+		return nil, nil
+	}
+	if !isNewerKymaVersion(current, target.Version) {
+		return nil, apiresponses.ErrMaintenanceInfoConflict
+	}
+
+	return target, nil
+}
+
+// currentKymaVersion returns the Kyma version of the last successful upgrade of the instance
+// or the version the instance was provisioned with
+func (b *UpdateEndpoint) currentKymaVersion(instanceID string) (string, error) {
+	provisioning, err := b.operationStorage.GetProvisioningOperationByInstanceID(instanceID)
+	if err != nil {
+		return "", err
+	}
+	version, last := provisioning.RuntimeVersion.Version, provisioning.CreatedAt
+
+	upgrades, err := b.operationStorage.ListUpgradeKymaOperationsByInstanceID(instanceID)
+	if err != nil && !dberr.IsNotFound(err) {
+		return "", err
+	}
+	for _, op := range upgrades {
+		if op.State != domain.Succeeded || op.DryRun || op.RuntimeVersion.IsEmpty() || op.CreatedAt.Before(last) {
+			continue
+		}
+		version, last = op.RuntimeVersion.Version, op.CreatedAt
+	}
+
+	return version, nil
+}
+
+// upgradeKyma creates the operation which upgrades Kyma of the instance to the given version, the operation
+// is not a part of any orchestration
+func (b *UpdateEndpoint) upgradeKyma(instance *internal.Instance, version internal.RuntimeVersionData, logger logrus.FieldLogger) (domain.UpdateServiceSpec, error) {
+	operation := internal.NewUpgradeKymaOperationWithID(uuid.New().String(), instance, version)
+	err := b.operationStorage.InsertUpgradeKymaOperation(operation)
+	if dberr.IsConflict(err) {
+		return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, "upgrading kyma")
+	}
+	if err != nil {
+		logger.Errorf("cannot save upgrade kyma operation: %s", err)
+		return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(errors.New("unable to save the operation"), http.StatusInternalServerError, "upgrading kyma")
+	}
+	logger.Infof("Upgrade kyma operation %s created", operation.Operation.ID)
+	b.upgradeKymaQueue.Add(operation.Operation.ID)
+
+	return domain.UpdateServiceSpec{
+		IsAsync:       true,
+		DashboardURL:  instance.DashboardURL,
+		OperationData: operation.Operation.ID,
+	}, nil
+}
+
+// updateRuntime creates the operation which updates the cluster of the instance with the given parameters
+// and upgrades the instance to the given plan
+func (b *UpdateEndpoint) updateRuntime(instance *internal.Instance, planID string, parameters internal.UpdatingParametersDTO, logger logrus.FieldLogger) (domain.UpdateServiceSpec, error) {
+	operation := internal.NewUpdatingOperationWithID(uuid.New().String(), instance, parameters)
+	if planID != instance.ServicePlanID {
+		operation.PlanID = planID
+	}
+	err := b.operationStorage.InsertUpdatingOperation(operation)
+	if dberr.IsConflict(err) {
+		return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, "updating parameters")
+	}
+	if err != nil {
+		logger.Errorf("cannot save updating operation: %s", err)
+		return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(errors.New("unable to save the operation"), http.StatusInternalServerError, "updating parameters")
+	}
+	logger.Infof("Updating operation %s created", operation.ID)
+	b.updatingQueue.Add(operation.ID)
+
+	return domain.UpdateServiceSpec{
+		IsAsync:       true,
+		DashboardURL:  instance.DashboardURL,
+		OperationData: operation.ID,
+	}, nil
+}
+
+// checkInstanceCanBeChanged returns the error if the instance is suspended, is being suspended by the request
+// or cannot be changed now. The storage rejects the operation as well when another operation was created in the meantime.
+func (b *UpdateEndpoint) checkInstanceCanBeChanged(instance *internal.Instance, ersContext internal.ERSContext) error {
+	if instance.Parameters.ErsContext.Active != nil && !*instance.Parameters.ErsContext.Active {
+		return apiresponses.NewFailureResponse(fmt.Errorf("instance %s is suspended", instance.InstanceID), http.StatusUnprocessableEntity, "updating parameters")
+	}
+	if ersContext.Active != nil && !*ersContext.Active {
+		return apiresponses.NewFailureResponse(fmt.Errorf("instance %s is being suspended", instance.InstanceID), http.StatusUnprocessableEntity, "updating parameters")
+	}
+
+	return b.checkNoOperationInProgress(instance.InstanceID)
+}
+
+// checkNoOperationInProgress returns the error if the instance is not provisioned yet or another operation changes it
+func (b *UpdateEndpoint) checkNoOperationInProgress(instanceID string) error {
+	provisioning, err := b.operationStorage.GetProvisioningOperationByInstanceID(instanceID)
+	if err != nil {
+		b.log.Errorf("cannot get provisioning operation of the instance %s: %s", instanceID, err)
+		return apiresponses.NewFailureResponse(errors.New("unable to get the provisioning operation"), http.StatusInternalServerError, "updating parameters")
+	}
+	if provisioning.State != domain.Succeeded {
+		return apiresponses.NewFailureResponse(fmt.Errorf("instance %s is not provisioned", instanceID), http.StatusUnprocessableEntity, "updating parameters")
+	}
+
+	deprovisioning, err := b.operationStorage.GetDeprovisioningOperationByInstanceID(instanceID)
+	switch {
+	case err == nil && deprovisioning.State == domain.InProgress:
+		return apiresponses.NewFailureResponse(fmt.Errorf("instance %s is being deprovisioned", instanceID), http.StatusUnprocessableEntity, "updating parameters")
+	case err != nil && !dberr.IsNotFound(err):
+		b.log.Errorf("cannot get deprovisioning operation of the instance %s: %s", instanceID, err)
+		return apiresponses.NewFailureResponse(errors.New("unable to get the deprovisioning operation"), http.StatusInternalServerError, "updating parameters")
+	}
+
+	updates, err := b.operationStorage.ListUpdatingOperationsByInstanceID(instanceID)
+	if err != nil {
+		b.log.Errorf("cannot list updating operations of the instance %s: %s", instanceID, err)
+		return apiresponses.NewFailureResponse(errors.New("unable to get the updating operations"), http.StatusInternalServerError, "updating parameters")
+	}
+	for _, op := range updates {
+		if op.State == domain.InProgress {
+			return apiresponses.NewFailureResponse(fmt.Errorf("instance %s is being updated by the operation %s", instanceID, op.ID), http.StatusUnprocessableEntity, "updating parameters")
+		}
+	}
+
+	upgrades, err := b.operationStorage.ListUpgradeKymaOperationsByInstanceID(instanceID)
+	if err != nil && !dberr.IsNotFound(err) {
+		b.log.Errorf("cannot list upgrade kyma operations of the instance %s: %s", instanceID, err)
+		return apiresponses.NewFailureResponse(errors.New("unable to get the upgrade kyma operations"), http.StatusInternalServerError, "updating parameters")
+	}
+	for _, op := range upgrades {
+		// the operations created by the maintenance info are pending until they are picked up from the queue
+		if op.State == domain.InProgress || (op.State == orchestration.Pending && op.OrchestrationID == "") {
+			return apiresponses.NewFailureResponse(fmt.Errorf("instance %s is being upgraded by the operation %s", instanceID, op.Operation.ID), http.StatusUnprocessableEntity, "updating parameters")
+		}
+	}
+
+	return nil
+}
+
 func (b *UpdateEndpoint) exctractActiveValue(id string, provisioning internal.ProvisioningOperation) (*bool, error) {
 	deprovisioning, dErr := b.operationStorage.GetDeprovisioningOperationByInstanceID(id)
 	if dErr != nil && !dberr.IsNotFound(dErr) {
@@ -3,17 +3,22 @@ package broker
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker/automock"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pivotal-cf/brokerapi/v7/domain/apiresponses"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 type handler struct {
@@ -27,6 +32,14 @@ func (h *handler) Handle(inst *internal.Instance, ers internal.ERSContext) error
 	return nil
 }
 
+type runtimeVersionConfigurator struct {
+	version string
+}
+
+func (c *runtimeVersionConfigurator) ForAccount(_, _ string) (*internal.RuntimeVersionData, error) {
+	return internal.NewRuntimeVersionFromDefaults(c.version), nil
+}
+
 func TestUpdateEndpoint_UpdateSuspension(t *testing.T) {
 	// given
 	instance := internal.Instance{
@@ -50,7 +63,7 @@ func TestUpdateEndpoint_UpdateSuspension(t *testing.T) {
 	st.Operations().InsertProvisioningOperation(fixProvisioningOperation("02"))
 
 	handler := &handler{}
-	svc := NewUpdate(st.Instances(), st.Operations(), handler, true, logrus.New())
+	svc := NewUpdate(st.Instances(), st.Operations(), handler, true, &automock.Queue{}, &automock.Queue{}, "1.20.0", &runtimeVersionConfigurator{}, RegionalPlansSchemaValidator{}, logrus.New())
 
 	// when
 	svc.Update(context.Background(), instanceID, domain.UpdateDetails{
@@ -100,7 +113,7 @@ func TestUpdateEndpoint_UpdateUnsuspension(t *testing.T) {
 	st.Operations().InsertDeprovisioningOperation(fixSuspensionOperation())
 
 	handler := &handler{}
-	svc := NewUpdate(st.Instances(), st.Operations(), handler, true, logrus.New())
+	svc := NewUpdate(st.Instances(), st.Operations(), handler, true, &automock.Queue{}, &automock.Queue{}, "1.20.0", &runtimeVersionConfigurator{}, RegionalPlansSchemaValidator{}, logrus.New())
 
 	// when
 	svc.Update(context.Background(), instanceID, domain.UpdateDetails{
@@ -158,7 +171,7 @@ func TestUpdateEndpoint_UpdateInstanceWithWrongActiveValue(t *testing.T) {
 	st.Instances().Insert(instance)
 	st.Operations().InsertProvisioningOperation(fixProvisioningOperation("01"))
 	handler := &handler{}
-	svc := NewUpdate(st.Instances(), st.Operations(), handler, true, logrus.New())
+	svc := NewUpdate(st.Instances(), st.Operations(), handler, true, &automock.Queue{}, &automock.Queue{}, "1.20.0", &runtimeVersionConfigurator{}, RegionalPlansSchemaValidator{}, logrus.New())
 
 	// when
 	svc.Update(context.Background(), instanceID, domain.UpdateDetails{
@@ -183,6 +196,509 @@ func TestUpdateEndpoint_UpdateInstanceWithWrongActiveValue(t *testing.T) {
 	assert.True(t, *handler.Instance.Parameters.ErsContext.Active)
 }
 
+func TestUpdateEndpoint_UpdateParameters(t *testing.T) {
+	// given
+	st := fixUpdateStorage(t, AzurePlanID)
+	queue := &automock.Queue{}
+	queue.On("Add", mock.AnythingOfType("string")).Return()
+	defer queue.AssertExpectations(t)
+	svc := fixUpdateEndpoint(t, st, queue)
+
+	// when
+	response, err := svc.Update(context.Background(), instanceID, domain.UpdateDetails{
+		PlanID:        AzurePlanID,
+		RawParameters: json.RawMessage(`{"machineType": "Standard_D8_v3", "autoScalerMax": 20}`),
+		RawContext:    json.RawMessage("{}"),
+	}, true)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, response.IsAsync)
+
+	operation, err := st.Operations().GetUpdatingOperationByID(response.OperationData)
+	require.NoError(t, err)
+	assert.Equal(t, domain.InProgress, operation.State)
+	assert.Equal(t, "Standard_D8_v3", *operation.UpdatingParameters.MachineType)
+	assert.Equal(t, 20, *operation.UpdatingParameters.AutoScalerMax)
+	assert.Nil(t, operation.UpdatingParameters.AutoScalerMin)
+
+	lastOperation, err := NewLastOperation(st.Operations(), st.Instances(), logrus.New()).
+		LastOperation(context.Background(), instanceID, domain.PollDetails{OperationData: response.OperationData})
+	require.NoError(t, err)
+	assert.Equal(t, domain.InProgress, lastOperation.State)
+}
+
+func TestUpdateEndpoint_UpdateParametersRejected(t *testing.T) {
+	for name, tc := range map[string]struct {
+		planID       string
+		parameters   string
+		asyncAllowed bool
+		inProgress   bool
+		expectedCode int
+	}{
+		"not valid machine type": {
+			planID:       AzurePlanID,
+			parameters:   `{"machineType": "Standard_D2_v3"}`,
+			asyncAllowed: true,
+			expectedCode: http.StatusBadRequest,
+		},
+		"not updatable parameter": {
+			planID:       AzurePlanID,
+			parameters:   `{"region": "westeurope"}`,
+			asyncAllowed: true,
+			expectedCode: http.StatusBadRequest,
+		},
+		"auto scaler minimum greater than the current maximum": {
+			planID:       AzurePlanID,
+			parameters:   `{"autoScalerMin": 30}`,
+			asyncAllowed: true,
+			expectedCode: http.StatusBadRequest,
+		},
+		"trial plan": {
+			planID:       TrialPlanID,
+			parameters:   `{"autoScalerMax": 3}`,
+			asyncAllowed: true,
+			expectedCode: http.StatusBadRequest,
+		},
+		"update in progress": {
+			planID:       AzurePlanID,
+			parameters:   `{"autoScalerMax": 30}`,
+			asyncAllowed: true,
+			inProgress:   true,
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		"async not allowed": {
+			planID:       AzurePlanID,
+			parameters:   `{"autoScalerMax": 30}`,
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			st := fixUpdateStorage(t, tc.planID)
+			if tc.inProgress {
+				instance, err := st.Instances().GetByID(instanceID)
+				require.NoError(t, err)
+				err = st.Operations().InsertUpdatingOperation(internal.NewUpdatingOperationWithID("update-01", instance, internal.UpdatingParametersDTO{
+					AutoScalerMax: ptr.Integer(15),
+				}))
+				require.NoError(t, err)
+			}
+			svc := fixUpdateEndpoint(t, st, &automock.Queue{})
+
+			// when
+			_, err := svc.Update(context.Background(), instanceID, domain.UpdateDetails{
+				PlanID:        tc.planID,
+				RawParameters: json.RawMessage(tc.parameters),
+				RawContext:    json.RawMessage("{}"),
+			}, tc.asyncAllowed)
+
+			// then
+			require.Error(t, err)
+			apiErr, ok := err.(*apiresponses.FailureResponse)
+			require.True(t, ok)
+			assert.Equal(t, tc.expectedCode, apiErr.ValidatedStatusCode(nil))
+		})
+	}
+}
+
+func TestUpdateEndpoint_UpdateRejectedBeforeContextIsProcessed(t *testing.T) {
+	// given
+	st := fixUpdateStorage(t, AzureLitePlanID)
+	err := st.Operations().InsertDeprovisioningOperation(fixSuspensionOperation())
+	require.NoError(t, err)
+	validator, err := NewPlansUpdateSchemaValidator(nil)
+	require.NoError(t, err)
+	handler := &handler{}
+	svc := NewUpdate(st.Instances(), st.Operations(), handler, true, &automock.Queue{}, &automock.Queue{},
+		"1.20.0", &runtimeVersionConfigurator{version: "1.20.0"}, validator, logrus.New())
+
+	// when
+	_, err = svc.Update(context.Background(), instanceID, domain.UpdateDetails{
+		PlanID:     AzurePlanID,
+		RawContext: json.RawMessage(`{"active":true}`),
+	}, true)
+
+	// then
+	require.Error(t, err)
+	apiErr, ok := err.(*apiresponses.FailureResponse)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusUnprocessableEntity, apiErr.ValidatedStatusCode(nil))
+	assert.Empty(t, handler.Instance.InstanceID)
+
+	instance, err := st.Instances().GetByID(instanceID)
+	require.NoError(t, err)
+	assert.Nil(t, instance.Parameters.ErsContext.Active)
+}
+
+func TestUpdateEndpoint_UpdateRejectedByStorage(t *testing.T) {
+	// given
+	st := fixUpdateStorage(t, AzurePlanID)
+	instance, err := st.Instances().GetByID(instanceID)
+	require.NoError(t, err)
+	err = st.Operations().InsertUpdatingOperation(internal.NewUpdatingOperationWithID("update-01", instance, internal.UpdatingParametersDTO{
+		AutoScalerMax: ptr.Integer(15),
+	}))
+	require.NoError(t, err)
+	validator, err := NewPlansUpdateSchemaValidator(nil)
+	require.NoError(t, err)
+	// the concurrent operation is not visible when the request is validated
+	operations := &concurrentlyUpdatedOperations{Operations: st.Operations()}
+	svc := NewUpdate(st.Instances(), operations, &handler{}, false, &automock.Queue{}, &automock.Queue{},
+		"1.20.0", &runtimeVersionConfigurator{version: "1.20.0"}, validator, logrus.New())
+
+	// when
+	_, err = svc.Update(context.Background(), instanceID, domain.UpdateDetails{
+		PlanID:        AzurePlanID,
+		RawParameters: json.RawMessage(`{"autoScalerMax": 20}`),
+		RawContext:    json.RawMessage("{}"),
+	}, true)
+
+	// then
+	require.Error(t, err)
+	apiErr, ok := err.(*apiresponses.FailureResponse)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusUnprocessableEntity, apiErr.ValidatedStatusCode(nil))
+
+	updates, err := st.Operations().ListUpdatingOperationsByInstanceID(instanceID)
+	require.NoError(t, err)
+	assert.Len(t, updates, 1)
+}
+
+type concurrentlyUpdatedOperations struct {
+	storage.Operations
+}
+
+func (o *concurrentlyUpdatedOperations) ListUpdatingOperationsByInstanceID(string) ([]internal.UpdatingOperation, error) {
+	return nil, nil
+}
+
+func TestUpdateEndpoint_UpdatePlan(t *testing.T) {
+	// given
+	st := fixUpdateStorage(t, TrialPlanID)
+	queue := &automock.Queue{}
+	queue.On("Add", mock.AnythingOfType("string")).Return()
+	defer queue.AssertExpectations(t)
+	svc := fixUpdateEndpoint(t, st, queue)
+
+	// when
+	response, err := svc.Update(context.Background(), instanceID, domain.UpdateDetails{
+		PlanID:     AzurePlanID,
+		RawContext: json.RawMessage("{}"),
+		PreviousValues: domain.PreviousValues{
+			PlanID: TrialPlanID,
+		},
+	}, true)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, response.IsAsync)
+
+	operation, err := st.Operations().GetUpdatingOperationByID(response.OperationData)
+	require.NoError(t, err)
+	assert.Equal(t, domain.InProgress, operation.State)
+	assert.Equal(t, AzurePlanID, operation.PlanID)
+	assert.True(t, operation.IsPlanChange())
+
+	instance, err := st.Instances().GetByID(instanceID)
+	require.NoError(t, err)
+	assert.Equal(t, TrialPlanID, instance.ServicePlanID)
+}
+
+func TestUpdateEndpoint_UpdatePlanRejected(t *testing.T) {
+	gcp := internal.Gcp
+	for name, tc := range map[string]struct {
+		planID        string
+		targetPlanID  string
+		trialProvider *internal.TrialCloudProvider
+		asyncAllowed  bool
+		expectedCode  int
+	}{
+		"downgrade to trial": {
+			planID:       AzurePlanID,
+			targetPlanID: TrialPlanID,
+			asyncAllowed: true,
+			expectedCode: http.StatusBadRequest,
+		},
+		"azure to azure lite": {
+			planID:       AzurePlanID,
+			targetPlanID: AzureLitePlanID,
+			asyncAllowed: true,
+			expectedCode: http.StatusBadRequest,
+		},
+		"trial to the plan of another provider": {
+			planID:        TrialPlanID,
+			targetPlanID:  AzurePlanID,
+			trialProvider: &gcp,
+			asyncAllowed:  true,
+			expectedCode:  http.StatusBadRequest,
+		},
+		"unknown plan": {
+			planID:       AzureLitePlanID,
+			targetPlanID: "not-existing",
+			asyncAllowed: true,
+			expectedCode: http.StatusBadRequest,
+		},
+		"async not allowed": {
+			planID:       AzureLitePlanID,
+			targetPlanID: AzurePlanID,
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			st := fixUpdateStorage(t, tc.planID)
+			if tc.trialProvider != nil {
+				instance, err := st.Instances().GetByID(instanceID)
+				require.NoError(t, err)
+				instance.Parameters.Parameters.Provider = tc.trialProvider
+				_, err = st.Instances().Update(*instance)
+				require.NoError(t, err)
+			}
+			svc := fixUpdateEndpoint(t, st, &automock.Queue{})
+
+			// when
+			_, err := svc.Update(context.Background(), instanceID, domain.UpdateDetails{
+				PlanID:     tc.targetPlanID,
+				RawContext: json.RawMessage("{}"),
+			}, tc.asyncAllowed)
+
+			// then
+			require.Error(t, err)
+			apiErr, ok := err.(*apiresponses.FailureResponse)
+			require.True(t, ok)
+			assert.Equal(t, tc.expectedCode, apiErr.ValidatedStatusCode(nil))
+		})
+	}
+}
+
+func TestUpdateEndpoint_UpdatePlanNotAvailableInRegion(t *testing.T) {
+	// given
+	st := fixUpdateStorage(t, AzureLitePlanID)
+	instance, err := st.Instances().GetByID(instanceID)
+	require.NoError(t, err)
+	instance.Parameters.PlatformRegion = "cf-eu10"
+	_, err = st.Instances().Update(*instance)
+	require.NoError(t, err)
+
+	validator, err := NewPlansUpdateSchemaValidator(PlansConfig{
+		"cf-eu10": {"azure_lite": {}},
+	})
+	require.NoError(t, err)
+	svc := NewUpdate(st.Instances(), st.Operations(), &handler{}, false, &automock.Queue{}, &automock.Queue{}, "1.20.0", &runtimeVersionConfigurator{}, validator, logrus.New())
+
+	// when
+	_, err = svc.Update(context.Background(), instanceID, domain.UpdateDetails{
+		PlanID:     AzurePlanID,
+		RawContext: json.RawMessage("{}"),
+	}, true)
+
+	// then
+	require.Error(t, err)
+	apiErr, ok := err.(*apiresponses.FailureResponse)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, apiErr.ValidatedStatusCode(nil))
+	assert.EqualError(t, err, "plan azure is not available in the region cf-eu10")
+}
+
+func TestUpdateEndpoint_UpgradeKyma(t *testing.T) {
+	// given
+	st := fixUpdateStorage(t, AzurePlanID)
+	queue := &automock.Queue{}
+	queue.On("Add", mock.AnythingOfType("string")).Return()
+	defer queue.AssertExpectations(t)
+	svc := fixUpdateEndpoint(t, st, queue)
+
+	// when
+	response, err := svc.Update(context.Background(), instanceID, domain.UpdateDetails{
+		PlanID:          AzurePlanID,
+		RawContext:      json.RawMessage("{}"),
+		MaintenanceInfo: &domain.MaintenanceInfo{Version: "1.20.0"},
+	}, true)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, response.IsAsync)
+
+	operation, err := st.Operations().GetUpgradeKymaOperationByID(response.OperationData)
+	require.NoError(t, err)
+	assert.Equal(t, orchestration.Pending, string(operation.State))
+	assert.Empty(t, operation.OrchestrationID)
+	assert.Equal(t, "1.20.0", operation.RuntimeVersion.Version)
+}
+
+func TestUpdateEndpoint_UpgradeKymaNotNeeded(t *testing.T) {
+	// given
+	st := fixUpdateStorage(t, AzurePlanID)
+	queue := &automock.Queue{}
+	defer queue.AssertExpectations(t)
+	validator, err := NewPlansUpdateSchemaValidator(nil)
+	require.NoError(t, err)
+	svc := NewUpdate(st.Instances(), st.Operations(), &handler{}, false, queue, queue, "1.19.0", &runtimeVersionConfigurator{version: "1.19.0"}, validator, logrus.New())
+
+	// when
+	response, err := svc.Update(context.Background(), instanceID, domain.UpdateDetails{
+		PlanID:          AzurePlanID,
+		RawContext:      json.RawMessage("{}"),
+		MaintenanceInfo: &domain.MaintenanceInfo{Version: "1.19.0"},
+	}, false)
+
+	// then
+	require.NoError(t, err)
+	assert.False(t, response.IsAsync)
+}
+
+func TestUpdateEndpoint_UpgradeKymaMappedAccount(t *testing.T) {
+	// given
+	st := fixUpdateStorage(t, AzurePlanID)
+	queue := &automock.Queue{}
+	queue.On("Add", mock.AnythingOfType("string")).Return()
+	defer queue.AssertExpectations(t)
+	validator, err := NewPlansUpdateSchemaValidator(nil)
+	require.NoError(t, err)
+	// the catalog advertises the default version, the accounts of the instance are mapped to another one
+	svc := NewUpdate(st.Instances(), st.Operations(), &handler{}, false, queue, queue, "1.20.0", &runtimeVersionConfigurator{version: "PR-1234"}, validator, logrus.New())
+
+	// when
+	_, err = svc.Update(context.Background(), instanceID, domain.UpdateDetails{
+		PlanID:          AzurePlanID,
+		RawContext:      json.RawMessage("{}"),
+		MaintenanceInfo: &domain.MaintenanceInfo{Version: "PR-1234"},
+	}, true)
+
+	// then
+	require.Error(t, err)
+	apiErr, ok := err.(*apiresponses.FailureResponse)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusUnprocessableEntity, apiErr.ValidatedStatusCode(nil))
+
+	// when
+	response, err := svc.Update(context.Background(), instanceID, domain.UpdateDetails{
+		PlanID:          AzurePlanID,
+		RawContext:      json.RawMessage("{}"),
+		MaintenanceInfo: &domain.MaintenanceInfo{Version: "1.20.0", Description: "Kyma 1.20.0"},
+	}, true)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, response.IsAsync)
+
+	operation, err := st.Operations().GetUpgradeKymaOperationByID(response.OperationData)
+	require.NoError(t, err)
+	assert.Equal(t, "PR-1234", operation.RuntimeVersion.Version)
+}
+
+func TestUpdateEndpoint_UpgradeKymaRejected(t *testing.T) {
+	for name, tc := range map[string]struct {
+		targetVersion   string
+		maintenanceInfo domain.MaintenanceInfo
+		parameters      string
+		asyncAllowed    bool
+		inProgress      bool
+		expectedCode    int
+	}{
+		"mismatched version": {
+			targetVersion:   "1.20.0",
+			maintenanceInfo: domain.MaintenanceInfo{Version: "1.21.0"},
+			asyncAllowed:    true,
+			expectedCode:    http.StatusUnprocessableEntity,
+		},
+		"older version": {
+			targetVersion:   "1.18.0",
+			maintenanceInfo: domain.MaintenanceInfo{Version: "1.18.0"},
+			asyncAllowed:    true,
+			expectedCode:    http.StatusUnprocessableEntity,
+		},
+		"version without maintenance info": {
+			targetVersion:   "PR-1234",
+			maintenanceInfo: domain.MaintenanceInfo{Version: "1.20.0"},
+			asyncAllowed:    true,
+			expectedCode:    http.StatusUnprocessableEntity,
+		},
+		"together with parameters": {
+			targetVersion:   "1.20.0",
+			maintenanceInfo: domain.MaintenanceInfo{Version: "1.20.0"},
+			parameters:      `{"autoScalerMax": 20}`,
+			asyncAllowed:    true,
+			expectedCode:    http.StatusBadRequest,
+		},
+		"async not allowed": {
+			targetVersion:   "1.20.0",
+			maintenanceInfo: domain.MaintenanceInfo{Version: "1.20.0"},
+			expectedCode:    http.StatusUnprocessableEntity,
+		},
+		"upgrade in progress": {
+			targetVersion:   "1.20.0",
+			maintenanceInfo: domain.MaintenanceInfo{Version: "1.20.0"},
+			asyncAllowed:    true,
+			inProgress:      true,
+			expectedCode:    http.StatusUnprocessableEntity,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			st := fixUpdateStorage(t, AzurePlanID)
+			if tc.inProgress {
+				err := st.Operations().InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{
+					Operation: internal.Operation{
+						ID:         "upgrade-kyma-id",
+						CreatedAt:  time.Now(),
+						InstanceID: instanceID,
+						State:      domain.InProgress,
+					},
+				})
+				require.NoError(t, err)
+			}
+			validator, err := NewPlansUpdateSchemaValidator(nil)
+			require.NoError(t, err)
+			svc := NewUpdate(st.Instances(), st.Operations(), &handler{}, false, &automock.Queue{}, &automock.Queue{},
+				tc.targetVersion, &runtimeVersionConfigurator{version: tc.targetVersion}, validator, logrus.New())
+
+			// when
+			_, err = svc.Update(context.Background(), instanceID, domain.UpdateDetails{
+				PlanID:          AzurePlanID,
+				RawParameters:   json.RawMessage(tc.parameters),
+				RawContext:      json.RawMessage("{}"),
+				MaintenanceInfo: &tc.maintenanceInfo,
+			}, tc.asyncAllowed)
+
+			// then
+			require.Error(t, err)
+			apiErr, ok := err.(*apiresponses.FailureResponse)
+			require.True(t, ok)
+			assert.Equal(t, tc.expectedCode, apiErr.ValidatedStatusCode(nil))
+		})
+	}
+}
+
+func fixUpdateStorage(t *testing.T, planID string) storage.BrokerStorage {
+	st := storage.NewMemoryStorage()
+	err := st.Instances().Insert(internal.Instance{
+		InstanceID:    instanceID,
+		ServicePlanID: planID,
+		Parameters: internal.ProvisioningParameters{
+			PlanID: planID,
+			Parameters: internal.ProvisioningParametersDTO{
+				AutoScalerMin: ptr.Integer(2),
+				AutoScalerMax: ptr.Integer(10),
+			},
+		},
+	})
+	require.NoError(t, err)
+	provisioning := fixProvisioningOperation("01")
+	provisioning.State = domain.Succeeded
+	provisioning.RuntimeVersion = *internal.NewRuntimeVersionFromDefaults("1.19.0")
+	err = st.Operations().InsertProvisioningOperation(provisioning)
+	require.NoError(t, err)
+	return st
+}
+
+func fixUpdateEndpoint(t *testing.T, st storage.BrokerStorage, queue Queue) *UpdateEndpoint {
+	validator, err := NewPlansUpdateSchemaValidator(nil)
+	require.NoError(t, err)
+	return NewUpdate(st.Instances(), st.Operations(), &handler{}, false, queue, queue, "1.20.0", &runtimeVersionConfigurator{version: "1.20.0"}, validator, logrus.New())
+}
+
 func fixProvisioningOperation(id string) internal.ProvisioningOperation {
 	return internal.ProvisioningOperation{
 		Operation: internal.Operation{
@@ -0,0 +1,40 @@
+package broker
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"golang.org/x/mod/semver"
+)
+
+// RuntimeVersionConfigurator returns the Kyma version which the runtimes of the given accounts should run
+type RuntimeVersionConfigurator interface {
+	ForAccount(globalAccountID, subAccountID string) (*internal.RuntimeVersionData, error)
+}
+
+// MaintenanceInfoForVersion returns the OSB maintenance info which advertises the given Kyma version.
+// The maintenance info version must be a semantic version, so the other Kyma versions (PR-<number>, <branch>-<commit>)
+// are not advertised and nil is returned.
+func MaintenanceInfoForVersion(version string) *domain.MaintenanceInfo {
+	if !semver.IsValid(fmt.Sprintf("v%s", version)) {
+		return nil
+	}
+
+	return &domain.MaintenanceInfo{
+		Version:     version,
+		Description: fmt.Sprintf("Kyma %s", version),
+	}
+}
+
+// isNewerKymaVersion returns true if the target version is newer than the current one, the versions
+// which are not semantic versions are always upgraded
+func isNewerKymaVersion(current, target string) bool {
+	current, target = fmt.Sprintf("v%s", current), fmt.Sprintf("v%s", target)
+	if !semver.IsValid(current) || !semver.IsValid(target) {
+		return current != target
+	}
+
+	return semver.Compare(target, current) > 0
+}
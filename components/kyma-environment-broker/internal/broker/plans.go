@@ -3,6 +3,9 @@ package broker
 import (
 	"encoding/json"
 
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+
 	"github.com/pivotal-cf/brokerapi/v7/domain"
 )
 
@@ -17,6 +20,8 @@ const (
 	AzureLitePlanName = "azure_lite"
 	TrialPlanID       = "7d55d31d-35ae-4438-bf13-6ffdfa107d9f"
 	TrialPlanName     = "trial"
+	AWSPlanID         = "361c511f-f939-4621-b228-d0fb79a1fe15"
+	AWSPlanName       = "aws"
 )
 
 var PlanNamesMapping = map[string]string{
@@ -24,6 +29,7 @@ var PlanNamesMapping = map[string]string{
 	AzurePlanID:     AzurePlanName,
 	AzureLitePlanID: AzureLitePlanName,
 	TrialPlanID:     TrialPlanName,
+	AWSPlanID:       AWSPlanName,
 }
 
 var PlanIDsMapping = map[string]string{
@@ -31,6 +37,7 @@ var PlanIDsMapping = map[string]string{
 	AzureLitePlanName: AzureLitePlanID,
 	GCPPlanName:       GCPPlanID,
 	TrialPlanName:     TrialPlanID,
+	AWSPlanName:       AWSPlanID,
 }
 
 type TrialCloudRegion string
@@ -68,6 +75,22 @@ func GCPRegions() []string {
 		"northamerica-northeast1", "southamerica-east1"}
 }
 
+func AWSRegions() []string {
+	return []string{
+		"eu-central-1",
+		"eu-west-2",
+		"ca-central-1",
+		"sa-east-1",
+		"us-east-1",
+		"us-west-1",
+		"ap-northeast-1",
+		"ap-northeast-2",
+		"ap-south-1",
+		"ap-southeast-1",
+		"ap-southeast-2",
+	}
+}
+
 type Type struct {
 	Type            string        `json:"type"`
 	Title           string        `json:"title,omitempty"`
@@ -87,8 +110,9 @@ type Type struct {
 type RootSchema struct {
 	Schema string `json:"$schema"`
 	Type
-	Properties interface{} `json:"properties"`
-	Required   []string    `json:"required"`
+	Properties           interface{} `json:"properties"`
+	Required             []string    `json:"required"`
+	AdditionalProperties *bool       `json:"additionalProperties,omitempty"`
 
 	// Specified to true enables form view on website
 	ShowFormView bool `json:"_show_form_view"`
@@ -104,6 +128,16 @@ type ProvisioningProperties struct {
 	AutoScalerMax *Type `json:"autoScalerMax,omitempty"`
 }
 
+// UpdateProperties holds the properties which can be changed by the update of the instance
+type UpdateProperties struct {
+	MachineType    *Type `json:"machineType,omitempty"`
+	AutoScalerMin  *Type `json:"autoScalerMin,omitempty"`
+	AutoScalerMax  *Type `json:"autoScalerMax,omitempty"`
+	VolumeSizeGb   *Type `json:"volumeSizeGb,omitempty"`
+	MaxSurge       *Type `json:"maxSurge,omitempty"`
+	MaxUnavailable *Type `json:"maxUnavailable,omitempty"`
+}
+
 func NameProperty() Type {
 	return Type{
 		Type:      "string",
@@ -141,6 +175,41 @@ func NewProvisioningProperties(machineTypes []string, regions []string) Provisio
 	}
 }
 
+// NewUpdateProperties creates the properties of the update for different plans
+func NewUpdateProperties(machineTypes []string) UpdateProperties {
+	return UpdateProperties{
+		MachineType: &Type{
+			Type: "string",
+			Enum: ToInterfaceSlice(machineTypes),
+		},
+		AutoScalerMin: &Type{
+			Type:        "integer",
+			Minimum:     2,
+			Description: "Specifies the minimum number of virtual machines to create",
+		},
+		AutoScalerMax: &Type{
+			Type:        "integer",
+			Minimum:     2,
+			Maximum:     40,
+			Description: "Specifies the maximum number of virtual machines to create",
+		},
+		VolumeSizeGb: &Type{
+			Type:        "integer",
+			Minimum:     30,
+			Description: "Specifies the size of the disk of the virtual machines in GB",
+		},
+		MaxSurge: &Type{
+			Type:        "integer",
+			Minimum:     1,
+			Description: "Specifies the maximum number of virtual machines that are created during an update",
+		},
+		MaxUnavailable: &Type{
+			Type:        "integer",
+			Description: "Specifies the maximum number of virtual machines that can be unavailable during an update",
+		},
+	}
+}
+
 func DefaultControlsOrder() []string {
 	return []string{"name", "region", "machineType", "autoScalerMin", "autoScalerMax"}
 }
@@ -158,6 +227,19 @@ func NewSchema(properties ProvisioningProperties, controlsOrder []string) RootSc
 	}
 }
 
+// NewUpdateSchema creates the schema of the update parameters, the parameters which are not defined cannot be updated
+func NewUpdateSchema(properties UpdateProperties) RootSchema {
+	return RootSchema{
+		Schema: "http://json-schema.org/draft-04/schema#",
+		Type: Type{
+			Type: "object",
+		},
+		Properties:           properties,
+		Required:             []string{},
+		AdditionalProperties: ptr.Bool(false),
+	}
+}
+
 func GCPSchema(machineTypes []string) []byte {
 	properties := NewProvisioningProperties(machineTypes, GCPRegions())
 	schema := NewSchema(properties, DefaultControlsOrder())
@@ -180,6 +262,29 @@ func AzureSchema(machineTypes []string) []byte {
 	return bytes
 }
 
+// ProvisioningSchema returns the schema of the provisioning parameters of the plans which offer the given machine types and regions
+func ProvisioningSchema(machineTypes []string, regions []string) []byte {
+	properties := NewProvisioningProperties(machineTypes, regions)
+	schema := NewSchema(properties, DefaultControlsOrder())
+
+	bytes, err := json.Marshal(schema)
+	if err != nil {
+		panic(err)
+	}
+	return bytes
+}
+
+func AWSSchema(machineTypes []string) []byte {
+	properties := NewProvisioningProperties(machineTypes, AWSRegions())
+	schema := NewSchema(properties, DefaultControlsOrder())
+
+	bytes, err := json.Marshal(schema)
+	if err != nil {
+		panic(err)
+	}
+	return bytes
+}
+
 func TrialSchema() []byte {
 	schema := NewSchema(
 		ProvisioningProperties{
@@ -193,6 +298,27 @@ func TrialSchema() []byte {
 	return bytes
 }
 
+func UpdateSchema(machineTypes []string) []byte {
+	schema := NewUpdateSchema(NewUpdateProperties(machineTypes))
+
+	bytes, err := json.Marshal(schema)
+	if err != nil {
+		panic(err)
+	}
+	return bytes
+}
+
+// TrialUpdateSchema returns the schema of the trial plan which does not allow to update any parameter
+func TrialUpdateSchema() []byte {
+	schema := NewUpdateSchema(UpdateProperties{})
+
+	bytes, err := json.Marshal(schema)
+	if err != nil {
+		panic(err)
+	}
+	return bytes
+}
+
 func ToInterfaceSlice(input []string) []interface{} {
 	interfaces := make([]interface{}, len(input))
 	for i, item := range input {
@@ -201,11 +327,23 @@ func ToInterfaceSlice(input []string) []interface{} {
 	return interfaces
 }
 
+var (
+	gcpMachineTypes       = []string{"n1-standard-2", "n1-standard-4", "n1-standard-8", "n1-standard-16", "n1-standard-32", "n1-standard-64"}
+	azureMachineTypes     = []string{"Standard_D8_v3"}
+	azureLiteMachineTypes = []string{"Standard_D4_v3"}
+	awsMachineTypes       = []string{"m5.2xlarge", "m5.4xlarge", "m5.8xlarge", "m5.12xlarge"}
+)
+
 // plans is designed to hold plan defaulting logic
 // keep internal/hyperscaler/azure/config.go in sync with any changes to available zones
 var Plans = map[string]struct {
 	PlanDefinition        domain.ServicePlan
 	provisioningRawSchema []byte
+	updateRawSchema       []byte
+
+	// regions and machineTypes are offered by the plan in the platform regions which do not restrict them
+	regions      []string
+	machineTypes []string
 }{
 	GCPPlanID: {
 		PlanDefinition: domain.ServicePlan{
@@ -220,10 +358,16 @@ var Plans = map[string]struct {
 					Create: domain.Schema{
 						Parameters: make(map[string]interface{}),
 					},
+					Update: domain.Schema{
+						Parameters: make(map[string]interface{}),
+					},
 				},
 			},
 		},
-		provisioningRawSchema: GCPSchema([]string{"n1-standard-2", "n1-standard-4", "n1-standard-8", "n1-standard-16", "n1-standard-32", "n1-standard-64"}),
+		provisioningRawSchema: GCPSchema(gcpMachineTypes),
+		updateRawSchema:       UpdateSchema(gcpMachineTypes),
+		regions:               GCPRegions(),
+		machineTypes:          gcpMachineTypes,
 	},
 	AzurePlanID: {
 		PlanDefinition: domain.ServicePlan{
@@ -238,10 +382,16 @@ var Plans = map[string]struct {
 					Create: domain.Schema{
 						Parameters: make(map[string]interface{}),
 					},
+					Update: domain.Schema{
+						Parameters: make(map[string]interface{}),
+					},
 				},
 			},
 		},
-		provisioningRawSchema: AzureSchema([]string{"Standard_D8_v3"}),
+		provisioningRawSchema: AzureSchema(azureMachineTypes),
+		updateRawSchema:       UpdateSchema(azureMachineTypes),
+		regions:               AzureRegions(),
+		machineTypes:          azureMachineTypes,
 	},
 	AzureLitePlanID: {
 		PlanDefinition: domain.ServicePlan{
@@ -256,10 +406,16 @@ var Plans = map[string]struct {
 					Create: domain.Schema{
 						Parameters: make(map[string]interface{}),
 					},
+					Update: domain.Schema{
+						Parameters: make(map[string]interface{}),
+					},
 				},
 			},
 		},
-		provisioningRawSchema: AzureSchema([]string{"Standard_D4_v3"}),
+		provisioningRawSchema: AzureSchema(azureLiteMachineTypes),
+		updateRawSchema:       UpdateSchema(azureLiteMachineTypes),
+		regions:               AzureRegions(),
+		machineTypes:          azureLiteMachineTypes,
 	},
 	TrialPlanID: {
 		PlanDefinition: domain.ServicePlan{
@@ -274,10 +430,38 @@ var Plans = map[string]struct {
 					Create: domain.Schema{
 						Parameters: make(map[string]interface{}),
 					},
+					Update: domain.Schema{
+						Parameters: make(map[string]interface{}),
+					},
 				},
 			},
 		},
 		provisioningRawSchema: TrialSchema(),
+		updateRawSchema:       TrialUpdateSchema(),
+	},
+	AWSPlanID: {
+		PlanDefinition: domain.ServicePlan{
+			ID:          AWSPlanID,
+			Name:        AWSPlanName,
+			Description: "AWS",
+			Metadata: &domain.ServicePlanMetadata{
+				DisplayName: "AWS",
+			},
+			Schemas: &domain.ServiceSchemas{
+				Instance: domain.ServiceInstanceSchema{
+					Create: domain.Schema{
+						Parameters: make(map[string]interface{}),
+					},
+					Update: domain.Schema{
+						Parameters: make(map[string]interface{}),
+					},
+				},
+			},
+		},
+		provisioningRawSchema: AWSSchema(awsMachineTypes),
+		updateRawSchema:       UpdateSchema(awsMachineTypes),
+		regions:               AWSRegions(),
+		machineTypes:          awsMachineTypes,
 	},
 }
 
@@ -289,3 +473,42 @@ func IsTrialPlan(planID string) bool {
 		return false
 	}
 }
+
+// PlanTransitions is the plan-transition matrix, it defines the plans to which the instance of the given plan can be upgraded
+var PlanTransitions = map[string][]string{
+	TrialPlanID:     {AzurePlanID, AzureLitePlanID, GCPPlanID, AWSPlanID},
+	AzureLitePlanID: {AzurePlanID},
+}
+
+// trialProviderPlans defines the plans which run on the same cloud provider as the trial
+var trialProviderPlans = map[internal.TrialCloudProvider][]string{
+	internal.Azure: {AzurePlanID, AzureLitePlanID},
+	internal.Gcp:   {GCPPlanID},
+	internal.Aws:   {AWSPlanID},
+}
+
+// IsPlanTransitionAllowed returns true if the instance with the given parameters can be upgraded to the plan,
+// the trial instance can be upgraded only to the plans of its cloud provider
+func IsPlanTransitionAllowed(parameters internal.ProvisioningParameters, toPlanID string) bool {
+	if !containsPlan(PlanTransitions[parameters.PlanID], toPlanID) {
+		return false
+	}
+	if !IsTrialPlan(parameters.PlanID) {
+		return true
+	}
+
+	provider := internal.Azure
+	if parameters.Parameters.Provider != nil {
+		provider = *parameters.Parameters.Provider
+	}
+	return containsPlan(trialProviderPlans[provider], toPlanID)
+}
+
+func containsPlan(planIDs []string, planID string) bool {
+	for _, id := range planIDs {
+		if id == planID {
+			return true
+		}
+	}
+	return false
+}
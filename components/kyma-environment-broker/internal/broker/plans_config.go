@@ -0,0 +1,110 @@
+package broker
+
+import (
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// PlansConfig defines the plans offered in the platform regions, the first key is the platform region
+// and the second one is the name of the plan. Only the listed plans are offered in the configured platform regions,
+// all plans with all their regions and machine types are offered in the platform regions which are not configured.
+type PlansConfig map[string]map[string]PlanConfig
+
+// PlanConfig restricts the regions and the machine types offered by the plan, all of them are offered if not set
+type PlanConfig struct {
+	Regions      []string `yaml:"regions"`
+	MachineTypes []string `yaml:"machineTypes"`
+}
+
+// ReadPlansConfigFromFile reads the plans configuration from the given YAML file
+func ReadPlansConfigFromFile(filename string) (PlansConfig, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return PlansConfig{}, errors.Wrapf(err, "while reading %s file with plans config", filename)
+	}
+	var config PlansConfig
+	err = yaml.UnmarshalStrict(content, &config)
+	if err != nil {
+		return PlansConfig{}, errors.Wrapf(err, "while unmarshalling a file with plans config")
+	}
+	if err := config.Validate(); err != nil {
+		return PlansConfig{}, errors.Wrapf(err, "while validating plans config")
+	}
+	return config, nil
+}
+
+// Validate checks if the configured plans exist and offer the configured regions and machine types
+func (c PlansConfig) Validate() error {
+	for platformRegion, plans := range c {
+		for planName, cfg := range plans {
+			planID, found := PlanIDsMapping[planName]
+			if !found {
+				return errors.Errorf("plan %s configured for the platform region %s does not exist", planName, platformRegion)
+			}
+			plan := Plans[planID]
+			for _, region := range cfg.Regions {
+				if !contains(plan.regions, region) {
+					return errors.Errorf("plan %s does not offer the region %s configured for the platform region %s", planName, region, platformRegion)
+				}
+			}
+			for _, machineType := range cfg.MachineTypes {
+				if !contains(plan.machineTypes, machineType) {
+					return errors.Errorf("plan %s does not offer the machine type %s configured for the platform region %s", planName, machineType, platformRegion)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// IsPlanAvailable returns true if the plan is offered in the platform region
+func (c PlansConfig) IsPlanAvailable(platformRegion, planID string) bool {
+	plans, configured := c[platformRegion]
+	if !configured {
+		return true
+	}
+	_, found := plans[PlanNamesMapping[planID]]
+	return found
+}
+
+// planConfig returns the restrictions of the plan in the platform region
+func (c PlansConfig) planConfig(platformRegion, planID string) PlanConfig {
+	return c[platformRegion][PlanNamesMapping[planID]]
+}
+
+// planSchemas returns the provisioning and the update schemas of the plan restricted by the given configuration
+func planSchemas(planID string, cfg PlanConfig) (provisioningRawSchema []byte, updateRawSchema []byte) {
+	plan := Plans[planID]
+	if len(cfg.Regions) == 0 && len(cfg.MachineTypes) == 0 {
+		return plan.provisioningRawSchema, plan.updateRawSchema
+	}
+
+	regions := restrict(plan.regions, cfg.Regions)
+	machineTypes := restrict(plan.machineTypes, cfg.MachineTypes)
+	return ProvisioningSchema(machineTypes, regions), UpdateSchema(machineTypes)
+}
+
+// restrict returns the values offered by default which are allowed, all values are returned if the allowed ones are not set
+func restrict(values []string, allowed []string) []string {
+	if len(allowed) == 0 {
+		return values
+	}
+	var restricted []string
+	for _, value := range values {
+		if contains(allowed, value) {
+			restricted = append(restricted, value)
+		}
+	}
+	return restricted
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
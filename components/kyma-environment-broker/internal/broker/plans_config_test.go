@@ -0,0 +1,58 @@
+package broker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadPlansConfigFromFile(t *testing.T) {
+	// when
+	config, err := ReadPlansConfigFromFile("testdata/plans.yaml")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, PlansConfig{
+		"cf-eu10": {
+			"azure":      {Regions: []string{"westeurope", "northeurope"}},
+			"azure_lite": {},
+			"trial":      {},
+		},
+		"cf-us10": {
+			"aws": {Regions: []string{"us-east-1"}, MachineTypes: []string{"m5.2xlarge", "m5.4xlarge"}},
+		},
+	}, config)
+
+	assert.True(t, config.IsPlanAvailable("cf-eu10", AzurePlanID))
+	assert.False(t, config.IsPlanAvailable("cf-eu10", GCPPlanID))
+	assert.True(t, config.IsPlanAvailable("cf-ap10", GCPPlanID))
+}
+
+func TestPlansConfig_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		config PlansConfig
+		expErr string
+	}{
+		"unknown plan": {
+			config: PlansConfig{"cf-eu10": {"openstack": {}}},
+			expErr: "plan openstack configured for the platform region cf-eu10 does not exist",
+		},
+		"region not offered by the plan": {
+			config: PlansConfig{"cf-eu10": {"azure": {Regions: []string{"europe-west3"}}}},
+			expErr: "plan azure does not offer the region europe-west3 configured for the platform region cf-eu10",
+		},
+		"machine type not offered by the plan": {
+			config: PlansConfig{"cf-eu10": {"trial": {MachineTypes: []string{"Standard_D8_v3"}}}},
+			expErr: "plan trial does not offer the machine type Standard_D8_v3 configured for the platform region cf-eu10",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			err := tc.config.Validate()
+
+			// then
+			assert.EqualError(t, err, tc.expErr)
+		})
+	}
+}
@@ -8,6 +8,9 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -36,6 +39,12 @@ func TestSchemaGenerator(t *testing.T) {
 			machineTypes: []string{"n1-standard-2", "n1-standard-4", "n1-standard-8", "n1-standard-16", "n1-standard-32", "n1-standard-64"},
 			file:         "gcp-schema.json",
 		},
+		{
+			name:         "AWS schema is correct",
+			generator:    AWSSchema,
+			machineTypes: []string{"m5.2xlarge", "m5.4xlarge", "m5.8xlarge", "m5.12xlarge"},
+			file:         "aws-schema.json",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -49,6 +58,60 @@ func TestTrialSchemaGenerator(t *testing.T) {
 	validateSchema(t, TrialSchema(), "azure-trial-schema.json")
 }
 
+func TestIsPlanTransitionAllowed(t *testing.T) {
+	gcp := internal.Gcp
+	for name, tc := range map[string]struct {
+		parameters internal.ProvisioningParameters
+		toPlanID   string
+		expected   bool
+	}{
+		"trial to azure": {
+			parameters: internal.ProvisioningParameters{PlanID: TrialPlanID},
+			toPlanID:   AzurePlanID,
+			expected:   true,
+		},
+		"trial to azure lite": {
+			parameters: internal.ProvisioningParameters{PlanID: TrialPlanID},
+			toPlanID:   AzureLitePlanID,
+			expected:   true,
+		},
+		"gcp trial to gcp": {
+			parameters: internal.ProvisioningParameters{PlanID: TrialPlanID, Parameters: internal.ProvisioningParametersDTO{Provider: &gcp}},
+			toPlanID:   GCPPlanID,
+			expected:   true,
+		},
+		"gcp trial to azure": {
+			parameters: internal.ProvisioningParameters{PlanID: TrialPlanID, Parameters: internal.ProvisioningParametersDTO{Provider: &gcp}},
+			toPlanID:   AzurePlanID,
+			expected:   false,
+		},
+		"azure trial to aws": {
+			parameters: internal.ProvisioningParameters{PlanID: TrialPlanID},
+			toPlanID:   AWSPlanID,
+			expected:   false,
+		},
+		"azure lite to azure": {
+			parameters: internal.ProvisioningParameters{PlanID: AzureLitePlanID},
+			toPlanID:   AzurePlanID,
+			expected:   true,
+		},
+		"azure to azure lite": {
+			parameters: internal.ProvisioningParameters{PlanID: AzurePlanID},
+			toPlanID:   AzureLitePlanID,
+			expected:   false,
+		},
+		"azure to trial": {
+			parameters: internal.ProvisioningParameters{PlanID: AzurePlanID},
+			toPlanID:   TrialPlanID,
+			expected:   false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsPlanTransitionAllowed(tc.parameters, tc.toPlanID))
+		})
+	}
+}
+
 func validateSchema(t *testing.T, got []byte, file string) {
 	var prettyWant bytes.Buffer
 
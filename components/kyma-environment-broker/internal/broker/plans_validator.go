@@ -16,13 +16,67 @@ type JSONSchemaValidator interface {
 
 type PlansSchemaValidator map[string]JSONSchemaValidator
 
-func NewPlansSchemaValidator() (PlansSchemaValidator, error) {
-	planIDs := []string{GCPPlanID, AzurePlanID, AzureLitePlanID, TrialPlanID}
+// RegionalPlansSchemaValidator holds the validators of the plans offered in the platform regions,
+// the validators of the platform regions which are not configured are stored under the empty key
+type RegionalPlansSchemaValidator map[string]PlansSchemaValidator
+
+// ForRegion returns the validators of the plans offered in the platform region
+func (v RegionalPlansSchemaValidator) ForRegion(platformRegion string) PlansSchemaValidator {
+	if validator, found := v[platformRegion]; found {
+		return validator
+	}
+	return v[""]
+}
+
+// NewPlansSchemaValidator creates validators of the provisioning parameters of the plans offered in the platform regions
+func NewPlansSchemaValidator(cfg PlansConfig) (RegionalPlansSchemaValidator, error) {
+	return newRegionalPlansSchemaValidator(cfg, func(planID string, planCfg PlanConfig) []byte {
+		provisioningRawSchema, _ := planSchemas(planID, planCfg)
+		return provisioningRawSchema
+	})
+}
+
+// NewPlansUpdateSchemaValidator creates validators of the parameters of the update (PATCH) requests
+func NewPlansUpdateSchemaValidator(cfg PlansConfig) (RegionalPlansSchemaValidator, error) {
+	return newRegionalPlansSchemaValidator(cfg, func(planID string, planCfg PlanConfig) []byte {
+		_, updateRawSchema := planSchemas(planID, planCfg)
+		return updateRawSchema
+	})
+}
+
+func newRegionalPlansSchemaValidator(cfg PlansConfig, rawSchema func(planID string, planCfg PlanConfig) []byte) (RegionalPlansSchemaValidator, error) {
+	validators := RegionalPlansSchemaValidator{}
+
+	platformRegions := []string{""}
+	for platformRegion := range cfg {
+		platformRegions = append(platformRegions, platformRegion)
+	}
+	for _, platformRegion := range platformRegions {
+		validator, err := newPlansSchemaValidator(func(planID string) ([]byte, bool) {
+			if !cfg.IsPlanAvailable(platformRegion, planID) {
+				return nil, false
+			}
+			return rawSchema(planID, cfg.planConfig(platformRegion, planID)), true
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "while creating schema validators for platform region %q", platformRegion)
+		}
+		validators[platformRegion] = validator
+	}
+
+	return validators, nil
+}
+
+func newPlansSchemaValidator(rawSchema func(planID string) ([]byte, bool)) (PlansSchemaValidator, error) {
+	planIDs := []string{GCPPlanID, AzurePlanID, AzureLitePlanID, TrialPlanID, AWSPlanID}
 	validators := PlansSchemaValidator{}
 
 	for _, id := range planIDs {
-		schema := string(Plans[id].provisioningRawSchema)
-		validator, err := jsonschema.NewValidatorFromStringSchema(schema)
+		raw, available := rawSchema(id)
+		if !available {
+			continue
+		}
+		validator, err := jsonschema.NewValidatorFromStringSchema(string(raw))
 		if err != nil {
 			return nil, errors.Wrapf(err, "while creating schema validator for Plan ID %s", id)
 		}
@@ -32,12 +32,12 @@ func TestNewPlansSchemaValidatorErrors(t *testing.T) {
 	for tN, tC := range tests {
 		t.Run(tN, func(t *testing.T) {
 			// given
-			validator, err := NewPlansSchemaValidator()
+			validator, err := NewPlansSchemaValidator(nil)
 			require.NoError(t, err)
 
 			for _, id := range tC.againstPlans {
 				// when
-				result, err := validator[id].ValidateString(tC.inputJSON)
+				result, err := validator.ForRegion("")[id].ValidateString(tC.inputJSON)
 				require.NoError(t, err)
 
 				// then
@@ -52,12 +52,12 @@ func TestNewPlansSchemaValidatorSuccess(t *testing.T) {
 	// given
 	validJSON := `{"name": "only-name-is-required"}`
 
-	validator, err := NewPlansSchemaValidator()
+	validator, err := NewPlansSchemaValidator(nil)
 	require.NoError(t, err)
 
 	for _, id := range []string{GCPPlanID, AzurePlanID, TrialPlanID} {
 		// when
-		result, err := validator[id].ValidateString(validJSON)
+		result, err := validator.ForRegion("")[id].ValidateString(validJSON)
 		require.NoError(t, err)
 
 		// then
@@ -68,3 +68,31 @@ func TestNewPlansSchemaValidatorSuccess(t *testing.T) {
 		assert.Nil(t, result.Error)
 	}
 }
+
+func TestNewPlansSchemaValidatorForRegion(t *testing.T) {
+	// given
+	validator, err := NewPlansSchemaValidator(PlansConfig{
+		"cf-us10": {
+			"aws": {Regions: []string{"us-east-1"}, MachineTypes: []string{"m5.2xlarge"}},
+		},
+	})
+	require.NoError(t, err)
+
+	// when
+	regional := validator.ForRegion("cf-us10")
+	result, err := regional[AWSPlanID].ValidateString(`{"name": "cluster", "region": "us-east-1", "machineType": "m5.4xlarge"}`)
+
+	// then
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.EqualError(t, result.Error, `machineType: machineType must be one of the following: "m5.2xlarge"`)
+	assert.NotContains(t, regional, AzurePlanID)
+
+	// when
+	result, err = validator.ForRegion("cf-eu10")[AWSPlanID].ValidateString(`{"name": "cluster", "region": "eu-central-1", "machineType": "m5.4xlarge"}`)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Contains(t, validator.ForRegion("cf-eu10"), AzurePlanID)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/middleware"
+
 	"github.com/pivotal-cf/brokerapi/v7/domain"
 	"github.com/sirupsen/logrus"
 )
@@ -12,10 +14,12 @@ type ServicesEndpoint struct {
 	log logrus.FieldLogger
 	cfg Service
 
-	enabledPlanIDs map[string]struct{}
+	enabledPlanIDs  map[string]struct{}
+	plansConfig     PlansConfig
+	maintenanceInfo *domain.MaintenanceInfo
 }
 
-func NewServices(cfg Config, log logrus.FieldLogger) *ServicesEndpoint {
+func NewServices(cfg Config, plansConfig PlansConfig, kymaVersion string, log logrus.FieldLogger) *ServicesEndpoint {
 	enabledPlanIDs := map[string]struct{}{}
 	for _, planName := range cfg.EnablePlans {
 		id := PlanIDsMapping[planName]
@@ -23,28 +27,45 @@ func NewServices(cfg Config, log logrus.FieldLogger) *ServicesEndpoint {
 	}
 
 	return &ServicesEndpoint{
-		log:            log.WithField("service", "ServicesEndpoint"),
-		cfg:            cfg.Service,
-		enabledPlanIDs: enabledPlanIDs,
+		log:             log.WithField("service", "ServicesEndpoint"),
+		cfg:             cfg.Service,
+		enabledPlanIDs:  enabledPlanIDs,
+		plansConfig:     plansConfig,
+		maintenanceInfo: MaintenanceInfoForVersion(kymaVersion),
 	}
 }
 
-// Services gets the catalog of services offered by the service broker
+// Services gets the catalog of services offered by the service broker in the platform region of the request
 //   GET /v2/catalog
 func (b *ServicesEndpoint) Services(ctx context.Context) ([]domain.Service, error) {
 	var availableServicePlans []domain.ServicePlan
 
+	platformRegion, _ := middleware.RegionFromContext(ctx)
 	for _, plan := range Plans {
 		// filter out not enabled plans
 		if _, exists := b.enabledPlanIDs[plan.PlanDefinition.ID]; !exists {
 			continue
 		}
+		// filter out plans not offered in the platform region
+		if !b.plansConfig.IsPlanAvailable(platformRegion, plan.PlanDefinition.ID) {
+			continue
+		}
+		provisioningRawSchema, updateRawSchema := planSchemas(plan.PlanDefinition.ID, b.plansConfig.planConfig(platformRegion, plan.PlanDefinition.ID))
+
+		// the schemas differ between the platform regions, they cannot be unmarshalled into the shared plan definition
 		p := plan.PlanDefinition
-		err := json.Unmarshal(plan.provisioningRawSchema, &p.Schemas.Instance.Create.Parameters)
+		p.Schemas = &domain.ServiceSchemas{}
+		err := json.Unmarshal(provisioningRawSchema, &p.Schemas.Instance.Create.Parameters)
 		if err != nil {
 			b.log.Errorf("while unmarshal schema: %s", err)
 			return nil, err
 		}
+		err = json.Unmarshal(updateRawSchema, &p.Schemas.Instance.Update.Parameters)
+		if err != nil {
+			b.log.Errorf("while unmarshal update schema: %s", err)
+			return nil, err
+		}
+		p.MaintenanceInfo = b.maintenanceInfo
 		availableServicePlans = append(availableServicePlans, p)
 	}
 
@@ -55,6 +76,7 @@ func (b *ServicesEndpoint) Services(ctx context.Context) ([]domain.Service, erro
 			Description:          "[EXPERIMENTAL] Service Class for Kyma Runtime",
 			Bindable:             true,
 			InstancesRetrievable: true,
+			PlanUpdatable:        true,
 			Tags: []string{
 				"SAP",
 				"Kyma",
@@ -20,7 +20,7 @@ func TestServices_Services(t *testing.T) {
 	cfg := broker.Config{EnablePlans: []string{"gcp", "azure"}}
 	cfg.DisplayName = name
 	cfg.SupportUrl = supportURL
-	servicesEndpoint := broker.NewServices(cfg, logrus.StandardLogger())
+	servicesEndpoint := broker.NewServices(cfg, nil, "1.20.0", logrus.StandardLogger())
 
 	// when
 	services, err := servicesEndpoint.Services(context.TODO())
@@ -32,4 +32,53 @@ func TestServices_Services(t *testing.T) {
 
 	assert.Equal(t, name, services[0].Metadata.DisplayName)
 	assert.Equal(t, supportURL, services[0].Metadata.SupportUrl)
+
+	for _, plan := range services[0].Plans {
+		require.NotNil(t, plan.MaintenanceInfo)
+		assert.Equal(t, "1.20.0", plan.MaintenanceInfo.Version)
+	}
+}
+
+func TestServices_ServicesWithoutMaintenanceInfo(t *testing.T) {
+	// given
+	cfg := broker.Config{EnablePlans: []string{"gcp", "azure"}}
+	servicesEndpoint := broker.NewServices(cfg, nil, "PR-1234", logrus.StandardLogger())
+
+	// when
+	services, err := servicesEndpoint.Services(context.TODO())
+
+	// then
+	require.NoError(t, err)
+	for _, plan := range services[0].Plans {
+		assert.Nil(t, plan.MaintenanceInfo)
+	}
+}
+
+func TestServices_ServicesInRegion(t *testing.T) {
+	// given
+	cfg := broker.Config{EnablePlans: []string{"gcp", "azure", "aws"}}
+	plansConfig := broker.PlansConfig{
+		"cf-us10": {
+			"aws": {Regions: []string{"us-east-1"}},
+		},
+	}
+	servicesEndpoint := broker.NewServices(cfg, plansConfig, "1.20.0", logrus.StandardLogger())
+
+	// when
+	services, err := servicesEndpoint.Services(fixReqCtxWithRegion(t, "cf-us10"))
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, services[0].Plans, 1)
+	plan := services[0].Plans[0]
+	assert.Equal(t, broker.AWSPlanID, plan.ID)
+	properties := plan.Schemas.Instance.Create.Parameters["properties"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"us-east-1"}, properties["region"].(map[string]interface{})["enum"])
+
+	// when
+	services, err = servicesEndpoint.Services(fixReqCtxWithRegion(t, "cf-eu10"))
+
+	// then
+	require.NoError(t, err)
+	assert.Len(t, services[0].Plans, 3)
 }
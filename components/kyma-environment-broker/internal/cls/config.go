@@ -3,6 +3,7 @@ package cls
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"strings"
 
 	"gopkg.in/yaml.v2"
@@ -106,6 +107,16 @@ func Load(s string) (*Config, error) {
 	return &config, nil
 }
 
+// ReadConfigFromFile reads the file with the given name and parses its content into a Config
+func ReadConfigFromFile(filename string) (*Config, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("while reading %s file with cls config: %v", filename, err)
+	}
+
+	return Load(string(content))
+}
+
 func (c *Config) validate() error {
 	if c.ServiceManager == nil || len(c.ServiceManager.Credentials) == 0 {
 		return errors.New("no service manager credentials")
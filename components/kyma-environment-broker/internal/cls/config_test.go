@@ -1,6 +1,9 @@
 package cls
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -107,3 +110,32 @@ saml:
 	require.Equal(t, 2, config.MaxDataInstances)
 	require.Equal(t, 2, config.MaxIngestInstances)
 }
+
+func TestReadConfigFromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cls-config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "config.yaml")
+	require.NoError(t, ioutil.WriteFile(filename, []byte(`
+retentionPeriod: 14
+serviceManager:
+  credentials:
+    - region: eu
+      url: https://service-manager.cfapps.sap.hana.ondemand.com
+      username: sm
+      password: qwerty
+saml:
+  initiated: true
+`), 0644))
+
+	config, err := ReadConfigFromFile(filename)
+
+	require.NoError(t, err)
+	require.Equal(t, 14, config.RetentionPeriod)
+	require.Equal(t, RegionEurope, config.ServiceManager.Credentials[0].Region)
+
+	_, err = ReadConfigFromFile(filepath.Join(dir, "missing.yaml"))
+
+	require.Error(t, err)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/servicemanager"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
@@ -23,6 +24,9 @@ type InstanceCreator interface {
 	CreateInstance(smClient servicemanager.Client, instance servicemanager.InstanceKey) error
 }
 
+// maxProvisionAttempts is the number of attempts to provision the instance which is concurrently modified
+const maxProvisionAttempts = 3
+
 type provisioner struct {
 	storage ProvisionerStorage
 	creator InstanceCreator
@@ -50,7 +54,20 @@ type ProvisionResult struct {
 	Region                string
 }
 
+// Provision references the CLS instance of the global account or creates a new one. The attempt is repeated if the instance
+// was concurrently created or referenced by another SKR.
 func (p *provisioner) Provision(smClient servicemanager.Client, request *ProvisionRequest) (*ProvisionResult, error) {
+	for attempt := 1; ; attempt++ {
+		result, err := p.provision(smClient, request)
+		if err != nil && attempt < maxProvisionAttempts && isConcurrentModification(errors.Cause(err)) {
+			p.log.Infof("The cls instance for global account %s was modified concurrently, retrying: %s", request.GlobalAccountID, err)
+			continue
+		}
+		return result, err
+	}
+}
+
+func (p *provisioner) provision(smClient servicemanager.Client, request *ProvisionRequest) (*ProvisionResult, error) {
 	instance, exists, err := p.storage.FindInstance(request.GlobalAccountID)
 	if err != nil {
 		return nil, errors.Wrapf(err, "while checking if instance is already created for global account %s", request.GlobalAccountID)
@@ -105,3 +122,11 @@ func (p *provisioner) createNewInstance(smClient servicemanager.Client, request
 		Region:                request.Region,
 	}, nil
 }
+
+func isConcurrentModification(err error) bool {
+	dbErr, ok := err.(dberr.Error)
+	if !ok {
+		return false
+	}
+	return dbErr.Code() == dberr.CodeConflict || dbErr.Code() == dberr.CodeAlreadyExists
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/logger"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/servicemanager"
 	smautomock "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/servicemanager/automock"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -236,3 +237,39 @@ func TestProvisionAddsReferenceIfFoundInDB(t *testing.T) {
 	storageMock.AssertNumberOfCalls(t, "InsertInstance", 0)
 	creatorMock.AssertNumberOfCalls(t, "CreateInstance", 0)
 }
+
+func TestProvisionRetriesReferenceIfInstanceWasModifiedConcurrently(t *testing.T) {
+	const (
+		fakeGlobalAccountID = "fake-global-account-id"
+		fakeSKRInstanceID   = "fake-skr-instance-id"
+		fakeInstanceID      = "fake-instance-id"
+	)
+
+	storageMock := &automock.ProvisionerStorage{}
+	storageMock.On("FindInstance", fakeGlobalAccountID).Return(&internal.CLSInstance{
+		ID:              fakeInstanceID,
+		GlobalAccountID: fakeGlobalAccountID,
+		Version:         1,
+	}, true, nil).Once()
+	storageMock.On("FindInstance", fakeGlobalAccountID).Return(&internal.CLSInstance{
+		ID:              fakeInstanceID,
+		GlobalAccountID: fakeGlobalAccountID,
+		Version:         2,
+	}, true, nil).Once()
+	storageMock.On("Reference", 1, fakeGlobalAccountID, fakeSKRInstanceID).Return(dberr.Conflict("version mismatch")).Once()
+	storageMock.On("Reference", 2, fakeGlobalAccountID, fakeSKRInstanceID).Return(nil).Once()
+
+	smClientMock := &smautomock.Client{}
+	creatorMock := &automock.InstanceCreator{}
+
+	sut := NewProvisioner(storageMock, creatorMock, logger.NewLogDummy())
+	result, err := sut.Provision(smClientMock, &ProvisionRequest{
+		GlobalAccountID: fakeGlobalAccountID,
+		SKRInstanceID:   fakeSKRInstanceID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, fakeInstanceID, result.InstanceID)
+	require.False(t, result.ProvisioningTriggered)
+
+	storageMock.AssertExpectations(t)
+}
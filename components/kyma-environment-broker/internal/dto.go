@@ -45,6 +45,7 @@ type TrialCloudProvider string
 
 const Gcp TrialCloudProvider = "GCP"
 const Azure TrialCloudProvider = "Azure"
+const Aws TrialCloudProvider = "AWS"
 
 type ProvisioningParametersDTO struct {
 	Name         string  `json:"name"`
@@ -68,6 +69,43 @@ type ProvisioningParametersDTO struct {
 	Provider *TrialCloudProvider `json:"provider"`
 }
 
+// UpdatingParametersDTO holds the parameters of the instance which can be changed by the update (PATCH) request
+type UpdatingParametersDTO struct {
+	MachineType    *string `json:"machineType,omitempty"`
+	VolumeSizeGb   *int    `json:"volumeSizeGb,omitempty"`
+	AutoScalerMin  *int    `json:"autoScalerMin,omitempty"`
+	AutoScalerMax  *int    `json:"autoScalerMax,omitempty"`
+	MaxSurge       *int    `json:"maxSurge,omitempty"`
+	MaxUnavailable *int    `json:"maxUnavailable,omitempty"`
+}
+
+// IsEmpty returns true if none of the parameters is set
+func (u UpdatingParametersDTO) IsEmpty() bool {
+	return reflect.DeepEqual(u, UpdatingParametersDTO{})
+}
+
+// UpdateProvisioningParameters overwrites the provisioning parameters with the values which are set
+func (u UpdatingParametersDTO) UpdateProvisioningParameters(params *ProvisioningParametersDTO) {
+	if u.MachineType != nil {
+		params.MachineType = u.MachineType
+	}
+	if u.VolumeSizeGb != nil {
+		params.VolumeSizeGb = u.VolumeSizeGb
+	}
+	if u.AutoScalerMin != nil {
+		params.AutoScalerMin = u.AutoScalerMin
+	}
+	if u.AutoScalerMax != nil {
+		params.AutoScalerMax = u.AutoScalerMax
+	}
+	if u.MaxSurge != nil {
+		params.MaxSurge = u.MaxSurge
+	}
+	if u.MaxUnavailable != nil {
+		params.MaxUnavailable = u.MaxUnavailable
+	}
+}
+
 type ERSContext struct {
 	TenantID        string                  `json:"tenant_id"`
 	SubAccountID    string                  `json:"subaccount_id"`
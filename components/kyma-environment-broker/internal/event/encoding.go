@@ -0,0 +1,74 @@
+package event
+
+import (
+	"bytes"
+	"encoding/gob"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+func init() {
+	gob.Register(new(eventError))
+}
+
+// eventError is the error restored from the stored event, the errors are stored as their messages
+type eventError string
+
+func (e *eventError) Error() string {
+	return string(*e)
+}
+
+// encode serializes the event with gob, so that also the fields which are skipped in JSON are stored. The errors are
+// stored as their messages and the other interface fields are dropped, because their implementations cannot be restored.
+func encode(ev interface{}) ([]byte, error) {
+	v := reflect.New(reflect.TypeOf(ev)).Elem()
+	v.Set(reflect.ValueOf(ev))
+	forEachInterfaceField(v, func(field reflect.Value) {
+		if field.IsNil() {
+			return
+		}
+		if field.Type() == errorType {
+			msg := eventError(field.Interface().(error).Error())
+			field.Set(reflect.ValueOf(&msg))
+			return
+		}
+		field.Set(reflect.Zero(field.Type()))
+	})
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).EncodeValue(v); err != nil {
+		return nil, errors.Wrapf(err, "while encoding %s", v.Type())
+	}
+	return buf.Bytes(), nil
+}
+
+// decode restores the event of the given type, the errors are restored as eventError
+func decode(tt reflect.Type, payload []byte) (interface{}, error) {
+	v := reflect.New(tt)
+	if err := gob.NewDecoder(bytes.NewReader(payload)).DecodeValue(v); err != nil {
+		return nil, errors.Wrapf(err, "while decoding %s", tt)
+	}
+	return v.Elem().Interface(), nil
+}
+
+// forEachInterfaceField calls fn for every exported field of the interface type of the struct, including the nested structs
+func forEachInterfaceField(v reflect.Value, fn func(field reflect.Value)) {
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+		switch field.Kind() {
+		case reflect.Interface:
+			fn(field)
+		case reflect.Struct:
+			forEachInterfaceField(field, fn)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// OutboxConfig configures the delivery of the events stored in the broker database
+type OutboxConfig struct {
+	// PollInterval is the interval between the checks of the pending events, the published events are delivered immediately
+	PollInterval time.Duration `envconfig:"default=5s"`
+	// BatchSize is the maximum number of the events of one subscriber claimed from the database at once
+	BatchSize int `envconfig:"default=100"`
+	// ClaimTimeout is the time the claimed events are reserved for the delivery, the events which were not delivered
+	// within this time, for example because the application stopped, are claimed again
+	ClaimTimeout time.Duration `envconfig:"default=5m"`
+	// MaxAttempts is the number of the failed deliveries after which the event is moved to the dead state
+	MaxAttempts int `envconfig:"default=10"`
+	// RetryInterval is the delay of the first retry, it is doubled with every next attempt up to MaxRetryInterval
+	RetryInterval    time.Duration `envconfig:"default=10s"`
+	MaxRetryInterval time.Duration `envconfig:"default=10m"`
+}
+
+// Outbox implements the event broker which stores the published events in the broker database and delivers them
+// to the subscribers asynchronously. The event is stored separately for every subscriber, so the subscribers receive
+// the event independently and the failure of one of them does not cause the redelivery to the others. Every subscriber
+// has its own worker, so a slow subscriber does not delay the others, and the events are claimed before the delivery,
+// so the instances of the application running in parallel do not deliver the same event. The events survive
+// the restart of the application and are delivered at least once, there is no guarantee of the delivery order.
+// The event which could not be delivered within MaxAttempts attempts is moved to the dead state and kept in the database.
+type Outbox struct {
+	mu  sync.RWMutex
+	log logrus.FieldLogger
+	cfg OutboxConfig
+
+	events      storage.OutboxEvents
+	subscribers map[reflect.Type][]subscriber
+	// types map the stored names of the event types to the types the payloads are decoded to
+	types map[string]reflect.Type
+	// notify contains the channel triggering the worker of every subscriber name
+	notify map[string]chan struct{}
+}
+
+// subscriber is the handler identified by the name which is stored with the event, the name must not change
+// between the restarts of the application
+type subscriber struct {
+	name    string
+	handler Handler
+}
+
+func NewOutbox(events storage.OutboxEvents, cfg OutboxConfig, log logrus.FieldLogger) *Outbox {
+	return &Outbox{
+		log:         log.WithField("service", "outbox"),
+		cfg:         cfg,
+		events:      events,
+		subscribers: make(map[reflect.Type][]subscriber),
+		types:       make(map[string]reflect.Type),
+		notify:      make(map[string]chan struct{}),
+	}
+}
+
+// Publish stores the event in the database, the event is delivered asynchronously. The events without any subscriber
+// are not stored.
+func (o *Outbox) Publish(_ context.Context, ev interface{}) {
+	events, err := o.Stage(ev)
+	if err != nil {
+		o.log.Errorf("while staging event %s: %s", reflect.TypeOf(ev), err)
+		return
+	}
+	o.PublishStaged(events)
+}
+
+// PublishStaged stores the staged events which could not be stored together with the change they are about
+func (o *Outbox) PublishStaged(events []internal.OutboxEvent) {
+	for _, stored := range events {
+		if err := o.events.Insert(stored); err != nil {
+			o.log.Errorf("while storing event %s for %s: %s", stored.Type, stored.Subscriber, err)
+		}
+	}
+	if len(events) > 0 {
+		o.Notify()
+	}
+}
+
+// Stage returns the event prepared to be stored for every subscriber, the caller is responsible for storing
+// the returned events, for example in one transaction with the change the event is about, and calling Notify.
+func (o *Outbox) Stage(ev interface{}) ([]internal.OutboxEvent, error) {
+	tt := reflect.TypeOf(ev)
+	o.mu.RLock()
+	subscribers := o.subscribers[tt]
+	o.mu.RUnlock()
+	if len(subscribers) == 0 {
+		return nil, nil
+	}
+
+	payload, err := encode(ev)
+	if err != nil {
+		return nil, errors.Wrap(err, "while encoding event")
+	}
+	now := time.Now()
+	events := make([]internal.OutboxEvent, 0, len(subscribers))
+	for _, sub := range subscribers {
+		events = append(events, internal.OutboxEvent{
+			ID:            uuid.New().String(),
+			Type:          tt.String(),
+			Payload:       payload,
+			Subscriber:    sub.name,
+			State:         internal.OutboxEventPending,
+			CreatedAt:     now,
+			NextAttemptAt: now,
+		})
+	}
+	return events, nil
+}
+
+// Notify triggers the delivery of the stored events
+func (o *Outbox) Notify() {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	for _, notify := range o.notify {
+		select {
+		case notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Subscribe registers the handler of the events of the given type. The name is stored with the events, so it must not
+// change between the releases of the application, otherwise the events stored before are not delivered. One subscriber
+// can handle many event types with the same name, the name must be unique for the event type.
+func (o *Outbox) Subscribe(name string, evType interface{}, evHandler Handler) {
+	tt := reflect.TypeOf(evType)
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, sub := range o.subscribers[tt] {
+		if sub.name == name {
+			panic(fmt.Sprintf("subscriber %s of the event type %s is already registered", name, tt))
+		}
+	}
+	if _, found := o.notify[name]; !found {
+		o.notify[name] = make(chan struct{}, 1)
+	}
+
+	o.subscribers[tt] = append(o.subscribers[tt], subscriber{name: name, handler: evHandler})
+	o.types[tt.String()] = tt
+}
+
+// Run starts the delivery worker of every subscriber and blocks until the channel is closed, the subscribers
+// must be registered before
+func (o *Outbox) Run(stopCh <-chan struct{}) {
+	o.mu.RLock()
+	notify := make(map[string]chan struct{}, len(o.notify))
+	for name, ch := range o.notify {
+		notify[name] = ch
+	}
+	o.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for name, ch := range notify {
+		wg.Add(1)
+		go func(name string, notify <-chan struct{}) {
+			defer wg.Done()
+			o.runWorker(name, notify, stopCh)
+		}(name, ch)
+	}
+	wg.Wait()
+}
+
+// runWorker delivers the events of one subscriber until the channel is closed
+func (o *Outbox) runWorker(name string, notify <-chan struct{}, stopCh <-chan struct{}) {
+	ticker := time.NewTicker(o.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		delivered, err := o.deliverPending(name)
+		if err != nil {
+			o.log.Errorf("while delivering events to %s: %s", name, err)
+		}
+		if err == nil && delivered == o.cfg.BatchSize {
+			// there can be more pending events
+			continue
+		}
+
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+		case <-notify:
+		}
+	}
+}
+
+// DeliverPending delivers the pending events of all subscribers which are due and returns the number of the processed events
+func (o *Outbox) DeliverPending() (int, error) {
+	o.mu.RLock()
+	names := make([]string, 0, len(o.notify))
+	for name := range o.notify {
+		names = append(names, name)
+	}
+	o.mu.RUnlock()
+
+	var result *multierror.Error
+	delivered := 0
+	for _, name := range names {
+		count, err := o.deliverPending(name)
+		if err != nil {
+			result = multierror.Append(result, errors.Wrapf(err, "while delivering events to %s", name))
+		}
+		delivered += count
+	}
+	return delivered, result.ErrorOrNil()
+}
+
+// deliverPending claims the pending events of the subscriber which are due and delivers them, the events which were
+// claimed but not processed because of the error are claimed again when the claim expires
+func (o *Outbox) deliverPending(name string) (int, error) {
+	now := time.Now()
+	events, err := o.events.ClaimPending(name, now, now.Add(o.cfg.ClaimTimeout), o.cfg.BatchSize)
+	if err != nil {
+		return 0, errors.Wrap(err, "while claiming pending events")
+	}
+
+	for i, ev := range events {
+		if err := o.deliver(ev); err != nil {
+			return i, err
+		}
+	}
+	return len(events), nil
+}
+
+// deliver calls the subscriber of the event, the delivered event is removed and the failed one is scheduled for the retry
+func (o *Outbox) deliver(ev internal.OutboxEvent) error {
+	log := o.log.WithFields(logrus.Fields{"eventID": ev.ID, "eventType": ev.Type, "subscriber": ev.Subscriber})
+
+	err := o.handle(ev)
+	if err == nil {
+		if err := o.events.Delete(ev.ID); err != nil {
+			return errors.Wrapf(err, "while removing delivered event %s", ev.ID)
+		}
+		return nil
+	}
+
+	ev.Attempts++
+	ev.LastError = err.Error()
+	if ev.Attempts >= o.cfg.MaxAttempts {
+		log.Errorf("Event could not be delivered in %d attempts, moving it to the dead state: %s", ev.Attempts, err)
+		ev.State = internal.OutboxEventDead
+	} else {
+		delay := o.retryDelay(ev.Attempts)
+		log.Warnf("Event delivery failed (attempt %d of %d), it will be retried in %s: %s", ev.Attempts, o.cfg.MaxAttempts, delay, err)
+		ev.NextAttemptAt = time.Now().Add(delay)
+	}
+	if err := o.events.Update(ev); err != nil {
+		return errors.Wrapf(err, "while updating failed event %s", ev.ID)
+	}
+	return nil
+}
+
+func (o *Outbox) handle(ev internal.OutboxEvent) error {
+	o.mu.RLock()
+	tt, found := o.types[ev.Type]
+	var handler Handler
+	for _, sub := range o.subscribers[tt] {
+		if sub.name == ev.Subscriber {
+			handler = sub.handler
+		}
+	}
+	o.mu.RUnlock()
+	if !found {
+		return fmt.Errorf("there is no subscriber of the event type %s", ev.Type)
+	}
+	if handler == nil {
+		return fmt.Errorf("there is no subscriber %s of the event type %s", ev.Subscriber, ev.Type)
+	}
+
+	payload, err := decode(tt, ev.Payload)
+	if err != nil {
+		return errors.Wrap(err, "while decoding event")
+	}
+
+	return handler(context.Background(), payload)
+}
+
+func (o *Outbox) retryDelay(attempts int) time.Duration {
+	delay := o.cfg.RetryInterval
+	for i := 1; i < attempts && delay < o.cfg.MaxRetryInterval; i++ {
+		delay *= 2
+	}
+	if delay > o.cfg.MaxRetryInterval {
+		return o.cfg.MaxRetryInterval
+	}
+	return delay
+}
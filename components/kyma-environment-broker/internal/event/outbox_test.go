@@ -0,0 +1,335 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutbox(t *testing.T) {
+	t.Run("should deliver events to all subscribers", func(t *testing.T) {
+		// given
+		events := storage.NewMemoryStorage().OutboxEvents()
+		var gotA1, gotA2 []storedEventA
+		var gotB []storedEventB
+		svc := event.NewOutbox(events, fixOutboxConfig(), logrus.New())
+		svc.Subscribe("a1", storedEventA{}, func(ctx context.Context, ev interface{}) error {
+			gotA1 = append(gotA1, ev.(storedEventA))
+			return nil
+		})
+		svc.Subscribe("b", storedEventB{}, func(ctx context.Context, ev interface{}) error {
+			gotB = append(gotB, ev.(storedEventB))
+			return nil
+		})
+		svc.Subscribe("a2", storedEventA{}, func(ctx context.Context, ev interface{}) error {
+			gotA2 = append(gotA2, ev.(storedEventA))
+			return nil
+		})
+
+		// when
+		svc.Publish(context.TODO(), storedEventA{Msg: "first event"})
+		svc.Publish(context.TODO(), storedEventB{Msg: "second event"})
+		svc.Publish(context.TODO(), storedEventA{Msg: "third event"})
+		delivered, err := svc.DeliverPending()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 5, delivered)
+		assert.ElementsMatch(t, []storedEventA{{Msg: "first event"}, {Msg: "third event"}}, gotA1)
+		assert.ElementsMatch(t, []storedEventA{{Msg: "first event"}, {Msg: "third event"}}, gotA2)
+		assert.Equal(t, []storedEventB{{Msg: "second event"}}, gotB)
+		assertPendingEvents(t, events, "a1", 0)
+		assertPendingEvents(t, events, "a2", 0)
+		assertPendingEvents(t, events, "b", 0)
+	})
+
+	t.Run("should not redeliver event to the subscriber which handled it", func(t *testing.T) {
+		// given
+		events := storage.NewMemoryStorage().OutboxEvents()
+		succeeded, failed := 0, 0
+		svc := event.NewOutbox(events, fixOutboxConfig(), logrus.New())
+		svc.Subscribe("succeeding", storedEventA{}, func(ctx context.Context, ev interface{}) error {
+			succeeded++
+			return nil
+		})
+		svc.Subscribe("failing", storedEventA{}, func(ctx context.Context, ev interface{}) error {
+			failed++
+			if failed == 1 {
+				return errors.New("some error")
+			}
+			return nil
+		})
+		svc.Publish(context.TODO(), storedEventA{Msg: "first event"})
+
+		// when
+		_, err := svc.DeliverPending()
+		require.NoError(t, err)
+		pendingSucceeding := pendingEvents(t, events, "succeeding")
+		pendingFailing := pendingEvents(t, events, "failing")
+		_, err = svc.DeliverPending()
+		require.NoError(t, err)
+
+		// then
+		assert.Empty(t, pendingSucceeding)
+		assert.Len(t, pendingFailing, 1)
+		assert.Equal(t, 1, succeeded)
+		assert.Equal(t, 2, failed)
+		assertPendingEvents(t, events, "failing", 0)
+	})
+
+	t.Run("should deliver event to the handler subscribed with two names", func(t *testing.T) {
+		// given
+		events := storage.NewMemoryStorage().OutboxEvents()
+		calls := 0
+		handler := func(ctx context.Context, ev interface{}) error {
+			calls++
+			return nil
+		}
+		svc := event.NewOutbox(events, fixOutboxConfig(), logrus.New())
+		svc.Subscribe("first", storedEventA{}, handler)
+		svc.Subscribe("second", storedEventA{}, handler)
+
+		// when
+		svc.Publish(context.TODO(), storedEventA{Msg: "first event"})
+		delivered, err := svc.DeliverPending()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 2, delivered)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("should not allow to subscribe twice with the same name", func(t *testing.T) {
+		// given
+		handler := func(ctx context.Context, ev interface{}) error {
+			return nil
+		}
+		svc := event.NewOutbox(storage.NewMemoryStorage().OutboxEvents(), fixOutboxConfig(), logrus.New())
+		svc.Subscribe("subscriber", storedEventA{}, handler)
+		svc.Subscribe("subscriber", storedEventB{}, handler)
+
+		// when
+		subscribe := func() {
+			svc.Subscribe("subscriber", storedEventA{}, handler)
+		}
+
+		// then
+		assert.Panics(t, subscribe)
+	})
+
+	t.Run("should not store events without subscribers", func(t *testing.T) {
+		// given
+		events := storage.NewMemoryStorage().OutboxEvents()
+		svc := event.NewOutbox(events, fixOutboxConfig(), logrus.New())
+
+		// when
+		svc.Publish(context.TODO(), storedEventA{Msg: "first event"})
+
+		// then
+		assertPendingEvents(t, events, "subscriber", 0)
+	})
+
+	t.Run("should retry failed event and move it to the dead state", func(t *testing.T) {
+		// given
+		events := storage.NewMemoryStorage().OutboxEvents()
+		calls := 0
+		svc := event.NewOutbox(events, fixOutboxConfig(), logrus.New())
+		svc.Subscribe("subscriber", storedEventA{}, func(ctx context.Context, ev interface{}) error {
+			calls++
+			return errors.New("some error")
+		})
+		svc.Publish(context.TODO(), storedEventA{Msg: "first event"})
+
+		// when
+		for i := 0; i < 5; i++ {
+			_, err := svc.DeliverPending()
+			require.NoError(t, err)
+		}
+
+		// then
+		assert.Equal(t, 3, calls)
+		assertPendingEvents(t, events, "subscriber", 0)
+	})
+
+	t.Run("should deliver event after failed attempt", func(t *testing.T) {
+		// given
+		events := storage.NewMemoryStorage().OutboxEvents()
+		var got []storedEventA
+		svc := event.NewOutbox(events, fixOutboxConfig(), logrus.New())
+		svc.Subscribe("subscriber", storedEventA{}, func(ctx context.Context, ev interface{}) error {
+			got = append(got, ev.(storedEventA))
+			if len(got) == 1 {
+				return errors.New("some error")
+			}
+			return nil
+		})
+		svc.Publish(context.TODO(), storedEventA{Msg: "first event"})
+
+		// when
+		_, err := svc.DeliverPending()
+		require.NoError(t, err)
+		pending := pendingEvents(t, events, "subscriber")
+		_, err = svc.DeliverPending()
+		require.NoError(t, err)
+
+		// then
+		require.Len(t, pending, 1)
+		assert.Equal(t, 1, pending[0].Attempts)
+		assert.Equal(t, "some error", pending[0].LastError)
+		assert.Equal(t, []storedEventA{{Msg: "first event"}, {Msg: "first event"}}, got)
+		assertPendingEvents(t, events, "subscriber", 0)
+	})
+
+	t.Run("should not deliver events claimed by another instance until the claim expires", func(t *testing.T) {
+		// given
+		events := storage.NewMemoryStorage().OutboxEvents()
+		calls := 0
+		svc := event.NewOutbox(events, fixOutboxConfig(), logrus.New())
+		svc.Subscribe("subscriber", storedEventA{}, func(ctx context.Context, ev interface{}) error {
+			calls++
+			return nil
+		})
+		svc.Publish(context.TODO(), storedEventA{Msg: "first event"})
+		claimed, err := events.ClaimPending("subscriber", time.Now(), time.Now().Add(100*time.Millisecond), 10)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+
+		// when
+		deliveredWhileClaimed, err := svc.DeliverPending()
+		require.NoError(t, err)
+		time.Sleep(100 * time.Millisecond)
+		deliveredAfterClaim, err := svc.DeliverPending()
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, 0, deliveredWhileClaimed)
+		assert.Equal(t, 1, deliveredAfterClaim)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("should deliver events to subscriber while another subscriber is busy", func(t *testing.T) {
+		// given
+		events := storage.NewMemoryStorage().OutboxEvents()
+		release := make(chan struct{})
+		got := make(chan storedEventA, 2)
+		svc := event.NewOutbox(events, fixOutboxConfig(), logrus.New())
+		svc.Subscribe("slow", storedEventA{}, func(ctx context.Context, ev interface{}) error {
+			<-release
+			return nil
+		})
+		svc.Subscribe("fast", storedEventA{}, func(ctx context.Context, ev interface{}) error {
+			got <- ev.(storedEventA)
+			return nil
+		})
+		stopCh := make(chan struct{})
+		stopped := make(chan struct{})
+		go func() {
+			svc.Run(stopCh)
+			close(stopped)
+		}()
+
+		// when
+		svc.Publish(context.TODO(), storedEventA{Msg: "first event"})
+		svc.Publish(context.TODO(), storedEventA{Msg: "second event"})
+
+		// then
+		for _, msg := range []string{"first event", "second event"} {
+			select {
+			case ev := <-got:
+				assert.Equal(t, msg, ev.Msg)
+			case <-time.After(2 * time.Second):
+				t.Fatalf("event %q was not delivered", msg)
+			}
+		}
+		close(release)
+		close(stopCh)
+		<-stopped
+	})
+
+	t.Run("should restore step processed event with operation and error", func(t *testing.T) {
+		// given
+		events := storage.NewMemoryStorage().OutboxEvents()
+		var got []process.ProvisioningStepProcessed
+		svc := event.NewOutbox(events, fixOutboxConfig(), logrus.New())
+		svc.Subscribe("subscriber", process.ProvisioningStepProcessed{}, func(ctx context.Context, ev interface{}) error {
+			got = append(got, ev.(process.ProvisioningStepProcessed))
+			return nil
+		})
+		operation := fixture.FixProvisioningOperation("operation-id", "instance-id")
+		operation.State = domain.InProgress
+
+		// when
+		svc.Publish(context.TODO(), process.ProvisioningStepProcessed{
+			StepProcessed: process.StepProcessed{
+				StepName: "Create_Runtime",
+				Duration: time.Second,
+				When:     time.Minute,
+				Error:    errors.New("provisioner not available"),
+			},
+			OldOperation: operation,
+			Operation:    operation,
+		})
+		svc.Publish(context.TODO(), process.ProvisioningStepProcessed{
+			StepProcessed: process.StepProcessed{StepName: "Create_Runtime"},
+			OldOperation:  operation,
+			Operation:     operation,
+		})
+		_, err := svc.DeliverPending()
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, "Create_Runtime", got[0].StepName)
+		assert.Equal(t, time.Second, got[0].Duration)
+		assert.Equal(t, time.Minute, got[0].When)
+		require.Error(t, got[0].Error)
+		assert.Equal(t, "provisioner not available", got[0].Error.Error())
+		assert.Equal(t, operation.ID, got[0].Operation.ID)
+		assert.Equal(t, operation.InstanceID, got[0].OldOperation.InstanceID)
+		assert.Equal(t, domain.InProgress, got[0].Operation.State)
+		assert.Equal(t, operation.ProvisioningParameters.PlanID, got[0].Operation.ProvisioningParameters.PlanID)
+		assert.NoError(t, got[1].Error)
+	})
+}
+
+func fixOutboxConfig() event.OutboxConfig {
+	return event.OutboxConfig{
+		PollInterval: time.Second,
+		BatchSize:    10,
+		MaxAttempts:  3,
+	}
+}
+
+func assertPendingEvents(t *testing.T, events storage.OutboxEvents, subscriber string, expected int) {
+	t.Helper()
+	assert.Len(t, pendingEvents(t, events, subscriber), expected)
+}
+
+// pendingEvents returns the pending events of the subscriber which are due within an hour, the claim expires
+// immediately, so the events can be delivered afterwards
+func pendingEvents(t *testing.T, events storage.OutboxEvents, subscriber string) []internal.OutboxEvent {
+	t.Helper()
+	pending, err := events.ClaimPending(subscriber, time.Now().Add(time.Hour), time.Now(), 10)
+	require.NoError(t, err)
+	return pending
+}
+
+type storedEventA struct {
+	Msg string
+}
+
+type storedEventB struct {
+	Msg string
+}
@@ -15,7 +15,8 @@ type Publisher interface {
 }
 
 type Subscriber interface {
-	Subscribe(evType interface{}, evHandler Handler)
+	// Subscribe registers the handler of the event type under the name of the subscriber
+	Subscribe(name string, evType interface{}, evHandler Handler)
 }
 
 // PubSub implements a simple event broker which allows to send event across the application.
@@ -48,7 +49,8 @@ func (b *PubSub) Publish(ctx context.Context, ev interface{}) {
 	}
 }
 
-func (b *PubSub) Subscribe(evType interface{}, evHandler Handler) {
+// Subscribe registers the handler, the name is not used because the events are not stored
+func (b *PubSub) Subscribe(_ string, evType interface{}, evHandler Handler) {
 	tt := reflect.TypeOf(evType)
 	b.mu.Lock()
 	defer b.mu.Unlock()
@@ -0,0 +1,54 @@
+package event
+
+import (
+	"context"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+)
+
+// Stager is implemented by the publishers which allow to store the event together with the change the event is about
+type Stager interface {
+	Stage(ev interface{}) ([]internal.OutboxEvent, error)
+	// PublishStaged stores the staged events which could not be stored together with the change
+	PublishStaged(events []internal.OutboxEvent)
+	Notify()
+}
+
+// Stage returns the event prepared to be stored together with the change the event is about, see StorePending.
+// The event is published directly and nothing is returned when the publisher does not implement Stager
+// or the event could not be staged.
+func Stage(pub Publisher, ev interface{}) []internal.OutboxEvent {
+	stager, ok := pub.(Stager)
+	if !ok {
+		pub.Publish(context.TODO(), ev)
+		return nil
+	}
+
+	events, err := stager.Stage(ev)
+	if err != nil {
+		pub.Publish(context.TODO(), ev)
+		return nil
+	}
+	return events
+}
+
+// StorePending saves the staged events with the store function, which stores them in the same transaction
+// as the change, and triggers the delivery of the stored events. The store function is not called when there are
+// no events. When the change could not be saved, the events are stored alone and the error of the store function is returned.
+func StorePending(pub Publisher, events []internal.OutboxEvent, store func() error) error {
+	stager, ok := pub.(Stager)
+	if !ok {
+		return nil
+	}
+	// the events could be stored also by the previous changes, for example the updates of the operation made by the steps
+	defer stager.Notify()
+
+	if len(events) == 0 {
+		return nil
+	}
+	if err := store(); err != nil {
+		stager.PublishStaged(events)
+		return err
+	}
+	return nil
+}
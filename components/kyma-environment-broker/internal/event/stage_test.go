@@ -0,0 +1,107 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStage(t *testing.T) {
+	t.Run("should return the staged events without storing them", func(t *testing.T) {
+		// given
+		events := storage.NewMemoryStorage().OutboxEvents()
+		svc := event.NewOutbox(events, fixOutboxConfig(), logrus.New())
+		svc.Subscribe("subscriber", storedEventA{}, func(ctx context.Context, ev interface{}) error { return nil })
+
+		// when
+		staged := event.Stage(svc, storedEventA{Msg: "first event"})
+
+		// then
+		require.Len(t, staged, 1)
+		assert.Equal(t, "subscriber", staged[0].Subscriber)
+		assertPendingEvents(t, events, "subscriber", 0)
+	})
+
+	t.Run("should publish the event with the publisher which does not stage events", func(t *testing.T) {
+		// given
+		got := make(chan storedEventA, 1)
+		svc := event.NewPubSub(logrus.New())
+		svc.Subscribe("subscriber", storedEventA{}, func(ctx context.Context, ev interface{}) error {
+			got <- ev.(storedEventA)
+			return nil
+		})
+
+		// when
+		staged := event.Stage(svc, storedEventA{Msg: "first event"})
+
+		// then
+		assert.Empty(t, staged)
+		select {
+		case ev := <-got:
+			assert.Equal(t, "first event", ev.Msg)
+		case <-time.After(time.Second):
+			t.Fatal("event was not published")
+		}
+	})
+}
+
+func TestStorePending(t *testing.T) {
+	t.Run("should store the events with the store function", func(t *testing.T) {
+		// given
+		events := storage.NewMemoryStorage().OutboxEvents()
+		svc := event.NewOutbox(events, fixOutboxConfig(), logrus.New())
+		svc.Subscribe("subscriber", storedEventA{}, func(ctx context.Context, ev interface{}) error { return nil })
+		staged := event.Stage(svc, storedEventA{Msg: "first event"})
+		stored := false
+
+		// when
+		err := event.StorePending(svc, staged, func() error {
+			stored = true
+			return nil
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, stored)
+		assertPendingEvents(t, events, "subscriber", 0)
+	})
+
+	t.Run("should not call the store function without events", func(t *testing.T) {
+		// given
+		svc := event.NewOutbox(storage.NewMemoryStorage().OutboxEvents(), fixOutboxConfig(), logrus.New())
+
+		// when
+		err := event.StorePending(svc, nil, func() error {
+			t.Fatal("the store function must not be called")
+			return nil
+		})
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("should store the events alone when the store function failed", func(t *testing.T) {
+		// given
+		events := storage.NewMemoryStorage().OutboxEvents()
+		svc := event.NewOutbox(events, fixOutboxConfig(), logrus.New())
+		svc.Subscribe("subscriber", storedEventA{}, func(ctx context.Context, ev interface{}) error { return nil })
+		staged := event.Stage(svc, storedEventA{Msg: "first event"})
+
+		// when
+		err := event.StorePending(svc, staged, func() error {
+			return errors.New("conflict")
+		})
+
+		// then
+		assert.EqualError(t, err, "conflict")
+		assertPendingEvents(t, events, "subscriber", 1)
+	})
+}
@@ -0,0 +1,28 @@
+package expiration
+
+import "time"
+
+// TrialExpirationWarning is published when the trial instance reaches the warning threshold before its expiration
+type TrialExpirationWarning struct {
+	InstanceID      string
+	GlobalAccountID string
+	SubAccountID    string
+	ExpiresAt       time.Time
+	Threshold       time.Duration
+}
+
+// TrialExpired is published when the suspension of the expired trial instance is started
+type TrialExpired struct {
+	InstanceID      string
+	GlobalAccountID string
+	SubAccountID    string
+	ExpiresAt       time.Time
+}
+
+// TrialDeprovisioningStarted is published when the deprovisioning of the trial instance is started after the grace period
+type TrialDeprovisioningStarted struct {
+	InstanceID      string
+	GlobalAccountID string
+	SubAccountID    string
+	OperationID     string
+}
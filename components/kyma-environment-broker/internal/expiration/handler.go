@@ -0,0 +1,112 @@
+package expiration
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ExtensionRequest is the body of the request which extends the trial instance
+type ExtensionRequest struct {
+	// Days by which the expiration is postponed, the expired instance is extended from now
+	Days int `json:"days"`
+}
+
+// TrialDTO describes the lifecycle of the trial instance
+type TrialDTO struct {
+	InstanceID    string    `json:"instanceID"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+	DeprovisionAt time.Time `json:"deprovisionAt"`
+}
+
+// Handler exposes the admin API which manages the lifecycle of the trial instances
+type Handler struct {
+	instances  storage.Instances
+	operations storage.Operations
+
+	cfg Config
+	log logrus.FieldLogger
+}
+
+func NewHandler(instances storage.Instances, operations storage.Operations, cfg Config, log logrus.FieldLogger) *Handler {
+	return &Handler{
+		instances:  instances,
+		operations: operations,
+		cfg:        cfg,
+		log:        log.WithField("service", "trialExpirationHandler"),
+	}
+}
+
+func (h *Handler) AttachRoutes(router *mux.Router) {
+	router.HandleFunc("/trials/{instance_id}/extend", h.extendTrial).Methods(http.MethodPut)
+}
+
+func (h *Handler) extendTrial(w http.ResponseWriter, r *http.Request) {
+	instanceID := mux.Vars(r)["instance_id"]
+
+	var req ExtensionRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrap(err, "while decoding request body"))
+		return
+	}
+	if req.Days <= 0 {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Errorf("the number of days must be positive, got %d", req.Days))
+		return
+	}
+
+	instance, err := h.instances.GetByID(instanceID)
+	switch {
+	case err == nil:
+	case dberr.IsNotFound(err):
+		httputil.WriteErrorResponse(w, http.StatusNotFound, errors.Errorf("instance %s not found", instanceID))
+		return
+	default:
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while getting instance %s", instanceID))
+		return
+	}
+	if !broker.IsTrialPlan(instance.ServicePlanID) {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Errorf("instance %s is not a trial instance", instanceID))
+		return
+	}
+
+	lastDeprovisioning, err := h.operations.GetDeprovisioningOperationByInstanceID(instanceID)
+	switch {
+	case err == nil && !lastDeprovisioning.Temporary:
+		httputil.WriteErrorResponse(w, http.StatusConflict, errors.Errorf("deprovisioning of the instance %s has already started", instanceID))
+		return
+	case err == nil, dberr.IsNotFound(err):
+	default:
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while getting deprovisioning operation of instance %s", instanceID))
+		return
+	}
+
+	expiresAt := ExpiresAt(*instance, h.cfg)
+	if now := time.Now(); expiresAt.Before(now) {
+		expiresAt = now
+	}
+	instance.ExpiresAt = expiresAt.Add(time.Duration(req.Days) * 24 * time.Hour)
+	instance.ExpirationWarnedAt = time.Time{}
+
+	instance, err = h.instances.Update(*instance)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while updating instance %s", instanceID))
+		return
+	}
+	h.log.Infof("Trial instance %s extended by %d days, expires at %s", instanceID, req.Days, instance.ExpiresAt)
+
+	httputil.WriteResponse(w, http.StatusOK, TrialDTO{
+		InstanceID:    instance.InstanceID,
+		ExpiresAt:     instance.ExpiresAt,
+		DeprovisionAt: instance.ExpiresAt.Add(h.cfg.GracePeriod),
+	})
+}
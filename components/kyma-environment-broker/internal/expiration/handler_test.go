@@ -0,0 +1,129 @@
+package expiration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ExtendTrial(t *testing.T) {
+	t.Run("should extend the trial instance", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		expiresAt := time.Now().Add(24 * time.Hour)
+		instance := fixTrialInstance(expiresAt)
+		instance.ExpirationWarnedAt = time.Now()
+		require.NoError(t, st.Instances().Insert(instance))
+
+		// when
+		resp := callExtend(t, st, `{"days": 14}`)
+
+		// then
+		require.Equal(t, http.StatusOK, resp.Code)
+		var dto TrialDTO
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &dto))
+		assert.True(t, expiresAt.Add(14*24*time.Hour).Equal(dto.ExpiresAt))
+		assert.True(t, dto.ExpiresAt.Add(fixConfig().GracePeriod).Equal(dto.DeprovisionAt))
+
+		got, err := st.Instances().GetByID(instanceID)
+		require.NoError(t, err)
+		assert.True(t, dto.ExpiresAt.Equal(got.ExpiresAt))
+		assert.True(t, got.ExpirationWarnedAt.IsZero())
+	})
+
+	t.Run("should extend the expired trial instance from now", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		require.NoError(t, st.Instances().Insert(fixTrialInstance(time.Now().Add(-48*time.Hour))))
+
+		// when
+		resp := callExtend(t, st, `{"days": 1}`)
+
+		// then
+		require.Equal(t, http.StatusOK, resp.Code)
+		got, err := st.Instances().GetByID(instanceID)
+		require.NoError(t, err)
+		assert.True(t, got.ExpiresAt.After(time.Now().Add(23*time.Hour)))
+	})
+
+	for name, tc := range map[string]struct {
+		planID         string
+		deprovisioning bool
+		body           string
+		expectedCode   int
+	}{
+		"not a trial instance": {
+			planID:       broker.AzurePlanID,
+			body:         `{"days": 14}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		"deprovisioning started": {
+			planID:         broker.TrialPlanID,
+			deprovisioning: true,
+			body:           `{"days": 14}`,
+			expectedCode:   http.StatusConflict,
+		},
+		"not positive number of days": {
+			planID:       broker.TrialPlanID,
+			body:         `{"days": 0}`,
+			expectedCode: http.StatusBadRequest,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			st := storage.NewMemoryStorage()
+			instance := fixTrialInstance(time.Now().Add(time.Hour))
+			instance.ServicePlanID = tc.planID
+			require.NoError(t, st.Instances().Insert(instance))
+			if tc.deprovisioning {
+				operation, err := internal.NewDeprovisioningOperationWithID("deprovisioning-id", &instance)
+				require.NoError(t, err)
+				require.NoError(t, st.Operations().InsertDeprovisioningOperation(operation))
+			}
+
+			// when
+			resp := callExtend(t, st, tc.body)
+
+			// then
+			assert.Equal(t, tc.expectedCode, resp.Code)
+		})
+	}
+
+	t.Run("should return not found for not existing instance", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+
+		// when
+		resp := callExtend(t, st, `{"days": 14}`)
+
+		// then
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+}
+
+func callExtend(t *testing.T, st storage.BrokerStorage, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	router := mux.NewRouter()
+	NewHandler(st.Instances(), st.Operations(), fixConfig(), logrus.New()).AttachRoutes(router)
+
+	req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/trials/%s/extend", instanceID), bytes.NewBufferString(body))
+	require.NoError(t, err)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	return resp
+}
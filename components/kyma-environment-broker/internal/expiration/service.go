@@ -0,0 +1,245 @@
+package expiration
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+
+	"github.com/google/uuid"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Config defines the lifecycle of the trial instances
+type Config struct {
+	// Disabled turns off the expiration of the trial instances
+	Disabled bool `envconfig:"default=true"`
+	// Duration is the lifetime of the trial instance counted from its creation, the instance is suspended when it ends
+	Duration time.Duration `envconfig:"default=720h"`
+	// WarningThresholds define how long before the expiration the warnings are emitted, for example 168h,24h
+	WarningThresholds []time.Duration `envconfig:"optional"`
+	// GracePeriod is the time after the expiration after which the suspended instance is deprovisioned
+	GracePeriod time.Duration `envconfig:"default=168h"`
+	// Interval between the checks of the trial instances
+	Interval time.Duration `envconfig:"default=1h"`
+}
+
+// ContextUpdateHandler suspends the instance through the same path as the context update from ERS
+type ContextUpdateHandler interface {
+	Handle(instance *internal.Instance, newCtx internal.ERSContext) error
+}
+
+type Adder interface {
+	Add(processId string)
+}
+
+// Service tracks the expiration of the trial instances, it warns about the coming expiration,
+// suspends the expired instances and deprovisions them after the grace period
+type Service struct {
+	instances           storage.Instances
+	operations          storage.Operations
+	suspensionHandler   ContextUpdateHandler
+	deprovisioningQueue Adder
+	publisher           event.Publisher
+
+	cfg Config
+	log logrus.FieldLogger
+}
+
+func NewService(instances storage.Instances, operations storage.Operations, suspensionHandler ContextUpdateHandler, deprovisioningQueue Adder,
+	publisher event.Publisher, cfg Config, log logrus.FieldLogger) *Service {
+	return &Service{
+		instances:           instances,
+		operations:          operations,
+		suspensionHandler:   suspensionHandler,
+		deprovisioningQueue: deprovisioningQueue,
+		publisher:           publisher,
+		cfg:                 cfg,
+		log:                 log.WithField("service", "trialExpiration"),
+	}
+}
+
+// Run processes the trial instances periodically until the channel is closed
+func (s *Service) Run(stopCh <-chan struct{}) {
+	wait.Until(func() {
+		if err := s.ProcessTrials(); err != nil {
+			s.log.Errorf("while processing trial instances: %s", err)
+		}
+	}, s.cfg.Interval, stopCh)
+}
+
+// ProcessTrials moves the trial instances through their lifecycle, the instance which cannot be processed
+// does not stop the processing of the other ones
+func (s *Service) ProcessTrials() error {
+	instances, _, _, err := s.instances.List(dbmodel.InstanceFilter{Plans: []string{broker.TrialPlanName}})
+	if err != nil {
+		return errors.Wrap(err, "while listing trial instances")
+	}
+
+	for _, instance := range instances {
+		log := s.log.WithFields(logrus.Fields{"instanceID": instance.InstanceID, "globalAccountID": instance.GlobalAccountID})
+		if err := s.processTrial(instance, log); err != nil {
+			log.Errorf("while processing trial instance: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) processTrial(instance internal.Instance, log logrus.FieldLogger) error {
+	if instance.ExpiresAt.IsZero() {
+		instance.ExpiresAt = ExpiresAt(instance, s.cfg)
+		updated, err := s.instances.Update(instance)
+		if err != nil {
+			return errors.Wrap(err, "while setting the expiration time")
+		}
+		instance = *updated
+		log.Infof("Trial instance expires at %s", instance.ExpiresAt)
+	}
+
+	now := time.Now()
+	switch {
+	case now.After(instance.ExpiresAt.Add(s.cfg.GracePeriod)):
+		return s.deprovision(&instance, log)
+	case now.After(instance.ExpiresAt):
+		return s.suspend(&instance, log)
+	default:
+		return s.warn(&instance, now, log)
+	}
+}
+
+// warn emits the warning when the instance reached the next threshold before the expiration
+func (s *Service) warn(instance *internal.Instance, now time.Time, log logrus.FieldLogger) error {
+	threshold, due := s.dueWarning(instance, now)
+	if !due {
+		return nil
+	}
+
+	instance.ExpirationWarnedAt = now
+	_, err := s.instances.Update(*instance)
+	if err != nil {
+		return errors.Wrap(err, "while saving the expiration warning time")
+	}
+
+	log.Warnf("Trial instance expires in less than %s at %s", threshold, instance.ExpiresAt)
+	s.publisher.Publish(context.Background(), TrialExpirationWarning{
+		InstanceID:      instance.InstanceID,
+		GlobalAccountID: instance.GlobalAccountID,
+		SubAccountID:    instance.SubAccountID,
+		ExpiresAt:       instance.ExpiresAt,
+		Threshold:       threshold,
+	})
+	return nil
+}
+
+// dueWarning returns the smallest threshold reached by the instance if the warning for it was not emitted yet
+func (s *Service) dueWarning(instance *internal.Instance, now time.Time) (time.Duration, bool) {
+	thresholds := make([]time.Duration, len(s.cfg.WarningThresholds))
+	copy(thresholds, s.cfg.WarningThresholds)
+	sort.Slice(thresholds, func(i, j int) bool { return thresholds[i] < thresholds[j] })
+
+	for _, threshold := range thresholds {
+		warnAt := instance.ExpiresAt.Add(-threshold)
+		if now.Before(warnAt) {
+			continue
+		}
+		return threshold, instance.ExpirationWarnedAt.Before(warnAt)
+	}
+	return 0, false
+}
+
+// suspend suspends the expired instance in the same way as ERS does when the instance is deactivated
+func (s *Service) suspend(instance *internal.Instance, log logrus.FieldLogger) error {
+	if instance.Parameters.ErsContext.Active != nil && !*instance.Parameters.ErsContext.Active {
+		return nil
+	}
+
+	log.Infof("Trial instance expired at %s, starting suspension", instance.ExpiresAt)
+	err := s.suspensionHandler.Handle(instance, internal.ERSContext{Active: ptr.Bool(false)})
+	if err != nil {
+		return errors.Wrap(err, "while suspending the expired instance")
+	}
+	instance.Parameters.ErsContext.Active = ptr.Bool(false)
+	_, err = s.instances.Update(*instance)
+	if err != nil {
+		return errors.Wrap(err, "while deactivating the expired instance")
+	}
+
+	s.publisher.Publish(context.Background(), TrialExpired{
+		InstanceID:      instance.InstanceID,
+		GlobalAccountID: instance.GlobalAccountID,
+		SubAccountID:    instance.SubAccountID,
+		ExpiresAt:       instance.ExpiresAt,
+	})
+	return nil
+}
+
+// deprovision starts the deprovisioning of the instance whose grace period has passed, the deprovisioning waits
+// for the suspension which is still in progress
+func (s *Service) deprovision(instance *internal.Instance, log logrus.FieldLogger) error {
+	lastDeprovisioning, err := s.operations.GetDeprovisioningOperationByInstanceID(instance.InstanceID)
+	switch {
+	case err == nil:
+		if !lastDeprovisioning.Temporary {
+			return nil
+		}
+		if lastDeprovisioning.State == domain.InProgress || lastDeprovisioning.State == orchestration.Pending {
+			log.Infof("Suspension of the instance in progress, deprovisioning postponed")
+			return nil
+		}
+	case dberr.IsNotFound(err):
+	default:
+		return errors.Wrap(err, "while getting the last deprovisioning operation")
+	}
+
+	operation, err := internal.NewDeprovisioningOperationWithID(uuid.New().String(), instance)
+	if err != nil {
+		return errors.Wrap(err, "while creating deprovisioning operation")
+	}
+	err = s.operations.InsertDeprovisioningOperation(operation)
+	if err != nil {
+		return errors.Wrap(err, "while saving deprovisioning operation")
+	}
+	log.Infof("Grace period of the expired trial instance has passed, deprovisioning operation %s started", operation.ID)
+	s.deprovisioningQueue.Add(operation.ID)
+
+	s.publisher.Publish(context.Background(), TrialDeprovisioningStarted{
+		InstanceID:      instance.InstanceID,
+		GlobalAccountID: instance.GlobalAccountID,
+		SubAccountID:    instance.SubAccountID,
+		OperationID:     operation.ID,
+	})
+	return nil
+}
+
+// ExpiresAt returns the expiration time of the trial instance, the instances which do not have it set yet
+// expire after the configured duration from their creation, but not earlier than the largest warning threshold
+// from now, so the instances created before the expiration was turned on get all the warnings
+func ExpiresAt(instance internal.Instance, cfg Config) time.Time {
+	if !instance.ExpiresAt.IsZero() {
+		return instance.ExpiresAt
+	}
+
+	expiresAt := instance.CreatedAt.Add(cfg.Duration)
+	var largestThreshold time.Duration
+	for _, threshold := range cfg.WarningThresholds {
+		if threshold > largestThreshold {
+			largestThreshold = threshold
+		}
+	}
+	if earliest := time.Now().Add(largestThreshold); expiresAt.Before(earliest) {
+		return earliest
+	}
+	return expiresAt
+}
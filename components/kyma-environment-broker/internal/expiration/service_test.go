@@ -0,0 +1,243 @@
+package expiration
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	instanceID      = "instance-id"
+	globalAccountID = "ga-id"
+)
+
+func TestService_ProcessTrials(t *testing.T) {
+	t.Run("should set the expiration time of the trial instance", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		createdAt := time.Now().Add(-time.Hour)
+		instance := fixTrialInstance(time.Time{})
+		instance.CreatedAt = createdAt
+		require.NoError(t, st.Instances().Insert(instance))
+		azure := fixTrialInstance(time.Time{})
+		azure.InstanceID = "azure-instance-id"
+		azure.ServicePlanID = broker.AzurePlanID
+		azure.ServicePlanName = broker.AzurePlanName
+		require.NoError(t, st.Instances().Insert(azure))
+
+		svc, _, _, _ := fixService(st)
+
+		// when
+		err := svc.ProcessTrials()
+
+		// then
+		require.NoError(t, err)
+		got, err := st.Instances().GetByID(instanceID)
+		require.NoError(t, err)
+		assert.Equal(t, createdAt.Add(fixConfig().Duration), got.ExpiresAt)
+		got, err = st.Instances().GetByID(azure.InstanceID)
+		require.NoError(t, err)
+		assert.True(t, got.ExpiresAt.IsZero())
+	})
+
+	t.Run("should not expire the old trial instance before all warnings are emitted", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		instance := fixTrialInstance(time.Time{})
+		instance.CreatedAt = time.Now().Add(-60 * 24 * time.Hour)
+		require.NoError(t, st.Instances().Insert(instance))
+		svc, handler, queue, publisher := fixService(st)
+
+		// when
+		err := svc.ProcessTrials()
+
+		// then
+		require.NoError(t, err)
+		got, err := st.Instances().GetByID(instanceID)
+		require.NoError(t, err)
+		assert.True(t, got.ExpiresAt.After(time.Now().Add(7*24*time.Hour-time.Minute)))
+		assert.Empty(t, handler.suspended)
+		assert.Empty(t, queue.IDs)
+		require.Len(t, publisher.Events(), 1)
+		assert.IsType(t, TrialExpirationWarning{}, publisher.Events()[0])
+	})
+
+	t.Run("should warn once at the reached threshold", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		require.NoError(t, st.Instances().Insert(fixTrialInstance(time.Now().Add(12*time.Hour))))
+		svc, _, _, publisher := fixService(st)
+
+		// when
+		require.NoError(t, svc.ProcessTrials())
+		require.NoError(t, svc.ProcessTrials())
+
+		// then
+		events := publisher.Events()
+		require.Len(t, events, 1)
+		warning, ok := events[0].(TrialExpirationWarning)
+		require.True(t, ok)
+		assert.Equal(t, instanceID, warning.InstanceID)
+		assert.Equal(t, 24*time.Hour, warning.Threshold)
+	})
+
+	t.Run("should not warn before the first threshold", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		require.NoError(t, st.Instances().Insert(fixTrialInstance(time.Now().Add(10*24*time.Hour))))
+		svc, _, _, publisher := fixService(st)
+
+		// when
+		require.NoError(t, svc.ProcessTrials())
+
+		// then
+		assert.Empty(t, publisher.Events())
+	})
+
+	t.Run("should suspend the expired instance", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		require.NoError(t, st.Instances().Insert(fixTrialInstance(time.Now().Add(-time.Hour))))
+		svc, handler, _, publisher := fixService(st)
+
+		// when
+		require.NoError(t, svc.ProcessTrials())
+		require.NoError(t, svc.ProcessTrials())
+
+		// then
+		assert.Equal(t, []string{instanceID}, handler.suspended)
+		got, err := st.Instances().GetByID(instanceID)
+		require.NoError(t, err)
+		assert.False(t, *got.Parameters.ErsContext.Active)
+		require.Len(t, publisher.Events(), 1)
+		assert.IsType(t, TrialExpired{}, publisher.Events()[0])
+	})
+
+	t.Run("should deprovision the instance after the grace period", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		instance := fixTrialInstance(time.Now().Add(-fixConfig().GracePeriod - time.Hour))
+		instance.Parameters.ErsContext.Active = ptr.Bool(false)
+		require.NoError(t, st.Instances().Insert(instance))
+		require.NoError(t, st.Operations().InsertDeprovisioningOperation(fixSuspensionOperation(domain.Succeeded)))
+		svc, handler, queue, publisher := fixService(st)
+
+		// when
+		require.NoError(t, svc.ProcessTrials())
+		require.NoError(t, svc.ProcessTrials())
+
+		// then
+		assert.Empty(t, handler.suspended)
+		require.Len(t, queue.IDs, 1)
+		operation, err := st.Operations().GetDeprovisioningOperationByID(queue.IDs[0])
+		require.NoError(t, err)
+		assert.False(t, operation.Temporary)
+		assert.Equal(t, domain.InProgress, operation.State)
+		require.Len(t, publisher.Events(), 1)
+		assert.IsType(t, TrialDeprovisioningStarted{}, publisher.Events()[0])
+	})
+
+	t.Run("should postpone the deprovisioning until the suspension finishes", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		require.NoError(t, st.Instances().Insert(fixTrialInstance(time.Now().Add(-fixConfig().GracePeriod-time.Hour))))
+		require.NoError(t, st.Operations().InsertDeprovisioningOperation(fixSuspensionOperation(orchestration.Pending)))
+		svc, _, queue, _ := fixService(st)
+
+		// when
+		require.NoError(t, svc.ProcessTrials())
+
+		// then
+		assert.Empty(t, queue.IDs)
+	})
+}
+
+func fixConfig() Config {
+	return Config{
+		Duration:          30 * 24 * time.Hour,
+		WarningThresholds: []time.Duration{7 * 24 * time.Hour, 24 * time.Hour},
+		GracePeriod:       7 * 24 * time.Hour,
+		Interval:          time.Hour,
+	}
+}
+
+func fixService(st storage.BrokerStorage) (*Service, *suspensionHandler, *dummyQueue, *recordingPublisher) {
+	handler := &suspensionHandler{}
+	queue := &dummyQueue{}
+	publisher := &recordingPublisher{}
+	return NewService(st.Instances(), st.Operations(), handler, queue, publisher, fixConfig(), logrus.New()), handler, queue, publisher
+}
+
+func fixTrialInstance(expiresAt time.Time) internal.Instance {
+	return internal.Instance{
+		InstanceID:      instanceID,
+		RuntimeID:       "runtime-id",
+		GlobalAccountID: globalAccountID,
+		ServicePlanID:   broker.TrialPlanID,
+		ServicePlanName: broker.TrialPlanName,
+		ExpiresAt:       expiresAt,
+		CreatedAt:       time.Now(),
+	}
+}
+
+func fixSuspensionOperation(state domain.LastOperationState) internal.DeprovisioningOperation {
+	return internal.DeprovisioningOperation{
+		Operation: internal.Operation{
+			ID:         "suspension-id",
+			InstanceID: instanceID,
+			State:      state,
+			CreatedAt:  time.Now().Add(-time.Hour),
+			UpdatedAt:  time.Now().Add(-time.Hour),
+		},
+		Temporary:   true,
+		Hibernation: true,
+	}
+}
+
+type suspensionHandler struct {
+	suspended []string
+}
+
+func (h *suspensionHandler) Handle(instance *internal.Instance, newCtx internal.ERSContext) error {
+	if newCtx.Active != nil && !*newCtx.Active {
+		h.suspended = append(h.suspended, instance.InstanceID)
+	}
+	return nil
+}
+
+type dummyQueue struct {
+	IDs []string
+}
+
+func (q *dummyQueue) Add(id string) {
+	q.IDs = append(q.IDs, id)
+}
+
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []interface{}
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+}
+
+func (p *recordingPublisher) Events() []interface{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.events
+}
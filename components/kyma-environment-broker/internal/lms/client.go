@@ -60,7 +60,9 @@ type Config struct {
 	Token      string
 	SamlTenant string
 	Region     string `envconfig:"optional"`
-	Mandatory  bool   `envconfig:"default=true"`
+	// Mandatory is deprecated, set the LMS steps as optional in the pipeline config instead.
+	// If false, the Create_LMS_Tenant and Request_LMS_Certificates steps are optional in the default pipeline.
+	Mandatory bool `envconfig:"default=true"`
 
 	EnabledForGlobalAccounts string // "all", "none", or "{global-account-ID-1}, <global-account-ID-2>, .."
 }
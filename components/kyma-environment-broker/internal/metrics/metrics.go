@@ -6,6 +6,13 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+const (
+	// the names are stored with the events delivered to the collectors, they must not change
+	operationResultSubscriberName   = "metrics-operation-result"
+	operationDurationSubscriberName = "metrics-operation-duration"
+	stepResultSubscriberName        = "metrics-step-result"
+)
+
 func RegisterAll(sub event.Subscriber, operationStatsGetter OperationsStatsGetter, instanceStatsGetter InstancesStatsGetter) {
 	opResultCollector := NewOperationResultCollector()
 	opDurationCollector := NewOperationDurationCollector()
@@ -14,11 +21,11 @@ func RegisterAll(sub event.Subscriber, operationStatsGetter OperationsStatsGette
 	prometheus.MustRegister(NewOperationsCollector(operationStatsGetter))
 	prometheus.MustRegister(NewInstancesCollector(instanceStatsGetter))
 
-	sub.Subscribe(process.ProvisioningStepProcessed{}, opResultCollector.OnProvisioningStepProcessed)
-	sub.Subscribe(process.DeprovisioningStepProcessed{}, opResultCollector.OnDeprovisioningStepProcessed)
-	sub.Subscribe(process.UpgradeKymaStepProcessed{}, opResultCollector.OnUpgradeStepProcessed)
-	sub.Subscribe(process.ProvisioningStepProcessed{}, opDurationCollector.OnProvisioningStepProcessed)
-	sub.Subscribe(process.DeprovisioningStepProcessed{}, opDurationCollector.OnDeprovisioningStepProcessed)
-	sub.Subscribe(process.ProvisioningStepProcessed{}, stepResultCollector.OnProvisioningStepProcessed)
-	sub.Subscribe(process.DeprovisioningStepProcessed{}, stepResultCollector.OnDeprovisioningStepProcessed)
+	sub.Subscribe(operationResultSubscriberName, process.ProvisioningStepProcessed{}, opResultCollector.OnProvisioningStepProcessed)
+	sub.Subscribe(operationResultSubscriberName, process.DeprovisioningStepProcessed{}, opResultCollector.OnDeprovisioningStepProcessed)
+	sub.Subscribe(operationResultSubscriberName, process.UpgradeKymaStepProcessed{}, opResultCollector.OnUpgradeStepProcessed)
+	sub.Subscribe(operationDurationSubscriberName, process.ProvisioningStepProcessed{}, opDurationCollector.OnProvisioningStepProcessed)
+	sub.Subscribe(operationDurationSubscriberName, process.DeprovisioningStepProcessed{}, opDurationCollector.OnDeprovisioningStepProcessed)
+	sub.Subscribe(stepResultSubscriberName, process.ProvisioningStepProcessed{}, stepResultCollector.OnProvisioningStepProcessed)
+	sub.Subscribe(stepResultSubscriberName, process.DeprovisioningStepProcessed{}, stepResultCollector.OnDeprovisioningStepProcessed)
 }
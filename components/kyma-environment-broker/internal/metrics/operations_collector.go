@@ -21,7 +21,7 @@ import (
 // - compass_keb_operations_{plan_name}_deprovisioning_succeeded_total
 
 var (
-	supportedPlansIDs = []string{broker.AzurePlanID, broker.AzureLitePlanID, broker.TrialPlanID}
+	supportedPlansIDs = []string{broker.AzurePlanID, broker.AzureLitePlanID, broker.TrialPlanID, broker.AWSPlanID}
 )
 
 type OperationsStatsGetter interface {
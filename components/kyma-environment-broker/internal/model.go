@@ -24,6 +24,7 @@ type ProvisionerInputCreator interface {
 	AppendGlobalOverrides(overrides []*gqlschema.ConfigEntryInput) ProvisionerInputCreator
 	CreateProvisionRuntimeInput() (gqlschema.ProvisionRuntimeInput, error)
 	CreateUpgradeRuntimeInput() (gqlschema.UpgradeRuntimeInput, error)
+	CreateUpgradeShootInput() (gqlschema.UpgradeShootInput, error)
 	EnableOptionalComponent(componentName string) ProvisionerInputCreator
 }
 
@@ -55,6 +56,10 @@ type LMS struct {
 	TenantID    string    `json:"tenant_id"`
 	Failed      bool      `json:"failed"`
 	RequestedAt time.Time `json:"requested_at"`
+
+	// KibanaURL and the encrypted Overrides of the fluent-bit forwarding are stored when the certificates are issued
+	KibanaURL string `json:"kibana_url,omitempty"`
+	Overrides string `json:"overrides,omitempty"`
 }
 
 type AvsEvaluationStatus struct {
@@ -107,6 +112,14 @@ func NewRuntimeVersionFromAccountMapping(version string) *RuntimeVersionData {
 
 type EventHub struct {
 	Deleted bool `json:"event_hub_deleted"`
+
+	// Overrides are the encrypted Kafka channel overrides with the connection string of the created namespace
+	Overrides string `json:"overrides,omitempty"`
+}
+
+type IASData struct {
+	// Overrides are the encrypted overrides with the client credentials of the registered service providers
+	Overrides string `json:"overrides,omitempty"`
 }
 
 type Instance struct {
@@ -123,8 +136,16 @@ type Instance struct {
 	Parameters     ProvisioningParameters
 	ProviderRegion string
 
+	// PlanHistory contains the plan changes of the instance made by the update operations
+	PlanHistory []PlanChange
+
 	InstanceDetails InstanceDetails
 
+	// ExpiresAt is the time the trial instance is suspended at, it is set only for the trial instances
+	ExpiresAt time.Time
+	// ExpirationWarnedAt is the time of the last warning about the expiration of the trial instance
+	ExpirationWarnedAt time.Time
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt time.Time
@@ -132,10 +153,30 @@ type Instance struct {
 	Version int
 }
 
+// IsExpired returns true if the instance has the expiration time which has passed
+func (i *Instance) IsExpired() bool {
+	return !i.ExpiresAt.IsZero() && time.Now().After(i.ExpiresAt)
+}
+
+// PlanChange describes the upgrade of the instance from one plan to another
+type PlanChange struct {
+	FromPlanID  string    `json:"from_plan_id"`
+	ToPlanID    string    `json:"to_plan_id"`
+	OperationID string    `json:"operation_id"`
+	ChangedAt   time.Time `json:"changed_at"`
+}
+
 type Operation struct {
 	// following fields are serialized to JSON and stored in the storage
 	InstanceDetails
 
+	// FinishedSteps contains names of the steps which were successfully processed,
+	// such steps are skipped when the operation is processed again (retried or after the restart)
+	FinishedSteps []string `json:"finished_steps,omitempty"`
+
+	// StepAttempts contains information about processing of steps, the key is the name of the step
+	StepAttempts map[string]StepAttempt `json:"step_attempts,omitempty"`
+
 	ID        string    `json:"-"`
 	Version   int       `json:"-"`
 	CreatedAt time.Time `json:"-"`
@@ -149,12 +190,84 @@ type Operation struct {
 
 	// OrchestrationID specifies the origin orchestration which triggers the operation, empty for OSB operations (provisioning/deprovisioning)
 	OrchestrationID string `json:"-"`
+
+	// pendingEvents are the outbox events about the processed steps which are stored with the next update of the operation,
+	// the field is not exported, so the events are not stored in the payloads of other events
+	pendingEvents []OutboxEvent
 }
 
 func (o *Operation) IsFinished() bool {
 	return o.State != orchestration.InProgress && o.State != orchestration.Pending && o.State != orchestration.Canceled
 }
 
+// IsStepFinished returns true if the step with the given name was already successfully processed
+func (o *Operation) IsStepFinished(name string) bool {
+	for _, step := range o.FinishedSteps {
+		if step == name {
+			return true
+		}
+	}
+	return false
+}
+
+// FinishStep marks the step with the given name as successfully processed
+func (o *Operation) FinishStep(name string) {
+	if o.IsStepFinished(name) {
+		return
+	}
+	o.FinishedSteps = append(o.FinishedSteps, name)
+}
+
+// StepAttempt returns information about processing of the step with the given name
+func (o *Operation) StepAttempt(name string) StepAttempt {
+	return o.StepAttempts[name]
+}
+
+// SetStepAttempt stores information about processing of the step with the given name
+func (o *Operation) SetStepAttempt(name string, attempt StepAttempt) {
+	// the map is copied, the operation could be shared with other copies of the operation
+	attempts := make(map[string]StepAttempt, len(o.StepAttempts)+1)
+	for step, a := range o.StepAttempts {
+		attempts[step] = a
+	}
+	attempts[name] = attempt
+	o.StepAttempts = attempts
+}
+
+// AddPendingEvents adds the outbox events which are stored in one transaction with the next update of the operation
+func (o *Operation) AddPendingEvents(events []OutboxEvent) {
+	if len(events) == 0 {
+		return
+	}
+	// the slice is copied, the operation could be shared with other copies of the operation
+	pending := make([]OutboxEvent, 0, len(o.pendingEvents)+len(events))
+	o.pendingEvents = append(append(pending, o.pendingEvents...), events...)
+}
+
+// PendingEvents returns the outbox events which were not stored yet
+func (o *Operation) PendingEvents() []OutboxEvent {
+	return o.pendingEvents
+}
+
+// TakePendingEvents returns the outbox events which were not stored yet and removes them from the operation,
+// it is used by the storage which stores the events together with the operation
+func (o *Operation) TakePendingEvents() []OutboxEvent {
+	events := o.pendingEvents
+	o.pendingEvents = nil
+	return events
+}
+
+// StepAttempt holds information about processing of a single step
+type StepAttempt struct {
+	StartedAt     time.Time `json:"started_at"`
+	Count         int       `json:"count,omitempty"`
+	LastAttemptAt time.Time `json:"last_attempt_at,omitempty"`
+	NextAttemptAt time.Time `json:"next_attempt_at,omitempty"`
+	LastError     string    `json:"last_error,omitempty"`
+	// Skipped is true if the optional step was skipped after its time limit or retry budget was exhausted
+	Skipped bool `json:"skipped,omitempty"`
+}
+
 // Orchestration holds all information about an orchestration.
 // Orchestration performs operations of a specific type (UpgradeKymaOperation, UpgradeClusterOperation)
 // on specific targets of SKRs.
@@ -164,7 +277,10 @@ type Orchestration struct {
 	Description     string
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
+	Type            orchestration.Type
 	Parameters      orchestration.Parameters
+	// RetryOf is the ID of the finished orchestration which is retried by this orchestration
+	RetryOf string
 }
 
 func (o *Orchestration) IsFinished() bool {
@@ -202,6 +318,7 @@ type InstanceDetails struct {
 	ShootDomain  string    `json:"shoot_domain"`
 	XSUAA        XSUAAData `json:"xsuaa"`
 	Ems          EmsData   `json:"ems"`
+	IAS          IASData   `json:"ias"`
 	Cls          ClsData   `json:"cls"`
 }
 
@@ -264,6 +381,8 @@ type DeprovisioningOperation struct {
 
 	// Temporary indicates that this deprovisioning operation must not remove the instance
 	Temporary bool `json:"temporary"`
+	// Hibernation indicates that the runtime is hibernated instead of being removed
+	Hibernation bool `json:"hibernation,omitempty"`
 }
 
 // UpgradeKymaOperation holds all information about upgrade Kyma operation
@@ -275,9 +394,49 @@ type UpgradeKymaOperation struct {
 
 	RuntimeVersion RuntimeVersionData `json:"runtime_version"`
 
+	// KymaConfigDiff is computed by dry run operations, it describes how the upgrade would change the Kyma configuration
+	KymaConfigDiff *orchestration.KymaConfigDiff `json:"kyma_config_diff,omitempty"`
+
 	SMClientFactory SMClientFactory `json:"-"`
 }
 
+// UpgradeClusterOperation holds all information about upgrade cluster (shoot) operation
+type UpgradeClusterOperation struct {
+	Operation
+
+	orchestration.RuntimeOperation `json:"runtime_operation"`
+	InputCreator                   ProvisionerInputCreator `json:"-"`
+}
+
+// UpdatingOperation holds all information about the update of the instance parameters
+type UpdatingOperation struct {
+	Operation
+
+	UpdatingParameters UpdatingParametersDTO `json:"updating_parameters"`
+
+	// PlanID is the plan to which the instance is upgraded, it is empty if the plan is not changed
+	PlanID string `json:"plan_id,omitempty"`
+	// KymaProvisionerOperationID is the ID of the provisioner operation which reconfigures Kyma for the new plan
+	KymaProvisionerOperationID string `json:"kyma_provisioner_operation_id,omitempty"`
+	// WakeUp indicates that the operation wakes up the hibernated runtime of the unsuspended instance
+	WakeUp bool `json:"wake_up,omitempty"`
+}
+
+// IsPlanChange returns true if the operation upgrades the instance to another plan
+func (o UpdatingOperation) IsPlanChange() bool {
+	return o.PlanID != "" && o.PlanID != o.ProvisioningParameters.PlanID
+}
+
+// TargetProvisioningParameters returns the provisioning parameters of the instance after the update
+func (o UpdatingOperation) TargetProvisioningParameters() ProvisioningParameters {
+	pp := o.ProvisioningParameters
+	if o.IsPlanChange() {
+		pp.PlanID = o.PlanID
+	}
+	o.UpdatingParameters.UpdateProvisioningParameters(&pp.Parameters)
+	return pp
+}
+
 func NewRuntimeState(runtimeID, operationID string, kymaConfig *gqlschema.KymaConfigInput, clusterConfig *gqlschema.GardenerConfigInput) RuntimeState {
 	var (
 		kymaConfigInput    gqlschema.KymaConfigInput
@@ -312,6 +471,123 @@ type RuntimeState struct {
 	ClusterConfig gqlschema.GardenerConfigInput `json:"clusterConfig"`
 }
 
+// Operations processed on service bindings
+const (
+	BindingOperationBind   = "bind"
+	BindingOperationUnbind = "unbind"
+)
+
+// Binding holds the service binding of the instance and the kubeconfig issued for it on the SKR
+type Binding struct {
+	BindingID  string
+	InstanceID string
+
+	// Operation is the last asynchronous operation on the binding, bind or unbind
+	Operation   string
+	State       domain.LastOperationState
+	Description string
+
+	Kubeconfig string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// InstanceArchive is the read-only snapshot of the deleted instance together with its operations and runtime states,
+// it is taken when the instance is deleted and it does not contain the secrets
+type InstanceArchive struct {
+	Instance Instance
+
+	ProvisioningOperations   []ProvisioningOperation
+	DeprovisioningOperations []DeprovisioningOperation
+	UpgradeKymaOperations    []UpgradeKymaOperation
+	UpgradeClusterOperations []UpgradeClusterOperation
+	UpdatingOperations       []UpdatingOperation
+
+	RuntimeStates []RuntimeState
+
+	ArchivedAt time.Time
+}
+
+// OperationEvent is an entry of the append-only log of the operation, it records a single processing of the step
+// together with the transition of the operation state caused by it
+type OperationEvent struct {
+	ID          string
+	OperationID string
+	InstanceID  string
+
+	StepName   string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Duration   time.Duration
+	// RequeueDelay is the time after which the step is processed again, zero if the step is not repeated
+	RequeueDelay time.Duration
+	Error        string
+
+	// OldState and NewState are the states of the operation before and after the step, the Description
+	// is the description of the operation after the step
+	OldState    domain.LastOperationState
+	NewState    domain.LastOperationState
+	Description string
+}
+
+type OutboxEventState string
+
+const (
+	// OutboxEventPending is the state of the event which waits for the delivery to the subscribers
+	OutboxEventPending OutboxEventState = "pending"
+	// OutboxEventDead is the state of the event which could not be delivered within the allowed number of attempts
+	OutboxEventDead OutboxEventState = "dead"
+)
+
+// OutboxEvent is the application event stored in the broker database until it is delivered to the subscriber,
+// the event is stored separately for every subscriber of its type
+type OutboxEvent struct {
+	ID string
+	// Type is the name of the Go type of the event and Payload is the event encoded with gob
+	Type    string
+	Payload []byte
+	// Subscriber is the name of the handler the event is delivered to
+	Subscriber string
+
+	State         OutboxEventState
+	Attempts      int
+	LastError     string
+	CreatedAt     time.Time
+	NextAttemptAt time.Time
+}
+
+// WebhookSubscription registers the URL which is notified about the lifecycle events of the instances and orchestrations,
+// the notifications are signed with the Secret
+type WebhookSubscription struct {
+	ID     string
+	URL    string
+	Secret string
+	Filter WebhookFilter
+
+	CreatedAt time.Time
+}
+
+// WebhookFilter narrows the notifications sent to the webhook, the empty lists match all values
+type WebhookFilter struct {
+	EventTypes       []string `json:"eventTypes,omitempty"`
+	Plans            []string `json:"plans,omitempty"`
+	GlobalAccountIDs []string `json:"globalAccountIDs,omitempty"`
+}
+
+// WebhookDelivery is an entry of the delivery log of the webhook, it records a single attempt to send the notification
+type WebhookDelivery struct {
+	ID             string
+	SubscriptionID string
+	NotificationID string
+	EventType      string
+
+	// StatusCode is the status of the response, zero if the request failed without the response
+	StatusCode int
+	Error      string
+	CreatedAt  time.Time
+}
+
 // OperationStats provide number of operations per type and state
 type OperationStats struct {
 	Provisioning   map[domain.LastOperationState]int
@@ -364,7 +640,63 @@ func NewDeprovisioningOperationWithID(operationID string, instance *Instance) (D
 	}, nil
 }
 
-// NewSuspensionOperationWithID creates a fresh (just starting) instance of the DeprovisioningOperation which does not remove the instance.
+// NewUpdatingOperationWithID creates a fresh (just starting) instance of the UpdatingOperation with provided ID
+func NewUpdatingOperationWithID(operationID string, instance *Instance, parameters UpdatingParametersDTO) UpdatingOperation {
+	return UpdatingOperation{
+		Operation: Operation{
+			ID:                     operationID,
+			Version:                0,
+			Description:            "Operation created",
+			InstanceID:             instance.InstanceID,
+			State:                  domain.InProgress,
+			CreatedAt:              time.Now(),
+			UpdatedAt:              time.Now(),
+			InstanceDetails:        instance.InstanceDetails,
+			ProvisioningParameters: instance.Parameters,
+		},
+		UpdatingParameters: parameters,
+	}
+}
+
+// NewWakeUpOperationWithID creates a fresh (just starting) instance of the UpdatingOperation which wakes up the hibernated runtime.
+func NewWakeUpOperationWithID(operationID string, instance *Instance) UpdatingOperation {
+	operation := NewUpdatingOperationWithID(operationID, instance, UpdatingParametersDTO{})
+	operation.RuntimeID = instance.RuntimeID
+	operation.WakeUp = true
+	return operation
+}
+
+// NewUpgradeKymaOperationWithID creates a fresh (just starting) instance of the UpgradeKymaOperation with provided ID,
+// the operation is not a part of any orchestration
+func NewUpgradeKymaOperationWithID(operationID string, instance *Instance, version RuntimeVersionData) UpgradeKymaOperation {
+	return UpgradeKymaOperation{
+		Operation: Operation{
+			ID:                     operationID,
+			Version:                0,
+			Description:            "Operation created",
+			InstanceID:             instance.InstanceID,
+			State:                  orchestration.Pending,
+			CreatedAt:              time.Now(),
+			UpdatedAt:              time.Now(),
+			InstanceDetails:        instance.InstanceDetails,
+			ProvisioningParameters: instance.Parameters,
+		},
+		RuntimeOperation: orchestration.RuntimeOperation{
+			ID: operationID,
+			Runtime: orchestration.Runtime{
+				InstanceID:      instance.InstanceID,
+				RuntimeID:       instance.RuntimeID,
+				GlobalAccountID: instance.GlobalAccountID,
+				SubAccountID:    instance.SubAccountID,
+				ShootName:       instance.InstanceDetails.ShootName,
+			},
+		},
+		RuntimeVersion: version,
+	}
+}
+
+// NewSuspensionOperationWithID creates a fresh (just starting) instance of the DeprovisioningOperation which does not remove the instance,
+// the runtime is hibernated if it is possible.
 func NewSuspensionOperationWithID(operationID string, instance *Instance) DeprovisioningOperation {
 	return DeprovisioningOperation{
 		Operation: Operation{
@@ -377,7 +709,8 @@ func NewSuspensionOperationWithID(operationID string, instance *Instance) Deprov
 			UpdatedAt:       time.Now(),
 			InstanceDetails: instance.InstanceDetails,
 		},
-		Temporary: true,
+		Temporary:   true,
+		Hibernation: true,
 	}
 }
 
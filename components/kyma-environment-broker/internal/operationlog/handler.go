@@ -0,0 +1,100 @@
+package operationlog
+
+import (
+	"net/http"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/operation"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// Handler exposes the admin API which shows the operations and their event logs
+type Handler struct {
+	operations storage.Operations
+	events     storage.OperationEvents
+}
+
+func NewHandler(operations storage.Operations, events storage.OperationEvents) *Handler {
+	return &Handler{
+		operations: operations,
+		events:     events,
+	}
+}
+
+func (h *Handler) AttachRoutes(router *mux.Router) {
+	router.HandleFunc("/operations/{operation_id}", h.getOperation).Methods(http.MethodGet)
+	router.HandleFunc("/operations/{operation_id}/events", h.listEvents).Methods(http.MethodGet)
+}
+
+func (h *Handler) getOperation(w http.ResponseWriter, r *http.Request) {
+	operationID := mux.Vars(r)["operation_id"]
+
+	op, err := h.operations.GetOperationByID(operationID)
+	switch {
+	case err == nil:
+	case dberr.IsNotFound(err):
+		httputil.WriteErrorResponse(w, http.StatusNotFound, errors.Errorf("operation %s not found", operationID))
+		return
+	default:
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while getting operation %s", operationID))
+		return
+	}
+
+	httputil.WriteResponse(w, http.StatusOK, operation.OperationDTO{
+		OperationID:     op.ID,
+		InstanceID:      op.InstanceID,
+		OrchestrationID: op.OrchestrationID,
+		State:           string(op.State),
+		Description:     op.Description,
+		CreatedAt:       op.CreatedAt,
+		UpdatedAt:       op.UpdatedAt,
+	})
+}
+
+func (h *Handler) listEvents(w http.ResponseWriter, r *http.Request) {
+	operationID := mux.Vars(r)["operation_id"]
+
+	_, err := h.operations.GetOperationByID(operationID)
+	switch {
+	case err == nil:
+	case dberr.IsNotFound(err):
+		httputil.WriteErrorResponse(w, http.StatusNotFound, errors.Errorf("operation %s not found", operationID))
+		return
+	default:
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while getting operation %s", operationID))
+		return
+	}
+
+	events, err := h.events.ListByOperationID(operationID)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while listing events of operation %s", operationID))
+		return
+	}
+
+	list := operation.EventList{
+		Data:  make([]operation.EventDTO, 0, len(events)),
+		Count: len(events),
+	}
+	for _, e := range events {
+		dto := operation.EventDTO{
+			StepName:    e.StepName,
+			StartedAt:   e.StartedAt,
+			FinishedAt:  e.FinishedAt,
+			Duration:    e.Duration.String(),
+			Error:       e.Error,
+			OldState:    string(e.OldState),
+			NewState:    string(e.NewState),
+			Description: e.Description,
+		}
+		if e.RequeueDelay > 0 {
+			dto.RequeueDelay = e.RequeueDelay.String()
+		}
+		list.Data = append(list.Data, dto)
+	}
+
+	httputil.WriteResponse(w, http.StatusOK, list)
+}
@@ -0,0 +1,106 @@
+package operationlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/operation"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/gorilla/mux"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ListEvents(t *testing.T) {
+	t.Run("should return the events of the operation", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		require.NoError(t, st.Operations().InsertProvisioningOperation(fixture.FixProvisioningOperation(operationID, instanceID)))
+		startedAt := time.Now().Add(-time.Hour)
+		require.NoError(t, st.OperationEvents().Insert(internal.OperationEvent{
+			ID:           "second",
+			OperationID:  operationID,
+			StepName:     "Check_Runtime",
+			StartedAt:    startedAt.Add(time.Minute),
+			FinishedAt:   startedAt.Add(time.Minute + time.Second),
+			Duration:     time.Second,
+			RequeueDelay: time.Minute,
+			OldState:     domain.InProgress,
+			NewState:     domain.InProgress,
+		}))
+		require.NoError(t, st.OperationEvents().Insert(internal.OperationEvent{
+			ID:          "first",
+			OperationID: operationID,
+			StepName:    "Create_Runtime",
+			StartedAt:   startedAt,
+			FinishedAt:  startedAt.Add(time.Second),
+			Duration:    time.Second,
+			OldState:    domain.InProgress,
+			NewState:    domain.InProgress,
+		}))
+
+		// when
+		resp := callHandler(t, st, fmt.Sprintf("/operations/%s/events", operationID))
+
+		// then
+		require.Equal(t, http.StatusOK, resp.Code)
+		var events operation.EventList
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &events))
+		assert.Equal(t, 2, events.Count)
+		require.Len(t, events.Data, 2)
+		assert.Equal(t, "Create_Runtime", events.Data[0].StepName)
+		assert.Empty(t, events.Data[0].RequeueDelay)
+		assert.Equal(t, "Check_Runtime", events.Data[1].StepName)
+		assert.Equal(t, "1s", events.Data[1].Duration)
+		assert.Equal(t, "1m0s", events.Data[1].RequeueDelay)
+	})
+
+	t.Run("should return not found for not existing operation", func(t *testing.T) {
+		// when
+		resp := callHandler(t, storage.NewMemoryStorage(), fmt.Sprintf("/operations/%s/events", operationID))
+
+		// then
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+}
+
+func TestHandler_GetOperation(t *testing.T) {
+	// given
+	st := storage.NewMemoryStorage()
+	op := fixture.FixProvisioningOperation(operationID, instanceID)
+	op.State = domain.InProgress
+	require.NoError(t, st.Operations().InsertProvisioningOperation(op))
+
+	// when
+	resp := callHandler(t, st, fmt.Sprintf("/operations/%s", operationID))
+
+	// then
+	require.Equal(t, http.StatusOK, resp.Code)
+	var dto operation.OperationDTO
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &dto))
+	assert.Equal(t, operationID, dto.OperationID)
+	assert.Equal(t, instanceID, dto.InstanceID)
+	assert.Equal(t, string(domain.InProgress), dto.State)
+}
+
+func callHandler(t *testing.T, st storage.BrokerStorage, path string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	router := mux.NewRouter()
+	NewHandler(st.Operations(), st.OperationEvents()).AttachRoutes(router)
+
+	req, err := http.NewRequest(http.MethodGet, path, nil)
+	require.NoError(t, err)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	return resp
+}
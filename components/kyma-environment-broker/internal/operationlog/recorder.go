@@ -0,0 +1,89 @@
+package operationlog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/pkg/errors"
+)
+
+// subscriberName is stored with the events delivered to the recorder, it must not change
+const subscriberName = "operation-log-recorder"
+
+// Recorder appends the steps processed by the operation managers to the event log of the operations
+type Recorder struct {
+	events storage.OperationEvents
+}
+
+func NewRecorder(events storage.OperationEvents) *Recorder {
+	return &Recorder{
+		events: events,
+	}
+}
+
+// Subscribe registers the recorder for the steps of all operation types
+func (r *Recorder) Subscribe(sub event.Subscriber) {
+	sub.Subscribe(subscriberName, process.ProvisioningStepProcessed{}, r.OnStepProcessed)
+	sub.Subscribe(subscriberName, process.DeprovisioningStepProcessed{}, r.OnStepProcessed)
+	sub.Subscribe(subscriberName, process.UpgradeKymaStepProcessed{}, r.OnStepProcessed)
+	sub.Subscribe(subscriberName, process.UpgradeClusterStepProcessed{}, r.OnStepProcessed)
+	sub.Subscribe(subscriberName, process.UpdatingStepProcessed{}, r.OnStepProcessed)
+}
+
+func (r *Recorder) OnStepProcessed(_ context.Context, ev interface{}) error {
+	switch e := ev.(type) {
+	case process.ProvisioningStepProcessed:
+		return r.record(e.StepProcessed, e.OldOperation.Operation, e.Operation.Operation)
+	case process.DeprovisioningStepProcessed:
+		return r.record(e.StepProcessed, e.OldOperation.Operation, e.Operation.Operation)
+	case process.UpgradeKymaStepProcessed:
+		return r.record(e.StepProcessed, e.OldOperation.Operation, e.Operation.Operation)
+	case process.UpgradeClusterStepProcessed:
+		return r.record(e.StepProcessed, e.OldOperation.Operation, e.Operation.Operation)
+	case process.UpdatingStepProcessed:
+		return r.record(e.StepProcessed, e.OldOperation.Operation, e.Operation.Operation)
+	default:
+		return fmt.Errorf("expected one of the StepProcessed events but got %+v", ev)
+	}
+}
+
+// record stores the processed step, the event delivered again is already stored under the same ID
+func (r *Recorder) record(step process.StepProcessed, oldOperation, operation internal.Operation) error {
+	if operation.ID == "" {
+		// the step failed without returning the operation
+		operation = oldOperation
+	}
+
+	event := internal.OperationEvent{
+		ID:           step.ID,
+		OperationID:  oldOperation.ID,
+		InstanceID:   oldOperation.InstanceID,
+		StepName:     step.StepName,
+		StartedAt:    step.StartedAt,
+		FinishedAt:   step.FinishedAt,
+		Duration:     step.Duration,
+		RequeueDelay: step.When,
+		OldState:     oldOperation.State,
+		NewState:     operation.State,
+		Description:  operation.Description,
+	}
+	if step.Error != nil {
+		event.Error = step.Error.Error()
+	}
+
+	err := r.events.Insert(event)
+	switch {
+	case err == nil:
+	case dberr.IsAlreadyExists(err):
+		return nil
+	default:
+		return errors.Wrapf(err, "while saving event of the step %s of the operation %s", step.StepName, oldOperation.ID)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package operationlog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	operationID = "operation-id"
+	instanceID  = "instance-id"
+)
+
+func TestRecorder_OnStepProcessed(t *testing.T) {
+	t.Run("should record the processed step with the state transition", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		recorder := NewRecorder(st.OperationEvents())
+
+		oldOperation := fixture.FixProvisioningOperation(operationID, instanceID)
+		oldOperation.State = domain.InProgress
+		operation := oldOperation
+		operation.State = domain.Failed
+		operation.Description = "step Create_Runtime failed"
+
+		startedAt := time.Now().Add(-time.Minute)
+
+		// when
+		err := recorder.OnStepProcessed(context.Background(), process.ProvisioningStepProcessed{
+			StepProcessed: process.StepProcessed{
+				ID:         "event-id",
+				StepName:   "Create_Runtime",
+				StartedAt:  startedAt,
+				FinishedAt: startedAt.Add(2 * time.Second),
+				Duration:   2 * time.Second,
+				Error:      errors.New("provisioner not available"),
+			},
+			OldOperation: oldOperation,
+			Operation:    operation,
+		})
+
+		// then
+		require.NoError(t, err)
+		events, err := st.OperationEvents().ListByOperationID(operationID)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, "event-id", events[0].ID)
+		assert.Equal(t, instanceID, events[0].InstanceID)
+		assert.Equal(t, "Create_Runtime", events[0].StepName)
+		assert.Equal(t, startedAt, events[0].StartedAt)
+		assert.Equal(t, startedAt.Add(2*time.Second), events[0].FinishedAt)
+		assert.Equal(t, "provisioner not available", events[0].Error)
+		assert.Equal(t, domain.InProgress, events[0].OldState)
+		assert.Equal(t, domain.Failed, events[0].NewState)
+		assert.Equal(t, "step Create_Runtime failed", events[0].Description)
+	})
+
+	t.Run("should record the requeued step which did not return the operation", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		recorder := NewRecorder(st.OperationEvents())
+
+		oldOperation := fixture.FixUpgradeKymaOperation(operationID, instanceID)
+		oldOperation.State = domain.InProgress
+
+		// when
+		err := recorder.OnStepProcessed(context.Background(), process.UpgradeKymaStepProcessed{
+			StepProcessed: process.NewStepProcessed(operationID, "Upgrade_Kyma", time.Now(), time.Minute, nil),
+			OldOperation: oldOperation,
+			Operation:    internal.UpgradeKymaOperation{},
+		})
+
+		// then
+		require.NoError(t, err)
+		events, err := st.OperationEvents().ListByOperationID(operationID)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, time.Minute, events[0].RequeueDelay)
+		assert.Equal(t, domain.InProgress, events[0].NewState)
+	})
+
+	t.Run("should record the event delivered again only once", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		recorder := NewRecorder(st.OperationEvents())
+
+		oldOperation := fixture.FixDeprovisioningOperation(operationID, instanceID)
+		ev := process.DeprovisioningStepProcessed{
+			StepProcessed: process.NewStepProcessed(operationID, "Remove_Runtime", time.Now(), 0, nil),
+			OldOperation:  oldOperation,
+			Operation:     oldOperation,
+		}
+
+		// when
+		err := recorder.OnStepProcessed(context.Background(), ev)
+		require.NoError(t, err)
+		err = recorder.OnStepProcessed(context.Background(), ev)
+
+		// then
+		require.NoError(t, err)
+		events, err := st.OperationEvents().ListByOperationID(operationID)
+		require.NoError(t, err)
+		assert.Len(t, events, 1)
+	})
+
+	t.Run("should return error for unknown event", func(t *testing.T) {
+		// given
+		recorder := NewRecorder(storage.NewMemoryStorage().OperationEvents())
+
+		// when
+		err := recorder.OnStepProcessed(context.Background(), process.StepProcessed{})
+
+		// then
+		assert.Error(t, err)
+	})
+}
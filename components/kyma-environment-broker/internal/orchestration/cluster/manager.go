@@ -0,0 +1,21 @@
+package cluster
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	internalOrchestration "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/sirupsen/logrus"
+)
+
+// NewUpgradeClusterManager returns the orchestration manager which schedules the upgrade cluster operations
+func NewUpgradeClusterManager(orchestrationStorage storage.Orchestrations, operationStorage storage.Operations, instanceStorage storage.Instances,
+	clusterUpgradeExecutor process.Executor, resolver orchestration.RuntimeResolver,
+	pollingInterval time.Duration, log logrus.FieldLogger) process.Executor {
+	return internalOrchestration.NewManager(orchestrationStorage, operationStorage, instanceStorage,
+		internalOrchestration.NewUpgradeClusterOperations(orchestrationStorage, operationStorage),
+		clusterUpgradeExecutor, resolver, pollingInterval, log)
+}
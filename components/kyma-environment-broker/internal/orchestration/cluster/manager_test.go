@@ -0,0 +1,209 @@
+package cluster_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration/automock"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/orchestration/cluster"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const poolingInterval = 20 * time.Millisecond
+
+func TestUpgradeClusterManager_Execute(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+
+		resolver := &automock.RuntimeResolver{}
+		defer resolver.AssertExpectations(t)
+
+		resolver.On("Resolve", orchestration.TargetSpec{
+			Include: nil,
+			Exclude: nil,
+		}).Return([]orchestration.Runtime{}, nil)
+
+		id := "id"
+		err := store.Orchestrations().Insert(internal.Orchestration{OrchestrationID: id, State: orchestration.Pending, Type: orchestration.UpgradeClusterOrchestration})
+		require.NoError(t, err)
+
+		svc := cluster.NewUpgradeClusterManager(store.Orchestrations(), store.Operations(), store.Instances(), nil, resolver, poolingInterval, logrus.New())
+
+		// when
+		_, err = svc.Execute(id)
+		require.NoError(t, err)
+
+		o, err := store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+
+		assert.Equal(t, orchestration.Succeeded, o.State)
+	})
+
+	t.Run("Scheduled", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+
+		resolver := &automock.RuntimeResolver{}
+		defer resolver.AssertExpectations(t)
+
+		targets := orchestration.TargetSpec{Include: []orchestration.RuntimeTarget{{Target: orchestration.TargetAll}}}
+		resolver.On("Resolve", targets).Return([]orchestration.Runtime{
+			{
+				InstanceID:      "instance-id",
+				RuntimeID:       "runtime-id",
+				GlobalAccountID: "ga-id",
+				SubAccountID:    "sa-id",
+				ShootName:       "shoot",
+			},
+		}, nil).Once()
+
+		err := store.Instances().Insert(internal.Instance{InstanceID: "instance-id", RuntimeID: "runtime-id"})
+		require.NoError(t, err)
+		err = store.Operations().InsertProvisioningOperation(internal.ProvisioningOperation{
+			Operation: internal.Operation{
+				ID:                     "provisioning-id",
+				InstanceID:             "instance-id",
+				State:                  domain.Succeeded,
+				ProvisioningParameters: internal.ProvisioningParameters{PlanID: broker.AzurePlanID},
+			},
+		})
+		require.NoError(t, err)
+
+		id := "id"
+		err = store.Orchestrations().Insert(internal.Orchestration{
+			OrchestrationID: id,
+			State:           orchestration.Pending,
+			Type:            orchestration.UpgradeClusterOrchestration,
+			Parameters: orchestration.Parameters{
+				Targets: targets,
+				Strategy: orchestration.StrategySpec{
+					Type:     orchestration.ParallelStrategy,
+					Schedule: orchestration.Immediate,
+					Parallel: orchestration.ParallelStrategySpec{Workers: 1},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		executor := &succeedingExecutor{operations: store.Operations()}
+		svc := cluster.NewUpgradeClusterManager(store.Orchestrations(), store.Operations(), store.Instances(), executor, resolver, poolingInterval, logrus.New())
+
+		// when
+		_, err = svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		o, err := store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Succeeded, o.State)
+
+		ops, _, _, err := store.Operations().ListUpgradeClusterOperationsByOrchestrationID(id, dbmodel.OperationFilter{})
+		require.NoError(t, err)
+		require.Len(t, ops, 1)
+		assert.Equal(t, "runtime-id", ops[0].RuntimeOperation.RuntimeID)
+		assert.Equal(t, broker.AzurePlanID, ops[0].ProvisioningParameters.PlanID)
+		assert.Equal(t, orchestration.Succeeded, string(ops[0].State))
+	})
+
+	t.Run("StartTime", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+
+		resolver := &automock.RuntimeResolver{}
+		defer resolver.AssertExpectations(t)
+
+		startTime := time.Now().Add(time.Hour)
+		id := "id"
+		err := store.Orchestrations().Insert(internal.Orchestration{
+			OrchestrationID: id,
+			Type:            orchestration.UpgradeClusterOrchestration,
+			State:           orchestration.Pending,
+			Parameters: orchestration.Parameters{
+				Strategy: orchestration.StrategySpec{
+					Type:      orchestration.ParallelStrategy,
+					Schedule:  orchestration.Immediate,
+					StartTime: &startTime,
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		svc := cluster.NewUpgradeClusterManager(store.Orchestrations(), store.Operations(), store.Instances(), nil, resolver, poolingInterval, logrus.New())
+
+		// when
+		when, err := svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		assert.True(t, when > 59*time.Minute && when <= time.Hour)
+		o, err := store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Pending, o.State)
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+
+		resolver := &automock.RuntimeResolver{}
+		defer resolver.AssertExpectations(t)
+
+		id := "id"
+		err := store.Orchestrations().Insert(internal.Orchestration{
+			OrchestrationID: id,
+			State:           orchestration.Canceling,
+			Type:            orchestration.UpgradeClusterOrchestration,
+			Parameters: orchestration.Parameters{Strategy: orchestration.StrategySpec{
+				Type:     orchestration.ParallelStrategy,
+				Schedule: orchestration.Immediate,
+			}},
+		})
+		require.NoError(t, err)
+		err = store.Operations().InsertUpgradeClusterOperation(internal.UpgradeClusterOperation{
+			Operation: internal.Operation{
+				ID:              id,
+				OrchestrationID: id,
+				State:           orchestration.Pending,
+			},
+		})
+		require.NoError(t, err)
+
+		svc := cluster.NewUpgradeClusterManager(store.Orchestrations(), store.Operations(), store.Instances(), &succeedingExecutor{}, resolver, poolingInterval, logrus.New())
+
+		// when
+		_, err = svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		o, err := store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Canceled, o.State)
+
+		op, err := store.Operations().GetUpgradeClusterOperationByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Canceled, string(op.State))
+	})
+}
+
+type succeedingExecutor struct {
+	operations storage.Operations
+}
+
+func (e *succeedingExecutor) Execute(opID string) (time.Duration, error) {
+	op, err := e.operations.GetUpgradeClusterOperationByID(opID)
+	if err != nil {
+		return 0, err
+	}
+	op.State = orchestration.Succeeded
+	_, err = e.operations.UpdateUpgradeClusterOperation(*op)
+	return 0, err
+}
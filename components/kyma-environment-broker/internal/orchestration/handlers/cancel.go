@@ -4,6 +4,7 @@ import (
 	"time"
 
 	orchestrationExt "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -11,12 +12,14 @@ import (
 
 type Canceler struct {
 	orchestrations storage.Orchestrations
+	queues         orchestrationQueues
 	log            logrus.FieldLogger
 }
 
-func NewCanceler(orchestrations storage.Orchestrations, logger logrus.FieldLogger) *Canceler {
+func NewCanceler(orchestrations storage.Orchestrations, kymaQueue, clusterQueue *process.Queue, logger logrus.FieldLogger) *Canceler {
 	return &Canceler{
 		orchestrations: orchestrations,
+		queues:         orchestrationQueues{kyma: kymaQueue, cluster: clusterQueue},
 		log:            logger,
 	}
 }
@@ -31,12 +34,24 @@ func (c *Canceler) CancelForID(orchestrationID string) error {
 		return nil
 	}
 
+	paused := o.State == orchestrationExt.Paused
+	scheduled := o.State == orchestrationExt.Pending && o.Parameters.Strategy.StartsIn(time.Now()) > 0
+
 	o.UpdatedAt = time.Now()
 	o.Description = "Orchestration was canceled"
 	o.State = orchestrationExt.Canceling
+	// orchestration which waits for its start time does not have any operations to cancel
+	if scheduled {
+		o.State = orchestrationExt.Canceled
+	}
 	err = c.orchestrations.Update(*o)
 	if err != nil {
 		return errors.Wrap(err, "while updating orchestration")
 	}
+
+	// paused orchestration is not processed, it must be queued to cancel its pending operations
+	if paused {
+		c.queues.add(o)
+	}
 	return nil
 }
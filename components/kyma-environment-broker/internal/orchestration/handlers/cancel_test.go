@@ -3,10 +3,12 @@ package handlers
 import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"sync"
 	"testing"
 	"time"
 
@@ -23,7 +25,7 @@ func TestCanceler_CancelForID(t *testing.T) {
 		err := s.Orchestrations().Insert(fixOrchestration())
 		require.NoError(t, err)
 
-		c := NewCanceler(s.Orchestrations(), logrus.New())
+		c := NewCanceler(s.Orchestrations(), nil, nil, logrus.New())
 
 		err = c.CancelForID(fixOrchestrationID)
 		require.NoError(t, err)
@@ -40,7 +42,7 @@ func TestCanceler_CancelForID(t *testing.T) {
 		err := s.Orchestrations().Insert(o)
 		require.NoError(t, err)
 
-		c := NewCanceler(s.Orchestrations(), logrus.New())
+		c := NewCanceler(s.Orchestrations(), nil, nil, logrus.New())
 
 		err = c.CancelForID(fixOrchestrationID)
 		require.NoError(t, err)
@@ -57,7 +59,7 @@ func TestCanceler_CancelForID(t *testing.T) {
 		err := s.Orchestrations().Insert(o)
 		require.NoError(t, err)
 
-		c := NewCanceler(s.Orchestrations(), logrus.New())
+		c := NewCanceler(s.Orchestrations(), nil, nil, logrus.New())
 
 		err = c.CancelForID(fixOrchestrationID)
 		require.NoError(t, err)
@@ -67,9 +69,53 @@ func TestCanceler_CancelForID(t *testing.T) {
 
 		assert.False(t, isCanceling)
 	})
+	t.Run("should queue paused orchestration", func(t *testing.T) {
+		s := storage.NewMemoryStorage()
+		o := fixOrchestration()
+		o.State = orchestration.Paused
+		err := s.Orchestrations().Insert(o)
+		require.NoError(t, err)
+
+		executor := newRecordingExecutor()
+		kymaQueue := process.NewQueue(executor, logrus.New())
+		stop := make(chan struct{})
+		defer close(stop)
+		kymaQueue.Run(stop, 1)
+
+		c := NewCanceler(s.Orchestrations(), kymaQueue, nil, logrus.New())
+
+		err = c.CancelForID(fixOrchestrationID)
+		require.NoError(t, err)
+
+		isCanceling, err := isCanceling(s.Orchestrations())
+		require.NoError(t, err)
+
+		assert.True(t, isCanceling)
+		assert.Eventually(t, func() bool {
+			return executor.processed(fixOrchestrationID)
+		}, time.Second, 10*time.Millisecond)
+	})
+	t.Run("should cancel scheduled orchestration", func(t *testing.T) {
+		s := storage.NewMemoryStorage()
+		o := fixOrchestration()
+		o.State = orchestration.Pending
+		startTime := time.Now().Add(time.Hour)
+		o.Parameters.Strategy.StartTime = &startTime
+		err := s.Orchestrations().Insert(o)
+		require.NoError(t, err)
+
+		c := NewCanceler(s.Orchestrations(), nil, nil, logrus.New())
+
+		err = c.CancelForID(fixOrchestrationID)
+		require.NoError(t, err)
+
+		updated, err := s.Orchestrations().GetByID(fixOrchestrationID)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Canceled, updated.State)
+	})
 	t.Run("should return error when orchestration not found", func(t *testing.T) {
 		s := storage.NewMemoryStorage()
-		c := NewCanceler(s.Orchestrations(), logrus.New())
+		c := NewCanceler(s.Orchestrations(), nil, nil, logrus.New())
 
 		err := c.CancelForID(fixOrchestrationID)
 		assert.Error(t, err)
@@ -87,6 +133,28 @@ func isCanceling(s storage.Orchestrations) (bool, error) {
 	return false, nil
 }
 
+type recordingExecutor struct {
+	mux sync.Mutex
+	ids map[string]bool
+}
+
+func newRecordingExecutor() *recordingExecutor {
+	return &recordingExecutor{ids: map[string]bool{}}
+}
+
+func (e *recordingExecutor) Execute(id string) (time.Duration, error) {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	e.ids[id] = true
+	return 0, nil
+}
+
+func (e *recordingExecutor) processed(id string) bool {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	return e.ids[id]
+}
+
 func fixOrchestration() internal.Orchestration {
 	n := time.Now()
 	return internal.Orchestration{
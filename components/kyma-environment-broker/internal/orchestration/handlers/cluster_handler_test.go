@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterHandler_AttachRoutes(t *testing.T) {
+	t.Run("upgrade", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		logs := logrus.New()
+		cHandler := NewClusterHandler(db.Orchestrations(), process.NewQueue(&testExecutor{}, logs), logs)
+
+		params := orchestration.Parameters{
+			Targets: orchestration.TargetSpec{
+				Include: []orchestration.RuntimeTarget{
+					{
+						RuntimeID: "test",
+					},
+				},
+			},
+		}
+		p, err := json.Marshal(&params)
+		require.NoError(t, err)
+
+		req, err := http.NewRequest("POST", "/upgrade/cluster", bytes.NewBuffer(p))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		router := mux.NewRouter()
+		cHandler.AttachRoutes(router)
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusAccepted, rr.Code)
+
+		var out orchestration.UpgradeResponse
+
+		err = json.Unmarshal(rr.Body.Bytes(), &out)
+		require.NoError(t, err)
+		assert.NotEmpty(t, out.OrchestrationID)
+
+		o, err := db.Orchestrations().GetByID(out.OrchestrationID)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.UpgradeClusterOrchestration, o.Type)
+		assert.Equal(t, orchestration.Pending, o.State)
+		assert.Equal(t, orchestration.ParallelStrategy, o.Parameters.Strategy.Type)
+		assert.Equal(t, orchestration.Immediate, o.Parameters.Strategy.Schedule)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		for name, params := range map[string]orchestration.Parameters{
+			"without targets": {},
+			"with version": {
+				Version: "1.18.0",
+				Targets: orchestration.TargetSpec{
+					Include: []orchestration.RuntimeTarget{{Target: orchestration.TargetAll}},
+				},
+			},
+			"with invalid staged strategy": {
+				Targets: orchestration.TargetSpec{
+					Include: []orchestration.RuntimeTarget{{Target: orchestration.TargetAll}},
+				},
+				Strategy: orchestration.StrategySpec{
+					Type:   orchestration.StagedStrategy,
+					Staged: orchestration.StagedStrategySpec{CanaryPercentage: 150},
+				},
+			},
+			"with invalid soak time": {
+				Targets: orchestration.TargetSpec{
+					Include: []orchestration.RuntimeTarget{{Target: orchestration.TargetAll}},
+				},
+				Strategy: orchestration.StrategySpec{
+					Type:   orchestration.StagedStrategy,
+					Staged: orchestration.StagedStrategySpec{SoakTime: "soon"},
+				},
+			},
+			"with invalid max failure ratio": {
+				Targets: orchestration.TargetSpec{
+					Include: []orchestration.RuntimeTarget{{Target: orchestration.TargetAll}},
+				},
+				Strategy: orchestration.StrategySpec{
+					Type:            orchestration.ParallelStrategy,
+					MaxFailureRatio: 1.5,
+				},
+			},
+			"with invalid blackout period": {
+				Targets: orchestration.TargetSpec{
+					Include: []orchestration.RuntimeTarget{{Target: orchestration.TargetAll}},
+				},
+				Strategy: orchestration.StrategySpec{
+					Type: orchestration.ParallelStrategy,
+					Blackouts: []orchestration.BlackoutPeriod{
+						{Start: time.Date(2021, 3, 2, 0, 0, 0, 0, time.UTC), End: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+					},
+				},
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				// given
+				db := storage.NewMemoryStorage()
+				logs := logrus.New()
+				cHandler := NewClusterHandler(db.Orchestrations(), process.NewQueue(&testExecutor{}, logs), logs)
+
+				p, err := json.Marshal(&params)
+				require.NoError(t, err)
+
+				req, err := http.NewRequest("POST", "/upgrade/cluster", bytes.NewBuffer(p))
+				require.NoError(t, err)
+
+				rr := httptest.NewRecorder()
+				router := mux.NewRouter()
+				cHandler.AttachRoutes(router)
+
+				// when
+				router.ServeHTTP(rr, req)
+
+				// then
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+			})
+		}
+	})
+}
@@ -13,6 +13,7 @@ type Converter struct{}
 func (*Converter) OrchestrationToDTO(o *internal.Orchestration, stats map[string]int) (*orchestration.StatusResponse, error) {
 	return &orchestration.StatusResponse{
 		OrchestrationID: o.OrchestrationID,
+		Type:            o.Type,
 		State:           o.State,
 		Description:     o.Description,
 		CreatedAt:       o.CreatedAt,
@@ -41,25 +42,7 @@ func (c *Converter) OrchestrationListToDTO(orchestrations []internal.Orchestrati
 }
 
 func (c *Converter) UpgradeKymaOperationToDTO(op internal.UpgradeKymaOperation) (orchestration.OperationResponse, error) {
-	plan, ok := broker.Plans[op.ProvisioningParameters.PlanID]
-	if !ok {
-		return orchestration.OperationResponse{}, errors.Errorf("plan with ID %s not exist in the broker's plans definitions", op.ProvisioningParameters.PlanID)
-	}
-	return orchestration.OperationResponse{
-		OperationID:            op.Operation.ID,
-		RuntimeID:              op.RuntimeOperation.RuntimeID,
-		GlobalAccountID:        op.GlobalAccountID,
-		SubAccountID:           op.RuntimeOperation.SubAccountID,
-		OrchestrationID:        op.OrchestrationID,
-		ServicePlanID:          op.ProvisioningParameters.PlanID,
-		ServicePlanName:        plan.PlanDefinition.Name,
-		DryRun:                 op.DryRun,
-		ShootName:              op.RuntimeOperation.ShootName,
-		MaintenanceWindowBegin: op.MaintenanceWindowBegin,
-		MaintenanceWindowEnd:   op.MaintenanceWindowEnd,
-		State:                  string(op.Operation.State),
-		Description:            op.Operation.Description,
-	}, nil
+	return c.runtimeOperationToDTO(op.Operation, op.RuntimeOperation)
 }
 
 func (c *Converter) UpgradeKymaOperationListToDTO(ops []internal.UpgradeKymaOperation, count, totalCount int) (orchestration.OperationResponseList, error) {
@@ -89,5 +72,62 @@ func (c *Converter) UpgradeKymaOperationToDetailDTO(op internal.UpgradeKymaOpera
 		OperationResponse: resp,
 		KymaConfig:        kymaConfig,
 		ClusterConfig:     clusterConfig,
+		KymaConfigDiff:    op.KymaConfigDiff,
+	}, nil
+}
+
+func (c *Converter) UpgradeClusterOperationToDTO(op internal.UpgradeClusterOperation) (orchestration.OperationResponse, error) {
+	return c.runtimeOperationToDTO(op.Operation, op.RuntimeOperation)
+}
+
+func (c *Converter) UpgradeClusterOperationListToDTO(ops []internal.UpgradeClusterOperation, count, totalCount int) (orchestration.OperationResponseList, error) {
+	data := make([]orchestration.OperationResponse, 0)
+
+	for _, op := range ops {
+		o, err := c.UpgradeClusterOperationToDTO(op)
+		if err != nil {
+			return orchestration.OperationResponseList{}, errors.Wrap(err, "while converting operation to DTO")
+		}
+		data = append(data, o)
+	}
+
+	return orchestration.OperationResponseList{
+		Data:       data,
+		Count:      count,
+		TotalCount: totalCount,
+	}, nil
+}
+
+func (c *Converter) UpgradeClusterOperationToDetailDTO(op internal.UpgradeClusterOperation, clusterConfig gqlschema.GardenerConfigInput) (orchestration.OperationDetailResponse, error) {
+	resp, err := c.UpgradeClusterOperationToDTO(op)
+	if err != nil {
+		return orchestration.OperationDetailResponse{}, errors.Wrap(err, "while converting operation to DTO")
+	}
+	return orchestration.OperationDetailResponse{
+		OperationResponse: resp,
+		ClusterConfig:     clusterConfig,
+	}, nil
+}
+
+func (c *Converter) runtimeOperationToDTO(op internal.Operation, runtimeOperation orchestration.RuntimeOperation) (orchestration.OperationResponse, error) {
+	plan, ok := broker.Plans[op.ProvisioningParameters.PlanID]
+	if !ok {
+		return orchestration.OperationResponse{}, errors.Errorf("plan with ID %s not exist in the broker's plans definitions", op.ProvisioningParameters.PlanID)
+	}
+	return orchestration.OperationResponse{
+		OperationID:            op.ID,
+		RuntimeID:              runtimeOperation.RuntimeID,
+		GlobalAccountID:        runtimeOperation.GlobalAccountID,
+		SubAccountID:           runtimeOperation.SubAccountID,
+		OrchestrationID:        op.OrchestrationID,
+		ServicePlanID:          op.ProvisioningParameters.PlanID,
+		ServicePlanName:        plan.PlanDefinition.Name,
+		DryRun:                 runtimeOperation.DryRun,
+		ShootName:              runtimeOperation.ShootName,
+		MaintenanceWindowBegin: runtimeOperation.MaintenanceWindowBegin,
+		MaintenanceWindowEnd:   runtimeOperation.MaintenanceWindowEnd,
+		State:                  string(op.State),
+		Description:            op.Description,
+		RetryOf:                runtimeOperation.RetryOf,
 	}, nil
 }
@@ -1,9 +1,16 @@
 package handlers
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/gorilla/mux"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration/strategies"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
@@ -15,11 +22,12 @@ type handler struct {
 	handlers []Handler
 }
 
-func NewOrchestrationHandler(db storage.BrokerStorage, kymaQueue *process.Queue, defaultMaxPage int, log logrus.FieldLogger) Handler {
+func NewOrchestrationHandler(db storage.BrokerStorage, kymaQueue *process.Queue, clusterQueue *process.Queue, defaultMaxPage int, log logrus.FieldLogger) Handler {
 	return &handler{
 		handlers: []Handler{
 			NewKymaHandler(db.Orchestrations(), kymaQueue, log),
-			NewOrchestrationStatusHandler(db.Operations(), db.Orchestrations(), db.RuntimeStates(), defaultMaxPage, log),
+			NewClusterHandler(db.Orchestrations(), clusterQueue, log),
+			NewOrchestrationStatusHandler(db.Operations(), db.Orchestrations(), db.Instances(), db.RuntimeStates(), kymaQueue, clusterQueue, defaultMaxPage, log),
 		},
 	}
 }
@@ -29,3 +37,80 @@ func (h *handler) AttachRoutes(router *mux.Router) {
 		handler.AttachRoutes(router)
 	}
 }
+
+// orchestrationQueues routes orchestrations to the queues which process them according to their type
+type orchestrationQueues struct {
+	kyma    *process.Queue
+	cluster *process.Queue
+}
+
+func (q orchestrationQueues) add(o *internal.Orchestration) {
+	switch o.Type {
+	case orchestration.UpgradeClusterOrchestration:
+		q.cluster.Add(o.OrchestrationID)
+	default:
+		q.kyma.Add(o.OrchestrationID)
+	}
+}
+
+func validateTarget(spec orchestration.TargetSpec) error {
+	if spec.Include == nil || len(spec.Include) == 0 {
+		return errors.New("targets.include array must be not empty")
+	}
+	return nil
+}
+
+// validateStrategy checks the failure thresholds, blackout periods and the parameters of the staged strategy
+func validateStrategy(spec orchestration.StrategySpec) error {
+	if spec.MaxFailures < 0 {
+		return errors.New("maxFailures must not be negative")
+	}
+	if spec.MaxFailureRatio < 0 || spec.MaxFailureRatio > 1 {
+		return errors.New("maxFailureRatio must be between 0 and 1")
+	}
+	for _, b := range spec.Blackouts {
+		if !b.End.After(b.Start) {
+			return fmt.Errorf("blackout end %s must be after its start %s", b.End.Format(time.RFC3339), b.Start.Format(time.RFC3339))
+		}
+	}
+	if spec.Type != orchestration.StagedStrategy {
+		return nil
+	}
+	staged := spec.Staged
+	if staged.CanarySize < 0 || staged.WaveSize < 0 {
+		return errors.New("staged canarySize and waveSize must not be negative")
+	}
+	for name, percentage := range map[string]int{
+		"canaryPercentage": staged.CanaryPercentage,
+		"wavePercentage":   staged.WavePercentage,
+	} {
+		if percentage < 0 || percentage > 100 {
+			return fmt.Errorf("staged %s must be between 0 and 100", name)
+		}
+	}
+	if _, err := strategies.ParseSoakTime(staged.SoakTime); err != nil {
+		return err
+	}
+	return nil
+}
+
+// defaultOrchestrationStrategy defaults the strategy if not specified to Parallel with Immediate schedule
+func defaultOrchestrationStrategy(spec *orchestration.StrategySpec) {
+	if spec.Parallel.Workers == 0 {
+		spec.Parallel.Workers = 1
+	}
+
+	switch spec.Type {
+	case orchestration.ParallelStrategy:
+	case orchestration.StagedStrategy:
+	default:
+		spec.Type = orchestration.ParallelStrategy
+	}
+
+	switch spec.Schedule {
+	case orchestration.MaintenanceWindow:
+	case orchestration.Immediate:
+	default:
+		spec.Schedule = orchestration.Immediate
+	}
+}
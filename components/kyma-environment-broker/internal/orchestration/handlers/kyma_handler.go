@@ -60,7 +60,7 @@ func (h *kymaHandler) createOrchestration(w http.ResponseWriter, r *http.Request
 	}
 
 	// validate target
-	err := h.validateTarget(params.Targets)
+	err := validateTarget(params.Targets)
 	if err != nil {
 		h.log.Errorf("while validating target: %v", err)
 		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrapf(err, "while validating target"))
@@ -75,12 +75,21 @@ func (h *kymaHandler) createOrchestration(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// validate strategy
+	err = validateStrategy(params.Strategy)
+	if err != nil {
+		h.log.Errorf("while validating strategy: %v", err)
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrapf(err, "while validating strategy"))
+		return
+	}
+
 	// defaults strategy if not specified to Parallel with Immediate schedule
-	h.defaultOrchestrationStrategy(&params.Strategy)
+	defaultOrchestrationStrategy(&params.Strategy)
 
 	now := time.Now()
 	o := internal.Orchestration{
 		OrchestrationID: uuid.New().String(),
+		Type:            orchestration.UpgradeKymaOrchestration,
 		State:           orchestration.Pending,
 		Description:     "started processing of Kyma upgrade",
 		Parameters:      params,
@@ -112,13 +121,6 @@ func (h *kymaHandler) resolveErrorStatus(err error) int {
 	}
 }
 
-func (h *kymaHandler) validateTarget(spec orchestration.TargetSpec) error {
-	if spec.Include == nil || len(spec.Include) == 0 {
-		return errors.New("targets.include array must be not empty")
-	}
-	return nil
-}
-
 // ValidateKymaVersion validates provided version. Supports three types of versioning:
 // semantic version, PR-<number>, and <branch name>-<commit hash>.
 // Validates version iff GitHub responded with 4xx code. If GitHub API does not work
@@ -164,22 +166,3 @@ func (h *kymaHandler) ValidateKymaVersion(version string) error {
 
 	return nil
 }
-
-func (h *kymaHandler) defaultOrchestrationStrategy(spec *orchestration.StrategySpec) {
-	if spec.Parallel.Workers == 0 {
-		spec.Parallel.Workers = 1
-	}
-
-	switch spec.Type {
-	case orchestration.ParallelStrategy:
-	default:
-		spec.Type = orchestration.ParallelStrategy
-	}
-
-	switch spec.Schedule {
-	case orchestration.MaintenanceWindow:
-	case orchestration.Immediate:
-	default:
-		spec.Schedule = orchestration.Immediate
-	}
-}
@@ -1,13 +1,17 @@
 package handlers
 
 import (
+	"encoding/json"
+	"io"
 	"net/http"
 
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pagination"
 
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
@@ -28,12 +32,15 @@ type orchestrationHandler struct {
 	log       logrus.FieldLogger
 
 	canceler *Canceler
+	resumer  *Resumer
+	retryer  *Retryer
 
 	defaultMaxPage int
 }
 
 // NewOrchestrationStatusHandler exposes data about orchestrations and allows to manage them
-func NewOrchestrationStatusHandler(operations storage.Operations, orchestrations storage.Orchestrations, runtimeStates storage.RuntimeStates, defaultMaxPage int, log logrus.FieldLogger) *orchestrationHandler {
+func NewOrchestrationStatusHandler(operations storage.Operations, orchestrations storage.Orchestrations, instances storage.Instances, runtimeStates storage.RuntimeStates,
+	kymaQueue, clusterQueue *process.Queue, defaultMaxPage int, log logrus.FieldLogger) *orchestrationHandler {
 	return &orchestrationHandler{
 		operations:     operations,
 		orchestrations: orchestrations,
@@ -41,7 +48,9 @@ func NewOrchestrationStatusHandler(operations storage.Operations, orchestrations
 		log:            log,
 		defaultMaxPage: defaultMaxPage,
 		converter:      Converter{},
-		canceler:       NewCanceler(orchestrations, log),
+		canceler:       NewCanceler(orchestrations, kymaQueue, clusterQueue, log),
+		resumer:        NewResumer(orchestrations, kymaQueue, clusterQueue, log),
+		retryer:        NewRetryer(orchestrations, operations, instances, kymaQueue, clusterQueue, log),
 	}
 }
 
@@ -49,6 +58,8 @@ func (h *orchestrationHandler) AttachRoutes(router *mux.Router) {
 	router.HandleFunc("/orchestrations", h.listOrchestration).Methods(http.MethodGet)
 	router.HandleFunc("/orchestrations/{orchestration_id}", h.getOrchestration).Methods(http.MethodGet)
 	router.HandleFunc("/orchestrations/{orchestration_id}/cancel", h.cancelOrchestrationByID).Methods(http.MethodPut)
+	router.HandleFunc("/orchestrations/{orchestration_id}/resume", h.resumeOrchestrationByID).Methods(http.MethodPut)
+	router.HandleFunc("/orchestrations/{orchestration_id}/retry", h.retryOrchestrationByID).Methods(http.MethodPost)
 	router.HandleFunc("/orchestrations/{orchestration_id}/operations", h.listOperations).Methods(http.MethodGet)
 	router.HandleFunc("/orchestrations/{orchestration_id}/operations/{operation_id}", h.getOperation).Methods(http.MethodGet)
 }
@@ -95,6 +106,46 @@ func (h *orchestrationHandler) cancelOrchestrationByID(w http.ResponseWriter, r
 	httputil.WriteResponse(w, http.StatusOK, response)
 }
 
+func (h *orchestrationHandler) resumeOrchestrationByID(w http.ResponseWriter, r *http.Request) {
+	orchestrationID := mux.Vars(r)["orchestration_id"]
+
+	err := h.resumer.ResumeForID(orchestrationID)
+	if err != nil {
+		h.log.Errorf("while resuming orchestration %s: %v", orchestrationID, err)
+		httputil.WriteErrorResponse(w, h.resolveErrorStatus(err), errors.Wrapf(err, "while resuming orchestration %s", orchestrationID))
+		return
+	}
+
+	response := commonOrchestration.UpgradeResponse{OrchestrationID: orchestrationID}
+
+	httputil.WriteResponse(w, http.StatusOK, response)
+}
+
+func (h *orchestrationHandler) retryOrchestrationByID(w http.ResponseWriter, r *http.Request) {
+	orchestrationID := mux.Vars(r)["orchestration_id"]
+
+	req := commonOrchestration.RetryRequest{}
+	if r.Body != nil {
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil && err != io.EOF {
+			h.log.Errorf("while decoding request body: %v", err)
+			httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrapf(err, "while decoding request body"))
+			return
+		}
+	}
+
+	retryID, err := h.retryer.RetryForID(orchestrationID, req.OperationIDs)
+	if err != nil {
+		h.log.Errorf("while retrying orchestration %s: %v", orchestrationID, err)
+		httputil.WriteErrorResponse(w, h.resolveErrorStatus(err), errors.Wrapf(err, "while retrying orchestration %s", orchestrationID))
+		return
+	}
+
+	response := commonOrchestration.UpgradeResponse{OrchestrationID: retryID}
+
+	httputil.WriteResponse(w, http.StatusAccepted, response)
+}
+
 func (h *orchestrationHandler) listOrchestration(w http.ResponseWriter, r *http.Request) {
 	pageSize, page, err := pagination.ExtractPaginationConfigFromRequest(r, h.defaultMaxPage)
 	if err != nil {
@@ -141,17 +192,36 @@ func (h *orchestrationHandler) listOperations(w http.ResponseWriter, r *http.Req
 		States: query[commonOrchestration.StateParam],
 	}
 
-	operations, count, totalCount, err := h.operations.ListUpgradeKymaOperationsByOrchestrationID(orchestrationID, filter)
+	o, err := h.orchestrations.GetByID(orchestrationID)
 	if err != nil {
-		h.log.Errorf("while getting operations: %v", err)
-		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while getting operations"))
+		h.log.Errorf("while getting orchestration %s: %v", orchestrationID, err)
+		httputil.WriteErrorResponse(w, h.resolveErrorStatus(err), errors.Wrapf(err, "while getting orchestration %s", orchestrationID))
 		return
 	}
 
-	response, err := h.converter.UpgradeKymaOperationListToDTO(operations, count, totalCount)
+	var (
+		response          commonOrchestration.OperationResponseList
+		count, totalCount int
+	)
+	switch o.Type {
+	case commonOrchestration.UpgradeClusterOrchestration:
+		var operations []internal.UpgradeClusterOperation
+		operations, count, totalCount, err = h.operations.ListUpgradeClusterOperationsByOrchestrationID(orchestrationID, filter)
+		if err != nil {
+			break
+		}
+		response, err = h.converter.UpgradeClusterOperationListToDTO(operations, count, totalCount)
+	default:
+		var operations []internal.UpgradeKymaOperation
+		operations, count, totalCount, err = h.operations.ListUpgradeKymaOperationsByOrchestrationID(orchestrationID, filter)
+		if err != nil {
+			break
+		}
+		response, err = h.converter.UpgradeKymaOperationListToDTO(operations, count, totalCount)
+	}
 	if err != nil {
-		h.log.Errorf("while converting operations: %v", err)
-		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while converting operations"))
+		h.log.Errorf("while getting operations: %v", err)
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while getting operations"))
 		return
 	}
 
@@ -159,8 +229,20 @@ func (h *orchestrationHandler) listOperations(w http.ResponseWriter, r *http.Req
 }
 
 func (h *orchestrationHandler) getOperation(w http.ResponseWriter, r *http.Request) {
+	orchestrationID := mux.Vars(r)["orchestration_id"]
 	operationID := mux.Vars(r)["operation_id"]
 
+	o, err := h.orchestrations.GetByID(orchestrationID)
+	if err != nil {
+		h.log.Errorf("while getting orchestration %s: %v", orchestrationID, err)
+		httputil.WriteErrorResponse(w, h.resolveErrorStatus(err), errors.Wrapf(err, "while getting orchestration %s", orchestrationID))
+		return
+	}
+	if o.Type == commonOrchestration.UpgradeClusterOrchestration {
+		h.getUpgradeClusterOperation(w, operationID)
+		return
+	}
+
 	operation, err := h.operations.GetUpgradeKymaOperationByID(operationID)
 	if err != nil {
 		h.log.Errorf("while getting upgrade operation %s: %v", operationID, err)
@@ -193,6 +275,34 @@ func (h *orchestrationHandler) getOperation(w http.ResponseWriter, r *http.Reque
 	httputil.WriteResponse(w, http.StatusOK, response)
 }
 
+func (h *orchestrationHandler) getUpgradeClusterOperation(w http.ResponseWriter, operationID string) {
+	operation, err := h.operations.GetUpgradeClusterOperationByID(operationID)
+	if err != nil {
+		h.log.Errorf("while getting upgrade cluster operation %s: %v", operationID, err)
+		httputil.WriteErrorResponse(w, h.resolveErrorStatus(err), errors.Wrapf(err, "while getting operation %s", operationID))
+		return
+	}
+	provisioningOp, err := h.operations.GetProvisioningOperationByInstanceID(operation.InstanceID)
+	if err != nil {
+		h.log.Errorf("while getting provisioning operation for instance %s: %v", operation.InstanceID, err)
+		httputil.WriteErrorResponse(w, h.resolveErrorStatus(err), errors.Wrapf(err, "while getting provisioning operation for instance %s", operation.InstanceID))
+		return
+	}
+	provisioningState, err := h.runtimeStates.GetByOperationID(provisioningOp.ID)
+	if err != nil {
+		h.log.Errorf("while getting runtime state for operation %s: %v", provisioningOp.ID, err)
+	}
+
+	response, err := h.converter.UpgradeClusterOperationToDetailDTO(*operation, provisioningState.ClusterConfig)
+	if err != nil {
+		h.log.Errorf("while converting operation: %v", err)
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while converting operation"))
+		return
+	}
+
+	httputil.WriteResponse(w, http.StatusOK, response)
+}
+
 func (h *orchestrationHandler) resolveErrorStatus(err error) int {
 	cause := errors.Cause(err)
 	switch {
@@ -200,6 +310,8 @@ func (h *orchestrationHandler) resolveErrorStatus(err error) int {
 		return http.StatusNotFound
 	case apiErrors.IsBadRequest(cause):
 		return http.StatusBadRequest
+	case apiErrors.IsConflict(cause):
+		return http.StatusConflict
 	default:
 		return http.StatusInternalServerError
 	}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -9,7 +10,9 @@ import (
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
 	"github.com/pivotal-cf/brokerapi/v7/domain"
 	"github.com/stretchr/testify/assert"
 
@@ -30,7 +33,7 @@ func TestStatusHandler_AttachRoutes(t *testing.T) {
 		require.NoError(t, err)
 
 		logs := logrus.New()
-		kymaHandler := NewOrchestrationStatusHandler(db.Operations(), db.Orchestrations(), db.RuntimeStates(), 100, logs)
+		kymaHandler := NewOrchestrationStatusHandler(db.Operations(), db.Orchestrations(), db.Instances(), db.RuntimeStates(), nil, nil, 100, logs)
 
 		req, err := http.NewRequest("GET", "/orchestrations?page_size=1", nil)
 		require.NoError(t, err)
@@ -129,8 +132,10 @@ func TestStatusHandler_AttachRoutes(t *testing.T) {
 				},
 			},
 			RuntimeOperation: orchestration.RuntimeOperation{
-				ID: fixID,
+				ID:     fixID,
+				DryRun: true,
 			},
+			KymaConfigDiff: &orchestration.KymaConfigDiff{CurrentVersion: "1.18.0", TargetVersion: "1.19.0"},
 		})
 		err = db.Operations().InsertProvisioningOperation(internal.ProvisioningOperation{
 			Operation: internal.Operation{
@@ -146,7 +151,7 @@ func TestStatusHandler_AttachRoutes(t *testing.T) {
 		require.NoError(t, err)
 
 		logs := logrus.New()
-		kymaHandler := NewOrchestrationStatusHandler(db.Operations(), db.Orchestrations(), db.RuntimeStates(), 100, logs)
+		kymaHandler := NewOrchestrationStatusHandler(db.Operations(), db.Orchestrations(), db.Instances(), db.RuntimeStates(), nil, nil, 100, logs)
 
 		urlPath := fmt.Sprintf("/orchestrations/%s/operations", fixID)
 		req, err := http.NewRequest("GET", urlPath, nil)
@@ -188,6 +193,77 @@ func TestStatusHandler_AttachRoutes(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, dto.OrchestrationID, fixID)
 		assert.Equal(t, dto.OperationID, fixID)
+		require.NotNil(t, dto.KymaConfigDiff)
+		assert.Equal(t, "1.19.0", dto.KymaConfigDiff.TargetVersion)
+	})
+
+	t.Run("cluster upgrade operations", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+
+		err := db.Orchestrations().Insert(internal.Orchestration{OrchestrationID: fixID, Type: orchestration.UpgradeClusterOrchestration})
+		require.NoError(t, err)
+		err = db.Operations().InsertUpgradeClusterOperation(internal.UpgradeClusterOperation{
+			Operation: internal.Operation{
+				ID:              fixID,
+				InstanceID:      fixID,
+				OrchestrationID: fixID,
+				ProvisioningParameters: internal.ProvisioningParameters{
+					PlanID: "4deee563-e5ec-4731-b9b1-53b42d855f0c",
+				},
+			},
+			RuntimeOperation: orchestration.RuntimeOperation{
+				ID: fixID,
+			},
+		})
+		require.NoError(t, err)
+		err = db.Operations().InsertProvisioningOperation(internal.ProvisioningOperation{
+			Operation: internal.Operation{
+				ID:         "id-2",
+				InstanceID: fixID,
+			},
+		})
+		require.NoError(t, err)
+
+		logs := logrus.New()
+		handler := NewOrchestrationStatusHandler(db.Operations(), db.Orchestrations(), db.Instances(), db.RuntimeStates(), nil, nil, 100, logs)
+
+		req, err := http.NewRequest("GET", fmt.Sprintf("/orchestrations/%s/operations", fixID), nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		router := mux.NewRouter()
+		handler.AttachRoutes(router)
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var out orchestration.OperationResponseList
+		err = json.Unmarshal(rr.Body.Bytes(), &out)
+		require.NoError(t, err)
+		require.Len(t, out.Data, 1)
+		assert.Equal(t, fixID, out.Data[0].OperationID)
+
+		// given
+		req, err = http.NewRequest(http.MethodGet, fmt.Sprintf("/orchestrations/%s/operations/%s", fixID, fixID), nil)
+		require.NoError(t, err)
+		rr = httptest.NewRecorder()
+
+		dto := orchestration.OperationDetailResponse{}
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		err = json.Unmarshal(rr.Body.Bytes(), &dto)
+		require.NoError(t, err)
+		assert.Equal(t, fixID, dto.OrchestrationID)
+		assert.Equal(t, fixID, dto.OperationID)
 	})
 
 	t.Run("cancel orchestration", func(t *testing.T) {
@@ -198,7 +274,7 @@ func TestStatusHandler_AttachRoutes(t *testing.T) {
 		require.NoError(t, err)
 
 		logs := logrus.New()
-		kymaHandler := NewOrchestrationStatusHandler(db.Operations(), db.Orchestrations(), db.RuntimeStates(), 100, logs)
+		kymaHandler := NewOrchestrationStatusHandler(db.Operations(), db.Orchestrations(), db.Instances(), db.RuntimeStates(), nil, nil, 100, logs)
 
 		req, err := http.NewRequest("PUT", fmt.Sprintf("/orchestrations/%s/cancel", fixID), nil)
 		require.NoError(t, err)
@@ -223,4 +299,38 @@ func TestStatusHandler_AttachRoutes(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, orchestration.Canceling, o.State)
 	})
+
+	t.Run("retry orchestration", func(t *testing.T) {
+		// given
+		db := fixRetryStorage(t, orchestration.UpgradeKymaOrchestration)
+
+		logs := logrus.New()
+		kymaHandler := NewOrchestrationStatusHandler(db.Operations(), db.Orchestrations(), db.Instances(), db.RuntimeStates(), process.NewQueue(&testExecutor{}, logs), nil, 100, logs)
+
+		p, err := json.Marshal(orchestration.RetryRequest{OperationIDs: []string{"failed"}})
+		require.NoError(t, err)
+		req, err := http.NewRequest("POST", fmt.Sprintf("/orchestrations/%s/retry", fixOrchestrationID), bytes.NewBuffer(p))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		router := mux.NewRouter()
+		kymaHandler.AttachRoutes(router)
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusAccepted, rr.Code)
+
+		var out orchestration.UpgradeResponse
+
+		err = json.Unmarshal(rr.Body.Bytes(), &out)
+		require.NoError(t, err)
+		assert.NotEqual(t, fixOrchestrationID, out.OrchestrationID)
+
+		ops, _, _, err := db.Operations().ListUpgradeKymaOperationsByOrchestrationID(out.OrchestrationID, dbmodel.OperationFilter{})
+		require.NoError(t, err)
+		require.Len(t, ops, 1)
+		assert.Equal(t, "failed", ops[0].RetryOf)
+	})
 }
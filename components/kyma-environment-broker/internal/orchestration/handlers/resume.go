@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	orchestrationExt "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type Resumer struct {
+	orchestrations storage.Orchestrations
+	queues         orchestrationQueues
+	log            logrus.FieldLogger
+}
+
+func NewResumer(orchestrations storage.Orchestrations, kymaQueue, clusterQueue *process.Queue, logger logrus.FieldLogger) *Resumer {
+	return &Resumer{
+		orchestrations: orchestrations,
+		queues:         orchestrationQueues{kyma: kymaQueue, cluster: clusterQueue},
+		log:            logger,
+	}
+}
+
+// ResumeForID resumes processing of the paused orchestration by ID
+func (r *Resumer) ResumeForID(orchestrationID string) error {
+	o, err := r.orchestrations.GetByID(orchestrationID)
+	if err != nil {
+		return errors.Wrap(err, "while getting orchestration")
+	}
+	if o.State != orchestrationExt.Paused {
+		return apiErrors.NewBadRequest(fmt.Sprintf("orchestration in state %s cannot be resumed", o.State))
+	}
+
+	o.UpdatedAt = time.Now()
+	o.Description = "Orchestration was resumed"
+	o.State = orchestrationExt.InProgress
+	err = r.orchestrations.Update(*o)
+	if err != nil {
+		return errors.Wrap(err, "while updating orchestration")
+	}
+
+	r.queues.add(o)
+	return nil
+}
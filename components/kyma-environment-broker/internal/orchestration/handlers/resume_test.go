@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestResumer_ResumeForID(t *testing.T) {
+	t.Run("should resume paused orchestration", func(t *testing.T) {
+		s := storage.NewMemoryStorage()
+		o := fixOrchestration()
+		o.State = orchestration.Paused
+		o.Type = orchestration.UpgradeClusterOrchestration
+		err := s.Orchestrations().Insert(o)
+		require.NoError(t, err)
+
+		executor := newRecordingExecutor()
+		clusterQueue := process.NewQueue(executor, logrus.New())
+		stop := make(chan struct{})
+		defer close(stop)
+		clusterQueue.Run(stop, 1)
+
+		r := NewResumer(s.Orchestrations(), nil, clusterQueue, logrus.New())
+
+		err = r.ResumeForID(fixOrchestrationID)
+		require.NoError(t, err)
+
+		resumed, err := s.Orchestrations().GetByID(fixOrchestrationID)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.InProgress, resumed.State)
+		assert.Eventually(t, func() bool {
+			return executor.processed(fixOrchestrationID)
+		}, time.Second, 10*time.Millisecond)
+	})
+	t.Run("should not resume orchestration which is not paused", func(t *testing.T) {
+		s := storage.NewMemoryStorage()
+		err := s.Orchestrations().Insert(fixOrchestration())
+		require.NoError(t, err)
+
+		r := NewResumer(s.Orchestrations(), nil, nil, logrus.New())
+
+		err = r.ResumeForID(fixOrchestrationID)
+		require.Error(t, err)
+		assert.True(t, apiErrors.IsBadRequest(err))
+	})
+	t.Run("should return error when orchestration not found", func(t *testing.T) {
+		s := storage.NewMemoryStorage()
+		r := NewResumer(s.Orchestrations(), nil, nil, logrus.New())
+
+		err := r.ResumeForID(fixOrchestrationID)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	orchestrationExt "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	internalOrchestration "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	retriableStates  = []string{orchestrationExt.Failed, orchestrationExt.Canceled}
+	unfinishedStates = []string{orchestrationExt.Pending, orchestrationExt.InProgress, orchestrationExt.Paused, orchestrationExt.Canceling}
+
+	orchestrationResource = schema.GroupResource{Resource: "orchestrations"}
+)
+
+type Retryer struct {
+	orchestrations storage.Orchestrations
+	operations     storage.Operations
+	instances      storage.Instances
+	queues         orchestrationQueues
+	log            logrus.FieldLogger
+}
+
+func NewRetryer(orchestrations storage.Orchestrations, operations storage.Operations, instances storage.Instances, kymaQueue, clusterQueue *process.Queue, logger logrus.FieldLogger) *Retryer {
+	return &Retryer{
+		orchestrations: orchestrations,
+		operations:     operations,
+		instances:      instances,
+		queues:         orchestrationQueues{kyma: kymaQueue, cluster: clusterQueue},
+		log:            logger,
+	}
+}
+
+// RetryForID schedules a new orchestration with the parameters of the given finished orchestration, which retries its failed
+// and canceled operations. If operation IDs are given, only these operations are retried. Only one retry of the orchestration
+// can be unfinished at a time. Returns the ID of the new orchestration.
+func (r *Retryer) RetryForID(orchestrationID string, operationIDs []string) (string, error) {
+	o, err := r.orchestrations.GetByID(orchestrationID)
+	if err != nil {
+		return "", errors.Wrap(err, "while getting orchestration")
+	}
+	if !o.IsFinished() {
+		return "", apiErrors.NewBadRequest(fmt.Sprintf("orchestration in state %s cannot be retried", o.State))
+	}
+	err = r.checkUnfinishedRetry(o)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	retry := internal.Orchestration{
+		OrchestrationID: uuid.New().String(),
+		Type:            o.Type,
+		State:           orchestrationExt.InProgress,
+		Parameters:      o.Parameters,
+		RetryOf:         o.OrchestrationID,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	err = r.retryOperations(o, &retry, operationIDs)
+	if err != nil {
+		return "", err
+	}
+
+	r.queues.add(&retry)
+	return retry.OrchestrationID, nil
+}
+
+// checkUnfinishedRetry fails if the orchestration is already retried by an orchestration which is not finished
+func (r *Retryer) checkUnfinishedRetry(o *internal.Orchestration) error {
+	retries, _, _, err := r.orchestrations.List(dbmodel.OrchestrationFilter{States: unfinishedStates})
+	if err != nil {
+		return errors.Wrap(err, "while listing orchestrations")
+	}
+	for _, retry := range retries {
+		if retry.RetryOf == o.OrchestrationID {
+			return apiErrors.NewConflict(orchestrationResource, o.OrchestrationID,
+				fmt.Errorf("orchestration is already retried by orchestration %s in state %s", retry.OrchestrationID, retry.State))
+		}
+	}
+	return nil
+}
+
+func (r *Retryer) retryOperations(o, retry *internal.Orchestration, operationIDs []string) error {
+	operations := internalOrchestration.OperationsForType(o.Type, r.orchestrations, r.operations)
+	ops, err := operations.ListOperations(o.OrchestrationID, retriableStates)
+	if err != nil {
+		return errors.Wrap(err, "while listing operations")
+	}
+	selected, err := selectOperations(len(ops), func(i int) string { return ops[i].Operation.ID }, operationIDs)
+	if err != nil {
+		return err
+	}
+
+	var retryOps []internalOrchestration.RuntimeOperation
+	for _, i := range selected {
+		op, found, err := r.newRetryOperation(retry, ops[i])
+		if err != nil {
+			return err
+		}
+		if found {
+			retryOps = append(retryOps, op)
+		}
+	}
+	if len(retryOps) == 0 {
+		return apiErrors.NewBadRequest(fmt.Sprintf("orchestration %s does not have operations to retry", o.OrchestrationID))
+	}
+
+	retry.Description = fmt.Sprintf("Retrying %d operations of orchestration %s", len(retryOps), o.OrchestrationID)
+	err = operations.InsertOrchestration(*retry, retryOps)
+	switch {
+	case dberr.IsAlreadyExists(err):
+		return apiErrors.NewConflict(orchestrationResource, o.OrchestrationID, err)
+	case err != nil:
+		return errors.Wrap(err, "while inserting orchestration")
+	}
+	return nil
+}
+
+// newRetryOperation creates a pending operation which retries the given operation in the new orchestration,
+// false is returned if the instance of the operation does not exist anymore
+func (r *Retryer) newRetryOperation(retry *internal.Orchestration, retried internalOrchestration.RuntimeOperation) (internalOrchestration.RuntimeOperation, bool, error) {
+	op := retried.Operation
+	inst, err := r.instances.GetByID(op.InstanceID)
+	switch {
+	case dberr.IsNotFound(err):
+		r.log.Infof("Skipping retry of operation %s, instance %s does not exist", op.ID, op.InstanceID)
+		return internalOrchestration.RuntimeOperation{}, false, nil
+	case err != nil:
+		return internalOrchestration.RuntimeOperation{}, false, errors.Wrapf(err, "while getting instance %s", op.InstanceID)
+	}
+
+	runtime := retried.RuntimeOperation.Runtime
+	if retry.Parameters.Strategy.Schedule == orchestrationExt.MaintenanceWindow {
+		runtime.MaintenanceWindowBegin, runtime.MaintenanceWindowEnd = internalOrchestration.ResolveMaintenanceWindowTime(runtime.MaintenanceWindowBegin, runtime.MaintenanceWindowEnd)
+	}
+
+	id := uuid.New().String()
+	now := time.Now()
+	return internalOrchestration.RuntimeOperation{
+		Operation: internal.Operation{
+			ID:                     id,
+			Version:                0,
+			CreatedAt:              now,
+			UpdatedAt:              now,
+			InstanceID:             op.InstanceID,
+			State:                  orchestrationExt.Pending,
+			Description:            "Operation created",
+			OrchestrationID:        retry.OrchestrationID,
+			ProvisioningParameters: op.ProvisioningParameters,
+			InstanceDetails:        inst.InstanceDetails,
+		},
+		RuntimeOperation: orchestrationExt.RuntimeOperation{
+			ID:      id,
+			Runtime: runtime,
+			DryRun:  retry.Parameters.DryRun,
+			RetryOf: op.ID,
+		},
+	}, true, nil
+}
+
+// selectOperations returns the indexes of the operations with the given IDs, or all indexes if no IDs are given
+func selectOperations(count int, id func(i int) string, operationIDs []string) ([]int, error) {
+	var selected []int
+	if len(operationIDs) == 0 {
+		for i := 0; i < count; i++ {
+			selected = append(selected, i)
+		}
+		return selected, nil
+	}
+
+	indexes := map[string]int{}
+	for i := 0; i < count; i++ {
+		indexes[id(i)] = i
+	}
+	seen := map[string]bool{}
+	for _, opID := range operationIDs {
+		i, found := indexes[opID]
+		if !found {
+			return nil, apiErrors.NewBadRequest(fmt.Sprintf("operation %s is not a failed or canceled operation of the orchestration", opID))
+		}
+		if !seen[opID] {
+			selected = append(selected, i)
+			seen[opID] = true
+		}
+	}
+	return selected, nil
+}
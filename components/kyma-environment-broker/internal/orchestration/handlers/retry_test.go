@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestRetryer_RetryForID(t *testing.T) {
+	t.Run("should retry failed and canceled operations", func(t *testing.T) {
+		// given
+		s := fixRetryStorage(t, orchestration.UpgradeKymaOrchestration)
+
+		executor := newRecordingExecutor()
+		kymaQueue := process.NewQueue(executor, logrus.New())
+		stop := make(chan struct{})
+		defer close(stop)
+		kymaQueue.Run(stop, 1)
+
+		r := NewRetryer(s.Orchestrations(), s.Operations(), s.Instances(), kymaQueue, nil, logrus.New())
+
+		// when
+		retryID, err := r.RetryForID(fixOrchestrationID, nil)
+
+		// then
+		require.NoError(t, err)
+		o, err := s.Orchestrations().GetByID(retryID)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.InProgress, o.State)
+		assert.Equal(t, orchestration.UpgradeKymaOrchestration, o.Type)
+		assert.Equal(t, orchestration.ParallelStrategy, o.Parameters.Strategy.Type)
+
+		ops, _, _, err := s.Operations().ListUpgradeKymaOperationsByOrchestrationID(retryID, dbmodel.OperationFilter{})
+		require.NoError(t, err)
+		var retried []string
+		for _, op := range ops {
+			assert.Equal(t, orchestration.Pending, string(op.State))
+			assert.Equal(t, op.Operation.ID, op.RuntimeOperation.ID)
+			retried = append(retried, op.RetryOf)
+		}
+		assert.ElementsMatch(t, []string{"failed", "canceled"}, retried)
+		assert.Eventually(t, func() bool {
+			return executor.processed(retryID)
+		}, time.Second, 10*time.Millisecond)
+	})
+	t.Run("should retry only given cluster upgrade operations", func(t *testing.T) {
+		// given
+		s := fixRetryStorage(t, orchestration.UpgradeClusterOrchestration)
+
+		executor := newRecordingExecutor()
+		clusterQueue := process.NewQueue(executor, logrus.New())
+		stop := make(chan struct{})
+		defer close(stop)
+		clusterQueue.Run(stop, 1)
+
+		r := NewRetryer(s.Orchestrations(), s.Operations(), s.Instances(), nil, clusterQueue, logrus.New())
+
+		// when
+		retryID, err := r.RetryForID(fixOrchestrationID, []string{"canceled"})
+
+		// then
+		require.NoError(t, err)
+		ops, _, _, err := s.Operations().ListUpgradeClusterOperationsByOrchestrationID(retryID, dbmodel.OperationFilter{})
+		require.NoError(t, err)
+		require.Len(t, ops, 1)
+		assert.Equal(t, "canceled", ops[0].RetryOf)
+		assert.Equal(t, "instance-canceled", ops[0].InstanceID)
+	})
+	t.Run("should not retry succeeded operation", func(t *testing.T) {
+		// given
+		s := fixRetryStorage(t, orchestration.UpgradeKymaOrchestration)
+		r := NewRetryer(s.Orchestrations(), s.Operations(), s.Instances(), nil, nil, logrus.New())
+
+		// when
+		_, err := r.RetryForID(fixOrchestrationID, []string{"succeeded"})
+
+		// then
+		require.Error(t, err)
+		assert.True(t, apiErrors.IsBadRequest(err))
+	})
+	t.Run("should not retry operations of deleted instances", func(t *testing.T) {
+		// given
+		s := fixRetryStorage(t, orchestration.UpgradeKymaOrchestration)
+		r := NewRetryer(s.Orchestrations(), s.Operations(), s.Instances(), nil, nil, logrus.New())
+
+		// when
+		_, err := r.RetryForID(fixOrchestrationID, []string{"deleted"})
+
+		// then
+		require.Error(t, err)
+		assert.True(t, apiErrors.IsBadRequest(err))
+	})
+	t.Run("should not retry orchestration which is already retried", func(t *testing.T) {
+		// given
+		s := fixRetryStorage(t, orchestration.UpgradeKymaOrchestration)
+		r := NewRetryer(s.Orchestrations(), s.Operations(), s.Instances(), process.NewQueue(newRecordingExecutor(), logrus.New()), nil, logrus.New())
+		retryID, err := r.RetryForID(fixOrchestrationID, []string{"failed"})
+		require.NoError(t, err)
+
+		// when
+		_, err = r.RetryForID(fixOrchestrationID, []string{"canceled"})
+
+		// then
+		require.Error(t, err)
+		assert.True(t, apiErrors.IsConflict(err))
+		_, _, total, err := s.Orchestrations().List(dbmodel.OrchestrationFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+
+		// when the retry is finished
+		retry, err := s.Orchestrations().GetByID(retryID)
+		require.NoError(t, err)
+		assert.Equal(t, fixOrchestrationID, retry.RetryOf)
+		retry.State = orchestration.Failed
+		err = s.Orchestrations().Update(*retry)
+		require.NoError(t, err)
+		_, err = r.RetryForID(fixOrchestrationID, []string{"canceled"})
+
+		// then
+		require.NoError(t, err)
+	})
+	t.Run("should not store retry if the orchestration is retried concurrently", func(t *testing.T) {
+		// given
+		s := fixRetryStorage(t, orchestration.UpgradeKymaOrchestration)
+		err := s.Orchestrations().Insert(internal.Orchestration{OrchestrationID: "concurrent", State: orchestration.Pending, RetryOf: fixOrchestrationID})
+		require.NoError(t, err)
+		r := NewRetryer(s.Orchestrations(), s.Operations(), s.Instances(), nil, nil, logrus.New())
+
+		// when
+		err = r.retryOperations(&internal.Orchestration{OrchestrationID: fixOrchestrationID, Type: orchestration.UpgradeKymaOrchestration},
+			&internal.Orchestration{OrchestrationID: "retry", RetryOf: fixOrchestrationID, State: orchestration.InProgress}, nil)
+
+		// then
+		require.Error(t, err)
+		assert.True(t, apiErrors.IsConflict(err))
+		_, err = s.Orchestrations().GetByID("retry")
+		assert.True(t, dberr.IsNotFound(err))
+		ops, _, _, err := s.Operations().ListUpgradeKymaOperationsByOrchestrationID("retry", dbmodel.OperationFilter{})
+		require.NoError(t, err)
+		assert.Empty(t, ops)
+	})
+	t.Run("should not retry orchestration which is not finished", func(t *testing.T) {
+		// given
+		s := storage.NewMemoryStorage()
+		err := s.Orchestrations().Insert(fixOrchestration())
+		require.NoError(t, err)
+		r := NewRetryer(s.Orchestrations(), s.Operations(), s.Instances(), nil, nil, logrus.New())
+
+		// when
+		_, err = r.RetryForID(fixOrchestrationID, nil)
+
+		// then
+		require.Error(t, err)
+		assert.True(t, apiErrors.IsBadRequest(err))
+	})
+}
+
+func fixRetryStorage(t *testing.T, orchestrationType orchestration.Type) storage.BrokerStorage {
+	s := storage.NewMemoryStorage()
+	o := fixOrchestration()
+	o.Type = orchestrationType
+	o.State = orchestration.Failed
+	o.Parameters.Strategy = orchestration.StrategySpec{
+		Type:     orchestration.ParallelStrategy,
+		Schedule: orchestration.Immediate,
+	}
+	err := s.Orchestrations().Insert(o)
+	require.NoError(t, err)
+
+	for opID, state := range map[string]string{
+		"failed":    orchestration.Failed,
+		"canceled":  orchestration.Canceled,
+		"succeeded": orchestration.Succeeded,
+		"deleted":   orchestration.Failed,
+	} {
+		instanceID := "instance-" + opID
+		if opID != "deleted" {
+			err = s.Instances().Insert(internal.Instance{InstanceID: instanceID})
+			require.NoError(t, err)
+		}
+		op := internal.Operation{
+			ID:              opID,
+			InstanceID:      instanceID,
+			OrchestrationID: fixOrchestrationID,
+			State:           domain.LastOperationState(state),
+		}
+		runtimeOp := orchestration.RuntimeOperation{ID: opID, Runtime: orchestration.Runtime{RuntimeID: "runtime-" + opID, InstanceID: instanceID}}
+		switch orchestrationType {
+		case orchestration.UpgradeClusterOrchestration:
+			err = s.Operations().InsertUpgradeClusterOperation(internal.UpgradeClusterOperation{Operation: op, RuntimeOperation: runtimeOp})
+		default:
+			err = s.Operations().InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{Operation: op, RuntimeOperation: runtimeOp})
+		}
+		require.NoError(t, err)
+	}
+	return s
+}
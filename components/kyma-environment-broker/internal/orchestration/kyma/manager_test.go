@@ -78,6 +78,41 @@ func TestUpgradeKymaManager_Execute(t *testing.T) {
 
 	})
 
+	t.Run("StartTime", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+
+		resolver := &automock.RuntimeResolver{}
+		defer resolver.AssertExpectations(t)
+
+		startTime := time.Now().Add(time.Hour)
+		id := "id"
+		err := store.Orchestrations().Insert(internal.Orchestration{
+			OrchestrationID: id,
+			State:           orchestration.Pending,
+			Parameters: orchestration.Parameters{
+				Strategy: orchestration.StrategySpec{
+					Type:      orchestration.ParallelStrategy,
+					Schedule:  orchestration.Immediate,
+					StartTime: &startTime,
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		svc := kyma.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), nil, resolver, poolingInterval, nil, logrus.New())
+
+		// when
+		when, err := svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		assert.True(t, when > 59*time.Minute && when <= time.Hour)
+		o, err := store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Pending, o.State)
+	})
+
 	t.Run("DryRun", func(t *testing.T) {
 		// given
 		store := storage.NewMemoryStorage()
@@ -207,6 +242,141 @@ func TestUpgradeKymaManager_Execute(t *testing.T) {
 
 		assert.Equal(t, orchestration.Canceled, string(op.State))
 	})
+
+	t.Run("StagedPaused", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+
+		resolver := &automock.RuntimeResolver{}
+		defer resolver.AssertExpectations(t)
+
+		id := "id"
+		err := store.Orchestrations().Insert(internal.Orchestration{
+			OrchestrationID: id,
+			State:           orchestration.InProgress,
+			Parameters: orchestration.Parameters{Strategy: orchestration.StrategySpec{
+				Type:     orchestration.StagedStrategy,
+				Schedule: orchestration.Immediate,
+				Parallel: orchestration.ParallelStrategySpec{Workers: 1},
+				Staged:   orchestration.StagedStrategySpec{CanarySize: 1},
+			}},
+		})
+		require.NoError(t, err)
+		for i, opID := range []string{"canary", "wave"} {
+			err = store.Operations().InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{
+				Operation: internal.Operation{
+					ID:              opID,
+					OrchestrationID: id,
+					State:           orchestration.Pending,
+					CreatedAt:       time.Now().Add(time.Duration(i) * time.Second),
+				},
+				RuntimeOperation: orchestration.RuntimeOperation{ID: opID},
+			})
+			require.NoError(t, err)
+		}
+
+		svc := kyma.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), &failingExecutor{operations: store.Operations()}, resolver, poolingInterval, nil, logrus.New())
+
+		// when
+		_, err = svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		o, err := store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Paused, o.State)
+
+		op, err := store.Operations().GetUpgradeKymaOperationByID("canary")
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Failed, string(op.State))
+
+		// the wave is upgraded when the orchestration is resumed
+		op, err = store.Operations().GetUpgradeKymaOperationByID("wave")
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Pending, string(op.State))
+	})
+
+	t.Run("Paused", func(t *testing.T) {
+		// given
+		store := storage.NewMemoryStorage()
+
+		resolver := &automock.RuntimeResolver{}
+		defer resolver.AssertExpectations(t)
+
+		id := "id"
+		err := store.Orchestrations().Insert(internal.Orchestration{
+			OrchestrationID: id,
+			State:           orchestration.InProgress,
+			Parameters: orchestration.Parameters{Strategy: orchestration.StrategySpec{
+				Type:            orchestration.ParallelStrategy,
+				Schedule:        orchestration.Immediate,
+				Parallel:        orchestration.ParallelStrategySpec{Workers: 1},
+				MaxFailureRatio: 0.5,
+			}},
+		})
+		require.NoError(t, err)
+		for i, opID := range []string{"failing", "second", "third"} {
+			err = store.Operations().InsertUpgradeKymaOperation(internal.UpgradeKymaOperation{
+				Operation: internal.Operation{
+					ID:              opID,
+					OrchestrationID: id,
+					State:           orchestration.Pending,
+					CreatedAt:       time.Now().Add(time.Duration(i) * time.Second),
+				},
+				RuntimeOperation: orchestration.RuntimeOperation{ID: opID},
+			})
+			require.NoError(t, err)
+		}
+
+		executor := &pausingExecutor{failing: "failing", failingExecutor: failingExecutor{operations: store.Operations()}}
+		svc := kyma.NewUpgradeKymaManager(store.Orchestrations(), store.Operations(), store.Instances(), executor, resolver, poolingInterval, nil, logrus.New())
+
+		// when
+		_, err = svc.Execute(id)
+		require.NoError(t, err)
+
+		// then
+		o, err := store.Orchestrations().GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Paused, o.State)
+
+		op, err := store.Operations().GetUpgradeKymaOperationByID("failing")
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Failed, string(op.State))
+
+		for _, opID := range []string{"second", "third"} {
+			op, err = store.Operations().GetUpgradeKymaOperationByID(opID)
+			require.NoError(t, err)
+			assert.Equal(t, orchestration.Pending, string(op.State))
+		}
+	})
+}
+
+// pausingExecutor fails the given operation and keeps the other ones pending
+type pausingExecutor struct {
+	failingExecutor
+	failing string
+}
+
+func (p *pausingExecutor) Execute(opID string) (time.Duration, error) {
+	if opID == p.failing {
+		return p.failingExecutor.Execute(opID)
+	}
+	return 10 * time.Millisecond, nil
+}
+
+type failingExecutor struct {
+	operations storage.Operations
+}
+
+func (f *failingExecutor) Execute(opID string) (time.Duration, error) {
+	op, err := f.operations.GetUpgradeKymaOperationByID(opID)
+	if err != nil {
+		return 0, err
+	}
+	op.State = orchestration.Failed
+	_, err = f.operations.UpdateUpgradeKymaOperation(*op)
+	return 0, err
 }
 
 type testExecutor struct{}
@@ -0,0 +1,25 @@
+package orchestration
+
+import (
+	"time"
+)
+
+// ResolveMaintenanceWindowTime resolves when is the next occurrence of the time window
+func ResolveMaintenanceWindowTime(beginTime, endTime time.Time) (time.Time, time.Time) {
+	n := time.Now()
+	start := time.Date(n.Year(), n.Month(), n.Day(), beginTime.Hour(), beginTime.Minute(), beginTime.Second(), beginTime.Nanosecond(), beginTime.Location())
+	end := time.Date(n.Year(), n.Month(), n.Day(), endTime.Hour(), endTime.Minute(), endTime.Second(), endTime.Nanosecond(), endTime.Location())
+
+	// if the window end slips through the next day, adjust the date accordingly
+	if end.Before(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+
+	// if time window has already passed we wait until next day
+	if start.Before(n) && end.Before(n) {
+		start = start.AddDate(0, 0, 1)
+		end = end.AddDate(0, 0, 1)
+	}
+
+	return start, end
+}
@@ -0,0 +1,345 @@
+package orchestration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration/strategies"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Manager processes orchestrations: it schedules the operations of the orchestration for the resolved runtimes,
+// executes them according to the strategy and resolves the state of the orchestration. The operations of the
+// orchestration type are handled by the given OrchestratedOperations.
+type Manager struct {
+	orchestrationStorage storage.Orchestrations
+	operationStorage     storage.Operations
+	instanceStorage      storage.Instances
+	operations           OrchestratedOperations
+	resolver             orchestration.RuntimeResolver
+	executor             process.Executor
+	log                  logrus.FieldLogger
+	pollingInterval      time.Duration
+}
+
+func NewManager(orchestrationStorage storage.Orchestrations, operationStorage storage.Operations, instanceStorage storage.Instances,
+	operations OrchestratedOperations, executor process.Executor, resolver orchestration.RuntimeResolver,
+	pollingInterval time.Duration, log logrus.FieldLogger) *Manager {
+	return &Manager{
+		orchestrationStorage: orchestrationStorage,
+		operationStorage:     operationStorage,
+		instanceStorage:      instanceStorage,
+		operations:           operations,
+		resolver:             resolver,
+		executor:             executor,
+		pollingInterval:      pollingInterval,
+		log:                  log,
+	}
+}
+
+// Execute reconciles runtimes for a given orchestration
+func (m *Manager) Execute(orchestrationID string) (time.Duration, error) {
+	logger := m.log.WithField("orchestrationID", orchestrationID)
+	m.log.Infof("Processing orchestration %s", orchestrationID)
+	o, err := m.orchestrationStorage.GetByID(orchestrationID)
+	if err != nil {
+		return m.failOrchestration(o, errors.Wrap(err, "while getting orchestration"))
+	}
+
+	// scheduled orchestration stays pending until its start time
+	if o.State == orchestration.Pending {
+		if until := o.Parameters.Strategy.StartsIn(time.Now()); until > 0 {
+			logger.Infof("Orchestration will be started in %v", until)
+			return until, nil
+		}
+	}
+
+	operations, err := m.resolveOperations(o, o.Parameters)
+	if err != nil {
+		return m.failOrchestration(o, errors.Wrap(err, "while resolving operations"))
+	}
+
+	err = m.orchestrationStorage.Update(*o)
+	if err != nil {
+		logger.Errorf("while updating orchestration: %v", err)
+		return m.pollingInterval, nil
+	}
+	// do not perform any action if the orchestration is finished
+	if o.IsFinished() {
+		m.log.Infof("Orchestration was already finished, state: %s", o.State)
+		return 0, nil
+	}
+
+	// failures from before the orchestration was resumed are not taken into account by the failure threshold
+	baseline, err := m.operationStorage.GetOperationStatsForOrchestration(o.OrchestrationID)
+	if err != nil {
+		logger.Errorf("while getting operation stats: %v", err)
+		return m.pollingInterval, nil
+	}
+
+	strategy := m.resolveStrategy(o.Parameters.Strategy.Type, logger)
+	execID, err := strategy.Execute(operations, o.Parameters.Strategy)
+	if err != nil {
+		return 0, errors.Wrap(err, "while executing upgrade strategy")
+	}
+
+	o, err = m.waitForCompletion(o, strategy, execID, baseline, logger)
+	if err != nil {
+		return 0, errors.Wrap(err, "while waiting for orchestration to finish")
+	}
+
+	o.UpdatedAt = time.Now()
+	err = m.orchestrationStorage.Update(*o)
+	if err != nil {
+		logger.Errorf("while updating orchestration: %v", err)
+		return m.pollingInterval, nil
+	}
+
+	logger.Infof("Finished processing orchestration, state: %s", o.State)
+	return 0, nil
+}
+
+// resolveOperations schedules the operations of the pending orchestration or returns the not finished operations
+// of the orchestration which is resumed, the operations in progress are returned first
+func (m *Manager) resolveOperations(o *internal.Orchestration, params orchestration.Parameters) ([]orchestration.RuntimeOperation, error) {
+	if o.State == orchestration.Pending {
+		return m.scheduleOperations(o, params)
+	}
+
+	// Resume processing of not finished upgrade operations after restart
+	var result []orchestration.RuntimeOperation
+	for _, state := range []string{orchestration.InProgress, orchestration.Pending} {
+		ops, err := m.operations.ListOperations(o.OrchestrationID, []string{state})
+		if err != nil {
+			return nil, err
+		}
+		for _, op := range ops {
+			result = append(result, op.RuntimeOperation)
+		}
+	}
+	m.log.Infof("Resuming %d operations for orchestration %s", len(result), o.OrchestrationID)
+	return result, nil
+}
+
+func (m *Manager) scheduleOperations(o *internal.Orchestration, params orchestration.Parameters) ([]orchestration.RuntimeOperation, error) {
+	runtimes, err := m.resolver.Resolve(params.Targets)
+	if err != nil {
+		return nil, errors.Wrap(err, "while resolving targets")
+	}
+
+	var result []orchestration.RuntimeOperation
+	for _, r := range runtimes {
+		// we set planID fetched from provisioning parameters
+		po, err := m.operationStorage.GetProvisioningOperationByInstanceID(r.InstanceID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while getting provisioning operation for instance id %s", r.InstanceID)
+		}
+		if po.ProvisioningParameters.PlanID == "" {
+			m.log.Infof("Operation %s does not have correct ProvisioningParameters", po.ID)
+			continue
+		}
+		windowBegin := time.Time{}
+		windowEnd := time.Time{}
+		if params.Strategy.Schedule == orchestration.MaintenanceWindow {
+			windowBegin, windowEnd = ResolveMaintenanceWindowTime(r.MaintenanceWindowBegin, r.MaintenanceWindowEnd)
+		}
+
+		inst, err := m.instanceStorage.GetByID(r.InstanceID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while getting instance %s", r.InstanceID)
+		}
+		id := uuid.New().String()
+		op := RuntimeOperation{
+			Operation: internal.Operation{
+				ID:                     id,
+				Version:                0,
+				CreatedAt:              time.Now(),
+				UpdatedAt:              time.Now(),
+				InstanceID:             r.InstanceID,
+				State:                  orchestration.Pending,
+				Description:            "Operation created",
+				OrchestrationID:        o.OrchestrationID,
+				ProvisioningParameters: po.ProvisioningParameters,
+				InstanceDetails:        inst.InstanceDetails,
+			},
+			RuntimeOperation: orchestration.RuntimeOperation{
+				ID: id,
+				Runtime: orchestration.Runtime{
+					ShootName:              r.ShootName,
+					MaintenanceWindowBegin: windowBegin,
+					MaintenanceWindowEnd:   windowEnd,
+					RuntimeID:              r.RuntimeID,
+					GlobalAccountID:        r.GlobalAccountID,
+					SubAccountID:           r.SubAccountID,
+				},
+				DryRun: params.DryRun,
+			},
+		}
+		result = append(result, op.RuntimeOperation)
+		err = m.operations.InsertOperation(op)
+		if err != nil {
+			m.log.Errorf("while inserting operation for runtime id %q", r.RuntimeID)
+		}
+	}
+
+	if len(runtimes) != 0 {
+		o.State = orchestration.InProgress
+	} else {
+		o.State = orchestration.Succeeded
+	}
+	o.Description = fmt.Sprintf("Scheduled %d operations", len(runtimes))
+	return result, nil
+}
+
+func (m *Manager) resolveStrategy(sType orchestration.StrategyType, log logrus.FieldLogger) orchestration.Strategy {
+	states := &operationStates{operations: m.operationStorage, orchestrated: m.operations}
+	switch sType {
+	case orchestration.ParallelStrategy:
+		return strategies.NewParallelOrchestrationStrategy(m.executor, states, log)
+	case orchestration.StagedStrategy:
+		return strategies.NewStagedOrchestrationStrategy(m.executor, states, log)
+	}
+	return nil
+}
+
+// waitForCompletion waits until processing of given orchestration ends or if it's canceled or paused.
+// The orchestration is paused when the failure threshold is exceeded.
+func (m *Manager) waitForCompletion(o *internal.Orchestration, strategy orchestration.Strategy, execID string, baseline map[string]int, log logrus.FieldLogger) (*internal.Orchestration, error) {
+	canceled := false
+	var err error
+	var stats map[string]int
+	err = wait.PollImmediateInfinite(m.pollingInterval, func() (bool, error) {
+		// check if orchestration wasn't canceled
+		o, err = m.orchestrationStorage.GetByID(o.OrchestrationID)
+		switch {
+		case err == nil:
+			if o.State == orchestration.Canceling {
+				log.Info("Orchestration was canceled")
+				canceled = true
+			}
+		case dberr.IsNotFound(err):
+			log.Errorf("while getting orchestration: %v", err)
+			return false, err
+		default:
+			log.Errorf("while getting orchestration: %v", err)
+			return false, nil
+		}
+		s, err := m.operationStorage.GetOperationStatsForOrchestration(o.OrchestrationID)
+		if err != nil {
+			log.Errorf("while getting operations: %v", err)
+			return false, nil
+		}
+		stats = s
+
+		if o.State == orchestration.InProgress && strategies.FailureThresholdExceeded(o.Parameters.Strategy, baseline, stats) {
+			log.Infof("Pausing orchestration, failure threshold exceeded")
+			o.State = orchestration.Paused
+			o.Description = fmt.Sprintf("Orchestration was paused, %d operations failed", stats[orchestration.Failed])
+			o.UpdatedAt = time.Now()
+			err = m.orchestrationStorage.Update(*o)
+			if err != nil {
+				log.Errorf("while updating orchestration: %v", err)
+				return false, nil
+			}
+		}
+
+		numberOfNotFinished := 0
+		numberOfInProgress, found := stats[orchestration.InProgress]
+		if found {
+			numberOfNotFinished += numberOfInProgress
+		}
+		numberOfPending, found := stats[orchestration.Pending]
+		if found {
+			numberOfNotFinished += numberOfPending
+		}
+
+		// don't wait for pending operations if orchestration was canceled or paused
+		if canceled || o.State == orchestration.Paused {
+			return numberOfInProgress == 0, nil
+		} else {
+			return numberOfNotFinished == 0, nil
+		}
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "while waiting for scheduled operations to finish")
+	}
+
+	return m.resolveOrchestration(o, strategy, execID, stats)
+}
+
+func (m *Manager) resolveOrchestration(o *internal.Orchestration, strategy orchestration.Strategy, execID string, stats map[string]int) (*internal.Orchestration, error) {
+	if o.State == orchestration.Canceling {
+		err := m.resolveCanceledOperations(o)
+		if err != nil {
+			return nil, errors.Wrap(err, "while resolving canceled operations")
+		}
+		strategy.Cancel(execID)
+		o.State = orchestration.Canceled
+	} else if o.State == orchestration.Paused {
+		// pending operations stay pending until the orchestration is resumed
+		strategy.Cancel(execID)
+	} else {
+		state := orchestration.Succeeded
+		if stats[orchestration.Failed] > 0 {
+			state = orchestration.Failed
+		}
+		o.State = state
+	}
+	return o, nil
+}
+
+func (m *Manager) resolveCanceledOperations(o *internal.Orchestration) error {
+	ops, err := m.operations.ListOperations(o.OrchestrationID, []string{orchestration.Pending})
+	if err != nil {
+		return errors.Wrap(err, "while listing upgrade operations")
+	}
+	for _, op := range ops {
+		err := m.operations.CancelOperation(op.Operation.ID, "Operation was canceled")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Manager) failOrchestration(o *internal.Orchestration, err error) (time.Duration, error) {
+	m.log.Errorf("orchestration %s failed: %s", o.OrchestrationID, err)
+	return m.updateOrchestration(o, orchestration.Failed, err.Error()), nil
+}
+
+func (m *Manager) updateOrchestration(o *internal.Orchestration, state, description string) time.Duration {
+	o.UpdatedAt = time.Now()
+	o.State = state
+	o.Description = description
+	err := m.orchestrationStorage.Update(*o)
+	if err != nil {
+		if !dberr.IsNotFound(err) {
+			m.log.Errorf("while updating orchestration: %v", err)
+			return time.Minute
+		}
+	}
+	return 0
+}
+
+// operationStates exposes the states of the orchestrated operations to the orchestration strategies
+type operationStates struct {
+	operations   storage.Operations
+	orchestrated OrchestratedOperations
+}
+
+func (s *operationStates) State(operationID string) (string, error) {
+	op, err := s.operations.GetOperationByID(operationID)
+	if err != nil {
+		return "", errors.Wrapf(err, "while getting operation %s", operationID)
+	}
+	return string(op.State), nil
+}
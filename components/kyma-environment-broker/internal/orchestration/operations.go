@@ -0,0 +1,162 @@
+package orchestration
+
+import (
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+
+	"github.com/pkg/errors"
+)
+
+// RuntimeOperation is the operation of the orchestration executed for one runtime, regardless of the orchestration type
+type RuntimeOperation struct {
+	Operation        internal.Operation
+	RuntimeOperation orchestration.RuntimeOperation
+}
+
+// OrchestratedOperations stores the operations of one orchestration type, so the orchestration manager
+// and the retry of orchestrations process the upgrade kyma and upgrade cluster operations in the same way
+type OrchestratedOperations interface {
+	// InsertOperation stores the new operation of the orchestration
+	InsertOperation(op RuntimeOperation) error
+	// InsertOrchestration stores the new orchestration together with its operations in one transaction
+	InsertOrchestration(o internal.Orchestration, ops []RuntimeOperation) error
+	// ListOperations returns the operations of the orchestration in the given states
+	ListOperations(orchestrationID string, states []string) ([]RuntimeOperation, error)
+	// CancelOperation cancels the operation if it is still pending
+	CancelOperation(operationID, description string) error
+}
+
+// OperationsForType returns the operations of the given orchestration type
+func OperationsForType(orchestrationType orchestration.Type, orchestrations storage.Orchestrations, operations storage.Operations) OrchestratedOperations {
+	if orchestrationType == orchestration.UpgradeClusterOrchestration {
+		return NewUpgradeClusterOperations(orchestrations, operations)
+	}
+	return NewUpgradeKymaOperations(orchestrations, operations, nil)
+}
+
+type upgradeKymaOperations struct {
+	orchestrations storage.Orchestrations
+	operations     storage.Operations
+	smcf           internal.SMClientFactory
+}
+
+// NewUpgradeKymaOperations returns the upgrade kyma operations, the given client factory is set in the created operations
+func NewUpgradeKymaOperations(orchestrations storage.Orchestrations, operations storage.Operations, smcf internal.SMClientFactory) OrchestratedOperations {
+	return &upgradeKymaOperations{
+		orchestrations: orchestrations,
+		operations:     operations,
+		smcf:           smcf,
+	}
+}
+
+func (u *upgradeKymaOperations) InsertOperation(op RuntimeOperation) error {
+	return u.operations.InsertUpgradeKymaOperation(u.toUpgradeKymaOperation(op))
+}
+
+func (u *upgradeKymaOperations) InsertOrchestration(o internal.Orchestration, ops []RuntimeOperation) error {
+	operations := make([]internal.UpgradeKymaOperation, 0, len(ops))
+	for _, op := range ops {
+		operations = append(operations, u.toUpgradeKymaOperation(op))
+	}
+	return u.orchestrations.InsertWithUpgradeKymaOperations(o, operations)
+}
+
+func (u *upgradeKymaOperations) toUpgradeKymaOperation(op RuntimeOperation) internal.UpgradeKymaOperation {
+	return internal.UpgradeKymaOperation{
+		Operation:        op.Operation,
+		RuntimeOperation: op.RuntimeOperation,
+		SMClientFactory:  u.smcf,
+	}
+}
+
+func (u *upgradeKymaOperations) ListOperations(orchestrationID string, states []string) ([]RuntimeOperation, error) {
+	ops, _, _, err := u.operations.ListUpgradeKymaOperationsByOrchestrationID(orchestrationID, dbmodel.OperationFilter{States: states})
+	if err != nil {
+		return nil, errors.Wrap(err, "while listing upgrade kyma operations")
+	}
+	result := make([]RuntimeOperation, 0, len(ops))
+	for _, op := range ops {
+		result = append(result, RuntimeOperation{Operation: op.Operation, RuntimeOperation: op.RuntimeOperation})
+	}
+	return result, nil
+}
+
+func (u *upgradeKymaOperations) CancelOperation(operationID, description string) error {
+	op, err := u.operations.GetUpgradeKymaOperationByID(operationID)
+	if err != nil {
+		return errors.Wrapf(err, "while getting upgrade kyma operation %s", operationID)
+	}
+	if op.State != orchestration.Pending {
+		return nil
+	}
+	op.State = orchestration.Canceled
+	op.Description = description
+	_, err = u.operations.UpdateUpgradeKymaOperation(*op)
+	if err != nil {
+		return errors.Wrap(err, "while updating upgrade kyma operation")
+	}
+	return nil
+}
+
+type upgradeClusterOperations struct {
+	orchestrations storage.Orchestrations
+	operations     storage.Operations
+}
+
+// NewUpgradeClusterOperations returns the upgrade cluster operations
+func NewUpgradeClusterOperations(orchestrations storage.Orchestrations, operations storage.Operations) OrchestratedOperations {
+	return &upgradeClusterOperations{
+		orchestrations: orchestrations,
+		operations:     operations,
+	}
+}
+
+func (u *upgradeClusterOperations) InsertOperation(op RuntimeOperation) error {
+	return u.operations.InsertUpgradeClusterOperation(toUpgradeClusterOperation(op))
+}
+
+func (u *upgradeClusterOperations) InsertOrchestration(o internal.Orchestration, ops []RuntimeOperation) error {
+	operations := make([]internal.UpgradeClusterOperation, 0, len(ops))
+	for _, op := range ops {
+		operations = append(operations, toUpgradeClusterOperation(op))
+	}
+	return u.orchestrations.InsertWithUpgradeClusterOperations(o, operations)
+}
+
+func toUpgradeClusterOperation(op RuntimeOperation) internal.UpgradeClusterOperation {
+	return internal.UpgradeClusterOperation{
+		Operation:        op.Operation,
+		RuntimeOperation: op.RuntimeOperation,
+	}
+}
+
+func (u *upgradeClusterOperations) ListOperations(orchestrationID string, states []string) ([]RuntimeOperation, error) {
+	ops, _, _, err := u.operations.ListUpgradeClusterOperationsByOrchestrationID(orchestrationID, dbmodel.OperationFilter{States: states})
+	if err != nil {
+		return nil, errors.Wrap(err, "while listing upgrade cluster operations")
+	}
+	result := make([]RuntimeOperation, 0, len(ops))
+	for _, op := range ops {
+		result = append(result, RuntimeOperation{Operation: op.Operation, RuntimeOperation: op.RuntimeOperation})
+	}
+	return result, nil
+}
+
+func (u *upgradeClusterOperations) CancelOperation(operationID, description string) error {
+	op, err := u.operations.GetUpgradeClusterOperationByID(operationID)
+	if err != nil {
+		return errors.Wrapf(err, "while getting upgrade cluster operation %s", operationID)
+	}
+	if op.State != orchestration.Pending {
+		return nil
+	}
+	op.State = orchestration.Canceled
+	op.Description = description
+	_, err = u.operations.UpdateUpgradeClusterOperation(*op)
+	if err != nil {
+		return errors.Wrap(err, "while updating upgrade cluster operation")
+	}
+	return nil
+}
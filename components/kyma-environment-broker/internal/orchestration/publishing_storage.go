@@ -0,0 +1,50 @@
+package orchestration
+
+import (
+	"context"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/pkg/errors"
+)
+
+// StateChanged is published when the orchestration is stored with the state different than the previous one
+type StateChanged struct {
+	OldState      string
+	Orchestration internal.Orchestration
+}
+
+// PublishingStorage publishes StateChanged events for the orchestrations updated by the orchestration managers
+type PublishingStorage struct {
+	storage.Orchestrations
+	pub event.Publisher
+}
+
+func NewPublishingStorage(orchestrations storage.Orchestrations, pub event.Publisher) *PublishingStorage {
+	return &PublishingStorage{
+		Orchestrations: orchestrations,
+		pub:            pub,
+	}
+}
+
+func (s *PublishingStorage) Update(orchestration internal.Orchestration) error {
+	old, err := s.Orchestrations.GetByID(orchestration.OrchestrationID)
+	if err != nil {
+		return errors.Wrapf(err, "while getting orchestration %s", orchestration.OrchestrationID)
+	}
+
+	err = s.Orchestrations.Update(orchestration)
+	if err != nil {
+		return err
+	}
+
+	if old.State != orchestration.State {
+		s.pub.Publish(context.TODO(), StateChanged{
+			OldState:      old.State,
+			Orchestration: orchestration,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishingStorage_Update(t *testing.T) {
+	// given
+	db := storage.NewMemoryStorage()
+	o := fixture.FixOrchestration()
+	o.OrchestrationID = "orchestration-id"
+	o.State = orchestration.Pending
+	require.NoError(t, db.Orchestrations().Insert(o))
+
+	pub := &publisherMock{}
+	svc := NewPublishingStorage(db.Orchestrations(), pub)
+
+	// when
+	o.State = orchestration.InProgress
+	require.NoError(t, svc.Update(o))
+	o.Description = "processing"
+	require.NoError(t, svc.Update(o))
+
+	// then
+	require.Len(t, pub.events, 1)
+	assert.Equal(t, orchestration.Pending, pub.events[0].OldState)
+	assert.Equal(t, orchestration.InProgress, pub.events[0].Orchestration.State)
+
+	stored, err := db.Orchestrations().GetByID(o.OrchestrationID)
+	require.NoError(t, err)
+	assert.Equal(t, "processing", stored.Description)
+}
+
+type publisherMock struct {
+	events []StateChanged
+}
+
+func (p *publisherMock) Publish(_ context.Context, ev interface{}) {
+	p.events = append(p.events, ev.(StateChanged))
+}
@@ -59,11 +59,19 @@ func (s *InitialisationStep) Name() string {
 	return "Deprovision_Initialization"
 }
 
+// Repeatable returns true, the initialisation step checks the operation state and prepares data
+// which is not stored in the storage, so it must be executed each time the operation is processed
+func (s *InitialisationStep) Repeatable() bool {
+	return true
+}
+
 func (s *InitialisationStep) Run(operation internal.DeprovisioningOperation, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
 	op, when, err := s.run(operation, log)
 
 	if op.State == domain.Succeeded {
-		if op.Temporary {
+		if op.Temporary && op.Hibernation {
+			log.Info("Runtime hibernated, keeping RuntimeID in the instance")
+		} else if op.Temporary {
 			log.Info("Removing RuntimeID from the instance")
 			err := s.removeRuntimeID(operation.InstanceID)
 			if err != nil {
@@ -139,6 +147,9 @@ func (s *InitialisationStep) run(operation internal.DeprovisioningOperation, log
 			}
 		}
 
+		if operation.Hibernation && operation.ProvisionerOperationID == "" {
+			return s.checkHibernationPossible(operation, instance, log)
+		}
 		if operation.ProvisionerOperationID == "" {
 			return operation, 0, nil
 		}
@@ -163,6 +174,31 @@ func setAvsIds(deprovisioningOperation *internal.DeprovisioningOperation, provis
 	}
 }
 
+// checkHibernationPossible verifies if the runtime can be hibernated, if not - the runtime is deprovisioned
+func (s *InitialisationStep) checkHibernationPossible(operation internal.DeprovisioningOperation, instance *internal.Instance, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
+	possible := false
+	if instance.RuntimeID != "" {
+		status, err := s.provisionerClient.RuntimeStatus(instance.GlobalAccountID, instance.RuntimeID)
+		if err != nil {
+			log.Errorf("unable to get runtime status: %s", err)
+			return operation, 10 * time.Second, nil
+		}
+		possible = status.HibernationStatus != nil && status.HibernationStatus.HibernationPossible != nil && *status.HibernationStatus.HibernationPossible
+	}
+	if possible {
+		return operation, 0, nil
+	}
+
+	log.Info("runtime hibernation is not possible, the runtime will be deprovisioned")
+	operation.Hibernation = false
+	operation, repeat, _ := s.operationManager.UpdateOperation(operation)
+	if repeat != 0 {
+		log.Errorf("cannot save the operation")
+		return operation, time.Second, nil
+	}
+	return operation, 0, nil
+}
+
 func (s *InitialisationStep) checkRuntimeStatus(operation internal.DeprovisioningOperation, instance *internal.Instance, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
 	if time.Since(operation.UpdatedAt) > CheckStatusTimeout {
 		log.Infof("operation has reached the time limit: updated operation time: %s", operation.UpdatedAt)
@@ -116,6 +116,125 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.Equal(t, operation, *storedOp)
 	})
 
+	t.Run("Should keep hibernation when the runtime can be hibernated", func(t *testing.T) {
+		// given
+		log := logrus.New()
+		memoryStorage := storage.NewMemoryStorage()
+
+		operation := fixSuspensionOperation()
+		err := memoryStorage.Operations().InsertDeprovisioningOperation(operation)
+		assert.NoError(t, err)
+
+		err = memoryStorage.Operations().InsertProvisioningOperation(fixProvisioningOperation())
+		assert.NoError(t, err)
+
+		err = memoryStorage.Instances().Insert(fixInstanceRuntimeStatus())
+		assert.NoError(t, err)
+
+		provisionerClient := &provisionerAutomock.Client{}
+		provisionerClient.On("RuntimeStatus", fixGlobalAccountID, fixRuntimeID).Return(fixRuntimeStatusWithHibernation(true), nil)
+
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Instances(), provisionerClient, accountProviderMock, nil, time.Hour)
+
+		// when
+		operation, repeat, err := step.Run(operation, log)
+
+		// then
+		assert.NoError(t, err)
+		assert.Zero(t, repeat)
+		assert.True(t, operation.Hibernation)
+		provisionerClient.AssertExpectations(t)
+	})
+
+	t.Run("Should fall back to deprovisioning when the runtime cannot be hibernated", func(t *testing.T) {
+		// given
+		log := logrus.New()
+		memoryStorage := storage.NewMemoryStorage()
+
+		operation := fixSuspensionOperation()
+		err := memoryStorage.Operations().InsertDeprovisioningOperation(operation)
+		assert.NoError(t, err)
+
+		err = memoryStorage.Operations().InsertProvisioningOperation(fixProvisioningOperation())
+		assert.NoError(t, err)
+
+		err = memoryStorage.Instances().Insert(fixInstanceRuntimeStatus())
+		assert.NoError(t, err)
+
+		provisionerClient := &provisionerAutomock.Client{}
+		provisionerClient.On("RuntimeStatus", fixGlobalAccountID, fixRuntimeID).Return(fixRuntimeStatusWithHibernation(false), nil)
+
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Instances(), provisionerClient, accountProviderMock, nil, time.Hour)
+
+		// when
+		operation, repeat, err := step.Run(operation, log)
+
+		// then
+		assert.NoError(t, err)
+		assert.Zero(t, repeat)
+		assert.False(t, operation.Hibernation)
+
+		storedOp, err := memoryStorage.Operations().GetDeprovisioningOperationByID(operation.ID)
+		assert.NoError(t, err)
+		assert.False(t, storedOp.Hibernation)
+	})
+
+	t.Run("Should keep RuntimeID when the runtime was hibernated", func(t *testing.T) {
+		// given
+		log := logrus.New()
+		memoryStorage := storage.NewMemoryStorage()
+
+		operation := fixSuspensionOperation()
+		operation.ProvisionerOperationID = fixProvisionerOperationID
+		err := memoryStorage.Operations().InsertDeprovisioningOperation(operation)
+		assert.NoError(t, err)
+
+		err = memoryStorage.Operations().InsertProvisioningOperation(fixProvisioningOperation())
+		assert.NoError(t, err)
+
+		instance := fixInstanceRuntimeStatus()
+		instance.ServicePlanID = broker.TrialPlanID
+		err = memoryStorage.Instances().Insert(instance)
+		assert.NoError(t, err)
+
+		provisionerClient := &provisionerAutomock.Client{}
+		provisionerClient.On("RuntimeOperationStatus", fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
+			ID:        ptr.String(fixProvisionerOperationID),
+			Operation: gqlschema.OperationTypeHibernate,
+			State:     gqlschema.OperationStateSucceeded,
+		}, nil)
+
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Instances(), provisionerClient, accountProviderMock, nil, time.Hour)
+
+		// when
+		operation, repeat, err := step.Run(operation, log)
+
+		// then
+		assert.NoError(t, err)
+		assert.Zero(t, repeat)
+		assert.Equal(t, domain.Succeeded, operation.State)
+
+		inst, err := memoryStorage.Instances().GetByID(operation.InstanceID)
+		assert.NoError(t, err)
+		assert.Equal(t, fixRuntimeID, inst.RuntimeID)
+	})
+}
+
+func fixSuspensionOperation() internal.DeprovisioningOperation {
+	operation := fixDeprovisioningOperation()
+	operation.ProvisionerOperationID = ""
+	operation.Temporary = true
+	operation.Hibernation = true
+	return operation
+}
+
+func fixRuntimeStatusWithHibernation(possible bool) gqlschema.RuntimeStatus {
+	return gqlschema.RuntimeStatus{
+		HibernationStatus: &gqlschema.HibernationStatus{
+			Hibernated:          ptr.Bool(false),
+			HibernationPossible: ptr.Bool(possible),
+		},
+	}
 }
 
 func fixDeprovisioningOperation() internal.DeprovisioningOperation {
@@ -2,6 +2,7 @@ package deprovisioning
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"time"
 
@@ -23,7 +24,9 @@ type Step interface {
 type Manager struct {
 	log              logrus.FieldLogger
 	steps            map[int][]Step
+	policies         map[string]process.StepPolicy
 	operationStorage storage.Operations
+	operationManager *process.DeprovisionOperationManager
 
 	publisher event.Publisher
 }
@@ -33,6 +36,8 @@ func NewManager(storage storage.Operations, pub event.Publisher, logger logrus.F
 		log:              logger,
 		operationStorage: storage,
 		steps:            make(map[int][]Step, 0),
+		policies:         make(map[string]process.StepPolicy),
+		operationManager: process.NewDeprovisionOperationManager(storage),
 		publisher:        pub,
 	}
 }
@@ -48,19 +53,137 @@ func (m *Manager) AddStep(weight int, step Step) {
 	m.steps[weight] = append(m.steps[weight], step)
 }
 
+// AddStepWithPolicy adds the step which is executed according to the given policy
+func (m *Manager) AddStepWithPolicy(weight int, step Step, policy process.StepPolicy) {
+	m.AddStep(weight, step)
+	m.policies[step.Name()] = policy
+}
+
+// AddPipelineStep adds the step of the pipeline, it fails if the step is not a deprovisioning step
+func (m *Manager) AddPipelineStep(weight int, step process.NamedStep, policy process.StepPolicy) error {
+	processStep, ok := step.(Step)
+	if !ok {
+		return fmt.Errorf("step %s is not a deprovisioning step", step.Name())
+	}
+	m.AddStepWithPolicy(weight, processStep, policy)
+	return nil
+}
+
+// runStep executes the step according to its policy. Attempts of steps with a policy are recorded in the operation,
+// a step which exceeded its time limit or retry budget is given up.
 func (m *Manager) runStep(step Step, operation internal.DeprovisioningOperation, logger logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
+	policy := m.policies[step.Name()]
+	if planID := operation.ProvisioningParameters.PlanID; !policy.EnabledForPlan(planID) {
+		logger.Infof("Skipping step %s, it is not enabled for the plan %s", step.Name(), planID)
+		return operation, 0, nil
+	}
+	if policy.IsZero() {
+		processedOperation, when, err := m.runStepOnce(step, operation, logger)
+		if process.IsStepFailedError(err) {
+			return m.giveUpStep(step, operation, processedOperation, policy, operation.StepAttempt(step.Name()), err.Error(), err, logger)
+		}
+		return processedOperation, when, err
+	}
+
+	now := time.Now()
+	attempt := operation.StepAttempt(step.Name())
+	if attempt.StartedAt.IsZero() {
+		attempt.StartedAt = now
+	}
+	if reason := policy.Exhausted(attempt, now); reason != "" {
+		logger.Errorf("Step %s, it was started at %s", reason, attempt.StartedAt)
+		return m.giveUpStep(step, operation, operation, policy, attempt, fmt.Sprintf("step %s %s", step.Name(), reason), nil, logger)
+	}
+	if wait := attempt.NextAttemptAt.Sub(now); wait > 0 {
+		logger.Infof("Step is backing off, next attempt in %s", wait)
+		return operation, wait, nil
+	}
+	attempt.Count++
+	attempt.LastAttemptAt = now
+	attempt.NextAttemptAt = time.Time{}
+	operation.SetStepAttempt(step.Name(), attempt)
+
+	processedOperation, when, err := m.runStepOnce(step, operation, logger)
+	switch {
+	case process.IsStepFailedError(err):
+		attempt.LastError = err.Error()
+		return m.giveUpStep(step, operation, processedOperation, policy, attempt, err.Error(), err, logger)
+	case err != nil && processedOperation.State != domain.Failed && policy.MaxAttempts > 0:
+		attempt.LastError = err.Error()
+		if reason := policy.Exhausted(attempt, time.Now()); reason != "" {
+			return m.giveUpStep(step, operation, processedOperation, policy, attempt, fmt.Sprintf("step %s %s: %s", step.Name(), reason, err), err, logger)
+		}
+		logger.Warnf("Step failed, it will be retried (attempt %d of %d): %s", attempt.Count, policy.MaxAttempts, err)
+		when = time.Second
+	case err != nil:
+		return m.giveUpStep(step, operation, processedOperation, policy, attempt, fmt.Sprintf("step %s failed: %s", step.Name(), err), err, logger)
+	case processedOperation.State == domain.Failed:
+		return m.giveUpStep(step, operation, processedOperation, policy, attempt, processedOperation.Description, nil, logger)
+	case when == 0 || !(processedOperation.State == domain.InProgress || processedOperation.State == orchestration.Pending):
+		processedOperation.SetStepAttempt(step.Name(), attempt)
+		return processedOperation, when, nil
+	}
+
+	if delay := policy.Delay(attempt.Count); delay > when {
+		when = delay
+	}
+	if policy.Backoff > 0 {
+		attempt.NextAttemptAt = now.Add(when)
+	}
+	processedOperation.SetStepAttempt(step.Name(), attempt)
+	processedOperation, retry, err := m.operationManager.UpdateOperation(processedOperation)
+	if err != nil || retry > 0 {
+		return processedOperation, retry, err
+	}
+	return processedOperation, when, nil
+}
+
+// giveUpStep fails the operation if the step is mandatory. The optional step is skipped: the state of the operation
+// from before the step execution is restored and the processing continues with the next step. The outcome is decided
+// before the operation is stored, unless the step failed the operation itself instead of returning StepFailedError.
+func (m *Manager) giveUpStep(step Step, operation, processedOperation internal.DeprovisioningOperation, policy process.StepPolicy, attempt internal.StepAttempt, reason string, stepErr error, logger logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
+	if processedOperation.Operation.ID == "" {
+		// the step failed without returning the operation
+		processedOperation = operation
+	}
+	if attempt.LastError == "" {
+		attempt.LastError = reason
+	}
+	if !policy.Optional {
+		processedOperation.SetStepAttempt(step.Name(), attempt)
+		if processedOperation.State == domain.Failed {
+			return processedOperation, 0, stepErr
+		}
+		failedOperation, when, err := m.operationManager.OperationFailed(processedOperation, reason)
+		if stepErr != nil && when == 0 {
+			err = stepErr
+		}
+		return failedOperation, when, err
+	}
+
+	logger.Warnf("Skipping optional step: %s", reason)
+	attempt.Skipped = true
+	processedOperation.State = operation.State
+	processedOperation.Description = operation.Description
+	processedOperation.SetStepAttempt(step.Name(), attempt)
+	return m.operationManager.UpdateOperation(processedOperation)
+}
+
+func (m *Manager) runStepOnce(step Step, operation internal.DeprovisioningOperation, logger logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
 	start := time.Now()
 	processedOperation, when, err := step.Run(operation, logger)
-	m.publisher.Publish(context.TODO(), process.DeprovisioningStepProcessed{
-		StepProcessed: process.StepProcessed{
-			StepName: step.Name(),
-			Duration: time.Since(start),
-			When:     when,
-			Error:    err,
-		},
-		OldOperation: operation,
-		Operation:    processedOperation,
-	})
+	ev := process.DeprovisioningStepProcessed{
+		StepProcessed: process.NewStepProcessed(operation.ID, step.Name(), start, when, err),
+		OldOperation:  operation,
+		Operation:     processedOperation,
+	}
+	if processedOperation.ID == "" {
+		m.publisher.Publish(context.TODO(), ev)
+		return processedOperation, when, err
+	}
+	// the event is stored in one transaction with the next update of the operation, so it is not lost when KEB stops,
+	// see storePendingEvents
+	processedOperation.AddPendingEvents(event.Stage(m.publisher, ev))
 	return processedOperation, when, err
 }
 
@@ -79,6 +202,8 @@ func (m *Manager) Execute(operationID string) (time.Duration, error) {
 	}
 
 	logOperation := m.log.WithFields(logrus.Fields{"operation": operationID, "instanceID": operation.InstanceID, "planID": provisioningOp.ProvisioningParameters.PlanID})
+	// the events of the steps which were not stored with the updates of the operation made by the steps
+	defer func() { m.storePendingEvents(operation, logOperation) }()
 
 	var when time.Duration
 	logOperation.Info("Start process operation steps")
@@ -86,6 +211,10 @@ func (m *Manager) Execute(operationID string) (time.Duration, error) {
 		steps := m.steps[weightStep]
 		for _, step := range steps {
 			logStep := logOperation.WithField("step", step.Name())
+			if operation.IsStepFinished(step.Name()) && !process.IsStepRepeatable(step) {
+				logStep.Info("Step already finished, skipping")
+				continue
+			}
 			logStep.Infof("Start step")
 
 			operation, when, err = m.runStep(step, operation, logStep)
@@ -103,6 +232,10 @@ func (m *Manager) Execute(operationID string) (time.Duration, error) {
 			}
 			if when == 0 {
 				logStep.Info("Process operation successful")
+				operation, when = m.finishStep(step, operation, logStep)
+				if when != 0 {
+					return when, nil
+				}
 				continue
 			}
 
@@ -115,6 +248,39 @@ func (m *Manager) Execute(operationID string) (time.Duration, error) {
 	return 0, nil
 }
 
+// storePendingEvents stores the events of the processed steps which were not stored with the updates of the operation,
+// the operation is updated only if there are such events
+func (m *Manager) storePendingEvents(operation internal.DeprovisioningOperation, logger logrus.FieldLogger) internal.DeprovisioningOperation {
+	err := event.StorePending(m.publisher, operation.PendingEvents(), func() error {
+		updated, err := m.operationStorage.UpdateDeprovisioningOperation(operation)
+		if err != nil {
+			return err
+		}
+		operation = *updated
+		return nil
+	})
+	if err != nil {
+		logger.Warnf("unable to store the events of the steps with the operation, the events were stored separately: %s", err)
+		operation.TakePendingEvents()
+	}
+	return operation
+}
+
+// finishStep stores the information that the step was successfully processed,
+// so the step is not executed again when the operation is retried
+func (m *Manager) finishStep(step Step, operation internal.DeprovisioningOperation, logger logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration) {
+	if process.IsStepRepeatable(step) {
+		return operation, 0
+	}
+	operation.FinishStep(step.Name())
+	updated, err := m.operationStorage.UpdateDeprovisioningOperation(operation)
+	if err != nil {
+		logger.Errorf("Cannot save finished step: %s", err)
+		return operation, time.Second
+	}
+	return *updated, 0
+}
+
 func (m *Manager) sortWeight() []int {
 	var weight []int
 	for w := range m.steps {
@@ -0,0 +1,80 @@
+package deprovisioning
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// the time after which the bindings are removed without revoking their credentials
+	RemoveBindingsTimeout = 10 * time.Minute
+)
+
+//go:generate mockery -name=BindingsRemover -output=automock -outpkg=automock -case=underscore
+type BindingsRemover interface {
+	RemoveBindings(instance *internal.Instance) error
+}
+
+type RemoveBindingsStep struct {
+	instanceStorage storage.Instances
+	bindingStorage  storage.Bindings
+	remover         BindingsRemover
+}
+
+func NewRemoveBindingsStep(is storage.Instances, bs storage.Bindings, remover BindingsRemover) *RemoveBindingsStep {
+	return &RemoveBindingsStep{
+		instanceStorage: is,
+		bindingStorage:  bs,
+		remover:         remover,
+	}
+}
+
+func (s *RemoveBindingsStep) Name() string {
+	return "Remove_Bindings"
+}
+
+func (s *RemoveBindingsStep) Run(operation internal.DeprovisioningOperation, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
+	instance, err := s.instanceStorage.GetByID(operation.InstanceID)
+	switch {
+	case err == nil:
+	case dberr.IsNotFound(err):
+		log.Info("instance already deprovisioned, removing bindings")
+		return s.deleteBindings(operation, log)
+	default:
+		log.Errorf("unable to get instance from storage: %s", err)
+		return operation, 1 * time.Second, nil
+	}
+
+	err = s.remover.RemoveBindings(instance)
+	if err == nil {
+		return operation, 0, nil
+	}
+	if time.Since(operation.UpdatedAt) < RemoveBindingsTimeout {
+		log.Errorf("unable to remove bindings: %s. Retry...", err)
+		return operation, 10 * time.Second, nil
+	}
+
+	// the credentials are removed together with the runtime
+	log.Errorf("Step %s failed, credentials of the bindings have not been revoked: %s", s.Name(), err)
+	return s.deleteBindings(operation, log)
+}
+
+func (s *RemoveBindingsStep) deleteBindings(operation internal.DeprovisioningOperation, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
+	bindings, err := s.bindingStorage.ListByInstanceID(operation.InstanceID)
+	if err != nil {
+		log.Errorf("unable to list bindings: %s", err)
+		return operation, 1 * time.Second, nil
+	}
+	for _, binding := range bindings {
+		if err := s.bindingStorage.Delete(binding.BindingID); err != nil {
+			log.Errorf("unable to delete binding %s: %s", binding.BindingID, err)
+			return operation, 1 * time.Second, nil
+		}
+	}
+	return operation, 0, nil
+}
@@ -0,0 +1,89 @@
+package deprovisioning
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/deprovisioning/automock"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveBindingsStep_Run(t *testing.T) {
+	t.Run("Should remove bindings", func(t *testing.T) {
+		// given
+		memoryStorage := fixRemoveBindingsStorage(t)
+		remover := &automock.BindingsRemover{}
+		remover.On("RemoveBindings", mock.AnythingOfType("*internal.Instance")).Return(nil).Once()
+
+		step := NewRemoveBindingsStep(memoryStorage.Instances(), memoryStorage.Bindings(), remover)
+
+		// when
+		_, repeat, err := step.Run(fixOperationRemoveRuntime(), logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, repeat)
+		remover.AssertExpectations(t)
+	})
+
+	t.Run("Should retry when bindings cannot be removed", func(t *testing.T) {
+		// given
+		memoryStorage := fixRemoveBindingsStorage(t)
+		remover := &automock.BindingsRemover{}
+		remover.On("RemoveBindings", mock.AnythingOfType("*internal.Instance")).Return(errors.New("runtime unreachable"))
+
+		step := NewRemoveBindingsStep(memoryStorage.Instances(), memoryStorage.Bindings(), remover)
+
+		// when
+		_, repeat, err := step.Run(fixOperationRemoveRuntime(), logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.NotZero(t, repeat)
+		bindings, err := memoryStorage.Bindings().ListByInstanceID(fixInstanceID)
+		require.NoError(t, err)
+		assert.Len(t, bindings, 1)
+	})
+
+	t.Run("Should delete bindings after timeout", func(t *testing.T) {
+		// given
+		memoryStorage := fixRemoveBindingsStorage(t)
+		remover := &automock.BindingsRemover{}
+		remover.On("RemoveBindings", mock.AnythingOfType("*internal.Instance")).Return(errors.New("runtime unreachable"))
+
+		step := NewRemoveBindingsStep(memoryStorage.Instances(), memoryStorage.Bindings(), remover)
+		operation := fixOperationRemoveRuntime()
+		operation.UpdatedAt = time.Now().Add(-RemoveBindingsTimeout)
+
+		// when
+		_, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, repeat)
+		bindings, err := memoryStorage.Bindings().ListByInstanceID(fixInstanceID)
+		require.NoError(t, err)
+		assert.Empty(t, bindings)
+	})
+}
+
+func fixRemoveBindingsStorage(t *testing.T) storage.BrokerStorage {
+	memoryStorage := storage.NewMemoryStorage()
+	err := memoryStorage.Instances().Insert(fixInstanceRuntimeStatus())
+	require.NoError(t, err)
+	err = memoryStorage.Bindings().Insert(internal.Binding{
+		BindingID:  "binding-id",
+		InstanceID: fixInstanceID,
+		Operation:  internal.BindingOperationBind,
+		State:      domain.Succeeded,
+	})
+	require.NoError(t, err)
+	return memoryStorage
+}
@@ -62,6 +62,10 @@ func (s *RemoveRuntimeStep) Run(operation internal.DeprovisioningOperation, log
 	}
 	log = log.WithField("runtimeID", instance.RuntimeID)
 
+	if operation.Hibernation {
+		return s.hibernateRuntime(operation, instance, log)
+	}
+
 	var provisionerResponse string
 	if operation.ProvisionerOperationID == "" {
 
@@ -84,3 +88,29 @@ func (s *RemoveRuntimeStep) Run(operation internal.DeprovisioningOperation, log
 	// return repeat mode (1 sec) to start the initialization step which will now check the runtime status
 	return operation, 1 * time.Second, nil
 }
+
+func (s *RemoveRuntimeStep) hibernateRuntime(operation internal.DeprovisioningOperation, instance *internal.Instance, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
+	if operation.ProvisionerOperationID == "" {
+		provisionerResponse, err := s.provisionerClient.HibernateRuntime(instance.GlobalAccountID, instance.RuntimeID)
+		if err != nil {
+			log.Errorf("unable to hibernate runtime: %s", err)
+			return operation, 10 * time.Second, nil
+		}
+		if provisionerResponse.ID == nil {
+			log.Errorf("provisioner returned empty operation ID")
+			return operation, 10 * time.Second, nil
+		}
+		operation.ProvisionerOperationID = *provisionerResponse.ID
+		log.Infof("fetched ProvisionerOperationID=%s", operation.ProvisionerOperationID)
+
+		operation, repeat, err := s.operationManager.UpdateOperation(operation)
+		if repeat != 0 {
+			log.Errorf("cannot save operation ID from provisioner: %s", err)
+			return operation, 5 * time.Second, nil
+		}
+	}
+
+	log.Infof("runtime hibernation process initiated successfully")
+	// return repeat mode (1 sec) to start the initialization step which will now check the runtime status
+	return operation, 1 * time.Second, nil
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	provisionerAutomock "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner/automock"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 	"github.com/pivotal-cf/brokerapi/v7/domain"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -75,6 +77,39 @@ func TestRemoveRuntimeStep_Run(t *testing.T) {
 		assert.Equal(t, "", result.ProvisionerOperationID)
 		assert.Equal(t, "", result.RuntimeID)
 	})
+
+	t.Run("Should hibernate runtime when operation is a hibernation", func(t *testing.T) {
+		// given
+		log := logrus.New()
+		memoryStorage := storage.NewMemoryStorage()
+
+		operation := fixOperationRemoveRuntime()
+		operation.Temporary = true
+		operation.Hibernation = true
+		err := memoryStorage.Operations().InsertDeprovisioningOperation(operation)
+		assert.NoError(t, err)
+
+		err = memoryStorage.Instances().Insert(fixInstanceRuntimeStatus())
+		assert.NoError(t, err)
+
+		provisionerClient := &provisionerAutomock.Client{}
+		provisionerClient.On("HibernateRuntime", fixGlobalAccountID, fixRuntimeID).Return(gqlschema.OperationStatus{
+			ID:        ptr.String(fixProvisionerOperationID),
+			Operation: gqlschema.OperationTypeHibernate,
+		}, nil)
+
+		step := NewRemoveRuntimeStep(memoryStorage.Operations(), memoryStorage.Instances(), provisionerClient)
+
+		// when
+		entry := log.WithFields(logrus.Fields{"step": "TEST"})
+		result, repeat, err := step.Run(operation, entry)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, 1*time.Second, repeat)
+		assert.Equal(t, fixProvisionerOperationID, result.ProvisionerOperationID)
+		provisionerClient.AssertExpectations(t)
+	})
 }
 
 func fixOperationRemoveRuntime() internal.DeprovisioningOperation {
@@ -0,0 +1,41 @@
+package deprovisioning
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+)
+
+// SkipForHibernationStep skips the step when the runtime is hibernated instead of being removed,
+// the hibernated runtime keeps all its resources and can be woken up
+type SkipForHibernationStep struct {
+	step Step
+}
+
+var _ Step = &SkipForHibernationStep{}
+
+func NewSkipForHibernationStep(step Step) SkipForHibernationStep {
+	return SkipForHibernationStep{
+		step: step,
+	}
+}
+
+func (s SkipForHibernationStep) Name() string {
+	return s.step.Name()
+}
+
+func (s SkipForHibernationStep) Repeatable() bool {
+	return process.IsStepRepeatable(s.step)
+}
+
+func (s SkipForHibernationStep) Run(operation internal.DeprovisioningOperation, log logrus.FieldLogger) (internal.DeprovisioningOperation, time.Duration, error) {
+	if operation.Hibernation {
+		log.Infof("Skipping step %s", s.Name())
+		return operation, 0, nil
+	}
+
+	return s.step.Run(operation, log)
+}
@@ -0,0 +1,55 @@
+package deprovisioning
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/deprovisioning/automock"
+)
+
+func TestSkipForHibernationStepShouldSkip(t *testing.T) {
+	// Given
+	log := logrus.New()
+	wantOperation := fixOperationWithPlanID(broker.TrialPlanID)
+	wantOperation.Temporary = true
+	wantOperation.Hibernation = true
+
+	mockStep := new(automock.Step)
+	mockStep.On("Name").Return("Test")
+	skipStep := NewSkipForHibernationStep(mockStep)
+
+	// When
+	gotOperation, gotSkipTime, gotErr := skipStep.Run(wantOperation, log)
+
+	// Then
+	mockStep.AssertExpectations(t)
+	assert.Nil(t, gotErr)
+	assert.Zero(t, gotSkipTime)
+	assert.Equal(t, wantOperation, gotOperation)
+}
+
+func TestSkipForHibernationStepShouldNotSkip(t *testing.T) {
+	// Given
+	log := logrus.New()
+	wantSkipTime := time.Duration(10)
+	givenOperation := fixOperationWithPlanID(broker.TrialPlanID)
+	givenOperation.Temporary = true
+	wantOperation := fixOperationWithPlanID("operation2")
+
+	mockStep := new(automock.Step)
+	mockStep.On("Run", givenOperation, log).Return(wantOperation, wantSkipTime, nil)
+	skipStep := NewSkipForHibernationStep(mockStep)
+
+	// When
+	gotOperation, gotSkipTime, gotErr := skipStep.Run(givenOperation, log)
+
+	// Then
+	mockStep.AssertExpectations(t)
+	assert.Nil(t, gotErr)
+	assert.Equal(t, wantSkipTime, gotSkipTime)
+	assert.Equal(t, wantOperation, gotOperation)
+}
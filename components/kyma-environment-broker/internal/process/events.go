@@ -1,16 +1,40 @@
 package process
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+
+	"github.com/google/uuid"
 )
 
 type StepProcessed struct {
-	StepName string
-	Duration time.Duration
-	When     time.Duration
-	Error    error
+	// ID identifies the processed step, it is the same for every delivery of the event
+	ID         string
+	StepName   string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Duration   time.Duration
+	When       time.Duration
+	Error      error
+}
+
+// NewStepProcessed describes the step of the operation which started at the given time and has just finished,
+// the ID is derived from the operation, the step and its start time
+func NewStepProcessed(operationID, stepName string, startedAt time.Time, when time.Duration, err error) StepProcessed {
+	finishedAt := time.Now()
+	name := fmt.Sprintf("%s/%s/%s", operationID, stepName, startedAt.UTC().Format(time.RFC3339Nano))
+
+	return StepProcessed{
+		ID:         uuid.NewSHA1(uuid.NameSpaceOID, []byte(name)).String(),
+		StepName:   stepName,
+		StartedAt:  startedAt,
+		FinishedAt: finishedAt,
+		Duration:   finishedAt.Sub(startedAt),
+		When:       when,
+		Error:      err,
+	}
 }
 
 type ProvisioningStepProcessed struct {
@@ -30,3 +54,15 @@ type UpgradeKymaStepProcessed struct {
 	OldOperation internal.UpgradeKymaOperation
 	Operation    internal.UpgradeKymaOperation
 }
+
+type UpgradeClusterStepProcessed struct {
+	StepProcessed
+	OldOperation internal.UpgradeClusterOperation
+	Operation    internal.UpgradeClusterOperation
+}
+
+type UpdatingStepProcessed struct {
+	StepProcessed
+	OldOperation internal.UpdatingOperation
+	Operation    internal.UpdatingOperation
+}
@@ -0,0 +1,25 @@
+package process
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStepProcessed(t *testing.T) {
+	// given
+	startedAt := time.Now().Add(-time.Second)
+
+	// when
+	first := NewStepProcessed("operation-id", "Create_Runtime", startedAt, time.Minute, nil)
+	second := NewStepProcessed("operation-id", "Create_Runtime", startedAt, time.Minute, nil)
+	other := NewStepProcessed("operation-id", "Create_Runtime", startedAt.Add(time.Millisecond), time.Minute, nil)
+
+	// then
+	assert.Equal(t, first.ID, second.ID)
+	assert.NotEqual(t, first.ID, other.ID)
+	assert.Equal(t, startedAt, first.StartedAt)
+	assert.Equal(t, first.FinishedAt.Sub(startedAt), first.Duration)
+	assert.Equal(t, time.Minute, first.When)
+}
@@ -41,6 +41,8 @@ type (
 		IsPlanSupport(planID string) bool
 		CreateProvisionInput(parameters internal.ProvisioningParameters, version internal.RuntimeVersionData) (internal.ProvisionerInputCreator, error)
 		CreateUpgradeInput(parameters internal.ProvisioningParameters, version internal.RuntimeVersionData) (internal.ProvisionerInputCreator, error)
+		CreateUpgradeShootInput(parameters internal.ProvisioningParameters) (internal.ProvisionerInputCreator, error)
+		CreatePlanUpgradeShootInput(parameters internal.ProvisioningParameters) (gqlschema.UpgradeShootInput, error)
 	}
 
 	ComponentListProvider interface {
@@ -79,7 +81,7 @@ func NewInputBuilderFactory(optComponentsSvc OptionalComponentService, disabledC
 
 func (f *InputBuilderFactory) IsPlanSupport(planID string) bool {
 	switch planID {
-	case broker.GCPPlanID, broker.AzurePlanID, broker.AzureLitePlanID, broker.TrialPlanID:
+	case broker.GCPPlanID, broker.AzurePlanID, broker.AzureLitePlanID, broker.TrialPlanID, broker.AWSPlanID:
 		return true
 	default:
 		return false
@@ -95,9 +97,10 @@ func (f *InputBuilderFactory) getHyperscalerProviderForPlanID(planID string, par
 		provider = &cloudProvider.AzureInput{}
 	case broker.AzureLitePlanID:
 		provider = &cloudProvider.AzureLiteInput{}
+	case broker.AWSPlanID:
+		provider = &cloudProvider.AWSInput{}
 	case broker.TrialPlanID:
 		provider = f.forTrialPlan(parametersProvider)
-		// insert cases for other providers like AWS or GCP
 	default:
 		return nil, errors.Errorf("case with plan %s is not supported", planID)
 	}
@@ -151,6 +154,10 @@ func (f *InputBuilderFactory) forTrialPlan(provider *internal.TrialCloudProvider
 		return &cloudProvider.GcpTrialInput{
 			PlatformRegionMapping: f.trialPlatformRegionMapping,
 		}
+	case internal.Aws:
+		return &cloudProvider.AWSTrialInput{
+			PlatformRegionMapping: f.trialPlatformRegionMapping,
+		}
 	default:
 		return &cloudProvider.AzureTrialInput{
 			PlatformRegionMapping: f.trialPlatformRegionMapping,
@@ -260,6 +267,85 @@ func (f *InputBuilderFactory) initUpgradeRuntimeInput(version internal.RuntimeVe
 	}, nil
 }
 
+func (f *InputBuilderFactory) CreateUpgradeShootInput(pp internal.ProvisioningParameters) (internal.ProvisionerInputCreator, error) {
+	if !f.IsPlanSupport(pp.PlanID) {
+		return nil, errors.Errorf("plan %s in not supported", pp.PlanID)
+	}
+
+	upgradeShootInput, err := f.initUpgradeShootInput()
+	if err != nil {
+		return nil, errors.Wrap(err, "while initializing UpgradeShootInput")
+	}
+
+	return &RuntimeInput{
+		upgradeShootInput:      upgradeShootInput,
+		mutex:                  nsync.NewNamedMutex(),
+		overrides:              make(map[string][]*gqlschema.ConfigEntryInput, 0),
+		globalOverrides:        make([]*gqlschema.ConfigEntryInput, 0),
+		provisioningParameters: pp,
+	}, nil
+}
+
+// CreatePlanUpgradeShootInput creates the input which changes the shoot to the default specification of the plan
+// and moves it to the hyperscaler account resolved for the plan,
+// the defaults are overwritten with the parameters
+func (f *InputBuilderFactory) CreatePlanUpgradeShootInput(pp internal.ProvisioningParameters) (gqlschema.UpgradeShootInput, error) {
+	if !f.IsPlanSupport(pp.PlanID) {
+		return gqlschema.UpgradeShootInput{}, errors.Errorf("plan %s in not supported", pp.PlanID)
+	}
+
+	provider, err := f.getHyperscalerProviderForPlanID(pp.PlanID, pp.Parameters.Provider)
+	if err != nil {
+		return gqlschema.UpgradeShootInput{}, errors.Wrap(err, "during creating upgrade shoot input")
+	}
+
+	config := provider.Defaults().GardenerConfig
+	if config.Purpose == nil {
+		config.Purpose = &f.config.DefaultGardenerShootPurpose
+	}
+	params := pp.Parameters
+	updateString(&config.MachineType, params.MachineType)
+	updateInt(&config.VolumeSizeGb, params.VolumeSizeGb)
+	updateInt(&config.AutoScalerMin, params.AutoScalerMin)
+	updateInt(&config.AutoScalerMax, params.AutoScalerMax)
+	updateInt(&config.MaxSurge, params.MaxSurge)
+	updateInt(&config.MaxUnavailable, params.MaxUnavailable)
+
+	return gqlschema.UpgradeShootInput{
+		GardenerConfig: &gqlschema.GardenerUpgradeInput{
+			MachineType:    &config.MachineType,
+			DiskType:       &config.DiskType,
+			VolumeSizeGb:   &config.VolumeSizeGb,
+			AutoScalerMin:  &config.AutoScalerMin,
+			AutoScalerMax:  &config.AutoScalerMax,
+			MaxSurge:       &config.MaxSurge,
+			MaxUnavailable: &config.MaxUnavailable,
+			Purpose:        config.Purpose,
+			TargetSecret:   params.TargetSecret,
+		},
+	}, nil
+}
+
+func (f *InputBuilderFactory) initUpgradeShootInput() (gqlschema.UpgradeShootInput, error) {
+	if f.config.KubernetesVersion == "" {
+		return gqlschema.UpgradeShootInput{}, errors.New("desired Kubernetes version cannot be empty")
+	}
+
+	input := gqlschema.UpgradeShootInput{
+		GardenerConfig: &gqlschema.GardenerUpgradeInput{
+			KubernetesVersion: &f.config.KubernetesVersion,
+		},
+	}
+	if f.config.MachineImage != "" {
+		input.GardenerConfig.MachineImage = &f.config.MachineImage
+	}
+	if f.config.MachineImageVersion != "" {
+		input.GardenerConfig.MachineImageVersion = &f.config.MachineImageVersion
+	}
+
+	return input, nil
+}
+
 func mapToGQLComponentConfigurationInput(kymaComponents []v1alpha1.KymaComponent) internal.ComponentConfigurationInputList {
 	var input internal.ComponentConfigurationInputList
 	for _, component := range kymaComponents {
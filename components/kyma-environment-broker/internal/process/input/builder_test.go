@@ -33,6 +33,7 @@ func TestInputBuilderFactory_IsPlanSupport(t *testing.T) {
 	assert.True(t, ibf.IsPlanSupport(broker.GCPPlanID))
 	assert.True(t, ibf.IsPlanSupport(broker.AzurePlanID))
 	assert.True(t, ibf.IsPlanSupport(broker.TrialPlanID))
+	assert.True(t, ibf.IsPlanSupport(broker.AWSPlanID))
 }
 
 func TestInputBuilderFactory_ForPlan(t *testing.T) {
@@ -215,6 +216,61 @@ func TestInputBuilderFactory_ForPlan(t *testing.T) {
 		assert.Equal(t, gqlschema.KymaProfileEvaluation, *result.upgradeRuntimeInput.KymaConfig.Profile)
 	})
 
+	t.Run("should build UpgradeShootInput with Kubernetes version and machine image from the configuration", func(t *testing.T) {
+		// given
+		componentsProvider := &automock.ComponentListProvider{}
+		componentsProvider.On("AllComponents", "1.10").Return([]v1alpha1.KymaComponent{}, nil).Once()
+		defer componentsProvider.AssertExpectations(t)
+
+		ibf, err := NewInputBuilderFactory(nil, runtime.NewDisabledComponentsProvider(), componentsProvider,
+			Config{KubernetesVersion: "1.18.12", MachineImage: "gardenlinux", MachineImageVersion: "184.0.0"}, "1.10", fixTrialRegionMapping())
+		assert.NoError(t, err)
+		pp := fixProvisioningParameters(broker.AzurePlanID, "")
+
+		// when
+		input, err := ibf.CreateUpgradeShootInput(pp)
+
+		// Then
+		assert.NoError(t, err)
+		require.IsType(t, &RuntimeInput{}, input)
+
+		shootInput, err := input.CreateUpgradeShootInput()
+		assert.NoError(t, err)
+		require.NotNil(t, shootInput.GardenerConfig)
+		assert.Equal(t, "1.18.12", *shootInput.GardenerConfig.KubernetesVersion)
+		assert.Equal(t, "gardenlinux", *shootInput.GardenerConfig.MachineImage)
+		assert.Equal(t, "184.0.0", *shootInput.GardenerConfig.MachineImageVersion)
+		assert.Nil(t, shootInput.GardenerConfig.MachineType)
+	})
+
+	t.Run("should build UpgradeShootInput with the specification of the target plan", func(t *testing.T) {
+		// given
+		componentsProvider := &automock.ComponentListProvider{}
+		componentsProvider.On("AllComponents", "1.10").Return([]v1alpha1.KymaComponent{}, nil).Once()
+		defer componentsProvider.AssertExpectations(t)
+
+		ibf, err := NewInputBuilderFactory(nil, runtime.NewDisabledComponentsProvider(), componentsProvider,
+			Config{DefaultGardenerShootPurpose: "production"}, "1.10", fixTrialRegionMapping())
+		assert.NoError(t, err)
+		pp := fixProvisioningParameters(broker.AzurePlanID, "")
+		autoScalerMax := 20
+		pp.Parameters.AutoScalerMax = &autoScalerMax
+		targetSecret := "azure-secret"
+		pp.Parameters.TargetSecret = &targetSecret
+
+		// when
+		shootInput, err := ibf.CreatePlanUpgradeShootInput(pp)
+
+		// Then
+		assert.NoError(t, err)
+		require.NotNil(t, shootInput.GardenerConfig)
+		assert.Equal(t, "Standard_D8_v3", *shootInput.GardenerConfig.MachineType)
+		assert.Equal(t, 2, *shootInput.GardenerConfig.AutoScalerMin)
+		assert.Equal(t, 20, *shootInput.GardenerConfig.AutoScalerMax)
+		assert.Equal(t, "production", *shootInput.GardenerConfig.Purpose)
+		assert.Equal(t, "azure-secret", *shootInput.GardenerConfig.TargetSecret)
+	})
+
 }
 
 func fixProvisioningParameters(planID, kymaVersion string) internal.ProvisioningParameters {
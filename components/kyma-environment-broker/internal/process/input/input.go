@@ -31,6 +31,7 @@ type Config struct {
 type RuntimeInput struct {
 	provisionRuntimeInput gqlschema.ProvisionRuntimeInput
 	upgradeRuntimeInput   gqlschema.UpgradeRuntimeInput
+	upgradeShootInput     gqlschema.UpgradeShootInput
 	mutex                 *nsync.NamedMutex
 	overrides             map[string][]*gqlschema.ConfigEntryInput
 	labels                map[string]string
@@ -182,6 +183,10 @@ func (r *RuntimeInput) CreateUpgradeRuntimeInput() (gqlschema.UpgradeRuntimeInpu
 	return r.upgradeRuntimeInput, nil
 }
 
+func (r *RuntimeInput) CreateUpgradeShootInput() (gqlschema.UpgradeShootInput, error) {
+	return r.upgradeShootInput, nil
+}
+
 func (r *RuntimeInput) applyProvisioningParameters() error {
 	params := r.provisioningParameters.Parameters
 	updateString(&r.provisionRuntimeInput.RuntimeInput.Name, &params.Name)
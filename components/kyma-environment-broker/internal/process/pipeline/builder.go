@@ -0,0 +1,41 @@
+package pipeline
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+
+	"github.com/pkg/errors"
+)
+
+// Registry maps names of steps to functions creating the steps
+type Registry map[string]func() process.NamedStep
+
+// Manager is the process manager executing the steps of the pipeline
+type Manager interface {
+	// AddPipelineStep adds the step executed according to the policy, it fails if the step does not belong to the process
+	AddPipelineStep(weight int, step process.NamedStep, policy process.StepPolicy) error
+}
+
+// AddSteps resolves the step definitions against the registered steps and adds the enabled steps to the manager
+func AddSteps(manager Manager, definitions []StepDefinition, registry Registry) error {
+	var names []string
+	for name := range registry {
+		names = append(names, name)
+	}
+	steps, err := Resolve(definitions, names)
+	if err != nil {
+		return errors.Wrap(err, "while resolving steps")
+	}
+
+	for _, s := range steps {
+		step := registry[s.Name]()
+		if step.Name() != s.Name {
+			return fmt.Errorf("step registered as %q is named %q", s.Name, step.Name())
+		}
+		if err := manager.AddPipelineStep(s.Weight, step, s.Policy()); err != nil {
+			return errors.Wrapf(err, "while adding step %q", s.Name)
+		}
+	}
+	return nil
+}
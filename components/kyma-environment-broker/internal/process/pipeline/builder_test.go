@@ -0,0 +1,96 @@
+package pipeline
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddSteps(t *testing.T) {
+	t.Run("should add resolved steps with their policies", func(t *testing.T) {
+		// given
+		manager := &fakeManager{}
+		definitions := []StepDefinition{
+			{Name: "two", DependsOn: []string{"one"}, Plans: []string{broker.AzurePlanName}, MaxAttempts: 3},
+			{Name: "one"},
+			{Name: "three", Disabled: true},
+		}
+
+		// when
+		err := AddSteps(manager, definitions, fixRegistry())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []addedStep{
+			{weight: 1, name: "one"},
+			{weight: 2, name: "two", policy: process.StepPolicy{PlanIDs: []string{broker.AzurePlanID}, MaxAttempts: 3}},
+		}, manager.steps)
+	})
+
+	t.Run("should fail when the registered step has a different name", func(t *testing.T) {
+		// given
+		registry := fixRegistry()
+		registry["one"] = func() process.NamedStep { return namedStep("other") }
+
+		// when
+		err := AddSteps(&fakeManager{}, []StepDefinition{{Name: "one"}}, registry)
+
+		// then
+		assert.EqualError(t, err, `step registered as "one" is named "other"`)
+	})
+
+	t.Run("should fail when the manager rejects the step", func(t *testing.T) {
+		// when
+		err := AddSteps(&fakeManager{reject: "one"}, []StepDefinition{{Name: "one"}}, fixRegistry())
+
+		// then
+		assert.EqualError(t, err, `while adding step "one": step one is not a test step`)
+	})
+
+	t.Run("should fail for the unknown step", func(t *testing.T) {
+		// when
+		err := AddSteps(&fakeManager{}, []StepDefinition{{Name: "unknown"}}, fixRegistry())
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+type namedStep string
+
+func (s namedStep) Name() string {
+	return string(s)
+}
+
+func fixRegistry() Registry {
+	registry := Registry{}
+	for _, name := range registered {
+		step := namedStep(name)
+		registry[name] = func() process.NamedStep { return step }
+	}
+	return registry
+}
+
+type addedStep struct {
+	weight int
+	name   string
+	policy process.StepPolicy
+}
+
+type fakeManager struct {
+	reject string
+	steps  []addedStep
+}
+
+func (m *fakeManager) AddPipelineStep(weight int, step process.NamedStep, policy process.StepPolicy) error {
+	if step.Name() == m.reject {
+		return fmt.Errorf("step %s is not a test step", step.Name())
+	}
+	m.steps = append(m.steps, addedStep{weight: weight, name: step.Name(), policy: policy})
+	return nil
+}
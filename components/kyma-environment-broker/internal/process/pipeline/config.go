@@ -0,0 +1,81 @@
+package pipeline
+
+import (
+	"io/ioutil"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Config defines steps executed by the provisioning, deprovisioning, upgrade kyma and upgrade cluster processes
+type Config struct {
+	Provisioning   []StepDefinition `yaml:"provisioning"`
+	Deprovisioning []StepDefinition `yaml:"deprovisioning"`
+	UpgradeKyma    []StepDefinition `yaml:"upgradeKyma"`
+	UpgradeCluster []StepDefinition `yaml:"upgradeCluster"`
+}
+
+// StepDefinition describes a single step of the process
+type StepDefinition struct {
+	// Name must match the name of a step registered in the broker
+	Name string `yaml:"name"`
+
+	// Weight defines the order of steps, steps with the same weight can be executed concurrently.
+	// If not set, the weight is computed from the steps the step depends on.
+	Weight int `yaml:"weight"`
+
+	// DependsOn lists names of steps which must be executed before the step
+	DependsOn []string `yaml:"dependsOn"`
+
+	// Plans lists names of plans for which the step is executed, the step is executed for all plans if empty
+	Plans []string `yaml:"plans"`
+
+	// Disabled removes the step from the process
+	Disabled bool `yaml:"disabled"`
+
+	// Timeout defines the maximum time of the step processing, not limited if empty
+	Timeout time.Duration `yaml:"timeout"`
+
+	// MaxAttempts defines the retry budget of the step, not limited if empty
+	MaxAttempts int `yaml:"maxAttempts"`
+
+	// Backoff defines the minimum time between attempts of the step, doubled with every attempt up to MaxBackoff
+	Backoff    time.Duration `yaml:"backoff"`
+	MaxBackoff time.Duration `yaml:"maxBackoff"`
+
+	// Optional steps are skipped instead of failing the operation when they fail or exceed the time limit or the retry budget
+	Optional bool `yaml:"optional"`
+}
+
+// ReadConfigFromFile reads the pipeline configuration from the given YAML file
+func ReadConfigFromFile(filename string) (Config, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return Config{}, errors.Wrapf(err, "while reading %s file with pipeline config", filename)
+	}
+	var config Config
+	err = yaml.UnmarshalStrict(content, &config)
+	if err != nil {
+		return Config{}, errors.Wrapf(err, "while unmarshalling a file with pipeline config")
+	}
+	return config, nil
+}
+
+// Override returns the configuration in which definitions of processes present in the given configuration
+// replace definitions of the default one
+func (c Config) Override(config Config) Config {
+	if len(config.Provisioning) > 0 {
+		c.Provisioning = config.Provisioning
+	}
+	if len(config.Deprovisioning) > 0 {
+		c.Deprovisioning = config.Deprovisioning
+	}
+	if len(config.UpgradeKyma) > 0 {
+		c.UpgradeKyma = config.UpgradeKyma
+	}
+	if len(config.UpgradeCluster) > 0 {
+		c.UpgradeCluster = config.UpgradeCluster
+	}
+	return c
+}
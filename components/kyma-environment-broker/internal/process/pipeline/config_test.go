@@ -0,0 +1,47 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadConfigFromFile(t *testing.T) {
+	// when
+	config, err := ReadConfigFromFile("testdata/pipeline.yaml")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []StepDefinition{
+		{Name: "Resolve_Target_Secret", Weight: 2},
+		{Name: "EDP_Registration", DependsOn: []string{"Resolve_Target_Secret"}, Plans: []string{"azure", "gcp"},
+			Timeout: 10 * time.Minute, MaxAttempts: 5, Backoff: 10 * time.Second, MaxBackoff: time.Minute, Optional: true},
+		{Name: "Create_Runtime", Weight: 10},
+	}, config.Provisioning)
+	assert.Equal(t, []StepDefinition{{Name: "Remove_Runtime", Weight: 10}}, config.Deprovisioning)
+	assert.Empty(t, config.UpgradeKyma)
+	assert.Empty(t, config.UpgradeCluster)
+}
+
+func TestConfig_Override(t *testing.T) {
+	// given
+	defaults := Config{
+		Provisioning:   []StepDefinition{{Name: "Create_Runtime", Weight: 10}},
+		Deprovisioning: []StepDefinition{{Name: "Remove_Runtime", Weight: 10}},
+		UpgradeKyma:    []StepDefinition{{Name: "Upgrade_Kyma", Weight: 10}},
+		UpgradeCluster: []StepDefinition{{Name: "Upgrade_Cluster", Weight: 10}},
+	}
+
+	// when
+	config := defaults.Override(Config{
+		Provisioning: []StepDefinition{{Name: "Create_Runtime", Weight: 5}},
+	})
+
+	// then
+	assert.Equal(t, []StepDefinition{{Name: "Create_Runtime", Weight: 5}}, config.Provisioning)
+	assert.Equal(t, defaults.Deprovisioning, config.Deprovisioning)
+	assert.Equal(t, defaults.UpgradeKyma, config.UpgradeKyma)
+	assert.Equal(t, defaults.UpgradeCluster, config.UpgradeCluster)
+}
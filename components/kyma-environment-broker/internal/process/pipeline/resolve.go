@@ -0,0 +1,153 @@
+package pipeline
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+
+	"github.com/pkg/errors"
+)
+
+// Step is a validated step definition ready to be added to the process manager
+type Step struct {
+	Name        string
+	Weight      int
+	PlanIDs     []string
+	Timeout     time.Duration
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+	Optional    bool
+}
+
+// Resolve validates given step definitions against names of registered steps and returns enabled steps sorted by weight.
+// It fails if a step or a plan is unknown, a step is defined more than once, dependencies of steps contain a cycle
+// or a step weight is not greater than weights of steps it depends on.
+func Resolve(definitions []StepDefinition, registered []string) ([]Step, error) {
+	known := make(map[string]struct{})
+	for _, name := range registered {
+		known[name] = struct{}{}
+	}
+
+	byName := make(map[string]StepDefinition)
+	for _, def := range definitions {
+		switch {
+		case def.Name == "":
+			return nil, errors.New("step name must not be empty")
+		case def.Weight < 0:
+			return nil, fmt.Errorf("weight of the step %q must not be negative", def.Name)
+		case def.Timeout < 0:
+			return nil, fmt.Errorf("timeout of the step %q must not be negative", def.Name)
+		case def.MaxAttempts < 0:
+			return nil, fmt.Errorf("max attempts of the step %q must not be negative", def.Name)
+		case def.Backoff < 0, def.MaxBackoff < 0:
+			return nil, fmt.Errorf("backoff of the step %q must not be negative", def.Name)
+		case def.MaxBackoff > 0 && def.MaxBackoff < def.Backoff:
+			return nil, fmt.Errorf("max backoff of the step %q must not be less than its backoff", def.Name)
+		}
+		if _, found := known[def.Name]; !found {
+			return nil, fmt.Errorf("unknown step %q", def.Name)
+		}
+		if _, found := byName[def.Name]; found {
+			return nil, fmt.Errorf("step %q is defined more than once", def.Name)
+		}
+		for _, plan := range def.Plans {
+			if _, found := broker.PlanIDsMapping[plan]; !found {
+				return nil, fmt.Errorf("unknown plan %q of the step %q", plan, def.Name)
+			}
+		}
+		byName[def.Name] = def
+	}
+
+	r := resolver{definitions: byName, weights: make(map[string]int)}
+	var steps []Step
+	for _, def := range definitions {
+		weight, err := r.weight(def.Name, nil)
+		if err != nil {
+			return nil, err
+		}
+		if def.Disabled {
+			continue
+		}
+
+		var planIDs []string
+		for _, plan := range def.Plans {
+			planIDs = append(planIDs, broker.PlanIDsMapping[plan])
+		}
+		steps = append(steps, Step{
+			Name:        def.Name,
+			Weight:      weight,
+			PlanIDs:     planIDs,
+			Timeout:     def.Timeout,
+			MaxAttempts: def.MaxAttempts,
+			Backoff:     def.Backoff,
+			MaxBackoff:  def.MaxBackoff,
+			Optional:    def.Optional,
+		})
+	}
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].Weight < steps[j].Weight
+	})
+
+	return steps, nil
+}
+
+// Policy returns the policy of executing the step by the process manager
+func (s Step) Policy() process.StepPolicy {
+	return process.StepPolicy{
+		PlanIDs:     s.PlanIDs,
+		Timeout:     s.Timeout,
+		MaxAttempts: s.MaxAttempts,
+		Backoff:     s.Backoff,
+		MaxBackoff:  s.MaxBackoff,
+		Optional:    s.Optional,
+	}
+}
+
+type resolver struct {
+	definitions map[string]StepDefinition
+	weights     map[string]int
+}
+
+// weight returns the weight of the step, path contains steps which depend on the step and is used to detect cycles
+func (r *resolver) weight(name string, path []string) (int, error) {
+	if weight, found := r.weights[name]; found {
+		return weight, nil
+	}
+	for i, visited := range path {
+		if visited == name {
+			return 0, fmt.Errorf("steps dependency cycle: %s", strings.Join(append(path[i:], name), " -> "))
+		}
+	}
+
+	def := r.definitions[name]
+	dependencyPath := append(append([]string{}, path...), name)
+	maxDependencyWeight := 0
+	for _, dependency := range def.DependsOn {
+		if _, found := r.definitions[dependency]; !found {
+			return 0, fmt.Errorf("step %q depends on undefined step %q", name, dependency)
+		}
+		weight, err := r.weight(dependency, dependencyPath)
+		if err != nil {
+			return 0, err
+		}
+		if weight > maxDependencyWeight {
+			maxDependencyWeight = weight
+		}
+	}
+
+	weight := def.Weight
+	switch {
+	case weight == 0:
+		weight = maxDependencyWeight + 1
+	case weight <= maxDependencyWeight:
+		return 0, fmt.Errorf("weight of the step %q must be greater than weights of steps it depends on (%d)", name, maxDependencyWeight)
+	}
+	r.weights[name] = weight
+
+	return weight, nil
+}
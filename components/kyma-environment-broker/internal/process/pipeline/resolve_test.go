@@ -0,0 +1,109 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var registered = []string{"one", "two", "three", "four"}
+
+func TestResolve(t *testing.T) {
+	t.Run("should compute weights and sort steps", func(t *testing.T) {
+		// given
+		definitions := []StepDefinition{
+			{Name: "four", Weight: 10, MaxAttempts: 3, Backoff: time.Second, Optional: true},
+			{Name: "three", DependsOn: []string{"one", "two"}, Timeout: time.Minute},
+			{Name: "two", DependsOn: []string{"one"}, Plans: []string{broker.AzurePlanName, broker.GCPPlanName}},
+			{Name: "one"},
+		}
+
+		// when
+		steps, err := Resolve(definitions, registered)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []Step{
+			{Name: "one", Weight: 1},
+			{Name: "two", Weight: 2, PlanIDs: []string{broker.AzurePlanID, broker.GCPPlanID}},
+			{Name: "three", Weight: 3, Timeout: time.Minute},
+			{Name: "four", Weight: 10, MaxAttempts: 3, Backoff: time.Second, Optional: true},
+		}, steps)
+	})
+
+	t.Run("should skip disabled steps", func(t *testing.T) {
+		// given
+		definitions := []StepDefinition{
+			{Name: "one", Weight: 1, Disabled: true},
+			{Name: "two", DependsOn: []string{"one"}},
+		}
+
+		// when
+		steps, err := Resolve(definitions, registered)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []Step{{Name: "two", Weight: 2}}, steps)
+	})
+
+	for name, tc := range map[string]struct {
+		definitions   []StepDefinition
+		expectedError string
+	}{
+		"unknown step": {
+			definitions:   []StepDefinition{{Name: "five"}},
+			expectedError: `unknown step "five"`,
+		},
+		"duplicated step": {
+			definitions:   []StepDefinition{{Name: "one"}, {Name: "one", Weight: 2}},
+			expectedError: `step "one" is defined more than once`,
+		},
+		"unknown plan": {
+			definitions:   []StepDefinition{{Name: "one", Plans: []string{"openstack"}}},
+			expectedError: `unknown plan "openstack" of the step "one"`,
+		},
+		"undefined dependency": {
+			definitions:   []StepDefinition{{Name: "one", DependsOn: []string{"two"}}},
+			expectedError: `step "one" depends on undefined step "two"`,
+		},
+		"dependency cycle": {
+			definitions: []StepDefinition{
+				{Name: "one", DependsOn: []string{"three"}},
+				{Name: "two", DependsOn: []string{"one"}},
+				{Name: "three", DependsOn: []string{"two"}},
+			},
+			expectedError: "steps dependency cycle: one -> three -> two -> one",
+		},
+		"weight lower than dependency weight": {
+			definitions: []StepDefinition{
+				{Name: "one", Weight: 5},
+				{Name: "two", Weight: 5, DependsOn: []string{"one"}},
+			},
+			expectedError: `weight of the step "two" must be greater than weights of steps it depends on (5)`,
+		},
+		"negative weight": {
+			definitions:   []StepDefinition{{Name: "one", Weight: -1}},
+			expectedError: `weight of the step "one" must not be negative`,
+		},
+		"negative max attempts": {
+			definitions:   []StepDefinition{{Name: "one", MaxAttempts: -1}},
+			expectedError: `max attempts of the step "one" must not be negative`,
+		},
+		"max backoff lower than backoff": {
+			definitions:   []StepDefinition{{Name: "one", Backoff: time.Minute, MaxBackoff: time.Second}},
+			expectedError: `max backoff of the step "one" must not be less than its backoff`,
+		},
+	} {
+		t.Run("should fail for "+name, func(t *testing.T) {
+			// when
+			_, err := Resolve(tc.definitions, registered)
+
+			// then
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
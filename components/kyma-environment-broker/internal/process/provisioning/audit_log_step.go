@@ -28,6 +28,11 @@ func (alo *AuditLogOverrides) Name() string {
 	return "Audit_Log_Overrides"
 }
 
+// Repeatable returns true, the Fluent Bit configuration is rendered from the mounted script
+func (alo *AuditLogOverrides) Repeatable() bool {
+	return true
+}
+
 func NewAuditLogOverridesStep(os storage.Operations, cfg auditlog.Config) *AuditLogOverrides {
 	fileSystem := afero.NewOsFs()
 
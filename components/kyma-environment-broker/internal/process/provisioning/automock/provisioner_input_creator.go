@@ -88,6 +88,27 @@ func (_m *ProvisionerInputCreator) CreateUpgradeRuntimeInput() (gqlschema.Upgrad
 	return r0, r1
 }
 
+// CreateUpgradeShootInput provides a mock function with given fields:
+func (_m *ProvisionerInputCreator) CreateUpgradeShootInput() (gqlschema.UpgradeShootInput, error) {
+	ret := _m.Called()
+
+	var r0 gqlschema.UpgradeShootInput
+	if rf, ok := ret.Get(0).(func() gqlschema.UpgradeShootInput); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(gqlschema.UpgradeShootInput)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // EnableOptionalComponent provides a mock function with given fields: componentName
 func (_m *ProvisionerInputCreator) EnableOptionalComponent(componentName string) internal.ProvisionerInputCreator {
 	ret := _m.Called(componentName)
@@ -11,7 +11,6 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
-	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtime/components"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/servicemanager"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
@@ -66,47 +65,37 @@ func (s *EmsBindStep) Run(operation internal.ProvisioningOperation, log logrus.F
 	case servicemanager.Failed:
 		return s.operationManager.OperationFailed(operation, fmt.Sprintf("Ems provisioning failed: %s", resp.Description))
 	}
-	// execute binding
-	var eventingOverrides *EventingOverrides
-	if !operation.Ems.Instance.Provisioned {
-		if operation.Ems.BindingID == "" {
-			operation.Ems.BindingID = uuid.New().String()
-		}
-		respBinding, err := smCli.Bind(operation.Ems.Instance.InstanceKey(), operation.Ems.BindingID, nil, false)
-		if err != nil {
-			return s.handleError(operation, err, log, fmt.Sprintf("Bind() call failed"))
-		}
-		// get overrides
-		eventingOverrides, err = GetEventingCredentials(respBinding.Binding)
-		if err != nil {
-			return s.handleError(operation, err, log, fmt.Sprintf("getCredentials() call failed"))
-		}
-		encryptedOverrides, err := EncryptEventingOverrides(s.secretKey, eventingOverrides)
-		if err != nil {
-			return s.handleError(operation, err, log, fmt.Sprintf("encryptOverrides() call failed"))
-		}
-		operation.Ems.Overrides = encryptedOverrides
-		operation.Ems.Instance.Provisioned = true
-		operation.Ems.Instance.ProvisioningTriggered = false
-		// save the status
-		op, retry := s.operationManager.UpdateOperation(operation)
-		if retry > 0 {
-			log.Errorf("unable to update operation")
-			return operation, time.Second, nil
-		}
-		operation = op
-	} else {
-		// get the credentials from encrypted string in operation.Ems.Instance.
-		eventingOverrides, err = DecryptEventingOverrides(s.secretKey, operation.Ems.Overrides)
-		if err != nil {
-			return s.handleError(operation, err, log, fmt.Sprintf("decryptOverrides() call failed"))
-		}
+	// execute binding, the encrypted overrides are applied by the Stored_Overrides step
+	if operation.Ems.Instance.Provisioned {
+		return operation, 0, nil
+	}
+	if operation.Ems.BindingID == "" {
+		operation.Ems.BindingID = uuid.New().String()
+	}
+	respBinding, err := smCli.Bind(operation.Ems.Instance.InstanceKey(), operation.Ems.BindingID, nil, false)
+	if err != nil {
+		return s.handleError(operation, err, log, fmt.Sprintf("Bind() call failed"))
+	}
+	// get overrides
+	eventingOverrides, err := GetEventingCredentials(respBinding.Binding)
+	if err != nil {
+		return s.handleError(operation, err, log, fmt.Sprintf("getCredentials() call failed"))
+	}
+	encryptedOverrides, err := EncryptEventingOverrides(s.secretKey, eventingOverrides)
+	if err != nil {
+		return s.handleError(operation, err, log, fmt.Sprintf("encryptOverrides() call failed"))
+	}
+	operation.Ems.Overrides = encryptedOverrides
+	operation.Ems.Instance.Provisioned = true
+	operation.Ems.Instance.ProvisioningTriggered = false
+	// save the status
+	op, retry := s.operationManager.UpdateOperation(operation)
+	if retry > 0 {
+		log.Errorf("unable to update operation")
+		return operation, time.Second, nil
 	}
 
-	// append overrides
-	operation.InputCreator.AppendOverrides(components.Eventing, GetEventingOverrides(eventingOverrides))
-
-	return operation, 0, nil
+	return op, 0, nil
 }
 
 func (s *EmsBindStep) handleError(operation internal.ProvisioningOperation, err error, log logrus.FieldLogger, msg string) (internal.ProvisioningOperation, time.Duration, error) {
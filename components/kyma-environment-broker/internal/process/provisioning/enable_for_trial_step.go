@@ -7,6 +7,7 @@ import (
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 )
 
 type EnableForTrialPlanStep struct {
@@ -26,6 +27,10 @@ func (s *EnableForTrialPlanStep) Name() string {
 	return s.step.Name()
 }
 
+func (s *EnableForTrialPlanStep) Repeatable() bool {
+	return process.IsStepRepeatable(s.step)
+}
+
 func (s *EnableForTrialPlanStep) Run(operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
 	if broker.IsTrialPlan(operation.ProvisioningParameters.PlanID) {
 		log.Infof("Running step %s", s.Name())
@@ -6,8 +6,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtime/components"
-
 	"github.com/Azure/azure-sdk-for-go/services/eventhub/mgmt/2017-04-01/eventhub"
 	"github.com/sirupsen/logrus"
 
@@ -40,11 +38,13 @@ var _ Step = (*ProvisionAzureEventHubStep)(nil)
 type ProvisionAzureEventHubStep struct {
 	operationManager *process.ProvisionOperationManager
 	processazure.EventHub
+	secretKey string
 }
 
-func NewProvisionAzureEventHubStep(os storage.Operations, hyperscalerProvider azure.HyperscalerProvider, accountProvider hyperscaler.AccountProvider, ctx context.Context) *ProvisionAzureEventHubStep {
+func NewProvisionAzureEventHubStep(os storage.Operations, hyperscalerProvider azure.HyperscalerProvider, accountProvider hyperscaler.AccountProvider, ctx context.Context, secretKey string) *ProvisionAzureEventHubStep {
 	return &ProvisionAzureEventHubStep{
 		operationManager: process.NewProvisionOperationManager(os),
+		secretKey:        secretKey,
 		EventHub: processazure.EventHub{
 			HyperscalerProvider: hyperscalerProvider,
 			AccountProvider:     accountProvider,
@@ -129,11 +129,19 @@ func (p *ProvisionAzureEventHubStep) Run(operation internal.ProvisioningOperatio
 	kafkaEndpoint := extractEndpoint(accessKeys)
 	kafkaPassword := *accessKeys.PrimaryConnectionString
 
-	// append installation overrides
-	operation.InputCreator.AppendOverrides(components.KnativeEventing, getKnativeEventingOverrides())
-	operation.InputCreator.AppendOverrides(components.KnativeEventingKafka, getKafkaChannelOverrides(kafkaEndpoint, kafkaPort, k8sSecretNamespace, "$ConnectionString", kafkaPassword, kafkaProvider))
+	// the overrides are appended by the Stored_Overrides step, the namespace is not created again when the operation is requeued
+	kafkaOverrides, err := encryptOverrides(p.secretKey, getKafkaChannelOverrides(kafkaEndpoint, kafkaPort, k8sSecretNamespace, "$ConnectionString", kafkaPassword, kafkaProvider))
+	if err != nil {
+		// internal error, repeating doesn't solve the problem
+		return p.operationManager.OperationFailed(operation, fmt.Sprintf("Failed to encrypt Azure EventHubs overrides: %v", err))
+	}
+	operation.EventHub.Overrides = kafkaOverrides
 
-	return operation, 0, nil
+	updatedOperation, retry := p.operationManager.UpdateOperation(operation)
+	if retry > 0 {
+		log.Errorf("unable to store Azure EventHubs overrides")
+	}
+	return updatedOperation, retry, nil
 }
 
 func extractEndpoint(accessKeys eventhub.AccessKeys) string {
@@ -52,6 +52,9 @@ func Test_HappyPath(t *testing.T) {
 	op.UpdatedAt = time.Now()
 	op, when, err := step.Run(op, fixLogger())
 	require.NoError(t, err)
+	assert.NotEmpty(t, op.EventHub.Overrides)
+	op, _, err = NewStoredOverridesStep(memoryStorage.Operations(), testSecretKey).Run(op, fixLogger())
+	require.NoError(t, err)
 	provisionRuntimeInput, err := op.InputCreator.CreateProvisionRuntimeInput()
 	require.NoError(t, err)
 
@@ -89,6 +92,7 @@ func Test_StepsUnhappyPath(t *testing.T) {
 					azuretesting.NewFakeHyperscalerProvider(azuretesting.NewFakeNamespaceClientCreationError()),
 					accountProvider,
 					context.Background(),
+					testSecretKey,
 				)
 			},
 			wantRepeatOperation: true,
@@ -103,6 +107,7 @@ func Test_StepsUnhappyPath(t *testing.T) {
 					azuretesting.NewFakeHyperscalerProvider(azuretesting.NewFakeNamespaceClientListError()),
 					accountProvider,
 					context.Background(),
+					testSecretKey,
 				)
 			},
 			wantRepeatOperation: true,
@@ -117,6 +122,7 @@ func Test_StepsUnhappyPath(t *testing.T) {
 					azuretesting.NewFakeHyperscalerProvider(azuretesting.NewFakeNamespaceAccessKeysNil()),
 					accountProvider,
 					context.Background(),
+					testSecretKey,
 				)
 			},
 			wantRepeatOperation: true,
@@ -131,6 +137,7 @@ func Test_StepsUnhappyPath(t *testing.T) {
 					azuretesting.NewFakeHyperscalerProviderError(),
 					accountProvider,
 					context.Background(),
+					testSecretKey,
 				)
 			},
 			wantRepeatOperation: false,
@@ -145,6 +152,7 @@ func Test_StepsUnhappyPath(t *testing.T) {
 					azuretesting.NewFakeHyperscalerProvider(azuretesting.NewFakeNamespaceResourceGroupError()),
 					accountProvider,
 					context.Background(),
+					testSecretKey,
 				)
 			},
 			wantRepeatOperation: true,
@@ -368,7 +376,7 @@ func fixAccountProviderGardenerCredentialsError() *hyperscalerautomock.AccountPr
 
 func fixEventHubStep(memoryStorageOp storage.Operations, hyperscalerProvider azure.HyperscalerProvider,
 	accountProvider *hyperscalerautomock.AccountProvider) *ProvisionAzureEventHubStep {
-	return NewProvisionAzureEventHubStep(memoryStorageOp, hyperscalerProvider, accountProvider, context.Background())
+	return NewProvisionAzureEventHubStep(memoryStorageOp, hyperscalerProvider, accountProvider, context.Background(), testSecretKey)
 }
 
 func fixProvisioningOperation(t *testing.T, planID, region string) internal.ProvisioningOperation {
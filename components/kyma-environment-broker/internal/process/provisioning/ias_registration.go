@@ -17,12 +17,14 @@ import (
 type IASRegistrationStep struct {
 	operationManager *process.ProvisionOperationManager
 	bundleBuilder    ias.BundleBuilder
+	secretKey        string
 }
 
-func NewIASRegistrationStep(os storage.Operations, builder ias.BundleBuilder) *IASRegistrationStep {
+func NewIASRegistrationStep(os storage.Operations, builder ias.BundleBuilder, secretKey string) *IASRegistrationStep {
 	return &IASRegistrationStep{
 		operationManager: process.NewProvisionOperationManager(os),
 		bundleBuilder:    builder,
+		secretKey:        secretKey,
 	}
 }
 
@@ -31,6 +33,7 @@ func (s *IASRegistrationStep) Name() string {
 }
 
 func (s *IASRegistrationStep) Run(operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+	var monitoringOverrides []*gqlschema.ConfigEntryInput
 	for spID := range ias.ServiceProviderInputs {
 		spb, err := s.bundleBuilder.NewBundle(operation.InstanceID, spID)
 		if err != nil {
@@ -70,7 +73,7 @@ func (s *IASRegistrationStep) Run(operation internal.ProvisioningOperation, log
 
 			switch spID {
 			case ias.SPGrafanaID:
-				operation.InputCreator.AppendOverrides("monitoring", []*gqlschema.ConfigEntryInput{
+				monitoringOverrides = append(monitoringOverrides, []*gqlschema.ConfigEntryInput{
 					{
 						Key:    "grafana.env.GF_AUTH_GENERIC_OAUTH_CLIENT_ID",
 						Value:  secret.ClientID,
@@ -81,12 +84,27 @@ func (s *IASRegistrationStep) Run(operation internal.ProvisioningOperation, log
 						Value:  secret.ClientSecret,
 						Secret: ptr.Bool(true),
 					},
-				})
+				}...)
 			}
 		}
 	}
+	if len(monitoringOverrides) == 0 {
+		return operation, 0, nil
+	}
+
+	// the generated secrets are stored, so the service providers are not configured again when the operation is requeued
+	encrypted, err := encryptOverrides(s.secretKey, monitoringOverrides)
+	if err != nil {
+		log.Errorf("unable to encrypt IAS overrides: %s", err)
+		return s.operationManager.OperationFailed(operation, "encrypting IAS overrides failed")
+	}
+	operation.IAS.Overrides = encrypted
 
-	return operation, 0, nil
+	updatedOperation, retry := s.operationManager.UpdateOperation(operation)
+	if retry > 0 {
+		log.Error("unable to store IAS overrides")
+	}
+	return updatedOperation, retry, nil
 }
 
 func (s *IASRegistrationStep) handleError(operation internal.ProvisioningOperation, err error, log logrus.FieldLogger, msg string) (internal.ProvisioningOperation, time.Duration, error) {
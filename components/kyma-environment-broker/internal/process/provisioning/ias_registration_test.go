@@ -8,7 +8,6 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ias"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ias/automock"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/logger"
-	provisioningAutomock "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/provisioning/automock"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
@@ -50,33 +49,36 @@ func TestIASRegistration_Run(t *testing.T) {
 		bundleBuilder.On("NewBundle", iasInstanceID, inputID).Return(bundle, nil).Once()
 	}
 
-	inputCreatorMock := &provisioningAutomock.ProvisionerInputCreator{}
-	defer inputCreatorMock.AssertExpectations(t)
-	inputCreatorMock.On("AppendOverrides", "monitoring", []*gqlschema.ConfigEntryInput{
-		{
-			Key:    "grafana.env.GF_AUTH_GENERIC_OAUTH_CLIENT_ID",
-			Value:  iasClentID,
-			Secret: ptr.Bool(true),
-		},
-		{
-			Key:    "grafana.env.GF_AUTH_GENERIC_OAUTH_CLIENT_SECRET",
-			Value:  iasClientSecret,
-			Secret: ptr.Bool(true),
-		},
-	}).Return(nil).Once()
 	operation := internal.ProvisioningOperation{
 		Operation: internal.Operation{
+			ID:         "operation-id",
 			InstanceID: iasInstanceID,
 		},
-		InputCreator: inputCreatorMock,
 	}
+	err := memoryStorage.Operations().InsertProvisioningOperation(operation)
+	assert.NoError(t, err)
 
-	step := NewIASRegistrationStep(memoryStorage.Operations(), bundleBuilder)
+	step := NewIASRegistrationStep(memoryStorage.Operations(), bundleBuilder, testSecretKey)
 
 	// when
-	_, repeat, err := step.Run(operation, logger.NewLogDummy())
+	operation, repeat, err := step.Run(operation, logger.NewLogDummy())
 
 	// then
 	assert.Equal(t, time.Duration(0), repeat)
 	assert.NoError(t, err)
+
+	overrides, err := decryptOverrides(testSecretKey, operation.IAS.Overrides)
+	assert.NoError(t, err)
+	assert.Equal(t, []*gqlschema.ConfigEntryInput{
+		{
+			Key:    "grafana.env.GF_AUTH_GENERIC_OAUTH_CLIENT_ID",
+			Value:  iasClentID,
+			Secret: ptr.Bool(true),
+		},
+		{
+			Key:    "grafana.env.GF_AUTH_GENERIC_OAUTH_CLIENT_SECRET",
+			Value:  iasClientSecret,
+			Secret: ptr.Bool(true),
+		},
+	}, overrides)
 }
@@ -87,6 +87,12 @@ func (s *InitialisationStep) Name() string {
 	return "Provision_Initialization"
 }
 
+// Repeatable returns true, the initialisation step checks the operation state and prepares data
+// which is not stored in the storage, so it must be executed each time the operation is processed
+func (s *InitialisationStep) Repeatable() bool {
+	return true
+}
+
 func (s *InitialisationStep) Run(operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
 	if time.Since(operation.CreatedAt) > s.operationTimeout {
 		log.Infof("operation has reached the time limit: operation was created at: %s", operation.CreatedAt)
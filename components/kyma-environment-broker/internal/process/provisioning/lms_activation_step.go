@@ -9,6 +9,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/lms"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 )
 
 type LmsActivationStep struct {
@@ -27,6 +28,10 @@ func (s *LmsActivationStep) Name() string {
 	return s.step.Name()
 }
 
+func (s *LmsActivationStep) Repeatable() bool {
+	return process.IsStepRepeatable(s.step)
+}
+
 func (s *LmsActivationStep) Run(operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
 	if s.cfg.EnabledForGlobalAccounts != "" && !strings.EqualFold(s.cfg.EnabledForGlobalAccounts, "none") {
 		enabledForGA := false
@@ -41,17 +41,18 @@ type lmsCertStep struct {
 	LmsStep
 	provider            LmsClient
 	normalizationRegexp *regexp.Regexp
+	secretKey           string
 }
 
-func NewLmsCertificatesStep(certProvider LmsClient, os storage.Operations, isMandatory bool) *lmsCertStep {
+func NewLmsCertificatesStep(certProvider LmsClient, os storage.Operations, secretKey string) *lmsCertStep {
 	return &lmsCertStep{
 		LmsStep: LmsStep{
 			operationManager: process.NewProvisionOperationManager(os),
-			isMandatory:      isMandatory,
 			expirationTime:   lmsTimeout,
 		},
 		provider:            certProvider,
 		normalizationRegexp: regexp.MustCompile("[^a-zA-Z0-9]+"),
+		secretKey:           secretKey,
 	}
 }
 
@@ -63,6 +64,7 @@ func (s *lmsCertStep) Name() string {
 // 1. check if the tenant is ready
 // 2. request certificates
 // 3. poll CA and signed certificates
+// 4. store the fluent-bit overrides with the certificates, they are appended by the Stored_Overrides step
 func (s *lmsCertStep) Run(operation internal.ProvisioningOperation, l logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
 	if operation.Lms.Failed {
 		l.Info("LMS has failed, skipping")
@@ -89,7 +91,7 @@ func (s *lmsCertStep) Run(operation internal.ProvisioningOperation, l logrus.Fie
 		logger.Infof("LMS tenant not ready: elasticDNS=%v, kibanaDNS=%v", status.ElasticsearchDNSResolves, status.KibanaDNSResolves)
 		if time.Since(operation.Lms.RequestedAt) > lmsTimeout {
 			logger.Error("Setting LMS operation failed - tenant provisioning timed out")
-			return s.failLmsAndUpdate(operation, "LMS Tenant provisioning timeout")
+			return s.failLms(operation, "LMS Tenant provisioning timeout")
 		}
 		return operation, tenantReadyRetryInterval, nil
 	}
@@ -137,7 +139,7 @@ func (s *lmsCertStep) Run(operation internal.ProvisioningOperation, l logrus.Fie
 	})
 	if err != nil {
 		logger.Errorf("Setting LMS operation failed: %s", err.Error())
-		return s.failLmsAndUpdate(operation, "Getting LMS Signed Certificate timeout")
+		return s.failLms(operation, "Getting LMS Signed Certificate timeout")
 	}
 
 	// get CA cert
@@ -156,12 +158,10 @@ func (s *lmsCertStep) Run(operation internal.ProvisioningOperation, l logrus.Fie
 	})
 	if err != nil {
 		logger.Errorf("Setting LMS operation failed: %s", err.Error())
-		return s.failLmsAndUpdate(operation, "getting LMS CA certificate timeout")
+		return s.failLms(operation, "getting LMS CA certificate timeout")
 	}
 
-	operation.InputCreator.SetLabel(kibanaURLLabelKey, fmt.Sprintf("https://kibana.%s", tenantInfo.DNS))
-
-	operation.InputCreator.AppendOverrides("logging", []*gqlschema.ConfigEntryInput{
+	loggingOverrides, err := encryptOverrides(s.secretKey, []*gqlschema.ConfigEntryInput{
 		{Key: "fluent-bit.conf.Output.forward.enabled", Value: "true"},
 		{Key: "fluent-bit.conf.Output.forward.Match", Value: "kube.*"},
 
@@ -185,12 +185,22 @@ func (s *lmsCertStep) Run(operation internal.ProvisioningOperation, l logrus.Fie
 		//input should not contain dex logs as it contains sensitive data
 		{Key: "fluent-bit.conf.Input.Kubernetes.Exclude_Path", Value: "/var/log/containers/*_dex-*.log,/var/log/containers/*_kcproxy-*.log"},
 	})
-	return operation, 0, nil
+	if err != nil {
+		logger.Errorf("Unable to encrypt LMS overrides: %s", err)
+		return s.failLms(operation, "encrypting LMS overrides failed")
+	}
+	operation.Lms.KibanaURL = fmt.Sprintf("https://kibana.%s", tenantInfo.DNS)
+	operation.Lms.Overrides = loggingOverrides
+
+	updatedOperation, retry := s.operationManager.UpdateOperation(operation)
+	if retry > 0 {
+		logger.Error("Unable to store LMS overrides")
+	}
+	return updatedOperation, retry, nil
 }
 
 type LmsStep struct {
 	operationManager *process.ProvisionOperationManager
-	isMandatory      bool
 	expirationTime   time.Duration
 }
 
@@ -198,20 +208,21 @@ func (s *LmsStep) handleError(operation internal.ProvisioningOperation, log logr
 	log.Errorf("%s: %s", msg, err)
 	switch {
 	case kebError.IsTemporaryError(err):
-		return s.operationManager.RetryOperation(operation, msg, 10*time.Second, time.Minute*30, log)
+		if time.Since(operation.UpdatedAt) < lmsTimeout {
+			log.Infof("Retrying for %s in %s steps", lmsTimeout, 10*time.Second)
+			return operation, 10 * time.Second, nil
+		}
+		return s.failLms(operation, msg)
 	default:
 		if since < s.expirationTime {
 			return operation, tenantReadyRetryInterval, nil
 		}
-		return s.failLmsAndUpdate(operation, "getting LMS tenant failed")
+		return s.failLms(operation, "getting LMS tenant failed")
 	}
 }
 
-func (s *LmsStep) failLmsAndUpdate(operation internal.ProvisioningOperation, msg string) (internal.ProvisioningOperation, time.Duration, error) {
+// failLms gives up the step, the process manager fails the operation or skips the step if it is optional
+func (s *LmsStep) failLms(operation internal.ProvisioningOperation, msg string) (internal.ProvisioningOperation, time.Duration, error) {
 	operation.Lms.Failed = true
-	if s.isMandatory {
-		return s.operationManager.OperationFailed(operation, msg)
-	}
-	modifiedOp, retry := s.operationManager.UpdateOperation(operation)
-	return modifiedOp, retry, nil
+	return operation, 0, process.NewStepFailedError(msg)
 }
@@ -7,8 +7,10 @@ import (
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/lms"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -16,7 +18,7 @@ import (
 func TestCertStep_RunFreshOperation(t *testing.T) {
 	// given
 	repo := storage.NewMemoryStorage().Operations()
-	svc := NewLmsCertificatesStep(nil, repo, false)
+	svc := NewLmsCertificatesStep(nil, repo, testSecretKey)
 	// a fresh operation
 	operation := internal.ProvisioningOperation{
 		Operation: internal.Operation{
@@ -35,7 +37,7 @@ func TestCertStep_Run(t *testing.T) {
 	// given
 	cli, tID := newFakeClientWithTenant(0)
 	repo := storage.NewMemoryStorage().Operations()
-	svc := NewLmsCertificatesStep(cli, repo, false)
+	svc := NewLmsCertificatesStep(cli, repo, testSecretKey)
 	operation := internal.ProvisioningOperation{
 		Operation: internal.Operation{
 			ProvisioningParameters: internal.ProvisioningParameters{},
@@ -59,63 +61,60 @@ func TestCertStep_Run(t *testing.T) {
 }
 
 func TestCertStep_TenantNotReady(t *testing.T) {
-	runForOptionalAndMandatory(t, func(t *testing.T, isMandatory bool, a asserter) {
-		// given
-		cli, tID := newFakeClientWithTenant(time.Hour)
-		repo := storage.NewMemoryStorage().Operations()
-		svc := NewLmsCertificatesStep(cli, repo, isMandatory)
-		operation := internal.ProvisioningOperation{
-			Operation: internal.Operation{
-				ProvisioningParameters: internal.ProvisioningParameters{},
-				InstanceDetails: internal.InstanceDetails{Lms: internal.LMS{
-					TenantID:    tID,
-					RequestedAt: time.Now(),
-				}},
-			},
-		}
-		repo.InsertProvisioningOperation(operation)
+	// given
+	cli, tID := newFakeClientWithTenant(time.Hour)
+	repo := storage.NewMemoryStorage().Operations()
+	svc := NewLmsCertificatesStep(cli, repo, testSecretKey)
+	operation := internal.ProvisioningOperation{
+		Operation: internal.Operation{
+			ProvisioningParameters: internal.ProvisioningParameters{},
+			InstanceDetails: internal.InstanceDetails{Lms: internal.LMS{
+				TenantID:    tID,
+				RequestedAt: time.Now(),
+			}},
+		},
+	}
+	repo.InsertProvisioningOperation(operation)
 
-		// when
-		op, duration, err := svc.Run(operation, fixLogger())
+	// when
+	op, duration, err := svc.Run(operation, fixLogger())
 
-		// then
-		require.NoError(t, err)
-		assert.NotZero(t, duration.Seconds())
-		assert.False(t, op.Lms.Failed)
+	// then
+	require.NoError(t, err)
+	assert.NotZero(t, duration.Seconds())
+	assert.False(t, op.Lms.Failed)
 
-		// do not expect call to LMS
-		assert.False(t, cli.IsCertRequestedForTenant(tID))
-	})
+	// do not expect call to LMS
+	assert.False(t, cli.IsCertRequestedForTenant(tID))
 }
 
 func TestCertStep_TenantNotReadyTimeout(t *testing.T) {
-	runForOptionalAndMandatory(t, func(t *testing.T, isMandatory bool, a asserter) {
-		// given
-		cli, tID := newFakeClientWithTenant(time.Hour)
-		repo := storage.NewMemoryStorage().Operations()
-		svc := NewLmsCertificatesStep(cli, repo, isMandatory)
-		operation := internal.ProvisioningOperation{
-			Operation: internal.Operation{
-				ProvisioningParameters: internal.ProvisioningParameters{},
-				InstanceDetails: internal.InstanceDetails{Lms: internal.LMS{
-					TenantID:    tID,
-					RequestedAt: time.Now().Add(-10 * time.Hour), // very old
-				}},
-			},
-		}
-		repo.InsertProvisioningOperation(operation)
+	// given
+	cli, tID := newFakeClientWithTenant(time.Hour)
+	repo := storage.NewMemoryStorage().Operations()
+	svc := NewLmsCertificatesStep(cli, repo, testSecretKey)
+	operation := internal.ProvisioningOperation{
+		Operation: internal.Operation{
+			ProvisioningParameters: internal.ProvisioningParameters{},
+			InstanceDetails: internal.InstanceDetails{Lms: internal.LMS{
+				TenantID:    tID,
+				RequestedAt: time.Now().Add(-10 * time.Hour), // very old
+			}},
+		},
+	}
+	repo.InsertProvisioningOperation(operation)
 
-		// when
-		op, duration, err := svc.Run(operation, fixLogger())
+	// when
+	op, duration, err := svc.Run(operation, fixLogger())
 
-		// then
-		a.AssertError(t, err)
-		assert.Zero(t, duration.Seconds())
-		assert.True(t, op.Lms.Failed)
+	// then
+	assert.True(t, process.IsStepFailedError(err))
+	assert.Zero(t, duration.Seconds())
+	assert.True(t, op.Lms.Failed)
+	assert.NotEqual(t, domain.Failed, op.State)
 
-		// do not expect call to LMS
-		assert.False(t, cli.IsCertRequestedForTenant(tID))
-	})
+	// do not expect call to LMS
+	assert.False(t, cli.IsCertRequestedForTenant(tID))
 }
 
 func TestLmsStepsHappyPath(t *testing.T) {
@@ -123,9 +122,9 @@ func TestLmsStepsHappyPath(t *testing.T) {
 	lmsClient := lms.NewFakeClient(0)
 	opRepo := storage.NewMemoryStorage().Operations()
 	tRepo := storage.NewMemoryStorage().LMSTenants()
-	certStep := NewLmsCertificatesStep(lmsClient, opRepo, false)
+	certStep := NewLmsCertificatesStep(lmsClient, opRepo, testSecretKey)
 	tManager := lms.NewTenantManager(tRepo, lmsClient, fixLogger())
-	tenantStep := NewProvideLmsTenantStep(tManager, opRepo, "eu", false)
+	tenantStep := NewProvideLmsTenantStep(tManager, opRepo, "eu")
 
 	inputCreator := newInputCreator()
 	operation := internal.ProvisioningOperation{
@@ -153,6 +152,13 @@ func TestLmsStepsHappyPath(t *testing.T) {
 	require.Zero(t, when)
 	lmsClient.IsCertRequestedForTenant(op.Lms.TenantID)
 
+	// when
+	op, when, err = NewStoredOverridesStep(opRepo, testSecretKey).Run(op, fixLogger())
+
+	// then
+	require.NoError(t, err)
+	require.Zero(t, when)
+
 	inputCreator.AssertOverride(t, "logging", gqlschema.ConfigEntryInput{
 		Key: "fluent-bit.conf.Output.forward.enabled", Value: "true"})
 	inputCreator.AssertOverride(t, "logging", gqlschema.ConfigEntryInput{
@@ -220,6 +226,10 @@ func (c *simpleInputCreator) CreateUpgradeRuntimeInput() (gqlschema.UpgradeRunti
 	return gqlschema.UpgradeRuntimeInput{}, nil
 }
 
+func (c *simpleInputCreator) CreateUpgradeShootInput() (gqlschema.UpgradeShootInput, error) {
+	return gqlschema.UpgradeShootInput{}, nil
+}
+
 func (c *simpleInputCreator) SetProvisioningParameters(params internal.ProvisioningParameters) internal.ProvisionerInputCreator {
 	return c
 }
@@ -259,43 +269,3 @@ func (c *simpleInputCreator) AssertLabel(t *testing.T, key, expectedValue string
 func (c *simpleInputCreator) AssertEnabledComponent(t *testing.T, componentName string) {
 	assert.Contains(t, c.enabledComponents, componentName)
 }
-
-type asserter interface {
-	AssertError(t *testing.T, err error)
-}
-
-type asserterForOptional struct {
-}
-
-func (asserterForOptional) AssertError(t *testing.T, err error) {
-	assert.NoError(t, err)
-}
-
-type asserterForMandatory struct {
-}
-
-func (asserterForMandatory) AssertError(t *testing.T, err error) {
-	assert.Error(t, err)
-}
-
-func errorAsserter(isMandatory bool) asserter {
-	if isMandatory {
-		return asserterForMandatory{}
-	} else {
-		return asserterForOptional{}
-	}
-}
-
-func runForOptionalAndMandatory(t *testing.T, fn func(t *testing.T, isMandatory bool, a asserter)) {
-	for _, tc := range []struct {
-		name        string
-		isMandatory bool
-	}{
-		{name: "step mandatory", isMandatory: true},
-		{name: "step optional", isMandatory: false},
-	} {
-		t.Run(tc.name, func(t *testing.T) {
-			fn(t, tc.isMandatory, errorAsserter(tc.isMandatory))
-		})
-	}
-}
@@ -24,11 +24,10 @@ type provideLmsTenantStep struct {
 	regionOverride   string
 }
 
-func NewProvideLmsTenantStep(tp LmsTenantProvider, repo storage.Operations, regionOverride string, isMandatory bool) *provideLmsTenantStep {
+func NewProvideLmsTenantStep(tp LmsTenantProvider, repo storage.Operations, regionOverride string) *provideLmsTenantStep {
 	return &provideLmsTenantStep{
 		LmsStep: LmsStep{
 			operationManager: process.NewProvisionOperationManager(repo),
-			isMandatory:      isMandatory,
 			expirationTime:   3 * time.Minute,
 		},
 		operationManager: process.NewProvisionOperationManager(repo),
@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -15,7 +17,7 @@ func TestProvideLmsTenantStep_TenantProviderWithRetry(t *testing.T) {
 	// given
 	now := time.Now()
 	opRepo := storage.NewMemoryStorage().Operations()
-	tenantStep := NewProvideLmsTenantStep(fakeErrorTenantProvider{}, opRepo, "eu", true)
+	tenantStep := NewProvideLmsTenantStep(fakeErrorTenantProvider{}, opRepo, "eu")
 
 	inputCreator := newInputCreator()
 	operation := internal.ProvisioningOperation{
@@ -37,31 +39,30 @@ func TestProvideLmsTenantStep_TenantProviderWithRetry(t *testing.T) {
 }
 
 func TestProvideLmsTenantStep_TenantProviderWithError(t *testing.T) {
-	runForOptionalAndMandatory(t, func(t *testing.T, isMandatory bool, a asserter) {
-		// given
-		now := time.Now().Add(-10 * time.Hour)
-		opRepo := storage.NewMemoryStorage().Operations()
-		tenantStep := NewProvideLmsTenantStep(fakeErrorTenantProvider{}, opRepo, "eu", isMandatory)
+	// given
+	now := time.Now().Add(-10 * time.Hour)
+	opRepo := storage.NewMemoryStorage().Operations()
+	tenantStep := NewProvideLmsTenantStep(fakeErrorTenantProvider{}, opRepo, "eu")
 
-		inputCreator := newInputCreator()
-		operation := internal.ProvisioningOperation{
-			Operation: internal.Operation{
-				UpdatedAt:              now,
-				ProvisioningParameters: internal.ProvisioningParameters{Parameters: internal.ProvisioningParametersDTO{Name: "awesome"}},
-				InstanceDetails:        internal.InstanceDetails{Lms: internal.LMS{}},
-			},
-			InputCreator: inputCreator,
-		}
-		opRepo.InsertProvisioningOperation(operation)
+	inputCreator := newInputCreator()
+	operation := internal.ProvisioningOperation{
+		Operation: internal.Operation{
+			UpdatedAt:              now,
+			ProvisioningParameters: internal.ProvisioningParameters{Parameters: internal.ProvisioningParametersDTO{Name: "awesome"}},
+			InstanceDetails:        internal.InstanceDetails{Lms: internal.LMS{}},
+		},
+		InputCreator: inputCreator,
+	}
+	opRepo.InsertProvisioningOperation(operation)
 
-		// when
-		op, when, err := tenantStep.Run(operation, fixLogger())
+	// when
+	op, when, err := tenantStep.Run(operation, fixLogger())
 
-		// then
-		a.AssertError(t, err)
-		assert.Zero(t, when)
-		assert.True(t, op.Lms.Failed)
-	})
+	// then
+	assert.True(t, process.IsStepFailedError(err))
+	assert.Zero(t, when)
+	assert.True(t, op.Lms.Failed)
+	assert.NotEqual(t, domain.Failed, op.State)
 }
 
 type fakeErrorTenantProvider struct {
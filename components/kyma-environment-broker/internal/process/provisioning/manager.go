@@ -2,14 +2,18 @@ package provisioning
 
 import (
 	"context"
+	"fmt"
 	"sort"
+	"sync"
 	"time"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
@@ -21,7 +25,12 @@ type Step interface {
 type Manager struct {
 	log              logrus.FieldLogger
 	steps            map[int][]Step
+	policies         map[string]process.StepPolicy
 	operationStorage storage.Operations
+	operationManager *process.ProvisionOperationManager
+
+	// parallelSteps enables running steps with the same weight concurrently
+	parallelSteps bool
 
 	publisher event.Publisher
 }
@@ -31,12 +40,21 @@ func NewManager(storage storage.Operations, pub event.Publisher, logger logrus.F
 		log:              logger,
 		operationStorage: storage,
 		steps:            make(map[int][]Step, 0),
+		policies:         make(map[string]process.StepPolicy),
+		operationManager: process.NewProvisionOperationManager(storage),
 		publisher:        pub,
 	}
 }
 
 func (m *Manager) InitStep(step Step) {
-	m.AddStep(0, step)
+	m.steps[0] = append(m.steps[0], step)
+}
+
+// EnableParallelSteps makes the manager run steps with the same weight concurrently.
+// Changes made by the steps are merged, the operation storage should be able to merge conflicting
+// updates made by the steps (see MergingOperationStorage).
+func (m *Manager) EnableParallelSteps() {
+	m.parallelSteps = true
 }
 
 func (m *Manager) AddStep(weight int, step Step) {
@@ -46,19 +64,141 @@ func (m *Manager) AddStep(weight int, step Step) {
 	m.steps[weight] = append(m.steps[weight], step)
 }
 
+// AddStepWithPolicy adds the step which is executed according to the given policy
+func (m *Manager) AddStepWithPolicy(weight int, step Step, policy process.StepPolicy) {
+	m.AddStep(weight, step)
+	m.policies[step.Name()] = policy
+}
+
+// AddPipelineStep adds the step of the pipeline, it fails if the step is not a provisioning step
+func (m *Manager) AddPipelineStep(weight int, step process.NamedStep, policy process.StepPolicy) error {
+	processStep, ok := step.(Step)
+	if !ok {
+		return fmt.Errorf("step %s is not a provisioning step", step.Name())
+	}
+	m.AddStepWithPolicy(weight, processStep, policy)
+	return nil
+}
+
+// runStep executes the step according to its policy. Attempts of steps with a policy are recorded in the operation,
+// a step which exceeded its time limit or retry budget is given up.
 func (m *Manager) runStep(step Step, operation internal.ProvisioningOperation, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+	policy := m.policies[step.Name()]
+	if planID := operation.ProvisioningParameters.PlanID; !policy.EnabledForPlan(planID) {
+		logger.Infof("Skipping step %s, it is not enabled for the plan %s", step.Name(), planID)
+		return operation, 0, nil
+	}
+	if policy.IsZero() {
+		processedOperation, when, err := m.runStepOnce(step, operation, logger)
+		if process.IsStepFailedError(err) {
+			return m.giveUpStep(step, operation, processedOperation, policy, operation.StepAttempt(step.Name()), err.Error(), err, logger)
+		}
+		return processedOperation, when, err
+	}
+
+	now := time.Now()
+	attempt := operation.StepAttempt(step.Name())
+	if attempt.StartedAt.IsZero() {
+		attempt.StartedAt = now
+	}
+	if reason := policy.Exhausted(attempt, now); reason != "" {
+		logger.Errorf("Step %s, it was started at %s", reason, attempt.StartedAt)
+		return m.giveUpStep(step, operation, operation, policy, attempt, fmt.Sprintf("step %s %s", step.Name(), reason), nil, logger)
+	}
+	if wait := attempt.NextAttemptAt.Sub(now); wait > 0 {
+		logger.Infof("Step is backing off, next attempt in %s", wait)
+		return operation, wait, nil
+	}
+	attempt.Count++
+	attempt.LastAttemptAt = now
+	attempt.NextAttemptAt = time.Time{}
+	operation.SetStepAttempt(step.Name(), attempt)
+
+	processedOperation, when, err := m.runStepOnce(step, operation, logger)
+	switch {
+	case process.IsStepFailedError(err):
+		attempt.LastError = err.Error()
+		return m.giveUpStep(step, operation, processedOperation, policy, attempt, err.Error(), err, logger)
+	case err != nil && processedOperation.State != domain.Failed && policy.MaxAttempts > 0:
+		attempt.LastError = err.Error()
+		if reason := policy.Exhausted(attempt, time.Now()); reason != "" {
+			return m.giveUpStep(step, operation, processedOperation, policy, attempt, fmt.Sprintf("step %s %s: %s", step.Name(), reason, err), err, logger)
+		}
+		logger.Warnf("Step failed, it will be retried (attempt %d of %d): %s", attempt.Count, policy.MaxAttempts, err)
+		when = time.Second
+	case err != nil:
+		return m.giveUpStep(step, operation, processedOperation, policy, attempt, fmt.Sprintf("step %s failed: %s", step.Name(), err), err, logger)
+	case processedOperation.State == domain.Failed:
+		return m.giveUpStep(step, operation, processedOperation, policy, attempt, processedOperation.Description, nil, logger)
+	case when == 0 || processedOperation.State != domain.InProgress:
+		processedOperation.SetStepAttempt(step.Name(), attempt)
+		return processedOperation, when, nil
+	}
+
+	if delay := policy.Delay(attempt.Count); delay > when {
+		when = delay
+	}
+	if policy.Backoff > 0 {
+		attempt.NextAttemptAt = now.Add(when)
+	}
+	processedOperation.SetStepAttempt(step.Name(), attempt)
+	processedOperation, retry := m.operationManager.UpdateOperation(processedOperation)
+	if retry > 0 {
+		return processedOperation, retry, nil
+	}
+	return processedOperation, when, nil
+}
+
+// giveUpStep fails the operation if the step is mandatory. The optional step is skipped: the state of the operation
+// from before the step execution is restored and the processing continues with the next step. The outcome is decided
+// before the operation is stored, unless the step failed the operation itself instead of returning StepFailedError.
+func (m *Manager) giveUpStep(step Step, operation, processedOperation internal.ProvisioningOperation, policy process.StepPolicy, attempt internal.StepAttempt, reason string, stepErr error, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+	if processedOperation.Operation.ID == "" {
+		// the step failed without returning the operation
+		processedOperation = operation
+	}
+	if attempt.LastError == "" {
+		attempt.LastError = reason
+	}
+	if !policy.Optional {
+		processedOperation.SetStepAttempt(step.Name(), attempt)
+		if processedOperation.State == domain.Failed {
+			return processedOperation, 0, stepErr
+		}
+		failedOperation, when, err := m.operationManager.OperationFailed(processedOperation, reason)
+		if stepErr != nil && when == 0 {
+			err = stepErr
+		}
+		return failedOperation, when, err
+	}
+
+	logger.Warnf("Skipping optional step: %s", reason)
+	attempt.Skipped = true
+	processedOperation.State = operation.State
+	processedOperation.Description = operation.Description
+	processedOperation.SetStepAttempt(step.Name(), attempt)
+	skippedOperation, retry := m.operationManager.UpdateOperation(processedOperation)
+	if retry > 0 {
+		return skippedOperation, retry, nil
+	}
+	return skippedOperation, 0, nil
+}
+
+func (m *Manager) runStepOnce(step Step, operation internal.ProvisioningOperation, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
 	start := time.Now()
 	processedOperation, when, err := step.Run(operation, logger)
-	m.publisher.Publish(context.TODO(), process.ProvisioningStepProcessed{
-		OldOperation: operation,
-		Operation:    processedOperation,
-		StepProcessed: process.StepProcessed{
-			StepName: step.Name(),
-			Duration: time.Since(start),
-			When:     when,
-			Error:    err,
-		},
-	})
+	ev := process.ProvisioningStepProcessed{
+		OldOperation:  operation,
+		Operation:     processedOperation,
+		StepProcessed: process.NewStepProcessed(operation.ID, step.Name(), start, when, err),
+	}
+	if processedOperation.ID == "" {
+		m.publisher.Publish(context.TODO(), ev)
+		return processedOperation, when, err
+	}
+	// the event is stored in one transaction with the next update of the operation, so it is not lost when KEB stops,
+	// see storePendingEvents
+	processedOperation.AddPendingEvents(event.Stage(m.publisher, ev))
 	return processedOperation, when, err
 }
 
@@ -73,12 +213,34 @@ func (m *Manager) Execute(operationID string) (time.Duration, error) {
 	processedOperation := *operation
 
 	logOperation := m.log.WithFields(logrus.Fields{"operation": operationID, "instanceID": operation.InstanceID, "planID": operation.ProvisioningParameters.PlanID})
+	// the events of the steps which were not stored with the updates of the operation made by the steps
+	defer func() { m.storePendingEvents(processedOperation, logOperation) }()
 
 	logOperation.Info("Start process operation steps")
 	for _, weightStep := range m.sortWeight() {
 		steps := m.steps[weightStep]
+		if m.parallelSteps && canRunInParallel(steps) {
+			processedOperation, when, err = m.runStepsInParallel(steps, processedOperation, logOperation)
+			if err != nil {
+				logOperation.Errorf("Process operation failed: %s", err)
+				return 0, err
+			}
+			if processedOperation.State != domain.InProgress {
+				logOperation.Infof("Operation %q got status %s. Process finished.", operation.ID, processedOperation.State)
+				return 0, nil
+			}
+			if when != 0 {
+				logOperation.Infof("Process operation will be repeated in %s ...", when)
+				return when, nil
+			}
+			continue
+		}
 		for _, step := range steps {
 			logStep := logOperation.WithField("step", step.Name())
+			if processedOperation.IsStepFinished(step.Name()) && !process.IsStepRepeatable(step) {
+				logStep.Info("Step already finished, skipping")
+				continue
+			}
 			logStep.Infof("Start step")
 
 			processedOperation, when, err = m.runStep(step, processedOperation, logStep)
@@ -92,6 +254,10 @@ func (m *Manager) Execute(operationID string) (time.Duration, error) {
 			}
 			if when == 0 {
 				logStep.Info("Process operation successful")
+				processedOperation, when = m.finishStep(step, processedOperation, logStep)
+				if when != 0 {
+					return when, nil
+				}
 				continue
 			}
 
@@ -104,6 +270,141 @@ func (m *Manager) Execute(operationID string) (time.Duration, error) {
 	return 0, nil
 }
 
+// storePendingEvents stores the events of the processed steps which were not stored with the updates of the operation,
+// the operation is updated only if there are such events
+func (m *Manager) storePendingEvents(operation internal.ProvisioningOperation, logger logrus.FieldLogger) internal.ProvisioningOperation {
+	err := event.StorePending(m.publisher, operation.PendingEvents(), func() error {
+		updated, err := m.operationStorage.UpdateProvisioningOperation(operation)
+		if err != nil {
+			return err
+		}
+		operation = *updated
+		return nil
+	})
+	if err != nil {
+		logger.Warnf("unable to store the events of the steps with the operation, the events were stored separately: %s", err)
+		operation.TakePendingEvents()
+	}
+	return operation
+}
+
+// finishStep stores the information that the step was successfully processed,
+// so the step is not executed again when the operation is retried
+func (m *Manager) finishStep(step Step, operation internal.ProvisioningOperation, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration) {
+	if process.IsStepRepeatable(step) {
+		return operation, 0
+	}
+	operation.FinishStep(step.Name())
+	updated, err := m.operationStorage.UpdateProvisioningOperation(operation)
+	if err != nil {
+		logger.Errorf("Cannot save finished step: %s", err)
+		return operation, time.Second
+	}
+	return *updated, 0
+}
+
+// runStepsInParallel runs the given steps concurrently, each step gets its own copy of the operation.
+// Operations returned by the steps are merged and stored, errors returned by the steps are combined.
+// If any step must be repeated, the shortest requested time is returned.
+func (m *Manager) runStepsInParallel(steps []Step, operation internal.ProvisioningOperation, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+	type stepResult struct {
+		step      Step
+		operation internal.ProvisioningOperation
+		when      time.Duration
+		err       error
+	}
+
+	var toRun []Step
+	for _, step := range steps {
+		if operation.IsStepFinished(step.Name()) {
+			logger.WithField("step", step.Name()).Info("Step already finished, skipping")
+			continue
+		}
+		toRun = append(toRun, step)
+	}
+	if len(toRun) == 0 {
+		return operation, 0, nil
+	}
+	if len(operation.PendingEvents()) > 0 {
+		// every step gets the copy of the operation, the events would be stored by each of them
+		operation = m.storePendingEvents(operation, logger)
+	}
+
+	results := make([]stepResult, len(toRun))
+	var wg sync.WaitGroup
+	for i, step := range toRun {
+		wg.Add(1)
+		go func(i int, step Step) {
+			defer wg.Done()
+			logStep := logger.WithField("step", step.Name())
+			logStep.Infof("Start step")
+			processedOperation, when, err := m.runStep(step, operation, logStep)
+			results[i] = stepResult{step: step, operation: processedOperation, when: when, err: err}
+		}(i, step)
+	}
+	wg.Wait()
+
+	latest, err := m.operationStorage.GetProvisioningOperationByID(operation.ID)
+	if err != nil {
+		logger.Errorf("Cannot fetch operation from storage: %s", err)
+		return operation, time.Second, nil
+	}
+
+	var result *multierror.Error
+	var when time.Duration
+	merged := *latest
+	for _, r := range results {
+		logStep := logger.WithField("step", r.step.Name())
+		if r.err != nil {
+			logStep.Errorf("Step failed: %s", r.err)
+			result = multierror.Append(result, errors.Wrapf(r.err, "step %s failed", r.step.Name()))
+		}
+		merged, err = mergeOperations(operation, merged, r.operation)
+		if err != nil {
+			logStep.Errorf("Cannot merge operation: %s", err)
+			result = multierror.Append(result, errors.Wrapf(err, "while merging the operation processed by step %s", r.step.Name()))
+			continue
+		}
+		if r.err == nil && r.when != 0 && (when == 0 || r.when < when) {
+			when = r.when
+		}
+	}
+	if result != nil {
+		return merged, 0, result.ErrorOrNil()
+	}
+	if merged.State != domain.InProgress {
+		return merged, 0, nil
+	}
+
+	for _, r := range results {
+		if r.when == 0 {
+			logger.WithField("step", r.step.Name()).Info("Process operation successful")
+			merged.FinishStep(r.step.Name())
+		}
+	}
+	updated, err := m.operationStorage.UpdateProvisioningOperation(merged)
+	if err != nil {
+		logger.Errorf("Cannot save merged operation: %s", err)
+		return merged, time.Second, nil
+	}
+
+	return *updated, when, nil
+}
+
+// canRunInParallel returns true if the given steps can be executed concurrently, repeatable steps
+// prepare the runtime input which cannot be modified concurrently
+func canRunInParallel(steps []Step) bool {
+	if len(steps) < 2 {
+		return false
+	}
+	for _, step := range steps {
+		if process.IsStepRepeatable(step) {
+			return false
+		}
+	}
+	return true
+}
+
 func (m *Manager) sortWeight() []int {
 	var weight []int
 	for w := range m.steps {
@@ -17,6 +17,7 @@ import (
 	"github.com/pivotal-cf/brokerapi/v7/domain"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/util/wait"
 )
 
@@ -29,9 +30,11 @@ const (
 func TestManager_Execute(t *testing.T) {
 	for name, tc := range map[string]struct {
 		operationID            string
+		finishedSteps          []string
 		expectedError          bool
 		expectedRepeat         time.Duration
 		expectedDesc           string
+		expectedFinishedSteps  []string
 		expectedNumberOfEvents int
 	}{
 		"operation successful": {
@@ -39,8 +42,18 @@ func TestManager_Execute(t *testing.T) {
 			expectedError:          false,
 			expectedRepeat:         time.Duration(0),
 			expectedDesc:           "init one two final",
+			expectedFinishedSteps:  []string{"init", "one", "two", "final"},
 			expectedNumberOfEvents: 4,
 		},
+		"operation resumed": {
+			operationID:            operationIDSuccess,
+			finishedSteps:          []string{"init", "one"},
+			expectedError:          false,
+			expectedRepeat:         time.Duration(0),
+			expectedDesc:           "two final",
+			expectedFinishedSteps:  []string{"init", "one", "two", "final"},
+			expectedNumberOfEvents: 2,
+		},
 		"operation failed": {
 			operationID:            operationIDFailed,
 			expectedError:          true,
@@ -51,6 +64,7 @@ func TestManager_Execute(t *testing.T) {
 			expectedError:          false,
 			expectedRepeat:         time.Duration(10),
 			expectedDesc:           "init",
+			expectedFinishedSteps:  nil,
 			expectedNumberOfEvents: 1,
 		},
 	} {
@@ -58,7 +72,9 @@ func TestManager_Execute(t *testing.T) {
 			// given
 			log := logrus.New()
 			memoryStorage := storage.NewMemoryStorage()
-			err := memoryStorage.Operations().InsertProvisioningOperation(fixProvisionOperation(tc.operationID))
+			operation := fixProvisionOperation(tc.operationID)
+			operation.FinishedSteps = tc.finishedSteps
+			err := memoryStorage.Operations().InsertProvisioningOperation(operation)
 			assert.NoError(t, err)
 
 			sInit := testStep{name: "init", storage: memoryStorage.Operations()}
@@ -68,7 +84,7 @@ func TestManager_Execute(t *testing.T) {
 
 			eventBroker := event.NewPubSub(logrus.New())
 			eventCollector := &collectingEventHandler{}
-			eventBroker.Subscribe(process.ProvisioningStepProcessed{}, eventCollector.OnEvent)
+			eventBroker.Subscribe("collector", process.ProvisioningStepProcessed{}, eventCollector.OnEvent)
 
 			manager := NewManager(memoryStorage.Operations(), eventBroker, log)
 			manager.InitStep(&sInit)
@@ -90,6 +106,160 @@ func TestManager_Execute(t *testing.T) {
 				operation, err := memoryStorage.Operations().GetOperationByID(tc.operationID)
 				assert.NoError(t, err)
 				assert.Equal(t, tc.expectedDesc, strings.Trim(operation.Description, " "))
+				assert.Equal(t, tc.expectedFinishedSteps, operation.FinishedSteps)
+			}
+
+			assert.NoError(t, wait.PollImmediate(20*time.Millisecond, 2*time.Second, func() (bool, error) {
+				return len(eventCollector.Events) == tc.expectedNumberOfEvents, nil
+			}))
+		})
+	}
+}
+
+func TestManager_ExecuteWithOutbox(t *testing.T) {
+	// given
+	log := logrus.New()
+	memoryStorage := storage.NewMemoryStorage()
+	operation := fixProvisionOperation(operationIDSuccess)
+	err := memoryStorage.Operations().InsertProvisioningOperation(operation)
+	assert.NoError(t, err)
+
+	eventBroker := event.NewOutbox(memoryStorage.OutboxEvents(), event.OutboxConfig{BatchSize: 10, MaxAttempts: 3}, log)
+	eventCollector := &collectingEventHandler{}
+	eventBroker.Subscribe("collector", process.ProvisioningStepProcessed{}, eventCollector.OnEvent)
+
+	manager := NewManager(memoryStorage.Operations(), eventBroker, log)
+	manager.InitStep(&testStep{name: "init", storage: memoryStorage.Operations()})
+	manager.AddStep(1, &testStep{name: "one", storage: memoryStorage.Operations()})
+
+	// when
+	_, err = manager.Execute(operationIDSuccess)
+	assert.NoError(t, err)
+	delivered, deliverErr := eventBroker.DeliverPending()
+
+	// then
+	assert.NoError(t, deliverErr)
+	assert.Equal(t, 2, delivered)
+	assert.Len(t, eventCollector.Events, 2)
+
+	stored, err := memoryStorage.Operations().GetProvisioningOperationByID(operationIDSuccess)
+	assert.NoError(t, err)
+	assert.Equal(t, "init one", strings.Trim(stored.Description, " "))
+	// the events are stored with the updates made by the steps and by finishing the steps, the operation
+	// is not updated only to store the events
+	assert.Equal(t, operation.Version+4, stored.Version)
+}
+
+func TestManager_ExecuteWithOutboxStoresRemainingEvents(t *testing.T) {
+	// given
+	log := logrus.New()
+	memoryStorage := storage.NewMemoryStorage()
+	operation := fixProvisionOperation(operationIDSuccess)
+	err := memoryStorage.Operations().InsertProvisioningOperation(operation)
+	assert.NoError(t, err)
+
+	eventBroker := event.NewOutbox(memoryStorage.OutboxEvents(), event.OutboxConfig{BatchSize: 10, MaxAttempts: 3}, log)
+	eventCollector := &collectingEventHandler{}
+	eventBroker.Subscribe("collector", process.ProvisioningStepProcessed{}, eventCollector.OnEvent)
+
+	manager := NewManager(memoryStorage.Operations(), eventBroker, log)
+	manager.InitStep(&readOnlyStep{name: "init"})
+	manager.AddStep(1, &readOnlyStep{name: "one"})
+
+	// when
+	_, err = manager.Execute(operationIDSuccess)
+	assert.NoError(t, err)
+	delivered, deliverErr := eventBroker.DeliverPending()
+
+	// then
+	assert.NoError(t, deliverErr)
+	assert.Equal(t, 2, delivered)
+	assert.Len(t, eventCollector.Events, 2)
+
+	stored, err := memoryStorage.Operations().GetProvisioningOperationByID(operationIDSuccess)
+	assert.NoError(t, err)
+	assert.Equal(t, operation.Version+1, stored.Version)
+}
+
+func TestManager_ExecuteParallelSteps(t *testing.T) {
+	for name, tc := range map[string]struct {
+		operationID            string
+		failingSteps           bool
+		expectedError          []string
+		expectedRepeat         time.Duration
+		expectedLastStep       string
+		expectedFinishedSteps  []string
+		expectedNumberOfEvents int
+	}{
+		"operation successful": {
+			operationID:            operationIDSuccess,
+			expectedRepeat:         time.Duration(0),
+			expectedLastStep:       "final",
+			expectedFinishedSteps:  []string{"init", "one", "two", "three", "final"},
+			expectedNumberOfEvents: 5,
+		},
+		"operation failed": {
+			operationID:            operationIDSuccess,
+			failingSteps:           true,
+			expectedError:          []string{"step two failed", "step three failed"},
+			expectedNumberOfEvents: 4,
+		},
+		"operation repeated": {
+			operationID:            operationIDRepeat,
+			expectedRepeat:         time.Duration(10),
+			expectedLastStep:       "init",
+			expectedNumberOfEvents: 1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			log := logrus.New()
+			memoryStorage := storage.NewMemoryStorage()
+			operations := NewMergingOperationStorage(memoryStorage.Operations())
+			err := operations.InsertProvisioningOperation(fixProvisionOperation(tc.operationID))
+			assert.NoError(t, err)
+
+			sInit := testStep{t: t, name: "init", storage: operations}
+			s1 := testStep{t: t, name: "one", storage: operations}
+			var s2, s3 Step = &testStep{t: t, name: "two", storage: operations}, &testStep{t: t, name: "three", storage: operations}
+			if tc.failingSteps {
+				s2, s3 = &failingStep{name: "two"}, &failingStep{name: "three"}
+			}
+			sFinal := testStep{t: t, name: "final", storage: operations}
+
+			eventBroker := event.NewPubSub(logrus.New())
+			eventCollector := &collectingEventHandler{}
+			eventBroker.Subscribe("collector", process.ProvisioningStepProcessed{}, eventCollector.OnEvent)
+
+			manager := NewManager(operations, eventBroker, log)
+			manager.EnableParallelSteps()
+			manager.InitStep(&sInit)
+
+			manager.AddStep(2, &sFinal)
+			manager.AddStep(1, &s1)
+			manager.AddStep(1, s2)
+			manager.AddStep(1, s3)
+
+			// when
+			repeat, err := manager.Execute(tc.operationID)
+
+			// then
+			if len(tc.expectedError) > 0 {
+				assert.Error(t, err)
+				for _, msg := range tc.expectedError {
+					assert.Contains(t, err.Error(), msg)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedRepeat, repeat)
+
+				operation, err := operations.GetProvisioningOperationByID(tc.operationID)
+				assert.NoError(t, err)
+				// the descriptions set by the parallel steps are not merged, the last update wins
+				steps := strings.Fields(operation.Description)
+				require.NotEmpty(t, steps)
+				assert.Equal(t, tc.expectedLastStep, steps[len(steps)-1])
+				assert.ElementsMatch(t, tc.expectedFinishedSteps, operation.FinishedSteps)
 			}
 
 			assert.NoError(t, wait.PollImmediate(20*time.Millisecond, 2*time.Second, func() (bool, error) {
@@ -99,6 +269,238 @@ func TestManager_Execute(t *testing.T) {
 	}
 }
 
+func TestManager_ExecuteStepWithTimeout(t *testing.T) {
+	for name, tc := range map[string]struct {
+		startedAt     time.Time
+		expectedError bool
+		expectedState domain.LastOperationState
+		expectedDesc  string
+	}{
+		"step started within the time limit": {
+			startedAt:     time.Now().Add(-time.Minute),
+			expectedError: false,
+			expectedState: domain.InProgress,
+			expectedDesc:  "init one two final",
+		},
+		"step exceeded the time limit": {
+			startedAt:     time.Now().Add(-2 * time.Hour),
+			expectedError: true,
+			expectedState: domain.Failed,
+			expectedDesc:  "init : step one has reached the time limit: 1h0m0s",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			log := logrus.New()
+			memoryStorage := storage.NewMemoryStorage()
+			operation := fixProvisionOperation(operationIDSuccess)
+			operation.StepAttempts = map[string]internal.StepAttempt{"one": {StartedAt: tc.startedAt}}
+			err := memoryStorage.Operations().InsertProvisioningOperation(operation)
+			assert.NoError(t, err)
+
+			sInit := testStep{t: t, name: "init", storage: memoryStorage.Operations()}
+			s1 := testStep{t: t, name: "one", storage: memoryStorage.Operations()}
+			s2 := testStep{t: t, name: "two", storage: memoryStorage.Operations()}
+			sFinal := testStep{t: t, name: "final", storage: memoryStorage.Operations()}
+
+			manager := NewManager(memoryStorage.Operations(), event.NewPubSub(logrus.New()), log)
+			manager.InitStep(&sInit)
+
+			manager.AddStep(2, &sFinal)
+			manager.AddStepWithPolicy(1, &s1, process.StepPolicy{Timeout: time.Hour})
+			manager.AddStep(1, &s2)
+
+			// when
+			_, err = manager.Execute(operationIDSuccess)
+
+			// then
+			if tc.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			processedOperation, err := memoryStorage.Operations().GetProvisioningOperationByID(operationIDSuccess)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedState, processedOperation.State)
+			assert.Equal(t, tc.expectedDesc, strings.Trim(processedOperation.Description, " "))
+		})
+	}
+}
+
+func TestManager_ExecuteStepWithPolicy(t *testing.T) {
+	for name, tc := range map[string]struct {
+		policy          process.StepPolicy
+		expectedError   bool
+		expectedWhen    time.Duration
+		expectedState   domain.LastOperationState
+		expectedDesc    string
+		expectedAttempt internal.StepAttempt
+	}{
+		"failing step is retried with backoff": {
+			policy:          process.StepPolicy{MaxAttempts: 3, Backoff: time.Minute},
+			expectedWhen:    time.Minute,
+			expectedState:   domain.InProgress,
+			expectedDesc:    "init",
+			expectedAttempt: internal.StepAttempt{Count: 1, LastError: "one failed"},
+		},
+		"mandatory step exceeded the retry budget": {
+			policy:          process.StepPolicy{MaxAttempts: 1},
+			expectedError:   true,
+			expectedState:   domain.Failed,
+			expectedDesc:    "init : step one has reached the maximum number of attempts: 1: one failed",
+			expectedAttempt: internal.StepAttempt{Count: 1, LastError: "one failed"},
+		},
+		"optional step is skipped": {
+			policy:          process.StepPolicy{Optional: true},
+			expectedState:   domain.InProgress,
+			expectedDesc:    "init two",
+			expectedAttempt: internal.StepAttempt{Count: 1, LastError: "step one failed: one failed", Skipped: true},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			log := logrus.New()
+			memoryStorage := storage.NewMemoryStorage()
+			operation := fixProvisionOperation(operationIDSuccess)
+			err := memoryStorage.Operations().InsertProvisioningOperation(operation)
+			assert.NoError(t, err)
+
+			sInit := testStep{t: t, name: "init", storage: memoryStorage.Operations()}
+			s1 := failingStep{name: "one"}
+			s2 := testStep{t: t, name: "two", storage: memoryStorage.Operations()}
+
+			manager := NewManager(memoryStorage.Operations(), event.NewPubSub(logrus.New()), log)
+			manager.InitStep(&sInit)
+			manager.AddStepWithPolicy(1, &s1, tc.policy)
+			manager.AddStep(2, &s2)
+
+			// when
+			when, err := manager.Execute(operationIDSuccess)
+
+			// then
+			if tc.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedWhen, when)
+
+			processedOperation, err := memoryStorage.Operations().GetProvisioningOperationByID(operationIDSuccess)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedState, processedOperation.State)
+			assert.Equal(t, tc.expectedDesc, strings.Trim(processedOperation.Description, " "))
+
+			attempt := processedOperation.StepAttempt("one")
+			assert.Equal(t, tc.expectedAttempt.Count, attempt.Count)
+			assert.Equal(t, tc.expectedAttempt.LastError, attempt.LastError)
+			assert.Equal(t, tc.expectedAttempt.Skipped, attempt.Skipped)
+			assert.Equal(t, tc.policy.Backoff > 0, !attempt.NextAttemptAt.IsZero())
+		})
+	}
+}
+
+func TestManager_ExecuteGivenUpStep(t *testing.T) {
+	for name, tc := range map[string]struct {
+		policy        process.StepPolicy
+		expectedError bool
+		expectedState domain.LastOperationState
+		expectedDesc  string
+	}{
+		"mandatory step fails the operation": {
+			expectedError: true,
+			expectedState: domain.Failed,
+			expectedDesc:  "init : one given up",
+		},
+		"optional step is skipped": {
+			policy:        process.StepPolicy{Optional: true},
+			expectedState: domain.InProgress,
+			expectedDesc:  "init two",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			log := logrus.New()
+			memoryStorage := storage.NewMemoryStorage()
+			operation := fixProvisionOperation(operationIDSuccess)
+			err := memoryStorage.Operations().InsertProvisioningOperation(operation)
+			assert.NoError(t, err)
+
+			sInit := testStep{t: t, name: "init", storage: memoryStorage.Operations()}
+			s1 := givingUpStep{name: "one"}
+			s2 := testStep{t: t, name: "two", storage: memoryStorage.Operations()}
+
+			manager := NewManager(memoryStorage.Operations(), event.NewPubSub(logrus.New()), log)
+			manager.InitStep(&sInit)
+			manager.AddStepWithPolicy(1, &s1, tc.policy)
+			manager.AddStep(2, &s2)
+
+			// when
+			_, err = manager.Execute(operationIDSuccess)
+
+			// then
+			if tc.expectedError {
+				assert.True(t, process.IsStepFailedError(err))
+			} else {
+				assert.NoError(t, err)
+			}
+
+			processedOperation, err := memoryStorage.Operations().GetProvisioningOperationByID(operationIDSuccess)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedState, processedOperation.State)
+			assert.Equal(t, tc.expectedDesc, strings.Trim(processedOperation.Description, " "))
+			assert.Equal(t, "one given up", processedOperation.StepAttempt("one").LastError)
+			assert.Equal(t, tc.policy.Optional, processedOperation.StepAttempt("one").Skipped)
+		})
+	}
+}
+
+func TestManager_ExecuteStepEnabledForPlans(t *testing.T) {
+	for name, tc := range map[string]struct {
+		planIDs      []string
+		expectedDesc string
+	}{
+		"step enabled for the plan is executed": {
+			planIDs:      []string{broker.GCPPlanID, broker.AzurePlanID},
+			expectedDesc: "init one two",
+		},
+		"step not enabled for the plan is skipped": {
+			planIDs:      []string{broker.GCPPlanID, broker.TrialPlanID},
+			expectedDesc: "init two",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			log := logrus.New()
+			memoryStorage := storage.NewMemoryStorage()
+			operation := fixProvisionOperation(operationIDSuccess)
+			err := memoryStorage.Operations().InsertProvisioningOperation(operation)
+			assert.NoError(t, err)
+
+			sInit := testStep{t: t, name: "init", storage: memoryStorage.Operations()}
+			s1 := testStep{t: t, name: "one", storage: memoryStorage.Operations()}
+			s2 := testStep{t: t, name: "two", storage: memoryStorage.Operations()}
+
+			manager := NewManager(memoryStorage.Operations(), event.NewPubSub(logrus.New()), log)
+			manager.InitStep(&sInit)
+			manager.AddStepWithPolicy(1, &s1, process.StepPolicy{PlanIDs: tc.planIDs})
+			manager.AddStep(2, &s2)
+
+			// when
+			when, err := manager.Execute(operationIDSuccess)
+
+			// then
+			assert.NoError(t, err)
+			assert.Zero(t, when)
+
+			processedOperation, err := memoryStorage.Operations().GetProvisioningOperationByID(operationIDSuccess)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedDesc, strings.Trim(processedOperation.Description, " "))
+			assert.Empty(t, processedOperation.StepAttempt("one").Count)
+		})
+	}
+}
+
 func fixProvisionOperation(ID string) internal.ProvisioningOperation {
 	return internal.ProvisioningOperation{
 		Operation: internal.Operation{
@@ -139,6 +541,47 @@ func (ts *testStep) Run(operation internal.ProvisioningOperation, logger logrus.
 	}
 }
 
+// readOnlyStep is the repeatable step which does not update the operation
+type readOnlyStep struct {
+	name string
+}
+
+func (s *readOnlyStep) Name() string {
+	return s.name
+}
+
+func (s *readOnlyStep) Repeatable() bool {
+	return true
+}
+
+func (s *readOnlyStep) Run(operation internal.ProvisioningOperation, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+	return operation, 0, nil
+}
+
+type failingStep struct {
+	name string
+}
+
+func (fs *failingStep) Name() string {
+	return fs.name
+}
+
+func (fs *failingStep) Run(operation internal.ProvisioningOperation, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+	return operation, 0, fmt.Errorf("%s failed", fs.name)
+}
+
+type givingUpStep struct {
+	name string
+}
+
+func (gs *givingUpStep) Name() string {
+	return gs.name
+}
+
+func (gs *givingUpStep) Run(operation internal.ProvisioningOperation, logger logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+	return operation, 0, process.NewStepFailedError(fmt.Sprintf("%s given up", gs.name))
+}
+
 type collectingEventHandler struct {
 	mu     sync.Mutex
 	Events []interface{}
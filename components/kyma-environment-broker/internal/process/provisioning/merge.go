@@ -0,0 +1,153 @@
+package provisioning
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+)
+
+// mergeOperations applies changes made in the "mine" operation (compared to the "base" operation)
+// on top of the "theirs" operation, which is the latest version of the operation. Fields changed only
+// in one of the operations are taken from that operation, plain text fields changed in both operations
+// are taken from the "mine" operation, any other field changed in both operations is a conflict.
+// The version of the result is always taken from the "theirs" operation, fields which are not stored
+// in the storage are always taken from the "mine" operation. The pending events of both operations are kept.
+func mergeOperations(base, theirs, mine internal.ProvisioningOperation) (internal.ProvisioningOperation, error) {
+	events := mergePendingEvents(base.TakePendingEvents(), theirs.TakePendingEvents(), mine.TakePendingEvents())
+	for _, op := range []*internal.ProvisioningOperation{&base, &mine} {
+		op.Version = theirs.Version
+		op.UpdatedAt = theirs.UpdatedAt
+	}
+	for _, op := range []*internal.ProvisioningOperation{&base, &theirs} {
+		op.InputCreator = mine.InputCreator
+		op.SMClientFactory = mine.SMClientFactory
+	}
+
+	merged, err := mergeValues(reflect.ValueOf(base), reflect.ValueOf(theirs), reflect.ValueOf(mine), "")
+	if err != nil {
+		return internal.ProvisioningOperation{}, err
+	}
+
+	result := merged.Interface().(internal.ProvisioningOperation)
+	result.AddPendingEvents(events)
+	return result, nil
+}
+
+// mergePendingEvents returns the events of the "theirs" operation and the events added in the "mine" operation
+func mergePendingEvents(base, theirs, mine []internal.OutboxEvent) []internal.OutboxEvent {
+	known := make(map[string]struct{}, len(base)+len(theirs))
+	for _, events := range [][]internal.OutboxEvent{base, theirs} {
+		for _, event := range events {
+			known[event.ID] = struct{}{}
+		}
+	}
+	result := append([]internal.OutboxEvent{}, theirs...)
+	for _, event := range mine {
+		if _, found := known[event.ID]; !found {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+func mergeValues(base, theirs, mine reflect.Value, path string) (reflect.Value, error) {
+	switch {
+	case reflect.DeepEqual(mine.Interface(), base.Interface()):
+		return theirs, nil
+	case reflect.DeepEqual(theirs.Interface(), base.Interface()), reflect.DeepEqual(theirs.Interface(), mine.Interface()):
+		return mine, nil
+	}
+
+	switch base.Kind() {
+	case reflect.Struct:
+		return mergeStructs(base, theirs, mine, path)
+	case reflect.Ptr:
+		if base.IsNil() || theirs.IsNil() || mine.IsNil() {
+			break
+		}
+		merged, err := mergeValues(base.Elem(), theirs.Elem(), mine.Elem(), path)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result := reflect.New(base.Type().Elem())
+		result.Elem().Set(merged)
+		return result, nil
+	case reflect.Map:
+		return mergeMaps(base, theirs, mine, path)
+	case reflect.String:
+		// the last writer wins for texts like the description, the named string types
+		// like the operation state are enumerations and stay conflicts
+		if base.Type() == reflect.TypeOf("") {
+			return mine, nil
+		}
+	}
+
+	return reflect.Value{}, fmt.Errorf("conflicting changes of the field %q", strings.TrimPrefix(path, "."))
+}
+
+func mergeStructs(base, theirs, mine reflect.Value, path string) (reflect.Value, error) {
+	result := reflect.New(base.Type()).Elem()
+	result.Set(theirs)
+
+	for i := 0; i < base.NumField(); i++ {
+		field := base.Type().Field(i)
+		if field.PkgPath != "" {
+			// unexported fields cannot be set, such fields are taken from the latest version
+			continue
+		}
+		merged, err := mergeValues(base.Field(i), theirs.Field(i), mine.Field(i), path+"."+field.Name)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result.Field(i).Set(merged)
+	}
+
+	return result, nil
+}
+
+func mergeMaps(base, theirs, mine reflect.Value, path string) (reflect.Value, error) {
+	if theirs.IsNil() || mine.IsNil() {
+		return reflect.Value{}, fmt.Errorf("conflicting changes of the field %q", strings.TrimPrefix(path, "."))
+	}
+
+	result := reflect.MakeMap(base.Type())
+	for _, key := range theirs.MapKeys() {
+		result.SetMapIndex(key, theirs.MapIndex(key))
+	}
+	for _, key := range mine.MapKeys() {
+		keyPath := fmt.Sprintf("%s[%v]", path, key.Interface())
+		baseValue, mineValue, theirsValue := base.MapIndex(key), mine.MapIndex(key), theirs.MapIndex(key)
+		switch {
+		case !theirsValue.IsValid() && !baseValue.IsValid():
+			result.SetMapIndex(key, mineValue)
+		case !theirsValue.IsValid():
+			// removed in the latest version
+			if !reflect.DeepEqual(baseValue.Interface(), mineValue.Interface()) {
+				return reflect.Value{}, fmt.Errorf("conflicting changes of the field %q", strings.TrimPrefix(keyPath, "."))
+			}
+		case !baseValue.IsValid():
+			if !reflect.DeepEqual(theirsValue.Interface(), mineValue.Interface()) {
+				return reflect.Value{}, fmt.Errorf("conflicting changes of the field %q", strings.TrimPrefix(keyPath, "."))
+			}
+		default:
+			merged, err := mergeValues(baseValue, theirsValue, mineValue, keyPath)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			result.SetMapIndex(key, merged)
+		}
+	}
+	for _, key := range base.MapKeys() {
+		if !mine.MapIndex(key).IsValid() && theirs.MapIndex(key).IsValid() {
+			// removed in the "mine" version
+			if !reflect.DeepEqual(base.MapIndex(key).Interface(), theirs.MapIndex(key).Interface()) {
+				return reflect.Value{}, fmt.Errorf("conflicting changes of the field %q", strings.TrimPrefix(fmt.Sprintf("%s[%v]", path, key.Interface()), "."))
+			}
+			result.SetMapIndex(key, reflect.Value{})
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,111 @@
+package provisioning
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeOperations(t *testing.T) {
+	t.Run("should merge changes of different fields", func(t *testing.T) {
+		// given
+		base := fixProvisionOperation(operationIDSuccess)
+		base.Version = 1
+
+		theirs := base
+		theirs.Version = 2
+		theirs.XSUAA.Instance.InstanceID = "xsuaa-instance"
+		theirs.Description = "xsuaa provisioned"
+
+		mine := base
+		mine.Ems.Instance.InstanceID = "ems-instance"
+		mine.Lms.TenantID = "tenant"
+
+		// when
+		merged, err := mergeOperations(base, theirs, mine)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 2, merged.Version)
+		assert.Equal(t, "xsuaa-instance", merged.XSUAA.Instance.InstanceID)
+		assert.Equal(t, "ems-instance", merged.Ems.Instance.InstanceID)
+		assert.Equal(t, "tenant", merged.Lms.TenantID)
+		assert.Equal(t, "xsuaa provisioned", merged.Description)
+	})
+
+	t.Run("should take the description of the last update", func(t *testing.T) {
+		// given
+		base := fixProvisionOperation(operationIDSuccess)
+		base.Description = "started"
+
+		theirs := base
+		theirs.Description = "started : one"
+
+		mine := base
+		mine.Description = "started : two"
+
+		// when
+		merged, err := mergeOperations(base, theirs, mine)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "started : two", merged.Description)
+	})
+
+	t.Run("should merge provisioning parameters", func(t *testing.T) {
+		// given
+		base := fixProvisionOperation(operationIDSuccess)
+
+		theirs := base
+		theirs.ProvisioningParameters.Parameters.Name = "new-name"
+
+		mine := base
+		mine.ProvisioningParameters.PlanID = broker.GCPPlanID
+
+		// when
+		merged, err := mergeOperations(base, theirs, mine)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "new-name", merged.ProvisioningParameters.Parameters.Name)
+		assert.Equal(t, broker.GCPPlanID, merged.ProvisioningParameters.PlanID)
+	})
+
+	t.Run("should return error for conflicting changes", func(t *testing.T) {
+		// given
+		base := fixProvisionOperation(operationIDSuccess)
+
+		theirs := base
+		theirs.State = domain.Failed
+
+		mine := base
+		mine.State = domain.Succeeded
+
+		// when
+		_, err := mergeOperations(base, theirs, mine)
+
+		// then
+		assert.EqualError(t, err, `conflicting changes of the field "Operation.State"`)
+	})
+
+	t.Run("should keep not stored fields from the processed operation", func(t *testing.T) {
+		// given
+		base := fixProvisionOperation(operationIDSuccess)
+
+		theirs := base
+
+		mine := base
+		mine.InputCreator = newInputCreator()
+
+		// when
+		merged, err := mergeOperations(base, theirs, mine)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, mine.InputCreator, merged.InputCreator)
+	})
+}
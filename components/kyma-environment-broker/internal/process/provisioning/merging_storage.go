@@ -0,0 +1,214 @@
+package provisioning
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+)
+
+const (
+	// maxVersionsPerOperation limits the number of the operation versions kept to resolve conflicts
+	maxVersionsPerOperation = 20
+	// versionsTTL is the time after which the versions of the operation which was not read or updated are removed,
+	// the versions are needed only during the processing of the steps, the abandoned operations must not stay forever
+	versionsTTL = time.Hour
+	// maxOperations limits the number of the operations which versions are kept
+	maxOperations = 1000
+)
+
+type operationVersions struct {
+	versions  map[int]internal.ProvisioningOperation
+	touchedAt time.Time
+}
+
+// operationLock serializes the updates of one operation, refs counts the updates waiting for the lock
+type operationLock struct {
+	sync.Mutex
+	refs int
+}
+
+// MergingOperationStorage is an operation storage which resolves conflicts of provisioning operation updates
+// made by steps running in parallel. When an update is rejected because the operation was modified in the meantime,
+// changes of the rejected update are merged with the latest version of the operation and stored again.
+type MergingOperationStorage struct {
+	storage.Operations
+
+	// mu guards the versions and the locks maps, it is never held during the storage calls
+	mu       sync.Mutex
+	versions map[string]*operationVersions
+	locks    map[string]*operationLock
+	now      func() time.Time
+}
+
+func NewMergingOperationStorage(operations storage.Operations) *MergingOperationStorage {
+	return &MergingOperationStorage{
+		Operations: operations,
+		versions:   make(map[string]*operationVersions),
+		locks:      make(map[string]*operationLock),
+		now:        time.Now,
+	}
+}
+
+func (s *MergingOperationStorage) InsertProvisioningOperation(operation internal.ProvisioningOperation) error {
+	err := s.Operations.InsertProvisioningOperation(operation)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remember(operation)
+	return nil
+}
+
+func (s *MergingOperationStorage) GetProvisioningOperationByID(operationID string) (*internal.ProvisioningOperation, error) {
+	operation, err := s.Operations.GetProvisioningOperationByID(operationID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remember(*operation)
+	return operation, nil
+}
+
+func (s *MergingOperationStorage) GetProvisioningOperationByInstanceID(instanceID string) (*internal.ProvisioningOperation, error) {
+	operation, err := s.Operations.GetProvisioningOperationByInstanceID(instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remember(*operation)
+	return operation, nil
+}
+
+func (s *MergingOperationStorage) UpdateProvisioningOperation(operation internal.ProvisioningOperation) (*internal.ProvisioningOperation, error) {
+	unlock := s.lockOperation(operation.ID)
+	defer unlock()
+
+	updated, err := s.Operations.UpdateProvisioningOperation(operation)
+	switch {
+	case err == nil:
+		s.rememberLocked(*updated)
+		return updated, nil
+	case !dberr.IsConflict(err):
+		return nil, err
+	}
+
+	base, found := s.version(operation.ID, operation.Version)
+	if !found {
+		return nil, err
+	}
+	latest, getErr := s.Operations.GetProvisioningOperationByID(operation.ID)
+	if getErr != nil {
+		return nil, err
+	}
+	merged, mergeErr := mergeOperations(base, *latest, operation)
+	if mergeErr != nil {
+		return nil, dberr.Conflict("unable to merge provisioning operation with id %s: %s", operation.ID, mergeErr)
+	}
+
+	updated, err = s.Operations.UpdateProvisioningOperation(merged)
+	if err != nil {
+		return nil, err
+	}
+	s.rememberLocked(*updated)
+	return updated, nil
+}
+
+// lockOperation blocks until no other update of the operation is processed and returns the function releasing the lock
+func (s *MergingOperationStorage) lockOperation(operationID string) func() {
+	s.mu.Lock()
+	lock, found := s.locks[operationID]
+	if !found {
+		lock = &operationLock{}
+		s.locks[operationID] = lock
+	}
+	lock.refs++
+	s.mu.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(s.locks, operationID)
+		}
+	}
+}
+
+func (s *MergingOperationStorage) version(operationID string, version int) (internal.ProvisioningOperation, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	versions, found := s.versions[operationID]
+	if !found {
+		return internal.ProvisioningOperation{}, false
+	}
+	operation, found := versions.versions[version]
+	return operation, found
+}
+
+func (s *MergingOperationStorage) rememberLocked(operation internal.ProvisioningOperation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remember(operation)
+}
+
+// remember stores the given version of the operation, must be called with the lock held
+func (s *MergingOperationStorage) remember(operation internal.ProvisioningOperation) {
+	now := s.now()
+	s.evict(now)
+	if operation.State != domain.InProgress {
+		delete(s.versions, operation.ID)
+		return
+	}
+
+	versions, found := s.versions[operation.ID]
+	if !found {
+		if len(s.versions) >= maxOperations {
+			s.evictLeastRecentlyUsed()
+		}
+		versions = &operationVersions{versions: make(map[int]internal.ProvisioningOperation)}
+		s.versions[operation.ID] = versions
+	}
+	versions.touchedAt = now
+	versions.versions[operation.Version] = operation
+	for version := range versions.versions {
+		if version <= operation.Version-maxVersionsPerOperation {
+			delete(versions.versions, version)
+		}
+	}
+}
+
+// evict removes the versions of the operations which were not used within the TTL, for example the operations
+// which timed out or were finished by another instance of KEB
+func (s *MergingOperationStorage) evict(now time.Time) {
+	for id, versions := range s.versions {
+		if now.Sub(versions.touchedAt) > versionsTTL {
+			delete(s.versions, id)
+		}
+	}
+}
+
+func (s *MergingOperationStorage) evictLeastRecentlyUsed() {
+	var oldestID string
+	var oldest time.Time
+	for id, versions := range s.versions {
+		if oldestID == "" || versions.touchedAt.Before(oldest) {
+			oldestID, oldest = id, versions.touchedAt
+		}
+	}
+	delete(s.versions, oldestID)
+}
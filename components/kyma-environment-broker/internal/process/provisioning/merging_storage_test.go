@@ -0,0 +1,65 @@
+package provisioning
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergingOperationStorage_EvictsNotUsedOperations(t *testing.T) {
+	// given
+	now := time.Now()
+	operations := NewMergingOperationStorage(storage.NewMemoryStorage().Operations())
+	operations.now = func() time.Time { return now }
+
+	require.NoError(t, operations.InsertProvisioningOperation(fixProvisionOperation("abandoned-id")))
+	now = now.Add(versionsTTL / 2)
+	require.NoError(t, operations.InsertProvisioningOperation(fixProvisionOperation("active-id")))
+	assert.Len(t, operations.versions, 2)
+
+	// when
+	now = now.Add(versionsTTL/2 + time.Minute)
+	_, err := operations.GetProvisioningOperationByID("active-id")
+
+	// then
+	require.NoError(t, err)
+	assert.Len(t, operations.versions, 1)
+	assert.Contains(t, operations.versions, "active-id")
+}
+
+func TestMergingOperationStorage_MergesConcurrentUpdates(t *testing.T) {
+	// given
+	operations := NewMergingOperationStorage(storage.NewMemoryStorage().Operations())
+	require.NoError(t, operations.InsertProvisioningOperation(fixProvisionOperation(operationIDSuccess)))
+	base, err := operations.GetProvisioningOperationByID(operationIDSuccess)
+	require.NoError(t, err)
+
+	xsuaa, ems := *base, *base
+	xsuaa.XSUAA.Instance.InstanceID = "xsuaa-instance"
+	ems.Ems.Instance.InstanceID = "ems-instance"
+
+	// when
+	var wg sync.WaitGroup
+	for _, op := range []internal.ProvisioningOperation{xsuaa, ems} {
+		wg.Add(1)
+		go func(op internal.ProvisioningOperation) {
+			defer wg.Done()
+			_, err := operations.UpdateProvisioningOperation(op)
+			assert.NoError(t, err)
+		}(op)
+	}
+	wg.Wait()
+
+	// then
+	stored, err := operations.GetProvisioningOperationByID(operationIDSuccess)
+	require.NoError(t, err)
+	assert.Equal(t, "xsuaa-instance", stored.XSUAA.Instance.InstanceID)
+	assert.Equal(t, "ems-instance", stored.Ems.Instance.InstanceID)
+	assert.Empty(t, operations.locks)
+}
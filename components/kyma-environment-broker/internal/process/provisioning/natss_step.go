@@ -23,6 +23,11 @@ func (s *NatsStreamingStep) Name() string {
 	return "Provision Nats Streaming"
 }
 
+// Repeatable returns true, the NATS Streaming overrides are static
+func (s *NatsStreamingStep) Repeatable() bool {
+	return true
+}
+
 func (s *NatsStreamingStep) Run(operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
 	log.Infof("Provisioning for PlanID: %s", operation.ProvisioningParameters.PlanID)
 	operation.InputCreator.AppendOverrides(components.NatsStreaming, getNatsStreamingOverrides())
@@ -27,6 +27,8 @@ func getHyperscalerType(pp internal.ProvisioningParameters) (hyperscaler.Type, e
 		return hyperscaler.GCP, nil
 	case broker.AzurePlanID, broker.AzureLitePlanID:
 		return hyperscaler.Azure, nil
+	case broker.AWSPlanID:
+		return hyperscaler.AWS, nil
 	case broker.TrialPlanID:
 		return forTrialProvider(pp.Parameters.Provider)
 	default:
@@ -44,6 +46,8 @@ func forTrialProvider(provider *internal.TrialCloudProvider) (hyperscaler.Type,
 		return hyperscaler.Azure, nil
 	case internal.Gcp:
 		return hyperscaler.GCP, nil
+	case internal.Aws:
+		return hyperscaler.AWS, nil
 	default:
 		return "", errors.Errorf("Cannot determine the type of Hyperscaler to use for provider: %s", string(*provider))
 	}
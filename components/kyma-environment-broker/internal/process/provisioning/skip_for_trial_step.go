@@ -7,6 +7,7 @@ import (
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 )
 
 type SkipForTrialPlanStep struct {
@@ -23,6 +24,10 @@ func (s *SkipForTrialPlanStep) Name() string {
 	return s.step.Name()
 }
 
+func (s *SkipForTrialPlanStep) Repeatable() bool {
+	return process.IsStepRepeatable(s.step)
+}
+
 func (s *SkipForTrialPlanStep) Run(operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
 	if broker.IsTrialPlan(operation.ProvisioningParameters.PlanID) {
 		log.Infof("Skipping step %s", s.Name())
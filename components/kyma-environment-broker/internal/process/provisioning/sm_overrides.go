@@ -29,6 +29,11 @@ func (s *ServiceManagerOverridesStep) Name() string {
 	return "ServiceManagerOverrides"
 }
 
+// Repeatable returns true, the Service Manager credentials are passed only to the runtime input
+func (s *ServiceManagerOverridesStep) Repeatable() bool {
+	return true
+}
+
 func (s *ServiceManagerOverridesStep) Run(operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
 	creds, err := operation.ProvideServiceManagerCredentials(log)
 	if err != nil {
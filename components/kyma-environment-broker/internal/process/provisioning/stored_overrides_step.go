@@ -0,0 +1,112 @@
+package provisioning
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtime/components"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// StoredOverridesStep appends the overrides which were prepared by the steps calling external services (Azure Event Hubs,
+// LMS, IAS, EMS) and stored in the operation. The external services are called only once, but the runtime input is built
+// from scratch each time the operation is processed, so only this step is repeated.
+type StoredOverridesStep struct {
+	operationManager *process.ProvisionOperationManager
+	secretKey        string
+}
+
+func NewStoredOverridesStep(os storage.Operations, secretKey string) *StoredOverridesStep {
+	return &StoredOverridesStep{
+		operationManager: process.NewProvisionOperationManager(os),
+		secretKey:        secretKey,
+	}
+}
+
+var _ Step = (*StoredOverridesStep)(nil)
+
+func (s *StoredOverridesStep) Name() string {
+	return "Stored_Overrides"
+}
+
+// Repeatable returns true, the step only decrypts the stored overrides and does not call any service
+func (s *StoredOverridesStep) Repeatable() bool {
+	return true
+}
+
+func (s *StoredOverridesStep) Run(operation internal.ProvisioningOperation, log logrus.FieldLogger) (internal.ProvisioningOperation, time.Duration, error) {
+	if operation.EventHub.Overrides != "" {
+		kafkaOverrides, err := decryptOverrides(s.secretKey, operation.EventHub.Overrides)
+		if err != nil {
+			return s.failed(operation, err, log, "unable to decrypt Azure Event Hubs overrides")
+		}
+		operation.InputCreator.AppendOverrides(components.KnativeEventing, getKnativeEventingOverrides())
+		operation.InputCreator.AppendOverrides(components.KnativeEventingKafka, kafkaOverrides)
+	}
+
+	if operation.Lms.KibanaURL != "" {
+		operation.InputCreator.SetLabel(kibanaURLLabelKey, operation.Lms.KibanaURL)
+	}
+	if operation.Lms.Overrides != "" {
+		loggingOverrides, err := decryptOverrides(s.secretKey, operation.Lms.Overrides)
+		if err != nil {
+			return s.failed(operation, err, log, "unable to decrypt LMS overrides")
+		}
+		operation.InputCreator.AppendOverrides("logging", loggingOverrides)
+	}
+
+	if operation.IAS.Overrides != "" {
+		monitoringOverrides, err := decryptOverrides(s.secretKey, operation.IAS.Overrides)
+		if err != nil {
+			return s.failed(operation, err, log, "unable to decrypt IAS overrides")
+		}
+		operation.InputCreator.AppendOverrides("monitoring", monitoringOverrides)
+	}
+
+	if operation.Ems.Overrides != "" {
+		eventingOverrides, err := DecryptEventingOverrides(s.secretKey, operation.Ems.Overrides)
+		if err != nil {
+			return s.failed(operation, err, log, "unable to decrypt EMS overrides")
+		}
+		operation.InputCreator.AppendOverrides(components.Eventing, GetEventingOverrides(eventingOverrides))
+	}
+
+	return operation, 0, nil
+}
+
+func (s *StoredOverridesStep) failed(operation internal.ProvisioningOperation, err error, log logrus.FieldLogger, msg string) (internal.ProvisioningOperation, time.Duration, error) {
+	log.Errorf("%s: %s", msg, err)
+	// internal error, repeating doesn't solve the problem
+	return s.operationManager.OperationFailed(operation, msg)
+}
+
+// encryptOverrides encrypts the overrides which contain credentials before they are stored in the operation
+func encryptOverrides(secretKey string, overrides []*gqlschema.ConfigEntryInput) (string, error) {
+	data, err := json.Marshal(overrides)
+	if err != nil {
+		return "", errors.Wrap(err, "while encoding overrides")
+	}
+	encrypted, err := storage.NewEncrypter(secretKey).Encrypt(data)
+	if err != nil {
+		return "", errors.Wrap(err, "while encrypting overrides")
+	}
+	return string(encrypted), nil
+}
+
+func decryptOverrides(secretKey string, encrypted string) ([]*gqlschema.ConfigEntryInput, error) {
+	data, err := storage.NewEncrypter(secretKey).Decrypt([]byte(encrypted))
+	if err != nil {
+		return nil, errors.Wrap(err, "while decrypting overrides")
+	}
+	var overrides []*gqlschema.ConfigEntryInput
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, errors.Wrap(err, "while decoding overrides")
+	}
+	return overrides, nil
+}
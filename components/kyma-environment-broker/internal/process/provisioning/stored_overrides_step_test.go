@@ -0,0 +1,109 @@
+package provisioning
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtime/components"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecretKey = "1234567890123456"
+
+func TestStoredOverridesStep_Run(t *testing.T) {
+	// given
+	memoryStorage := storage.NewMemoryStorage()
+	step := NewStoredOverridesStep(memoryStorage.Operations(), testSecretKey)
+
+	kafkaOverrides, err := encryptOverrides(testSecretKey, []*gqlschema.ConfigEntryInput{
+		{Key: "kafka.password", Value: "kafka-secret", Secret: ptr.Bool(true)},
+	})
+	require.NoError(t, err)
+	loggingOverrides, err := encryptOverrides(testSecretKey, []*gqlschema.ConfigEntryInput{
+		{Key: "fluent-bit.backend.forward.tls.key", Value: "key", Secret: ptr.Bool(true)},
+	})
+	require.NoError(t, err)
+	monitoringOverrides, err := encryptOverrides(testSecretKey, []*gqlschema.ConfigEntryInput{
+		{Key: "grafana.env.GF_AUTH_GENERIC_OAUTH_CLIENT_SECRET", Value: "secret", Secret: ptr.Bool(true)},
+	})
+	require.NoError(t, err)
+	eventingOverrides, err := EncryptEventingOverrides(testSecretKey, &EventingOverrides{OauthClientId: "ems-client"})
+	require.NoError(t, err)
+
+	inputCreator := newInputCreator()
+	operation := internal.ProvisioningOperation{
+		Operation: internal.Operation{
+			InstanceDetails: internal.InstanceDetails{
+				EventHub: internal.EventHub{Overrides: kafkaOverrides},
+				Lms:      internal.LMS{KibanaURL: "https://kibana.example.com", Overrides: loggingOverrides},
+				IAS:      internal.IASData{Overrides: monitoringOverrides},
+				Ems:      internal.EmsData{Overrides: eventingOverrides},
+			},
+		},
+		InputCreator: inputCreator,
+	}
+
+	// when
+	_, repeat, err := step.Run(operation, fixLogger())
+
+	// then
+	require.NoError(t, err)
+	assert.Zero(t, repeat)
+	inputCreator.AssertOverride(t, components.KnativeEventing, gqlschema.ConfigEntryInput{
+		Key: "knative-eventing.channel.default.kind", Value: "KafkaChannel"})
+	inputCreator.AssertOverride(t, components.KnativeEventingKafka, gqlschema.ConfigEntryInput{
+		Key: "kafka.password", Value: "kafka-secret", Secret: ptr.Bool(true)})
+	inputCreator.AssertOverride(t, "logging", gqlschema.ConfigEntryInput{
+		Key: "fluent-bit.backend.forward.tls.key", Value: "key", Secret: ptr.Bool(true)})
+	inputCreator.AssertLabel(t, kibanaURLLabelKey, "https://kibana.example.com")
+	inputCreator.AssertOverride(t, "monitoring", gqlschema.ConfigEntryInput{
+		Key: "grafana.env.GF_AUTH_GENERIC_OAUTH_CLIENT_SECRET", Value: "secret", Secret: ptr.Bool(true)})
+	inputCreator.AssertOverride(t, components.Eventing, gqlschema.ConfigEntryInput{
+		Key: "authentication.oauthClientId", Value: "ems-client", Secret: ptr.Bool(true)})
+}
+
+func TestStoredOverridesStep_RunWithoutStoredOverrides(t *testing.T) {
+	// given
+	memoryStorage := storage.NewMemoryStorage()
+	step := NewStoredOverridesStep(memoryStorage.Operations(), testSecretKey)
+	inputCreator := newInputCreator()
+	operation := internal.ProvisioningOperation{
+		InputCreator: inputCreator,
+	}
+
+	// when
+	_, repeat, err := step.Run(operation, fixLogger())
+
+	// then
+	require.NoError(t, err)
+	assert.Zero(t, repeat)
+	inputCreator.AssertNoOverrides(t)
+}
+
+func TestStoredOverridesStep_RunWithInvalidOverrides(t *testing.T) {
+	// given
+	memoryStorage := storage.NewMemoryStorage()
+	step := NewStoredOverridesStep(memoryStorage.Operations(), testSecretKey)
+	operation := internal.ProvisioningOperation{
+		Operation: internal.Operation{
+			ID:              "operation-id",
+			InstanceDetails: internal.InstanceDetails{IAS: internal.IASData{Overrides: "not-encrypted"}},
+		},
+		InputCreator: newInputCreator(),
+	}
+	err := memoryStorage.Operations().InsertProvisioningOperation(operation)
+	require.NoError(t, err)
+
+	// when
+	operation, _, err = step.Run(operation, fixLogger())
+
+	// then
+	require.Error(t, err)
+	assert.Equal(t, domain.Failed, operation.State)
+}
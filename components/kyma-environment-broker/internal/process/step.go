@@ -0,0 +1,120 @@
+package process
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+
+	"github.com/pkg/errors"
+)
+
+// RepeatableStep is implemented by steps which must be executed each time the operation is processed,
+// even if they were already successfully processed, for example steps which prepare data
+// that is not stored in the storage (like the runtime input creator or overrides).
+type RepeatableStep interface {
+	Repeatable() bool
+}
+
+// IsStepRepeatable returns true if the step must be executed each time the operation is processed
+func IsStepRepeatable(step interface{}) bool {
+	repeatable, ok := step.(RepeatableStep)
+	return ok && repeatable.Repeatable()
+}
+
+// NamedStep is implemented by steps of all processes, the process manager checks the type of the step when it is added
+type NamedStep interface {
+	Name() string
+}
+
+// StepFailedError is returned by the step which cannot be completed instead of failing the operation by the step.
+// The process manager decides about the outcome before the operation is stored: the operation fails
+// if the step is mandatory and the optional step is skipped.
+type StepFailedError struct {
+	Description string
+}
+
+func (e StepFailedError) Error() string {
+	return e.Description
+}
+
+// NewStepFailedError returns the error which gives up the step
+func NewStepFailedError(description string) error {
+	return StepFailedError{Description: description}
+}
+
+// IsStepFailedError returns true if the step must be given up
+func IsStepFailedError(err error) bool {
+	_, ok := errors.Cause(err).(StepFailedError)
+	return ok
+}
+
+// StepPolicy defines how the process manager executes the step
+type StepPolicy struct {
+	// PlanIDs limits the step to operations of the given plans, the step is executed for all plans if the list is empty
+	PlanIDs []string
+
+	// Timeout is the maximum time of the step processing counted from the first execution of the step,
+	// the step is given up when the time is exceeded. The time is not limited if the timeout is zero.
+	Timeout time.Duration
+
+	// MaxAttempts is the retry budget of the step, the step is given up when it was executed MaxAttempts times
+	// and still needs to be repeated. Errors returned by the step are retried until the budget is exhausted.
+	// The number of attempts is not limited if MaxAttempts is zero.
+	MaxAttempts int
+
+	// Backoff is the minimum time between attempts, doubled with every attempt up to MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+
+	// Optional steps are skipped when given up, the operation fails when a mandatory step is given up.
+	// Steps which can be optional must return StepFailedError instead of failing the operation themselves.
+	Optional bool
+}
+
+// IsZero returns true if the policy does not define any limits, such steps are executed without recording attempts
+func (p StepPolicy) IsZero() bool {
+	return p.Timeout == 0 && p.MaxAttempts == 0 && p.Backoff == 0 && p.MaxBackoff == 0 && !p.Optional
+}
+
+// EnabledForPlan returns true if the step is executed for operations of the given plan
+func (p StepPolicy) EnabledForPlan(planID string) bool {
+	if len(p.PlanIDs) == 0 {
+		return true
+	}
+	for _, id := range p.PlanIDs {
+		if id == planID {
+			return true
+		}
+	}
+	return false
+}
+
+// Exhausted returns the reason why the step cannot be attempted anymore or an empty string if the step can be attempted
+func (p StepPolicy) Exhausted(attempt internal.StepAttempt, now time.Time) string {
+	if p.Timeout > 0 && !attempt.StartedAt.IsZero() && now.Sub(attempt.StartedAt) > p.Timeout {
+		return fmt.Sprintf("has reached the time limit: %s", p.Timeout)
+	}
+	if p.MaxAttempts > 0 && attempt.Count >= p.MaxAttempts {
+		return fmt.Sprintf("has reached the maximum number of attempts: %d", p.MaxAttempts)
+	}
+	return ""
+}
+
+// Delay returns the minimum time before the next attempt of the step which was already attempted the given number of times
+func (p StepPolicy) Delay(attempts int) time.Duration {
+	if p.Backoff <= 0 || attempts <= 0 {
+		return 0
+	}
+	delay := p.Backoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if p.MaxBackoff > 0 && delay >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return delay
+}
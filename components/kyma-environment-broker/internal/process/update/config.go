@@ -0,0 +1,17 @@
+package update
+
+import "time"
+
+type TimeSchedule struct {
+	Retry               time.Duration
+	StatusCheck         time.Duration
+	UpgradeShootTimeout time.Duration
+}
+
+func defaultTimeSchedule() TimeSchedule {
+	return TimeSchedule{
+		Retry:               5 * time.Second,
+		StatusCheck:         time.Minute,
+		UpgradeShootTimeout: time.Hour,
+	}
+}
@@ -0,0 +1,59 @@
+package update
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/avs"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ExternalEvalStep enables the AVS external evaluation for the runtimes upgraded from the trial plan,
+// the external evaluation is not created for the trial runtimes during provisioning
+type ExternalEvalStep struct {
+	delegator       *avs.Delegator
+	assistant       *avs.ExternalEvalAssistant
+	instanceStorage storage.Instances
+	disabled        bool
+}
+
+func NewExternalEvalStep(delegator *avs.Delegator, assistant *avs.ExternalEvalAssistant, is storage.Instances, disabled bool) *ExternalEvalStep {
+	return &ExternalEvalStep{
+		delegator:       delegator,
+		assistant:       assistant,
+		instanceStorage: is,
+		disabled:        disabled,
+	}
+}
+
+func (s *ExternalEvalStep) Name() string {
+	return "AVS_External_Evaluation"
+}
+
+func (s *ExternalEvalStep) Run(operation internal.UpdatingOperation, log logrus.FieldLogger) (internal.UpdatingOperation, time.Duration, error) {
+	if !operation.IsPlanChange() || !broker.IsTrialPlan(operation.ProvisioningParameters.PlanID) || broker.IsTrialPlan(operation.PlanID) {
+		return operation, 0, nil
+	}
+	if s.disabled {
+		log.Infof("creating AVS external evaluation is disabled")
+		return operation, 0, nil
+	}
+
+	instance, err := s.instanceStorage.GetByID(operation.InstanceID)
+	switch {
+	case err == nil:
+	case dberr.IsNotFound(err):
+		log.Info("instance does not exist, it may have been deprovisioned")
+		return operation, 0, nil
+	default:
+		log.Errorf("unable to get instance from storage: %s", err)
+		return operation, 5 * time.Second, nil
+	}
+
+	log.Infof("creating external evaluation for instance %s", instance.InstanceID)
+	return s.delegator.CreateUpdateEvaluation(log, operation, s.assistant, instance.DashboardURL)
+}
@@ -0,0 +1,160 @@
+package update
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// the time after which the operation is marked as expired
+	CheckStatusTimeout = 3 * time.Hour
+)
+
+type InitialisationStep struct {
+	operationManager  *process.UpdateOperationManager
+	instanceStorage   storage.Instances
+	provisionerClient provisioner.Client
+	timeSchedule      TimeSchedule
+}
+
+func NewInitialisationStep(os storage.Operations, is storage.Instances, pc provisioner.Client, timeSchedule *TimeSchedule) *InitialisationStep {
+	ts := defaultTimeSchedule()
+	if timeSchedule != nil {
+		ts = *timeSchedule
+	}
+	return &InitialisationStep{
+		operationManager:  process.NewUpdateOperationManager(os),
+		instanceStorage:   is,
+		provisionerClient: pc,
+		timeSchedule:      ts,
+	}
+}
+
+func (s *InitialisationStep) Name() string {
+	return "Update_Initialisation"
+}
+
+// Repeatable returns true, the initialisation step checks the status of the provisioner operation,
+// so it must be executed each time the operation is processed
+func (s *InitialisationStep) Repeatable() bool {
+	return true
+}
+
+func (s *InitialisationStep) Run(operation internal.UpdatingOperation, log logrus.FieldLogger) (internal.UpdatingOperation, time.Duration, error) {
+	instance, err := s.instanceStorage.GetByID(operation.InstanceID)
+	switch {
+	case err == nil:
+	case dberr.IsNotFound(err):
+		log.Info("instance does not exist, it may have been deprovisioned")
+		return s.operationManager.OperationFailed(operation, "instance was not found")
+	default:
+		log.Errorf("unable to get instance from storage: %s", err)
+		return operation, s.timeSchedule.Retry, nil
+	}
+
+	operation.InstanceDetails.RuntimeID = instance.RuntimeID
+
+	if operation.ProvisionerOperationID == "" {
+		log.Info("provisioner operation ID is empty, the shoot must be upgraded")
+		return operation, 0, nil
+	}
+	if time.Since(operation.UpdatedAt) > CheckStatusTimeout {
+		log.Infof("operation has reached the time limit: updated operation time: %s", operation.UpdatedAt)
+		return s.operationManager.OperationFailed(operation, fmt.Sprintf("operation has reached the time limit: %s", CheckStatusTimeout))
+	}
+	log = log.WithField("runtimeID", instance.RuntimeID)
+
+	log.Infof("cluster being updated, check operation status")
+	operation, when, err := s.checkProvisionerOperation(operation, instance.GlobalAccountID, operation.ProvisionerOperationID, log)
+	if when != 0 || err != nil {
+		return operation, when, err
+	}
+
+	if operation.IsPlanChange() {
+		if operation.KymaProvisionerOperationID == "" {
+			log.Infof("shoot upgraded, Kyma must be reconfigured for the plan %s", operation.PlanID)
+			return operation, 0, nil
+		}
+
+		log.Infof("Kyma being reconfigured, check operation status")
+		operation, when, err = s.checkProvisionerOperation(operation, instance.GlobalAccountID, operation.KymaProvisionerOperationID, log)
+		if when != 0 || err != nil {
+			return operation, when, err
+		}
+	}
+
+	return s.finishUpdate(operation, instance, log)
+}
+
+// checkProvisionerOperation checks the status of the provisioner operation, it returns zero duration
+// without error only when the provisioner operation succeeded
+func (s *InitialisationStep) checkProvisionerOperation(operation internal.UpdatingOperation, globalAccountID, provisionerOperationID string, log logrus.FieldLogger) (internal.UpdatingOperation, time.Duration, error) {
+	status, err := s.provisionerClient.RuntimeOperationStatus(globalAccountID, provisionerOperationID)
+	if err != nil {
+		return operation, s.timeSchedule.StatusCheck, nil
+	}
+	log.Infof("call to provisioner returned %s status", status.State.String())
+
+	var msg string
+	if status.Message != nil {
+		msg = *status.Message
+	}
+
+	switch status.State {
+	case gqlschema.OperationStateInProgress, gqlschema.OperationStatePending:
+		return operation, s.timeSchedule.StatusCheck, nil
+	case gqlschema.OperationStateSucceeded:
+		return operation, 0, nil
+	case gqlschema.OperationStateFailed:
+		return s.operationManager.OperationFailed(operation, fmt.Sprintf("provisioner client returns failed status: %s", msg))
+	}
+
+	return s.operationManager.OperationFailed(operation, fmt.Sprintf("unsupported provisioner client status: %s", status.State.String()))
+}
+
+// finishUpdate updates the parameters and the plan of the instance and marks the operation as succeeded.
+// The plan change is recorded once, also when the operation is processed again after the instance was updated.
+func (s *InitialisationStep) finishUpdate(operation internal.UpdatingOperation, instance *internal.Instance, log logrus.FieldLogger) (internal.UpdatingOperation, time.Duration, error) {
+	if operation.IsPlanChange() {
+		if !planChangeRecorded(instance, operation.ID) {
+			instance.PlanHistory = append(instance.PlanHistory, internal.PlanChange{
+				FromPlanID:  instance.ServicePlanID,
+				ToPlanID:    operation.PlanID,
+				OperationID: operation.ID,
+				ChangedAt:   time.Now(),
+			})
+		}
+		instance.ServicePlanID = operation.PlanID
+		instance.ServicePlanName = broker.PlanNamesMapping[operation.PlanID]
+		instance.Parameters.PlanID = operation.PlanID
+		instance.Parameters.Parameters.TargetSecret = operation.ProvisioningParameters.Parameters.TargetSecret
+	}
+	operation.UpdatingParameters.UpdateProvisioningParameters(&instance.Parameters.Parameters)
+
+	_, err := s.instanceStorage.Update(*instance)
+	if err != nil {
+		log.Errorf("unable to update instance: %s", err)
+		return operation, s.timeSchedule.Retry, nil
+	}
+
+	return s.operationManager.OperationSucceeded(operation, "update succeeded")
+}
+
+func planChangeRecorded(instance *internal.Instance, operationID string) bool {
+	for _, change := range instance.PlanHistory {
+		if change.OperationID == operationID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,297 @@
+package update
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
+	provisionerAutomock "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner/automock"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	fixOperationID            = "fd5cee4d-0eeb-40d0-a7a7-0708e5eba470"
+	fixInstanceID             = "9d75a545-2e1e-4786-abd8-a37b14e185b9"
+	fixProvisionerOperationID = "e04de524-53b3-4890-b05a-296be393e4ba"
+	fixKymaOperationID        = "5c1d3e2b-7b4a-4a3e-9d4c-0f1f8f0b6b2a"
+)
+
+func TestInitialisationStep_Run(t *testing.T) {
+	t.Run("should go to the next step when the shoot upgrade was not triggered", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		instance := fixInstance(t, memoryStorage)
+		operation := fixUpdatingOperation(t, memoryStorage, instance, "")
+
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Instances(), &provisionerAutomock.Client{}, nil)
+
+		// when
+		operation, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, repeat)
+		assert.Equal(t, domain.InProgress, operation.State)
+		assert.Equal(t, instance.RuntimeID, operation.RuntimeID)
+	})
+
+	t.Run("should update the instance parameters when the shoot upgrade succeeded", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		instance := fixInstance(t, memoryStorage)
+		operation := fixUpdatingOperation(t, memoryStorage, instance, fixProvisionerOperationID)
+
+		provisionerClient := &provisionerAutomock.Client{}
+		provisionerClient.On("RuntimeOperationStatus", instance.GlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
+			ID:    ptr.String(fixProvisionerOperationID),
+			State: gqlschema.OperationStateSucceeded,
+		}, nil)
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Instances(), provisionerClient, nil)
+
+		// when
+		operation, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, repeat)
+		assert.Equal(t, domain.Succeeded, operation.State)
+
+		updatedInstance, err := memoryStorage.Instances().GetByID(fixInstanceID)
+		require.NoError(t, err)
+		assert.Equal(t, "Standard_D8_v3", *updatedInstance.Parameters.Parameters.MachineType)
+		assert.Equal(t, 20, *updatedInstance.Parameters.Parameters.AutoScalerMax)
+		assert.Equal(t, 3, *updatedInstance.Parameters.Parameters.AutoScalerMin)
+	})
+
+	t.Run("should fail the operation when the shoot upgrade failed", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		instance := fixInstance(t, memoryStorage)
+		operation := fixUpdatingOperation(t, memoryStorage, instance, fixProvisionerOperationID)
+
+		provisionerClient := &provisionerAutomock.Client{}
+		provisionerClient.On("RuntimeOperationStatus", instance.GlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
+			ID:      ptr.String(fixProvisionerOperationID),
+			State:   gqlschema.OperationStateFailed,
+			Message: ptr.String("quota exceeded"),
+		}, nil)
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Instances(), provisionerClient, nil)
+
+		// when
+		operation, _, err := step.Run(operation, logrus.New())
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, domain.Failed, operation.State)
+
+		notUpdatedInstance, err := memoryStorage.Instances().GetByID(fixInstanceID)
+		require.NoError(t, err)
+		assert.Equal(t, instance.Parameters.Parameters.MachineType, notUpdatedInstance.Parameters.Parameters.MachineType)
+	})
+
+	t.Run("should check the status again when the shoot upgrade is in progress", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		instance := fixInstance(t, memoryStorage)
+		operation := fixUpdatingOperation(t, memoryStorage, instance, fixProvisionerOperationID)
+
+		provisionerClient := &provisionerAutomock.Client{}
+		provisionerClient.On("RuntimeOperationStatus", instance.GlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
+			ID:    ptr.String(fixProvisionerOperationID),
+			State: gqlschema.OperationStateInProgress,
+		}, nil)
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Instances(), provisionerClient, nil)
+
+		// when
+		operation, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, repeat)
+		assert.Equal(t, domain.InProgress, operation.State)
+	})
+}
+
+func TestInitialisationStep_RunPlanChange(t *testing.T) {
+	t.Run("should go to the next step when the shoot was upgraded to the new plan", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		instance := fixTrialInstance(t, memoryStorage)
+		operation := fixPlanChangeOperation(t, memoryStorage, instance, fixProvisionerOperationID, "")
+
+		provisionerClient := &provisionerAutomock.Client{}
+		provisionerClient.On("RuntimeOperationStatus", instance.GlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
+			ID:    ptr.String(fixProvisionerOperationID),
+			State: gqlschema.OperationStateSucceeded,
+		}, nil)
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Instances(), provisionerClient, nil)
+
+		// when
+		operation, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, repeat)
+		assert.Equal(t, domain.InProgress, operation.State)
+
+		notUpdatedInstance, err := memoryStorage.Instances().GetByID(fixInstanceID)
+		require.NoError(t, err)
+		assert.Equal(t, broker.TrialPlanID, notUpdatedInstance.ServicePlanID)
+	})
+
+	t.Run("should change the plan of the instance when Kyma was reconfigured", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		instance := fixTrialInstance(t, memoryStorage)
+		operation := fixPlanChangeOperation(t, memoryStorage, instance, fixProvisionerOperationID, fixKymaOperationID)
+
+		provisionerClient := &provisionerAutomock.Client{}
+		provisionerClient.On("RuntimeOperationStatus", instance.GlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
+			ID:    ptr.String(fixProvisionerOperationID),
+			State: gqlschema.OperationStateSucceeded,
+		}, nil)
+		provisionerClient.On("RuntimeOperationStatus", instance.GlobalAccountID, fixKymaOperationID).Return(gqlschema.OperationStatus{
+			ID:    ptr.String(fixKymaOperationID),
+			State: gqlschema.OperationStateSucceeded,
+		}, nil)
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Instances(), provisionerClient, nil)
+
+		// when
+		operation, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, repeat)
+		assert.Equal(t, domain.Succeeded, operation.State)
+
+		updatedInstance, err := memoryStorage.Instances().GetByID(fixInstanceID)
+		require.NoError(t, err)
+		assert.Equal(t, broker.AzurePlanID, updatedInstance.ServicePlanID)
+		assert.Equal(t, broker.AzurePlanName, updatedInstance.ServicePlanName)
+		assert.Equal(t, broker.AzurePlanID, updatedInstance.Parameters.PlanID)
+		assert.Equal(t, "azure-secret", *updatedInstance.Parameters.Parameters.TargetSecret)
+		require.Len(t, updatedInstance.PlanHistory, 1)
+		assert.Equal(t, broker.TrialPlanID, updatedInstance.PlanHistory[0].FromPlanID)
+		assert.Equal(t, broker.AzurePlanID, updatedInstance.PlanHistory[0].ToPlanID)
+		assert.Equal(t, fixOperationID, updatedInstance.PlanHistory[0].OperationID)
+	})
+
+	t.Run("should record the plan change once when the operation is processed again", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		instance := fixTrialInstance(t, memoryStorage)
+		operation := fixPlanChangeOperation(t, memoryStorage, instance, fixProvisionerOperationID, fixKymaOperationID)
+
+		// the instance was updated, but the operation was not marked as succeeded
+		instance.PlanHistory = []internal.PlanChange{{
+			FromPlanID:  broker.TrialPlanID,
+			ToPlanID:    broker.AzurePlanID,
+			OperationID: fixOperationID,
+			ChangedAt:   time.Now(),
+		}}
+		instance.ServicePlanID = broker.AzurePlanID
+		instance.Parameters.PlanID = broker.AzurePlanID
+		_, err := memoryStorage.Instances().Update(instance)
+		require.NoError(t, err)
+
+		provisionerClient := &provisionerAutomock.Client{}
+		provisionerClient.On("RuntimeOperationStatus", instance.GlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
+			ID:    ptr.String(fixProvisionerOperationID),
+			State: gqlschema.OperationStateSucceeded,
+		}, nil)
+		provisionerClient.On("RuntimeOperationStatus", instance.GlobalAccountID, fixKymaOperationID).Return(gqlschema.OperationStatus{
+			ID:    ptr.String(fixKymaOperationID),
+			State: gqlschema.OperationStateSucceeded,
+		}, nil)
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Instances(), provisionerClient, nil)
+
+		// when
+		operation, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, repeat)
+		assert.Equal(t, domain.Succeeded, operation.State)
+
+		updatedInstance, err := memoryStorage.Instances().GetByID(fixInstanceID)
+		require.NoError(t, err)
+		assert.Equal(t, broker.AzurePlanID, updatedInstance.ServicePlanID)
+		require.Len(t, updatedInstance.PlanHistory, 1)
+		assert.Equal(t, broker.TrialPlanID, updatedInstance.PlanHistory[0].FromPlanID)
+	})
+
+	t.Run("should check the status again when Kyma reconfiguration is in progress", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		instance := fixTrialInstance(t, memoryStorage)
+		operation := fixPlanChangeOperation(t, memoryStorage, instance, fixProvisionerOperationID, fixKymaOperationID)
+
+		provisionerClient := &provisionerAutomock.Client{}
+		provisionerClient.On("RuntimeOperationStatus", instance.GlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
+			ID:    ptr.String(fixProvisionerOperationID),
+			State: gqlschema.OperationStateSucceeded,
+		}, nil)
+		provisionerClient.On("RuntimeOperationStatus", instance.GlobalAccountID, fixKymaOperationID).Return(gqlschema.OperationStatus{
+			ID:    ptr.String(fixKymaOperationID),
+			State: gqlschema.OperationStateInProgress,
+		}, nil)
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Instances(), provisionerClient, nil)
+
+		// when
+		operation, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, repeat)
+		assert.Equal(t, domain.InProgress, operation.State)
+	})
+}
+
+func fixInstance(t *testing.T, st storage.BrokerStorage) internal.Instance {
+	instance := fixture.FixInstance(fixInstanceID)
+	err := st.Instances().Insert(instance)
+	require.NoError(t, err)
+	return instance
+}
+
+func fixUpdatingOperation(t *testing.T, st storage.BrokerStorage, instance internal.Instance, provisionerOperationID string) internal.UpdatingOperation {
+	operation := internal.NewUpdatingOperationWithID(fixOperationID, &instance, internal.UpdatingParametersDTO{
+		MachineType:   ptr.String("Standard_D8_v3"),
+		AutoScalerMax: ptr.Integer(20),
+	})
+	operation.ProvisionerOperationID = provisionerOperationID
+	err := st.Operations().InsertUpdatingOperation(operation)
+	require.NoError(t, err)
+	return operation
+}
+
+func fixTrialInstance(t *testing.T, st storage.BrokerStorage) internal.Instance {
+	instance := fixture.FixInstance(fixInstanceID)
+	instance.ServicePlanID = broker.TrialPlanID
+	instance.ServicePlanName = broker.TrialPlanName
+	instance.Parameters.PlanID = broker.TrialPlanID
+	err := st.Instances().Insert(instance)
+	require.NoError(t, err)
+	return instance
+}
+
+func fixPlanChangeOperation(t *testing.T, st storage.BrokerStorage, instance internal.Instance, provisionerOperationID, kymaOperationID string) internal.UpdatingOperation {
+	operation := internal.NewUpdatingOperationWithID(fixOperationID, &instance, internal.UpdatingParametersDTO{})
+	operation.RuntimeID = instance.RuntimeID
+	operation.PlanID = broker.AzurePlanID
+	operation.ProvisioningParameters.Parameters.TargetSecret = ptr.String("azure-secret")
+	operation.ProvisionerOperationID = provisionerOperationID
+	operation.KymaProvisionerOperationID = kymaOperationID
+	err := st.Operations().InsertUpdatingOperation(operation)
+	require.NoError(t, err)
+	return operation
+}
@@ -0,0 +1,163 @@
+package update
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/sirupsen/logrus"
+)
+
+type Step interface {
+	Name() string
+	Run(operation internal.UpdatingOperation, logger logrus.FieldLogger) (internal.UpdatingOperation, time.Duration, error)
+}
+
+type Manager struct {
+	log              logrus.FieldLogger
+	steps            map[int][]Step
+	operationStorage storage.Operations
+
+	publisher event.Publisher
+}
+
+func NewManager(storage storage.Operations, pub event.Publisher, logger logrus.FieldLogger) *Manager {
+	return &Manager{
+		log:              logger,
+		steps:            make(map[int][]Step, 0),
+		operationStorage: storage,
+		publisher:        pub,
+	}
+}
+
+func (m *Manager) InitStep(step Step) {
+	m.AddStep(0, step)
+}
+
+func (m *Manager) AddStep(weight int, step Step) {
+	if weight <= 0 {
+		weight = 1
+	}
+	m.steps[weight] = append(m.steps[weight], step)
+}
+
+func (m *Manager) runStep(step Step, operation internal.UpdatingOperation, logger logrus.FieldLogger) (internal.UpdatingOperation, time.Duration, error) {
+	start := time.Now()
+	processedOperation, when, err := step.Run(operation, logger)
+	ev := process.UpdatingStepProcessed{
+		OldOperation:  operation,
+		Operation:     processedOperation,
+		StepProcessed: process.NewStepProcessed(operation.ID, step.Name(), start, when, err),
+	}
+	if processedOperation.Operation.ID == "" {
+		m.publisher.Publish(context.TODO(), ev)
+		return processedOperation, when, err
+	}
+	// the event is stored in one transaction with the next update of the operation, so it is not lost when KEB stops,
+	// see storePendingEvents
+	processedOperation.AddPendingEvents(event.Stage(m.publisher, ev))
+	return processedOperation, when, err
+}
+
+func (m *Manager) Execute(operationID string) (time.Duration, error) {
+	op, err := m.operationStorage.GetUpdatingOperationByID(operationID)
+	if err != nil {
+		m.log.Errorf("Cannot fetch operation from storage: %s", err)
+		return 3 * time.Second, nil
+	}
+	operation := *op
+	if operation.IsFinished() {
+		return 0, nil
+	}
+
+	var when time.Duration
+	logOperation := m.log.WithFields(logrus.Fields{"operation": operationID, "instanceID": operation.InstanceID})
+	// the events of the steps which were not stored with the updates of the operation made by the steps
+	defer func() { m.storePendingEvents(operation, logOperation) }()
+
+	logOperation.Info("Start process operation steps")
+	for _, weightStep := range m.sortWeight() {
+		steps := m.steps[weightStep]
+		for _, step := range steps {
+			logStep := logOperation.WithField("step", step.Name())
+			if operation.IsStepFinished(step.Name()) && !process.IsStepRepeatable(step) {
+				logStep.Info("Step already finished, skipping")
+				continue
+			}
+			logStep.Infof("Start step")
+
+			operation, when, err = m.runStep(step, operation, logStep)
+			if err != nil {
+				logStep.Errorf("Process operation failed: %s", err)
+				return 0, err
+			}
+			if operation.State != domain.InProgress {
+				logStep.Infof("Operation %q got status %s. Process finished.", operation.Operation.ID, operation.State)
+				return 0, nil
+			}
+			if when == 0 {
+				logStep.Info("Process operation successful")
+				operation, when = m.finishStep(step, operation, logStep)
+				if when != 0 {
+					return when, nil
+				}
+				continue
+			}
+
+			logStep.Infof("Process operation will be repeated in %s ...", when)
+			return when, nil
+		}
+	}
+
+	logOperation.Infof("Operation %q got status %s. All steps finished.", operation.Operation.ID, operation.State)
+	return 0, nil
+}
+
+// storePendingEvents stores the events of the processed steps which were not stored with the updates of the operation,
+// the operation is updated only if there are such events
+func (m *Manager) storePendingEvents(operation internal.UpdatingOperation, logger logrus.FieldLogger) internal.UpdatingOperation {
+	err := event.StorePending(m.publisher, operation.PendingEvents(), func() error {
+		updated, err := m.operationStorage.UpdateUpdatingOperation(operation)
+		if err != nil {
+			return err
+		}
+		operation = *updated
+		return nil
+	})
+	if err != nil {
+		logger.Warnf("unable to store the events of the steps with the operation, the events were stored separately: %s", err)
+		operation.TakePendingEvents()
+	}
+	return operation
+}
+
+// finishStep stores the information that the step was successfully processed,
+// so the step is not executed again when the operation is retried
+func (m *Manager) finishStep(step Step, operation internal.UpdatingOperation, logger logrus.FieldLogger) (internal.UpdatingOperation, time.Duration) {
+	if process.IsStepRepeatable(step) {
+		return operation, 0
+	}
+	operation.FinishStep(step.Name())
+	updated, err := m.operationStorage.UpdateUpdatingOperation(operation)
+	if err != nil {
+		logger.Errorf("Cannot save finished step: %s", err)
+		return operation, time.Second
+	}
+	return *updated, 0
+}
+
+func (m *Manager) sortWeight() []int {
+	var weight []int
+	for w := range m.steps {
+		weight = append(weight, w)
+	}
+	sort.Ints(weight)
+
+	return weight
+}
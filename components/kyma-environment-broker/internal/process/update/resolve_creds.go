@@ -0,0 +1,62 @@
+package update
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/hyperscaler"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ResolveCredentialsStep resolves the hyperscaler account of the global account for the new plan,
+// the runtimes of the trial plan use the shared accounts which cannot be used by the other plans
+type ResolveCredentialsStep struct {
+	operationManager *process.UpdateOperationManager
+	accountProvider  hyperscaler.AccountProvider
+}
+
+func NewResolveCredentialsStep(os storage.Operations, accountProvider hyperscaler.AccountProvider) *ResolveCredentialsStep {
+	return &ResolveCredentialsStep{
+		operationManager: process.NewUpdateOperationManager(os),
+		accountProvider:  accountProvider,
+	}
+}
+
+func (s *ResolveCredentialsStep) Name() string {
+	return "Resolve_Target_Secret"
+}
+
+func (s *ResolveCredentialsStep) Run(operation internal.UpdatingOperation, log logrus.FieldLogger) (internal.UpdatingOperation, time.Duration, error) {
+	if !operation.IsPlanChange() {
+		return operation, 0, nil
+	}
+
+	hypType, err := hyperscaler.HyperscalerTypeForPlanID(operation.PlanID)
+	if err != nil {
+		log.Errorf("Aborting after failing to determine the type of Hyperscaler to use for planID: %s", operation.PlanID)
+		return s.operationManager.OperationFailed(operation, err.Error())
+	}
+	globalAccountID := operation.ProvisioningParameters.ErsContext.GlobalAccountID
+
+	log.Infof("HAP lookup for credentials to upgrade cluster for global account ID %s on Hyperscaler %s", globalAccountID, hypType)
+	credentials, err := s.accountProvider.GardenerCredentials(hypType, globalAccountID)
+	if err != nil {
+		errMsg := fmt.Sprintf("HAP lookup for credentials to upgrade cluster for global account ID %s on Hyperscaler %s has failed: %s", globalAccountID, hypType, err)
+		log.Info(errMsg)
+		return s.operationManager.RetryOperation(operation, errMsg, 10*time.Second, 10*time.Minute, log)
+	}
+	operation.ProvisioningParameters.Parameters.TargetSecret = &credentials.Name
+
+	operation, repeat := s.operationManager.UpdateOperation(operation)
+	if repeat != 0 {
+		log.Errorf("cannot save the target secret")
+		return operation, repeat, nil
+	}
+	log.Infof("Resolved %s as target secret name for global account ID %s on Hyperscaler %s", credentials.Name, globalAccountID, hypType)
+
+	return operation, 0, nil
+}
@@ -0,0 +1,76 @@
+package update
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/hyperscaler"
+	hyperscalerMocks "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/hyperscaler/automock"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveCredentialsStep_Run(t *testing.T) {
+	t.Run("should resolve the target secret for the new plan", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		instance := fixTrialInstance(t, memoryStorage)
+		operation := fixPlanChangeOperation(t, memoryStorage, instance, "", "")
+
+		accountProviderMock := &hyperscalerMocks.AccountProvider{}
+		accountProviderMock.On("GardenerCredentials", hyperscaler.Azure, instance.GlobalAccountID).Return(hyperscaler.Credentials{
+			Name:            "gardener-secret-azure",
+			HyperscalerType: hyperscaler.Azure,
+			CredentialData:  map[string][]byte{},
+		}, nil)
+		step := NewResolveCredentialsStep(memoryStorage.Operations(), accountProviderMock)
+
+		// when
+		operation, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, repeat)
+		assert.Equal(t, "gardener-secret-azure", *operation.ProvisioningParameters.Parameters.TargetSecret)
+		accountProviderMock.AssertExpectations(t)
+	})
+
+	t.Run("should retry when the credentials cannot be resolved", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		instance := fixTrialInstance(t, memoryStorage)
+		operation := fixPlanChangeOperation(t, memoryStorage, instance, "", "")
+
+		accountProviderMock := &hyperscalerMocks.AccountProvider{}
+		accountProviderMock.On("GardenerCredentials", hyperscaler.Azure, instance.GlobalAccountID).Return(hyperscaler.Credentials{}, errors.New("no free secret"))
+		step := NewResolveCredentialsStep(memoryStorage.Operations(), accountProviderMock)
+
+		// when
+		operation, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.NotZero(t, repeat)
+		assert.Equal(t, domain.InProgress, operation.State)
+	})
+
+	t.Run("should skip the step when the plan is not changed", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		instance := fixInstance(t, memoryStorage)
+		operation := fixUpdatingOperation(t, memoryStorage, instance, "")
+
+		step := NewResolveCredentialsStep(memoryStorage.Operations(), &hyperscalerMocks.AccountProvider{})
+
+		// when
+		_, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, repeat)
+	})
+}
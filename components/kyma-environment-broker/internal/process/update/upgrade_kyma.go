@@ -0,0 +1,142 @@
+package update
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/input"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtimeoverrides"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+type RuntimeOverridesAppender interface {
+	Append(input runtimeoverrides.InputAppender, planID, kymaVersion string) error
+}
+
+// UpgradeKymaStep reconfigures Kyma for the new plan, the components and overrides
+// specific for the previous plan (for example the NATS overrides of the trial plan) are replaced
+type UpgradeKymaStep struct {
+	operationManager    *process.UpdateOperationManager
+	runtimeStateStorage storage.RuntimeStates
+	provisionerClient   provisioner.Client
+	inputBuilder        input.CreatorForPlan
+	runtimeOverrides    RuntimeOverridesAppender
+	timeSchedule        TimeSchedule
+}
+
+func NewUpgradeKymaStep(os storage.Operations, rs storage.RuntimeStates, cli provisioner.Client, b input.CreatorForPlan, ro RuntimeOverridesAppender, timeSchedule *TimeSchedule) *UpgradeKymaStep {
+	ts := defaultTimeSchedule()
+	if timeSchedule != nil {
+		ts = *timeSchedule
+	}
+	return &UpgradeKymaStep{
+		operationManager:    process.NewUpdateOperationManager(os),
+		runtimeStateStorage: rs,
+		provisionerClient:   cli,
+		inputBuilder:        b,
+		runtimeOverrides:    ro,
+		timeSchedule:        ts,
+	}
+}
+
+func (s *UpgradeKymaStep) Name() string {
+	return "Upgrade_Kyma"
+}
+
+func (s *UpgradeKymaStep) Run(operation internal.UpdatingOperation, log logrus.FieldLogger) (internal.UpdatingOperation, time.Duration, error) {
+	if !operation.IsPlanChange() || operation.KymaProvisionerOperationID != "" {
+		return operation, 0, nil
+	}
+
+	planName, exists := broker.PlanNamesMapping[operation.PlanID]
+	if !exists {
+		log.Errorf("cannot map planID '%s' to planName", operation.PlanID)
+		return s.operationManager.OperationFailed(operation, "invalid operation plan")
+	}
+
+	version, err := s.kymaVersion(operation.RuntimeID)
+	if err != nil {
+		log.Errorf("cannot determine the Kyma version: %s", err)
+		return s.operationManager.RetryOperation(operation, err.Error(), 5*time.Second, 5*time.Minute, log)
+	}
+
+	pp := operation.TargetProvisioningParameters()
+	creator, err := s.inputBuilder.CreateUpgradeInput(pp, *internal.NewRuntimeVersionFromDefaults(version))
+	if err != nil {
+		log.Errorf("cannot create input creator for plan %s: %s", operation.PlanID, err)
+		return s.operationManager.OperationFailed(operation, "cannot create upgrade runtime input creator")
+	}
+	creator.SetProvisioningParameters(pp)
+
+	if err := s.runtimeOverrides.Append(creator, planName, version); err != nil {
+		log.Errorf(err.Error())
+		return s.operationManager.RetryOperation(operation, err.Error(), 10*time.Second, 30*time.Minute, log)
+	}
+
+	requestInput, err := creator.CreateUpgradeRuntimeInput()
+	if err != nil {
+		log.Errorf("cannot create upgrade runtime input: %s", err)
+		return s.operationManager.OperationFailed(operation, "invalid operation data - cannot create upgradeRuntime input")
+	}
+
+	// trigger upgradeRuntime mutation
+	provisionerResponse, err := s.provisionerClient.UpgradeRuntime(operation.ProvisioningParameters.ErsContext.GlobalAccountID, operation.RuntimeID, requestInput)
+	if err != nil {
+		log.Errorf("call to provisioner failed: %s", err)
+		return operation, s.timeSchedule.Retry, nil
+	}
+	if provisionerResponse.ID == nil {
+		log.Errorf("provisioner returned empty operation ID")
+		return operation, s.timeSchedule.Retry, nil
+	}
+	operation.KymaProvisionerOperationID = *provisionerResponse.ID
+	operation.Description = "reconfiguration of Kyma for the new plan in progress"
+
+	operation, repeat := s.operationManager.UpdateOperation(operation)
+	if repeat != 0 {
+		log.Errorf("cannot save operation ID from provisioner")
+		return operation, s.timeSchedule.Retry, nil
+	}
+	log.Infof("call to provisioner succeeded, got operation ID %q", operation.KymaProvisionerOperationID)
+
+	err = s.runtimeStateStorage.Insert(
+		internal.NewRuntimeState(operation.RuntimeID, operation.Operation.ID, requestInput.KymaConfig, nil),
+	)
+	if err != nil {
+		log.Errorf("cannot insert runtimeState: %s", err)
+	}
+
+	// return repeat mode to start the initialization step which will now check the operation status
+	return operation, s.timeSchedule.Retry, nil
+}
+
+// kymaVersion returns the Kyma version from the last runtime state which contains the Kyma configuration
+func (s *UpgradeKymaStep) kymaVersion(runtimeID string) (string, error) {
+	states, err := s.runtimeStateStorage.ListByRuntimeID(runtimeID)
+	if err != nil && !dberr.IsNotFound(err) {
+		return "", errors.Wrapf(err, "while listing runtime states of runtime %s", runtimeID)
+	}
+
+	var (
+		version string
+		last    time.Time
+	)
+	for _, state := range states {
+		if state.KymaConfig.Version != "" && state.CreatedAt.After(last) {
+			version = state.KymaConfig.Version
+			last = state.CreatedAt
+		}
+	}
+	if version == "" {
+		return "", errors.Errorf("runtime %s has no state with the Kyma version", runtimeID)
+	}
+
+	return version, nil
+}
@@ -0,0 +1,88 @@
+package update
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	upgradeKymaAutomock "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/upgrade_kyma/automock"
+	provisionerAutomock "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner/automock"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const fixKymaVersion = "1.19.0"
+
+func TestUpgradeKymaStep_Run(t *testing.T) {
+	t.Run("should trigger the Kyma upgrade with the configuration of the new plan", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		instance := fixTrialInstance(t, memoryStorage)
+		operation := fixPlanChangeOperation(t, memoryStorage, instance, fixProvisionerOperationID, "")
+		err := memoryStorage.RuntimeStates().Insert(internal.NewRuntimeState(instance.RuntimeID, "provisioning-01", &gqlschema.KymaConfigInput{Version: fixKymaVersion}, nil))
+		require.NoError(t, err)
+
+		upgradeInput := gqlschema.UpgradeRuntimeInput{
+			KymaConfig: &gqlschema.KymaConfigInput{Version: fixKymaVersion},
+		}
+		inputCreator := &upgradeKymaAutomock.ProvisionerInputCreator{}
+		inputCreator.On("SetProvisioningParameters", mock.MatchedBy(func(pp internal.ProvisioningParameters) bool {
+			return pp.PlanID == broker.AzurePlanID
+		})).Return(inputCreator).Once()
+		inputCreator.On("CreateUpgradeRuntimeInput").Return(upgradeInput, nil).Once()
+
+		inputBuilder := &upgradeKymaAutomock.CreatorForPlan{}
+		inputBuilder.On("CreateUpgradeInput", mock.MatchedBy(func(pp internal.ProvisioningParameters) bool {
+			return pp.PlanID == broker.AzurePlanID
+		}), internal.RuntimeVersionData{Version: fixKymaVersion, Origin: internal.Defaults}).Return(inputCreator, nil).Once()
+
+		runtimeOverrides := &upgradeKymaAutomock.RuntimeOverridesAppender{}
+		runtimeOverrides.On("Append", inputCreator, broker.AzurePlanName, fixKymaVersion).Return(nil).Once()
+
+		provisionerClient := &provisionerAutomock.Client{}
+		provisionerClient.On("UpgradeRuntime", instance.GlobalAccountID, instance.RuntimeID, upgradeInput).Return(gqlschema.OperationStatus{
+			ID: ptr.String(fixKymaOperationID),
+		}, nil).Once()
+
+		step := NewUpgradeKymaStep(memoryStorage.Operations(), memoryStorage.RuntimeStates(), provisionerClient, inputBuilder, runtimeOverrides, nil)
+
+		// when
+		operation, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 5*time.Second, repeat)
+		assert.Equal(t, fixKymaOperationID, operation.KymaProvisionerOperationID)
+		inputBuilder.AssertExpectations(t)
+		inputCreator.AssertExpectations(t)
+		runtimeOverrides.AssertExpectations(t)
+		provisionerClient.AssertExpectations(t)
+
+		state, err := memoryStorage.RuntimeStates().GetByOperationID(fixOperationID)
+		require.NoError(t, err)
+		assert.Equal(t, fixKymaVersion, state.KymaConfig.Version)
+	})
+
+	t.Run("should skip the step when Kyma upgrade was already triggered", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		instance := fixTrialInstance(t, memoryStorage)
+		operation := fixPlanChangeOperation(t, memoryStorage, instance, fixProvisionerOperationID, fixKymaOperationID)
+
+		step := NewUpgradeKymaStep(memoryStorage.Operations(), memoryStorage.RuntimeStates(), &provisionerAutomock.Client{}, &upgradeKymaAutomock.CreatorForPlan{}, &upgradeKymaAutomock.RuntimeOverridesAppender{}, nil)
+
+		// when
+		_, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, repeat)
+	})
+}
@@ -0,0 +1,98 @@
+package update
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/input"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+
+	"github.com/sirupsen/logrus"
+)
+
+type UpgradeShootStep struct {
+	operationManager  *process.UpdateOperationManager
+	provisionerClient provisioner.Client
+	inputBuilder      input.CreatorForPlan
+	timeSchedule      TimeSchedule
+}
+
+func NewUpgradeShootStep(os storage.Operations, cli provisioner.Client, b input.CreatorForPlan, timeSchedule *TimeSchedule) *UpgradeShootStep {
+	ts := defaultTimeSchedule()
+	if timeSchedule != nil {
+		ts = *timeSchedule
+	}
+	return &UpgradeShootStep{
+		operationManager:  process.NewUpdateOperationManager(os),
+		provisionerClient: cli,
+		inputBuilder:      b,
+		timeSchedule:      ts,
+	}
+}
+
+func (s *UpgradeShootStep) Name() string {
+	return "Upgrade_Shoot"
+}
+
+func (s *UpgradeShootStep) Run(operation internal.UpdatingOperation, log logrus.FieldLogger) (internal.UpdatingOperation, time.Duration, error) {
+	if operation.ProvisionerOperationID != "" {
+		// the upgrade was already triggered, the initialisation step checks its status
+		return operation, 0, nil
+	}
+	if time.Since(operation.UpdatedAt) > s.timeSchedule.UpgradeShootTimeout {
+		log.Infof("operation has reached the time limit: updated operation time: %s", operation.UpdatedAt)
+		return s.operationManager.OperationFailed(operation, fmt.Sprintf("operation has reached the time limit: %s", s.timeSchedule.UpgradeShootTimeout))
+	}
+
+	requestInput := upgradeShootInput(operation.UpdatingParameters)
+	if operation.IsPlanChange() {
+		// the shoot gets the specification of the new plan
+		planInput, err := s.inputBuilder.CreatePlanUpgradeShootInput(operation.TargetProvisioningParameters())
+		if err != nil {
+			log.Errorf("cannot create upgrade shoot input for plan %s: %s", operation.PlanID, err)
+			return s.operationManager.OperationFailed(operation, "cannot create upgrade shoot input")
+		}
+		requestInput = planInput
+	}
+
+	// trigger upgradeShoot mutation
+	provisionerResponse, err := s.provisionerClient.UpgradeShoot(operation.ProvisioningParameters.ErsContext.GlobalAccountID, operation.RuntimeID, requestInput)
+	if err != nil {
+		log.Errorf("call to provisioner failed: %s", err)
+		return operation, s.timeSchedule.Retry, nil
+	}
+	if provisionerResponse.ID == nil {
+		log.Errorf("provisioner returned empty operation ID")
+		return operation, s.timeSchedule.Retry, nil
+	}
+	operation.ProvisionerOperationID = *provisionerResponse.ID
+	operation.Description = "update of the cluster in progress"
+
+	operation, repeat := s.operationManager.UpdateOperation(operation)
+	if repeat != 0 {
+		log.Errorf("cannot save operation ID from provisioner")
+		return operation, s.timeSchedule.Retry, nil
+	}
+	log.Infof("call to provisioner succeeded, got operation ID %q", operation.ProvisionerOperationID)
+
+	// return repeat mode to start the initialization step which will now check the operation status
+	return operation, s.timeSchedule.Retry, nil
+}
+
+// upgradeShootInput creates the input which changes only the updated parameters of the shoot
+func upgradeShootInput(params internal.UpdatingParametersDTO) gqlschema.UpgradeShootInput {
+	return gqlschema.UpgradeShootInput{
+		GardenerConfig: &gqlschema.GardenerUpgradeInput{
+			MachineType:    params.MachineType,
+			VolumeSizeGb:   params.VolumeSizeGb,
+			AutoScalerMin:  params.AutoScalerMin,
+			AutoScalerMax:  params.AutoScalerMax,
+			MaxSurge:       params.MaxSurge,
+			MaxUnavailable: params.MaxUnavailable,
+		},
+	}
+}
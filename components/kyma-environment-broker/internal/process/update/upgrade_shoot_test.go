@@ -0,0 +1,87 @@
+package update
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	upgradeKymaAutomock "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/upgrade_kyma/automock"
+	provisionerAutomock "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner/automock"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeShootStep_Run(t *testing.T) {
+	// given
+	memoryStorage := storage.NewMemoryStorage()
+	instance := fixInstance(t, memoryStorage)
+	operation := fixUpdatingOperation(t, memoryStorage, instance, "")
+	operation.RuntimeID = instance.RuntimeID
+
+	provisionerClient := &provisionerAutomock.Client{}
+	provisionerClient.On("UpgradeShoot", instance.GlobalAccountID, instance.RuntimeID, gqlschema.UpgradeShootInput{
+		GardenerConfig: &gqlschema.GardenerUpgradeInput{
+			MachineType:   ptr.String("Standard_D8_v3"),
+			AutoScalerMax: ptr.Integer(20),
+		},
+	}).Return(gqlschema.OperationStatus{
+		ID: ptr.String(fixProvisionerOperationID),
+	}, nil).Once()
+	defer provisionerClient.AssertExpectations(t)
+
+	step := NewUpgradeShootStep(memoryStorage.Operations(), provisionerClient, nil, nil)
+
+	// when
+	operation, repeat, err := step.Run(operation, logrus.New())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, repeat)
+	assert.Equal(t, fixProvisionerOperationID, operation.ProvisionerOperationID)
+
+	storedOperation, err := memoryStorage.Operations().GetUpdatingOperationByID(fixOperationID)
+	require.NoError(t, err)
+	assert.Equal(t, fixProvisionerOperationID, storedOperation.ProvisionerOperationID)
+}
+
+func TestUpgradeShootStep_RunPlanChange(t *testing.T) {
+	// given
+	memoryStorage := storage.NewMemoryStorage()
+	instance := fixTrialInstance(t, memoryStorage)
+	operation := fixPlanChangeOperation(t, memoryStorage, instance, "", "")
+
+	planInput := gqlschema.UpgradeShootInput{
+		GardenerConfig: &gqlschema.GardenerUpgradeInput{
+			MachineType:  ptr.String("Standard_D8_v3"),
+			TargetSecret: ptr.String("azure-secret"),
+		},
+	}
+	inputBuilder := &upgradeKymaAutomock.CreatorForPlan{}
+	inputBuilder.On("CreatePlanUpgradeShootInput", mock.MatchedBy(func(pp internal.ProvisioningParameters) bool {
+		return pp.PlanID == broker.AzurePlanID && pp.Parameters.TargetSecret != nil && *pp.Parameters.TargetSecret == "azure-secret"
+	})).Return(planInput, nil).Once()
+	defer inputBuilder.AssertExpectations(t)
+
+	provisionerClient := &provisionerAutomock.Client{}
+	provisionerClient.On("UpgradeShoot", instance.GlobalAccountID, instance.RuntimeID, planInput).Return(gqlschema.OperationStatus{
+		ID: ptr.String(fixProvisionerOperationID),
+	}, nil).Once()
+	defer provisionerClient.AssertExpectations(t)
+
+	step := NewUpgradeShootStep(memoryStorage.Operations(), provisionerClient, inputBuilder, nil)
+
+	// when
+	operation, repeat, err := step.Run(operation, logrus.New())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, repeat)
+	assert.Equal(t, fixProvisionerOperationID, operation.ProvisionerOperationID)
+}
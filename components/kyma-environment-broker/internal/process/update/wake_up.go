@@ -0,0 +1,64 @@
+package update
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/sirupsen/logrus"
+)
+
+// WakeUpStep wakes up the runtime hibernated during the suspension of the instance
+type WakeUpStep struct {
+	operationManager  *process.UpdateOperationManager
+	provisionerClient provisioner.Client
+	timeSchedule      TimeSchedule
+}
+
+func NewWakeUpStep(os storage.Operations, cli provisioner.Client, timeSchedule *TimeSchedule) *WakeUpStep {
+	ts := defaultTimeSchedule()
+	if timeSchedule != nil {
+		ts = *timeSchedule
+	}
+	return &WakeUpStep{
+		operationManager:  process.NewUpdateOperationManager(os),
+		provisionerClient: cli,
+		timeSchedule:      ts,
+	}
+}
+
+func (s *WakeUpStep) Name() string {
+	return "Wake_Up_Runtime"
+}
+
+func (s *WakeUpStep) Run(operation internal.UpdatingOperation, log logrus.FieldLogger) (internal.UpdatingOperation, time.Duration, error) {
+	if !operation.WakeUp || operation.ProvisionerOperationID != "" {
+		return operation, 0, nil
+	}
+
+	// trigger wakeUpRuntime mutation
+	provisionerResponse, err := s.provisionerClient.WakeUpRuntime(operation.ProvisioningParameters.ErsContext.GlobalAccountID, operation.RuntimeID)
+	if err != nil {
+		log.Errorf("call to provisioner failed: %s", err)
+		return operation, s.timeSchedule.Retry, nil
+	}
+	if provisionerResponse.ID == nil {
+		log.Errorf("provisioner returned empty operation ID")
+		return operation, s.timeSchedule.Retry, nil
+	}
+	operation.ProvisionerOperationID = *provisionerResponse.ID
+	operation.Description = "wake up of the runtime in progress"
+
+	operation, repeat := s.operationManager.UpdateOperation(operation)
+	if repeat != 0 {
+		log.Errorf("cannot save operation ID from provisioner")
+		return operation, s.timeSchedule.Retry, nil
+	}
+	log.Infof("call to provisioner succeeded, got operation ID %q", operation.ProvisionerOperationID)
+
+	// return repeat mode to start the initialization step which will now check the operation status
+	return operation, s.timeSchedule.Retry, nil
+}
@@ -0,0 +1,67 @@
+package update
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	provisionerAutomock "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner/automock"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWakeUpStep_Run(t *testing.T) {
+	t.Run("should wake up the hibernated runtime", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		instance := fixTrialInstance(t, memoryStorage)
+		operation := internal.NewWakeUpOperationWithID(fixOperationID, &instance)
+		err := memoryStorage.Operations().InsertUpdatingOperation(operation)
+		require.NoError(t, err)
+
+		provisionerClient := &provisionerAutomock.Client{}
+		provisionerClient.On("WakeUpRuntime", instance.GlobalAccountID, instance.RuntimeID).Return(gqlschema.OperationStatus{
+			ID: ptr.String(fixProvisionerOperationID),
+		}, nil).Once()
+		defer provisionerClient.AssertExpectations(t)
+
+		step := NewWakeUpStep(memoryStorage.Operations(), provisionerClient, nil)
+
+		// when
+		operation, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 5*time.Second, repeat)
+		assert.Equal(t, fixProvisionerOperationID, operation.ProvisionerOperationID)
+
+		storedOperation, err := memoryStorage.Operations().GetUpdatingOperationByID(fixOperationID)
+		require.NoError(t, err)
+		assert.Equal(t, fixProvisionerOperationID, storedOperation.ProvisionerOperationID)
+		assert.True(t, storedOperation.WakeUp)
+	})
+
+	t.Run("should skip the operation which is not a wake up", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		instance := fixInstance(t, memoryStorage)
+		operation := fixUpdatingOperation(t, memoryStorage, instance, "")
+
+		provisionerClient := &provisionerAutomock.Client{}
+		defer provisionerClient.AssertExpectations(t)
+
+		step := NewWakeUpStep(memoryStorage.Operations(), provisionerClient, nil)
+
+		// when
+		_, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, repeat)
+	})
+}
@@ -0,0 +1,73 @@
+package process
+
+import (
+	"errors"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/sirupsen/logrus"
+)
+
+type UpdateOperationManager struct {
+	storage storage.Updating
+}
+
+func NewUpdateOperationManager(storage storage.Operations) *UpdateOperationManager {
+	return &UpdateOperationManager{storage: storage}
+}
+
+// OperationSucceeded marks the operation as succeeded and only repeats it if there is a storage error
+func (om *UpdateOperationManager) OperationSucceeded(operation internal.UpdatingOperation, description string) (internal.UpdatingOperation, time.Duration, error) {
+	updatedOperation, repeat := om.update(operation, domain.Succeeded, description)
+	// repeat in case of storage error
+	if repeat != 0 {
+		return updatedOperation, repeat, nil
+	}
+
+	return updatedOperation, 0, nil
+}
+
+// OperationFailed marks the operation as failed and only repeats it if there is a storage error
+func (om *UpdateOperationManager) OperationFailed(operation internal.UpdatingOperation, description string) (internal.UpdatingOperation, time.Duration, error) {
+	updatedOperation, repeat := om.update(operation, domain.Failed, description)
+	// repeat in case of storage error
+	if repeat != 0 {
+		return updatedOperation, repeat, nil
+	}
+
+	return updatedOperation, 0, errors.New(description)
+}
+
+// RetryOperation retries an operation for at maxTime in retryInterval steps and fails the operation if retrying failed
+func (om *UpdateOperationManager) RetryOperation(operation internal.UpdatingOperation, errorMessage string, retryInterval time.Duration, maxTime time.Duration, log logrus.FieldLogger) (internal.UpdatingOperation, time.Duration, error) {
+	since := time.Since(operation.UpdatedAt)
+
+	log.Infof("Retry Operation was triggered with message: %s", errorMessage)
+	log.Infof("Retrying for %s in %s steps", maxTime.String(), retryInterval.String())
+	if since < maxTime {
+		return operation, retryInterval, nil
+	}
+	log.Errorf("Aborting after %s of failing retries", maxTime.String())
+	return om.OperationFailed(operation, errorMessage)
+}
+
+// UpdateOperation updates a given operation
+func (om *UpdateOperationManager) UpdateOperation(operation internal.UpdatingOperation) (internal.UpdatingOperation, time.Duration) {
+	updatedOperation, err := om.storage.UpdateUpdatingOperation(operation)
+	if err != nil {
+		logrus.WithField("instanceID", operation.InstanceID).
+			Errorf("Update updating operation failed: %s", err.Error())
+		return operation, 1 * time.Minute
+	}
+	return *updatedOperation, 0
+}
+
+func (om *UpdateOperationManager) update(operation internal.UpdatingOperation, state domain.LastOperationState, description string) (internal.UpdatingOperation, time.Duration) {
+	operation.State = state
+	operation.Description = description
+
+	return om.UpdateOperation(operation)
+}
@@ -0,0 +1,184 @@
+package upgrade_cluster
+
+import (
+	"fmt"
+	"time"
+
+	orchestrationExt "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	kebError "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/error"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/input"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// the time after which the operation is marked as expired
+	CheckStatusTimeout = 3 * time.Hour
+)
+
+type InitialisationStep struct {
+	operationManager     *process.UpgradeClusterOperationManager
+	operationStorage     storage.Operations
+	orchestrationStorage storage.Orchestrations
+	instanceStorage      storage.Instances
+	provisionerClient    provisioner.Client
+	inputBuilder         input.CreatorForPlan
+	timeSchedule         TimeSchedule
+}
+
+func NewInitialisationStep(os storage.Operations, ors storage.Orchestrations, is storage.Instances, pc provisioner.Client, b input.CreatorForPlan, timeSchedule *TimeSchedule) *InitialisationStep {
+	ts := timeSchedule
+	if ts == nil {
+		ts = &TimeSchedule{
+			Retry:                 5 * time.Second,
+			StatusCheck:           time.Minute,
+			UpgradeClusterTimeout: time.Hour,
+		}
+	}
+	return &InitialisationStep{
+		operationManager:     process.NewUpgradeClusterOperationManager(os),
+		operationStorage:     os,
+		orchestrationStorage: ors,
+		instanceStorage:      is,
+		provisionerClient:    pc,
+		inputBuilder:         b,
+		timeSchedule:         *ts,
+	}
+}
+
+func (s *InitialisationStep) Name() string {
+	return "Upgrade_Cluster_Initialisation"
+}
+
+// Repeatable returns true, the initialisation step checks the operation state and prepares data
+// which is not stored in the storage, so it must be executed each time the operation is processed
+func (s *InitialisationStep) Repeatable() bool {
+	return true
+}
+
+func (s *InitialisationStep) Run(operation internal.UpgradeClusterOperation, log logrus.FieldLogger) (internal.UpgradeClusterOperation, time.Duration, error) {
+	orchestration, err := s.orchestrationStorage.GetByID(operation.OrchestrationID)
+	if err != nil {
+		return operation, s.timeSchedule.Retry, nil
+	}
+	if orchestration.IsCanceled() {
+		log.Infof("Skipping processing because orchestration %s was canceled", operation.OrchestrationID)
+		return s.operationManager.OperationCanceled(operation, fmt.Sprintf("orchestration %s was canceled", operation.OrchestrationID))
+	}
+	if orchestration.State == orchestrationExt.Paused && operation.State == orchestrationExt.Pending {
+		log.Infof("Skipping processing because orchestration %s was paused", operation.OrchestrationID)
+		return operation, s.timeSchedule.Retry, nil
+	}
+
+	if operation.State == orchestrationExt.Pending {
+		operation.State = orchestrationExt.InProgress
+
+		op, err := s.operationStorage.UpdateUpgradeClusterOperation(operation)
+		if err != nil {
+			log.Errorf("while updating operation: %v", err)
+			return operation, s.timeSchedule.Retry, nil
+		}
+		operation = *op
+	}
+
+	// the upgrade waits for the provisioning operation to finish
+	provisioningOperation, err := s.operationStorage.GetProvisioningOperationByInstanceID(operation.InstanceID)
+	if err != nil {
+		log.Errorf("while getting provisioning operation from storage")
+		return operation, s.timeSchedule.Retry, nil
+	}
+	if provisioningOperation.State == domain.InProgress {
+		log.Info("waiting for provisioning operation to finish")
+		return operation, s.timeSchedule.UpgradeClusterTimeout, nil
+	}
+
+	instance, err := s.instanceStorage.GetByID(operation.InstanceID)
+	switch {
+	case err == nil:
+		// the parameters of the instance are kept up to date by the update operation, e.g. when the plan was changed
+		operation.ProvisioningParameters = instance.Parameters
+		if operation.ProvisionerOperationID == "" {
+			// if schedule is maintenanceWindow and time window for this operation has finished we reprocess on next time window
+			if !operation.MaintenanceWindowEnd.IsZero() && operation.MaintenanceWindowEnd.Before(time.Now()) {
+				return s.rescheduleAtNextMaintenanceWindow(operation, log)
+			}
+			log.Info("provisioner operation ID is empty, initialize upgrade shoot input request")
+			return s.initializeUpgradeShootRequest(operation, log)
+		}
+		log.Infof("cluster being upgraded, check operation status")
+		operation.InstanceDetails.RuntimeID = instance.RuntimeID
+		return s.checkRuntimeStatus(operation, instance, log.WithField("runtimeID", instance.RuntimeID))
+	case dberr.IsNotFound(err):
+		log.Info("instance does not exist, it may have been deprovisioned")
+		return s.operationManager.OperationSucceeded(operation, "instance was not found")
+	default:
+		log.Errorf("unable to get instance from storage: %s", err)
+		return operation, s.timeSchedule.Retry, nil
+	}
+}
+
+func (s *InitialisationStep) rescheduleAtNextMaintenanceWindow(operation internal.UpgradeClusterOperation, log logrus.FieldLogger) (internal.UpgradeClusterOperation, time.Duration, error) {
+	operation.MaintenanceWindowBegin = operation.MaintenanceWindowBegin.AddDate(0, 0, 1)
+	operation.MaintenanceWindowEnd = operation.MaintenanceWindowEnd.AddDate(0, 0, 1)
+	operation, repeat := s.operationManager.UpdateOperation(operation)
+	if repeat != 0 {
+		log.Errorf("cannot save updated maintenance window to DB")
+		return operation, s.timeSchedule.Retry, nil
+	}
+	until := time.Until(operation.MaintenanceWindowBegin)
+	log.Infof("Upgrade operation %s will be rescheduled in %v", operation.Operation.ID, until)
+	return operation, until, nil
+}
+
+func (s *InitialisationStep) initializeUpgradeShootRequest(operation internal.UpgradeClusterOperation, log logrus.FieldLogger) (internal.UpgradeClusterOperation, time.Duration, error) {
+	log.Infof("create provisioner input creator for plan ID %q", operation.ProvisioningParameters.PlanID)
+	creator, err := s.inputBuilder.CreateUpgradeShootInput(operation.ProvisioningParameters)
+	switch {
+	case err == nil:
+		operation.InputCreator = creator
+		return operation, 0, nil // go to next step
+	case kebError.IsTemporaryError(err):
+		log.Errorf("cannot create upgrade shoot input creator at the moment for plan %s: %s", operation.ProvisioningParameters.PlanID, err)
+		return s.operationManager.RetryOperation(operation, err.Error(), 5*time.Second, 5*time.Minute, log)
+	default:
+		log.Errorf("cannot create input creator for plan %s: %s", operation.ProvisioningParameters.PlanID, err)
+		return s.operationManager.OperationFailed(operation, "cannot create upgrade shoot input creator")
+	}
+}
+
+// checkRuntimeStatus checks the status of the provisioner operation which upgrades the cluster
+func (s *InitialisationStep) checkRuntimeStatus(operation internal.UpgradeClusterOperation, instance *internal.Instance, log logrus.FieldLogger) (internal.UpgradeClusterOperation, time.Duration, error) {
+	if time.Since(operation.UpdatedAt) > CheckStatusTimeout {
+		log.Infof("operation has reached the time limit: updated operation time: %s", operation.UpdatedAt)
+		return s.operationManager.OperationFailed(operation, fmt.Sprintf("operation has reached the time limit: %s", CheckStatusTimeout))
+	}
+
+	status, err := s.provisionerClient.RuntimeOperationStatus(instance.GlobalAccountID, operation.ProvisionerOperationID)
+	if err != nil {
+		return operation, s.timeSchedule.StatusCheck, nil
+	}
+	log.Infof("call to provisioner returned %s status", status.State.String())
+
+	var msg string
+	if status.Message != nil {
+		msg = *status.Message
+	}
+
+	switch status.State {
+	case gqlschema.OperationStateInProgress, gqlschema.OperationStatePending:
+		return operation, s.timeSchedule.StatusCheck, nil
+	case gqlschema.OperationStateSucceeded:
+		return s.operationManager.OperationSucceeded(operation, msg)
+	case gqlschema.OperationStateFailed:
+		return s.operationManager.OperationFailed(operation, fmt.Sprintf("provisioner client returns failed status: %s", msg))
+	}
+
+	return s.operationManager.OperationFailed(operation, fmt.Sprintf("unsupported provisioner client status: %s", status.State.String()))
+}
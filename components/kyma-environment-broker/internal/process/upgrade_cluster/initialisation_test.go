@@ -0,0 +1,185 @@
+package upgrade_cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	upgradeKymaAutomock "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/upgrade_kyma/automock"
+	provisionerAutomock "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner/automock"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitialisationStep_Run(t *testing.T) {
+	t.Run("should mark the operation as succeeded when the shoot upgrade is finished", func(t *testing.T) {
+		// given
+		memoryStorage := fixStorage(t)
+
+		operation := fixUpgradeClusterOperation()
+		operation.ProvisionerOperationID = fixProvisionerOperationID
+		err := memoryStorage.Operations().InsertUpgradeClusterOperation(operation)
+		require.NoError(t, err)
+
+		provisionerClient := &provisionerAutomock.Client{}
+		provisionerClient.On("RuntimeOperationStatus", fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
+			ID:        ptr.String(fixProvisionerOperationID),
+			Operation: gqlschema.OperationTypeUpgradeShoot,
+			State:     gqlschema.OperationStateSucceeded,
+			RuntimeID: ptr.String(fixRuntimeID),
+		}, nil)
+		defer provisionerClient.AssertExpectations(t)
+
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Orchestrations(), memoryStorage.Instances(), provisionerClient, nil, nil)
+
+		// when
+		operation, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), repeat)
+		assert.Equal(t, domain.Succeeded, operation.State)
+	})
+
+	t.Run("should mark the operation as canceled when the orchestration was canceled", func(t *testing.T) {
+		// given
+		memoryStorage := fixStorage(t)
+		o, err := memoryStorage.Orchestrations().GetByID(fixOrchestrationID)
+		require.NoError(t, err)
+		o.State = orchestration.Canceling
+		err = memoryStorage.Orchestrations().Update(*o)
+		require.NoError(t, err)
+
+		operation := fixUpgradeClusterOperation()
+		err = memoryStorage.Operations().InsertUpgradeClusterOperation(operation)
+		require.NoError(t, err)
+
+		provisionerClient := &provisionerAutomock.Client{}
+		defer provisionerClient.AssertExpectations(t)
+
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Orchestrations(), memoryStorage.Instances(), provisionerClient, nil, nil)
+
+		// when
+		operation, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), repeat)
+		assert.Equal(t, orchestration.Canceled, string(operation.State))
+	})
+
+	t.Run("should keep the operation pending when the orchestration was paused", func(t *testing.T) {
+		// given
+		memoryStorage := fixStorage(t)
+		o, err := memoryStorage.Orchestrations().GetByID(fixOrchestrationID)
+		require.NoError(t, err)
+		o.State = orchestration.Paused
+		err = memoryStorage.Orchestrations().Update(*o)
+		require.NoError(t, err)
+
+		operation := fixUpgradeClusterOperation()
+		err = memoryStorage.Operations().InsertUpgradeClusterOperation(operation)
+		require.NoError(t, err)
+
+		provisionerClient := &provisionerAutomock.Client{}
+		defer provisionerClient.AssertExpectations(t)
+
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Orchestrations(), memoryStorage.Instances(), provisionerClient, nil, nil)
+
+		// when
+		operation, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		assert.NoError(t, err)
+		assert.NotZero(t, repeat)
+		assert.Equal(t, orchestration.Pending, string(operation.State))
+	})
+
+	t.Run("should upgrade the shoot with the parameters of the plan the instance was changed to", func(t *testing.T) {
+		// given
+		memoryStorage := fixStorage(t)
+		instance, err := memoryStorage.Instances().GetByID(fixInstanceID)
+		require.NoError(t, err)
+		instance.ServicePlanID = broker.AzurePlanID
+		instance.Parameters.PlanID = broker.AzurePlanID
+		_, err = memoryStorage.Instances().Update(*instance)
+		require.NoError(t, err)
+
+		operation := fixUpgradeClusterOperation()
+		err = memoryStorage.Operations().InsertUpgradeClusterOperation(operation)
+		require.NoError(t, err)
+
+		inputCreator := fixInputCreator(t)
+		inputBuilder := &upgradeKymaAutomock.CreatorForPlan{}
+		inputBuilder.On("CreateUpgradeShootInput", mock.MatchedBy(func(pp internal.ProvisioningParameters) bool {
+			return pp.PlanID == broker.AzurePlanID
+		})).Return(inputCreator, nil)
+		defer inputBuilder.AssertExpectations(t)
+
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Orchestrations(), memoryStorage.Instances(), &provisionerAutomock.Client{}, inputBuilder, nil)
+
+		// when
+		operation, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		assert.NoError(t, err)
+		assert.Zero(t, repeat)
+		assert.Equal(t, broker.AzurePlanID, operation.ProvisioningParameters.PlanID)
+		assert.Equal(t, inputCreator, operation.InputCreator)
+	})
+}
+
+func fixStorage(t *testing.T) storage.BrokerStorage {
+	memoryStorage := storage.NewMemoryStorage()
+
+	err := memoryStorage.Orchestrations().Insert(internal.Orchestration{
+		OrchestrationID: fixOrchestrationID,
+		State:           orchestration.InProgress,
+		Type:            orchestration.UpgradeClusterOrchestration,
+	})
+	require.NoError(t, err)
+	err = memoryStorage.Operations().InsertProvisioningOperation(internal.ProvisioningOperation{
+		Operation: internal.Operation{
+			ID:                     "provisioning-operation-id",
+			InstanceID:             fixInstanceID,
+			State:                  domain.Succeeded,
+			ProvisioningParameters: fixProvisioningParameters(),
+		},
+	})
+	require.NoError(t, err)
+	err = memoryStorage.Instances().Insert(internal.Instance{
+		InstanceID:      fixInstanceID,
+		RuntimeID:       fixRuntimeID,
+		GlobalAccountID: fixGlobalAccountID,
+		ServicePlanID:   broker.GCPPlanID,
+		Parameters:      fixProvisioningParameters(),
+	})
+	require.NoError(t, err)
+
+	return memoryStorage
+}
+
+func fixUpgradeClusterOperation() internal.UpgradeClusterOperation {
+	return internal.UpgradeClusterOperation{
+		Operation: internal.Operation{
+			ID:              fixUpgradeOperationID,
+			InstanceID:      fixInstanceID,
+			OrchestrationID: fixOrchestrationID,
+			State:           orchestration.Pending,
+			UpdatedAt:       time.Now(),
+		},
+		RuntimeOperation: orchestration.RuntimeOperation{
+			Runtime: orchestration.Runtime{
+				RuntimeID: fixRuntimeID,
+			},
+		},
+	}
+}
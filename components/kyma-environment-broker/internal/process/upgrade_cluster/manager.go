@@ -0,0 +1,288 @@
+package upgrade_cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+type Step interface {
+	Name() string
+	Run(operation internal.UpgradeClusterOperation, logger logrus.FieldLogger) (internal.UpgradeClusterOperation, time.Duration, error)
+}
+
+type Manager struct {
+	log              logrus.FieldLogger
+	steps            map[int][]Step
+	policies         map[string]process.StepPolicy
+	operationStorage storage.Operations
+	operationManager *process.UpgradeClusterOperationManager
+
+	publisher event.Publisher
+}
+
+func NewManager(storage storage.Operations, pub event.Publisher, logger logrus.FieldLogger) *Manager {
+	return &Manager{
+		log:              logger,
+		steps:            make(map[int][]Step, 0),
+		policies:         make(map[string]process.StepPolicy),
+		operationManager: process.NewUpgradeClusterOperationManager(storage),
+		operationStorage: storage,
+		publisher:        pub,
+	}
+}
+
+func (m *Manager) InitStep(step Step) {
+	m.AddStep(0, step)
+}
+
+func (m *Manager) AddStep(weight int, step Step) {
+	if weight <= 0 {
+		weight = 1
+	}
+	m.steps[weight] = append(m.steps[weight], step)
+}
+
+// AddStepWithPolicy adds the step which is executed according to the given policy
+func (m *Manager) AddStepWithPolicy(weight int, step Step, policy process.StepPolicy) {
+	m.AddStep(weight, step)
+	m.policies[step.Name()] = policy
+}
+
+// AddPipelineStep adds the step of the pipeline, it fails if the step is not a upgrade cluster step
+func (m *Manager) AddPipelineStep(weight int, step process.NamedStep, policy process.StepPolicy) error {
+	processStep, ok := step.(Step)
+	if !ok {
+		return fmt.Errorf("step %s is not a upgrade cluster step", step.Name())
+	}
+	m.AddStepWithPolicy(weight, processStep, policy)
+	return nil
+}
+
+// runStep executes the step according to its policy. Attempts of steps with a policy are recorded in the operation,
+// a step which exceeded its time limit or retry budget is given up.
+func (m *Manager) runStep(step Step, operation internal.UpgradeClusterOperation, logger logrus.FieldLogger) (internal.UpgradeClusterOperation, time.Duration, error) {
+	policy := m.policies[step.Name()]
+	if planID := operation.ProvisioningParameters.PlanID; !policy.EnabledForPlan(planID) {
+		logger.Infof("Skipping step %s, it is not enabled for the plan %s", step.Name(), planID)
+		return operation, 0, nil
+	}
+	if policy.IsZero() {
+		processedOperation, when, err := m.runStepOnce(step, operation, logger)
+		if process.IsStepFailedError(err) {
+			return m.giveUpStep(step, operation, processedOperation, policy, operation.StepAttempt(step.Name()), err.Error(), err, logger)
+		}
+		return processedOperation, when, err
+	}
+
+	now := time.Now()
+	attempt := operation.StepAttempt(step.Name())
+	if attempt.StartedAt.IsZero() {
+		attempt.StartedAt = now
+	}
+	if reason := policy.Exhausted(attempt, now); reason != "" {
+		logger.Errorf("Step %s, it was started at %s", reason, attempt.StartedAt)
+		return m.giveUpStep(step, operation, operation, policy, attempt, fmt.Sprintf("step %s %s", step.Name(), reason), nil, logger)
+	}
+	if wait := attempt.NextAttemptAt.Sub(now); wait > 0 {
+		logger.Infof("Step is backing off, next attempt in %s", wait)
+		return operation, wait, nil
+	}
+	attempt.Count++
+	attempt.LastAttemptAt = now
+	attempt.NextAttemptAt = time.Time{}
+	operation.SetStepAttempt(step.Name(), attempt)
+
+	processedOperation, when, err := m.runStepOnce(step, operation, logger)
+	switch {
+	case process.IsStepFailedError(err):
+		attempt.LastError = err.Error()
+		return m.giveUpStep(step, operation, processedOperation, policy, attempt, err.Error(), err, logger)
+	case err != nil && processedOperation.State != orchestration.Failed && policy.MaxAttempts > 0:
+		attempt.LastError = err.Error()
+		if reason := policy.Exhausted(attempt, time.Now()); reason != "" {
+			return m.giveUpStep(step, operation, processedOperation, policy, attempt, fmt.Sprintf("step %s %s: %s", step.Name(), reason, err), err, logger)
+		}
+		logger.Warnf("Step failed, it will be retried (attempt %d of %d): %s", attempt.Count, policy.MaxAttempts, err)
+		when = time.Second
+	case err != nil:
+		return m.giveUpStep(step, operation, processedOperation, policy, attempt, fmt.Sprintf("step %s failed: %s", step.Name(), err), err, logger)
+	case processedOperation.State == orchestration.Failed:
+		return m.giveUpStep(step, operation, processedOperation, policy, attempt, processedOperation.Description, nil, logger)
+	case when == 0 || !(processedOperation.State == orchestration.InProgress || processedOperation.State == orchestration.Pending):
+		processedOperation.SetStepAttempt(step.Name(), attempt)
+		return processedOperation, when, nil
+	}
+
+	if delay := policy.Delay(attempt.Count); delay > when {
+		when = delay
+	}
+	if policy.Backoff > 0 {
+		attempt.NextAttemptAt = now.Add(when)
+	}
+	processedOperation.SetStepAttempt(step.Name(), attempt)
+	processedOperation, retry := m.operationManager.UpdateOperation(processedOperation)
+	if retry > 0 {
+		return processedOperation, retry, nil
+	}
+	return processedOperation, when, nil
+}
+
+// giveUpStep fails the operation if the step is mandatory. The optional step is skipped: the state of the operation
+// from before the step execution is restored and the processing continues with the next step. The outcome is decided
+// before the operation is stored, unless the step failed the operation itself instead of returning StepFailedError.
+func (m *Manager) giveUpStep(step Step, operation, processedOperation internal.UpgradeClusterOperation, policy process.StepPolicy, attempt internal.StepAttempt, reason string, stepErr error, logger logrus.FieldLogger) (internal.UpgradeClusterOperation, time.Duration, error) {
+	if processedOperation.Operation.ID == "" {
+		// the step failed without returning the operation
+		processedOperation = operation
+	}
+	if attempt.LastError == "" {
+		attempt.LastError = reason
+	}
+	if !policy.Optional {
+		processedOperation.SetStepAttempt(step.Name(), attempt)
+		if processedOperation.State == orchestration.Failed {
+			return processedOperation, 0, stepErr
+		}
+		failedOperation, when, err := m.operationManager.OperationFailed(processedOperation, reason)
+		if stepErr != nil && when == 0 {
+			err = stepErr
+		}
+		return failedOperation, when, err
+	}
+
+	logger.Warnf("Skipping optional step: %s", reason)
+	attempt.Skipped = true
+	processedOperation.State = operation.State
+	processedOperation.Description = operation.Description
+	processedOperation.SetStepAttempt(step.Name(), attempt)
+	skippedOperation, retry := m.operationManager.UpdateOperation(processedOperation)
+	if retry > 0 {
+		return skippedOperation, retry, nil
+	}
+	return skippedOperation, 0, nil
+}
+
+func (m *Manager) runStepOnce(step Step, operation internal.UpgradeClusterOperation, logger logrus.FieldLogger) (internal.UpgradeClusterOperation, time.Duration, error) {
+	start := time.Now()
+	processedOperation, when, err := step.Run(operation, logger)
+	ev := process.UpgradeClusterStepProcessed{
+		OldOperation:  operation,
+		Operation:     processedOperation,
+		StepProcessed: process.NewStepProcessed(operation.Operation.ID, step.Name(), start, when, err),
+	}
+	if processedOperation.Operation.ID == "" {
+		m.publisher.Publish(context.TODO(), ev)
+		return processedOperation, when, err
+	}
+	// the event is stored in one transaction with the next update of the operation, so it is not lost when KEB stops,
+	// see storePendingEvents
+	processedOperation.AddPendingEvents(event.Stage(m.publisher, ev))
+	return processedOperation, when, err
+}
+
+func (m *Manager) Execute(operationID string) (time.Duration, error) {
+	op, err := m.operationStorage.GetUpgradeClusterOperationByID(operationID)
+	if err != nil {
+		m.log.Errorf("Cannot fetch operation from storage: %s", err)
+		return 3 * time.Second, nil
+	}
+	operation := *op
+	if operation.IsFinished() {
+		return 0, nil
+	}
+
+	var when time.Duration
+	logOperation := m.log.WithFields(logrus.Fields{"operation": operationID, "instanceID": operation.InstanceID})
+	// the events of the steps which were not stored with the updates of the operation made by the steps
+	defer func() { m.storePendingEvents(operation, logOperation) }()
+
+	logOperation.Info("Start process operation steps")
+	for _, weightStep := range m.sortWeight() {
+		steps := m.steps[weightStep]
+		for _, step := range steps {
+			logStep := logOperation.WithField("step", step.Name())
+			if operation.IsStepFinished(step.Name()) && !process.IsStepRepeatable(step) {
+				logStep.Info("Step already finished, skipping")
+				continue
+			}
+			logStep.Infof("Start step")
+
+			operation, when, err = m.runStep(step, operation, logStep)
+			if err != nil {
+				logStep.Errorf("Process operation failed: %s", err)
+				return 0, err
+			}
+			if operation.IsFinished() || operation.State == orchestration.Canceled {
+				logStep.Infof("Operation %q got status %s. Process finished.", operation.Operation.ID, operation.State)
+				return 0, nil
+			}
+			if when == 0 {
+				logStep.Info("Process operation successful")
+				operation, when = m.finishStep(step, operation, logStep)
+				if when != 0 {
+					return when, nil
+				}
+				continue
+			}
+
+			logStep.Infof("Process operation will be repeated in %s ...", when)
+			return when, nil
+		}
+	}
+
+	logOperation.Infof("Operation %q got status %s. All steps finished.", operation.Operation.ID, operation.State)
+	return 0, nil
+}
+
+// storePendingEvents stores the events of the processed steps which were not stored with the updates of the operation,
+// the operation is updated only if there are such events
+func (m *Manager) storePendingEvents(operation internal.UpgradeClusterOperation, logger logrus.FieldLogger) internal.UpgradeClusterOperation {
+	err := event.StorePending(m.publisher, operation.PendingEvents(), func() error {
+		updated, err := m.operationStorage.UpdateUpgradeClusterOperation(operation)
+		if err != nil {
+			return err
+		}
+		operation = *updated
+		return nil
+	})
+	if err != nil {
+		logger.Warnf("unable to store the events of the steps with the operation, the events were stored separately: %s", err)
+		operation.TakePendingEvents()
+	}
+	return operation
+}
+
+// finishStep stores the information that the step was successfully processed,
+// so the step is not executed again when the operation is retried
+func (m *Manager) finishStep(step Step, operation internal.UpgradeClusterOperation, logger logrus.FieldLogger) (internal.UpgradeClusterOperation, time.Duration) {
+	if process.IsStepRepeatable(step) {
+		return operation, 0
+	}
+	operation.FinishStep(step.Name())
+	updated, err := m.operationStorage.UpdateUpgradeClusterOperation(operation)
+	if err != nil {
+		logger.Errorf("Cannot save finished step: %s", err)
+		return operation, time.Second
+	}
+	return *updated, 0
+}
+
+func (m *Manager) sortWeight() []int {
+	var weight []int
+	for w := range m.steps {
+		weight = append(weight, w)
+	}
+	sort.Ints(weight)
+
+	return weight
+}
@@ -0,0 +1,103 @@
+package upgrade_cluster
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+type UpgradeClusterStep struct {
+	operationManager  *process.UpgradeClusterOperationManager
+	provisionerClient provisioner.Client
+	timeSchedule      TimeSchedule
+}
+
+func NewUpgradeClusterStep(
+	os storage.Operations,
+	cli provisioner.Client,
+	timeSchedule *TimeSchedule) *UpgradeClusterStep {
+	ts := timeSchedule
+	if ts == nil {
+		ts = &TimeSchedule{
+			Retry:                 5 * time.Second,
+			StatusCheck:           time.Minute,
+			UpgradeClusterTimeout: time.Hour,
+		}
+	}
+
+	return &UpgradeClusterStep{
+		operationManager:  process.NewUpgradeClusterOperationManager(os),
+		provisionerClient: cli,
+		timeSchedule:      *ts,
+	}
+}
+
+func (s *UpgradeClusterStep) Name() string {
+	return "Upgrade_Cluster"
+}
+
+// Repeatable returns true, the upgrade is triggered only once, the initialisation step checks its status
+func (s *UpgradeClusterStep) Repeatable() bool {
+	return true
+}
+
+func (s *UpgradeClusterStep) Run(operation internal.UpgradeClusterOperation, log logrus.FieldLogger) (internal.UpgradeClusterOperation, time.Duration, error) {
+	if time.Since(operation.UpdatedAt) > s.timeSchedule.UpgradeClusterTimeout {
+		log.Infof("operation has reached the time limit: updated operation time: %s", operation.UpdatedAt)
+		return s.operationManager.OperationFailed(operation, fmt.Sprintf("operation has reached the time limit: %s", s.timeSchedule.UpgradeClusterTimeout))
+	}
+
+	requestInput, err := s.createUpgradeShootInput(operation)
+	if err != nil {
+		return s.operationManager.OperationFailed(operation, "invalid operation data - cannot create upgradeShoot input")
+	}
+
+	if operation.DryRun {
+		return s.operationManager.OperationSucceeded(operation, "dry run succeeded")
+	}
+
+	if operation.ProvisionerOperationID != "" {
+		// the upgrade was already triggered, the initialisation step checks its status
+		return operation, 0, nil
+	}
+
+	// trigger upgradeShoot mutation
+	provisionerResponse, err := s.provisionerClient.UpgradeShoot(operation.ProvisioningParameters.ErsContext.GlobalAccountID, operation.RuntimeOperation.RuntimeID, requestInput)
+	if err != nil {
+		log.Errorf("call to provisioner failed: %s", err)
+		return operation, s.timeSchedule.Retry, nil
+	}
+	if provisionerResponse.ID == nil {
+		log.Errorf("provisioner returned empty operation ID")
+		return operation, s.timeSchedule.Retry, nil
+	}
+	operation.ProvisionerOperationID = *provisionerResponse.ID
+	operation.Description = "cluster upgrade in progress"
+
+	operation, repeat := s.operationManager.UpdateOperation(operation)
+	if repeat != 0 {
+		log.Errorf("cannot save operation ID from provisioner")
+		return operation, s.timeSchedule.Retry, nil
+	}
+	log.Infof("call to provisioner succeeded, got operation ID %q", operation.ProvisionerOperationID)
+	log.Infof("cluster upgrade process initiated successfully")
+
+	// return repeat mode to start the initialization step which will now check the operation status
+	return operation, s.timeSchedule.Retry, nil
+}
+
+func (s *UpgradeClusterStep) createUpgradeShootInput(operation internal.UpgradeClusterOperation) (gqlschema.UpgradeShootInput, error) {
+	request, err := operation.InputCreator.CreateUpgradeShootInput()
+	if err != nil {
+		return request, errors.Wrap(err, "while building upgradeShootInput for provisioner")
+	}
+
+	return request, nil
+}
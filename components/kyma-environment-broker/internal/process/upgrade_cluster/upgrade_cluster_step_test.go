@@ -0,0 +1,150 @@
+package upgrade_cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/input"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/input/automock"
+	provisionerAutomock "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner/automock"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/kyma-project/kyma/components/kyma-operator/pkg/apis/installer/v1alpha1"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	fixUpgradeOperationID     = "fd5cee4d-0eeb-40d0-a7a7-0708e5eba470"
+	fixOrchestrationID        = "f4f95c0e-7b1b-4d1e-8b2f-3e4f1c5d0e9a"
+	fixInstanceID             = "9d75a545-2e1e-4786-abd8-a37b14e185b9"
+	fixRuntimeID              = "ef4e3210-652c-453e-8015-bba1c1cd1e1c"
+	fixGlobalAccountID        = "abf73c71-a653-4951-b9c2-a26d6c2cccbd"
+	fixSubAccountID           = "6424cc6d-5fce-49fc-b720-cf1fc1f36c7d"
+	fixProvisionerOperationID = "e04de524-53b3-4890-b05a-296be393e4ba"
+
+	kymaVersion  = "1.10.0"
+	k8sVersion   = "1.18.12"
+	machineImage = "gardenlinux"
+	imageVersion = "184.0.0"
+)
+
+func TestUpgradeClusterStep_Run(t *testing.T) {
+	t.Run("should trigger the shoot upgrade", func(t *testing.T) {
+		// given
+		log := logrus.New()
+		memoryStorage := storage.NewMemoryStorage()
+
+		operation := fixUpgradeClusterOperationWithInputCreator(t)
+		err := memoryStorage.Operations().InsertUpgradeClusterOperation(operation)
+		require.NoError(t, err)
+
+		provisionerClient := &provisionerAutomock.Client{}
+		provisionerClient.On("UpgradeShoot", fixGlobalAccountID, fixRuntimeID, gqlschema.UpgradeShootInput{
+			GardenerConfig: &gqlschema.GardenerUpgradeInput{
+				KubernetesVersion:   ptr.String(k8sVersion),
+				MachineImage:        ptr.String(machineImage),
+				MachineImageVersion: ptr.String(imageVersion),
+			},
+		}).Return(gqlschema.OperationStatus{
+			ID:        ptr.String(fixProvisionerOperationID),
+			RuntimeID: ptr.String(fixRuntimeID),
+		}, nil).Once()
+		defer provisionerClient.AssertExpectations(t)
+
+		step := NewUpgradeClusterStep(memoryStorage.Operations(), provisionerClient, nil)
+
+		// when
+		operation, repeat, err := step.Run(operation, log.WithFields(logrus.Fields{"step": "TEST"}))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, 5*time.Second, repeat)
+		assert.Equal(t, fixProvisionerOperationID, operation.ProvisionerOperationID)
+
+		storedOperation, err := memoryStorage.Operations().GetUpgradeClusterOperationByID(fixUpgradeOperationID)
+		require.NoError(t, err)
+		assert.Equal(t, fixProvisionerOperationID, storedOperation.ProvisionerOperationID)
+	})
+
+	t.Run("should not call the provisioner in dry run", func(t *testing.T) {
+		// given
+		log := logrus.New()
+		memoryStorage := storage.NewMemoryStorage()
+
+		operation := fixUpgradeClusterOperationWithInputCreator(t)
+		operation.DryRun = true
+		err := memoryStorage.Operations().InsertUpgradeClusterOperation(operation)
+		require.NoError(t, err)
+
+		provisionerClient := &provisionerAutomock.Client{}
+		defer provisionerClient.AssertExpectations(t)
+
+		step := NewUpgradeClusterStep(memoryStorage.Operations(), provisionerClient, nil)
+
+		// when
+		operation, repeat, err := step.Run(operation, log.WithFields(logrus.Fields{"step": "TEST"}))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), repeat)
+		assert.Equal(t, orchestration.Succeeded, string(operation.State))
+		assert.Empty(t, operation.ProvisionerOperationID)
+	})
+}
+
+func fixUpgradeClusterOperationWithInputCreator(t *testing.T) internal.UpgradeClusterOperation {
+	return internal.UpgradeClusterOperation{
+		Operation: internal.Operation{
+			ID:                     fixUpgradeOperationID,
+			InstanceID:             fixInstanceID,
+			OrchestrationID:        fixOrchestrationID,
+			State:                  orchestration.InProgress,
+			UpdatedAt:              time.Now(),
+			ProvisioningParameters: fixProvisioningParameters(),
+			InstanceDetails: internal.InstanceDetails{
+				RuntimeID: fixRuntimeID,
+			},
+		},
+		RuntimeOperation: orchestration.RuntimeOperation{
+			Runtime: orchestration.Runtime{
+				RuntimeID: fixRuntimeID,
+			},
+		},
+		InputCreator: fixInputCreator(t),
+	}
+}
+
+func fixInputCreator(t *testing.T) internal.ProvisionerInputCreator {
+	componentsProvider := &automock.ComponentListProvider{}
+	componentsProvider.On("AllComponents", kymaVersion).Return([]v1alpha1.KymaComponent{}, nil)
+	defer componentsProvider.AssertExpectations(t)
+
+	ibf, err := input.NewInputBuilderFactory(nil, runtime.NewDisabledComponentsProvider(), componentsProvider, input.Config{
+		KubernetesVersion:   k8sVersion,
+		MachineImage:        machineImage,
+		MachineImageVersion: imageVersion,
+	}, kymaVersion, map[string]string{})
+	require.NoError(t, err)
+
+	creator, err := ibf.CreateUpgradeShootInput(fixProvisioningParameters())
+	require.NoError(t, err)
+
+	return creator
+}
+
+func fixProvisioningParameters() internal.ProvisioningParameters {
+	return internal.ProvisioningParameters{
+		PlanID: broker.GCPPlanID,
+		ErsContext: internal.ERSContext{
+			GlobalAccountID: fixGlobalAccountID,
+			SubAccountID:    fixSubAccountID,
+		},
+	}
+}
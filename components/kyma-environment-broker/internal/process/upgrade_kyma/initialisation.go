@@ -76,14 +76,29 @@ func (s *InitialisationStep) Name() string {
 	return "Upgrade_Kyma_Initialisation"
 }
 
+// Repeatable returns true, the initialisation step checks the operation state and prepares data
+// which is not stored in the storage, so it must be executed each time the operation is processed
+func (s *InitialisationStep) Repeatable() bool {
+	return true
+}
+
 func (s *InitialisationStep) Run(operation internal.UpgradeKymaOperation, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
-	orchestration, err := s.orchestrationStorage.GetByID(operation.OrchestrationID)
-	if err != nil {
-		return operation, s.timeSchedule.Retry, nil
-	}
-	if orchestration.IsCanceled() {
-		log.Infof("Skipping processing because orchestration %s was canceled", operation.OrchestrationID)
-		return s.operationManager.OperationCanceled(operation, fmt.Sprintf("orchestration %s was canceled", operation.OrchestrationID))
+	// the operations triggered by the maintenance info of the OSB update are not a part of any orchestration
+	orchestration := &internal.Orchestration{}
+	if operation.OrchestrationID != "" {
+		var err error
+		orchestration, err = s.orchestrationStorage.GetByID(operation.OrchestrationID)
+		if err != nil {
+			return operation, s.timeSchedule.Retry, nil
+		}
+		if orchestration.IsCanceled() {
+			log.Infof("Skipping processing because orchestration %s was canceled", operation.OrchestrationID)
+			return s.operationManager.OperationCanceled(operation, fmt.Sprintf("orchestration %s was canceled", operation.OrchestrationID))
+		}
+		if orchestration.State == orchestrationExt.Paused && operation.State == orchestrationExt.Pending {
+			log.Infof("Skipping processing because orchestration %s was paused", operation.OrchestrationID)
+			return operation, s.timeSchedule.Retry, nil
+		}
 	}
 
 	operation.SMClientFactory = s.serviceManagerClientFactory
@@ -99,7 +114,7 @@ func (s *InitialisationStep) Run(operation internal.UpgradeKymaOperation, log lo
 		operation = *op
 	}
 
-	// rewrite necessary data from ProvisioningOperation to operation internal.UpgradeOperation
+	// the upgrade waits for the provisioning operation to finish
 	provisioningOperation, err := s.operationStorage.GetProvisioningOperationByInstanceID(operation.InstanceID)
 	if err != nil {
 		log.Errorf("while getting provisioning operation from storage")
@@ -109,11 +124,12 @@ func (s *InitialisationStep) Run(operation internal.UpgradeKymaOperation, log lo
 		log.Info("waiting for provisioning operation to finish")
 		return operation, s.timeSchedule.UpgradeKymaTimeout, nil
 	}
-	operation.ProvisioningParameters = provisioningOperation.ProvisioningParameters
 
 	instance, err := s.instanceStorage.GetByID(operation.InstanceID)
 	switch {
 	case err == nil:
+		// the parameters of the instance are kept up to date by the update operation, e.g. when the plan was changed
+		operation.ProvisioningParameters = instance.Parameters
 		if operation.ProvisionerOperationID == "" {
 			// if schedule is maintenanceWindow and time window for this operation has finished we reprocess on next time window
 			if !operation.MaintenanceWindowEnd.IsZero() && operation.MaintenanceWindowEnd.Before(time.Now()) {
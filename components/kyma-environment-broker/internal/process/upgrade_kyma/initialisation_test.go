@@ -211,6 +211,89 @@ func TestInitialisationStep_Run(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("should initialize UpgradeRuntimeInput request for operation without orchestration", func(t *testing.T) {
+		// given
+		log := logrus.New()
+		memoryStorage := storage.NewMemoryStorage()
+		evalManager, _ := createEvalManager(t, memoryStorage, log)
+		ver := internal.NewRuntimeVersionFromAccountMapping("1.20.0")
+
+		provisioningOperation := fixProvisioningOperation()
+		err := memoryStorage.Operations().InsertProvisioningOperation(provisioningOperation)
+		require.NoError(t, err)
+
+		upgradeOperation := fixUpgradeKymaOperation()
+		upgradeOperation.OrchestrationID = ""
+		upgradeOperation.ProvisionerOperationID = ""
+		upgradeOperation.RuntimeOperation.MaintenanceWindowEnd = time.Time{}
+		upgradeOperation.RuntimeVersion = *ver
+		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		require.NoError(t, err)
+
+		instance := fixInstanceRuntimeStatus()
+		err = memoryStorage.Instances().Insert(instance)
+		require.NoError(t, err)
+
+		inputBuilder := &automock.CreatorForPlan{}
+		inputBuilder.On("CreateUpgradeInput", fixProvisioningParameters(), *ver).Return(&input.RuntimeInput{}, nil)
+
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Orchestrations(), memoryStorage.Instances(), nil,
+			inputBuilder, evalManager, nil, nil, nil)
+
+		// when
+		op, repeat, err := step.Run(upgradeOperation, log)
+
+		// then
+		assert.NoError(t, err)
+		inputBuilder.AssertNumberOfCalls(t, "CreateUpgradeInput", 1)
+		assert.Equal(t, time.Duration(0), repeat)
+		assert.Equal(t, orchestration.InProgress, string(op.State))
+		assert.NotNil(t, op.InputCreator)
+	})
+
+	t.Run("should initialize UpgradeRuntimeInput request for the plan the instance was changed to", func(t *testing.T) {
+		// given
+		log := logrus.New()
+		memoryStorage := storage.NewMemoryStorage()
+		evalManager, _ := createEvalManager(t, memoryStorage, log)
+		ver := internal.NewRuntimeVersionFromAccountMapping("1.20.0")
+
+		provisioningOperation := fixProvisioningOperation()
+		err := memoryStorage.Operations().InsertProvisioningOperation(provisioningOperation)
+		require.NoError(t, err)
+
+		upgradeOperation := fixUpgradeKymaOperation()
+		upgradeOperation.OrchestrationID = ""
+		upgradeOperation.ProvisionerOperationID = ""
+		upgradeOperation.RuntimeOperation.MaintenanceWindowEnd = time.Time{}
+		upgradeOperation.RuntimeVersion = *ver
+		err = memoryStorage.Operations().InsertUpgradeKymaOperation(upgradeOperation)
+		require.NoError(t, err)
+
+		// the plan was changed by the update operation after the instance was provisioned
+		instance := fixInstanceRuntimeStatus()
+		instance.ServicePlanID = broker.AzurePlanID
+		instance.Parameters.PlanID = broker.AzurePlanID
+		err = memoryStorage.Instances().Insert(instance)
+		require.NoError(t, err)
+
+		inputBuilder := &automock.CreatorForPlan{}
+		inputBuilder.On("CreateUpgradeInput", instance.Parameters, *ver).Return(&input.RuntimeInput{}, nil)
+		defer inputBuilder.AssertExpectations(t)
+
+		step := NewInitialisationStep(memoryStorage.Operations(), memoryStorage.Orchestrations(), memoryStorage.Instances(), nil,
+			inputBuilder, evalManager, nil, nil, nil)
+
+		// when
+		op, repeat, err := step.Run(upgradeOperation, log)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), repeat)
+		assert.Equal(t, broker.AzurePlanID, op.ProvisioningParameters.PlanID)
+		assert.NotNil(t, op.InputCreator)
+	})
+
 	t.Run("should mark finish if orchestration was canceled", func(t *testing.T) {
 		// given
 		log := logrus.New()
@@ -764,6 +847,7 @@ func fixInstanceRuntimeStatus() internal.Instance {
 		RuntimeID:       fixRuntimeID,
 		DashboardURL:    "",
 		GlobalAccountID: fixGlobalAccountID,
+		Parameters:      fixProvisioningParameters(),
 		CreatedAt:       time.Time{},
 		UpdatedAt:       time.Time{},
 		DeletedAt:       time.Time{},
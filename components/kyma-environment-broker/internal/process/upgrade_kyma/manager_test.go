@@ -30,9 +30,11 @@ const (
 func TestManager_Execute(t *testing.T) {
 	for name, tc := range map[string]struct {
 		operationID            string
+		finishedSteps          []string
 		expectedError          bool
 		expectedRepeat         time.Duration
 		expectedDesc           string
+		expectedFinishedSteps  []string
 		expectedNumberOfEvents int
 	}{
 		"operation successful": {
@@ -40,8 +42,18 @@ func TestManager_Execute(t *testing.T) {
 			expectedError:          false,
 			expectedRepeat:         time.Duration(0),
 			expectedDesc:           "init one two final",
+			expectedFinishedSteps:  []string{"init", "one", "two", "final"},
 			expectedNumberOfEvents: 4,
 		},
+		"operation resumed": {
+			operationID:            operationIDSuccess,
+			finishedSteps:          []string{"init", "one"},
+			expectedError:          false,
+			expectedRepeat:         time.Duration(0),
+			expectedDesc:           "two final",
+			expectedFinishedSteps:  []string{"init", "one", "two", "final"},
+			expectedNumberOfEvents: 2,
+		},
 		"operation failed": {
 			operationID:            operationIDFailed,
 			expectedError:          true,
@@ -52,6 +64,7 @@ func TestManager_Execute(t *testing.T) {
 			expectedError:          false,
 			expectedRepeat:         time.Duration(10),
 			expectedDesc:           "init",
+			expectedFinishedSteps:  nil,
 			expectedNumberOfEvents: 1,
 		},
 	} {
@@ -60,7 +73,9 @@ func TestManager_Execute(t *testing.T) {
 			log := logrus.New()
 			memoryStorage := storage.NewMemoryStorage()
 			operations := memoryStorage.Operations()
-			err := operations.InsertUpgradeKymaOperation(fixOperation(tc.operationID))
+			operation := fixOperation(tc.operationID)
+			operation.FinishedSteps = tc.finishedSteps
+			err := operations.InsertUpgradeKymaOperation(operation)
 			assert.NoError(t, err)
 
 			sInit := testStep{t: t, name: "init", storage: operations}
@@ -70,7 +85,7 @@ func TestManager_Execute(t *testing.T) {
 
 			eventBroker := event.NewPubSub(logrus.New())
 			eventCollector := &collectingEventHandler{}
-			eventBroker.Subscribe(process.UpgradeKymaStepProcessed{}, eventCollector.OnEvent)
+			eventBroker.Subscribe("collector", process.UpgradeKymaStepProcessed{}, eventCollector.OnEvent)
 
 			manager := NewManager(operations, eventBroker, log)
 			manager.InitStep(&sInit)
@@ -92,6 +107,7 @@ func TestManager_Execute(t *testing.T) {
 				operation, err := operations.GetOperationByID(tc.operationID)
 				assert.NoError(t, err)
 				assert.Equal(t, tc.expectedDesc, strings.Trim(operation.Description, " "))
+				assert.Equal(t, tc.expectedFinishedSteps, operation.FinishedSteps)
 			}
 			assert.NoError(t, wait.PollImmediate(20*time.Millisecond, 2*time.Second, func() (bool, error) {
 				return len(eventCollector.Events) == tc.expectedNumberOfEvents, nil
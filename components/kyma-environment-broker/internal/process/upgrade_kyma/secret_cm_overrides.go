@@ -41,6 +41,11 @@ func (s *OverridesFromSecretsAndConfigStep) Name() string {
 	return "Overrides_From_Secrets_And_Config_Step"
 }
 
+// Repeatable returns true, the overrides are read from the cluster secrets and config maps each time the input is built
+func (s *OverridesFromSecretsAndConfigStep) Repeatable() bool {
+	return true
+}
+
 func (s *OverridesFromSecretsAndConfigStep) Run(operation internal.UpgradeKymaOperation, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
 	planName, exists := broker.PlanNamesMapping[operation.ProvisioningParameters.PlanID]
 	if !exists {
@@ -0,0 +1,54 @@
+package upgrade_kyma
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/provisioning"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtime/components"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/sirupsen/logrus"
+)
+
+// StoredOverridesStep appends the EMS overrides stored in the operation by the EMS_UpgradeBind step.
+// The binding is created only once, but the runtime input is built from scratch each time the operation is processed.
+type StoredOverridesStep struct {
+	operationManager *process.UpgradeKymaOperationManager
+	secretKey        string
+}
+
+func NewStoredOverridesStep(os storage.Operations, secretKey string) *StoredOverridesStep {
+	return &StoredOverridesStep{
+		operationManager: process.NewUpgradeKymaOperationManager(os),
+		secretKey:        secretKey,
+	}
+}
+
+var _ Step = (*StoredOverridesStep)(nil)
+
+func (s *StoredOverridesStep) Name() string {
+	return "Stored_Overrides"
+}
+
+// Repeatable returns true, the step only decrypts the stored overrides and does not call any service
+func (s *StoredOverridesStep) Repeatable() bool {
+	return true
+}
+
+func (s *StoredOverridesStep) Run(operation internal.UpgradeKymaOperation, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
+	if operation.Ems.Overrides == "" {
+		return operation, 0, nil
+	}
+
+	eventingOverrides, err := provisioning.DecryptEventingOverrides(s.secretKey, operation.Ems.Overrides)
+	if err != nil {
+		log.Errorf("unable to decrypt EMS overrides: %s", err)
+		// internal error, repeating doesn't solve the problem
+		return s.operationManager.OperationFailed(operation, "unable to decrypt EMS overrides")
+	}
+	operation.InputCreator.AppendOverrides(components.Eventing, provisioning.GetEventingOverrides(eventingOverrides))
+
+	return operation, 0, nil
+}
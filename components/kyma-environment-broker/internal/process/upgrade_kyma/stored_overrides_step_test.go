@@ -0,0 +1,41 @@
+package upgrade_kyma
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/provisioning"
+	provisioningAutomock "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/provisioning/automock"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtime/components"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoredOverridesStep_Run(t *testing.T) {
+	// given
+	secretKey := "1234567890123456"
+	memoryStorage := storage.NewMemoryStorage()
+	step := NewStoredOverridesStep(memoryStorage.Operations(), secretKey)
+
+	eventingOverrides := &provisioning.EventingOverrides{OauthClientId: "ems-client", IsBEBEnabled: "true"}
+	encrypted, err := provisioning.EncryptEventingOverrides(secretKey, eventingOverrides)
+	require.NoError(t, err)
+
+	inputCreator := &provisioningAutomock.ProvisionerInputCreator{}
+	defer inputCreator.AssertExpectations(t)
+	inputCreator.On("AppendOverrides", components.Eventing, provisioning.GetEventingOverrides(eventingOverrides)).
+		Return(nil).Once()
+
+	operation := fixUpgradeKymaOperation()
+	operation.Ems = internal.EmsData{Overrides: encrypted}
+	operation.InputCreator = inputCreator
+
+	// when
+	_, repeat, err := step.Run(operation, fixLogger())
+
+	// then
+	require.NoError(t, err)
+	assert.Zero(t, repeat)
+}
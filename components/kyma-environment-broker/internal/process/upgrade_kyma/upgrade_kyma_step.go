@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -48,6 +50,11 @@ func (s *UpgradeKymaStep) Name() string {
 	return "Upgrade_Kyma"
 }
 
+// Repeatable returns true, the upgrade is triggered only once and the step checks the provisioner operation status until it finishes
+func (s *UpgradeKymaStep) Repeatable() bool {
+	return true
+}
+
 func (s *UpgradeKymaStep) Run(operation internal.UpgradeKymaOperation, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
 	if time.Since(operation.UpdatedAt) > s.timeSchedule.UpgradeKymaTimeout {
 		log.Infof("operation has reached the time limit: updated operation time: %s", operation.UpdatedAt)
@@ -60,6 +67,13 @@ func (s *UpgradeKymaStep) Run(operation internal.UpgradeKymaOperation, log logru
 	}
 
 	if operation.DryRun {
+		diff, err := s.kymaConfigDiff(operation.RuntimeOperation.RuntimeID, requestInput.KymaConfig)
+		if err != nil {
+			log.Errorf("while computing Kyma configuration diff: %v", err)
+			return operation, s.timeSchedule.Retry, nil
+		}
+		operation.KymaConfigDiff = &diff
+
 		// runtimeID is set with prefix to indicate the fake runtime state
 		err = s.runtimeStateStorage.Insert(
 			internal.NewRuntimeState(fmt.Sprintf("%s%s", DryRunPrefix, operation.RuntimeOperation.RuntimeID), operation.Operation.ID, requestInput.KymaConfig, nil),
@@ -115,6 +129,32 @@ func (s *UpgradeKymaStep) Run(operation internal.UpgradeKymaOperation, log logru
 	return operation, s.timeSchedule.Retry, nil
 }
 
+// kymaConfigDiff compares the Kyma configuration from the last runtime state with the configuration sent by the upgrade
+func (s *UpgradeKymaStep) kymaConfigDiff(runtimeID string, kymaConfig *gqlschema.KymaConfigInput) (orchestration.KymaConfigDiff, error) {
+	states, err := s.runtimeStateStorage.ListByRuntimeID(runtimeID)
+	if err != nil && !dberr.IsNotFound(err) {
+		return orchestration.KymaConfigDiff{}, errors.Wrapf(err, "while listing runtime states of runtime %s", runtimeID)
+	}
+
+	var (
+		current gqlschema.KymaConfigInput
+		last    time.Time
+	)
+	for _, state := range states {
+		if state.KymaConfig.Version != "" && state.CreatedAt.After(last) {
+			current = state.KymaConfig
+			last = state.CreatedAt
+		}
+	}
+
+	var target gqlschema.KymaConfigInput
+	if kymaConfig != nil {
+		target = *kymaConfig
+	}
+
+	return orchestration.NewKymaConfigDiff(current, target), nil
+}
+
 func (s *UpgradeKymaStep) createUpgradeKymaInput(operation internal.UpgradeKymaOperation) (gqlschema.UpgradeRuntimeInput, error) {
 	var request gqlschema.UpgradeRuntimeInput
 
@@ -79,6 +79,49 @@ func TestUpgradeKymaStep_Run(t *testing.T) {
 	assert.Equal(t, fixProvisionerOperationID, operation.ProvisionerOperationID)
 }
 
+func TestUpgradeKymaStep_RunDryRun(t *testing.T) {
+	// given
+	log := logrus.New()
+	memoryStorage := storage.NewMemoryStorage()
+
+	operation := fixUpgradeKymaOperationWithInputCreator(t)
+	operation.DryRun = true
+	err := memoryStorage.Operations().InsertUpgradeKymaOperation(operation)
+	assert.NoError(t, err)
+
+	err = memoryStorage.RuntimeStates().Insert(internal.NewRuntimeState(fixRuntimeID, "provisioning-id", &gqlschema.KymaConfigInput{
+		Version: "1.9.0",
+		Components: []*gqlschema.ComponentConfigurationInput{
+			{Component: "keb", Namespace: "kyma-system"},
+			{Component: "to-remove-component", Namespace: "kyma-system"},
+		},
+	}, nil))
+	assert.NoError(t, err)
+
+	provisionerClient := &provisionerAutomock.Client{}
+	defer provisionerClient.AssertExpectations(t)
+
+	step := NewUpgradeKymaStep(memoryStorage.Operations(), memoryStorage.RuntimeStates(), provisionerClient, nil)
+
+	// when
+	operation, repeat, err := step.Run(operation, log.WithFields(logrus.Fields{"step": "TEST"}))
+
+	// then
+	assert.NoError(t, err)
+	assert.Zero(t, repeat)
+	assert.Equal(t, orchestration.Succeeded, string(operation.State))
+	assert.Equal(t, &orchestration.KymaConfigDiff{
+		CurrentVersion: "1.9.0",
+		TargetVersion:  kymaVersion,
+		Components: []orchestration.ComponentDiff{
+			{Component: "to-remove-component", Change: orchestration.DiffRemoved},
+		},
+	}, operation.KymaConfigDiff)
+
+	_, err = memoryStorage.RuntimeStates().GetByOperationID(fixUpgradeOperationID)
+	assert.NoError(t, err)
+}
+
 func fixUpgradeKymaOperationWithInputCreator(t *testing.T) internal.UpgradeKymaOperation {
 	return internal.UpgradeKymaOperation{
 		Operation: internal.Operation{
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+)
+
+const (
+	DefaultAWSRegion = "eu-central-1"
+)
+
+var europeAWS = "eu-central-1"
+var usAWS = "us-east-1"
+var asiaAWS = "ap-southeast-1"
+
+var toAWSSpecific = map[string]*string{
+	string(broker.Europe): &europeAWS,
+	string(broker.Us):     &usAWS,
+	string(broker.Asia):   &asiaAWS,
+}
+
+type (
+	AWSInput      struct{}
+	AWSTrialInput struct {
+		PlatformRegionMapping map[string]string
+	}
+)
+
+func (p *AWSInput) Defaults() *gqlschema.ClusterConfigInput {
+	return &gqlschema.ClusterConfigInput{
+		GardenerConfig: &gqlschema.GardenerConfigInput{
+			DiskType:       "gp2",
+			VolumeSizeGb:   50,
+			MachineType:    "m5.2xlarge",
+			Region:         DefaultAWSRegion,
+			Provider:       "aws",
+			WorkerCidr:     "10.250.0.0/19",
+			AutoScalerMin:  3,
+			AutoScalerMax:  10,
+			MaxSurge:       4,
+			MaxUnavailable: 1,
+			ProviderSpecificConfig: &gqlschema.ProviderSpecificInput{
+				AwsConfig: defaultAWSConfig(DefaultAWSRegion),
+			},
+		},
+	}
+}
+
+func (p *AWSInput) ApplyParameters(input *gqlschema.ClusterConfigInput, pp internal.ProvisioningParameters) {
+	if pp.Parameters.Region != nil {
+		input.GardenerConfig.ProviderSpecificConfig.AwsConfig.Zone = ZoneForAWSRegion(*pp.Parameters.Region)
+	}
+
+	if len(pp.Parameters.Zones) > 0 {
+		input.GardenerConfig.ProviderSpecificConfig.AwsConfig.Zone = pp.Parameters.Zones[0]
+	}
+}
+
+func (p *AWSInput) Profile() gqlschema.KymaProfile {
+	return gqlschema.KymaProfileProduction
+}
+
+func (p *AWSTrialInput) Defaults() *gqlschema.ClusterConfigInput {
+	return &gqlschema.ClusterConfigInput{
+		GardenerConfig: &gqlschema.GardenerConfigInput{
+			DiskType:       "gp2",
+			VolumeSizeGb:   50,
+			MachineType:    "m5.xlarge",
+			Region:         DefaultAWSRegion,
+			Provider:       "aws",
+			WorkerCidr:     "10.250.0.0/19",
+			AutoScalerMin:  1,
+			AutoScalerMax:  1,
+			MaxSurge:       1,
+			MaxUnavailable: 1,
+			Purpose:        &trialPurpose,
+			ProviderSpecificConfig: &gqlschema.ProviderSpecificInput{
+				AwsConfig: defaultAWSConfig(DefaultAWSRegion),
+			},
+		},
+	}
+}
+
+func (p *AWSTrialInput) ApplyParameters(input *gqlschema.ClusterConfigInput, pp internal.ProvisioningParameters) {
+	params := pp.Parameters
+
+	// read platform region if exists
+	if pp.PlatformRegion != "" {
+		abstractRegion, found := p.PlatformRegionMapping[pp.PlatformRegion]
+		if found {
+			updateString(&input.GardenerConfig.Region, toAWSSpecific[abstractRegion])
+		}
+	}
+
+	if params.Region != nil {
+		updateString(&input.GardenerConfig.Region, toAWSSpecific[*params.Region])
+	}
+
+	input.GardenerConfig.ProviderSpecificConfig.AwsConfig.Zone = ZoneForAWSRegion(input.GardenerConfig.Region)
+}
+
+func (p *AWSTrialInput) Profile() gqlschema.KymaProfile {
+	return gqlschema.KymaProfileEvaluation
+}
+
+// defaultAWSConfig returns the AWS network configuration, the public and internal subnets are carved out
+// of the VPC next to the worker subnet
+func defaultAWSConfig(region string) *gqlschema.AWSProviderConfigInput {
+	return &gqlschema.AWSProviderConfigInput{
+		Zone:         ZoneForAWSRegion(region),
+		VpcCidr:      "10.250.0.0/16",
+		PublicCidr:   "10.250.32.0/20",
+		InternalCidr: "10.250.48.0/20",
+	}
+}
+
+func ZoneForAWSRegion(region string) string {
+	return fmt.Sprintf("%sa", region)
+}
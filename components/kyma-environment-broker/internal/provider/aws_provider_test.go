@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAWSInput_ApplyParameters(t *testing.T) {
+	// given
+	svc := AWSInput{}
+
+	// when
+	t.Run("use default zone", func(t *testing.T) {
+		// given
+		input := svc.Defaults()
+
+		// when
+		svc.ApplyParameters(input, internal.ProvisioningParameters{})
+
+		//then
+		assert.Equal(t, "eu-central-1a", input.GardenerConfig.ProviderSpecificConfig.AwsConfig.Zone)
+		assert.Equal(t, "10.250.0.0/16", input.GardenerConfig.ProviderSpecificConfig.AwsConfig.VpcCidr)
+	})
+
+	// when
+	t.Run("use zone of the region", func(t *testing.T) {
+		// given
+		input := svc.Defaults()
+		region := "us-east-1"
+
+		// when
+		svc.ApplyParameters(input, internal.ProvisioningParameters{
+			Parameters: internal.ProvisioningParametersDTO{
+				Region: &region,
+			},
+		})
+
+		//then
+		assert.Equal(t, "us-east-1a", input.GardenerConfig.ProviderSpecificConfig.AwsConfig.Zone)
+	})
+
+	// when
+	t.Run("use customer zone", func(t *testing.T) {
+		// given
+		input := svc.Defaults()
+		region := "us-east-1"
+
+		// when
+		svc.ApplyParameters(input, internal.ProvisioningParameters{
+			Parameters: internal.ProvisioningParametersDTO{
+				Region: &region,
+				Zones:  []string{"us-east-1c"},
+			},
+		})
+
+		//then
+		assert.Equal(t, "us-east-1c", input.GardenerConfig.ProviderSpecificConfig.AwsConfig.Zone)
+	})
+}
+
+func TestAWSTrialInput_ApplyParametersWithRegion(t *testing.T) {
+	// given
+	svc := AWSTrialInput{
+		PlatformRegionMapping: map[string]string{
+			"cf-eu": "europe",
+		},
+	}
+
+	// when
+	t.Run("use platform region mapping", func(t *testing.T) {
+		// given
+		input := svc.Defaults()
+
+		// when
+		svc.ApplyParameters(input, internal.ProvisioningParameters{
+			PlatformRegion: "cf-eu",
+		})
+
+		//then
+		assert.Equal(t, "eu-central-1", input.GardenerConfig.Region)
+		assert.Equal(t, "eu-central-1a", input.GardenerConfig.ProviderSpecificConfig.AwsConfig.Zone)
+	})
+
+	// when
+	t.Run("use customer mapping", func(t *testing.T) {
+		// given
+		input := svc.Defaults()
+		us := "us"
+
+		// when
+		svc.ApplyParameters(input, internal.ProvisioningParameters{
+			PlatformRegion: "cf-eu",
+			Parameters: internal.ProvisioningParametersDTO{
+				Region: &us,
+			},
+		})
+
+		//then
+		assert.Equal(t, "us-east-1", input.GardenerConfig.Region)
+		assert.Equal(t, "us-east-1a", input.GardenerConfig.ProviderSpecificConfig.AwsConfig.Zone)
+	})
+
+	// when
+	t.Run("use default region for not defined mapping", func(t *testing.T) {
+		// given
+		input := svc.Defaults()
+
+		// when
+		svc.ApplyParameters(input, internal.ProvisioningParameters{
+			PlatformRegion: "cf-southamerica",
+		})
+
+		//then
+		assert.Equal(t, "eu-central-1", input.GardenerConfig.Region)
+	})
+
+	// when
+	t.Run("use default region for not supported abstract region", func(t *testing.T) {
+		// given
+		input := svc.Defaults()
+		svc := AWSTrialInput{
+			PlatformRegionMapping: map[string]string{
+				"cf-ch": "switzerland",
+			},
+		}
+
+		// when
+		svc.ApplyParameters(input, internal.ProvisioningParameters{
+			PlatformRegion: "cf-ch",
+		})
+
+		//then
+		assert.Equal(t, "eu-central-1", input.GardenerConfig.Region)
+		assert.Equal(t, "eu-central-1a", input.GardenerConfig.ProviderSpecificConfig.AwsConfig.Zone)
+	})
+}
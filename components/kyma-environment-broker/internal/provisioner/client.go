@@ -25,7 +25,10 @@ type Client interface {
 	ProvisionRuntime(accountID, subAccountID string, config schema.ProvisionRuntimeInput) (schema.OperationStatus, error)
 	DeprovisionRuntime(accountID, runtimeID string) (string, error)
 	UpgradeRuntime(accountID, runtimeID string, config schema.UpgradeRuntimeInput) (schema.OperationStatus, error)
+	UpgradeShoot(accountID, runtimeID string, config schema.UpgradeShootInput) (schema.OperationStatus, error)
 	ReconnectRuntimeAgent(accountID, runtimeID string) (string, error)
+	HibernateRuntime(accountID, runtimeID string) (schema.OperationStatus, error)
+	WakeUpRuntime(accountID, runtimeID string) (schema.OperationStatus, error)
 	RuntimeOperationStatus(accountID, operationID string) (schema.OperationStatus, error)
 	RuntimeStatus(accountID, runtimeID string) (schema.RuntimeStatus, error)
 }
@@ -102,6 +105,24 @@ func (c *client) UpgradeRuntime(accountID, runtimeID string, config schema.Upgra
 	return res, nil
 }
 
+func (c *client) UpgradeShoot(accountID, runtimeID string, config schema.UpgradeShootInput) (schema.OperationStatus, error) {
+	upgradeShootIptGQL, err := c.graphqlizer.UpgradeShootInputToGraphQL(config)
+	if err != nil {
+		return schema.OperationStatus{}, errors.Wrap(err, "Failed to convert Upgrade Shoot Input to query")
+	}
+
+	query := c.queryProvider.upgradeShoot(runtimeID, upgradeShootIptGQL)
+	req := gcli.NewRequest(query)
+	req.Header.Add(accountIDKey, accountID)
+
+	var res schema.OperationStatus
+	err = c.executeRequest(req, &res)
+	if err != nil {
+		return schema.OperationStatus{}, errors.Wrap(err, "Failed to upgrade Shoot")
+	}
+	return res, nil
+}
+
 func (c *client) ReconnectRuntimeAgent(accountID, runtimeID string) (string, error) {
 	query := c.queryProvider.reconnectRuntimeAgent(runtimeID)
 	req := gcli.NewRequest(query)
@@ -115,6 +136,32 @@ func (c *client) ReconnectRuntimeAgent(accountID, runtimeID string) (string, err
 	return operationId, nil
 }
 
+func (c *client) HibernateRuntime(accountID, runtimeID string) (schema.OperationStatus, error) {
+	query := c.queryProvider.hibernateRuntime(runtimeID)
+	req := gcli.NewRequest(query)
+	req.Header.Add(accountIDKey, accountID)
+
+	var res schema.OperationStatus
+	err := c.executeRequest(req, &res)
+	if err != nil {
+		return schema.OperationStatus{}, errors.Wrap(err, "Failed to hibernate Runtime")
+	}
+	return res, nil
+}
+
+func (c *client) WakeUpRuntime(accountID, runtimeID string) (schema.OperationStatus, error) {
+	query := c.queryProvider.wakeUpRuntime(runtimeID)
+	req := gcli.NewRequest(query)
+	req.Header.Add(accountIDKey, accountID)
+
+	var res schema.OperationStatus
+	err := c.executeRequest(req, &res)
+	if err != nil {
+		return schema.OperationStatus{}, errors.Wrap(err, "Failed to wake up Runtime")
+	}
+	return res, nil
+}
+
 func (c *client) RuntimeOperationStatus(accountID, operationID string) (schema.OperationStatus, error) {
 	query := c.queryProvider.runtimeOperationStatus(operationID)
 	req := gcli.NewRequest(query)
@@ -24,6 +24,8 @@ const (
 	provisionRuntimeOperationID   = "c89f7862-0ef9-4d4e-bc82-afbc5ac98b8d"
 	upgradeRuntimeOperationID     = "74f47e0a-9a76-4336-9974-70705500a981"
 	deprovisionRuntimeOperationID = "f9f7b734-7538-419c-8ac1-37060c60531a"
+	hibernateRuntimeOperationID   = "0a4ba7d6-1e49-4bd1-8e35-3f3a3b5b4a7c"
+	wakeUpRuntimeOperationID      = "b5a2c3e1-4f7d-4b8a-9c6e-2d1f0e3a5b7c"
 )
 
 func TestClient_ProvisionRuntime(t *testing.T) {
@@ -155,6 +157,94 @@ func TestClient_UpgradeRuntime(t *testing.T) {
 	})
 }
 
+func TestClient_HibernateRuntime(t *testing.T) {
+	t.Run("should trigger hibernation", func(t *testing.T) {
+		// given
+		tr := &testResolver{t: t, runtime: &testRuntime{}}
+		testServer := fixHTTPServer(tr)
+		defer testServer.Close()
+
+		client := NewProvisionerClient(testServer.URL, false)
+		operation, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		assert.NoError(t, err)
+
+		// when
+		status, err := client.HibernateRuntime(testAccountID, *operation.RuntimeID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, ptr.String(hibernateRuntimeOperationID), status.ID)
+		assert.Equal(t, schema.OperationStateInProgress, status.State)
+		assert.Equal(t, schema.OperationTypeHibernate, status.Operation)
+		assert.Equal(t, ptr.String(provisionRuntimeID), status.RuntimeID)
+	})
+
+	t.Run("provisioner should return error", func(t *testing.T) {
+		// given
+		tr := &testResolver{t: t, runtime: &testRuntime{}}
+		testServer := fixHTTPServer(tr)
+		defer testServer.Close()
+
+		client := NewProvisionerClient(testServer.URL, false)
+		operation, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		assert.NoError(t, err)
+
+		tr.failed = true
+
+		// when
+		status, err := client.HibernateRuntime(testAccountID, *operation.RuntimeID)
+
+		// then
+		assert.Error(t, err)
+		assert.Empty(t, status)
+		assert.Equal(t, "", tr.getRuntime().hibernateOperationID)
+	})
+}
+
+func TestClient_WakeUpRuntime(t *testing.T) {
+	t.Run("should trigger wake up", func(t *testing.T) {
+		// given
+		tr := &testResolver{t: t, runtime: &testRuntime{}}
+		testServer := fixHTTPServer(tr)
+		defer testServer.Close()
+
+		client := NewProvisionerClient(testServer.URL, false)
+		operation, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		assert.NoError(t, err)
+
+		// when
+		status, err := client.WakeUpRuntime(testAccountID, *operation.RuntimeID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, ptr.String(wakeUpRuntimeOperationID), status.ID)
+		assert.Equal(t, schema.OperationStateInProgress, status.State)
+		assert.Equal(t, schema.OperationTypeWakeUp, status.Operation)
+		assert.Equal(t, ptr.String(provisionRuntimeID), status.RuntimeID)
+	})
+
+	t.Run("provisioner should return error", func(t *testing.T) {
+		// given
+		tr := &testResolver{t: t, runtime: &testRuntime{}}
+		testServer := fixHTTPServer(tr)
+		defer testServer.Close()
+
+		client := NewProvisionerClient(testServer.URL, false)
+		operation, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		assert.NoError(t, err)
+
+		tr.failed = true
+
+		// when
+		status, err := client.WakeUpRuntime(testAccountID, *operation.RuntimeID)
+
+		// then
+		assert.Error(t, err)
+		assert.Empty(t, status)
+		assert.Equal(t, "", tr.getRuntime().wakeUpOperationID)
+	})
+}
+
 func TestClient_ReconnectRuntimeAgent(t *testing.T) {
 	t.Run("should reconnect runtime agent", func(t *testing.T) {
 		// Given
@@ -315,6 +405,8 @@ type testRuntime struct {
 	provisionOperationID   string
 	upgradeOperationID     string
 	deprovisionOperationID string
+	hibernateOperationID   string
+	wakeUpOperationID      string
 }
 
 type testResolver struct {
@@ -454,6 +546,44 @@ func (tmr testMutationResolver) ReconnectRuntimeAgent(_ context.Context, id stri
 	return "", nil
 }
 
+func (tmr testMutationResolver) HibernateRuntime(_ context.Context, id string) (*schema.OperationStatus, error) {
+	tmr.t.Log("HibernateRuntime testMutationResolver")
+
+	if tmr.failed {
+		return nil, fmt.Errorf("hibernate runtime failed for %s", id)
+	}
+
+	if tmr.runtime.runtimeID == id {
+		tmr.runtime.hibernateOperationID = hibernateRuntimeOperationID
+	}
+
+	return &schema.OperationStatus{
+		ID:        ptr.String(tmr.runtime.hibernateOperationID),
+		State:     schema.OperationStateInProgress,
+		Operation: schema.OperationTypeHibernate,
+		RuntimeID: ptr.String(tmr.runtime.runtimeID),
+	}, nil
+}
+
+func (tmr testMutationResolver) WakeUpRuntime(_ context.Context, id string) (*schema.OperationStatus, error) {
+	tmr.t.Log("WakeUpRuntime testMutationResolver")
+
+	if tmr.failed {
+		return nil, fmt.Errorf("wake up runtime failed for %s", id)
+	}
+
+	if tmr.runtime.runtimeID == id {
+		tmr.runtime.wakeUpOperationID = wakeUpRuntimeOperationID
+	}
+
+	return &schema.OperationStatus{
+		ID:        ptr.String(tmr.runtime.wakeUpOperationID),
+		State:     schema.OperationStateInProgress,
+		Operation: schema.OperationTypeWakeUp,
+		RuntimeID: ptr.String(tmr.runtime.runtimeID),
+	}, nil
+}
+
 func (tqr testMutationResolver) UpgradeShoot(ctx context.Context, id string, config schema.UpgradeShootInput) (*schema.OperationStatus, error) {
 	return nil, nil
 }
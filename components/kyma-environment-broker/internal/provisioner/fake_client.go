@@ -14,17 +14,19 @@ type runtime struct {
 }
 
 type FakeClient struct {
-	mu         sync.Mutex
-	runtimes   []runtime
-	upgrades   map[string]schema.UpgradeRuntimeInput
-	operations map[string]schema.OperationStatus
+	mu            sync.Mutex
+	runtimes      []runtime
+	upgrades      map[string]schema.UpgradeRuntimeInput
+	shootUpgrades map[string]schema.UpgradeShootInput
+	operations    map[string]schema.OperationStatus
 }
 
 func NewFakeClient() *FakeClient {
 	return &FakeClient{
-		runtimes:   []runtime{},
-		operations: make(map[string]schema.OperationStatus),
-		upgrades:   make(map[string]schema.UpgradeRuntimeInput),
+		runtimes:      []runtime{},
+		operations:    make(map[string]schema.OperationStatus),
+		upgrades:      make(map[string]schema.UpgradeRuntimeInput),
+		shootUpgrades: make(map[string]schema.UpgradeShootInput),
 	}
 }
 
@@ -91,6 +93,40 @@ func (c *FakeClient) DeprovisionRuntime(accountID, runtimeID string) (string, er
 	return uuid.New().String(), nil
 }
 
+func (c *FakeClient) HibernateRuntime(accountID, runtimeID string) (schema.OperationStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	opId := uuid.New().String()
+	c.operations[opId] = schema.OperationStatus{
+		ID:        &opId,
+		RuntimeID: &runtimeID,
+		Operation: schema.OperationTypeHibernate,
+		State:     schema.OperationStateInProgress,
+	}
+	return schema.OperationStatus{
+		RuntimeID: &runtimeID,
+		ID:        &opId,
+	}, nil
+}
+
+func (c *FakeClient) WakeUpRuntime(accountID, runtimeID string) (schema.OperationStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	opId := uuid.New().String()
+	c.operations[opId] = schema.OperationStatus{
+		ID:        &opId,
+		RuntimeID: &runtimeID,
+		Operation: schema.OperationTypeWakeUp,
+		State:     schema.OperationStateInProgress,
+	}
+	return schema.OperationStatus{
+		RuntimeID: &runtimeID,
+		ID:        &opId,
+	}, nil
+}
+
 func (c *FakeClient) ReconnectRuntimeAgent(accountID, runtimeID string) (string, error) {
 	return "", fmt.Errorf("not implemented")
 }
@@ -111,6 +147,10 @@ func (c *FakeClient) RuntimeStatus(accountID, runtimeID string) (schema.RuntimeS
 	defer c.mu.Unlock()
 
 	return schema.RuntimeStatus{
+		HibernationStatus: &schema.HibernationStatus{
+			Hibernated:          ptr.Bool(false),
+			HibernationPossible: ptr.Bool(true),
+		},
 		RuntimeConfiguration: &schema.RuntimeConfig{
 			ClusterConfig: &schema.GardenerConfig{
 				Name:   ptr.String("fake-name"),
@@ -139,6 +179,32 @@ func (c *FakeClient) UpgradeRuntime(accountID, runtimeID string, config schema.U
 	}, nil
 }
 
+func (c *FakeClient) UpgradeShoot(accountID, runtimeID string, config schema.UpgradeShootInput) (schema.OperationStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	opId := uuid.New().String()
+	c.operations[opId] = schema.OperationStatus{
+		ID:        &opId,
+		RuntimeID: &runtimeID,
+		Operation: schema.OperationTypeUpgradeShoot,
+		State:     schema.OperationStateInProgress,
+	}
+	c.shootUpgrades[runtimeID] = config
+	return schema.OperationStatus{
+		RuntimeID: &runtimeID,
+		ID:        &opId,
+	}, nil
+}
+
+func (c *FakeClient) IsShootUpgraded(runtimeID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, found := c.shootUpgrades[runtimeID]
+	return found
+}
+
 func (c *FakeClient) IsRuntimeUpgraded(runtimeID string) bool {
 	_, found := c.upgrades[runtimeID]
 	return found
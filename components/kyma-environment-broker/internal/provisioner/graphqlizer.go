@@ -206,6 +206,61 @@ func (g *Graphqlizer) UpgradeRuntimeInputToGraphQL(in gqlschema.UpgradeRuntimeIn
 	}`)
 }
 
+func (g *Graphqlizer) UpgradeShootInputToGraphQL(in gqlschema.UpgradeShootInput) (string, error) {
+	return g.genericToGraphQL(in, `{
+		{{- if .GardenerConfig }}
+		gardenerConfig: {{ GardenerUpgradeInputToGraphQL .GardenerConfig }},
+		{{- end }}
+	}`)
+}
+
+func (g *Graphqlizer) GardenerUpgradeInputToGraphQL(in gqlschema.GardenerUpgradeInput) (string, error) {
+	return g.genericToGraphQL(in, `{
+		{{- if .KubernetesVersion }}
+		kubernetesVersion: "{{ .KubernetesVersion }}",
+		{{- end }}
+		{{- if .MachineType }}
+		machineType: "{{ .MachineType }}",
+		{{- end }}
+		{{- if .DiskType }}
+		diskType: "{{ .DiskType }}",
+		{{- end }}
+		{{- if .VolumeSizeGb }}
+		volumeSizeGB: {{ .VolumeSizeGb }},
+		{{- end }}
+		{{- if .AutoScalerMin }}
+		autoScalerMin: {{ .AutoScalerMin }},
+		{{- end }}
+		{{- if .AutoScalerMax }}
+		autoScalerMax: {{ .AutoScalerMax }},
+		{{- end }}
+		{{- if .MachineImage }}
+		machineImage: "{{ .MachineImage }}",
+		{{- end }}
+		{{- if .MachineImageVersion }}
+		machineImageVersion: "{{ .MachineImageVersion }}",
+		{{- end }}
+		{{- if .MaxSurge }}
+		maxSurge: {{ .MaxSurge }},
+		{{- end }}
+		{{- if .MaxUnavailable }}
+		maxUnavailable: {{ .MaxUnavailable }},
+		{{- end }}
+		{{- if .Purpose }}
+		purpose: "{{ .Purpose }}",
+		{{- end }}
+		{{- if .TargetSecret }}
+		targetSecret: "{{ .TargetSecret }}",
+		{{- end }}
+		{{- if .EnableKubernetesVersionAutoUpdate }}
+		enableKubernetesVersionAutoUpdate: {{ .EnableKubernetesVersionAutoUpdate }},
+		{{- end }}
+		{{- if .EnableMachineImageVersionAutoUpdate }}
+		enableMachineImageVersionAutoUpdate: {{ .EnableMachineImageVersionAutoUpdate }},
+		{{- end }}
+	}`)
+}
+
 func (g *Graphqlizer) genericToGraphQL(obj interface{}, tmpl string) (string, error) {
 	fm := sprig.TxtFuncMap()
 	fm["marshal"] = g.marshal
@@ -213,6 +268,7 @@ func (g *Graphqlizer) genericToGraphQL(obj interface{}, tmpl string) (string, er
 	fm["ClusterConfigToGraphQL"] = g.ClusterConfigToGraphQL
 	fm["KymaConfigToGraphQL"] = g.KymaConfigToGraphQL
 	fm["GardenerConfigInputToGraphQL"] = g.GardenerConfigInputToGraphQL
+	fm["GardenerUpgradeInputToGraphQL"] = g.GardenerUpgradeInputToGraphQL
 	fm["AzureProviderConfigInputToGraphQL"] = g.AzureProviderConfigInputToGraphQL
 	fm["GCPProviderConfigInputToGraphQL"] = g.GCPProviderConfigInputToGraphQL
 	fm["AWSProviderConfigInputToGraphQL"] = g.AWSProviderConfigInputToGraphQL
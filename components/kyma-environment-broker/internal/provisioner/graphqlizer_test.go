@@ -209,6 +209,54 @@ func Test_GardenerConfigInputToGraphQLWithMachineImage(t *testing.T) {
 	assert.Equal(t, exp, got)
 }
 
+func Test_UpgradeShootInputToGraphQL(t *testing.T) {
+	// given
+	sut := Graphqlizer{}
+	exp := `{
+		gardenerConfig: {
+		kubernetesVersion: "1.18.12",
+		machineImage: "gardenlinux",
+		machineImageVersion: "184.0.0",
+	},
+	}`
+
+	// when
+	got, err := sut.UpgradeShootInputToGraphQL(gqlschema.UpgradeShootInput{
+		GardenerConfig: &gqlschema.GardenerUpgradeInput{
+			KubernetesVersion:   strPrt("1.18.12"),
+			MachineImage:        strPrt("gardenlinux"),
+			MachineImageVersion: strPrt("184.0.0"),
+		},
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, exp, got)
+}
+
+func Test_UpgradeShootInputToGraphQLWithTargetSecret(t *testing.T) {
+	// given
+	sut := Graphqlizer{}
+	exp := `{
+		gardenerConfig: {
+		machineType: "Standard_D8_v3",
+		targetSecret: "azure-secret",
+	},
+	}`
+
+	// when
+	got, err := sut.UpgradeShootInputToGraphQL(gqlschema.UpgradeShootInput{
+		GardenerConfig: &gqlschema.GardenerUpgradeInput{
+			MachineType:  strPrt("Standard_D8_v3"),
+			TargetSecret: strPrt("azure-secret"),
+		},
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, exp, got)
+}
+
 func Test_LabelsToGQL(t *testing.T) {
 
 	sut := Graphqlizer{}
@@ -20,6 +20,14 @@ func (qp queryProvider) upgradeRuntime(runtimeID string, config string) string {
 }`, runtimeID, config, operationStatusData())
 }
 
+func (qp queryProvider) upgradeShoot(runtimeID string, config string) string {
+	return fmt.Sprintf(`mutation {
+	result: upgradeShoot(id: "%s", config: %s) {
+		%s
+}
+}`, runtimeID, config, operationStatusData())
+}
+
 func (qp queryProvider) deprovisionRuntime(runtimeID string) string {
 	return fmt.Sprintf(`mutation {
 	result: deprovisionRuntime(id: "%s")
@@ -32,6 +40,22 @@ func (qp queryProvider) reconnectRuntimeAgent(runtimeID string) string {
 }`, runtimeID)
 }
 
+func (qp queryProvider) hibernateRuntime(runtimeID string) string {
+	return fmt.Sprintf(`mutation {
+	result: hibernateRuntime(id: "%s") {
+		%s
+}
+}`, runtimeID, operationStatusData())
+}
+
+func (qp queryProvider) wakeUpRuntime(runtimeID string) string {
+	return fmt.Sprintf(`mutation {
+	result: wakeUpRuntime(id: "%s") {
+		%s
+}
+}`, runtimeID, operationStatusData())
+}
+
 func (qp queryProvider) runtimeStatus(runtimeID string) string {
 	return fmt.Sprintf(`query {
 	result: runtimeStatus(id: "%s") {
@@ -51,6 +75,7 @@ func (qp queryProvider) runtimeOperationStatus(operationID string) string {
 func runtimeStatusData() string {
 	return fmt.Sprintf(`lastOperationStatus { operation state message }
 			runtimeConnectionStatus { status }
+			hibernationStatus { hibernated hibernationPossible }
 			runtimeConfiguration { 
 				kubeconfig
 				clusterConfig { 
@@ -0,0 +1,94 @@
+package quota
+
+import (
+	"io/ioutil"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Config defines the maximum numbers of the instances the global accounts can create, the default quota
+// applies to all global accounts and the quotas of the listed global accounts override it
+type Config struct {
+	Default        Quota            `yaml:"default"`
+	GlobalAccounts map[string]Quota `yaml:"globalAccounts"`
+}
+
+// Quota limits the number of the instances of the global account, the limits which are not set are not enforced
+type Quota struct {
+	// Total is the maximum number of the instances of all plans
+	Total *int `yaml:"total"`
+	// Plans define the maximum numbers of the instances per plan name, zero forbids the plan
+	Plans map[string]int `yaml:"plans"`
+}
+
+// ReadConfigFromFile reads the quotas configuration from the given YAML file
+func ReadConfigFromFile(filename string) (Config, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return Config{}, errors.Wrapf(err, "while reading %s file with quotas config", filename)
+	}
+	var config Config
+	err = yaml.UnmarshalStrict(content, &config)
+	if err != nil {
+		return Config{}, errors.Wrapf(err, "while unmarshalling a file with quotas config")
+	}
+	if err := config.Validate(); err != nil {
+		return Config{}, errors.Wrapf(err, "while validating quotas config")
+	}
+	return config, nil
+}
+
+// Validate checks if the configured plans exist and the limits are not negative
+func (c Config) Validate() error {
+	if err := c.Default.validate(); err != nil {
+		return errors.Wrap(err, "invalid default quota")
+	}
+	for globalAccountID, quota := range c.GlobalAccounts {
+		if err := quota.validate(); err != nil {
+			return errors.Wrapf(err, "invalid quota of the global account %s", globalAccountID)
+		}
+	}
+	return nil
+}
+
+// ForGlobalAccount returns the quota of the global account, the total limit and the plan limits
+// configured for the global account take precedence over the default ones
+func (c Config) ForGlobalAccount(globalAccountID string) Quota {
+	quota := Quota{
+		Total: c.Default.Total,
+		Plans: map[string]int{},
+	}
+	for planName, limit := range c.Default.Plans {
+		quota.Plans[planName] = limit
+	}
+
+	override, found := c.GlobalAccounts[globalAccountID]
+	if !found {
+		return quota
+	}
+	if override.Total != nil {
+		quota.Total = override.Total
+	}
+	for planName, limit := range override.Plans {
+		quota.Plans[planName] = limit
+	}
+	return quota
+}
+
+func (q Quota) validate() error {
+	if q.Total != nil && *q.Total < 0 {
+		return errors.Errorf("total limit cannot be negative, got %d", *q.Total)
+	}
+	for planName, limit := range q.Plans {
+		if _, found := broker.PlanIDsMapping[planName]; !found {
+			return errors.Errorf("plan %s does not exist", planName)
+		}
+		if limit < 0 {
+			return errors.Errorf("limit of the plan %s cannot be negative, got %d", planName, limit)
+		}
+	}
+	return nil
+}
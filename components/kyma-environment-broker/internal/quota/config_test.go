@@ -0,0 +1,67 @@
+package quota
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadConfigFromFile(t *testing.T) {
+	// when
+	config, err := ReadConfigFromFile("testdata/quotas.yaml")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, Config{
+		Default: Quota{
+			Total: ptr.Integer(5),
+			Plans: map[string]int{"trial": 1, "azure": 3},
+		},
+		GlobalAccounts: map[string]Quota{
+			"automation-ga": {
+				Total: ptr.Integer(20),
+				Plans: map[string]int{"azure": 10, "gcp": 0},
+			},
+		},
+	}, config)
+
+	assert.Equal(t, Quota{
+		Total: ptr.Integer(20),
+		Plans: map[string]int{"trial": 1, "azure": 10, "gcp": 0},
+	}, config.ForGlobalAccount("automation-ga"))
+	assert.Equal(t, Quota{
+		Total: ptr.Integer(5),
+		Plans: map[string]int{"trial": 1, "azure": 3},
+	}, config.ForGlobalAccount("other-ga"))
+}
+
+func TestConfig_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		config Config
+		expErr string
+	}{
+		"unknown plan": {
+			config: Config{Default: Quota{Plans: map[string]int{"openstack": 1}}},
+			expErr: "invalid default quota: plan openstack does not exist",
+		},
+		"negative total limit": {
+			config: Config{GlobalAccounts: map[string]Quota{"ga": {Total: ptr.Integer(-1)}}},
+			expErr: "invalid quota of the global account ga: total limit cannot be negative, got -1",
+		},
+		"negative plan limit": {
+			config: Config{GlobalAccounts: map[string]Quota{"ga": {Plans: map[string]int{"azure": -2}}}},
+			expErr: "invalid quota of the global account ga: limit of the plan azure cannot be negative, got -2",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			err := tc.config.Validate()
+
+			// then
+			assert.EqualError(t, err, tc.expErr)
+		})
+	}
+}
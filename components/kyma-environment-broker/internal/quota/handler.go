@@ -0,0 +1,37 @@
+package quota
+
+import (
+	"net/http"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// Handler exposes the admin API which shows the usage of the quotas
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) AttachRoutes(router *mux.Router) {
+	router.HandleFunc("/quotas/{global_account_id}", h.getUsage).Methods(http.MethodGet)
+}
+
+func (h *Handler) getUsage(w http.ResponseWriter, r *http.Request) {
+	globalAccountID := mux.Vars(r)["global_account_id"]
+
+	usage, err := h.service.Usage(globalAccountID)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while getting usage of the global account %s", globalAccountID))
+		return
+	}
+
+	httputil.WriteResponse(w, http.StatusOK, usage)
+}
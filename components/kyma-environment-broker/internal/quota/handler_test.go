@@ -0,0 +1,47 @@
+package quota
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_GetUsage(t *testing.T) {
+	// given
+	st := storage.NewMemoryStorage()
+	require.NoError(t, st.Instances().Insert(fixInstance("trial", limitedAccountID, broker.TrialPlanName)))
+
+	router := mux.NewRouter()
+	NewHandler(NewService(st.Instances(), fixConfig(), logrus.New())).AttachRoutes(router)
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/quotas/%s", limitedAccountID), nil)
+	require.NoError(t, err)
+	resp := httptest.NewRecorder()
+
+	// when
+	router.ServeHTTP(resp, req)
+
+	// then
+	require.Equal(t, http.StatusOK, resp.Code)
+	var usage UsageDTO
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &usage))
+	assert.Equal(t, UsageDTO{
+		GlobalAccountID: limitedAccountID,
+		Total:           Usage{Used: 1, Limit: ptr.Integer(1)},
+		Plans: map[string]Usage{
+			broker.TrialPlanName: {Used: 1, Limit: ptr.Integer(1)},
+			broker.AzurePlanName: {Used: 0, Limit: ptr.Integer(3)},
+		},
+	}, usage)
+}
@@ -0,0 +1,99 @@
+package quota
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ExceededError is returned when the global account cannot create another instance
+type ExceededError struct {
+	message string
+}
+
+func (e ExceededError) Error() string     { return e.message }
+func (ExceededError) QuotaExceeded() bool { return true }
+
+// UsageDTO compares the instances of the global account with its quota
+type UsageDTO struct {
+	GlobalAccountID string           `json:"globalAccountID"`
+	Total           Usage            `json:"total"`
+	Plans           map[string]Usage `json:"plans"`
+}
+
+// Usage is the number of the existing instances and the limit, the limit is not set if it is not enforced
+type Usage struct {
+	Used  int  `json:"used"`
+	Limit *int `json:"limit,omitempty"`
+}
+
+// Service checks the instances of the global accounts against their quotas
+type Service struct {
+	instances storage.Instances
+
+	cfg Config
+	log logrus.FieldLogger
+}
+
+func NewService(instances storage.Instances, cfg Config, log logrus.FieldLogger) *Service {
+	return &Service{
+		instances: instances,
+		cfg:       cfg,
+		log:       log.WithField("service", "quota"),
+	}
+}
+
+// CheckQuota returns ExceededError if the global account with the given instances cannot create another instance
+// of the given plan
+func (s *Service) CheckQuota(globalAccountID, planName string, instances []internal.Instance) error {
+	usage := s.usage(globalAccountID, instances)
+
+	if limit := usage.Total.Limit; limit != nil && usage.Total.Used >= *limit {
+		s.log.Infof("Global account %s reached the quota of %d instances", globalAccountID, *limit)
+		return ExceededError{message: fmt.Sprintf("global account %s reached the quota of %d instances", globalAccountID, *limit)}
+	}
+	if plan := usage.Plans[planName]; plan.Limit != nil && plan.Used >= *plan.Limit {
+		s.log.Infof("Global account %s reached the quota of %d instances of the plan %s", globalAccountID, *plan.Limit, planName)
+		return ExceededError{message: fmt.Sprintf("global account %s reached the quota of %d instances of the plan %s", globalAccountID, *plan.Limit, planName)}
+	}
+	return nil
+}
+
+// Usage returns the numbers of the instances of the global account together with its quota,
+// the plans which have neither instances nor limits are omitted
+func (s *Service) Usage(globalAccountID string) (UsageDTO, error) {
+	instances, _, _, err := s.instances.List(dbmodel.InstanceFilter{GlobalAccountIDs: []string{globalAccountID}})
+	if err != nil {
+		return UsageDTO{}, errors.Wrapf(err, "while listing instances of the global account %s", globalAccountID)
+	}
+
+	return s.usage(globalAccountID, instances), nil
+}
+
+func (s *Service) usage(globalAccountID string, instances []internal.Instance) UsageDTO {
+	quota := s.cfg.ForGlobalAccount(globalAccountID)
+	usage := UsageDTO{
+		GlobalAccountID: globalAccountID,
+		Total: Usage{
+			Used:  len(instances),
+			Limit: quota.Total,
+		},
+		Plans: map[string]Usage{},
+	}
+	for planName, limit := range quota.Plans {
+		usage.Plans[planName] = Usage{Limit: ptr.Integer(limit)}
+	}
+	for _, instance := range instances {
+		plan := usage.Plans[instance.ServicePlanName]
+		plan.Used++
+		usage.Plans[instance.ServicePlanName] = plan
+	}
+
+	return usage
+}
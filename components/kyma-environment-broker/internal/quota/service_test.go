@@ -0,0 +1,122 @@
+package quota
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	globalAccountID      = "ga-id"
+	limitedAccountID     = "limited-ga-id"
+	otherGlobalAccountID = "other-ga-id"
+)
+
+func TestService_CheckQuota(t *testing.T) {
+	// given
+	st := storage.NewMemoryStorage()
+	require.NoError(t, st.Instances().Insert(fixInstance("azure-1", globalAccountID, broker.AzurePlanName)))
+	require.NoError(t, st.Instances().Insert(fixInstance("azure-2", globalAccountID, broker.AzurePlanName)))
+	require.NoError(t, st.Instances().Insert(fixInstance("trial", globalAccountID, broker.TrialPlanName)))
+	require.NoError(t, st.Instances().Insert(fixInstance("gcp", limitedAccountID, broker.GCPPlanName)))
+	require.NoError(t, st.Instances().Insert(fixInstance("other", otherGlobalAccountID, broker.AzurePlanName)))
+	svc := NewService(st.Instances(), fixConfig(), logrus.New())
+
+	for name, tc := range map[string]struct {
+		globalAccountID string
+		planName        string
+		exceeded        bool
+	}{
+		"plan below the limit": {
+			globalAccountID: globalAccountID,
+			planName:        broker.AzurePlanName,
+		},
+		"plan limit reached": {
+			globalAccountID: globalAccountID,
+			planName:        broker.TrialPlanName,
+			exceeded:        true,
+		},
+		"plan without limit": {
+			globalAccountID: globalAccountID,
+			planName:        broker.GCPPlanName,
+		},
+		"total limit reached": {
+			globalAccountID: limitedAccountID,
+			planName:        broker.AzurePlanName,
+			exceeded:        true,
+		},
+		"plan forbidden": {
+			globalAccountID: otherGlobalAccountID,
+			planName:        broker.GCPPlanName,
+			exceeded:        true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			instances, _, _, err := st.Instances().List(dbmodel.InstanceFilter{GlobalAccountIDs: []string{tc.globalAccountID}})
+			require.NoError(t, err)
+			err = svc.CheckQuota(tc.globalAccountID, tc.planName, instances)
+
+			// then
+			if tc.exceeded {
+				require.Error(t, err)
+				assert.IsType(t, ExceededError{}, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_Usage(t *testing.T) {
+	// given
+	st := storage.NewMemoryStorage()
+	require.NoError(t, st.Instances().Insert(fixInstance("azure-1", globalAccountID, broker.AzurePlanName)))
+	require.NoError(t, st.Instances().Insert(fixInstance("gcp", globalAccountID, broker.GCPPlanName)))
+	require.NoError(t, st.Instances().Insert(fixInstance("other", otherGlobalAccountID, broker.AzurePlanName)))
+	svc := NewService(st.Instances(), fixConfig(), logrus.New())
+
+	// when
+	usage, err := svc.Usage(globalAccountID)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, UsageDTO{
+		GlobalAccountID: globalAccountID,
+		Total:           Usage{Used: 2},
+		Plans: map[string]Usage{
+			broker.AzurePlanName: {Used: 1, Limit: ptr.Integer(3)},
+			broker.TrialPlanName: {Used: 0, Limit: ptr.Integer(1)},
+			broker.GCPPlanName:   {Used: 1},
+		},
+	}, usage)
+}
+
+func fixConfig() Config {
+	return Config{
+		Default: Quota{
+			Plans: map[string]int{broker.TrialPlanName: 1, broker.AzurePlanName: 3},
+		},
+		GlobalAccounts: map[string]Quota{
+			limitedAccountID:     {Total: ptr.Integer(1)},
+			otherGlobalAccountID: {Plans: map[string]int{broker.GCPPlanName: 0}},
+		},
+	}
+}
+
+func fixInstance(id, globalAccountID, planName string) internal.Instance {
+	return internal.Instance{
+		InstanceID:      id,
+		GlobalAccountID: globalAccountID,
+		ServicePlanID:   broker.PlanIDsMapping[planName],
+		ServicePlanName: planName,
+	}
+}
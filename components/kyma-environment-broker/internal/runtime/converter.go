@@ -1,6 +1,7 @@
 package runtime
 
 import (
+	"sort"
 	"strings"
 
 	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
@@ -14,6 +15,7 @@ type Converter interface {
 	ApplyUpgradingKymaOperations(dto *pkg.RuntimeDTO, oprs []internal.UpgradeKymaOperation, totalCount int)
 	ApplySuspensionOperations(dto *pkg.RuntimeDTO, oprs []internal.DeprovisioningOperation)
 	ApplyUnsuspensionOperations(dto *pkg.RuntimeDTO, oprs []internal.ProvisioningOperation)
+	ApplyWakeUpOperations(dto *pkg.RuntimeDTO, oprs []internal.UpdatingOperation)
 }
 
 type converter struct {
@@ -129,3 +131,25 @@ func (c *converter) ApplyUnsuspensionOperations(dto *pkg.RuntimeDTO, oprs []inte
 		dto.Status.Unsuspension.Data = append(dto.Status.Unsuspension.Data, op)
 	}
 }
+
+func (c *converter) ApplyWakeUpOperations(dto *pkg.RuntimeDTO, oprs []internal.UpdatingOperation) {
+	dto.Status.WakeUp.Data = make([]pkg.Operation, 0)
+
+	wakeUpOps := make([]internal.UpdatingOperation, 0)
+	for _, o := range oprs {
+		if o.WakeUp {
+			wakeUpOps = append(wakeUpOps, o)
+		}
+	}
+	sort.Slice(wakeUpOps, func(i, j int) bool {
+		return wakeUpOps[i].CreatedAt.After(wakeUpOps[j].CreatedAt)
+	})
+
+	for _, o := range wakeUpOps {
+		op := pkg.Operation{}
+		c.applyOperation(&o.Operation, &op)
+		dto.Status.WakeUp.Data = append(dto.Status.WakeUp.Data, op)
+	}
+	dto.Status.WakeUp.TotalCount = len(dto.Status.WakeUp.Data)
+	dto.Status.WakeUp.Count = len(dto.Status.WakeUp.Data)
+}
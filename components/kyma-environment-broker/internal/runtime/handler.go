@@ -2,6 +2,7 @@ package runtime
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pagination"
 	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
@@ -20,15 +21,17 @@ const numberOfUpgradeOperationsToReturn = 2
 type Handler struct {
 	instancesDb  storage.Instances
 	operationsDb storage.Operations
+	archivesDb   storage.InstanceArchives
 	converter    Converter
 
 	defaultMaxPage int
 }
 
-func NewHandler(instanceDb storage.Instances, operationDb storage.Operations, defaultMaxPage int, defaultRequestRegion string) *Handler {
+func NewHandler(instanceDb storage.Instances, operationDb storage.Operations, archiveDb storage.InstanceArchives, defaultMaxPage int, defaultRequestRegion string) *Handler {
 	return &Handler{
 		instancesDb:    instanceDb,
 		operationsDb:   operationDb,
+		archivesDb:     archiveDb,
 		converter:      NewConverter(defaultRequestRegion),
 		defaultMaxPage: defaultMaxPage,
 	}
@@ -46,6 +49,14 @@ func (h *Handler) getRuntimes(w http.ResponseWriter, req *http.Request) {
 		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrap(err, "while getting query parameters"))
 		return
 	}
+	includeDeleted := false
+	if value := req.URL.Query().Get(pkg.IncludeDeletedParam); value != "" {
+		includeDeleted, err = strconv.ParseBool(value)
+		if err != nil {
+			httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrapf(err, "while parsing %s query parameter", pkg.IncludeDeletedParam))
+			return
+		}
+	}
 	filter := h.getFilters(req)
 	filter.PageSize = pageSize
 	filter.Page = page
@@ -97,9 +108,39 @@ func (h *Handler) getRuntimes(w http.ResponseWriter, req *http.Request) {
 		}
 		h.converter.ApplySuspensionOperations(&dto, deprovOprs)
 
+		updOprs, err := h.operationsDb.ListUpdatingOperationsByInstanceID(instance.InstanceID)
+		if err != nil && !dberr.IsNotFound(err) {
+			httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrap(err, "while fetching updating operations list for instance"))
+			return
+		}
+		h.converter.ApplyWakeUpOperations(&dto, updOprs)
+
 		toReturn = append(toReturn, dto)
 	}
 
+	if includeDeleted {
+		// the archived runtimes are returned after all existing ones
+		offset := (page-1)*pageSize - totalCount
+		if offset < 0 {
+			offset = 0
+		}
+		archives, archivedCount, err := h.archivesDb.List(filter, offset, pageSize-len(instances))
+		if err != nil {
+			httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrap(err, "while fetching instance archives"))
+			return
+		}
+		for _, archive := range archives {
+			dto, err := h.archivedRuntimeDTO(archive)
+			if err != nil {
+				httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrap(err, "while converting instance archive to DTO"))
+				return
+			}
+			toReturn = append(toReturn, dto)
+		}
+		count += len(archives)
+		totalCount += archivedCount
+	}
+
 	runtimePage := pkg.RuntimesPage{
 		Data:       toReturn,
 		Count:      count,
@@ -108,6 +149,34 @@ func (h *Handler) getRuntimes(w http.ResponseWriter, req *http.Request) {
 	httputil.WriteResponse(w, http.StatusOK, runtimePage)
 }
 
+func (h *Handler) archivedRuntimeDTO(archive internal.InstanceArchive) (pkg.RuntimeDTO, error) {
+	dto, err := h.converter.NewDTO(archive.Instance)
+	if err != nil {
+		return pkg.RuntimeDTO{}, err
+	}
+	archivedAt := archive.ArchivedAt
+	dto.Status.ArchivedAt = &archivedAt
+
+	var firstProvOp internal.ProvisioningOperation
+	if len(archive.ProvisioningOperations) != 0 {
+		firstProvOp = archive.ProvisioningOperations[len(archive.ProvisioningOperations)-1]
+	}
+	h.converter.ApplyProvisioningOperation(&dto, &firstProvOp)
+	h.converter.ApplyUnsuspensionOperations(&dto, archive.ProvisioningOperations)
+
+	// the operations are archived in the same order as they are listed, the latest first
+	if len(archive.DeprovisioningOperations) != 0 {
+		h.converter.ApplyDeprovisioningOperation(&dto, &archive.DeprovisioningOperations[0])
+	}
+
+	ukOprs, totalCount := h.takeLastNonDryRunOperations(archive.UpgradeKymaOperations)
+	h.converter.ApplyUpgradingKymaOperations(&dto, ukOprs, totalCount)
+	h.converter.ApplySuspensionOperations(&dto, archive.DeprovisioningOperations)
+	h.converter.ApplyWakeUpOperations(&dto, archive.UpdatingOperations)
+
+	return dto, nil
+}
+
 func (h *Handler) takeLastNonDryRunOperations(oprs []internal.UpgradeKymaOperation) ([]internal.UpgradeKymaOperation, int) {
 	toReturn := make([]internal.UpgradeKymaOperation, 0)
 	totalCount := 0
@@ -14,6 +14,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/driver/memory"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,6 +25,7 @@ func TestRuntimeHandler(t *testing.T) {
 		// given
 		operations := memory.NewOperation()
 		instances := memory.NewInstance(operations)
+		archives := memory.NewInstanceArchives(operations)
 		testID1 := "Test1"
 		testID2 := "Test2"
 		testTime1 := time.Now()
@@ -44,7 +46,7 @@ func TestRuntimeHandler(t *testing.T) {
 		err = instances.Insert(testInstance2)
 		require.NoError(t, err)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, 2, "")
+		runtimeHandler := runtime.NewHandler(instances, operations, archives, 2, "")
 
 		req, err := http.NewRequest("GET", "/runtimes?page_size=1", nil)
 		require.NoError(t, err)
@@ -93,8 +95,9 @@ func TestRuntimeHandler(t *testing.T) {
 		// given
 		operations := memory.NewOperation()
 		instances := memory.NewInstance(operations)
+		archives := memory.NewInstanceArchives(operations)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, 2, "region")
+		runtimeHandler := runtime.NewHandler(instances, operations, archives, 2, "region")
 
 		req, err := http.NewRequest("GET", "/runtimes?page_size=a", nil)
 		require.NoError(t, err)
@@ -128,6 +131,7 @@ func TestRuntimeHandler(t *testing.T) {
 		// given
 		operations := memory.NewOperation()
 		instances := memory.NewInstance(operations)
+		archives := memory.NewInstanceArchives(operations)
 		testID1 := "Test1"
 		testID2 := "Test2"
 		testTime1 := time.Now()
@@ -140,7 +144,7 @@ func TestRuntimeHandler(t *testing.T) {
 		err = instances.Insert(testInstance2)
 		require.NoError(t, err)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, 2, "")
+		runtimeHandler := runtime.NewHandler(instances, operations, archives, 2, "")
 
 		req, err := http.NewRequest("GET", fmt.Sprintf("/runtimes?account=%s&subaccount=%s&instance_id=%s&runtime_id=%s&region=%s&shoot=%s", testID1, testID1, testID1, testID1, testID1, testID1), nil)
 		require.NoError(t, err)
@@ -169,6 +173,7 @@ func TestRuntimeHandler(t *testing.T) {
 		// given
 		operations := memory.NewOperation()
 		instances := memory.NewInstance(operations)
+		archives := memory.NewInstanceArchives(operations)
 		testID1 := "Test1"
 		testTime1 := time.Now()
 		testInstance1 := fixInstance(testID1, testTime1)
@@ -212,7 +217,7 @@ func TestRuntimeHandler(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, 2, "")
+		runtimeHandler := runtime.NewHandler(instances, operations, archives, 2, "")
 
 		req, err := http.NewRequest("GET", "/runtimes", nil)
 		require.NoError(t, err)
@@ -244,6 +249,178 @@ func TestRuntimeHandler(t *testing.T) {
 		assert.Equal(t, 1, len(suspensionOps))
 		assert.Equal(t, suspensionOpId, suspensionOps[0].OperationID)
 	})
+	t.Run("should show wake up operations", func(t *testing.T) {
+		// given
+		operations := memory.NewOperation()
+		instances := memory.NewInstance(operations)
+		archives := memory.NewInstanceArchives(operations)
+		testID1 := "Test1"
+		testTime1 := time.Now()
+		testInstance1 := fixInstance(testID1, testTime1)
+
+		wakeUpOpId := "wake-up-op-id"
+
+		err := instances.Insert(testInstance1)
+		require.NoError(t, err)
+
+		err = operations.InsertProvisioningOperation(internal.ProvisioningOperation{
+			Operation: internal.Operation{
+				ID:         "provisioning-id",
+				Version:    0,
+				CreatedAt:  time.Now(),
+				UpdatedAt:  time.Now(),
+				InstanceID: testID1,
+			},
+		})
+		require.NoError(t, err)
+		err = operations.InsertUpdatingOperation(internal.UpdatingOperation{
+			Operation: internal.Operation{
+				ID:         "plan-update-id",
+				Version:    0,
+				CreatedAt:  time.Now().Add(1 * time.Hour),
+				UpdatedAt:  time.Now().Add(1 * time.Hour),
+				InstanceID: testID1,
+			},
+		})
+		require.NoError(t, err)
+		err = operations.InsertUpdatingOperation(internal.UpdatingOperation{
+			Operation: internal.Operation{
+				ID:         wakeUpOpId,
+				Version:    0,
+				CreatedAt:  time.Now().Add(2 * time.Hour),
+				UpdatedAt:  time.Now().Add(2 * time.Hour),
+				InstanceID: testID1,
+			},
+			WakeUp: true,
+		})
+		require.NoError(t, err)
+
+		runtimeHandler := runtime.NewHandler(instances, operations, archives, 2, "")
+
+		req, err := http.NewRequest("GET", "/runtimes", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		router := mux.NewRouter()
+		runtimeHandler.AttachRoutes(router)
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var out pkg.RuntimesPage
+
+		err = json.Unmarshal(rr.Body.Bytes(), &out)
+		require.NoError(t, err)
+
+		wakeUpOps := out.Data[0].Status.WakeUp.Data
+		assert.Equal(t, 1, len(wakeUpOps))
+		assert.Equal(t, wakeUpOpId, wakeUpOps[0].OperationID)
+
+		_, opType := pkg.FindLastOperation(out.Data[0])
+		assert.Equal(t, pkg.WakeUp, opType)
+	})
+
+	t.Run("test deleted runtimes should be returned from the archive after the existing ones", func(t *testing.T) {
+		// given
+		operations := memory.NewOperation()
+		instances := memory.NewInstance(operations)
+		archives := memory.NewInstanceArchives(operations)
+		testID1 := "Test1"
+		testID2 := "Test2"
+		testID3 := "Test3"
+		deprovOpId := "deprov-op-id"
+		archivedAt := time.Now()
+
+		err := instances.Insert(fixInstance(testID1, time.Now()))
+		require.NoError(t, err)
+		err = archives.Insert(internal.InstanceArchive{
+			Instance: fixInstance(testID2, time.Now().Add(-2*time.Hour)),
+			DeprovisioningOperations: []internal.DeprovisioningOperation{
+				{
+					Operation: internal.Operation{
+						ID:         deprovOpId,
+						CreatedAt:  archivedAt.Add(-time.Minute),
+						InstanceID: testID2,
+						State:      domain.Succeeded,
+					},
+				},
+			},
+			ArchivedAt: archivedAt,
+		})
+		require.NoError(t, err)
+		err = archives.Insert(internal.InstanceArchive{
+			Instance:   fixInstance(testID3, time.Now().Add(-time.Hour)),
+			ArchivedAt: archivedAt,
+		})
+		require.NoError(t, err)
+
+		runtimeHandler := runtime.NewHandler(instances, operations, archives, 2, "")
+
+		req, err := http.NewRequest("GET", "/runtimes?page_size=2&includeDeleted=true", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		router := mux.NewRouter()
+		runtimeHandler.AttachRoutes(router)
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var out pkg.RuntimesPage
+
+		err = json.Unmarshal(rr.Body.Bytes(), &out)
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, out.TotalCount)
+		assert.Equal(t, 2, out.Count)
+		assert.Equal(t, testID1, out.Data[0].InstanceID)
+		assert.Nil(t, out.Data[0].Status.ArchivedAt)
+		assert.Equal(t, testID2, out.Data[1].InstanceID)
+		require.NotNil(t, out.Data[1].Status.ArchivedAt)
+		require.NotNil(t, out.Data[1].Status.Deprovisioning)
+		assert.Equal(t, deprovOpId, out.Data[1].Status.Deprovisioning.OperationID)
+
+		// given
+		req, err = http.NewRequest("GET", "/runtimes?page=2&page_size=2&includeDeleted=true", nil)
+		require.NoError(t, err)
+		rr = httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		err = json.Unmarshal(rr.Body.Bytes(), &out)
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, out.TotalCount)
+		assert.Equal(t, 1, out.Count)
+		assert.Equal(t, testID3, out.Data[0].InstanceID)
+
+		// given
+		req, err = http.NewRequest("GET", "/runtimes", nil)
+		require.NoError(t, err)
+		rr = httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		err = json.Unmarshal(rr.Body.Bytes(), &out)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, out.TotalCount)
+		assert.Equal(t, testID1, out.Data[0].InstanceID)
+	})
 }
 
 func fixInstance(id string, t time.Time) internal.Instance {
@@ -31,7 +31,12 @@ func (rvc *RuntimeVersionConfigurator) ForProvisioning(op internal.ProvisioningO
 }
 
 func (rvc *RuntimeVersionConfigurator) ForUpgrade(op internal.UpgradeKymaOperation) (*internal.RuntimeVersionData, error) {
-	version, found, err := rvc.accountMapping.Get(op.GlobalAccountID, op.RuntimeOperation.SubAccountID)
+	return rvc.ForAccount(op.GlobalAccountID, op.RuntimeOperation.SubAccountID)
+}
+
+// ForAccount returns the Kyma version configured for the given accounts or the default one
+func (rvc *RuntimeVersionConfigurator) ForAccount(globalAccountID, subAccountID string) (*internal.RuntimeVersionData, error) {
+	version, found, err := rvc.accountMapping.Get(globalAccountID, subAccountID)
 	if err != nil {
 		return nil, err
 	}
@@ -98,6 +98,36 @@ func Test_RuntimeVersionConfigurator_ForProvisioning_FromParameters(t *testing.T
 	})
 }
 
+func Test_RuntimeVersionConfigurator_ForAccount(t *testing.T) {
+	t.Run("should return version from Defaults when no mapping provided", func(t *testing.T) {
+		// given
+		runtimeVer := "1.12"
+		rvc := NewRuntimeVersionConfigurator(runtimeVer, fixAccountVersionMapping(t, map[string]string{}))
+
+		// when
+		ver, err := rvc.ForAccount(fixGlobalAccountID, fixSubAccountID)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, runtimeVer, ver.Version)
+		require.Equal(t, internal.Defaults, ver.Origin)
+	})
+	t.Run("should return version from GlobalAccount mapping", func(t *testing.T) {
+		// given
+		rvc := NewRuntimeVersionConfigurator("1.12", fixAccountVersionMapping(t, map[string]string{
+			fmt.Sprintf("%s%s", globalAccountPrefix, fixGlobalAccountID): versionForGA,
+		}))
+
+		// when
+		ver, err := rvc.ForAccount(fixGlobalAccountID, fixSubAccountID)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, versionForGA, ver.Version)
+		require.Equal(t, internal.AccountMapping, ver.Origin)
+	})
+}
+
 func fixAccountVersionMapping(t *testing.T, mapping map[string]string) *AccountVersionMapping {
 	sch := runtime.NewScheme()
 	require.NoError(t, coreV1.AddToScheme(sch))
@@ -0,0 +1,20 @@
+package dbmodel
+
+import (
+	"time"
+)
+
+type BindingDTO struct {
+	BindingID  string
+	InstanceID string
+
+	Operation   string
+	State       string
+	Description string
+
+	// Kubeconfig is encrypted
+	Kubeconfig string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
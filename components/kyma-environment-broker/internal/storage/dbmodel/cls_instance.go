@@ -0,0 +1,17 @@
+package dbmodel
+
+import "time"
+
+type CLSInstanceDTO struct {
+	ID              string
+	Version         int
+	GlobalAccountID string
+	Region          string
+	CreatedAt       time.Time
+}
+
+type CLSInstanceReferenceDTO struct {
+	CLSInstanceID string
+	SKRInstanceID string
+	CreatedAt     time.Time
+}
@@ -31,6 +31,10 @@ type InstanceDTO struct {
 	DashboardURL           string
 	ProvisioningParameters string
 	ProviderRegion         string
+	PlanHistory            string
+
+	ExpiresAt          time.Time
+	ExpirationWarnedAt time.Time
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
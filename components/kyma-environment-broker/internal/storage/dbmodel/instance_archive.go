@@ -0,0 +1,21 @@
+package dbmodel
+
+import "time"
+
+// InstanceArchiveDTO holds the snapshot of the deleted instance, the columns are used to filter the archives
+// and the snapshot itself is stored as JSON in Data
+type InstanceArchiveDTO struct {
+	InstanceID      string
+	RuntimeID       string
+	GlobalAccountID string
+	SubAccountID    string
+	ServicePlanID   string
+	ServicePlanName string
+	ProviderRegion  string
+	DashboardURL    string
+
+	Data string
+
+	CreatedAt  time.Time
+	ArchivedAt time.Time
+}
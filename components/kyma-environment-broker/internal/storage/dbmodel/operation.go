@@ -24,6 +24,10 @@ const (
 	OperationTypeUndefined OperationType = ""
 	// OperationTypeUpgradeKyma means upgrade Kyma OperationType
 	OperationTypeUpgradeKyma OperationType = "upgradeKyma"
+	// OperationTypeUpgradeCluster means upgrade cluster (shoot) OperationType
+	OperationTypeUpgradeCluster OperationType = "upgradeCluster"
+	// OperationTypeUpdate means update of the instance parameters OperationType
+	OperationTypeUpdate OperationType = "update"
 )
 
 type OperationDTO struct {
@@ -0,0 +1,23 @@
+package dbmodel
+
+import (
+	"time"
+)
+
+type OperationEventDTO struct {
+	ID          string
+	OperationID string
+	InstanceID  string
+
+	StepName   string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	// Duration and RequeueDelay are stored in nanoseconds
+	Duration     int64
+	RequeueDelay int64
+	Error        string
+
+	OldState    string
+	NewState    string
+	Description string
+}
@@ -1,12 +1,14 @@
 package dbmodel
 
 import (
+	"database/sql"
 	"encoding/json"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/storage"
 )
 
 // OrchestrationFilter holds the filters when listing orchestrations
@@ -22,7 +24,9 @@ type OrchestrationDTO struct {
 	Description     string
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
+	Type            string
 	Parameters      string
+	RetryOf         sql.NullString
 }
 
 func NewOrchestrationDTO(o internal.Orchestration) (OrchestrationDTO, error) {
@@ -37,7 +41,9 @@ func NewOrchestrationDTO(o internal.Orchestration) (OrchestrationDTO, error) {
 		CreatedAt:       o.CreatedAt,
 		UpdatedAt:       o.UpdatedAt,
 		Description:     o.Description,
+		Type:            string(o.Type),
 		Parameters:      string(params),
+		RetryOf:         storage.StringToSQLNullString(o.RetryOf),
 	}
 	return dto, nil
 }
@@ -48,12 +54,19 @@ func (o *OrchestrationDTO) ToOrchestration() (internal.Orchestration, error) {
 	if err != nil {
 		return internal.Orchestration{}, err
 	}
+	// orchestrations created before the type was introduced upgrade Kyma
+	orchestrationType := orchestration.UpgradeKymaOrchestration
+	if o.Type != "" {
+		orchestrationType = orchestration.Type(o.Type)
+	}
 	return internal.Orchestration{
 		OrchestrationID: o.OrchestrationID,
 		State:           o.State,
 		Description:     o.Description,
 		CreatedAt:       o.CreatedAt,
 		UpdatedAt:       o.UpdatedAt,
+		Type:            orchestrationType,
 		Parameters:      params,
+		RetryOf:         storage.SQLNullStringToString(o.RetryOf),
 	}, nil
 }
@@ -0,0 +1,18 @@
+package dbmodel
+
+import (
+	"time"
+)
+
+type OutboxEventDTO struct {
+	ID         string
+	Type       string
+	Payload    []byte
+	Subscriber string
+
+	State         string
+	Attempts      int
+	LastError     string
+	CreatedAt     time.Time
+	NextAttemptAt time.Time
+}
@@ -0,0 +1,26 @@
+package dbmodel
+
+import (
+	"time"
+)
+
+type WebhookSubscriptionDTO struct {
+	ID     string
+	URL    string
+	Secret string
+	// Filter is the JSON encoded filter of the notifications
+	Filter string
+
+	CreatedAt time.Time
+}
+
+type WebhookDeliveryDTO struct {
+	ID             string
+	SubscriptionID string
+	NotificationID string
+	EventType      string
+
+	StatusCode int
+	Error      string
+	CreatedAt  time.Time
+}
@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+)
+
+type bindings struct {
+	mu sync.Mutex
+
+	bindings map[string]internal.Binding
+}
+
+func NewBindings() *bindings {
+	return &bindings{
+		bindings: make(map[string]internal.Binding, 0),
+	}
+}
+
+func (s *bindings) Insert(binding internal.Binding) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, found := s.bindings[binding.BindingID]; found {
+		return dberr.AlreadyExists("binding with id %s already exist", binding.BindingID)
+	}
+	s.bindings[binding.BindingID] = binding
+
+	return nil
+}
+
+func (s *bindings) Update(binding internal.Binding) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, found := s.bindings[binding.BindingID]; !found {
+		return dberr.NotFound("binding with id %s not exist", binding.BindingID)
+	}
+	s.bindings[binding.BindingID] = binding
+
+	return nil
+}
+
+func (s *bindings) Delete(bindingID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.bindings, bindingID)
+
+	return nil
+}
+
+func (s *bindings) GetByID(bindingID string) (*internal.Binding, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	binding, found := s.bindings[bindingID]
+	if !found {
+		return nil, dberr.NotFound("binding with id %s not exist", bindingID)
+	}
+
+	return &binding, nil
+}
+
+func (s *bindings) ListByInstanceID(instanceID string) ([]internal.Binding, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]internal.Binding, 0)
+	for _, binding := range s.bindings {
+		if binding.InstanceID == instanceID {
+			result = append(result, binding)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *bindings) ListByState(state domain.LastOperationState) ([]internal.Binding, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]internal.Binding, 0)
+	for _, binding := range s.bindings {
+		if binding.State == state {
+			result = append(result, binding)
+		}
+	}
+
+	return result, nil
+}
@@ -58,8 +58,18 @@ func (s *clsInstances) Reference(version int, globalAccountID, skrInstanceID str
 	if !exists {
 		return dberr.NotFound("instance not found")
 	}
+	if instance.Version != version {
+		return dberr.Conflict("unable to reference instance, version mismatch")
+	}
+	for _, reference := range instance.SKRReferences {
+		if reference == skrInstanceID {
+			return nil
+		}
+	}
 
-	instance.SKRReferences = append(instance.SKRReferences, skrInstanceID)
+	instance.Version = instance.Version + 1
+	instance.SKRReferences = append(append([]string{}, instance.SKRReferences...), skrInstanceID)
+	s.data[k] = instance
 
 	return nil
 }
@@ -160,6 +160,24 @@ func (s *instances) Insert(instance internal.Instance) error {
 	return nil
 }
 
+func (s *instances) InsertChecked(instance internal.Instance, check func(globalAccountInstances []internal.Instance) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var instances []internal.Instance
+	for _, i := range s.instances {
+		if i.GlobalAccountID == instance.GlobalAccountID {
+			instances = append(instances, i)
+		}
+	}
+	if err := check(instances); err != nil {
+		return err
+	}
+	s.instances[instance.InstanceID] = instance
+
+	return nil
+}
+
 func (s *instances) Update(instance internal.Instance) (*internal.Instance, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -208,7 +226,16 @@ func sortInstancesByCreatedAt(instances []internal.Instance) {
 
 func (s *instances) filterInstances(filter dbmodel.InstanceFilter) []internal.Instance {
 	inst := make([]internal.Instance, 0, len(s.instances))
-	var ok bool
+	for _, v := range s.instances {
+		if matchInstance(v, filter) {
+			inst = append(inst, v)
+		}
+	}
+
+	return inst
+}
+
+func matchInstance(v internal.Instance, filter dbmodel.InstanceFilter) bool {
 	equal := func(a, b string) bool {
 		return a == b
 	}
@@ -221,34 +248,26 @@ func (s *instances) filterInstances(filter dbmodel.InstanceFilter) []internal.In
 		return err == nil && matched
 	}
 
-	for _, v := range s.instances {
-		if ok = matchFilter(v.InstanceID, filter.InstanceIDs, equal); !ok {
-			continue
-		}
-		if ok = matchFilter(v.GlobalAccountID, filter.GlobalAccountIDs, equal); !ok {
-			continue
-		}
-		if ok = matchFilter(v.SubAccountID, filter.SubAccountIDs, equal); !ok {
-			continue
-		}
-		if ok = matchFilter(v.RuntimeID, filter.RuntimeIDs, equal); !ok {
-			continue
-		}
-		if ok = matchFilter(v.ServicePlanName, filter.Plans, equal); !ok {
-			continue
-		}
-		if ok = matchFilter(v.ProviderRegion, filter.Regions, equal); !ok {
-			continue
-		}
-		// Match domains with dashboard url
-		if ok = matchFilter(v.DashboardURL, filter.Domains, domainMatch); !ok {
-			continue
-		}
-
-		inst = append(inst, v)
+	if ok := matchFilter(v.InstanceID, filter.InstanceIDs, equal); !ok {
+		return false
 	}
-
-	return inst
+	if ok := matchFilter(v.GlobalAccountID, filter.GlobalAccountIDs, equal); !ok {
+		return false
+	}
+	if ok := matchFilter(v.SubAccountID, filter.SubAccountIDs, equal); !ok {
+		return false
+	}
+	if ok := matchFilter(v.RuntimeID, filter.RuntimeIDs, equal); !ok {
+		return false
+	}
+	if ok := matchFilter(v.ServicePlanName, filter.Plans, equal); !ok {
+		return false
+	}
+	if ok := matchFilter(v.ProviderRegion, filter.Regions, equal); !ok {
+		return false
+	}
+	// Match domains with dashboard url
+	return matchFilter(v.DashboardURL, filter.Domains, domainMatch)
 }
 
 func matchFilter(value string, filters []string, match func(string, string) bool) bool {
@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+)
+
+type instanceArchives struct {
+	mu sync.Mutex
+
+	archives          map[string]internal.InstanceArchive
+	operationsStorage *operations
+}
+
+func NewInstanceArchives(operations *operations) *instanceArchives {
+	return &instanceArchives{
+		archives:          make(map[string]internal.InstanceArchive),
+		operationsStorage: operations,
+	}
+}
+
+func (s *instanceArchives) Insert(archive internal.InstanceArchive) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := archive.Instance.InstanceID
+	if _, found := s.archives[id]; found {
+		return dberr.AlreadyExists("instance archive with instance id %s already exist", id)
+	}
+	s.archives[id] = archive
+
+	return nil
+}
+
+func (s *instanceArchives) GetByInstanceID(instanceID string) (*internal.InstanceArchive, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	archive, found := s.archives[instanceID]
+	if !found {
+		return nil, dberr.NotFound("instance archive for instance id %s not found", instanceID)
+	}
+	return &archive, nil
+}
+
+func (s *instanceArchives) List(filter dbmodel.InstanceFilter, offset, limit int) ([]internal.InstanceArchive, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	matching := make([]internal.InstanceArchive, 0)
+	for _, archive := range s.archives {
+		if matchInstance(archive.Instance, filter) {
+			matching = append(matching, archive)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		return matching[i].Instance.CreatedAt.Before(matching[j].Instance.CreatedAt)
+	})
+
+	result := make([]internal.InstanceArchive, 0)
+	for i := offset; i < offset+limit && i < len(matching); i++ {
+		result = append(result, matching[i])
+	}
+	return result, len(matching), nil
+}
+
+func (s *instanceArchives) ListOperationIDsToPrune(createdBefore time.Time, limit int) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.operationsStorage.mu.Lock()
+	defer s.operationsStorage.mu.Unlock()
+
+	ops, err := s.operationsStorage.getAll()
+	switch {
+	case err == nil:
+	case dberr.IsNotFound(err):
+		return []string{}, nil
+	default:
+		return nil, err
+	}
+	s.operationsStorage.sortByCreatedAt(ops)
+
+	ids := make([]string, 0)
+	for _, op := range ops {
+		if len(ids) == limit {
+			break
+		}
+		if _, archived := s.archives[op.InstanceID]; !archived || !op.CreatedAt.Before(createdBefore) {
+			continue
+		}
+		if op.State == domain.Succeeded || op.State == domain.Failed || op.State == orchestration.Canceled {
+			ids = append(ids, op.ID)
+		}
+	}
+	return ids, nil
+}
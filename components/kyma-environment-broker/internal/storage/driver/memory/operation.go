@@ -20,14 +20,27 @@ type operations struct {
 	provisioningOperations   map[string]internal.ProvisioningOperation
 	deprovisioningOperations map[string]internal.DeprovisioningOperation
 	upgradeKymaOperations    map[string]internal.UpgradeKymaOperation
+	upgradeClusterOperations map[string]internal.UpgradeClusterOperation
+	updatingOperations       map[string]internal.UpdatingOperation
+
+	outbox *outboxEvents
 }
 
 // NewOperation creates in-memory storage for OSB operations.
 func NewOperation() *operations {
+	return NewOperationWithOutbox(NewOutboxEvents())
+}
+
+// NewOperationWithOutbox creates in-memory storage for OSB operations which stores
+// the outbox events passed with the operation updates in the given outbox storage.
+func NewOperationWithOutbox(outbox *outboxEvents) *operations {
 	return &operations{
+		outbox:                   outbox,
 		provisioningOperations:   make(map[string]internal.ProvisioningOperation, 0),
 		deprovisioningOperations: make(map[string]internal.DeprovisioningOperation, 0),
 		upgradeKymaOperations:    make(map[string]internal.UpgradeKymaOperation, 0),
+		upgradeClusterOperations: make(map[string]internal.UpgradeClusterOperation, 0),
+		updatingOperations:       make(map[string]internal.UpdatingOperation, 0),
 	}
 }
 
@@ -69,6 +82,7 @@ func (s *operations) GetProvisioningOperationByInstanceID(instanceID string) (*i
 }
 
 func (s *operations) UpdateProvisioningOperation(op internal.ProvisioningOperation) (*internal.ProvisioningOperation, error) {
+	events := op.TakePendingEvents()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -79,6 +93,9 @@ func (s *operations) UpdateProvisioningOperation(op internal.ProvisioningOperati
 	if oldOp.Version != op.Version {
 		return nil, dberr.Conflict("unable to update provisioning operation with id %s (for instance id %s) - conflict", op.ID, op.InstanceID)
 	}
+	if err := s.outbox.insertAll(events); err != nil {
+		return nil, errors.Wrapf(err, "while inserting outbox events of provisioning operation with id %s", op.ID)
+	}
 	op.Version = op.Version + 1
 	s.provisioningOperations[op.ID] = op
 
@@ -139,6 +156,7 @@ func (s *operations) GetDeprovisioningOperationByInstanceID(instanceID string) (
 }
 
 func (s *operations) UpdateDeprovisioningOperation(op internal.DeprovisioningOperation) (*internal.DeprovisioningOperation, error) {
+	events := op.TakePendingEvents()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -149,6 +167,9 @@ func (s *operations) UpdateDeprovisioningOperation(op internal.DeprovisioningOpe
 	if oldOp.Version != op.Version {
 		return nil, dberr.Conflict("unable to update deprovisioning operation with id %s (for instance id %s) - conflict", op.ID, op.InstanceID)
 	}
+	if err := s.outbox.insertAll(events); err != nil {
+		return nil, errors.Wrapf(err, "while inserting outbox events of deprovisioning operation with id %s", op.ID)
+	}
 	op.Version = op.Version + 1
 	s.deprovisioningOperations[op.ID] = op
 
@@ -192,6 +213,9 @@ func (s *operations) InsertUpgradeKymaOperation(operation internal.UpgradeKymaOp
 	if _, exists := s.upgradeKymaOperations[id]; exists {
 		return dberr.AlreadyExists("instance operation with id %s already exist", id)
 	}
+	if isUserUpgradeInProgress(operation) && s.instanceBeingChanged(operation.InstanceID) {
+		return dberr.Conflict("instance %s is being changed by another operation", operation.InstanceID)
+	}
 
 	s.upgradeKymaOperations[id] = operation
 	return nil
@@ -216,6 +240,7 @@ func (s *operations) GetUpgradeKymaOperationByInstanceID(instanceID string) (*in
 }
 
 func (s *operations) UpdateUpgradeKymaOperation(op internal.UpgradeKymaOperation) (*internal.UpgradeKymaOperation, error) {
+	events := op.TakePendingEvents()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -226,12 +251,167 @@ func (s *operations) UpdateUpgradeKymaOperation(op internal.UpgradeKymaOperation
 	if oldOp.Version != op.Version {
 		return nil, dberr.Conflict("unable to update upgradeKyma operation with id %s (for instance id %s) - conflict", op.Operation.ID, op.InstanceID)
 	}
+	if err := s.outbox.insertAll(events); err != nil {
+		return nil, errors.Wrapf(err, "while inserting outbox events of upgradeKyma operation with id %s", op.Operation.ID)
+	}
 	op.Version = op.Version + 1
 	s.upgradeKymaOperations[op.Operation.ID] = op
 
 	return &op, nil
 }
 
+func (s *operations) InsertUpgradeClusterOperation(operation internal.UpgradeClusterOperation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := operation.Operation.ID
+	if _, exists := s.upgradeClusterOperations[id]; exists {
+		return dberr.AlreadyExists("instance operation with id %s already exist", id)
+	}
+
+	s.upgradeClusterOperations[id] = operation
+	return nil
+}
+
+func (s *operations) GetUpgradeClusterOperationByID(operationID string) (*internal.UpgradeClusterOperation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	op, exists := s.upgradeClusterOperations[operationID]
+	if !exists {
+		return nil, dberr.NotFound("instance upgradeCluster operation with id %s not found", operationID)
+	}
+	return &op, nil
+}
+
+func (s *operations) UpdateUpgradeClusterOperation(op internal.UpgradeClusterOperation) (*internal.UpgradeClusterOperation, error) {
+	events := op.TakePendingEvents()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	oldOp, exists := s.upgradeClusterOperations[op.Operation.ID]
+	if !exists {
+		return nil, dberr.NotFound("instance operation with id %s not found", op.Operation.ID)
+	}
+	if oldOp.Version != op.Version {
+		return nil, dberr.Conflict("unable to update upgradeCluster operation with id %s (for instance id %s) - conflict", op.Operation.ID, op.InstanceID)
+	}
+	if err := s.outbox.insertAll(events); err != nil {
+		return nil, errors.Wrapf(err, "while inserting outbox events of upgradeCluster operation with id %s", op.Operation.ID)
+	}
+	op.Version = op.Version + 1
+	s.upgradeClusterOperations[op.Operation.ID] = op
+
+	return &op, nil
+}
+
+func (s *operations) ListUpgradeClusterOperationsByInstanceID(instanceID string) ([]internal.UpgradeClusterOperation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	operations := make([]internal.UpgradeClusterOperation, 0)
+	for _, op := range s.upgradeClusterOperations {
+		if op.InstanceID == instanceID {
+			operations = append(operations, op)
+		}
+	}
+	s.sortUpgradeClusterByCreatedAt(operations)
+
+	return operations, nil
+}
+
+func (s *operations) ListUpgradeClusterOperationsByOrchestrationID(orchestrationID string, filter dbmodel.OperationFilter) ([]internal.UpgradeClusterOperation, int, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]internal.UpgradeClusterOperation, 0)
+	offset := pagination.ConvertPageAndPageSizeToOffset(filter.PageSize, filter.Page)
+
+	operations := make([]internal.UpgradeClusterOperation, 0)
+	for _, op := range s.upgradeClusterOperations {
+		if op.OrchestrationID != orchestrationID {
+			continue
+		}
+		if ok := matchFilter(string(op.State), filter.States, s.equalFilter); !ok {
+			continue
+		}
+		operations = append(operations, op)
+	}
+	s.sortUpgradeClusterByCreatedAt(operations)
+
+	for i := offset; (filter.PageSize < 1 || i < offset+filter.PageSize) && i < len(operations); i++ {
+		result = append(result, operations[i])
+	}
+
+	return result,
+		len(result),
+		len(operations),
+		nil
+}
+
+func (s *operations) InsertUpdatingOperation(operation internal.UpdatingOperation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := operation.Operation.ID
+	if _, exists := s.updatingOperations[id]; exists {
+		return dberr.AlreadyExists("instance operation with id %s already exist", id)
+	}
+	if isUpdateInProgress(operation) && s.instanceBeingChanged(operation.InstanceID) {
+		return dberr.Conflict("instance %s is being changed by another operation", operation.InstanceID)
+	}
+
+	s.updatingOperations[id] = operation
+	return nil
+}
+
+func (s *operations) GetUpdatingOperationByID(operationID string) (*internal.UpdatingOperation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	op, exists := s.updatingOperations[operationID]
+	if !exists {
+		return nil, dberr.NotFound("instance updating operation with id %s not found", operationID)
+	}
+	return &op, nil
+}
+
+func (s *operations) UpdateUpdatingOperation(op internal.UpdatingOperation) (*internal.UpdatingOperation, error) {
+	events := op.TakePendingEvents()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	oldOp, exists := s.updatingOperations[op.Operation.ID]
+	if !exists {
+		return nil, dberr.NotFound("instance operation with id %s not found", op.Operation.ID)
+	}
+	if oldOp.Version != op.Version {
+		return nil, dberr.Conflict("unable to update updating operation with id %s (for instance id %s) - conflict", op.Operation.ID, op.InstanceID)
+	}
+	if err := s.outbox.insertAll(events); err != nil {
+		return nil, errors.Wrapf(err, "while inserting outbox events of updating operation with id %s", op.Operation.ID)
+	}
+	op.Version = op.Version + 1
+	s.updatingOperations[op.Operation.ID] = op
+
+	return &op, nil
+}
+
+func (s *operations) ListUpdatingOperationsByInstanceID(instanceID string) ([]internal.UpdatingOperation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	operations := make([]internal.UpdatingOperation, 0)
+	for _, op := range s.updatingOperations {
+		if op.InstanceID == instanceID {
+			operations = append(operations, op)
+		}
+	}
+	s.sortUpdatingByCreatedAt(operations)
+
+	return operations, nil
+}
+
 func (s *operations) GetLastOperation(instanceID string) (*internal.Operation, error) {
 	var rows []internal.Operation
 
@@ -250,6 +430,16 @@ func (s *operations) GetLastOperation(instanceID string) (*internal.Operation, e
 			rows = append(rows, op.Operation)
 		}
 	}
+	for _, op := range s.upgradeClusterOperations {
+		if op.InstanceID == instanceID && op.State != orchestration.Pending {
+			rows = append(rows, op.Operation)
+		}
+	}
+	for _, op := range s.updatingOperations {
+		if op.InstanceID == instanceID && op.State != orchestration.Pending {
+			rows = append(rows, op.Operation)
+		}
+	}
 
 	if len(rows) == 0 {
 		return nil, dberr.NotFound("instance operation with instance_id %s not found", instanceID)
@@ -277,6 +467,14 @@ func (s *operations) GetOperationByID(operationID string) (*internal.Operation,
 	if exists {
 		res = &upgradeKymaOp.Operation
 	}
+	upgradeClusterOp, exists := s.upgradeClusterOperations[operationID]
+	if exists {
+		res = &upgradeClusterOp.Operation
+	}
+	updatingOp, exists := s.updatingOperations[operationID]
+	if exists {
+		res = &updatingOp.Operation
+	}
 	if res == nil {
 		return nil, dberr.NotFound("instance operation with id %s not found", operationID)
 	}
@@ -302,6 +500,18 @@ func (s *operations) GetNotFinishedOperationsByType(opType dbmodel.OperationType
 				ops = append(ops, op.Operation)
 			}
 		}
+	case dbmodel.OperationTypeUpdate:
+		for _, op := range s.updatingOperations {
+			if op.State == domain.InProgress {
+				ops = append(ops, op.Operation)
+			}
+		}
+	case dbmodel.OperationTypeUpgradeKyma:
+		for _, op := range s.upgradeKymaOperations {
+			if op.State == domain.InProgress || op.State == orchestration.Pending {
+				ops = append(ops, op.Operation)
+			}
+		}
 	}
 
 	return ops, nil
@@ -320,6 +530,14 @@ func (s *operations) GetOperationsForIDs(opIdList []string) ([]internal.Operatio
 		}
 	}
 
+	for _, opID := range opIdList {
+		for _, op := range s.upgradeClusterOperations {
+			if op.Operation.ID == opID {
+				ops = append(ops, op.Operation)
+			}
+		}
+	}
+
 	for _, opID := range opIdList {
 		for _, op := range s.provisioningOperations {
 			if op.Operation.ID == opID {
@@ -336,6 +554,14 @@ func (s *operations) GetOperationsForIDs(opIdList []string) ([]internal.Operatio
 		}
 	}
 
+	for _, opID := range opIdList {
+		for _, op := range s.updatingOperations {
+			if op.Operation.ID == opID {
+				ops = append(ops, op.Operation)
+			}
+		}
+	}
+
 	if len(ops) == 0 {
 		return nil, dberr.NotFound("operations with ids from list %+q not exist", opIdList)
 	}
@@ -393,9 +619,29 @@ func (s *operations) GetOperationStatsForOrchestration(orchestrationID string) (
 			result[string(op.State)] = result[string(op.State)] + 1
 		}
 	}
+	for _, op := range s.upgradeClusterOperations {
+		if op.OrchestrationID == orchestrationID {
+			result[string(op.State)] = result[string(op.State)] + 1
+		}
+	}
 	return result, nil
 }
 
+func (s *operations) DeleteOperationsByIDs(operationIDList []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range operationIDList {
+		delete(s.provisioningOperations, id)
+		delete(s.deprovisioningOperations, id)
+		delete(s.upgradeKymaOperations, id)
+		delete(s.upgradeClusterOperations, id)
+		delete(s.updatingOperations, id)
+	}
+
+	return nil
+}
+
 func (s *operations) ListOperations(filter dbmodel.OperationFilter) ([]internal.Operation, int, int, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -437,11 +683,16 @@ func (s *operations) ListUpgradeKymaOperationsByOrchestrationID(orchestrationID
 	result := make([]internal.UpgradeKymaOperation, 0)
 	offset := pagination.ConvertPageAndPageSizeToOffset(filter.PageSize, filter.Page)
 
-	operations := s.filterUpgrade(filter)
+	operations := make([]internal.UpgradeKymaOperation, 0)
+	for _, op := range s.filterUpgrade(filter) {
+		if op.OrchestrationID == orchestrationID {
+			operations = append(operations, op)
+		}
+	}
 	s.sortUpgradeByCreatedAt(operations)
 
-	for i := offset; (filter.PageSize < 1 || i < offset+filter.PageSize) && i < len(operations)+offset; i++ {
-		result = append(result, s.upgradeKymaOperations[operations[i].OrchestrationID])
+	for i := offset; (filter.PageSize < 1 || i < offset+filter.PageSize) && i < len(operations); i++ {
+		result = append(result, operations[i])
 	}
 
 	return result,
@@ -454,8 +705,12 @@ func (s *operations) ListUpgradeKymaOperationsByInstanceID(instanceID string) ([
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	// Empty filter means get all
-	operations := s.filterUpgrade(dbmodel.OperationFilter{})
+	operations := make([]internal.UpgradeKymaOperation, 0)
+	for _, op := range s.upgradeKymaOperations {
+		if op.InstanceID == instanceID {
+			operations = append(operations, op)
+		}
+	}
 	s.sortUpgradeByCreatedAt(operations)
 
 	return operations, nil
@@ -467,6 +722,18 @@ func (s *operations) sortUpgradeByCreatedAt(operations []internal.UpgradeKymaOpe
 	})
 }
 
+func (s *operations) sortUpgradeClusterByCreatedAt(operations []internal.UpgradeClusterOperation) {
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].CreatedAt.Before(operations[j].CreatedAt)
+	})
+}
+
+func (s *operations) sortUpdatingByCreatedAt(operations []internal.UpdatingOperation) {
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].CreatedAt.Before(operations[j].CreatedAt)
+	})
+}
+
 func (s *operations) sortProvisioningByCreatedAtDesc(operations []internal.ProvisioningOperation) {
 	sort.Slice(operations, func(i, j int) bool {
 		return operations[i].CreatedAt.After(operations[j].CreatedAt)
@@ -491,6 +758,11 @@ func (s *operations) getOperation(id string) (internal.Operation, error) {
 			return op.Operation, nil
 		}
 	}
+	for _, op := range s.upgradeClusterOperations {
+		if op.Operation.ID == id {
+			return op.Operation, nil
+		}
+	}
 	for _, op := range s.provisioningOperations {
 		if op.Operation.ID == id {
 			return op.Operation, nil
@@ -501,6 +773,11 @@ func (s *operations) getOperation(id string) (internal.Operation, error) {
 			return op.Operation, nil
 		}
 	}
+	for _, op := range s.updatingOperations {
+		if op.Operation.ID == id {
+			return op.Operation, nil
+		}
+	}
 
 	return internal.Operation{}, dberr.NotFound("operation not found")
 }
@@ -514,6 +791,14 @@ func (s *operations) updateOperation(operation internal.Operation) (internal.Ope
 			return operation, nil
 		}
 	}
+	for i, op := range s.upgradeClusterOperations {
+		if op.Operation.ID == operation.ID {
+			temp := s.upgradeClusterOperations[i]
+			temp.ProvisioningParameters = operation.ProvisioningParameters
+			s.upgradeClusterOperations[i] = temp
+			return operation, nil
+		}
+	}
 	for i, op := range s.provisioningOperations {
 		if op.Operation.ID == operation.ID {
 			temp := s.provisioningOperations[i]
@@ -530,6 +815,14 @@ func (s *operations) updateOperation(operation internal.Operation) (internal.Ope
 			return operation, nil
 		}
 	}
+	for i, op := range s.updatingOperations {
+		if op.Operation.ID == operation.ID {
+			temp := s.updatingOperations[i]
+			temp.ProvisioningParameters = operation.ProvisioningParameters
+			s.updatingOperations[i] = temp
+			return operation, nil
+		}
+	}
 	return internal.Operation{}, dberr.NotFound("operation not found")
 }
 
@@ -538,12 +831,18 @@ func (s *operations) getAll() ([]internal.Operation, error) {
 	for _, op := range s.upgradeKymaOperations {
 		ops = append(ops, op.Operation)
 	}
+	for _, op := range s.upgradeClusterOperations {
+		ops = append(ops, op.Operation)
+	}
 	for _, op := range s.provisioningOperations {
 		ops = append(ops, op.Operation)
 	}
 	for _, op := range s.deprovisioningOperations {
 		ops = append(ops, op.Operation)
 	}
+	for _, op := range s.updatingOperations {
+		ops = append(ops, op.Operation)
+	}
 	if len(ops) == 0 {
 		return nil, dberr.NotFound("operations not found")
 	}
@@ -582,3 +881,28 @@ func (s *operations) filterUpgrade(filter dbmodel.OperationFilter) []internal.Up
 func (s *operations) equalFilter(a, b string) bool {
 	return a == b
 }
+
+// instanceBeingChanged returns true if an operation requested by the user changes the instance,
+// the same rule is enforced by the operations_in_progress_idx index in the database
+func (s *operations) instanceBeingChanged(instanceID string) bool {
+	for _, op := range s.updatingOperations {
+		if op.InstanceID == instanceID && isUpdateInProgress(op) {
+			return true
+		}
+	}
+	for _, op := range s.upgradeKymaOperations {
+		if op.InstanceID == instanceID && isUserUpgradeInProgress(op) {
+			return true
+		}
+	}
+	return false
+}
+
+func isUpdateInProgress(op internal.UpdatingOperation) bool {
+	return op.State == domain.InProgress
+}
+
+// isUserUpgradeInProgress returns true for the upgrades started by the maintenance info, they are pending until picked up from the queue
+func isUserUpgradeInProgress(op internal.UpgradeKymaOperation) bool {
+	return op.OrchestrationID == "" && (op.State == domain.InProgress || op.State == orchestration.Pending)
+}
@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+)
+
+type operationEvents struct {
+	mu sync.Mutex
+
+	ids    map[string]struct{}
+	events []internal.OperationEvent
+}
+
+func NewOperationEvents() *operationEvents {
+	return &operationEvents{
+		ids:    make(map[string]struct{}),
+		events: make([]internal.OperationEvent, 0),
+	}
+}
+
+func (s *operationEvents) Insert(event internal.OperationEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, found := s.ids[event.ID]; found {
+		return dberr.AlreadyExists("operation event with id %s already exist", event.ID)
+	}
+	s.ids[event.ID] = struct{}{}
+	s.events = append(s.events, event)
+
+	return nil
+}
+
+func (s *operationEvents) ListByOperationID(operationID string) ([]internal.OperationEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]internal.OperationEvent, 0)
+	for _, event := range s.events {
+		if event.OperationID == operationID {
+			result = append(result, event)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].FinishedAt.Before(result[j].FinishedAt) })
+
+	return result, nil
+}
+
+func (s *operationEvents) DeleteByOperationIDs(operationIDList []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := make(map[string]struct{}, len(operationIDList))
+	for _, id := range operationIDList {
+		removed[id] = struct{}{}
+	}
+
+	events := make([]internal.OperationEvent, 0, len(s.events))
+	for _, event := range s.events {
+		if _, found := removed[event.OperationID]; found {
+			delete(s.ids, event.ID)
+			continue
+		}
+		events = append(events, event)
+	}
+	s.events = events
+
+	return nil
+}
@@ -16,22 +16,84 @@ type orchestrations struct {
 	mu sync.Mutex
 
 	orchestrations map[string]internal.Orchestration
+	operations     *operations
 }
 
-func NewOrchestrations() *orchestrations {
+func NewOrchestrations(operations *operations) *orchestrations {
 	return &orchestrations{
 		orchestrations: make(map[string]internal.Orchestration, 0),
+		operations:     operations,
 	}
 }
 
 func (s *orchestrations) Insert(orchestration internal.Orchestration) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	if err := s.checkRetry(orchestration); err != nil {
+		return err
+	}
+	s.orchestrations[orchestration.OrchestrationID] = orchestration
+
+	return nil
+}
+
+func (s *orchestrations) InsertWithUpgradeKymaOperations(orchestration internal.Orchestration, operations []internal.UpgradeKymaOperation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkRetry(orchestration); err != nil {
+		return err
+	}
+
+	s.operations.mu.Lock()
+	defer s.operations.mu.Unlock()
+	for _, op := range operations {
+		if _, exists := s.operations.upgradeKymaOperations[op.Operation.ID]; exists {
+			return dberr.AlreadyExists("instance operation with id %s already exist", op.Operation.ID)
+		}
+	}
+	for _, op := range operations {
+		s.operations.upgradeKymaOperations[op.Operation.ID] = op
+	}
+	s.orchestrations[orchestration.OrchestrationID] = orchestration
+
+	return nil
+}
+
+func (s *orchestrations) InsertWithUpgradeClusterOperations(orchestration internal.Orchestration, operations []internal.UpgradeClusterOperation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkRetry(orchestration); err != nil {
+		return err
+	}
+
+	s.operations.mu.Lock()
+	defer s.operations.mu.Unlock()
+	for _, op := range operations {
+		if _, exists := s.operations.upgradeClusterOperations[op.Operation.ID]; exists {
+			return dberr.AlreadyExists("instance operation with id %s already exist", op.Operation.ID)
+		}
+	}
+	for _, op := range operations {
+		s.operations.upgradeClusterOperations[op.Operation.ID] = op
+	}
 	s.orchestrations[orchestration.OrchestrationID] = orchestration
 
 	return nil
 }
 
+// checkRetry fails if the orchestration retries another orchestration which already has an unfinished retry
+func (s *orchestrations) checkRetry(orchestration internal.Orchestration) error {
+	if orchestration.RetryOf == "" {
+		return nil
+	}
+	for _, o := range s.orchestrations {
+		if o.RetryOf == orchestration.RetryOf && !o.IsFinished() {
+			return dberr.AlreadyExists("Retry of orchestration %s is already in progress", orchestration.RetryOf)
+		}
+	}
+	return nil
+}
+
 func (s *orchestrations) GetByID(orchestrationID string) (*internal.Orchestration, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+)
+
+type outboxEvents struct {
+	mu sync.Mutex
+
+	events map[string]internal.OutboxEvent
+}
+
+func NewOutboxEvents() *outboxEvents {
+	return &outboxEvents{
+		events: make(map[string]internal.OutboxEvent),
+	}
+}
+
+func (s *outboxEvents) Insert(event internal.OutboxEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, found := s.events[event.ID]; found {
+		return dberr.AlreadyExists("outbox event with id %s already exist", event.ID)
+	}
+	s.events[event.ID] = event
+
+	return nil
+}
+
+// insertAll stores all the events or none of them
+func (s *outboxEvents) insertAll(events []internal.OutboxEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, event := range events {
+		if _, found := s.events[event.ID]; found {
+			return dberr.AlreadyExists("outbox event with id %s already exist", event.ID)
+		}
+	}
+	for _, event := range events {
+		s.events[event.ID] = event
+	}
+
+	return nil
+}
+
+func (s *outboxEvents) Update(event internal.OutboxEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, found := s.events[event.ID]; !found {
+		return dberr.NotFound("outbox event with id %s not exist", event.ID)
+	}
+	s.events[event.ID] = event
+
+	return nil
+}
+
+func (s *outboxEvents) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.events, id)
+
+	return nil
+}
+
+func (s *outboxEvents) ClaimPending(subscriber string, until, leaseUntil time.Time, limit int) ([]internal.OutboxEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]internal.OutboxEvent, 0)
+	for _, event := range s.events {
+		if event.Subscriber == subscriber && event.State == internal.OutboxEventPending && !event.NextAttemptAt.After(until) {
+			result = append(result, event)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	for i := range result {
+		result[i].NextAttemptAt = leaseUntil
+		s.events[result[i].ID] = result[i]
+	}
+
+	return result, nil
+}
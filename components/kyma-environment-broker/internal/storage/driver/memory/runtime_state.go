@@ -55,3 +55,18 @@ func (s *runtimeState) GetByOperationID(operationID string) (internal.RuntimeSta
 
 	return internal.RuntimeState{}, dberr.NotFound("runtime state with operation ID %s not found", operationID)
 }
+
+func (s *runtimeState) DeleteByOperationIDs(operationIDList []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, operationID := range operationIDList {
+		for id, rs := range s.runtimeStates {
+			if rs.OperationID == operationID {
+				delete(s.runtimeStates, id)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+)
+
+type webhookSubscriptions struct {
+	mu sync.Mutex
+
+	subscriptions map[string]internal.WebhookSubscription
+}
+
+func NewWebhookSubscriptions() *webhookSubscriptions {
+	return &webhookSubscriptions{
+		subscriptions: make(map[string]internal.WebhookSubscription),
+	}
+}
+
+func (s *webhookSubscriptions) Insert(subscription internal.WebhookSubscription) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, found := s.subscriptions[subscription.ID]; found {
+		return dberr.AlreadyExists("webhook subscription with id %s already exist", subscription.ID)
+	}
+	s.subscriptions[subscription.ID] = subscription
+
+	return nil
+}
+
+func (s *webhookSubscriptions) GetByID(id string) (*internal.WebhookSubscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subscription, found := s.subscriptions[id]
+	if !found {
+		return nil, dberr.NotFound("webhook subscription with id %s not exist", id)
+	}
+
+	return &subscription, nil
+}
+
+func (s *webhookSubscriptions) List() ([]internal.WebhookSubscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]internal.WebhookSubscription, 0, len(s.subscriptions))
+	for _, subscription := range s.subscriptions {
+		result = append(result, subscription)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+
+	return result, nil
+}
+
+func (s *webhookSubscriptions) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.subscriptions, id)
+
+	return nil
+}
+
+type webhookDeliveries struct {
+	mu sync.Mutex
+
+	deliveries []internal.WebhookDelivery
+}
+
+func NewWebhookDeliveries() *webhookDeliveries {
+	return &webhookDeliveries{
+		deliveries: make([]internal.WebhookDelivery, 0),
+	}
+}
+
+func (s *webhookDeliveries) Insert(delivery internal.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, d := range s.deliveries {
+		if d.ID == delivery.ID {
+			return dberr.AlreadyExists("webhook delivery with id %s already exist", delivery.ID)
+		}
+	}
+	s.deliveries = append(s.deliveries, delivery)
+
+	return nil
+}
+
+func (s *webhookDeliveries) ListBySubscriptionID(subscriptionID string) ([]internal.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]internal.WebhookDelivery, 0)
+	for _, delivery := range s.deliveries {
+		if delivery.SubscriptionID == subscriptionID {
+			result = append(result, delivery)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+
+	return result, nil
+}
@@ -0,0 +1,204 @@
+package postsql
+
+import (
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/postsql"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type bindings struct {
+	postsql.Factory
+
+	cipher Cipher
+}
+
+func NewBindings(sess postsql.Factory, cipher Cipher) *bindings {
+	return &bindings{
+		Factory: sess,
+		cipher:  cipher,
+	}
+}
+
+func (s *bindings) Insert(binding internal.Binding) error {
+	dto, err := s.toBindingDTO(binding)
+	if err != nil {
+		return err
+	}
+	sess := s.NewWriteSession()
+	var lastErr dberr.Error
+	err = wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = sess.InsertBinding(dto)
+		if lastErr != nil {
+			if lastErr.Code() == dberr.CodeAlreadyExists {
+				return false, lastErr
+			}
+			log.Errorf("while inserting binding ID %s: %v", binding.BindingID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return lastErr
+	}
+	return nil
+}
+
+func (s *bindings) Update(binding internal.Binding) error {
+	dto, err := s.toBindingDTO(binding)
+	if err != nil {
+		return err
+	}
+	sess := s.NewWriteSession()
+	var lastErr dberr.Error
+	err = wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = sess.UpdateBinding(dto)
+		if lastErr != nil {
+			if dberr.IsNotFound(lastErr) {
+				return false, lastErr
+			}
+			log.Errorf("while updating binding ID %s: %v", binding.BindingID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return lastErr
+	}
+	return nil
+}
+
+func (s *bindings) Delete(bindingID string) error {
+	sess := s.NewWriteSession()
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = sess.DeleteBinding(bindingID)
+		if lastErr != nil {
+			log.Errorf("while deleting binding ID %s: %v", bindingID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return lastErr
+	}
+	return nil
+}
+
+func (s *bindings) GetByID(bindingID string) (*internal.Binding, error) {
+	sess := s.NewReadSession()
+	dto := dbmodel.BindingDTO{}
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		dto, lastErr = sess.GetBindingByID(bindingID)
+		if lastErr != nil {
+			if dberr.IsNotFound(lastErr) {
+				return false, dberr.NotFound("binding with id %s not exist", bindingID)
+			}
+			log.Errorf("while getting binding ID %s: %v", bindingID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+	binding, err := s.toBinding(dto)
+	if err != nil {
+		return nil, err
+	}
+	return &binding, nil
+}
+
+func (s *bindings) ListByInstanceID(instanceID string) ([]internal.Binding, error) {
+	sess := s.NewReadSession()
+	var (
+		dtos    []dbmodel.BindingDTO
+		lastErr dberr.Error
+	)
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		dtos, lastErr = sess.ListBindingsByInstanceID(instanceID)
+		if lastErr != nil {
+			log.Errorf("while listing bindings for instance ID %s: %v", instanceID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+	return s.toBindings(dtos)
+}
+
+func (s *bindings) ListByState(state domain.LastOperationState) ([]internal.Binding, error) {
+	sess := s.NewReadSession()
+	var (
+		dtos    []dbmodel.BindingDTO
+		lastErr dberr.Error
+	)
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		dtos, lastErr = sess.ListBindingsByState(string(state))
+		if lastErr != nil {
+			log.Errorf("while listing %s bindings: %v", state, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+	return s.toBindings(dtos)
+}
+
+func (s *bindings) toBindingDTO(binding internal.Binding) (dbmodel.BindingDTO, error) {
+	kubeconfig, err := s.cipher.Encrypt([]byte(binding.Kubeconfig))
+	if err != nil {
+		return dbmodel.BindingDTO{}, errors.Wrap(err, "while encrypting kubeconfig")
+	}
+
+	return dbmodel.BindingDTO{
+		BindingID:   binding.BindingID,
+		InstanceID:  binding.InstanceID,
+		Operation:   binding.Operation,
+		State:       string(binding.State),
+		Description: binding.Description,
+		Kubeconfig:  string(kubeconfig),
+		CreatedAt:   binding.CreatedAt,
+		UpdatedAt:   binding.UpdatedAt,
+	}, nil
+}
+
+func (s *bindings) toBinding(dto dbmodel.BindingDTO) (internal.Binding, error) {
+	kubeconfig, err := s.cipher.Decrypt([]byte(dto.Kubeconfig))
+	if err != nil {
+		return internal.Binding{}, errors.Wrap(err, "while decrypting kubeconfig")
+	}
+
+	return internal.Binding{
+		BindingID:   dto.BindingID,
+		InstanceID:  dto.InstanceID,
+		Operation:   dto.Operation,
+		State:       domain.LastOperationState(dto.State),
+		Description: dto.Description,
+		Kubeconfig:  string(kubeconfig),
+		CreatedAt:   dto.CreatedAt,
+		UpdatedAt:   dto.UpdatedAt,
+	}, nil
+}
+
+func (s *bindings) toBindings(dtos []dbmodel.BindingDTO) ([]internal.Binding, error) {
+	result := make([]internal.Binding, 0)
+	for _, dto := range dtos {
+		binding, err := s.toBinding(dto)
+		if err != nil {
+			return nil, errors.Wrap(err, "while converting bindings")
+		}
+		result = append(result, binding)
+	}
+	return result, nil
+}
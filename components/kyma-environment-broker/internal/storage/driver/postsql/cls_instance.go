@@ -0,0 +1,134 @@
+package postsql
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/postsql"
+)
+
+type clsInstances struct {
+	postsql.Factory
+}
+
+func NewCLSInstances(sess postsql.Factory) *clsInstances {
+	return &clsInstances{
+		Factory: sess,
+	}
+}
+
+func (s *clsInstances) FindInstance(globalAccountID string) (*internal.CLSInstance, bool, error) {
+	sess := s.NewReadSession()
+	dto, err := sess.GetCLSInstanceByGlobalAccountID(globalAccountID)
+	switch {
+	case err == nil:
+	case err.Code() == dberr.CodeNotFound:
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+
+	references, err := sess.ListCLSInstanceReferences(dto.ID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	instance := &internal.CLSInstance{
+		Version:         dto.Version,
+		ID:              dto.ID,
+		GlobalAccountID: dto.GlobalAccountID,
+		Region:          dto.Region,
+		CreatedAt:       dto.CreatedAt,
+	}
+	for _, reference := range references {
+		instance.SKRReferences = append(instance.SKRReferences, reference.SKRInstanceID)
+	}
+	return instance, true, nil
+}
+
+// InsertInstance stores the instance together with its references
+func (s *clsInstances) InsertInstance(instance internal.CLSInstance) error {
+	sess, err := s.NewSessionWithinTransaction()
+	if err != nil {
+		return err
+	}
+	defer sess.RollbackUnlessCommitted()
+
+	err = sess.InsertCLSInstance(dbmodel.CLSInstanceDTO{
+		ID:              instance.ID,
+		Version:         instance.Version,
+		GlobalAccountID: instance.GlobalAccountID,
+		Region:          instance.Region,
+		CreatedAt:       instance.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	for _, skrInstanceID := range instance.SKRReferences {
+		err = sess.InsertCLSInstanceReference(dbmodel.CLSInstanceReferenceDTO{
+			CLSInstanceID: instance.ID,
+			SKRInstanceID: skrInstanceID,
+			CreatedAt:     instance.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return sess.Commit()
+}
+
+// Reference adds the SKR instance to the references of the CLS instance. The reference is added only if the CLS instance
+// was not modified since it was read with the given version, otherwise the conflict error is returned.
+func (s *clsInstances) Reference(version int, globalAccountID, skrInstanceID string) error {
+	instance, exists, err := s.FindInstance(globalAccountID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return dberr.NotFound("cls instance for global account %s not found", globalAccountID)
+	}
+	if instance.Version != version {
+		return dberr.Conflict("cls instance update conflict, global account ID: %s", globalAccountID)
+	}
+	for _, reference := range instance.SKRReferences {
+		if reference == skrInstanceID {
+			// the step which references the instance can be retried
+			return nil
+		}
+	}
+
+	sess, err := s.NewSessionWithinTransaction()
+	if err != nil {
+		return err
+	}
+	defer sess.RollbackUnlessCommitted()
+
+	err = sess.UpdateCLSInstance(dbmodel.CLSInstanceDTO{
+		ID:              instance.ID,
+		Version:         version,
+		GlobalAccountID: instance.GlobalAccountID,
+		Region:          instance.Region,
+		CreatedAt:       instance.CreatedAt,
+	})
+	switch {
+	case err == nil:
+	case dberr.IsNotFound(err):
+		return dberr.Conflict("cls instance update conflict, global account ID: %s", globalAccountID)
+	default:
+		return err
+	}
+
+	err = sess.InsertCLSInstanceReference(dbmodel.CLSInstanceReferenceDTO{
+		CLSInstanceID: instance.ID,
+		SKRInstanceID: skrInstanceID,
+		CreatedAt:     time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return sess.Commit()
+}
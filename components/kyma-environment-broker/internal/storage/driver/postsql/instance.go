@@ -36,6 +36,10 @@ func (s *Instance) InsertWithoutEncryption(instance internal.Instance) error {
 	if err != nil {
 		return errors.Wrap(err, "while marshaling parameters")
 	}
+	history, err := planHistoryToDTO(instance.PlanHistory)
+	if err != nil {
+		return err
+	}
 	dto := dbmodel.InstanceDTO{
 		InstanceID:             instance.InstanceID,
 		RuntimeID:              instance.RuntimeID,
@@ -48,6 +52,9 @@ func (s *Instance) InsertWithoutEncryption(instance internal.Instance) error {
 		DashboardURL:           instance.DashboardURL,
 		ProvisioningParameters: string(params),
 		ProviderRegion:         instance.ProviderRegion,
+		PlanHistory:            history,
+		ExpiresAt:              instance.ExpiresAt,
+		ExpirationWarnedAt:     instance.ExpirationWarnedAt,
 		CreatedAt:              instance.CreatedAt,
 		UpdatedAt:              instance.UpdatedAt,
 		DeletedAt:              instance.DeletedAt,
@@ -77,22 +84,29 @@ func (s *Instance) ListWithoutDecryption(filter dbmodel.InstanceFilter) ([]inter
 		if err != nil {
 			return nil, 0, 0, errors.Wrap(err, "while unmarshal parameters")
 		}
+		history, err := planHistoryFromDTO(dto.PlanHistory)
+		if err != nil {
+			return nil, 0, 0, err
+		}
 		instance := internal.Instance{
-			InstanceID:      dto.InstanceID,
-			RuntimeID:       dto.RuntimeID,
-			GlobalAccountID: dto.GlobalAccountID,
-			SubAccountID:    dto.SubAccountID,
-			ServiceID:       dto.ServiceID,
-			ServiceName:     dto.ServiceName,
-			ServicePlanID:   dto.ServicePlanID,
-			ServicePlanName: dto.ServicePlanName,
-			DashboardURL:    dto.DashboardURL,
-			Parameters:      params,
-			ProviderRegion:  dto.ProviderRegion,
-			CreatedAt:       dto.CreatedAt,
-			UpdatedAt:       dto.UpdatedAt,
-			DeletedAt:       dto.DeletedAt,
-			Version:         dto.Version,
+			InstanceID:         dto.InstanceID,
+			RuntimeID:          dto.RuntimeID,
+			GlobalAccountID:    dto.GlobalAccountID,
+			SubAccountID:       dto.SubAccountID,
+			ServiceID:          dto.ServiceID,
+			ServiceName:        dto.ServiceName,
+			ServicePlanID:      dto.ServicePlanID,
+			ServicePlanName:    dto.ServicePlanName,
+			DashboardURL:       dto.DashboardURL,
+			Parameters:         params,
+			ProviderRegion:     dto.ProviderRegion,
+			PlanHistory:        history,
+			ExpiresAt:          dto.ExpiresAt,
+			ExpirationWarnedAt: dto.ExpirationWarnedAt,
+			CreatedAt:          dto.CreatedAt,
+			UpdatedAt:          dto.UpdatedAt,
+			DeletedAt:          dto.DeletedAt,
+			Version:            dto.Version,
 		}
 		instances = append(instances, instance)
 	}
@@ -105,6 +119,10 @@ func (s *Instance) UpdateWithoutEncryption(instance internal.Instance) (*interna
 	if err != nil {
 		return nil, errors.Wrap(err, "while marshaling parameters")
 	}
+	history, err := planHistoryToDTO(instance.PlanHistory)
+	if err != nil {
+		return nil, err
+	}
 	dto := dbmodel.InstanceDTO{
 		InstanceID:             instance.InstanceID,
 		RuntimeID:              instance.RuntimeID,
@@ -117,6 +135,9 @@ func (s *Instance) UpdateWithoutEncryption(instance internal.Instance) (*interna
 		DashboardURL:           instance.DashboardURL,
 		ProvisioningParameters: string(params),
 		ProviderRegion:         instance.ProviderRegion,
+		PlanHistory:            history,
+		ExpiresAt:              instance.ExpiresAt,
+		ExpirationWarnedAt:     instance.ExpirationWarnedAt,
 		CreatedAt:              instance.CreatedAt,
 		UpdatedAt:              instance.UpdatedAt,
 		DeletedAt:              instance.DeletedAt,
@@ -306,22 +327,29 @@ func (s *Instance) toInstance(dto dbmodel.InstanceDTO) (internal.Instance, error
 	if err != nil {
 		return internal.Instance{}, errors.Wrap(err, "while decrypting parameters")
 	}
+	history, err := planHistoryFromDTO(dto.PlanHistory)
+	if err != nil {
+		return internal.Instance{}, err
+	}
 	return internal.Instance{
-		InstanceID:      dto.InstanceID,
-		RuntimeID:       dto.RuntimeID,
-		GlobalAccountID: dto.GlobalAccountID,
-		SubAccountID:    dto.SubAccountID,
-		ServiceID:       dto.ServiceID,
-		ServiceName:     dto.ServiceName,
-		ServicePlanID:   dto.ServicePlanID,
-		ServicePlanName: dto.ServicePlanName,
-		DashboardURL:    dto.DashboardURL,
-		Parameters:      params,
-		ProviderRegion:  dto.ProviderRegion,
-		CreatedAt:       dto.CreatedAt,
-		UpdatedAt:       dto.UpdatedAt,
-		DeletedAt:       dto.DeletedAt,
-		Version:         dto.Version,
+		InstanceID:         dto.InstanceID,
+		RuntimeID:          dto.RuntimeID,
+		GlobalAccountID:    dto.GlobalAccountID,
+		SubAccountID:       dto.SubAccountID,
+		ServiceID:          dto.ServiceID,
+		ServiceName:        dto.ServiceName,
+		ServicePlanID:      dto.ServicePlanID,
+		ServicePlanName:    dto.ServicePlanName,
+		DashboardURL:       dto.DashboardURL,
+		Parameters:         params,
+		ProviderRegion:     dto.ProviderRegion,
+		PlanHistory:        history,
+		ExpiresAt:          dto.ExpiresAt,
+		ExpirationWarnedAt: dto.ExpirationWarnedAt,
+		CreatedAt:          dto.CreatedAt,
+		UpdatedAt:          dto.UpdatedAt,
+		DeletedAt:          dto.DeletedAt,
+		Version:            dto.Version,
 	}, nil
 }
 
@@ -347,6 +375,48 @@ func (s *Instance) Insert(instance internal.Instance) error {
 	})
 }
 
+// InsertChecked holds the advisory lock of the global account while the instances are checked and the new one is inserted,
+// all the statements are executed in one transaction, so that the lock does not hold another connection of the pool
+func (s *Instance) InsertChecked(instance internal.Instance, check func(globalAccountInstances []internal.Instance) error) error {
+	dto, err := s.toInstanceDTO(instance)
+	if err != nil {
+		return err
+	}
+
+	sess, dbErr := s.NewSessionWithinTransaction()
+	if dbErr != nil {
+		return dbErr
+	}
+	defer sess.RollbackUnlessCommitted()
+
+	if dbErr := sess.LockGlobalAccount(instance.GlobalAccountID); dbErr != nil {
+		return dbErr
+	}
+	dtos, dbErr := sess.ListInstancesByGlobalAccountID(instance.GlobalAccountID)
+	if dbErr != nil {
+		return dbErr
+	}
+	var instances []internal.Instance
+	for _, d := range dtos {
+		i, err := s.toInstance(d)
+		if err != nil {
+			return err
+		}
+		instances = append(instances, i)
+	}
+	if err := check(instances); err != nil {
+		return err
+	}
+
+	if dbErr := sess.InsertInstance(dto); dbErr != nil {
+		return dbErr
+	}
+	if dbErr := sess.Commit(); dbErr != nil {
+		return dbErr
+	}
+	return nil
+}
+
 func (s *Instance) Update(instance internal.Instance) (*internal.Instance, error) {
 	sess := s.NewWriteSession()
 	dto, err := s.toInstanceDTO(instance)
@@ -393,6 +463,10 @@ func (s *Instance) toInstanceDTO(instance internal.Instance) (dbmodel.InstanceDT
 	if err != nil {
 		return dbmodel.InstanceDTO{}, errors.Wrap(err, "while marshaling parameters")
 	}
+	history, err := planHistoryToDTO(instance.PlanHistory)
+	if err != nil {
+		return dbmodel.InstanceDTO{}, err
+	}
 	return dbmodel.InstanceDTO{
 		InstanceID:             instance.InstanceID,
 		RuntimeID:              instance.RuntimeID,
@@ -405,6 +479,9 @@ func (s *Instance) toInstanceDTO(instance internal.Instance) (dbmodel.InstanceDT
 		DashboardURL:           instance.DashboardURL,
 		ProvisioningParameters: string(params),
 		ProviderRegion:         instance.ProviderRegion,
+		PlanHistory:            history,
+		ExpiresAt:              instance.ExpiresAt,
+		ExpirationWarnedAt:     instance.ExpirationWarnedAt,
 		CreatedAt:              instance.CreatedAt,
 		UpdatedAt:              instance.UpdatedAt,
 		DeletedAt:              instance.DeletedAt,
@@ -448,3 +525,29 @@ func (s *Instance) List(filter dbmodel.InstanceFilter) ([]internal.Instance, int
 	}
 	return instances, count, totalCount, err
 }
+
+func planHistoryToDTO(history []internal.PlanChange) (string, error) {
+	if history == nil {
+		history = []internal.PlanChange{}
+	}
+	data, err := json.Marshal(history)
+	if err != nil {
+		return "", errors.Wrap(err, "while marshaling plan history")
+	}
+	return string(data), nil
+}
+
+func planHistoryFromDTO(data string) ([]internal.PlanChange, error) {
+	var history []internal.PlanChange
+	if data == "" {
+		return history, nil
+	}
+	err := json.Unmarshal([]byte(data), &history)
+	if err != nil {
+		return nil, errors.Wrap(err, "while unmarshal plan history")
+	}
+	if len(history) == 0 {
+		return nil, nil
+	}
+	return history, nil
+}
@@ -0,0 +1,196 @@
+package postsql
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/postsql"
+
+	"github.com/pkg/errors"
+)
+
+type instanceArchives struct {
+	postsql.Factory
+
+	operations *operations
+}
+
+// instanceArchiveData is the snapshot stored in the data column, the operations are stored in the same form
+// as in the operations table
+type instanceArchiveData struct {
+	Instance      internal.Instance       `json:"instance"`
+	Operations    []dbmodel.OperationDTO  `json:"operations"`
+	RuntimeStates []internal.RuntimeState `json:"runtimeStates"`
+}
+
+func NewInstanceArchives(sess postsql.Factory, operations *operations) *instanceArchives {
+	return &instanceArchives{
+		Factory:    sess,
+		operations: operations,
+	}
+}
+
+func (s *instanceArchives) Insert(archive internal.InstanceArchive) error {
+	dto, err := s.toDTO(archive)
+	if err != nil {
+		return err
+	}
+
+	sess := s.NewWriteSession()
+	return sess.InsertInstanceArchive(dto)
+}
+
+func (s *instanceArchives) GetByInstanceID(instanceID string) (*internal.InstanceArchive, error) {
+	sess := s.NewReadSession()
+	dto, dbErr := sess.GetInstanceArchiveByInstanceID(instanceID)
+	if dbErr != nil {
+		return nil, dbErr
+	}
+
+	archive, err := s.toInstanceArchive(dto)
+	if err != nil {
+		return nil, err
+	}
+	return &archive, nil
+}
+
+func (s *instanceArchives) List(filter dbmodel.InstanceFilter, offset, limit int) ([]internal.InstanceArchive, int, error) {
+	sess := s.NewReadSession()
+	dtos, totalCount, err := sess.ListInstanceArchives(filter, offset, limit)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	result := make([]internal.InstanceArchive, 0, len(dtos))
+	for _, dto := range dtos {
+		archive, err := s.toInstanceArchive(dto)
+		if err != nil {
+			return nil, -1, err
+		}
+		result = append(result, archive)
+	}
+	return result, totalCount, nil
+}
+
+func (s *instanceArchives) ListOperationIDsToPrune(createdBefore time.Time, limit int) ([]string, error) {
+	sess := s.NewReadSession()
+	ids, err := sess.ListOperationIDsOfArchivedInstances(createdBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (s *instanceArchives) toDTO(archive internal.InstanceArchive) (dbmodel.InstanceArchiveDTO, error) {
+	data := instanceArchiveData{
+		Instance:      archive.Instance,
+		RuntimeStates: archive.RuntimeStates,
+	}
+	for _, op := range archive.ProvisioningOperations {
+		dto, err := s.operations.provisioningOperationToDTO(&op)
+		if err != nil {
+			return dbmodel.InstanceArchiveDTO{}, err
+		}
+		data.Operations = append(data.Operations, dto)
+	}
+	for _, op := range archive.DeprovisioningOperations {
+		dto, err := s.operations.deprovisioningOperationToDTO(&op)
+		if err != nil {
+			return dbmodel.InstanceArchiveDTO{}, err
+		}
+		data.Operations = append(data.Operations, dto)
+	}
+	for _, op := range archive.UpgradeKymaOperations {
+		dto, err := s.operations.upgradeKymaOperationToDTO(&op)
+		if err != nil {
+			return dbmodel.InstanceArchiveDTO{}, err
+		}
+		data.Operations = append(data.Operations, dto)
+	}
+	for _, op := range archive.UpgradeClusterOperations {
+		dto, err := s.operations.upgradeClusterOperationToDTO(&op)
+		if err != nil {
+			return dbmodel.InstanceArchiveDTO{}, err
+		}
+		data.Operations = append(data.Operations, dto)
+	}
+	for _, op := range archive.UpdatingOperations {
+		dto, err := s.operations.updatingOperationToDTO(&op)
+		if err != nil {
+			return dbmodel.InstanceArchiveDTO{}, err
+		}
+		data.Operations = append(data.Operations, dto)
+	}
+
+	serialized, err := json.Marshal(data)
+	if err != nil {
+		return dbmodel.InstanceArchiveDTO{}, errors.Wrapf(err, "while serializing archive of the instance %s", archive.Instance.InstanceID)
+	}
+
+	instance := archive.Instance
+	return dbmodel.InstanceArchiveDTO{
+		InstanceID:      instance.InstanceID,
+		RuntimeID:       instance.RuntimeID,
+		GlobalAccountID: instance.GlobalAccountID,
+		SubAccountID:    instance.SubAccountID,
+		ServicePlanID:   instance.ServicePlanID,
+		ServicePlanName: instance.ServicePlanName,
+		ProviderRegion:  instance.ProviderRegion,
+		DashboardURL:    instance.DashboardURL,
+		Data:            string(serialized),
+		CreatedAt:       instance.CreatedAt,
+		ArchivedAt:      archive.ArchivedAt,
+	}, nil
+}
+
+func (s *instanceArchives) toInstanceArchive(dto dbmodel.InstanceArchiveDTO) (internal.InstanceArchive, error) {
+	var data instanceArchiveData
+	if err := json.Unmarshal([]byte(dto.Data), &data); err != nil {
+		return internal.InstanceArchive{}, errors.Wrapf(err, "while unmarshalling archive of the instance %s", dto.InstanceID)
+	}
+
+	archive := internal.InstanceArchive{
+		Instance:      data.Instance,
+		RuntimeStates: data.RuntimeStates,
+		ArchivedAt:    dto.ArchivedAt,
+	}
+	for _, op := range data.Operations {
+		switch op.Type {
+		case dbmodel.OperationTypeProvision:
+			o, err := s.operations.toProvisioningOperation(&op)
+			if err != nil {
+				return internal.InstanceArchive{}, err
+			}
+			archive.ProvisioningOperations = append(archive.ProvisioningOperations, *o)
+		case dbmodel.OperationTypeDeprovision:
+			o, err := s.operations.toDeprovisioningOperation(&op)
+			if err != nil {
+				return internal.InstanceArchive{}, err
+			}
+			archive.DeprovisioningOperations = append(archive.DeprovisioningOperations, *o)
+		case dbmodel.OperationTypeUpgradeKyma:
+			o, err := s.operations.toUpgradeKymaOperation(&op)
+			if err != nil {
+				return internal.InstanceArchive{}, err
+			}
+			archive.UpgradeKymaOperations = append(archive.UpgradeKymaOperations, *o)
+		case dbmodel.OperationTypeUpgradeCluster:
+			o, err := s.operations.toUpgradeClusterOperation(&op)
+			if err != nil {
+				return internal.InstanceArchive{}, err
+			}
+			archive.UpgradeClusterOperations = append(archive.UpgradeClusterOperations, *o)
+		case dbmodel.OperationTypeUpdate:
+			o, err := s.operations.toUpdatingOperation(&op)
+			if err != nil {
+				return internal.InstanceArchive{}, err
+			}
+			archive.UpdatingOperations = append(archive.UpdatingOperations, *o)
+		default:
+			return internal.InstanceArchive{}, errors.Errorf("unknown type %s of the archived operation %s", op.Type, op.ID)
+		}
+	}
+	return archive, nil
+}
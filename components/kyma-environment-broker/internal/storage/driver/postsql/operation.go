@@ -106,6 +106,9 @@ func (s *operations) GetProvisioningOperationByInstanceID(instanceID string) (*i
 
 // UpdateProvisioningOperation updates ProvisioningOperation, fails if not exists or optimistic locking failure occurs.
 func (s *operations) UpdateProvisioningOperation(op internal.ProvisioningOperation) (*internal.ProvisioningOperation, error) {
+	if events := op.TakePendingEvents(); len(events) > 0 {
+		return s.updateProvisioningOperationWithEvents(op, events)
+	}
 	session := s.NewWriteSession()
 	op.UpdatedAt = time.Now()
 	dto, err := s.provisioningOperationToDTO(&op)
@@ -134,6 +137,20 @@ func (s *operations) UpdateProvisioningOperation(op internal.ProvisioningOperati
 	return &op, lastErr
 }
 
+// updateProvisioningOperationWithEvents updates ProvisioningOperation and inserts the outbox events in one transaction
+func (s *operations) updateProvisioningOperationWithEvents(op internal.ProvisioningOperation, events []internal.OutboxEvent) (*internal.ProvisioningOperation, error) {
+	op.UpdatedAt = time.Now()
+	dto, err := s.provisioningOperationToDTO(&op)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while converting Operation to DTO")
+	}
+	if err := s.updateWithEvents(dto, events); err != nil {
+		return nil, err
+	}
+	op.Version = op.Version + 1
+	return &op, nil
+}
+
 func (s *operations) ListProvisioningOperationsByInstanceID(instanceID string) ([]internal.ProvisioningOperation, error) {
 	session := s.NewReadSession()
 	operations := []dbmodel.OperationDTO{}
@@ -236,6 +253,9 @@ func (s *operations) GetDeprovisioningOperationByInstanceID(instanceID string) (
 
 // UpdateDeprovisioningOperation updates DeprovisioningOperation, fails if not exists or optimistic locking failure occurs.
 func (s *operations) UpdateDeprovisioningOperation(operation internal.DeprovisioningOperation) (*internal.DeprovisioningOperation, error) {
+	if events := operation.TakePendingEvents(); len(events) > 0 {
+		return s.updateDeprovisioningOperationWithEvents(operation, events)
+	}
 	session := s.NewWriteSession()
 	operation.UpdatedAt = time.Now()
 
@@ -265,6 +285,20 @@ func (s *operations) UpdateDeprovisioningOperation(operation internal.Deprovisio
 	return &operation, lastErr
 }
 
+// updateDeprovisioningOperationWithEvents updates DeprovisioningOperation and inserts the outbox events in one transaction
+func (s *operations) updateDeprovisioningOperationWithEvents(operation internal.DeprovisioningOperation, events []internal.OutboxEvent) (*internal.DeprovisioningOperation, error) {
+	operation.UpdatedAt = time.Now()
+	dto, err := s.deprovisioningOperationToDTO(&operation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while converting Operation to DTO")
+	}
+	if err := s.updateWithEvents(dto, events); err != nil {
+		return nil, err
+	}
+	operation.Version = operation.Version + 1
+	return &operation, nil
+}
+
 // ListDeprovisioningoOperationsByInstanceID
 func (s *operations) ListDeprovisioningOperationsByInstanceID(instanceID string) ([]internal.DeprovisioningOperation, error) {
 	session := s.NewReadSession()
@@ -323,6 +357,9 @@ func (s *operations) InsertUpgradeKymaOperation(operation internal.UpgradeKymaOp
 	var lastErr error
 	_ = wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
 		lastErr = session.InsertOperation(dto)
+		if dberr.IsConflict(lastErr) {
+			return false, lastErr
+		}
 		if lastErr != nil {
 			log.Errorf("while insert operation: %v", err)
 			return false, nil
@@ -438,6 +475,9 @@ func (s *operations) ListUpgradeKymaOperationsByInstanceID(instanceID string) ([
 
 // UpdateUpgradeKymaOperation updates UpgradeKymaOperation, fails if not exists or optimistic locking failure occurs.
 func (s *operations) UpdateUpgradeKymaOperation(operation internal.UpgradeKymaOperation) (*internal.UpgradeKymaOperation, error) {
+	if events := operation.TakePendingEvents(); len(events) > 0 {
+		return s.updateUpgradeKymaOperationWithEvents(operation, events)
+	}
 	session := s.NewWriteSession()
 	operation.UpdatedAt = time.Now()
 	dto, err := s.upgradeKymaOperationToDTO(&operation)
@@ -466,6 +506,287 @@ func (s *operations) UpdateUpgradeKymaOperation(operation internal.UpgradeKymaOp
 	return &operation, lastErr
 }
 
+// updateUpgradeKymaOperationWithEvents updates UpgradeKymaOperation and inserts the outbox events in one transaction
+func (s *operations) updateUpgradeKymaOperationWithEvents(operation internal.UpgradeKymaOperation, events []internal.OutboxEvent) (*internal.UpgradeKymaOperation, error) {
+	operation.UpdatedAt = time.Now()
+	dto, err := s.upgradeKymaOperationToDTO(&operation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while converting Operation to DTO")
+	}
+	if err := s.updateWithEvents(dto, events); err != nil {
+		return nil, err
+	}
+	operation.Version = operation.Version + 1
+	return &operation, nil
+}
+
+// InsertUpgradeClusterOperation insert new UpgradeClusterOperation to storage
+func (s *operations) InsertUpgradeClusterOperation(operation internal.UpgradeClusterOperation) error {
+	session := s.NewWriteSession()
+	dto, err := s.upgradeClusterOperationToDTO(&operation)
+	if err != nil {
+		return errors.Wrapf(err, "while inserting upgrade cluster operation (id: %s)", operation.Operation.ID)
+	}
+	var lastErr error
+	_ = wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = session.InsertOperation(dto)
+		if lastErr != nil {
+			log.Errorf("while insert operation: %v", lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	return lastErr
+}
+
+// GetUpgradeClusterOperationByID fetches the UpgradeClusterOperation by given ID, returns error if not found
+func (s *operations) GetUpgradeClusterOperationByID(operationID string) (*internal.UpgradeClusterOperation, error) {
+	session := s.NewReadSession()
+	operation := dbmodel.OperationDTO{}
+	var lastErr error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		operation, lastErr = session.GetOperationByID(operationID)
+		if lastErr != nil {
+			if dberr.IsNotFound(lastErr) {
+				lastErr = dberr.NotFound("Operation with id %s not exist", operationID)
+				return false, lastErr
+			}
+			log.Errorf("while reading operation from the storage: %v", lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "while getting operation by ID")
+	}
+	ret, err := s.toUpgradeClusterOperation(&operation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while converting DTO to Operation")
+	}
+
+	return ret, nil
+}
+
+func (s *operations) ListUpgradeClusterOperationsByInstanceID(instanceID string) ([]internal.UpgradeClusterOperation, error) {
+	session := s.NewReadSession()
+	operations := []dbmodel.OperationDTO{}
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		operations, lastErr = session.GetOperationsByTypeAndInstanceID(instanceID, dbmodel.OperationTypeUpgradeCluster)
+		if lastErr != nil {
+			log.Errorf("while reading operation from the storage: %v", lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+	ret, err := s.toUpgradeClusterOperationList(operations)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while converting DTO to Operation")
+	}
+
+	return ret, nil
+}
+
+func (s *operations) ListUpgradeClusterOperationsByOrchestrationID(orchestrationID string, filter dbmodel.OperationFilter) ([]internal.UpgradeClusterOperation, int, int, error) {
+	session := s.NewReadSession()
+	var (
+		operations        = make([]dbmodel.OperationDTO, 0)
+		lastErr           error
+		count, totalCount int
+	)
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		operations, count, totalCount, lastErr = session.ListOperationsByOrchestrationID(orchestrationID, filter)
+		if lastErr != nil {
+			if dberr.IsNotFound(lastErr) {
+				lastErr = dberr.NotFound("Operations for orchestration ID %s not exist", orchestrationID)
+				return false, lastErr
+			}
+			log.Errorf("while reading operation from the storage: %v", lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, -1, -1, errors.Wrapf(err, "while getting operation by ID: %v", lastErr)
+	}
+	ret, err := s.toUpgradeClusterOperationList(operations)
+	if err != nil {
+		return nil, -1, -1, errors.Wrapf(err, "while converting DTO to Operation")
+	}
+
+	return ret, count, totalCount, nil
+}
+
+// UpdateUpgradeClusterOperation updates UpgradeClusterOperation, fails if not exists or optimistic locking failure occurs.
+func (s *operations) UpdateUpgradeClusterOperation(operation internal.UpgradeClusterOperation) (*internal.UpgradeClusterOperation, error) {
+	if events := operation.TakePendingEvents(); len(events) > 0 {
+		return s.updateUpgradeClusterOperationWithEvents(operation, events)
+	}
+	session := s.NewWriteSession()
+	operation.UpdatedAt = time.Now()
+	dto, err := s.upgradeClusterOperationToDTO(&operation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while converting Operation to DTO")
+	}
+
+	var lastErr error
+	_ = wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = session.UpdateOperation(dto)
+		if lastErr != nil && dberr.IsNotFound(lastErr) {
+			_, lastErr = s.NewReadSession().GetOperationByID(operation.Operation.ID)
+			if lastErr != nil {
+				log.Errorf("while getting operation: %v", lastErr)
+				return false, nil
+			}
+
+			// the operation exists but the version is different
+			lastErr = dberr.Conflict("operation update conflict, operation ID: %s", operation.Operation.ID)
+			log.Warn(lastErr.Error())
+			return false, lastErr
+		}
+		return true, nil
+	})
+	operation.Version = operation.Version + 1
+	return &operation, lastErr
+}
+
+// updateUpgradeClusterOperationWithEvents updates UpgradeClusterOperation and inserts the outbox events in one transaction
+func (s *operations) updateUpgradeClusterOperationWithEvents(operation internal.UpgradeClusterOperation, events []internal.OutboxEvent) (*internal.UpgradeClusterOperation, error) {
+	operation.UpdatedAt = time.Now()
+	dto, err := s.upgradeClusterOperationToDTO(&operation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while converting Operation to DTO")
+	}
+	if err := s.updateWithEvents(dto, events); err != nil {
+		return nil, err
+	}
+	operation.Version = operation.Version + 1
+	return &operation, nil
+}
+
+// InsertUpdatingOperation insert new UpdatingOperation to storage
+func (s *operations) InsertUpdatingOperation(operation internal.UpdatingOperation) error {
+	session := s.NewWriteSession()
+	dto, err := s.updatingOperationToDTO(&operation)
+	if err != nil {
+		return errors.Wrapf(err, "while inserting updating operation (id: %s)", operation.Operation.ID)
+	}
+	var lastErr error
+	_ = wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = session.InsertOperation(dto)
+		if dberr.IsConflict(lastErr) {
+			return false, lastErr
+		}
+		if lastErr != nil {
+			log.Errorf("while insert operation: %v", lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	return lastErr
+}
+
+// GetUpdatingOperationByID fetches the UpdatingOperation by given ID, returns error if not found
+func (s *operations) GetUpdatingOperationByID(operationID string) (*internal.UpdatingOperation, error) {
+	session := s.NewReadSession()
+	operation := dbmodel.OperationDTO{}
+	var lastErr error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		operation, lastErr = session.GetOperationByID(operationID)
+		if lastErr != nil {
+			if dberr.IsNotFound(lastErr) {
+				lastErr = dberr.NotFound("Operation with id %s not exist", operationID)
+				return false, lastErr
+			}
+			log.Errorf("while reading operation from the storage: %v", lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "while getting operation by ID")
+	}
+	ret, err := s.toUpdatingOperation(&operation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while converting DTO to Operation")
+	}
+
+	return ret, nil
+}
+
+func (s *operations) ListUpdatingOperationsByInstanceID(instanceID string) ([]internal.UpdatingOperation, error) {
+	session := s.NewReadSession()
+	operations := []dbmodel.OperationDTO{}
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		operations, lastErr = session.GetOperationsByTypeAndInstanceID(instanceID, dbmodel.OperationTypeUpdate)
+		if lastErr != nil {
+			log.Errorf("while reading operation from the storage: %v", lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+	ret, err := s.toUpdatingOperationList(operations)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while converting DTO to Operation")
+	}
+
+	return ret, nil
+}
+
+// UpdateUpdatingOperation updates UpdatingOperation, fails if not exists or optimistic locking failure occurs.
+func (s *operations) UpdateUpdatingOperation(operation internal.UpdatingOperation) (*internal.UpdatingOperation, error) {
+	if events := operation.TakePendingEvents(); len(events) > 0 {
+		return s.updateUpdatingOperationWithEvents(operation, events)
+	}
+	session := s.NewWriteSession()
+	operation.UpdatedAt = time.Now()
+	dto, err := s.updatingOperationToDTO(&operation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while converting Operation to DTO")
+	}
+
+	var lastErr error
+	_ = wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = session.UpdateOperation(dto)
+		if lastErr != nil && dberr.IsNotFound(lastErr) {
+			_, lastErr = s.NewReadSession().GetOperationByID(operation.Operation.ID)
+			if lastErr != nil {
+				log.Errorf("while getting operation: %v", lastErr)
+				return false, nil
+			}
+
+			// the operation exists but the version is different
+			lastErr = dberr.Conflict("operation update conflict, operation ID: %s", operation.Operation.ID)
+			log.Warn(lastErr.Error())
+			return false, lastErr
+		}
+		return true, nil
+	})
+	operation.Version = operation.Version + 1
+	return &operation, lastErr
+}
+
+// updateUpdatingOperationWithEvents updates UpdatingOperation and inserts the outbox events in one transaction
+func (s *operations) updateUpdatingOperationWithEvents(operation internal.UpdatingOperation, events []internal.OutboxEvent) (*internal.UpdatingOperation, error) {
+	operation.UpdatedAt = time.Now()
+	dto, err := s.updatingOperationToDTO(&operation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while converting Operation to DTO")
+	}
+	if err := s.updateWithEvents(dto, events); err != nil {
+		return nil, err
+	}
+	operation.Version = operation.Version + 1
+	return &operation, nil
+}
+
 // GetLastOperation returns Operation for given instance ID which is not in 'pending' state. Returns an error if the operation does not exists.
 func (s *operations) GetLastOperation(instanceID string) (*internal.Operation, error) {
 	session := s.NewReadSession()
@@ -491,7 +812,7 @@ func (s *operations) GetLastOperation(instanceID string) (*internal.Operation, e
 	if err != nil {
 		return nil, errors.New("unable to unmarshall operation data")
 	}
-	op, err = s.toOperation(&operation, op.InstanceDetails)
+	op, err = s.toOperation(&operation, op)
 	if err != nil {
 		return nil, err
 	}
@@ -523,7 +844,7 @@ func (s *operations) GetOperationByID(operationID string) (*internal.Operation,
 	if err != nil {
 		return nil, errors.New("unable to unmarshall operation data")
 	}
-	op, err = s.toOperation(&operation, op.InstanceDetails)
+	op, err = s.toOperation(&operation, op)
 	if err != nil {
 		return nil, err
 	}
@@ -605,6 +926,15 @@ func (s *operations) GetOperationsForIDs(operationIDList []string) ([]internal.O
 	return s.toOperations(operations)
 }
 
+// DeleteOperationsByIDs removes the operations with the given IDs
+func (s *operations) DeleteOperationsByIDs(operationIDList []string) error {
+	if len(operationIDList) == 0 {
+		return nil
+	}
+	sess := s.NewWriteSession()
+	return sess.DeleteOperations(operationIDList)
+}
+
 func (s *operations) ListOperations(filter dbmodel.OperationFilter) ([]internal.Operation, int, int, error) {
 	session := s.NewReadSession()
 
@@ -661,6 +991,38 @@ func (s *operations) ListUpgradeKymaOperationsByOrchestrationID(orchestrationID
 	return ret, count, totalCount, nil
 }
 
+func (s *operations) updateWithEvents(dto dbmodel.OperationDTO, events []internal.OutboxEvent) error {
+	sess, dbErr := s.NewSessionWithinTransaction()
+	if dbErr != nil {
+		return dbErr
+	}
+	defer sess.RollbackUnlessCommitted()
+
+	dbErr = sess.UpdateOperation(dto)
+	if dbErr != nil {
+		if !dberr.IsNotFound(dbErr) {
+			return dbErr
+		}
+		if _, err := s.NewReadSession().GetOperationByID(dto.ID); err != nil {
+			return err
+		}
+		// the operation exists but the version is different
+		return dberr.Conflict("operation update conflict, operation ID: %s", dto.ID)
+	}
+	for _, event := range events {
+		eventDTO, err := toOutboxEventDTO(s.cipher, event)
+		if err != nil {
+			return err
+		}
+		dbErr = sess.InsertOutboxEvent(eventDTO)
+		if dbErr != nil {
+			return dbErr
+		}
+	}
+
+	return sess.Commit()
+}
+
 func (s *operations) operationToDB(op internal.Operation) (dbmodel.OperationDTO, error) {
 	err := s.cipher.EncryptBasicAuth(&op.ProvisioningParameters)
 	if err != nil {
@@ -685,7 +1047,7 @@ func (s *operations) operationToDB(op internal.Operation) (dbmodel.OperationDTO,
 	}, nil
 }
 
-func (s *operations) toOperation(op *dbmodel.OperationDTO, instanceDetails internal.InstanceDetails) (internal.Operation, error) {
+func (s *operations) toOperation(op *dbmodel.OperationDTO, existing internal.Operation) (internal.Operation, error) {
 	pp := internal.ProvisioningParameters{}
 	if op.ProvisioningParameters.Valid {
 		err := json.Unmarshal([]byte(op.ProvisioningParameters.String), &pp)
@@ -709,7 +1071,9 @@ func (s *operations) toOperation(op *dbmodel.OperationDTO, instanceDetails inter
 		Version:                op.Version,
 		OrchestrationID:        storage.SQLNullStringToString(op.OrchestrationID),
 		ProvisioningParameters: pp,
-		InstanceDetails:        instanceDetails,
+		InstanceDetails:        existing.InstanceDetails,
+		FinishedSteps:          existing.FinishedSteps,
+		StepAttempts:           existing.StepAttempts,
 	}, nil
 }
 
@@ -721,7 +1085,7 @@ func (s *operations) toOperations(op []dbmodel.OperationDTO) ([]internal.Operati
 		if err != nil {
 			return nil, errors.New("unable to unmarshall provisioning data")
 		}
-		operation, err = s.toOperation(&o, operation.InstanceDetails)
+		operation, err = s.toOperation(&o, operation)
 		if err != nil {
 			return nil, err
 		}
@@ -740,7 +1104,7 @@ func (s *operations) toProvisioningOperation(op *dbmodel.OperationDTO) (*interna
 	if err != nil {
 		return nil, errors.New("unable to unmarshall provisioning data")
 	}
-	operation.Operation, err = s.toOperation(op, operation.InstanceDetails)
+	operation.Operation, err = s.toOperation(op, operation.Operation)
 	if err != nil {
 		return nil, err
 	}
@@ -800,7 +1164,7 @@ func (s *operations) toDeprovisioningOperation(op *dbmodel.OperationDTO) (*inter
 	if err != nil {
 		return nil, errors.New("unable to unmarshall provisioning data")
 	}
-	operation.Operation, err = s.toOperation(op, operation.InstanceDetails)
+	operation.Operation, err = s.toOperation(op, operation.Operation)
 	if err != nil {
 		return nil, err
 	}
@@ -833,7 +1197,7 @@ func (s *operations) toUpgradeKymaOperation(op *dbmodel.OperationDTO) (*internal
 	if err != nil {
 		return nil, errors.New("unable to unmarshall provisioning data")
 	}
-	operation.Operation, err = s.toOperation(op, operation.InstanceDetails)
+	operation.Operation, err = s.toOperation(op, operation.Operation)
 	if err != nil {
 		return nil, err
 	}
@@ -874,3 +1238,102 @@ func (s *operations) upgradeKymaOperationToDTO(op *internal.UpgradeKymaOperation
 	ret.OrchestrationID = storage.StringToSQLNullString(op.OrchestrationID)
 	return ret, nil
 }
+
+func (s *operations) toUpgradeClusterOperation(op *dbmodel.OperationDTO) (*internal.UpgradeClusterOperation, error) {
+	if op.Type != dbmodel.OperationTypeUpgradeCluster {
+		return nil, errors.New(fmt.Sprintf("expected operation type Upgrade Cluster, but was %s", op.Type))
+	}
+	var operation internal.UpgradeClusterOperation
+	var err error
+	err = json.Unmarshal([]byte(op.Data), &operation)
+	if err != nil {
+		return nil, errors.New("unable to unmarshall upgrade cluster data")
+	}
+	operation.Operation, err = s.toOperation(op, operation.Operation)
+	if err != nil {
+		return nil, err
+	}
+	operation.RuntimeOperation.ID = op.ID
+	if op.OrchestrationID.Valid {
+		operation.OrchestrationID = op.OrchestrationID.String
+	}
+
+	return &operation, nil
+}
+
+func (s *operations) toUpgradeClusterOperationList(ops []dbmodel.OperationDTO) ([]internal.UpgradeClusterOperation, error) {
+	result := make([]internal.UpgradeClusterOperation, 0)
+
+	for _, op := range ops {
+		o, err := s.toUpgradeClusterOperation(&op)
+		if err != nil {
+			return nil, errors.Wrap(err, "while converting to upgrade cluster operation")
+		}
+		result = append(result, *o)
+	}
+
+	return result, nil
+}
+
+func (s *operations) upgradeClusterOperationToDTO(op *internal.UpgradeClusterOperation) (dbmodel.OperationDTO, error) {
+	serialized, err := json.Marshal(op)
+	if err != nil {
+		return dbmodel.OperationDTO{}, errors.Wrapf(err, "while serializing upgrade cluster data %v", op)
+	}
+
+	ret, err := s.operationToDB(op.Operation)
+	if err != nil {
+		return dbmodel.OperationDTO{}, errors.Wrapf(err, "while converting to operationDB %v", op)
+	}
+	ret.Data = string(serialized)
+	ret.Type = dbmodel.OperationTypeUpgradeCluster
+	ret.OrchestrationID = storage.StringToSQLNullString(op.OrchestrationID)
+	return ret, nil
+}
+
+func (s *operations) toUpdatingOperation(op *dbmodel.OperationDTO) (*internal.UpdatingOperation, error) {
+	if op.Type != dbmodel.OperationTypeUpdate {
+		return nil, errors.New(fmt.Sprintf("expected operation type Update, but was %s", op.Type))
+	}
+	var operation internal.UpdatingOperation
+	var err error
+	err = json.Unmarshal([]byte(op.Data), &operation)
+	if err != nil {
+		return nil, errors.New("unable to unmarshall updating data")
+	}
+	operation.Operation, err = s.toOperation(op, operation.Operation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &operation, nil
+}
+
+func (s *operations) toUpdatingOperationList(ops []dbmodel.OperationDTO) ([]internal.UpdatingOperation, error) {
+	result := make([]internal.UpdatingOperation, 0)
+
+	for _, op := range ops {
+		o, err := s.toUpdatingOperation(&op)
+		if err != nil {
+			return nil, errors.Wrap(err, "while converting to updating operation")
+		}
+		result = append(result, *o)
+	}
+
+	return result, nil
+}
+
+func (s *operations) updatingOperationToDTO(op *internal.UpdatingOperation) (dbmodel.OperationDTO, error) {
+	serialized, err := json.Marshal(op)
+	if err != nil {
+		return dbmodel.OperationDTO{}, errors.Wrapf(err, "while serializing updating data %v", op)
+	}
+
+	ret, err := s.operationToDB(op.Operation)
+	if err != nil {
+		return dbmodel.OperationDTO{}, errors.Wrapf(err, "while converting to operationDB %v", op)
+	}
+	ret.Data = string(serialized)
+	ret.Type = dbmodel.OperationTypeUpdate
+	return ret, nil
+}
@@ -0,0 +1,104 @@
+package postsql
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/postsql"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type operationEvents struct {
+	postsql.Factory
+}
+
+func NewOperationEvents(sess postsql.Factory) *operationEvents {
+	return &operationEvents{
+		Factory: sess,
+	}
+}
+
+func (s *operationEvents) Insert(event internal.OperationEvent) error {
+	dto := dbmodel.OperationEventDTO{
+		ID:           event.ID,
+		OperationID:  event.OperationID,
+		InstanceID:   event.InstanceID,
+		StepName:     event.StepName,
+		StartedAt:    event.StartedAt,
+		FinishedAt:   event.FinishedAt,
+		Duration:     int64(event.Duration),
+		RequeueDelay: int64(event.RequeueDelay),
+		Error:        event.Error,
+		OldState:     string(event.OldState),
+		NewState:     string(event.NewState),
+		Description:  event.Description,
+	}
+	sess := s.NewWriteSession()
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = sess.InsertOperationEvent(dto)
+		if lastErr != nil {
+			if lastErr.Code() == dberr.CodeAlreadyExists {
+				return false, lastErr
+			}
+			log.Errorf("while inserting event of operation ID %s: %v", event.OperationID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return lastErr
+	}
+	return nil
+}
+
+func (s *operationEvents) ListByOperationID(operationID string) ([]internal.OperationEvent, error) {
+	sess := s.NewReadSession()
+	var (
+		dtos    []dbmodel.OperationEventDTO
+		lastErr dberr.Error
+	)
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		dtos, lastErr = sess.ListOperationEventsByOperationID(operationID)
+		if lastErr != nil {
+			log.Errorf("while listing events of operation ID %s: %v", operationID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+
+	result := make([]internal.OperationEvent, 0)
+	for _, dto := range dtos {
+		result = append(result, internal.OperationEvent{
+			ID:           dto.ID,
+			OperationID:  dto.OperationID,
+			InstanceID:   dto.InstanceID,
+			StepName:     dto.StepName,
+			StartedAt:    dto.StartedAt,
+			FinishedAt:   dto.FinishedAt,
+			Duration:     time.Duration(dto.Duration),
+			RequeueDelay: time.Duration(dto.RequeueDelay),
+			Error:        dto.Error,
+			OldState:     domain.LastOperationState(dto.OldState),
+			NewState:     domain.LastOperationState(dto.NewState),
+			Description:  dto.Description,
+		})
+	}
+	return result, nil
+}
+
+func (s *operationEvents) DeleteByOperationIDs(operationIDList []string) error {
+	if len(operationIDList) == 0 {
+		return nil
+	}
+	sess := s.NewWriteSession()
+	return sess.DeleteOperationEventsByOperationIDs(operationIDList)
+}
@@ -12,11 +12,13 @@ import (
 
 type orchestrations struct {
 	postsql.Factory
+	operations *operations
 }
 
-func NewOrchestrations(sess postsql.Factory) *orchestrations {
+func NewOrchestrations(sess postsql.Factory, operations *operations) *orchestrations {
 	return &orchestrations{
-		Factory: sess,
+		Factory:    sess,
+		operations: operations,
 	}
 }
 
@@ -42,6 +44,56 @@ func (s *orchestrations) Insert(orchestration internal.Orchestration) error {
 	})
 }
 
+func (s *orchestrations) InsertWithUpgradeKymaOperations(orchestration internal.Orchestration, operations []internal.UpgradeKymaOperation) error {
+	dtos := make([]dbmodel.OperationDTO, 0, len(operations))
+	for _, op := range operations {
+		dto, err := s.operations.upgradeKymaOperationToDTO(&op)
+		if err != nil {
+			return errors.Wrapf(err, "while converting upgrade kyma operation %s", op.Operation.ID)
+		}
+		dtos = append(dtos, dto)
+	}
+	return s.insertWithOperations(orchestration, dtos)
+}
+
+func (s *orchestrations) InsertWithUpgradeClusterOperations(orchestration internal.Orchestration, operations []internal.UpgradeClusterOperation) error {
+	dtos := make([]dbmodel.OperationDTO, 0, len(operations))
+	for _, op := range operations {
+		dto, err := s.operations.upgradeClusterOperationToDTO(&op)
+		if err != nil {
+			return errors.Wrapf(err, "while converting upgrade cluster operation %s", op.Operation.ID)
+		}
+		dtos = append(dtos, dto)
+	}
+	return s.insertWithOperations(orchestration, dtos)
+}
+
+func (s *orchestrations) insertWithOperations(orchestration internal.Orchestration, operations []dbmodel.OperationDTO) error {
+	dto, err := dbmodel.NewOrchestrationDTO(orchestration)
+	if err != nil {
+		return errors.Wrapf(err, "while converting Orchestration to DTO")
+	}
+
+	sess, dbErr := s.NewSessionWithinTransaction()
+	if dbErr != nil {
+		return dbErr
+	}
+	defer sess.RollbackUnlessCommitted()
+
+	dbErr = sess.InsertOrchestration(dto)
+	if dbErr != nil {
+		return dbErr
+	}
+	for _, op := range operations {
+		dbErr = sess.InsertOperation(op)
+		if dbErr != nil {
+			return dbErr
+		}
+	}
+
+	return sess.Commit()
+}
+
 func (s *orchestrations) GetByID(orchestrationID string) (*internal.Orchestration, error) {
 	sess := s.NewReadSession()
 	orchestration := internal.Orchestration{}
@@ -0,0 +1,154 @@
+package postsql
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/postsql"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type outboxEvents struct {
+	postsql.Factory
+	cipher Cipher
+}
+
+func NewOutboxEvents(sess postsql.Factory, cipher Cipher) *outboxEvents {
+	return &outboxEvents{
+		Factory: sess,
+		cipher:  cipher,
+	}
+}
+
+func (s *outboxEvents) Insert(event internal.OutboxEvent) error {
+	dto, err := toOutboxEventDTO(s.cipher, event)
+	if err != nil {
+		return err
+	}
+
+	sess := s.NewWriteSession()
+	var lastErr dberr.Error
+	err = wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = sess.InsertOutboxEvent(dto)
+		if lastErr != nil {
+			if lastErr.Code() == dberr.CodeAlreadyExists {
+				return false, lastErr
+			}
+			log.Errorf("while inserting outbox event ID %s: %v", event.ID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return lastErr
+	}
+	return nil
+}
+
+func (s *outboxEvents) Update(event internal.OutboxEvent) error {
+	sess := s.NewWriteSession()
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = sess.UpdateOutboxEvent(dbmodel.OutboxEventDTO{
+			ID:            event.ID,
+			State:         string(event.State),
+			Attempts:      event.Attempts,
+			LastError:     event.LastError,
+			NextAttemptAt: event.NextAttemptAt,
+		})
+		if lastErr != nil {
+			if dberr.IsNotFound(lastErr) {
+				return false, lastErr
+			}
+			log.Errorf("while updating outbox event ID %s: %v", event.ID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return lastErr
+	}
+	return nil
+}
+
+func (s *outboxEvents) Delete(id string) error {
+	sess := s.NewWriteSession()
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = sess.DeleteOutboxEvent(id)
+		if lastErr != nil {
+			log.Errorf("while deleting outbox event ID %s: %v", id, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return lastErr
+	}
+	return nil
+}
+
+// ClaimPending skips the events claimed by another instance of the application, which are locked until the claim is stored
+func (s *outboxEvents) ClaimPending(subscriber string, until, leaseUntil time.Time, limit int) ([]internal.OutboxEvent, error) {
+	sess := s.NewWriteSession()
+	var (
+		dtos    []dbmodel.OutboxEventDTO
+		lastErr dberr.Error
+	)
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		dtos, lastErr = sess.ClaimPendingOutboxEvents(subscriber, until, leaseUntil, limit)
+		if lastErr != nil {
+			log.Errorf("while claiming pending outbox events of %s: %v", subscriber, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+
+	result := make([]internal.OutboxEvent, 0)
+	for _, dto := range dtos {
+		payload, err := s.cipher.Decrypt(dto.Payload)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while decrypting payload of outbox event ID %s", dto.ID)
+		}
+		result = append(result, internal.OutboxEvent{
+			ID:            dto.ID,
+			Type:          dto.Type,
+			Payload:       payload,
+			Subscriber:    dto.Subscriber,
+			State:         internal.OutboxEventState(dto.State),
+			Attempts:      dto.Attempts,
+			LastError:     dto.LastError,
+			CreatedAt:     dto.CreatedAt,
+			NextAttemptAt: dto.NextAttemptAt,
+		})
+	}
+	return result, nil
+}
+
+// toOutboxEventDTO encrypts the payload of the event, it can contain credentials, for example of Service Manager
+func toOutboxEventDTO(cipher Cipher, event internal.OutboxEvent) (dbmodel.OutboxEventDTO, error) {
+	payload, err := cipher.Encrypt(event.Payload)
+	if err != nil {
+		return dbmodel.OutboxEventDTO{}, errors.Wrapf(err, "while encrypting payload of outbox event ID %s", event.ID)
+	}
+
+	return dbmodel.OutboxEventDTO{
+		ID:            event.ID,
+		Type:          event.Type,
+		Payload:       payload,
+		Subscriber:    event.Subscriber,
+		State:         string(event.State),
+		Attempts:      event.Attempts,
+		LastError:     event.LastError,
+		CreatedAt:     event.CreatedAt,
+		NextAttemptAt: event.NextAttemptAt,
+	}, nil
+}
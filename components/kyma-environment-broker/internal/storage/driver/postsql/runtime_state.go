@@ -94,6 +94,14 @@ func (s *runtimeState) GetByOperationID(operationID string) (internal.RuntimeSta
 	return result, nil
 }
 
+func (s *runtimeState) DeleteByOperationIDs(operationIDList []string) error {
+	if len(operationIDList) == 0 {
+		return nil
+	}
+	sess := s.NewWriteSession()
+	return sess.DeleteRuntimeStatesByOperationIDs(operationIDList)
+}
+
 func (s *runtimeState) runtimeStateToDB(op internal.RuntimeState) (dbmodel.RuntimeStateDTO, error) {
 	kymaCfg, err := json.Marshal(op.KymaConfig)
 	if err != nil {
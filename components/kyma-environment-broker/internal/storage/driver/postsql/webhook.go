@@ -0,0 +1,235 @@
+package postsql
+
+import (
+	"encoding/json"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/postsql"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type webhookSubscriptions struct {
+	postsql.Factory
+	cipher Cipher
+}
+
+func NewWebhookSubscriptions(sess postsql.Factory, cipher Cipher) *webhookSubscriptions {
+	return &webhookSubscriptions{
+		Factory: sess,
+		cipher:  cipher,
+	}
+}
+
+func (s *webhookSubscriptions) Insert(subscription internal.WebhookSubscription) error {
+	dto, err := s.toSubscriptionDTO(subscription)
+	if err != nil {
+		return err
+	}
+	sess := s.NewWriteSession()
+	var lastErr dberr.Error
+	err = wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = sess.InsertWebhookSubscription(dto)
+		if lastErr != nil {
+			if lastErr.Code() == dberr.CodeAlreadyExists {
+				return false, lastErr
+			}
+			log.Errorf("while inserting webhook subscription ID %s: %v", subscription.ID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return lastErr
+	}
+	return nil
+}
+
+func (s *webhookSubscriptions) GetByID(id string) (*internal.WebhookSubscription, error) {
+	sess := s.NewReadSession()
+	var (
+		dto     dbmodel.WebhookSubscriptionDTO
+		lastErr dberr.Error
+	)
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		dto, lastErr = sess.GetWebhookSubscriptionByID(id)
+		if lastErr != nil {
+			if dberr.IsNotFound(lastErr) {
+				return false, dberr.NotFound("webhook subscription with id %s not exist", id)
+			}
+			log.Errorf("while getting webhook subscription by ID %s: %v", id, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.toSubscription(dto)
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (s *webhookSubscriptions) List() ([]internal.WebhookSubscription, error) {
+	sess := s.NewReadSession()
+	var (
+		dtos    []dbmodel.WebhookSubscriptionDTO
+		lastErr dberr.Error
+	)
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		dtos, lastErr = sess.ListWebhookSubscriptions()
+		if lastErr != nil {
+			log.Errorf("while listing webhook subscriptions: %v", lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+
+	result := make([]internal.WebhookSubscription, 0)
+	for _, dto := range dtos {
+		subscription, err := s.toSubscription(dto)
+		if err != nil {
+			return nil, errors.Wrap(err, "while converting webhook subscriptions")
+		}
+		result = append(result, subscription)
+	}
+	return result, nil
+}
+
+// Delete removes the subscription, its delivery log is removed by the database
+func (s *webhookSubscriptions) Delete(id string) error {
+	sess := s.NewWriteSession()
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = sess.DeleteWebhookSubscription(id)
+		if lastErr != nil {
+			log.Errorf("while deleting webhook subscription ID %s: %v", id, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return lastErr
+	}
+	return nil
+}
+
+func (s *webhookSubscriptions) toSubscriptionDTO(subscription internal.WebhookSubscription) (dbmodel.WebhookSubscriptionDTO, error) {
+	secret, err := s.cipher.Encrypt([]byte(subscription.Secret))
+	if err != nil {
+		return dbmodel.WebhookSubscriptionDTO{}, errors.Wrap(err, "while encrypting webhook secret")
+	}
+	filter, err := json.Marshal(subscription.Filter)
+	if err != nil {
+		return dbmodel.WebhookSubscriptionDTO{}, errors.Wrap(err, "while marshalling webhook filter")
+	}
+
+	return dbmodel.WebhookSubscriptionDTO{
+		ID:        subscription.ID,
+		URL:       subscription.URL,
+		Secret:    string(secret),
+		Filter:    string(filter),
+		CreatedAt: subscription.CreatedAt,
+	}, nil
+}
+
+func (s *webhookSubscriptions) toSubscription(dto dbmodel.WebhookSubscriptionDTO) (internal.WebhookSubscription, error) {
+	secret, err := s.cipher.Decrypt([]byte(dto.Secret))
+	if err != nil {
+		return internal.WebhookSubscription{}, errors.Wrap(err, "while decrypting webhook secret")
+	}
+	var filter internal.WebhookFilter
+	if err := json.Unmarshal([]byte(dto.Filter), &filter); err != nil {
+		return internal.WebhookSubscription{}, errors.Wrap(err, "while unmarshalling webhook filter")
+	}
+
+	return internal.WebhookSubscription{
+		ID:        dto.ID,
+		URL:       dto.URL,
+		Secret:    string(secret),
+		Filter:    filter,
+		CreatedAt: dto.CreatedAt,
+	}, nil
+}
+
+type webhookDeliveries struct {
+	postsql.Factory
+}
+
+func NewWebhookDeliveries(sess postsql.Factory) *webhookDeliveries {
+	return &webhookDeliveries{
+		Factory: sess,
+	}
+}
+
+func (s *webhookDeliveries) Insert(delivery internal.WebhookDelivery) error {
+	dto := dbmodel.WebhookDeliveryDTO{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		NotificationID: delivery.NotificationID,
+		EventType:      delivery.EventType,
+		StatusCode:     delivery.StatusCode,
+		Error:          delivery.Error,
+		CreatedAt:      delivery.CreatedAt,
+	}
+	sess := s.NewWriteSession()
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = sess.InsertWebhookDelivery(dto)
+		if lastErr != nil {
+			if lastErr.Code() == dberr.CodeAlreadyExists {
+				return false, lastErr
+			}
+			log.Errorf("while inserting delivery of webhook subscription ID %s: %v", delivery.SubscriptionID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return lastErr
+	}
+	return nil
+}
+
+func (s *webhookDeliveries) ListBySubscriptionID(subscriptionID string) ([]internal.WebhookDelivery, error) {
+	sess := s.NewReadSession()
+	var (
+		dtos    []dbmodel.WebhookDeliveryDTO
+		lastErr dberr.Error
+	)
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		dtos, lastErr = sess.ListWebhookDeliveriesBySubscriptionID(subscriptionID)
+		if lastErr != nil {
+			log.Errorf("while listing deliveries of webhook subscription ID %s: %v", subscriptionID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+
+	result := make([]internal.WebhookDelivery, 0)
+	for _, dto := range dtos {
+		result = append(result, internal.WebhookDelivery{
+			ID:             dto.ID,
+			SubscriptionID: dto.SubscriptionID,
+			NotificationID: dto.NotificationID,
+			EventType:      dto.EventType,
+			StatusCode:     dto.StatusCode,
+			Error:          dto.Error,
+			CreatedAt:      dto.CreatedAt,
+		})
+	}
+	return result, nil
+}
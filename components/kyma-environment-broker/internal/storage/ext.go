@@ -1,9 +1,12 @@
 package storage
 
 import (
+	"time"
+
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/predicate"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
 )
 
 type Instances interface {
@@ -17,6 +20,9 @@ type Instances interface {
 	GetInstanceStats() (internal.InstanceStats, error)
 	GetNumberOfInstancesForGlobalAccountID(globalAccountID string) (int, error)
 	List(dbmodel.InstanceFilter) ([]internal.Instance, int, int, error)
+	// InsertChecked inserts the instance only if the check function accepts the instances of the same global account.
+	// The check and the insert are not run concurrently for the same global account, also in the other replicas of the broker.
+	InsertChecked(instance internal.Instance, check func(globalAccountInstances []internal.Instance) error) error
 
 	// todo: remove after instances parameters migration is done
 	InsertWithoutEncryption(instance internal.Instance) error
@@ -28,6 +34,8 @@ type Operations interface {
 	Provisioning
 	Deprovisioning
 	UpgradeKyma
+	UpgradeCluster
+	Updating
 
 	GetLastOperation(instanceID string) (*internal.Operation, error)
 	GetOperationByID(operationID string) (*internal.Operation, error)
@@ -36,6 +44,7 @@ type Operations interface {
 	GetOperationsForIDs(operationIDList []string) ([]internal.Operation, error)
 	GetOperationStatsForOrchestration(orchestrationID string) (map[string]int, error)
 	ListOperations(filter dbmodel.OperationFilter) ([]internal.Operation, int, int, error)
+	DeleteOperationsByIDs(operationIDList []string) error
 }
 
 type Provisioning interface {
@@ -55,8 +64,21 @@ type Deprovisioning interface {
 	ListDeprovisioningOperations() ([]internal.DeprovisioningOperation, error)
 }
 
+type Updating interface {
+	InsertUpdatingOperation(operation internal.UpdatingOperation) error
+	GetUpdatingOperationByID(operationID string) (*internal.UpdatingOperation, error)
+	UpdateUpdatingOperation(operation internal.UpdatingOperation) (*internal.UpdatingOperation, error)
+	ListUpdatingOperationsByInstanceID(instanceID string) ([]internal.UpdatingOperation, error)
+}
+
 type Orchestrations interface {
 	Insert(orchestration internal.Orchestration) error
+	// InsertWithUpgradeKymaOperations stores the orchestration and its operations in one transaction, it fails with
+	// the AlreadyExists error if the orchestration retries another orchestration which already has an unfinished retry
+	InsertWithUpgradeKymaOperations(orchestration internal.Orchestration, operations []internal.UpgradeKymaOperation) error
+	// InsertWithUpgradeClusterOperations stores the orchestration and its operations in one transaction, it fails with
+	// the AlreadyExists error if the orchestration retries another orchestration which already has an unfinished retry
+	InsertWithUpgradeClusterOperations(orchestration internal.Orchestration, operations []internal.UpgradeClusterOperation) error
 	Update(orchestration internal.Orchestration) error
 	GetByID(orchestrationID string) (*internal.Orchestration, error)
 	List(filter dbmodel.OrchestrationFilter) ([]internal.Orchestration, int, int, error)
@@ -67,6 +89,7 @@ type RuntimeStates interface {
 	Insert(runtimeState internal.RuntimeState) error
 	GetByOperationID(operationID string) (internal.RuntimeState, error)
 	ListByRuntimeID(runtimeID string) ([]internal.RuntimeState, error)
+	DeleteByOperationIDs(operationIDList []string) error
 }
 
 type UpgradeKyma interface {
@@ -79,6 +102,14 @@ type UpgradeKyma interface {
 	ListUpgradeKymaOperationsByOrchestrationID(orchestrationID string, filter dbmodel.OperationFilter) ([]internal.UpgradeKymaOperation, int, int, error)
 }
 
+type UpgradeCluster interface {
+	InsertUpgradeClusterOperation(operation internal.UpgradeClusterOperation) error
+	UpdateUpgradeClusterOperation(operation internal.UpgradeClusterOperation) (*internal.UpgradeClusterOperation, error)
+	GetUpgradeClusterOperationByID(operationID string) (*internal.UpgradeClusterOperation, error)
+	ListUpgradeClusterOperationsByInstanceID(instanceID string) ([]internal.UpgradeClusterOperation, error)
+	ListUpgradeClusterOperationsByOrchestrationID(orchestrationID string, filter dbmodel.OperationFilter) ([]internal.UpgradeClusterOperation, int, int, error)
+}
+
 type LMSTenants interface {
 	FindTenantByName(name, region string) (internal.LMSTenant, bool, error)
 	InsertTenant(tenant internal.LMSTenant) error
@@ -89,3 +120,49 @@ type CLSInstances interface {
 	InsertInstance(instance internal.CLSInstance) error
 	Reference(version int, globalAccountID, skrInstanceID string) error
 }
+
+type Bindings interface {
+	Insert(binding internal.Binding) error
+	Update(binding internal.Binding) error
+	Delete(bindingID string) error
+	GetByID(bindingID string) (*internal.Binding, error)
+	ListByInstanceID(instanceID string) ([]internal.Binding, error)
+	ListByState(state domain.LastOperationState) ([]internal.Binding, error)
+}
+
+type OperationEvents interface {
+	Insert(event internal.OperationEvent) error
+	ListByOperationID(operationID string) ([]internal.OperationEvent, error)
+	DeleteByOperationIDs(operationIDList []string) error
+}
+
+type OutboxEvents interface {
+	Insert(event internal.OutboxEvent) error
+	Update(event internal.OutboxEvent) error
+	Delete(id string) error
+	// ClaimPending returns the pending events of the subscriber which are due and postpones their next attempt
+	// until leaseUntil, so the events are not claimed again while they are being delivered
+	ClaimPending(subscriber string, until, leaseUntil time.Time, limit int) ([]internal.OutboxEvent, error)
+}
+
+type WebhookSubscriptions interface {
+	Insert(subscription internal.WebhookSubscription) error
+	GetByID(id string) (*internal.WebhookSubscription, error)
+	List() ([]internal.WebhookSubscription, error)
+	Delete(id string) error
+}
+
+type WebhookDeliveries interface {
+	Insert(delivery internal.WebhookDelivery) error
+	ListBySubscriptionID(subscriptionID string) ([]internal.WebhookDelivery, error)
+}
+
+type InstanceArchives interface {
+	Insert(archive internal.InstanceArchive) error
+	GetByInstanceID(instanceID string) (*internal.InstanceArchive, error)
+	// List returns up to limit archives matching the filter starting at the offset, and the number of all matching archives,
+	// the page and the page size of the filter are ignored
+	List(filter dbmodel.InstanceFilter, offset, limit int) ([]internal.InstanceArchive, int, error)
+	// ListOperationIDsToPrune returns up to limit IDs of the finished operations of the archived instances created before the given time
+	ListOperationIDsToPrune(createdBefore time.Time, limit int) ([]string, error)
+}
@@ -1,6 +1,8 @@
 package postsql
 
 import (
+	"time"
+
 	dbr "github.com/gocraft/dbr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
@@ -39,6 +41,18 @@ type ReadSession interface {
 	ListInstances(filter dbmodel.InstanceFilter) ([]dbmodel.InstanceDTO, int, int, error)
 	ListOperationsByOrchestrationID(orchestrationID string, filter dbmodel.OperationFilter) ([]dbmodel.OperationDTO, int, int, error)
 	GetOperationStatsForOrchestration(orchestrationID string) ([]dbmodel.OperationStatEntry, error)
+	GetBindingByID(bindingID string) (dbmodel.BindingDTO, dberr.Error)
+	ListBindingsByInstanceID(instanceID string) ([]dbmodel.BindingDTO, dberr.Error)
+	ListBindingsByState(state string) ([]dbmodel.BindingDTO, dberr.Error)
+	ListOperationEventsByOperationID(operationID string) ([]dbmodel.OperationEventDTO, dberr.Error)
+	GetWebhookSubscriptionByID(id string) (dbmodel.WebhookSubscriptionDTO, dberr.Error)
+	ListWebhookSubscriptions() ([]dbmodel.WebhookSubscriptionDTO, dberr.Error)
+	ListWebhookDeliveriesBySubscriptionID(subscriptionID string) ([]dbmodel.WebhookDeliveryDTO, dberr.Error)
+	GetCLSInstanceByGlobalAccountID(globalAccountID string) (dbmodel.CLSInstanceDTO, dberr.Error)
+	ListCLSInstanceReferences(clsInstanceID string) ([]dbmodel.CLSInstanceReferenceDTO, dberr.Error)
+	GetInstanceArchiveByInstanceID(instanceID string) (dbmodel.InstanceArchiveDTO, dberr.Error)
+	ListInstanceArchives(filter dbmodel.InstanceFilter, offset, limit int) ([]dbmodel.InstanceArchiveDTO, int, error)
+	ListOperationIDsOfArchivedInstances(createdBefore time.Time, limit int) ([]string, dberr.Error)
 }
 
 //go:generate mockery -name=WriteSession
@@ -52,6 +66,26 @@ type WriteSession interface {
 	UpdateOrchestration(o dbmodel.OrchestrationDTO) dberr.Error
 	InsertRuntimeState(state dbmodel.RuntimeStateDTO) dberr.Error
 	InsertLMSTenant(dto dbmodel.LMSTenantDTO) dberr.Error
+	InsertBinding(binding dbmodel.BindingDTO) dberr.Error
+	UpdateBinding(binding dbmodel.BindingDTO) dberr.Error
+	DeleteBinding(bindingID string) dberr.Error
+	InsertOperationEvent(event dbmodel.OperationEventDTO) dberr.Error
+	InsertOutboxEvent(event dbmodel.OutboxEventDTO) dberr.Error
+	UpdateOutboxEvent(event dbmodel.OutboxEventDTO) dberr.Error
+	DeleteOutboxEvent(id string) dberr.Error
+	ClaimPendingOutboxEvents(subscriber string, until, leaseUntil time.Time, limit int) ([]dbmodel.OutboxEventDTO, dberr.Error)
+	InsertWebhookSubscription(subscription dbmodel.WebhookSubscriptionDTO) dberr.Error
+	DeleteWebhookSubscription(id string) dberr.Error
+	InsertWebhookDelivery(delivery dbmodel.WebhookDeliveryDTO) dberr.Error
+	InsertCLSInstance(instance dbmodel.CLSInstanceDTO) dberr.Error
+	UpdateCLSInstance(instance dbmodel.CLSInstanceDTO) dberr.Error
+	InsertCLSInstanceReference(reference dbmodel.CLSInstanceReferenceDTO) dberr.Error
+	InsertInstanceArchive(archive dbmodel.InstanceArchiveDTO) dberr.Error
+	DeleteOperations(operationIDs []string) dberr.Error
+	DeleteRuntimeStatesByOperationIDs(operationIDs []string) dberr.Error
+	DeleteOperationEventsByOperationIDs(operationIDs []string) dberr.Error
+	LockGlobalAccount(globalAccountID string) dberr.Error
+	ListInstancesByGlobalAccountID(globalAccountID string) ([]dbmodel.InstanceDTO, dberr.Error)
 }
 
 type Transaction interface {
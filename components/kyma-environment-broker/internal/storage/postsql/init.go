@@ -13,13 +13,21 @@ import (
 )
 
 const (
-	schemaName             = "public"
-	InstancesTableName     = "instances"
-	OperationTableName     = "operations"
-	OrchestrationTableName = "orchestrations"
-	RuntimeStateTableName  = "runtime_states"
-	LMSTenantTableName     = "lms_tenants"
-	CreatedAtField         = "created_at"
+	schemaName                     = "public"
+	InstancesTableName             = "instances"
+	OperationTableName             = "operations"
+	OrchestrationTableName         = "orchestrations"
+	RuntimeStateTableName          = "runtime_states"
+	LMSTenantTableName             = "lms_tenants"
+	BindingsTableName              = "bindings"
+	OperationEventsTableName       = "operation_events"
+	OutboxEventsTableName          = "outbox_events"
+	WebhookSubscriptionsTableName  = "webhook_subscriptions"
+	WebhookDeliveriesTableName     = "webhook_deliveries"
+	CLSInstancesTableName          = "cls_instances"
+	CLSInstanceReferencesTableName = "cls_instance_references"
+	InstanceArchivesTableName      = "instance_archives"
+	CreatedAtField                 = "created_at"
 )
 
 // InitializeDatabase opens database connection and initializes schema if it does not exist
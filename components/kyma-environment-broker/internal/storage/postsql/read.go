@@ -3,6 +3,7 @@ package postsql
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/pkg/errors"
@@ -357,6 +358,210 @@ func (r readSession) ListRuntimeStateByRuntimeID(runtimeID string) ([]dbmodel.Ru
 	return states, nil
 }
 
+func (r readSession) GetBindingByID(bindingID string) (dbmodel.BindingDTO, dberr.Error) {
+	var binding dbmodel.BindingDTO
+
+	err := r.session.
+		Select("*").
+		From(BindingsTableName).
+		Where(dbr.Eq("binding_id", bindingID)).
+		LoadOne(&binding)
+
+	if err != nil {
+		if err == dbr.ErrNotFound {
+			return dbmodel.BindingDTO{}, dberr.NotFound("cannot find binding: %s", err)
+		}
+		return dbmodel.BindingDTO{}, dberr.Internal("Failed to get binding: %s", err)
+	}
+	return binding, nil
+}
+
+func (r readSession) ListBindingsByInstanceID(instanceID string) ([]dbmodel.BindingDTO, dberr.Error) {
+	return r.listBindings(dbr.Eq("instance_id", instanceID))
+}
+
+func (r readSession) ListBindingsByState(state string) ([]dbmodel.BindingDTO, dberr.Error) {
+	return r.listBindings(dbr.Eq("state", state))
+}
+
+func (r readSession) listBindings(condition dbr.Builder) ([]dbmodel.BindingDTO, dberr.Error) {
+	var bindings []dbmodel.BindingDTO
+
+	_, err := r.session.
+		Select("*").
+		From(BindingsTableName).
+		Where(condition).
+		OrderBy(CreatedAtField).
+		Load(&bindings)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get bindings: %s", err)
+	}
+	return bindings, nil
+}
+
+func (r readSession) ListOperationEventsByOperationID(operationID string) ([]dbmodel.OperationEventDTO, dberr.Error) {
+	var events []dbmodel.OperationEventDTO
+
+	_, err := r.session.
+		Select("*").
+		From(OperationEventsTableName).
+		Where(dbr.Eq("operation_id", operationID)).
+		OrderBy("finished_at").
+		Load(&events)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get operation events: %s", err)
+	}
+	return events, nil
+}
+
+func (r readSession) GetWebhookSubscriptionByID(id string) (dbmodel.WebhookSubscriptionDTO, dberr.Error) {
+	var subscription dbmodel.WebhookSubscriptionDTO
+
+	err := r.session.
+		Select("*").
+		From(WebhookSubscriptionsTableName).
+		Where(dbr.Eq("id", id)).
+		LoadOne(&subscription)
+
+	if err != nil {
+		if err == dbr.ErrNotFound {
+			return dbmodel.WebhookSubscriptionDTO{}, dberr.NotFound("Cannot find webhook subscription for id: '%s'", id)
+		}
+		return dbmodel.WebhookSubscriptionDTO{}, dberr.Internal("Failed to get webhook subscription: %s", err)
+	}
+	return subscription, nil
+}
+
+func (r readSession) ListWebhookSubscriptions() ([]dbmodel.WebhookSubscriptionDTO, dberr.Error) {
+	var subscriptions []dbmodel.WebhookSubscriptionDTO
+
+	_, err := r.session.
+		Select("*").
+		From(WebhookSubscriptionsTableName).
+		OrderBy(CreatedAtField).
+		Load(&subscriptions)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get webhook subscriptions: %s", err)
+	}
+	return subscriptions, nil
+}
+
+func (r readSession) ListWebhookDeliveriesBySubscriptionID(subscriptionID string) ([]dbmodel.WebhookDeliveryDTO, dberr.Error) {
+	var deliveries []dbmodel.WebhookDeliveryDTO
+
+	_, err := r.session.
+		Select("*").
+		From(WebhookDeliveriesTableName).
+		Where(dbr.Eq("subscription_id", subscriptionID)).
+		OrderBy(CreatedAtField).
+		Load(&deliveries)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get webhook deliveries: %s", err)
+	}
+	return deliveries, nil
+}
+
+func (r readSession) GetCLSInstanceByGlobalAccountID(globalAccountID string) (dbmodel.CLSInstanceDTO, dberr.Error) {
+	var dto dbmodel.CLSInstanceDTO
+	err := r.session.
+		Select("*").
+		From(CLSInstancesTableName).
+		Where(dbr.Eq("global_account_id", globalAccountID)).
+		LoadOne(&dto)
+
+	if err != nil {
+		if err == dbr.ErrNotFound {
+			return dbmodel.CLSInstanceDTO{}, dberr.NotFound("Cannot find cls instance for global account: '%s'", globalAccountID)
+		}
+		return dbmodel.CLSInstanceDTO{}, dberr.Internal("Failed to get cls instance: %s", err)
+	}
+	return dto, nil
+}
+
+func (r readSession) ListCLSInstanceReferences(clsInstanceID string) ([]dbmodel.CLSInstanceReferenceDTO, dberr.Error) {
+	var references []dbmodel.CLSInstanceReferenceDTO
+
+	_, err := r.session.
+		Select("*").
+		From(CLSInstanceReferencesTableName).
+		Where(dbr.Eq("cls_instance_id", clsInstanceID)).
+		OrderBy(CreatedAtField).
+		Load(&references)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get cls instance references: %s", err)
+	}
+	return references, nil
+}
+
+func (r readSession) GetInstanceArchiveByInstanceID(instanceID string) (dbmodel.InstanceArchiveDTO, dberr.Error) {
+	var archive dbmodel.InstanceArchiveDTO
+
+	err := r.session.
+		Select("*").
+		From(InstanceArchivesTableName).
+		Where(dbr.Eq("instance_id", instanceID)).
+		LoadOne(&archive)
+
+	if err != nil {
+		if err == dbr.ErrNotFound {
+			return dbmodel.InstanceArchiveDTO{}, dberr.NotFound("Cannot find instance archive for instance id: '%s'", instanceID)
+		}
+		return dbmodel.InstanceArchiveDTO{}, dberr.Internal("Failed to get instance archive: %s", err)
+	}
+	return archive, nil
+}
+
+func (r readSession) ListInstanceArchives(filter dbmodel.InstanceFilter, offset, limit int) ([]dbmodel.InstanceArchiveDTO, int, error) {
+	var archives []dbmodel.InstanceArchiveDTO
+
+	stmt := r.session.
+		Select("*").
+		From(InstanceArchivesTableName).
+		OrderBy(CreatedAtField).
+		Offset(uint64(offset)).
+		Limit(uint64(limit))
+
+	addInstanceFilters(stmt, filter)
+
+	_, err := stmt.Load(&archives)
+	if err != nil {
+		return nil, -1, errors.Wrap(err, "while fetching instance archives")
+	}
+
+	var res struct {
+		Total int
+	}
+	countStmt := r.session.Select("count(*) as total").From(InstanceArchivesTableName)
+	addInstanceFilters(countStmt, filter)
+	err = countStmt.LoadOne(&res)
+	if err != nil {
+		return nil, -1, errors.Wrap(err, "while counting instance archives")
+	}
+
+	return archives, res.Total, nil
+}
+
+func (r readSession) ListOperationIDsOfArchivedInstances(createdBefore time.Time, limit int) ([]string, dberr.Error) {
+	var ids []string
+
+	join := fmt.Sprintf("%s.instance_id = %s.instance_id", OperationTableName, InstanceArchivesTableName)
+	_, err := r.session.
+		Select(fmt.Sprintf("%s.id", OperationTableName)).
+		From(OperationTableName).
+		Join(InstanceArchivesTableName, join).
+		Where(fmt.Sprintf("%s.created_at < ?", OperationTableName), createdBefore).
+		Where(fmt.Sprintf("%s.state IN ?", OperationTableName), []string{
+			string(domain.Succeeded), string(domain.Failed), orchestration.Canceled,
+		}).
+		OrderBy(fmt.Sprintf("%s.created_at", OperationTableName)).
+		Limit(uint64(limit)).
+		Load(&ids)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get operations of archived instances: %s", err)
+	}
+	return ids, nil
+}
+
 func (r readSession) getOperation(condition dbr.Builder) (dbmodel.OperationDTO, dberr.Error) {
 	var operation dbmodel.OperationDTO
 
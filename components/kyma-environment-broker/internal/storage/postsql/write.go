@@ -1,8 +1,10 @@
 package postsql
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
 
 	"github.com/gocraft/dbr"
@@ -12,6 +14,10 @@ import (
 
 const (
 	UniqueViolationErrorCode = "23505"
+	// OrchestrationRetryOfIndexName is the unique index which allows only one unfinished retry of an orchestration
+	OrchestrationRetryOfIndexName = "orchestrations_retry_of_idx"
+	// OperationInProgressIndexName is the unique index which allows only one operation requested by the user to change an instance at a time
+	OperationInProgressIndexName = "operations_in_progress_idx"
 )
 
 type writeSession struct {
@@ -32,6 +38,9 @@ func (ws writeSession) InsertInstance(instance dbmodel.InstanceDTO) dberr.Error
 		Pair("dashboard_url", instance.DashboardURL).
 		Pair("provisioning_parameters", instance.ProvisioningParameters).
 		Pair("provider_region", instance.ProviderRegion).
+		Pair("plan_history", instance.PlanHistory).
+		Pair("expires_at", instance.ExpiresAt).
+		Pair("expiration_warned_at", instance.ExpirationWarnedAt).
 		// in postgres database it will be equal to "0001-01-01 00:00:00+00"
 		Pair("deleted_at", time.Time{}).
 		Pair("version", instance.Version).
@@ -69,9 +78,13 @@ func (ws writeSession) UpdateInstance(instance dbmodel.InstanceDTO) dberr.Error
 		Set("global_account_id", instance.GlobalAccountID).
 		Set("service_id", instance.ServiceID).
 		Set("service_plan_id", instance.ServicePlanID).
+		Set("service_plan_name", instance.ServicePlanName).
 		Set("dashboard_url", instance.DashboardURL).
 		Set("provisioning_parameters", instance.ProvisioningParameters).
 		Set("provider_region", instance.ProviderRegion).
+		Set("plan_history", instance.PlanHistory).
+		Set("expires_at", instance.ExpiresAt).
+		Set("expiration_warned_at", instance.ExpirationWarnedAt).
 		Set("updated_at", time.Now()).
 		Set("version", instance.Version+1).
 		Exec()
@@ -108,6 +121,9 @@ func (ws writeSession) InsertOperation(op dbmodel.OperationDTO) dberr.Error {
 
 	if err != nil {
 		if err, ok := err.(*pq.Error); ok {
+			if err.Code == UniqueViolationErrorCode && err.Constraint == OperationInProgressIndexName {
+				return dberr.Conflict("instance %s is being changed by another operation", op.InstanceID)
+			}
 			if err.Code == UniqueViolationErrorCode {
 				return dberr.AlreadyExists("operation with id %s already exist", op.ID)
 			}
@@ -125,11 +141,16 @@ func (ws writeSession) InsertOrchestration(o dbmodel.OrchestrationDTO) dberr.Err
 		Pair("updated_at", o.UpdatedAt).
 		Pair("description", o.Description).
 		Pair("state", o.State).
+		Pair("type", o.Type).
 		Pair("parameters", o.Parameters).
+		Pair("retry_of", o.RetryOf).
 		Exec()
 
 	if err != nil {
 		if err, ok := err.(*pq.Error); ok {
+			if err.Code == UniqueViolationErrorCode && err.Constraint == OrchestrationRetryOfIndexName {
+				return dberr.AlreadyExists("Retry of orchestration %s is already in progress", o.RetryOf.String)
+			}
 			if err.Code == UniqueViolationErrorCode {
 				return dberr.AlreadyExists("Orchestration with id %s already exist", o.OrchestrationID)
 			}
@@ -212,6 +233,344 @@ func (ws writeSession) InsertLMSTenant(dto dbmodel.LMSTenantDTO) dberr.Error {
 	return nil
 }
 
+func (ws writeSession) InsertBinding(binding dbmodel.BindingDTO) dberr.Error {
+	_, err := ws.insertInto(BindingsTableName).
+		Pair("binding_id", binding.BindingID).
+		Pair("instance_id", binding.InstanceID).
+		Pair("operation", binding.Operation).
+		Pair("state", binding.State).
+		Pair("description", binding.Description).
+		Pair("kubeconfig", binding.Kubeconfig).
+		Pair("created_at", binding.CreatedAt).
+		Pair("updated_at", binding.UpdatedAt).
+		Exec()
+
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == UniqueViolationErrorCode {
+				return dberr.AlreadyExists("binding with id %s already exist", binding.BindingID)
+			}
+		}
+		return dberr.Internal("Failed to insert record to bindings table: %s", err)
+	}
+
+	return nil
+}
+
+func (ws writeSession) UpdateBinding(binding dbmodel.BindingDTO) dberr.Error {
+	res, err := ws.update(BindingsTableName).
+		Where(dbr.Eq("binding_id", binding.BindingID)).
+		Set("operation", binding.Operation).
+		Set("state", binding.State).
+		Set("description", binding.Description).
+		Set("kubeconfig", binding.Kubeconfig).
+		Set("updated_at", binding.UpdatedAt).
+		Exec()
+
+	if err != nil {
+		return dberr.Internal("Failed to update record to bindings table: %s", err)
+	}
+	rAffected, e := res.RowsAffected()
+	if e != nil {
+		return dberr.Internal("the DB driver does not support RowsAffected operation")
+	}
+	if rAffected == int64(0) {
+		return dberr.NotFound("Cannot find binding with ID:'%s'", binding.BindingID)
+	}
+
+	return nil
+}
+
+func (ws writeSession) DeleteBinding(bindingID string) dberr.Error {
+	_, err := ws.deleteFrom(BindingsTableName).
+		Where(dbr.Eq("binding_id", bindingID)).
+		Exec()
+
+	if err != nil {
+		return dberr.Internal("Failed to delete record from bindings table: %s", err)
+	}
+	return nil
+}
+
+func (ws writeSession) InsertOperationEvent(event dbmodel.OperationEventDTO) dberr.Error {
+	_, err := ws.insertInto(OperationEventsTableName).
+		Pair("id", event.ID).
+		Pair("operation_id", event.OperationID).
+		Pair("instance_id", event.InstanceID).
+		Pair("step_name", event.StepName).
+		Pair("started_at", event.StartedAt).
+		Pair("finished_at", event.FinishedAt).
+		Pair("duration", event.Duration).
+		Pair("requeue_delay", event.RequeueDelay).
+		Pair("error", event.Error).
+		Pair("old_state", event.OldState).
+		Pair("new_state", event.NewState).
+		Pair("description", event.Description).
+		Exec()
+
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == UniqueViolationErrorCode {
+				return dberr.AlreadyExists("operation event with id %s already exist", event.ID)
+			}
+		}
+		return dberr.Internal("Failed to insert record to operation events table: %s", err)
+	}
+
+	return nil
+}
+
+func (ws writeSession) InsertOutboxEvent(event dbmodel.OutboxEventDTO) dberr.Error {
+	_, err := ws.insertInto(OutboxEventsTableName).
+		Pair("id", event.ID).
+		Pair("type", event.Type).
+		Pair("payload", event.Payload).
+		Pair("subscriber", event.Subscriber).
+		Pair("state", event.State).
+		Pair("attempts", event.Attempts).
+		Pair("last_error", event.LastError).
+		Pair("created_at", event.CreatedAt).
+		Pair("next_attempt_at", event.NextAttemptAt).
+		Exec()
+
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == UniqueViolationErrorCode {
+				return dberr.AlreadyExists("outbox event with id %s already exist", event.ID)
+			}
+		}
+		return dberr.Internal("Failed to insert record to outbox events table: %s", err)
+	}
+
+	return nil
+}
+
+func (ws writeSession) UpdateOutboxEvent(event dbmodel.OutboxEventDTO) dberr.Error {
+	res, err := ws.update(OutboxEventsTableName).
+		Where(dbr.Eq("id", event.ID)).
+		Set("state", event.State).
+		Set("attempts", event.Attempts).
+		Set("last_error", event.LastError).
+		Set("next_attempt_at", event.NextAttemptAt).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to update record in outbox events table: %s", err)
+	}
+	rAffected, e := res.RowsAffected()
+	if e != nil {
+		return dberr.Internal("the DB driver does not support RowsAffected operation")
+	}
+	if rAffected == int64(0) {
+		return dberr.NotFound("Cannot find outbox event with ID:'%s'", event.ID)
+	}
+
+	return nil
+}
+
+func (ws writeSession) DeleteOutboxEvent(id string) dberr.Error {
+	_, err := ws.deleteFrom(OutboxEventsTableName).
+		Where(dbr.Eq("id", id)).
+		Exec()
+
+	if err != nil {
+		return dberr.Internal("Failed to delete record from outbox events table: %s", err)
+	}
+	return nil
+}
+
+// ClaimPendingOutboxEvents moves the next attempt of the pending events of the subscriber which are due to leaseUntil
+// and returns them. The rows locked by a concurrent claim are skipped, so the event is claimed by only one of them.
+func (ws writeSession) ClaimPendingOutboxEvents(subscriber string, until, leaseUntil time.Time, limit int) ([]dbmodel.OutboxEventDTO, dberr.Error) {
+	var events []dbmodel.OutboxEventDTO
+
+	query := fmt.Sprintf(`UPDATE %[1]s SET next_attempt_at = ? WHERE id IN (
+		SELECT id FROM %[1]s WHERE subscriber = ? AND state = ? AND next_attempt_at <= ?
+		ORDER BY %[2]s LIMIT ? FOR UPDATE SKIP LOCKED) RETURNING *`, OutboxEventsTableName, CreatedAtField)
+	_, err := ws.selectBySql(query, leaseUntil, subscriber, string(internal.OutboxEventPending), until, limit).Load(&events)
+	if err != nil {
+		return nil, dberr.Internal("Failed to claim pending outbox events: %s", err)
+	}
+	return events, nil
+}
+
+func (ws writeSession) InsertWebhookSubscription(subscription dbmodel.WebhookSubscriptionDTO) dberr.Error {
+	_, err := ws.insertInto(WebhookSubscriptionsTableName).
+		Pair("id", subscription.ID).
+		Pair("url", subscription.URL).
+		Pair("secret", subscription.Secret).
+		Pair("filter", subscription.Filter).
+		Pair("created_at", subscription.CreatedAt).
+		Exec()
+
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == UniqueViolationErrorCode {
+				return dberr.AlreadyExists("webhook subscription with id %s already exist", subscription.ID)
+			}
+		}
+		return dberr.Internal("Failed to insert record to webhook subscriptions table: %s", err)
+	}
+
+	return nil
+}
+
+func (ws writeSession) DeleteWebhookSubscription(id string) dberr.Error {
+	_, err := ws.deleteFrom(WebhookSubscriptionsTableName).
+		Where(dbr.Eq("id", id)).
+		Exec()
+
+	if err != nil {
+		return dberr.Internal("Failed to delete record from webhook subscriptions table: %s", err)
+	}
+	return nil
+}
+
+func (ws writeSession) InsertWebhookDelivery(delivery dbmodel.WebhookDeliveryDTO) dberr.Error {
+	_, err := ws.insertInto(WebhookDeliveriesTableName).
+		Pair("id", delivery.ID).
+		Pair("subscription_id", delivery.SubscriptionID).
+		Pair("notification_id", delivery.NotificationID).
+		Pair("event_type", delivery.EventType).
+		Pair("status_code", delivery.StatusCode).
+		Pair("error", delivery.Error).
+		Pair("created_at", delivery.CreatedAt).
+		Exec()
+
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == UniqueViolationErrorCode {
+				return dberr.AlreadyExists("webhook delivery with id %s already exist", delivery.ID)
+			}
+		}
+		return dberr.Internal("Failed to insert record to webhook deliveries table: %s", err)
+	}
+
+	return nil
+}
+
+func (ws writeSession) InsertCLSInstance(instance dbmodel.CLSInstanceDTO) dberr.Error {
+	_, err := ws.insertInto(CLSInstancesTableName).
+		Pair("id", instance.ID).
+		Pair("version", instance.Version).
+		Pair("global_account_id", instance.GlobalAccountID).
+		Pair("region", instance.Region).
+		Pair("created_at", instance.CreatedAt).
+		Exec()
+
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == UniqueViolationErrorCode {
+				return dberr.AlreadyExists("cls instance for global account %s already exist", instance.GlobalAccountID)
+			}
+		}
+		return dberr.Internal("Failed to insert record to cls instances table: %s", err)
+	}
+
+	return nil
+}
+
+func (ws writeSession) UpdateCLSInstance(instance dbmodel.CLSInstanceDTO) dberr.Error {
+	res, err := ws.update(CLSInstancesTableName).
+		Where(dbr.Eq("id", instance.ID)).
+		Where(dbr.Eq("version", instance.Version)).
+		Set("version", instance.Version+1).
+		Set("region", instance.Region).
+		Exec()
+
+	if err != nil {
+		return dberr.Internal("Failed to update record to cls instances table: %s", err)
+	}
+	rAffected, e := res.RowsAffected()
+	if e != nil {
+		// the optimistic locking requires numbers of rows affected
+		return dberr.Internal("the DB driver does not support RowsAffected operation")
+	}
+	if rAffected == int64(0) {
+		return dberr.NotFound("Cannot find cls instance with ID:'%s' Version: %v", instance.ID, instance.Version)
+	}
+
+	return nil
+}
+
+func (ws writeSession) InsertCLSInstanceReference(reference dbmodel.CLSInstanceReferenceDTO) dberr.Error {
+	_, err := ws.insertInto(CLSInstanceReferencesTableName).
+		Pair("cls_instance_id", reference.CLSInstanceID).
+		Pair("skr_instance_id", reference.SKRInstanceID).
+		Pair("created_at", reference.CreatedAt).
+		Exec()
+
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == UniqueViolationErrorCode {
+				return dberr.AlreadyExists("cls instance %s is already referenced by %s", reference.CLSInstanceID, reference.SKRInstanceID)
+			}
+		}
+		return dberr.Internal("Failed to insert record to cls instance references table: %s", err)
+	}
+
+	return nil
+}
+
+func (ws writeSession) InsertInstanceArchive(archive dbmodel.InstanceArchiveDTO) dberr.Error {
+	_, err := ws.insertInto(InstanceArchivesTableName).
+		Pair("instance_id", archive.InstanceID).
+		Pair("runtime_id", archive.RuntimeID).
+		Pair("global_account_id", archive.GlobalAccountID).
+		Pair("sub_account_id", archive.SubAccountID).
+		Pair("service_plan_id", archive.ServicePlanID).
+		Pair("service_plan_name", archive.ServicePlanName).
+		Pair("provider_region", archive.ProviderRegion).
+		Pair("dashboard_url", archive.DashboardURL).
+		Pair("data", archive.Data).
+		Pair("created_at", archive.CreatedAt).
+		Pair("archived_at", archive.ArchivedAt).
+		Exec()
+
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == UniqueViolationErrorCode {
+				return dberr.AlreadyExists("instance archive with instance id %s already exist", archive.InstanceID)
+			}
+		}
+		return dberr.Internal("Failed to insert record to instance archives table: %s", err)
+	}
+
+	return nil
+}
+
+func (ws writeSession) DeleteOperations(operationIDs []string) dberr.Error {
+	_, err := ws.deleteFrom(OperationTableName).
+		Where(dbr.Eq("id", operationIDs)).
+		Exec()
+
+	if err != nil {
+		return dberr.Internal("Failed to delete records from operations table: %s", err)
+	}
+	return nil
+}
+
+func (ws writeSession) DeleteRuntimeStatesByOperationIDs(operationIDs []string) dberr.Error {
+	_, err := ws.deleteFrom(RuntimeStateTableName).
+		Where(dbr.Eq("operation_id", operationIDs)).
+		Exec()
+
+	if err != nil {
+		return dberr.Internal("Failed to delete records from runtime states table: %s", err)
+	}
+	return nil
+}
+
+func (ws writeSession) DeleteOperationEventsByOperationIDs(operationIDs []string) dberr.Error {
+	_, err := ws.deleteFrom(OperationEventsTableName).
+		Where(dbr.Eq("operation_id", operationIDs)).
+		Exec()
+
+	if err != nil {
+		return dberr.Internal("Failed to delete records from operation events table: %s", err)
+	}
+	return nil
+}
+
 func (ws writeSession) UpdateOperation(op dbmodel.OperationDTO) dberr.Error {
 	res, err := ws.update(OperationTableName).
 		Where(dbr.Eq("id", op.ID)).
@@ -247,6 +606,32 @@ func (ws writeSession) UpdateOperation(op dbmodel.OperationDTO) dberr.Error {
 	return nil
 }
 
+// LockGlobalAccount acquires the advisory lock of the global account, the lock is released when the transaction ends.
+// It can be used only within the transaction.
+func (ws writeSession) LockGlobalAccount(globalAccountID string) dberr.Error {
+	_, err := ws.transaction.Exec("SELECT pg_advisory_xact_lock(hashtext($1))", globalAccountID)
+	if err != nil {
+		return dberr.Internal("Failed to lock global account %s: %s", globalAccountID, err)
+	}
+
+	return nil
+}
+
+// ListInstancesByGlobalAccountID reads the instances of the global account within the transaction
+func (ws writeSession) ListInstancesByGlobalAccountID(globalAccountID string) ([]dbmodel.InstanceDTO, dberr.Error) {
+	var instances []dbmodel.InstanceDTO
+	_, err := ws.transaction.
+		Select("*").
+		From(InstancesTableName).
+		Where(dbr.Eq("global_account_id", globalAccountID)).
+		Load(&instances)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get instances of global account %s: %s", globalAccountID, err)
+	}
+
+	return instances, nil
+}
+
 func (ws writeSession) Commit() dberr.Error {
 	err := ws.transaction.Commit()
 	if err != nil {
@@ -276,6 +661,14 @@ func (ws writeSession) deleteFrom(table string) *dbr.DeleteStmt {
 	return ws.session.DeleteFrom(table)
 }
 
+func (ws writeSession) selectBySql(query string, value ...interface{}) *dbr.SelectStmt {
+	if ws.transaction != nil {
+		return ws.transaction.SelectBySql(query, value...)
+	}
+
+	return ws.session.SelectBySql(query, value...)
+}
+
 func (ws writeSession) update(table string) *dbr.UpdateStmt {
 	if ws.transaction != nil {
 		return ws.transaction.Update(table)
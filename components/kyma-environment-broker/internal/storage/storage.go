@@ -17,6 +17,12 @@ type BrokerStorage interface {
 	Orchestrations() Orchestrations
 	RuntimeStates() RuntimeStates
 	CLSInstances() CLSInstances
+	Bindings() Bindings
+	OperationEvents() OperationEvents
+	OutboxEvents() OutboxEvents
+	WebhookSubscriptions() WebhookSubscriptions
+	WebhookDeliveries() WebhookDeliveries
+	InstanceArchives() InstanceArchives
 }
 
 const (
@@ -40,33 +46,53 @@ func NewFromConfig(cfg Config, cipher postgres.Cipher, log logrus.FieldLogger) (
 
 	operation := postgres.NewOperation(fact, cipher)
 	return storage{
-		instance:       postgres.NewInstance(fact, operation, cipher),
-		operation:      operation,
-		lmsTenants:     postgres.NewLMSTenants(fact),
-		orchestrations: postgres.NewOrchestrations(fact),
-		runtimeStates:  postgres.NewRuntimeStates(fact, cipher),
+		instance:             postgres.NewInstance(fact, operation, cipher),
+		operation:            operation,
+		lmsTenants:           postgres.NewLMSTenants(fact),
+		clsInstances:         postgres.NewCLSInstances(fact),
+		orchestrations:       postgres.NewOrchestrations(fact, operation),
+		runtimeStates:        postgres.NewRuntimeStates(fact, cipher),
+		bindings:             postgres.NewBindings(fact, cipher),
+		operationEvents:      postgres.NewOperationEvents(fact),
+		outboxEvents:         postgres.NewOutboxEvents(fact, cipher),
+		webhookSubscriptions: postgres.NewWebhookSubscriptions(fact, cipher),
+		webhookDeliveries:    postgres.NewWebhookDeliveries(fact),
+		instanceArchives:     postgres.NewInstanceArchives(fact, operation),
 	}, connection, nil
 }
 
 func NewMemoryStorage() BrokerStorage {
-	op := memory.NewOperation()
+	outboxEvents := memory.NewOutboxEvents()
+	op := memory.NewOperationWithOutbox(outboxEvents)
 	return storage{
-		operation:      op,
-		instance:       memory.NewInstance(op),
-		lmsTenants:     memory.NewLMSTenants(),
-		orchestrations: memory.NewOrchestrations(),
-		runtimeStates:  memory.NewRuntimeStates(),
-		clsInstances:   memory.NewCLSInstances(),
+		operation:            op,
+		instance:             memory.NewInstance(op),
+		lmsTenants:           memory.NewLMSTenants(),
+		orchestrations:       memory.NewOrchestrations(op),
+		runtimeStates:        memory.NewRuntimeStates(),
+		clsInstances:         memory.NewCLSInstances(),
+		bindings:             memory.NewBindings(),
+		operationEvents:      memory.NewOperationEvents(),
+		outboxEvents:         outboxEvents,
+		webhookSubscriptions: memory.NewWebhookSubscriptions(),
+		webhookDeliveries:    memory.NewWebhookDeliveries(),
+		instanceArchives:     memory.NewInstanceArchives(op),
 	}
 }
 
 type storage struct {
-	instance       Instances
-	operation      Operations
-	lmsTenants     LMSTenants
-	orchestrations Orchestrations
-	runtimeStates  RuntimeStates
-	clsInstances   CLSInstances
+	instance             Instances
+	operation            Operations
+	lmsTenants           LMSTenants
+	orchestrations       Orchestrations
+	runtimeStates        RuntimeStates
+	clsInstances         CLSInstances
+	bindings             Bindings
+	operationEvents      OperationEvents
+	outboxEvents         OutboxEvents
+	webhookSubscriptions WebhookSubscriptions
+	webhookDeliveries    WebhookDeliveries
+	instanceArchives     InstanceArchives
 }
 
 func (s storage) Instances() Instances {
@@ -100,3 +126,27 @@ func (s storage) Orchestrations() Orchestrations {
 func (s storage) RuntimeStates() RuntimeStates {
 	return s.runtimeStates
 }
+
+func (s storage) Bindings() Bindings {
+	return s.bindings
+}
+
+func (s storage) OperationEvents() OperationEvents {
+	return s.operationEvents
+}
+
+func (s storage) OutboxEvents() OutboxEvents {
+	return s.outboxEvents
+}
+
+func (s storage) WebhookSubscriptions() WebhookSubscriptions {
+	return s.webhookSubscriptions
+}
+
+func (s storage) WebhookDeliveries() WebhookDeliveries {
+	return s.webhookDeliveries
+}
+
+func (s storage) InstanceArchives() InstanceArchives {
+	return s.instanceArchives
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
@@ -21,6 +22,7 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 
 	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -99,6 +101,14 @@ func TestPostgres(t *testing.T) {
 			require.NoError(t, err)
 
 			fixInstance.DashboardURL = "diff"
+			fixInstance.PlanHistory = []internal.PlanChange{
+				{
+					FromPlanID:  "trial-plan-id",
+					ToPlanID:    fixInstance.ServicePlanID,
+					OperationID: "update-op-id",
+				},
+			}
+			fixInstance.ExpiresAt = time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
 			_, err = brokerStorage.Instances().Update(*fixInstance)
 			require.NoError(t, err)
 
@@ -167,6 +177,11 @@ func TestPostgres(t *testing.T) {
 			assert.Equal(t, fixInstance.DashboardURL, inst.DashboardURL)
 			assert.Equal(t, fixInstance.Parameters, inst.Parameters)
 			assert.Equal(t, "lms-tenant-id", inst.InstanceDetails.Lms.TenantID)
+			require.Len(t, inst.PlanHistory, 1)
+			assert.Equal(t, "trial-plan-id", inst.PlanHistory[0].FromPlanID)
+			assert.Equal(t, "update-op-id", inst.PlanHistory[0].OperationID)
+			assert.True(t, fixInstance.ExpiresAt.Equal(inst.ExpiresAt))
+			assert.True(t, inst.ExpirationWarnedAt.IsZero())
 			assert.NotEmpty(t, inst.CreatedAt)
 			assert.NotEmpty(t, inst.UpdatedAt)
 			assert.Equal(t, "0001-01-01 00:00:00 +0000 UTC", inst.DeletedAt.String())
@@ -325,6 +340,49 @@ func TestPostgres(t *testing.T) {
 			require.Contains(t, []string{"1", "3", "4"}, out[1].InstanceID)
 			require.Contains(t, []string{"1", "3", "4"}, out[2].InstanceID)
 		})
+		t.Run("Should insert instance only when the instances of the global account are accepted", func(t *testing.T) {
+			// given
+			containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
+			require.NoError(t, err)
+			defer containerCleanupFunc()
+
+			err = storage.InitTestDBTables(t, cfg.ConnectionURL())
+			require.NoError(t, err)
+
+			cipher := storage.NewEncrypter(cfg.SecretKey)
+			psqlStorage, _, err := storage.NewFromConfig(cfg, cipher, logrus.StandardLogger())
+			require.NoError(t, err)
+			require.NotNil(t, psqlStorage)
+
+			err = psqlStorage.Instances().Insert(*fixInstance(instanceData{val: "1", globalAccountID: "ga1"}))
+			require.NoError(t, err)
+			err = psqlStorage.Instances().Insert(*fixInstance(instanceData{val: "2", globalAccountID: "ga2"}))
+			require.NoError(t, err)
+
+			// when
+			var checked []internal.Instance
+			err = psqlStorage.Instances().InsertChecked(*fixInstance(instanceData{val: "3", globalAccountID: "ga1"}), func(instances []internal.Instance) error {
+				checked = instances
+				return nil
+			})
+
+			// then
+			require.NoError(t, err)
+			require.Len(t, checked, 1)
+			assert.Equal(t, "1", checked[0].InstanceID)
+			_, err = psqlStorage.Instances().GetByID("3")
+			require.NoError(t, err)
+
+			// when
+			err = psqlStorage.Instances().InsertChecked(*fixInstance(instanceData{val: "4", globalAccountID: "ga1"}), func(instances []internal.Instance) error {
+				return errors.New("quota exceeded")
+			})
+
+			// then
+			require.EqualError(t, err, "quota exceeded")
+			_, err = psqlStorage.Instances().GetByID("4")
+			assert.True(t, dberr.IsNotFound(err))
+		})
 		t.Run("should list instances based on page and page size", func(t *testing.T) {
 			// given
 			containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
@@ -750,6 +808,59 @@ func TestPostgres(t *testing.T) {
 			assert.Equal(t, count, 3)
 			assert.Equal(t, totalCount, 3)
 		})
+		t.Run("Updating", func(t *testing.T) {
+			containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
+			require.NoError(t, err)
+			defer containerCleanupFunc()
+
+			givenOperation := internal.UpdatingOperation{
+				Operation: internal.Operation{
+					ID:    "operation-id",
+					State: domain.InProgress,
+					// used Round and set timezone to be able to compare timestamps
+					CreatedAt:   time.Now().Truncate(time.Millisecond),
+					UpdatedAt:   time.Now().Truncate(time.Millisecond).Add(time.Second),
+					InstanceID:  fixInstanceId,
+					Description: "description",
+				},
+				UpdatingParameters: internal.UpdatingParametersDTO{
+					MachineType:   ptr.String("Standard_D8_v3"),
+					AutoScalerMax: ptr.Integer(20),
+				},
+			}
+
+			err = storage.InitTestDBTables(t, cfg.ConnectionURL())
+			require.NoError(t, err)
+
+			cipher := storage.NewEncrypter(cfg.SecretKey)
+			brokerStorage, _, err := storage.NewFromConfig(cfg, cipher, logrus.StandardLogger())
+			require.NoError(t, err)
+
+			svc := brokerStorage.Operations()
+
+			// when
+			err = svc.InsertUpdatingOperation(givenOperation)
+			require.NoError(t, err)
+
+			op, err := svc.GetUpdatingOperationByID(givenOperation.Operation.ID)
+			require.NoError(t, err)
+			op.ProvisionerOperationID = "target-op-id"
+			op.State = domain.Succeeded
+			_, err = svc.UpdateUpdatingOperation(*op)
+			require.NoError(t, err)
+
+			// then
+			ops, err := svc.ListUpdatingOperationsByInstanceID(fixInstanceId)
+			require.NoError(t, err)
+			require.Len(t, ops, 1)
+			assert.Equal(t, domain.Succeeded, ops[0].State)
+			assert.Equal(t, "target-op-id", ops[0].ProvisionerOperationID)
+			assert.Equal(t, givenOperation.UpdatingParameters, ops[0].UpdatingParameters)
+
+			notFinished, err := svc.GetNotFinishedOperationsByType(dbmodel.OperationTypeUpdate)
+			require.NoError(t, err)
+			assert.Empty(t, notFinished)
+		})
 	})
 	t.Run("Operations conflicts", func(t *testing.T) {
 		t.Run("Provisioning", func(t *testing.T) {
@@ -864,6 +975,65 @@ func TestPostgres(t *testing.T) {
 			// then
 			assertError(t, dberr.CodeAlreadyExists, err)
 		})
+		t.Run("Updating", func(t *testing.T) {
+			containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
+			require.NoError(t, err)
+			defer containerCleanupFunc()
+
+			givenUpdate := internal.UpdatingOperation{
+				Operation: internal.Operation{
+					ID:         "update-001",
+					State:      domain.InProgress,
+					CreatedAt:  time.Now(),
+					UpdatedAt:  time.Now(),
+					InstanceID: fixInstanceId,
+				},
+			}
+			givenUpgrade := internal.UpgradeKymaOperation{
+				Operation: internal.Operation{
+					ID:         "upgrade-001",
+					State:      orchestration.Pending,
+					CreatedAt:  time.Now(),
+					UpdatedAt:  time.Now(),
+					InstanceID: fixInstanceId,
+				},
+			}
+
+			err = storage.InitTestDBTables(t, cfg.ConnectionURL())
+			require.NoError(t, err)
+
+			cipher := storage.NewEncrypter(cfg.SecretKey)
+			brokerStorage, _, err := storage.NewFromConfig(cfg, cipher, logrus.StandardLogger())
+			require.NoError(t, err)
+
+			svc := brokerStorage.Operations()
+
+			err = svc.InsertUpdatingOperation(givenUpdate)
+			require.NoError(t, err)
+
+			// when
+			secondUpdate := givenUpdate
+			secondUpdate.ID = "update-002"
+			err = svc.InsertUpdatingOperation(secondUpdate)
+
+			// then
+			assertError(t, dberr.CodeConflict, err)
+
+			// when
+			err = svc.InsertUpgradeKymaOperation(givenUpgrade)
+
+			// then
+			assertError(t, dberr.CodeConflict, err)
+
+			// when
+			givenUpdate.State = domain.Succeeded
+			_, err = svc.UpdateUpdatingOperation(givenUpdate)
+			require.NoError(t, err)
+			err = svc.InsertUpgradeKymaOperation(givenUpgrade)
+
+			// then
+			require.NoError(t, err)
+		})
 	})
 	t.Run("Conflict Instances", func(t *testing.T) {
 		containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
@@ -966,6 +1136,30 @@ func TestPostgres(t *testing.T) {
 		l, err = svc.ListByState("test")
 		require.NoError(t, err)
 		assert.Len(t, l, 1)
+
+		// only one unfinished retry of the orchestration can be stored together with its operations
+		retry := givenOrchestration
+		retry.OrchestrationID = "retry-1"
+		retry.State = orchestration.InProgress
+		retry.RetryOf = fixID
+		err = svc.InsertWithUpgradeKymaOperations(retry, []internal.UpgradeKymaOperation{{
+			Operation: internal.Operation{ID: "retry-op-1", InstanceID: fixID, OrchestrationID: retry.OrchestrationID, CreatedAt: now, UpdatedAt: now},
+		}})
+		require.NoError(t, err)
+		gotRetry, err := svc.GetByID(retry.OrchestrationID)
+		require.NoError(t, err)
+		assert.Equal(t, fixID, gotRetry.RetryOf)
+		_, err = brokerStorage.Operations().GetUpgradeKymaOperationByID("retry-op-1")
+		require.NoError(t, err)
+
+		duplicate := retry
+		duplicate.OrchestrationID = "retry-2"
+		err = svc.InsertWithUpgradeKymaOperations(duplicate, []internal.UpgradeKymaOperation{{
+			Operation: internal.Operation{ID: "retry-op-2", InstanceID: fixID, OrchestrationID: duplicate.OrchestrationID, CreatedAt: now, UpdatedAt: now},
+		}})
+		assertError(t, dberr.CodeAlreadyExists, err)
+		_, err = brokerStorage.Operations().GetUpgradeKymaOperationByID("retry-op-2")
+		assert.True(t, dberr.IsNotFound(err))
 	})
 	t.Run("RuntimeStates", func(t *testing.T) {
 		containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
@@ -1009,6 +1203,429 @@ func TestPostgres(t *testing.T) {
 		assert.Equal(t, fixID, state.KymaConfig.Version)
 		assert.Equal(t, fixID, state.ClusterConfig.KubernetesVersion)
 	})
+	t.Run("Bindings", func(t *testing.T) {
+		containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
+		require.NoError(t, err)
+		defer containerCleanupFunc()
+
+		err = storage.InitTestDBTables(t, cfg.ConnectionURL())
+		require.NoError(t, err)
+
+		cipher := storage.NewEncrypter(cfg.SecretKey)
+		brokerStorage, _, err := storage.NewFromConfig(cfg, cipher, logrus.StandardLogger())
+		require.NoError(t, err)
+
+		svc := brokerStorage.Bindings()
+
+		givenBinding := internal.Binding{
+			BindingID:  "binding-id",
+			InstanceID: fixInstanceId,
+			Operation:  internal.BindingOperationBind,
+			State:      domain.InProgress,
+			CreatedAt:  time.Now().Truncate(time.Millisecond),
+			UpdatedAt:  time.Now().Truncate(time.Millisecond),
+		}
+
+		// when
+		err = svc.Insert(givenBinding)
+		require.NoError(t, err)
+		err = svc.Insert(givenBinding)
+		assert.Error(t, err)
+
+		givenBinding.State = domain.Succeeded
+		givenBinding.Kubeconfig = "kubeconfig"
+		err = svc.Update(givenBinding)
+		require.NoError(t, err)
+
+		// then
+		binding, err := svc.GetByID(givenBinding.BindingID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.Succeeded, binding.State)
+		assert.Equal(t, "kubeconfig", binding.Kubeconfig)
+
+		bindings, err := svc.ListByInstanceID(fixInstanceId)
+		require.NoError(t, err)
+		assert.Len(t, bindings, 1)
+
+		bindings, err = svc.ListByState(domain.InProgress)
+		require.NoError(t, err)
+		assert.Len(t, bindings, 0)
+
+		// when
+		err = svc.Delete(givenBinding.BindingID)
+		require.NoError(t, err)
+
+		// then
+		_, err = svc.GetByID(givenBinding.BindingID)
+		assert.True(t, dberr.IsNotFound(err))
+	})
+	t.Run("Operation Events", func(t *testing.T) {
+		containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
+		require.NoError(t, err)
+		defer containerCleanupFunc()
+
+		err = storage.InitTestDBTables(t, cfg.ConnectionURL())
+		require.NoError(t, err)
+
+		cipher := storage.NewEncrypter(cfg.SecretKey)
+		brokerStorage, _, err := storage.NewFromConfig(cfg, cipher, logrus.StandardLogger())
+		require.NoError(t, err)
+
+		svc := brokerStorage.OperationEvents()
+
+		operationID := "operation-id"
+		startedAt := time.Now().Truncate(time.Millisecond)
+		second := internal.OperationEvent{
+			ID:           "second-event-id",
+			OperationID:  operationID,
+			InstanceID:   fixInstanceId,
+			StepName:     "Provision_Runtime",
+			StartedAt:    startedAt.Add(time.Minute),
+			FinishedAt:   startedAt.Add(time.Minute + time.Second),
+			Duration:     time.Second,
+			RequeueDelay: 5 * time.Minute,
+			Error:        "provisioner not ready",
+			OldState:     domain.InProgress,
+			NewState:     domain.InProgress,
+			Description:  "waiting for the runtime",
+		}
+		first := internal.OperationEvent{
+			ID:          "first-event-id",
+			OperationID: operationID,
+			InstanceID:  fixInstanceId,
+			StepName:    "Create_Runtime",
+			StartedAt:   startedAt,
+			FinishedAt:  startedAt.Add(time.Second),
+			Duration:    time.Second,
+			OldState:    orchestration.Pending,
+			NewState:    domain.InProgress,
+		}
+		other := first
+		other.ID = "other-event-id"
+		other.OperationID = "other-operation-id"
+
+		// when
+		for _, event := range []internal.OperationEvent{second, first, other} {
+			err = svc.Insert(event)
+			require.NoError(t, err)
+		}
+		err = svc.Insert(first)
+
+		// then
+		assert.Error(t, err)
+		events, err := svc.ListByOperationID(operationID)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, first.ID, events[0].ID)
+		assert.Equal(t, second.StepName, events[1].StepName)
+		assert.Equal(t, second.RequeueDelay, events[1].RequeueDelay)
+		assert.Equal(t, second.Error, events[1].Error)
+		assert.True(t, second.FinishedAt.Equal(events[1].FinishedAt))
+	})
+	t.Run("Outbox Events", func(t *testing.T) {
+		containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
+		require.NoError(t, err)
+		defer containerCleanupFunc()
+
+		err = storage.InitTestDBTables(t, cfg.ConnectionURL())
+		require.NoError(t, err)
+
+		cipher := storage.NewEncrypter(cfg.SecretKey)
+		brokerStorage, _, err := storage.NewFromConfig(cfg, cipher, logrus.StandardLogger())
+		require.NoError(t, err)
+
+		svc := brokerStorage.OutboxEvents()
+
+		now := time.Now().Truncate(time.Millisecond)
+		first := internal.OutboxEvent{
+			ID:            "first-event-id",
+			Type:          "process.ProvisioningStepProcessed",
+			Payload:       []byte("payload"),
+			Subscriber:    "operation-log-recorder",
+			State:         internal.OutboxEventPending,
+			CreatedAt:     now.Add(-time.Minute),
+			NextAttemptAt: now.Add(-time.Minute),
+		}
+		second := first
+		second.ID = "second-event-id"
+		second.CreatedAt = now
+		second.NextAttemptAt = now
+		delayed := first
+		delayed.ID = "delayed-event-id"
+		delayed.NextAttemptAt = now.Add(time.Hour)
+		dead := first
+		dead.ID = "dead-event-id"
+		dead.State = internal.OutboxEventDead
+
+		// when
+		for _, event := range []internal.OutboxEvent{second, first, delayed, dead} {
+			err = svc.Insert(event)
+			require.NoError(t, err)
+		}
+		err = svc.Insert(first)
+
+		// then
+		assert.Error(t, err)
+		events, err := svc.ClaimPending(first.Subscriber, now, now.Add(time.Hour), 1)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, first.ID, events[0].ID)
+		assert.Equal(t, first.Payload, events[0].Payload)
+		assert.Equal(t, first.Subscriber, events[0].Subscriber)
+
+		events, err = svc.ClaimPending(first.Subscriber, now, now.Add(time.Hour), 10)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, second.ID, events[0].ID)
+
+		events, err = svc.ClaimPending("webhook-sender", now, now.Add(time.Hour), 10)
+		require.NoError(t, err)
+		assert.Len(t, events, 0)
+
+		// when
+		first.Attempts = 1
+		first.LastError = "handler failed"
+		first.NextAttemptAt = now
+		err = svc.Update(first)
+		require.NoError(t, err)
+		err = svc.Delete(second.ID)
+		require.NoError(t, err)
+
+		// then
+		events, err = svc.ClaimPending(first.Subscriber, now, now.Add(time.Hour), 10)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, first.ID, events[0].ID)
+		assert.Equal(t, 1, events[0].Attempts)
+		assert.Equal(t, "handler failed", events[0].LastError)
+
+		err = svc.Update(second)
+		assert.True(t, dberr.IsNotFound(err))
+
+		// when
+		operation := fixture.FixProvisioningOperation("operation-id", "instance-id")
+		err = brokerStorage.Operations().InsertProvisioningOperation(operation)
+		require.NoError(t, err)
+		withOperation := second
+		withOperation.ID = "operation-event-id"
+		operation.AddPendingEvents([]internal.OutboxEvent{withOperation})
+		updated, err := brokerStorage.Operations().UpdateProvisioningOperation(operation)
+		require.NoError(t, err)
+		conflicted := second
+		conflicted.ID = "conflicted-event-id"
+		operation.TakePendingEvents()
+		operation.AddPendingEvents([]internal.OutboxEvent{conflicted})
+		_, err = brokerStorage.Operations().UpdateProvisioningOperation(operation)
+
+		// then
+		assert.True(t, dberr.IsConflict(err))
+		assert.Equal(t, operation.Version+1, updated.Version)
+		assert.Empty(t, updated.PendingEvents())
+		events, err = svc.ClaimPending(first.Subscriber, now, now.Add(time.Hour), 10)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, withOperation.ID, events[0].ID)
+	})
+	t.Run("Webhooks", func(t *testing.T) {
+		containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
+		require.NoError(t, err)
+		defer containerCleanupFunc()
+
+		err = storage.InitTestDBTables(t, cfg.ConnectionURL())
+		require.NoError(t, err)
+
+		cipher := storage.NewEncrypter(cfg.SecretKey)
+		brokerStorage, _, err := storage.NewFromConfig(cfg, cipher, logrus.StandardLogger())
+		require.NoError(t, err)
+
+		subscriptions := brokerStorage.WebhookSubscriptions()
+		deliveries := brokerStorage.WebhookDeliveries()
+
+		now := time.Now().Truncate(time.Millisecond)
+		subscription := internal.WebhookSubscription{
+			ID:     "subscription-id",
+			URL:    "https://example.com/hook",
+			Secret: "secret",
+			Filter: internal.WebhookFilter{
+				EventTypes: []string{"provisioning.succeeded"},
+				Plans:      []string{"azure"},
+			},
+			CreatedAt: now,
+		}
+
+		// when
+		err = subscriptions.Insert(subscription)
+		require.NoError(t, err)
+		err = subscriptions.Insert(subscription)
+
+		// then
+		assertError(t, dberr.CodeAlreadyExists, err)
+		got, err := subscriptions.GetByID(subscription.ID)
+		require.NoError(t, err)
+		assert.Equal(t, subscription.Secret, got.Secret)
+		assert.Equal(t, subscription.Filter, got.Filter)
+		assert.Equal(t, subscription.URL, got.URL)
+
+		all, err := subscriptions.List()
+		require.NoError(t, err)
+		assert.Len(t, all, 1)
+
+		// when
+		for i, code := range []int{500, 200} {
+			err = deliveries.Insert(internal.WebhookDelivery{
+				ID:             fmt.Sprintf("delivery-%d", i),
+				SubscriptionID: subscription.ID,
+				NotificationID: "notification-id",
+				EventType:      "provisioning.succeeded",
+				StatusCode:     code,
+				CreatedAt:      now.Add(time.Duration(i) * time.Second),
+			})
+			require.NoError(t, err)
+		}
+
+		// then
+		log, err := deliveries.ListBySubscriptionID(subscription.ID)
+		require.NoError(t, err)
+		require.Len(t, log, 2)
+		assert.Equal(t, 500, log[0].StatusCode)
+		assert.Equal(t, 200, log[1].StatusCode)
+
+		// when
+		err = subscriptions.Delete(subscription.ID)
+		require.NoError(t, err)
+
+		// then
+		_, err = subscriptions.GetByID(subscription.ID)
+		assert.True(t, dberr.IsNotFound(err))
+	})
+	t.Run("CLS Instances", func(t *testing.T) {
+		containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
+		require.NoError(t, err)
+		defer containerCleanupFunc()
+
+		err = storage.InitTestDBTables(t, cfg.ConnectionURL())
+		require.NoError(t, err)
+
+		cipher := storage.NewEncrypter(cfg.SecretKey)
+		brokerStorage, _, err := storage.NewFromConfig(cfg, cipher, logrus.StandardLogger())
+		require.NoError(t, err)
+
+		svc := brokerStorage.CLSInstances()
+
+		instance := internal.CLSInstance{
+			ID:              "cls-instance-id",
+			GlobalAccountID: "global-account-id",
+			Region:          "eu",
+			CreatedAt:       time.Now(),
+			SKRReferences:   []string{"first-skr"},
+		}
+
+		// when
+		_, exists, err := svc.FindInstance(instance.GlobalAccountID)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		err = svc.InsertInstance(instance)
+		require.NoError(t, err)
+		err = svc.InsertInstance(instance)
+		assertError(t, dberr.CodeAlreadyExists, err)
+
+		// then
+		got, exists, err := svc.FindInstance(instance.GlobalAccountID)
+		require.NoError(t, err)
+		require.True(t, exists)
+		assert.Equal(t, instance.ID, got.ID)
+		assert.Equal(t, 0, got.Version)
+		assert.Equal(t, []string{"first-skr"}, got.SKRReferences)
+
+		// when
+		err = svc.Reference(got.Version, instance.GlobalAccountID, "second-skr")
+		require.NoError(t, err)
+		err = svc.Reference(got.Version, instance.GlobalAccountID, "third-skr")
+
+		// then
+		assertError(t, dberr.CodeConflict, err)
+		got, _, err = svc.FindInstance(instance.GlobalAccountID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, got.Version)
+		assert.ElementsMatch(t, []string{"first-skr", "second-skr"}, got.SKRReferences)
+
+		// the reference is idempotent
+		err = svc.Reference(got.Version, instance.GlobalAccountID, "second-skr")
+		require.NoError(t, err)
+
+		err = svc.Reference(0, "other-global-account-id", "second-skr")
+		assertError(t, dberr.CodeNotFound, err)
+	})
+	t.Run("Instance Archives", func(t *testing.T) {
+		containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
+		require.NoError(t, err)
+		defer containerCleanupFunc()
+
+		err = storage.InitTestDBTables(t, cfg.ConnectionURL())
+		require.NoError(t, err)
+
+		cipher := storage.NewEncrypter(cfg.SecretKey)
+		brokerStorage, _, err := storage.NewFromConfig(cfg, cipher, logrus.StandardLogger())
+		require.NoError(t, err)
+
+		svc := brokerStorage.InstanceArchives()
+
+		provisioning := fixture.FixProvisioningOperation("provisioning-id", "archived-id")
+		provisioning.CreatedAt = time.Now().Add(-48 * time.Hour)
+		deprovisioning := fixture.FixDeprovisioningOperation("deprovisioning-id", "archived-id")
+		live := fixture.FixProvisioningOperation("live-provisioning-id", "live-id")
+		live.CreatedAt = time.Now().Add(-48 * time.Hour)
+		require.NoError(t, brokerStorage.Operations().InsertProvisioningOperation(provisioning))
+		require.NoError(t, brokerStorage.Operations().InsertDeprovisioningOperation(deprovisioning))
+		require.NoError(t, brokerStorage.Operations().InsertProvisioningOperation(live))
+
+		archive := internal.InstanceArchive{
+			Instance:                 fixture.FixInstance("archived-id"),
+			ProvisioningOperations:   []internal.ProvisioningOperation{provisioning},
+			DeprovisioningOperations: []internal.DeprovisioningOperation{deprovisioning},
+			RuntimeStates: []internal.RuntimeState{
+				{ID: "runtime-state-id", OperationID: "provisioning-id", RuntimeID: "runtime-id", CreatedAt: time.Now()},
+			},
+			ArchivedAt: time.Now(),
+		}
+
+		// when
+		err = svc.Insert(archive)
+		require.NoError(t, err)
+		err = svc.Insert(archive)
+		assertError(t, dberr.CodeAlreadyExists, err)
+
+		// then
+		got, err := svc.GetByInstanceID("archived-id")
+		require.NoError(t, err)
+		assert.Equal(t, archive.Instance.RuntimeID, got.Instance.RuntimeID)
+		require.Len(t, got.ProvisioningOperations, 1)
+		assert.Equal(t, "provisioning-id", got.ProvisioningOperations[0].ID)
+		require.Len(t, got.DeprovisioningOperations, 1)
+		assert.Equal(t, "deprovisioning-id", got.DeprovisioningOperations[0].ID)
+		require.Len(t, got.RuntimeStates, 1)
+		assert.Equal(t, "runtime-state-id", got.RuntimeStates[0].ID)
+
+		_, err = svc.GetByInstanceID("live-id")
+		assertError(t, dberr.CodeNotFound, err)
+
+		archives, totalCount, err := svc.List(dbmodel.InstanceFilter{GlobalAccountIDs: []string{archive.Instance.GlobalAccountID}}, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, totalCount)
+		require.Len(t, archives, 1)
+		assert.Equal(t, "archived-id", archives[0].Instance.InstanceID)
+
+		archives, totalCount, err = svc.List(dbmodel.InstanceFilter{SubAccountIDs: []string{"other-subaccount"}}, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 0, totalCount)
+		assert.Empty(t, archives)
+
+		// only the old operations of the archived instances are pruned
+		ids, err := svc.ListOperationIDsToPrune(time.Now().Add(-time.Hour), 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"provisioning-id"}, ids)
+	})
 	t.Run("LMS Tenants", func(t *testing.T) {
 		containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t, ctx, "test_DB_1")
 		require.NoError(t, err)
@@ -211,6 +211,9 @@ func FixTables() map[string]string {
 			dashboard_url varchar(255) NOT NULL,
 			provisioning_parameters text NOT NULL,
 			provider_region varchar(32) NOT NULL,
+			plan_history text NOT NULL DEFAULT '[]',
+			expires_at TIMESTAMPTZ NOT NULL DEFAULT '0001-01-01 00:00:00+00',
+			expiration_warned_at TIMESTAMPTZ NOT NULL DEFAULT '0001-01-01 00:00:00+00',
             version integer NOT NULL DEFAULT 0,
 			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
@@ -230,17 +233,26 @@ func FixTables() map[string]string {
 			orchestration_id varchar(64),
 			created_at TIMESTAMPTZ NOT NULL,
 			updated_at TIMESTAMPTZ NOT NULL
-			)`, postsql.OperationTableName),
+			);
+			CREATE UNIQUE INDEX IF NOT EXISTS %s ON %s (instance_id)
+			WHERE (type = 'update' AND state = 'in progress')
+			OR (type = 'upgradeKyma' AND COALESCE(orchestration_id, '') = '' AND state IN ('pending', 'in progress'))`,
+			postsql.OperationTableName, postsql.OperationInProgressIndexName, postsql.OperationTableName),
 		postsql.OrchestrationTableName: fmt.Sprintf(
 			`CREATE TABLE IF NOT EXISTS %s (
 			orchestration_id varchar(255) PRIMARY KEY,
 			state varchar(32) NOT NULL,
 			description text,
+			type varchar(32) NOT NULL DEFAULT 'upgradeKyma',
 			parameters text NOT NULL,
 			runtime_operations text,
+			retry_of varchar(255),
 			created_at TIMESTAMPTZ NOT NULL,
 			updated_at TIMESTAMPTZ NOT NULL
-			)`, postsql.OrchestrationTableName),
+			);
+			CREATE UNIQUE INDEX IF NOT EXISTS %s ON %s (retry_of)
+			WHERE state IN ('pending', 'in progress', 'paused', 'canceling')`,
+			postsql.OrchestrationTableName, postsql.OrchestrationRetryOfIndexName, postsql.OrchestrationTableName),
 		postsql.LMSTenantTableName: fmt.Sprintf(
 			`CREATE TABLE IF NOT EXISTS %s (
 			id varchar(255) PRIMARY KEY,
@@ -260,5 +272,90 @@ func FixTables() map[string]string {
 			kyma_version text,
 			k8s_version text
 			)`, postsql.RuntimeStateTableName),
+		postsql.BindingsTableName: fmt.Sprintf(
+			`CREATE TABLE IF NOT EXISTS %s (
+			binding_id varchar(255) PRIMARY KEY,
+			instance_id varchar(255) NOT NULL,
+			operation varchar(32) NOT NULL,
+			state varchar(32) NOT NULL,
+			description text,
+			kubeconfig text,
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL
+			)`, postsql.BindingsTableName),
+		postsql.OperationEventsTableName: fmt.Sprintf(
+			`CREATE TABLE IF NOT EXISTS %s (
+			id varchar(255) PRIMARY KEY,
+			operation_id varchar(255) NOT NULL,
+			instance_id varchar(255),
+			step_name varchar(255) NOT NULL,
+			started_at TIMESTAMPTZ NOT NULL,
+			finished_at TIMESTAMPTZ NOT NULL,
+			duration bigint NOT NULL,
+			requeue_delay bigint NOT NULL,
+			error text,
+			old_state varchar(32),
+			new_state varchar(32),
+			description text
+			)`, postsql.OperationEventsTableName),
+		postsql.OutboxEventsTableName: fmt.Sprintf(
+			`CREATE TABLE IF NOT EXISTS %s (
+			id varchar(255) PRIMARY KEY,
+			type varchar(255) NOT NULL,
+			payload bytea NOT NULL,
+			subscriber varchar(255) NOT NULL,
+			state varchar(32) NOT NULL,
+			attempts integer NOT NULL,
+			last_error text,
+			created_at TIMESTAMPTZ NOT NULL,
+			next_attempt_at TIMESTAMPTZ NOT NULL
+			)`, postsql.OutboxEventsTableName),
+		postsql.WebhookSubscriptionsTableName: fmt.Sprintf(
+			`CREATE TABLE IF NOT EXISTS %s (
+			id varchar(255) PRIMARY KEY,
+			url text NOT NULL,
+			secret text NOT NULL,
+			filter text NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL
+			)`, postsql.WebhookSubscriptionsTableName),
+		postsql.WebhookDeliveriesTableName: fmt.Sprintf(
+			`CREATE TABLE IF NOT EXISTS %s (
+			id varchar(255) PRIMARY KEY,
+			subscription_id varchar(255) NOT NULL,
+			notification_id varchar(255) NOT NULL,
+			event_type varchar(255) NOT NULL,
+			status_code integer NOT NULL,
+			error text,
+			created_at TIMESTAMPTZ NOT NULL
+			)`, postsql.WebhookDeliveriesTableName),
+		postsql.CLSInstancesTableName: fmt.Sprintf(
+			`CREATE TABLE IF NOT EXISTS %s (
+			id varchar(255) PRIMARY KEY,
+			version integer NOT NULL,
+			global_account_id varchar(255) NOT NULL UNIQUE,
+			region varchar(12) NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL
+			)`, postsql.CLSInstancesTableName),
+		postsql.CLSInstanceReferencesTableName: fmt.Sprintf(
+			`CREATE TABLE IF NOT EXISTS %s (
+			cls_instance_id varchar(255) NOT NULL,
+			skr_instance_id varchar(255) NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			PRIMARY KEY (cls_instance_id, skr_instance_id)
+			)`, postsql.CLSInstanceReferencesTableName),
+		postsql.InstanceArchivesTableName: fmt.Sprintf(
+			`CREATE TABLE IF NOT EXISTS %s (
+			instance_id varchar(255) PRIMARY KEY,
+			runtime_id varchar(255),
+			global_account_id varchar(255) NOT NULL,
+			sub_account_id varchar(255),
+			service_plan_id varchar(255),
+			service_plan_name varchar(255),
+			provider_region varchar(32),
+			dashboard_url varchar(255),
+			data text NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			archived_at TIMESTAMPTZ NOT NULL
+			)`, postsql.InstanceArchivesTableName),
 	}
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
@@ -15,6 +16,7 @@ type ContextUpdateHandler struct {
 	operations          storage.Operations
 	provisioningQueue   Adder
 	deprovisioningQueue Adder
+	updateQueue         Adder
 
 	log logrus.FieldLogger
 }
@@ -23,11 +25,12 @@ type Adder interface {
 	Add(processId string)
 }
 
-func NewContextUpdateHandler(operations storage.Operations, provisioningQueue Adder, deprovisioningQueue Adder, l logrus.FieldLogger) *ContextUpdateHandler {
+func NewContextUpdateHandler(operations storage.Operations, provisioningQueue Adder, deprovisioningQueue Adder, updateQueue Adder, l logrus.FieldLogger) *ContextUpdateHandler {
 	return &ContextUpdateHandler{
 		operations:          operations,
 		provisioningQueue:   provisioningQueue,
 		deprovisioningQueue: deprovisioningQueue,
+		updateQueue:         updateQueue,
 		log:                 l,
 	}
 }
@@ -109,6 +112,19 @@ func (h *ContextUpdateHandler) suspend(instance *internal.Instance, log logrus.F
 }
 
 func (h *ContextUpdateHandler) unsuspend(instance *internal.Instance, log logrus.FieldLogger) error {
+	if instance.IsExpired() {
+		return errors.Errorf("trial instance %s expired at %s and cannot be unsuspended", instance.InstanceID, instance.ExpiresAt)
+	}
+
+	hibernation, err := h.hibernation(instance)
+	if err != nil {
+		return err
+	}
+	if hibernation != nil {
+		_, err = h.wakeUp(instance, log)
+		return err
+	}
+
 	id := uuid.New().String()
 
 	operation, err := internal.NewProvisioningOperationWithID(id, instance.InstanceID, instance.Parameters)
@@ -124,3 +140,38 @@ func (h *ContextUpdateHandler) unsuspend(instance *internal.Instance, log logrus
 	h.provisioningQueue.Add(operation.ID)
 	return nil
 }
+
+// hibernation returns the last suspension if it hibernated the runtime of the instance, otherwise nil is returned
+func (h *ContextUpdateHandler) hibernation(instance *internal.Instance) (*internal.DeprovisioningOperation, error) {
+	if instance.RuntimeID == "" {
+		return nil, nil
+	}
+	lastDeprovisioning, err := h.operations.GetDeprovisioningOperationByInstanceID(instance.InstanceID)
+	switch {
+	case err == nil:
+	case dberr.IsNotFound(err):
+		return nil, nil
+	default:
+		return nil, err
+	}
+
+	if lastDeprovisioning.Temporary && lastDeprovisioning.Hibernation && lastDeprovisioning.State == domain.Succeeded {
+		return lastDeprovisioning, nil
+	}
+	return nil, nil
+}
+
+// wakeUp creates the update operation which wakes up the hibernated runtime of the instance and returns its ID
+func (h *ContextUpdateHandler) wakeUp(instance *internal.Instance, log logrus.FieldLogger) (string, error) {
+	id := uuid.New().String()
+
+	operation := internal.NewWakeUpOperationWithID(id, instance)
+	log.Infof("Waking up the runtime %s", instance.RuntimeID)
+
+	err := h.operations.InsertUpdatingOperation(operation)
+	if err != nil {
+		return "", err
+	}
+	h.updateQueue.Add(operation.ID)
+	return operation.ID, nil
+}
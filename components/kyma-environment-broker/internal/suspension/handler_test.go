@@ -21,7 +21,7 @@ func TestSuspension(t *testing.T) {
 	deprovisioning := NewDummyQueue()
 	st := storage.NewMemoryStorage()
 
-	svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, logrus.New())
+	svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, NewDummyQueue(), logrus.New())
 	instance := fixInstance(fixActiveErsContext())
 	st.Instances().Insert(*instance)
 
@@ -36,6 +36,8 @@ func TestSuspension(t *testing.T) {
 
 	assert.Equal(t, domain.LastOperationState("pending"), op.State)
 	assert.Equal(t, instance.InstanceID, op.InstanceID)
+	assert.True(t, op.Temporary)
+	assert.True(t, op.Hibernation)
 }
 
 func TestSuspension_Retrigger(t *testing.T) {
@@ -45,7 +47,7 @@ func TestSuspension_Retrigger(t *testing.T) {
 		deprovisioning := NewDummyQueue()
 		st := storage.NewMemoryStorage()
 
-		svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, logrus.New())
+		svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, NewDummyQueue(), logrus.New())
 		instance := fixInstance(fixInactiveErsContext())
 		st.Instances().Insert(*instance)
 		st.Operations().InsertDeprovisioningOperation(internal.DeprovisioningOperation{
@@ -79,7 +81,7 @@ func TestSuspension_Retrigger(t *testing.T) {
 		deprovisioning := NewDummyQueue()
 		st := storage.NewMemoryStorage()
 
-		svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, logrus.New())
+		svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, NewDummyQueue(), logrus.New())
 		instance := fixInstance(fixInactiveErsContext())
 		st.Instances().Insert(*instance)
 		st.Operations().InsertDeprovisioningOperation(internal.DeprovisioningOperation{
@@ -123,7 +125,7 @@ func TestUnsuspension(t *testing.T) {
 	deprovisioning := NewDummyQueue()
 	st := storage.NewMemoryStorage()
 
-	svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, logrus.New())
+	svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, NewDummyQueue(), logrus.New())
 	instance := fixInstance(fixInactiveErsContext())
 
 	st.Instances().Insert(*instance)
@@ -142,6 +144,67 @@ func TestUnsuspension(t *testing.T) {
 	assert.Equal(t, instance.InstanceID, op.InstanceID)
 }
 
+func TestUnsuspension_WakeUp(t *testing.T) {
+	// given
+	provisioning := NewDummyQueue()
+	deprovisioning := NewDummyQueue()
+	update := NewDummyQueue()
+	st := storage.NewMemoryStorage()
+
+	svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, update, logrus.New())
+	instance := fixInstance(fixInactiveErsContext())
+	instance.RuntimeID = "runtime-id"
+
+	st.Instances().Insert(*instance)
+	st.Operations().InsertDeprovisioningOperation(internal.DeprovisioningOperation{
+		Operation: internal.Operation{
+			ID:         "suspended-op-id",
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+			InstanceID: instance.InstanceID,
+			State:      domain.Succeeded,
+		},
+		Temporary:   true,
+		Hibernation: true,
+	})
+
+	// when
+	err := svc.Handle(instance, fixActiveErsContext())
+	require.NoError(t, err)
+
+	// then
+	require.Len(t, update.IDs, 1)
+	op, err := st.Operations().GetUpdatingOperationByID(update.IDs[0])
+	require.NoError(t, err)
+	assertQueue(t, deprovisioning)
+	assertQueue(t, provisioning)
+
+	assert.True(t, op.WakeUp)
+	assert.Equal(t, "runtime-id", op.RuntimeID)
+	assert.Equal(t, instance.InstanceID, op.InstanceID)
+}
+
+func TestUnsuspension_Expired(t *testing.T) {
+	// given
+	provisioning := NewDummyQueue()
+	update := NewDummyQueue()
+	st := storage.NewMemoryStorage()
+
+	svc := NewContextUpdateHandler(st.Operations(), provisioning, NewDummyQueue(), update, logrus.New())
+	instance := fixInstance(fixInactiveErsContext())
+	instance.ExpiresAt = time.Now().Add(-time.Hour)
+
+	st.Instances().Insert(*instance)
+
+	// when
+	err := svc.Handle(instance, fixActiveErsContext())
+
+	// then
+	require.Error(t, err)
+	assertQueue(t, provisioning)
+	assertQueue(t, update)
+}
+
 func fixInstance(ersContext internal.ERSContext) *internal.Instance {
 	return &internal.Instance{
 		InstanceID:      "instance-id",
@@ -0,0 +1,93 @@
+package suspension
+
+import (
+	"net/http"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/gorilla/mux"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// WakeUpHandler exposes the admin API which wakes up the hibernated runtimes of the trial instances,
+// e.g. when the wake-up triggered by the unsuspension failed
+type WakeUpHandler struct {
+	instances  storage.Instances
+	operations storage.Operations
+	ctxHandler *ContextUpdateHandler
+
+	log logrus.FieldLogger
+}
+
+func NewWakeUpHandler(instances storage.Instances, operations storage.Operations, ctxHandler *ContextUpdateHandler, log logrus.FieldLogger) *WakeUpHandler {
+	return &WakeUpHandler{
+		instances:  instances,
+		operations: operations,
+		ctxHandler: ctxHandler,
+		log:        log.WithField("service", "wakeUpHandler"),
+	}
+}
+
+func (h *WakeUpHandler) AttachRoutes(router *mux.Router) {
+	router.HandleFunc("/trials/{instance_id}/wakeup", h.wakeUp).Methods(http.MethodPost)
+}
+
+func (h *WakeUpHandler) wakeUp(w http.ResponseWriter, r *http.Request) {
+	instanceID := mux.Vars(r)["instance_id"]
+
+	instance, err := h.instances.GetByID(instanceID)
+	switch {
+	case err == nil:
+	case dberr.IsNotFound(err):
+		httputil.WriteErrorResponse(w, http.StatusNotFound, errors.Errorf("instance %s not found", instanceID))
+		return
+	default:
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while getting instance %s", instanceID))
+		return
+	}
+	if !broker.IsTrialPlan(instance.ServicePlanID) {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Errorf("instance %s is not a trial instance", instanceID))
+		return
+	}
+	if active := instance.Parameters.ErsContext.Active; active != nil && !*active {
+		httputil.WriteErrorResponse(w, http.StatusConflict, errors.Errorf("instance %s is suspended", instanceID))
+		return
+	}
+
+	hibernation, err := h.ctxHandler.hibernation(instance)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while getting deprovisioning operation of instance %s", instanceID))
+		return
+	}
+	if hibernation == nil {
+		httputil.WriteErrorResponse(w, http.StatusConflict, errors.Errorf("runtime of the instance %s is not hibernated", instanceID))
+		return
+	}
+
+	updates, err := h.operations.ListUpdatingOperationsByInstanceID(instanceID)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while listing updating operations of instance %s", instanceID))
+		return
+	}
+	for _, op := range updates {
+		// only a failed wake-up can be retriggered
+		if op.WakeUp && op.CreatedAt.After(hibernation.CreatedAt) && op.State != domain.Failed {
+			httputil.WriteErrorResponse(w, http.StatusConflict, errors.Errorf("wake-up %s of the instance %s is %s", op.ID, instanceID, op.State))
+			return
+		}
+	}
+
+	operationID, err := h.ctxHandler.wakeUp(instance, h.log.WithField("instanceID", instanceID))
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while waking up the runtime of instance %s", instanceID))
+		return
+	}
+
+	httputil.WriteResponse(w, http.StatusAccepted, runtime.WakeUpResponse{OperationID: operationID})
+}
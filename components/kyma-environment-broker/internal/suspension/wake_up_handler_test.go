@@ -0,0 +1,175 @@
+package suspension
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/gorilla/mux"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWakeUpHandler(t *testing.T) {
+	t.Run("should wake up the hibernated runtime", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		fixHibernatedInstance(t, st)
+		update := NewDummyQueue()
+
+		// when
+		resp := callWakeUp(t, st, update)
+
+		// then
+		require.Equal(t, http.StatusAccepted, resp.Code)
+		var wr runtime.WakeUpResponse
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &wr))
+		assertQueue(t, update, wr.OperationID)
+
+		op, err := st.Operations().GetUpdatingOperationByID(wr.OperationID)
+		require.NoError(t, err)
+		assert.True(t, op.WakeUp)
+		assert.Equal(t, "runtime-id", op.RuntimeID)
+	})
+
+	t.Run("should retrigger the failed wake-up", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		fixHibernatedInstance(t, st)
+		fixWakeUp(t, st, domain.Failed)
+		update := NewDummyQueue()
+
+		// when
+		resp := callWakeUp(t, st, update)
+
+		// then
+		require.Equal(t, http.StatusAccepted, resp.Code)
+		assert.Len(t, update.IDs, 1)
+	})
+
+	t.Run("should reject the wake-up in progress", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		fixHibernatedInstance(t, st)
+		fixWakeUp(t, st, domain.InProgress)
+		update := NewDummyQueue()
+
+		// when
+		resp := callWakeUp(t, st, update)
+
+		// then
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		assertQueue(t, update)
+	})
+
+	t.Run("should reject the runtime which is not hibernated", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		instance := fixInstance(fixActiveErsContext())
+		instance.RuntimeID = "runtime-id"
+		require.NoError(t, st.Instances().Insert(*instance))
+		update := NewDummyQueue()
+
+		// when
+		resp := callWakeUp(t, st, update)
+
+		// then
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		assertQueue(t, update)
+	})
+
+	t.Run("should reject the suspended instance", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		instance := fixHibernatedInstance(t, st)
+		instance.Parameters.ErsContext = fixInactiveErsContext()
+		_, err := st.Instances().Update(*instance)
+		require.NoError(t, err)
+		update := NewDummyQueue()
+
+		// when
+		resp := callWakeUp(t, st, update)
+
+		// then
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		assertQueue(t, update)
+	})
+
+	t.Run("should reject the non-trial instance", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		instance := fixInstance(fixActiveErsContext())
+		instance.ServicePlanID = broker.AzurePlanID
+		require.NoError(t, st.Instances().Insert(*instance))
+
+		// when
+		resp := callWakeUp(t, st, NewDummyQueue())
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("should return not found for the missing instance", func(t *testing.T) {
+		// when
+		resp := callWakeUp(t, storage.NewMemoryStorage(), NewDummyQueue())
+
+		// then
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+}
+
+func callWakeUp(t *testing.T, st storage.BrokerStorage, update *dummyQueue) *httptest.ResponseRecorder {
+	t.Helper()
+
+	ctxHandler := NewContextUpdateHandler(st.Operations(), NewDummyQueue(), NewDummyQueue(), update, logrus.New())
+	router := mux.NewRouter()
+	NewWakeUpHandler(st.Instances(), st.Operations(), ctxHandler, logrus.New()).AttachRoutes(router)
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/trials/%s/wakeup", "instance-id"), nil)
+	require.NoError(t, err)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	return resp
+}
+
+func fixHibernatedInstance(t *testing.T, st storage.BrokerStorage) *internal.Instance {
+	t.Helper()
+
+	instance := fixInstance(fixActiveErsContext())
+	instance.RuntimeID = "runtime-id"
+	require.NoError(t, st.Instances().Insert(*instance))
+	require.NoError(t, st.Operations().InsertDeprovisioningOperation(internal.DeprovisioningOperation{
+		Operation: internal.Operation{
+			ID:         "suspended-op-id",
+			CreatedAt:  time.Now().Add(-time.Hour),
+			UpdatedAt:  time.Now().Add(-time.Hour),
+			InstanceID: instance.InstanceID,
+			State:      domain.Succeeded,
+		},
+		Temporary:   true,
+		Hibernation: true,
+	}))
+
+	return instance
+}
+
+func fixWakeUp(t *testing.T, st storage.BrokerStorage, state domain.LastOperationState) {
+	t.Helper()
+
+	instance, err := st.Instances().GetByID("instance-id")
+	require.NoError(t, err)
+	op := internal.NewWakeUpOperationWithID("wake-up-op-id", instance)
+	op.State = state
+	require.NoError(t, st.Operations().InsertUpdatingOperation(op))
+}
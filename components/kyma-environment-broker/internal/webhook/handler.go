@@ -0,0 +1,216 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/webhook"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const generatedSecretLength = 32
+
+// Handler exposes the admin API which manages the webhook subscriptions
+type Handler struct {
+	subscriptions storage.WebhookSubscriptions
+	deliveries    storage.WebhookDeliveries
+	log           logrus.FieldLogger
+}
+
+func NewHandler(subscriptions storage.WebhookSubscriptions, deliveries storage.WebhookDeliveries, log logrus.FieldLogger) *Handler {
+	return &Handler{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		log:           log,
+	}
+}
+
+func (h *Handler) AttachRoutes(router *mux.Router) {
+	router.HandleFunc("/webhooks", h.createSubscription).Methods(http.MethodPost)
+	router.HandleFunc("/webhooks", h.listSubscriptions).Methods(http.MethodGet)
+	router.HandleFunc("/webhooks/{subscription_id}", h.getSubscription).Methods(http.MethodGet)
+	router.HandleFunc("/webhooks/{subscription_id}", h.deleteSubscription).Methods(http.MethodDelete)
+	router.HandleFunc("/webhooks/{subscription_id}/deliveries", h.listDeliveries).Methods(http.MethodGet)
+}
+
+func (h *Handler) createSubscription(w http.ResponseWriter, r *http.Request) {
+	var req webhook.SubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.Wrap(err, "while decoding request body"))
+		return
+	}
+	if err := validate(req); err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		generated, err := generateSecret()
+		if err != nil {
+			httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrap(err, "while generating secret"))
+			return
+		}
+		secret = generated
+	}
+
+	subscription := internal.WebhookSubscription{
+		ID:     uuid.New().String(),
+		URL:    req.URL,
+		Secret: secret,
+		Filter: internal.WebhookFilter{
+			EventTypes:       req.Filter.EventTypes,
+			Plans:            req.Filter.Plans,
+			GlobalAccountIDs: req.Filter.GlobalAccountIDs,
+		},
+		CreatedAt: time.Now(),
+	}
+	if err := h.subscriptions.Insert(subscription); err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrap(err, "while storing webhook subscription"))
+		return
+	}
+	h.log.Infof("Webhook subscription %s for %s created", subscription.ID, subscription.URL)
+
+	dto := toSubscriptionDTO(subscription)
+	// the secret is returned only once, the receiver needs it to verify the signature
+	dto.Secret = subscription.Secret
+	httputil.WriteResponse(w, http.StatusCreated, dto)
+}
+
+func (h *Handler) listSubscriptions(w http.ResponseWriter, _ *http.Request) {
+	subscriptions, err := h.subscriptions.List()
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrap(err, "while listing webhook subscriptions"))
+		return
+	}
+
+	list := webhook.SubscriptionList{
+		Data:  make([]webhook.SubscriptionDTO, 0, len(subscriptions)),
+		Count: len(subscriptions),
+	}
+	for _, s := range subscriptions {
+		list.Data = append(list.Data, toSubscriptionDTO(s))
+	}
+
+	httputil.WriteResponse(w, http.StatusOK, list)
+}
+
+func (h *Handler) getSubscription(w http.ResponseWriter, r *http.Request) {
+	subscription, ok := h.subscription(w, mux.Vars(r)["subscription_id"])
+	if !ok {
+		return
+	}
+
+	httputil.WriteResponse(w, http.StatusOK, toSubscriptionDTO(*subscription))
+}
+
+func (h *Handler) deleteSubscription(w http.ResponseWriter, r *http.Request) {
+	subscription, ok := h.subscription(w, mux.Vars(r)["subscription_id"])
+	if !ok {
+		return
+	}
+
+	if err := h.subscriptions.Delete(subscription.ID); err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while deleting webhook subscription %s", subscription.ID))
+		return
+	}
+	h.log.Infof("Webhook subscription %s for %s deleted", subscription.ID, subscription.URL)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) listDeliveries(w http.ResponseWriter, r *http.Request) {
+	subscription, ok := h.subscription(w, mux.Vars(r)["subscription_id"])
+	if !ok {
+		return
+	}
+
+	deliveries, err := h.deliveries.ListBySubscriptionID(subscription.ID)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while listing deliveries of webhook subscription %s", subscription.ID))
+		return
+	}
+
+	list := webhook.DeliveryList{
+		Data:  make([]webhook.DeliveryDTO, 0, len(deliveries)),
+		Count: len(deliveries),
+	}
+	for _, d := range deliveries {
+		list.Data = append(list.Data, webhook.DeliveryDTO{
+			ID:             d.ID,
+			NotificationID: d.NotificationID,
+			EventType:      d.EventType,
+			StatusCode:     d.StatusCode,
+			Error:          d.Error,
+			CreatedAt:      d.CreatedAt,
+		})
+	}
+
+	httputil.WriteResponse(w, http.StatusOK, list)
+}
+
+// subscription returns the subscription with the given ID or writes the error response
+func (h *Handler) subscription(w http.ResponseWriter, id string) (*internal.WebhookSubscription, bool) {
+	subscription, err := h.subscriptions.GetByID(id)
+	switch {
+	case err == nil:
+		return subscription, true
+	case dberr.IsNotFound(err):
+		httputil.WriteErrorResponse(w, http.StatusNotFound, errors.Errorf("webhook subscription %s not found", id))
+	default:
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, errors.Wrapf(err, "while getting webhook subscription %s", id))
+	}
+	return nil, false
+}
+
+func validate(req webhook.SubscriptionRequest) error {
+	u, err := url.Parse(req.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.Errorf("url %q must be an absolute http or https URL", req.URL)
+	}
+	for _, eventType := range req.Filter.EventTypes {
+		if !contains(webhook.EventTypes, eventType) {
+			return errors.Errorf("unknown event type %q, allowed values: %v", eventType, webhook.EventTypes)
+		}
+	}
+	for _, plan := range req.Filter.Plans {
+		if _, found := broker.PlanIDsMapping[plan]; !found {
+			return errors.Errorf("unknown plan %q", plan)
+		}
+	}
+	return nil
+}
+
+func toSubscriptionDTO(subscription internal.WebhookSubscription) webhook.SubscriptionDTO {
+	return webhook.SubscriptionDTO{
+		ID:  subscription.ID,
+		URL: subscription.URL,
+		Filter: webhook.Filter{
+			EventTypes:       subscription.Filter.EventTypes,
+			Plans:            subscription.Filter.Plans,
+			GlobalAccountIDs: subscription.Filter.GlobalAccountIDs,
+		},
+		CreatedAt: subscription.CreatedAt,
+	}
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, generatedSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,155 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/webhook"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_CreateSubscription(t *testing.T) {
+	t.Run("should create subscription with generated secret", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+
+		// when
+		resp := callHandler(t, st, http.MethodPost, "/webhooks", webhook.SubscriptionRequest{
+			URL: "https://example.com/hook",
+			Filter: webhook.Filter{
+				EventTypes: []string{webhook.ProvisioningSucceeded},
+				Plans:      []string{broker.AzurePlanName},
+			},
+		})
+
+		// then
+		require.Equal(t, http.StatusCreated, resp.Code)
+		var created webhook.SubscriptionDTO
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &created))
+		assert.NotEmpty(t, created.ID)
+		assert.Len(t, created.Secret, 2*generatedSecretLength)
+
+		stored, err := st.WebhookSubscriptions().GetByID(created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, created.Secret, stored.Secret)
+		assert.Equal(t, []string{broker.AzurePlanName}, stored.Filter.Plans)
+	})
+
+	for name, req := range map[string]webhook.SubscriptionRequest{
+		"relative url":  {URL: "/hook"},
+		"unknown event": {URL: "https://example.com", Filter: webhook.Filter{EventTypes: []string{"runtime.ready"}}},
+		"unknown plan":  {URL: "https://example.com", Filter: webhook.Filter{Plans: []string{"openstack"}}},
+	} {
+		t.Run("should reject request with "+name, func(t *testing.T) {
+			// given
+			st := storage.NewMemoryStorage()
+
+			// when
+			resp := callHandler(t, st, http.MethodPost, "/webhooks", req)
+
+			// then
+			assert.Equal(t, http.StatusBadRequest, resp.Code)
+			subscriptions, err := st.WebhookSubscriptions().List()
+			require.NoError(t, err)
+			assert.Empty(t, subscriptions)
+		})
+	}
+}
+
+func TestHandler_Subscriptions(t *testing.T) {
+	t.Run("should list subscriptions without secrets", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		insertSubscription(t, st, "first", internal.WebhookFilter{})
+		insertSubscription(t, st, "second", internal.WebhookFilter{})
+
+		// when
+		resp := callHandler(t, st, http.MethodGet, "/webhooks", nil)
+
+		// then
+		require.Equal(t, http.StatusOK, resp.Code)
+		var list webhook.SubscriptionList
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &list))
+		assert.Equal(t, 2, list.Count)
+		for _, s := range list.Data {
+			assert.Empty(t, s.Secret)
+		}
+	})
+
+	t.Run("should return not found for missing subscription", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+
+		// when
+		resp := callHandler(t, st, http.MethodGet, "/webhooks/missing", nil)
+
+		// then
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("should delete subscription", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		insertSubscription(t, st, "first", internal.WebhookFilter{})
+
+		// when
+		resp := callHandler(t, st, http.MethodDelete, "/webhooks/first", nil)
+
+		// then
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+		resp = callHandler(t, st, http.MethodGet, "/webhooks/first", nil)
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("should list deliveries of subscription", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		insertSubscription(t, st, "first", internal.WebhookFilter{})
+		require.NoError(t, st.WebhookDeliveries().Insert(internal.WebhookDelivery{
+			ID:             "delivery-id",
+			SubscriptionID: "first",
+			NotificationID: "notification-id",
+			EventType:      webhook.ProvisioningFailed,
+			Error:          "connection refused",
+			CreatedAt:      time.Now(),
+		}))
+
+		// when
+		resp := callHandler(t, st, http.MethodGet, "/webhooks/first/deliveries", nil)
+
+		// then
+		require.Equal(t, http.StatusOK, resp.Code)
+		var list webhook.DeliveryList
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &list))
+		require.Equal(t, 1, list.Count)
+		assert.Equal(t, "notification-id", list.Data[0].NotificationID)
+		assert.Equal(t, "connection refused", list.Data[0].Error)
+	})
+}
+
+func callHandler(t *testing.T, st storage.BrokerStorage, method, url string, body interface{}) *httptest.ResponseRecorder {
+	var payload bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&payload).Encode(body))
+	}
+	req, err := http.NewRequest(method, url, &payload)
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	NewHandler(st.WebhookSubscriptions(), st.WebhookDeliveries(), logrus.New()).AttachRoutes(router)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	return resp
+}
@@ -0,0 +1,223 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/webhook"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
+	internalOrchestration "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/pkg/errors"
+)
+
+const (
+	// the names are stored with the events delivered to the subscribers, they must not change
+	notifierSubscriberName = "webhook-notifier"
+	senderSubscriberName   = "webhook-sender"
+)
+
+// DeliveryRequested is published for every subscription which matches the notification, the delivery to each
+// subscription is retried separately
+type DeliveryRequested struct {
+	SubscriptionID string
+	Notification   webhook.Notification
+}
+
+// Notifier creates the notifications about the finished operations and the orchestration state changes
+// and requests their delivery to the matching subscriptions
+type Notifier struct {
+	subscriptions storage.WebhookSubscriptions
+	pub           event.Publisher
+}
+
+func NewNotifier(subscriptions storage.WebhookSubscriptions, pub event.Publisher) *Notifier {
+	return &Notifier{
+		subscriptions: subscriptions,
+		pub:           pub,
+	}
+}
+
+// Subscribe registers the notifier for the steps of all operation types and for the orchestration state changes
+func (n *Notifier) Subscribe(sub event.Subscriber) {
+	sub.Subscribe(notifierSubscriberName, process.ProvisioningStepProcessed{}, n.OnStepProcessed)
+	sub.Subscribe(notifierSubscriberName, process.DeprovisioningStepProcessed{}, n.OnStepProcessed)
+	sub.Subscribe(notifierSubscriberName, process.UpgradeKymaStepProcessed{}, n.OnStepProcessed)
+	sub.Subscribe(notifierSubscriberName, process.UpgradeClusterStepProcessed{}, n.OnStepProcessed)
+	sub.Subscribe(notifierSubscriberName, process.UpdatingStepProcessed{}, n.OnStepProcessed)
+	sub.Subscribe(notifierSubscriberName, internalOrchestration.StateChanged{}, n.OnOrchestrationStateChanged)
+}
+
+func (n *Notifier) OnStepProcessed(ctx context.Context, ev interface{}) error {
+	switch e := ev.(type) {
+	case process.ProvisioningStepProcessed:
+		return n.onOperationProcessed(ctx, "provisioning", e.OldOperation.Operation, e.Operation.Operation)
+	case process.DeprovisioningStepProcessed:
+		if e.OldOperation.Temporary {
+			return n.onOperationProcessed(ctx, "suspension", e.OldOperation.Operation, e.Operation.Operation)
+		}
+		return n.onOperationProcessed(ctx, "deprovisioning", e.OldOperation.Operation, e.Operation.Operation)
+	case process.UpgradeKymaStepProcessed:
+		return n.onOperationProcessed(ctx, "upgradeKyma", e.OldOperation.Operation, e.Operation.Operation)
+	case process.UpgradeClusterStepProcessed:
+		return n.onOperationProcessed(ctx, "upgradeCluster", e.OldOperation.Operation, e.Operation.Operation)
+	case process.UpdatingStepProcessed:
+		return n.onOperationProcessed(ctx, "update", e.OldOperation.Operation, e.Operation.Operation)
+	default:
+		return fmt.Errorf("expected one of the StepProcessed events but got %+v", ev)
+	}
+}
+
+// onOperationProcessed sends the notification when the step finished the operation
+func (n *Notifier) onOperationProcessed(ctx context.Context, operationType string, oldOperation, operation internal.Operation) error {
+	if operation.ID == "" {
+		// the step failed without returning the operation
+		return nil
+	}
+	if oldOperation.State == operation.State {
+		return nil
+	}
+	var eventType string
+	switch operation.State {
+	case domain.Succeeded:
+		eventType = operationType + ".succeeded"
+	case domain.Failed:
+		eventType = operationType + ".failed"
+	default:
+		return nil
+	}
+
+	pp := operation.ProvisioningParameters
+	return n.notify(ctx, webhook.Notification{
+		ID:        uuid.NewSHA1(uuid.NameSpaceOID, []byte(operation.ID+eventType)).String(),
+		Type:      eventType,
+		CreatedAt: operation.UpdatedAt,
+		Runtime: &webhook.RuntimeData{
+			InstanceID:      operation.InstanceID,
+			RuntimeID:       operation.RuntimeID,
+			GlobalAccountID: pp.ErsContext.GlobalAccountID,
+			SubAccountID:    pp.ErsContext.SubAccountID,
+			PlanID:          pp.PlanID,
+			PlanName:        broker.PlanNamesMapping[pp.PlanID],
+			OperationID:     operation.ID,
+			State:           string(operation.State),
+			Description:     operation.Description,
+			OrchestrationID: operation.OrchestrationID,
+			Parameters:      redact(pp),
+		},
+	})
+}
+
+func (n *Notifier) OnOrchestrationStateChanged(ctx context.Context, ev interface{}) error {
+	e, ok := ev.(internalOrchestration.StateChanged)
+	if !ok {
+		return fmt.Errorf("expected orchestration.StateChanged but got %+v", ev)
+	}
+
+	var eventType string
+	switch e.Orchestration.State {
+	case orchestration.InProgress:
+		if e.OldState != orchestration.Pending {
+			// the resumed orchestration is not started again
+			return nil
+		}
+		eventType = webhook.OrchestrationStarted
+	case orchestration.Paused:
+		eventType = webhook.OrchestrationPaused
+	case orchestration.Succeeded:
+		eventType = webhook.OrchestrationSucceeded
+	case orchestration.Failed:
+		eventType = webhook.OrchestrationFailed
+	case orchestration.Canceled:
+		eventType = webhook.OrchestrationCanceled
+	default:
+		return nil
+	}
+
+	o := e.Orchestration
+	return n.notify(ctx, webhook.Notification{
+		ID:        uuid.NewSHA1(uuid.NameSpaceOID, []byte(o.OrchestrationID+eventType+o.UpdatedAt.String())).String(),
+		Type:      eventType,
+		CreatedAt: o.UpdatedAt,
+		Orchestration: &webhook.OrchestrationData{
+			OrchestrationID: o.OrchestrationID,
+			Type:            string(o.Type),
+			State:           o.State,
+			Description:     o.Description,
+		},
+	})
+}
+
+func (n *Notifier) notify(ctx context.Context, notification webhook.Notification) error {
+	subscriptions, err := n.subscriptions.List()
+	if err != nil {
+		return errors.Wrapf(err, "while listing webhook subscriptions for notification %s", notification.Type)
+	}
+
+	for _, subscription := range subscriptions {
+		if !matches(subscription.Filter, notification) {
+			continue
+		}
+		n.pub.Publish(ctx, DeliveryRequested{
+			SubscriptionID: subscription.ID,
+			Notification:   notification,
+		})
+	}
+	return nil
+}
+
+// matches returns true if the notification passes the filter, the orchestration notifications are sent only to the
+// subscriptions which are not limited to the plans or global accounts
+func matches(filter internal.WebhookFilter, notification webhook.Notification) bool {
+	if len(filter.EventTypes) > 0 && !contains(filter.EventTypes, notification.Type) {
+		return false
+	}
+	if notification.Runtime == nil {
+		return len(filter.Plans) == 0 && len(filter.GlobalAccountIDs) == 0
+	}
+	if len(filter.Plans) > 0 && !contains(filter.Plans, notification.Runtime.PlanName) {
+		return false
+	}
+	if len(filter.GlobalAccountIDs) > 0 && !contains(filter.GlobalAccountIDs, notification.Runtime.GlobalAccountID) {
+		return false
+	}
+	return true
+}
+
+// redact masks the secret values of the provisioning parameters
+func redact(pp internal.ProvisioningParameters) internal.ProvisioningParameters {
+	if pp.ErsContext.ServiceManager != nil {
+		sm := *pp.ErsContext.ServiceManager
+		sm.Credentials.BasicAuth.Username = masked(sm.Credentials.BasicAuth.Username)
+		sm.Credentials.BasicAuth.Password = masked(sm.Credentials.BasicAuth.Password)
+		pp.ErsContext.ServiceManager = &sm
+	}
+	if pp.Parameters.TargetSecret != nil {
+		secret := masked(*pp.Parameters.TargetSecret)
+		pp.Parameters.TargetSecret = &secret
+	}
+	return pp
+}
+
+func masked(value string) string {
+	if value == "" {
+		return value
+	}
+	return orchestration.MaskedValue
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
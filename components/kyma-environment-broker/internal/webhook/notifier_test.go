@@ -0,0 +1,234 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/webhook"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
+	internalOrchestration "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/pivotal-cf/brokerapi/v7/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	operationID     = "operation-id"
+	instanceID      = "instance-id"
+	globalAccountID = "global-account-id"
+)
+
+func TestNotifier_OnStepProcessed(t *testing.T) {
+	t.Run("should request delivery of the finished operation to the matching subscriptions", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		insertSubscription(t, st, "all", internal.WebhookFilter{})
+		insertSubscription(t, st, "azure", internal.WebhookFilter{Plans: []string{broker.AzurePlanName}})
+		insertSubscription(t, st, "trial", internal.WebhookFilter{Plans: []string{broker.TrialPlanName}})
+		insertSubscription(t, st, "account", internal.WebhookFilter{GlobalAccountIDs: []string{globalAccountID}})
+		insertSubscription(t, st, "failures", internal.WebhookFilter{EventTypes: []string{webhook.ProvisioningFailed}})
+		pub := &publisherMock{}
+		notifier := NewNotifier(st.WebhookSubscriptions(), pub)
+
+		oldOperation, operation := fixOperations(domain.Succeeded)
+
+		// when
+		err := notifier.OnStepProcessed(context.Background(), process.ProvisioningStepProcessed{
+			OldOperation: oldOperation,
+			Operation:    operation,
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"all", "azure", "account"}, pub.subscriptionIDs())
+
+		notification := pub.events[0].Notification
+		assert.Equal(t, webhook.ProvisioningSucceeded, notification.Type)
+		require.NotNil(t, notification.Runtime)
+		assert.Equal(t, instanceID, notification.Runtime.InstanceID)
+		assert.Equal(t, operationID, notification.Runtime.OperationID)
+		assert.Equal(t, broker.AzurePlanName, notification.Runtime.PlanName)
+		assert.Equal(t, string(domain.Succeeded), notification.Runtime.State)
+	})
+
+	t.Run("should mask the secrets of the provisioning parameters", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		insertSubscription(t, st, "all", internal.WebhookFilter{})
+		pub := &publisherMock{}
+		notifier := NewNotifier(st.WebhookSubscriptions(), pub)
+
+		oldOperation, operation := fixOperations(domain.Failed)
+
+		// when
+		err := notifier.OnStepProcessed(context.Background(), process.ProvisioningStepProcessed{
+			OldOperation: oldOperation,
+			Operation:    operation,
+		})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, pub.events, 1)
+		params := pub.events[0].Notification.Runtime.Parameters
+		assert.Equal(t, orchestration.MaskedValue, params.ErsContext.ServiceManager.Credentials.BasicAuth.Username)
+		assert.Equal(t, orchestration.MaskedValue, params.ErsContext.ServiceManager.Credentials.BasicAuth.Password)
+		assert.Equal(t, orchestration.MaskedValue, *params.Parameters.TargetSecret)
+		assert.Equal(t, "https://service-manager.local", params.ErsContext.ServiceManager.URL)
+		// the operation from the event is not modified
+		assert.Equal(t, "password", operation.ProvisioningParameters.ErsContext.ServiceManager.Credentials.BasicAuth.Password)
+	})
+
+	t.Run("should send suspension notification for the temporary deprovisioning", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		insertSubscription(t, st, "all", internal.WebhookFilter{})
+		pub := &publisherMock{}
+		notifier := NewNotifier(st.WebhookSubscriptions(), pub)
+
+		oldOperation, operation := fixOperations(domain.Succeeded)
+
+		// when
+		err := notifier.OnStepProcessed(context.Background(), process.DeprovisioningStepProcessed{
+			OldOperation: internal.DeprovisioningOperation{Operation: oldOperation.Operation, Temporary: true},
+			Operation:    internal.DeprovisioningOperation{Operation: operation.Operation, Temporary: true},
+		})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, pub.events, 1)
+		assert.Equal(t, webhook.SuspensionSucceeded, pub.events[0].Notification.Type)
+	})
+
+	t.Run("should not send notification for the operation in progress", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		insertSubscription(t, st, "all", internal.WebhookFilter{})
+		pub := &publisherMock{}
+		notifier := NewNotifier(st.WebhookSubscriptions(), pub)
+
+		oldOperation, operation := fixOperations(domain.InProgress)
+
+		// when
+		err := notifier.OnStepProcessed(context.Background(), process.ProvisioningStepProcessed{
+			OldOperation: oldOperation,
+			Operation:    operation,
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, pub.events)
+	})
+
+	t.Run("should use the same notification ID for the same operation", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		insertSubscription(t, st, "all", internal.WebhookFilter{})
+		pub := &publisherMock{}
+		notifier := NewNotifier(st.WebhookSubscriptions(), pub)
+
+		oldOperation, operation := fixOperations(domain.Succeeded)
+		ev := process.ProvisioningStepProcessed{OldOperation: oldOperation, Operation: operation}
+
+		// when
+		require.NoError(t, notifier.OnStepProcessed(context.Background(), ev))
+		require.NoError(t, notifier.OnStepProcessed(context.Background(), ev))
+
+		// then
+		require.Len(t, pub.events, 2)
+		assert.Equal(t, pub.events[0].Notification.ID, pub.events[1].Notification.ID)
+	})
+}
+
+func TestNotifier_OnOrchestrationStateChanged(t *testing.T) {
+	for name, tc := range map[string]struct {
+		oldState     string
+		state        string
+		expectedType string
+	}{
+		"started":   {oldState: orchestration.Pending, state: orchestration.InProgress, expectedType: webhook.OrchestrationStarted},
+		"resumed":   {oldState: orchestration.Paused, state: orchestration.InProgress},
+		"paused":    {oldState: orchestration.InProgress, state: orchestration.Paused, expectedType: webhook.OrchestrationPaused},
+		"succeeded": {oldState: orchestration.InProgress, state: orchestration.Succeeded, expectedType: webhook.OrchestrationSucceeded},
+		"canceling": {oldState: orchestration.InProgress, state: orchestration.Canceling},
+		"canceled":  {oldState: orchestration.Canceling, state: orchestration.Canceled, expectedType: webhook.OrchestrationCanceled},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			st := storage.NewMemoryStorage()
+			insertSubscription(t, st, "all", internal.WebhookFilter{})
+			insertSubscription(t, st, "azure", internal.WebhookFilter{Plans: []string{broker.AzurePlanName}})
+			pub := &publisherMock{}
+			notifier := NewNotifier(st.WebhookSubscriptions(), pub)
+
+			o := fixture.FixOrchestration()
+			o.OrchestrationID = "orchestration-id"
+			o.Type = orchestration.UpgradeKymaOrchestration
+			o.State = tc.state
+
+			// when
+			err := notifier.OnOrchestrationStateChanged(context.Background(), internalOrchestration.StateChanged{
+				OldState:      tc.oldState,
+				Orchestration: o,
+			})
+
+			// then
+			require.NoError(t, err)
+			if tc.expectedType == "" {
+				assert.Empty(t, pub.events)
+				return
+			}
+			// the orchestrations are not related to a single plan
+			assert.Equal(t, []string{"all"}, pub.subscriptionIDs())
+			assert.Equal(t, tc.expectedType, pub.events[0].Notification.Type)
+			require.NotNil(t, pub.events[0].Notification.Orchestration)
+			assert.Equal(t, "orchestration-id", pub.events[0].Notification.Orchestration.OrchestrationID)
+			assert.Equal(t, string(orchestration.UpgradeKymaOrchestration), pub.events[0].Notification.Orchestration.Type)
+		})
+	}
+}
+
+func fixOperations(state domain.LastOperationState) (internal.ProvisioningOperation, internal.ProvisioningOperation) {
+	oldOperation := fixture.FixProvisioningOperation(operationID, instanceID)
+	oldOperation.State = domain.InProgress
+	oldOperation.ProvisioningParameters.PlanID = broker.AzurePlanID
+	oldOperation.ProvisioningParameters.ErsContext.GlobalAccountID = globalAccountID
+	operation := oldOperation
+	operation.State = state
+	operation.UpdatedAt = time.Now()
+
+	return oldOperation, operation
+}
+
+func insertSubscription(t *testing.T, st storage.BrokerStorage, id string, filter internal.WebhookFilter) {
+	err := st.WebhookSubscriptions().Insert(internal.WebhookSubscription{
+		ID:        id,
+		URL:       "https://example.com/" + id,
+		Secret:    "secret-" + id,
+		Filter:    filter,
+		CreatedAt: time.Now(),
+	})
+	require.NoError(t, err)
+}
+
+type publisherMock struct {
+	events []DeliveryRequested
+}
+
+func (p *publisherMock) Publish(_ context.Context, ev interface{}) {
+	p.events = append(p.events, ev.(DeliveryRequested))
+}
+
+func (p *publisherMock) subscriptionIDs() []string {
+	ids := make([]string, 0, len(p.events))
+	for _, ev := range p.events {
+		ids = append(ids, ev.SubscriptionID)
+	}
+	return ids
+}
@@ -0,0 +1,115 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/webhook"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+type Config struct {
+	// Timeout is the timeout of the single notification request
+	Timeout time.Duration `envconfig:"default=10s"`
+}
+
+// Sender delivers the notifications to the webhooks. Every attempt is stored in the delivery log of the subscription,
+// the failed delivery is retried by the event broker.
+type Sender struct {
+	subscriptions storage.WebhookSubscriptions
+	deliveries    storage.WebhookDeliveries
+	httpClient    *http.Client
+	log           logrus.FieldLogger
+}
+
+func NewSender(subscriptions storage.WebhookSubscriptions, deliveries storage.WebhookDeliveries, cfg Config, log logrus.FieldLogger) *Sender {
+	return &Sender{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		httpClient:    &http.Client{Timeout: cfg.Timeout},
+		log:           log.WithField("service", "webhookSender"),
+	}
+}
+
+func (s *Sender) Subscribe(sub event.Subscriber) {
+	sub.Subscribe(senderSubscriberName, DeliveryRequested{}, s.OnDeliveryRequested)
+}
+
+func (s *Sender) OnDeliveryRequested(ctx context.Context, ev interface{}) error {
+	e, ok := ev.(DeliveryRequested)
+	if !ok {
+		return fmt.Errorf("expected webhook.DeliveryRequested but got %+v", ev)
+	}
+
+	subscription, err := s.subscriptions.GetByID(e.SubscriptionID)
+	switch {
+	case err == nil:
+	case dberr.IsNotFound(err):
+		s.log.Infof("Skipping notification %s, webhook subscription %s was removed", e.Notification.ID, e.SubscriptionID)
+		return nil
+	default:
+		return errors.Wrapf(err, "while getting webhook subscription %s", e.SubscriptionID)
+	}
+
+	statusCode, sendErr := s.send(ctx, *subscription, e.Notification)
+	delivery := internal.WebhookDelivery{
+		ID:             uuid.New().String(),
+		SubscriptionID: subscription.ID,
+		NotificationID: e.Notification.ID,
+		EventType:      e.Notification.Type,
+		StatusCode:     statusCode,
+		CreatedAt:      time.Now(),
+	}
+	if sendErr != nil {
+		delivery.Error = sendErr.Error()
+	}
+	if err := s.deliveries.Insert(delivery); err != nil {
+		s.log.Errorf("while storing delivery of notification %s to webhook subscription %s: %s", e.Notification.ID, subscription.ID, err)
+	}
+
+	return sendErr
+}
+
+// send posts the signed notification and returns the status code of the response
+func (s *Sender) send(ctx context.Context, subscription internal.WebhookSubscription, notification webhook.Notification) (int, error) {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return 0, errors.Wrap(err, "while marshalling notification")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, errors.Wrap(err, "while creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.EventTypeHeader, notification.Type)
+	req.Header.Set(webhook.DeliveryHeader, notification.ID)
+	req.Header.Set(webhook.SignatureHeader, webhook.Signature(subscription.Secret, body))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, errors.Wrapf(err, "while calling webhook %s", subscription.URL)
+	}
+	defer func() {
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("webhook %s responded with status code %d", subscription.URL, resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,125 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/webhook"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSender_OnDeliveryRequested(t *testing.T) {
+	t.Run("should send signed notification and record the delivery", func(t *testing.T) {
+		// given
+		var received *http.Request
+		var body []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r
+			body, _ = ioutil.ReadAll(r.Body)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		st := storage.NewMemoryStorage()
+		subscription := insertSubscriptionForURL(t, st, server.URL)
+		sender := NewSender(st.WebhookSubscriptions(), st.WebhookDeliveries(), Config{Timeout: time.Second}, logrus.New())
+
+		// when
+		err := sender.OnDeliveryRequested(context.Background(), DeliveryRequested{
+			SubscriptionID: subscription.ID,
+			Notification:   fixNotification(),
+		})
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, received)
+		assert.Equal(t, webhook.ProvisioningSucceeded, received.Header.Get(webhook.EventTypeHeader))
+		assert.Equal(t, "notification-id", received.Header.Get(webhook.DeliveryHeader))
+		assert.Equal(t, webhook.Signature(subscription.Secret, body), received.Header.Get(webhook.SignatureHeader))
+
+		var notification webhook.Notification
+		require.NoError(t, json.Unmarshal(body, &notification))
+		assert.Equal(t, instanceID, notification.Runtime.InstanceID)
+
+		deliveries, err := st.WebhookDeliveries().ListBySubscriptionID(subscription.ID)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, http.StatusAccepted, deliveries[0].StatusCode)
+		assert.Equal(t, "notification-id", deliveries[0].NotificationID)
+		assert.Empty(t, deliveries[0].Error)
+	})
+
+	t.Run("should return error for the failed delivery", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		st := storage.NewMemoryStorage()
+		subscription := insertSubscriptionForURL(t, st, server.URL)
+		sender := NewSender(st.WebhookSubscriptions(), st.WebhookDeliveries(), Config{Timeout: time.Second}, logrus.New())
+
+		// when
+		err := sender.OnDeliveryRequested(context.Background(), DeliveryRequested{
+			SubscriptionID: subscription.ID,
+			Notification:   fixNotification(),
+		})
+
+		// then
+		assert.Error(t, err)
+		deliveries, err := st.WebhookDeliveries().ListBySubscriptionID(subscription.ID)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, http.StatusServiceUnavailable, deliveries[0].StatusCode)
+		assert.NotEmpty(t, deliveries[0].Error)
+	})
+
+	t.Run("should skip the notification of the removed subscription", func(t *testing.T) {
+		// given
+		st := storage.NewMemoryStorage()
+		sender := NewSender(st.WebhookSubscriptions(), st.WebhookDeliveries(), Config{Timeout: time.Second}, logrus.New())
+
+		// when
+		err := sender.OnDeliveryRequested(context.Background(), DeliveryRequested{
+			SubscriptionID: "removed",
+			Notification:   fixNotification(),
+		})
+
+		// then
+		assert.NoError(t, err)
+	})
+}
+
+func insertSubscriptionForURL(t *testing.T, st storage.BrokerStorage, url string) internal.WebhookSubscription {
+	subscription := internal.WebhookSubscription{
+		ID:        "subscription-id",
+		URL:       url,
+		Secret:    "secret",
+		CreatedAt: time.Now(),
+	}
+	require.NoError(t, st.WebhookSubscriptions().Insert(subscription))
+	return subscription
+}
+
+func fixNotification() webhook.Notification {
+	return webhook.Notification{
+		ID:        "notification-id",
+		Type:      webhook.ProvisioningSucceeded,
+		CreatedAt: time.Now(),
+		Runtime: &webhook.RuntimeData{
+			InstanceID:  instanceID,
+			OperationID: operationID,
+		},
+	}
+}
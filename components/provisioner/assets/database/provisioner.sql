@@ -60,7 +60,8 @@ CREATE TYPE operation_type AS ENUM (
     'DEPROVISION',
     'RECONNECT_RUNTIME',
     'UPGRADE_SHOOT',
-    'HIBERNATE'
+    'HIBERNATE',
+    'WAKE_UP'
     );
 
 CREATE TABLE operation
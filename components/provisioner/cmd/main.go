@@ -73,6 +73,7 @@ type config struct {
 	ProvisioningTimeout   queue.ProvisioningTimeouts
 	DeprovisioningTimeout queue.DeprovisioningTimeouts
 	HibernationTimeout    queue.HibernationTimeouts
+	WakeUpTimeout         queue.WakeUpTimeouts
 
 	OperatorRoleBinding provisioningStages.OperatorRoleBinding
 
@@ -202,6 +203,8 @@ func main() {
 
 	hibernationQueue := queue.CreateHibernationQueue(cfg.HibernationTimeout, dbsFactory, directorClient, shootClient)
 
+	wakeUpQueue := queue.CreateWakeUpQueue(cfg.WakeUpTimeout, dbsFactory, directorClient, shootClient, installationService)
+
 	provisioner := gardener.NewProvisioner(gardenerNamespace, shootClient, dbsFactory, cfg.Gardener.AuditLogsPolicyConfigMap, cfg.Gardener.MaintenanceWindowConfigPath)
 	shootController, err := newShootController(gardenerNamespace, gardenerClusterConfig, dbsFactory, cfg.Gardener.AuditLogsTenantConfigPath)
 	exitOnError(err, "Failed to create Shoot controller.")
@@ -229,6 +232,7 @@ func main() {
 		upgradeQueue,
 		shootUpgradeQueue,
 		hibernationQueue,
+		wakeUpQueue,
 		cfg.Gardener.DefaultEnableKubernetesVersionAutoUpdate,
 		cfg.Gardener.DefaultEnableMachineImageVersionAutoUpdate,
 		cfg.Gardener.ForceAllowPrivilegedContainers)
@@ -253,6 +257,8 @@ func main() {
 
 	hibernationQueue.Run(ctx.Done())
 
+	wakeUpQueue.Run(ctx.Done())
+
 	gqlCfg := gqlschema.Config{
 		Resolvers: resolver,
 	}
@@ -302,14 +308,14 @@ func main() {
 	}()
 
 	if cfg.EnqueueInProgressOperations {
-		err = enqueueOperationsInProgress(dbsFactory, provisioningQueue, deprovisioningQueue, upgradeQueue, shootUpgradeQueue, hibernationQueue)
+		err = enqueueOperationsInProgress(dbsFactory, provisioningQueue, deprovisioningQueue, upgradeQueue, shootUpgradeQueue, hibernationQueue, wakeUpQueue)
 		exitOnError(err, "Failed to enqueue in progress operations")
 	}
 
 	wg.Wait()
 }
 
-func enqueueOperationsInProgress(dbFactory dbsession.Factory, provisioningQueue, deprovisioningQueue, upgradeQueue, shootUpgradeQueue, hibernationQueue queue.OperationQueue) error {
+func enqueueOperationsInProgress(dbFactory dbsession.Factory, provisioningQueue, deprovisioningQueue, upgradeQueue, shootUpgradeQueue, hibernationQueue, wakeUpQueue queue.OperationQueue) error {
 	readSession := dbFactory.NewReadSession()
 
 	var inProgressOps []model.Operation
@@ -350,6 +356,10 @@ func enqueueOperationsInProgress(dbFactory dbsession.Factory, provisioningQueue,
 		if op.Type == model.Hibernate {
 			hibernationQueue.Add(op.ID)
 		}
+
+		if op.Type == model.WakeUp {
+			wakeUpQueue.Add(op.ID)
+		}
 	}
 
 	return nil
@@ -179,6 +179,9 @@ func TestProvisioning_ProvisionRuntimeWithDatabase(t *testing.T) {
 	shootHibernationQueue := queue.CreateHibernationQueue(testHibernationTimeouts(), dbsFactory, directorServiceMock, shootInterface)
 	shootHibernationQueue.Run(queueCtx.Done())
 
+	shootWakeUpQueue := queue.CreateWakeUpQueue(testWakeUpTimeouts(), dbsFactory, directorServiceMock, shootInterface, installationServiceMock)
+	shootWakeUpQueue.Run(queueCtx.Done())
+
 	controler, err := gardener.NewShootController(mgr, dbsFactory, auditLogsConfigPath)
 	require.NoError(t, err)
 
@@ -226,7 +229,7 @@ func TestProvisioning_ProvisionRuntimeWithDatabase(t *testing.T) {
 			inputConverter := provisioning.NewInputConverter(uuidGenerator, provider, "Project", defaultEnableKubernetesVersionAutoUpdate, defaultEnableMachineImageVersionAutoUpdate, forceAllowPrivilegedContainers)
 			graphQLConverter := provisioning.NewGraphQLConverter()
 
-			provisioningService := provisioning.NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, dbsFactory, provisioner, uuidGenerator, provisioningQueue, deprovisioningQueue, upgradeQueue, shootUpgradeQueue, shootHibernationQueue)
+			provisioningService := provisioning.NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, dbsFactory, provisioner, uuidGenerator, provisioningQueue, deprovisioningQueue, upgradeQueue, shootUpgradeQueue, shootHibernationQueue, shootWakeUpQueue)
 
 			validator := api.NewValidator(dbsFactory.NewReadSession())
 
@@ -245,6 +248,8 @@ func TestProvisioning_ProvisionRuntimeWithDatabase(t *testing.T) {
 
 			testHibernateRuntime(t, ctx, resolver, dbsFactory, config.runtimeID, shootInterface)
 
+			testWakeUpRuntime(t, ctx, resolver, dbsFactory, config.runtimeID, shootInterface)
+
 			testDeprovisionRuntime(t, ctx, resolver, dbsFactory, config.runtimeID, shootInterface)
 		})
 	}
@@ -503,6 +508,32 @@ func testHibernateRuntime(t *testing.T, ctx context.Context, resolver *api.Resol
 	assert.Equal(t, strings.ToUpper(gqlschema.OperationStateSucceeded.String()), string(operation.State))
 }
 
+func testWakeUpRuntime(t *testing.T, ctx context.Context, resolver *api.Resolver, dbsFactory dbsession.Factory, runtimeID string, shootInterface gardener_apis.ShootInterface) {
+
+	list, err := shootInterface.List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	shoot := &list.Items[0]
+
+	readSession := dbsFactory.NewReadSession()
+
+	// when
+	wakeUpOperation, err := resolver.WakeUpRuntime(ctx, runtimeID)
+	require.NoError(t, err)
+	require.NotEmpty(t, wakeUpOperation.ID)
+
+	// when
+	simulateWakeUp(t, shootInterface, shoot)
+
+	// when
+	// wait for Shoot to update
+	time.Sleep(8 * waitPeriod)
+
+	// assert database content
+	operation, err := readSession.GetOperation(*wakeUpOperation.ID)
+	require.NoError(t, err)
+	assert.Equal(t, strings.ToUpper(gqlschema.OperationStateSucceeded.String()), string(operation.State))
+}
+
 func fixOperationStatusProvisioned(runtimeId, operationId *string) *gqlschema.OperationStatus {
 	return &gqlschema.OperationStatus{
 		ID:        operationId,
@@ -549,6 +580,13 @@ func testHibernationTimeouts() queue.HibernationTimeouts {
 	}
 }
 
+func testWakeUpTimeouts() queue.WakeUpTimeouts {
+	return queue.WakeUpTimeouts{
+		WaitingForClusterWakeUp: 5 * time.Minute,
+		Installation:            5 * time.Minute,
+	}
+}
+
 func removeFinalizers(t *testing.T, shootInterface gardener_apis.ShootInterface, shoot *gardener_types.Shoot) *gardener_types.Shoot {
 	shoot.SetFinalizers([]string{})
 
@@ -611,6 +649,19 @@ func simulateHibernation(t *testing.T, f gardener_apis.ShootInterface, shoot *ga
 	}
 }
 
+func simulateWakeUp(t *testing.T, f gardener_apis.ShootInterface, shoot *gardener_types.Shoot) {
+	if shoot != nil {
+		s, err := f.Get(context.Background(), shoot.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+
+		s.Status.IsHibernated = false
+		s.Status.LastOperation = &gardener_types.LastOperation{State: gardener_types.LastOperationStateSucceeded}
+
+		_, err = f.Update(context.Background(), s, metav1.UpdateOptions{})
+		require.NoError(t, err)
+	}
+}
+
 func createKubeconfigSecret(t *testing.T, s v1core.SecretInterface, shootName string) {
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -164,6 +164,7 @@ func TestGardenerProvisioner_UpgradeCluster(t *testing.T) {
 		InNamespace(gardenerNamespace).
 		WithKubernetesVersion("1.16").
 		WithAutoUpdate(false, false).
+		WithSecretBindingName("secret").
 		WithWorkers(
 			testkit.NewTestWorker("peon").
 				WithMachineType("n1-standard-4").
@@ -323,6 +324,92 @@ func TestGardenerProvisioner_HibernateCluster(t *testing.T) {
 	})
 }
 
+func TestGardenerProvisioner_WakeUpCluster(t *testing.T) {
+
+	gcpGardenerConfig, err := model.NewGCPGardenerConfig(&gqlschema.GCPProviderConfigInput{Zones: []string{"zone-1"}})
+	require.NoError(t, err)
+	cluster := newClusterConfig(clusterName, nil, gcpGardenerConfig, region)
+
+	t.Run("should return error if failed to get shoot", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset()
+		shootClient := clientset.CoreV1beta1().Shoots(gardenerNamespace)
+
+		sessionFactory := &sessionMocks.Factory{}
+		provisioner := NewProvisioner(gardenerNamespace, shootClient, sessionFactory, auditLogsPolicyCMName, "")
+
+		// when
+		apperr := provisioner.WakeUpCluster(cluster.ID, cluster.ClusterConfig)
+
+		// then
+		require.Error(t, apperr)
+		assert.Equal(t, apperrors.CodeInternal, apperr.Code())
+	})
+
+	t.Run("should return error if cluster is not hibernated", func(t *testing.T) {
+		shoot := testkit.NewTestShoot(clusterName).
+			InNamespace(gardenerNamespace).
+			ToShoot()
+
+		clientset := fake.NewSimpleClientset(shoot)
+		shootClient := clientset.CoreV1beta1().Shoots(gardenerNamespace)
+
+		sessionFactory := &sessionMocks.Factory{}
+		provisioner := NewProvisioner(gardenerNamespace, shootClient, sessionFactory, auditLogsPolicyCMName, "")
+
+		// when
+		apperr := provisioner.WakeUpCluster(cluster.ID, cluster.ClusterConfig)
+
+		// then
+		require.Error(t, apperr)
+		assert.Equal(t, apperrors.CodeBadRequest, apperr.Code())
+	})
+
+	t.Run("should wake up cluster", func(t *testing.T) {
+		shoot := testkit.NewTestShoot(clusterName).
+			InNamespace(gardenerNamespace).
+			WithHibernationState(true, true).
+			WithHibernationEnabled(true).
+			ToShoot()
+
+		clientset := fake.NewSimpleClientset(shoot)
+		shootClient := clientset.CoreV1beta1().Shoots(gardenerNamespace)
+
+		sessionFactory := &sessionMocks.Factory{}
+		provisioner := NewProvisioner(gardenerNamespace, shootClient, sessionFactory, auditLogsPolicyCMName, "")
+
+		// when
+		apperr := provisioner.WakeUpCluster(cluster.ID, cluster.ClusterConfig)
+
+		// then
+		require.NoError(t, apperr)
+
+		updatedShoot, err := shootClient.Get(context.Background(), clusterName, v1.GetOptions{})
+		require.NoError(t, err)
+		assert.False(t, *updatedShoot.Spec.Hibernation.Enabled)
+	})
+
+	t.Run("should return error if failed to wake up cluster", func(t *testing.T) {
+		shoot := testkit.NewTestShoot(clusterName).
+			InNamespace(gardenerNamespace).
+			WithHibernationEnabled(true).
+			ToShoot()
+
+		shootClient := &gardenerMocks.Client{}
+
+		shootClient.On("Get", mock.Anything, clusterName, mock.Anything).Return(shoot, nil)
+		shootClient.On("Update", mock.Anything, shoot, mock.Anything).Return(nil, errors.New("some error"))
+
+		sessionFactory := &sessionMocks.Factory{}
+		provisioner := NewProvisioner(gardenerNamespace, shootClient, sessionFactory, auditLogsPolicyCMName, "")
+
+		// when
+		apperr := provisioner.WakeUpCluster(cluster.ID, cluster.ClusterConfig)
+
+		// then
+		require.Error(t, apperr)
+	})
+}
+
 func TestGardenerProvisioner_GetHibernationStatus(t *testing.T) {
 	gcpGardenerConfig, err := model.NewGCPGardenerConfig(&gqlschema.GCPProviderConfigInput{Zones: []string{"zone-1"}})
 	require.NoError(t, err)
@@ -383,6 +383,10 @@ func updateShootConfig(upgradeConfig GardenerConfig, shoot *gardener_types.Shoot
 		shoot.Spec.Purpose = &purpose
 	}
 
+	if upgradeConfig.TargetSecret != "" {
+		shoot.Spec.SecretBindingName = upgradeConfig.TargetSecret
+	}
+
 	shoot.Spec.Maintenance.AutoUpdate.KubernetesVersion = upgradeConfig.EnableKubernetesVersionAutoUpdate
 	shoot.Spec.Maintenance.AutoUpdate.MachineImageVersion = upgradeConfig.EnableMachineImageVersionAutoUpdate
 
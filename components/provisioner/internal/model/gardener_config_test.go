@@ -379,6 +379,7 @@ func TestEditShootConfig(t *testing.T) {
 		WithKubernetesVersion("1.15").
 		WithAutoUpdate(true, false).
 		WithPurpose("testing").
+		WithSecretBindingName("gardener-secret").
 		WithWorkers(
 			testkit.NewTestWorker("peon").
 				WithMachineType("machine").
@@ -21,6 +21,7 @@ const (
 	Deprovision      OperationType = "DEPROVISION"
 	ReconnectRuntime OperationType = "RECONNECT_RUNTIME"
 	Hibernate        OperationType = "HIBERNATE"
+	WakeUp           OperationType = "WAKE_UP"
 )
 
 type OperationStage string
@@ -47,6 +48,8 @@ const (
 
 	WaitForHibernation OperationStage = "WaitForHibernation"
 
+	WaitForWakeUp OperationStage = "WaitForWakeUp"
+
 	FinishedStage OperationStage = "Finished"
 )
 
@@ -16,6 +16,7 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/stages/provisioning"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/stages/shootupgrade"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/stages/upgrade"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/stages/wakeup"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/runtime"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util/k8s"
@@ -45,6 +46,11 @@ type HibernationTimeouts struct {
 	WaitingForClusterHibernation time.Duration `envconfig:"default=60m"`
 }
 
+type WakeUpTimeouts struct {
+	WaitingForClusterWakeUp time.Duration `envconfig:"default=60m"`
+	Installation            time.Duration `envconfig:"default=60m"`
+}
+
 func CreateProvisioningQueue(
 	timeouts ProvisioningTimeouts,
 	factory dbsession.Factory,
@@ -190,3 +196,29 @@ func CreateHibernationQueue(
 
 	return NewQueue(hibernateClusterExecutor)
 }
+
+func CreateWakeUpQueue(
+	timeouts WakeUpTimeouts,
+	factory dbsession.Factory,
+	directorClient director.DirectorClient,
+	shootClient gardener_apis.ShootInterface,
+	installationClient installation.Service) OperationQueue {
+
+	waitForInstallation := provisioning.NewWaitForInstallationStep(installationClient, model.FinishedStage, timeouts.Installation)
+	waitForWakeUp := wakeup.NewWaitForWakeUpStep(shootClient, waitForInstallation.Name(), timeouts.WaitingForClusterWakeUp)
+
+	wakeUpSteps := map[model.OperationStage]operations.Step{
+		model.WaitForWakeUp:          waitForWakeUp,
+		model.WaitingForInstallation: waitForInstallation,
+	}
+
+	wakeUpClusterExecutor := operations.NewExecutor(
+		factory.NewReadWriteSession(),
+		model.WakeUp,
+		wakeUpSteps,
+		failure.NewNoopFailureHandler(),
+		directorClient,
+	)
+
+	return NewQueue(wakeUpClusterExecutor)
+}
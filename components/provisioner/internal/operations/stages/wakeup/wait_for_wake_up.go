@@ -0,0 +1,71 @@
+package wakeup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gardener_types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/operations"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//go:generate mockery -name=GardenerClient
+type GardenerClient interface {
+	Get(ctx context.Context, name string, options v1.GetOptions) (*gardener_types.Shoot, error)
+}
+
+type WaitForWakeUp struct {
+	gardenerClient GardenerClient
+	nextStep       model.OperationStage
+	timeLimit      time.Duration
+}
+
+func NewWaitForWakeUpStep(gardenerClient GardenerClient, nextStep model.OperationStage, timeLimit time.Duration) *WaitForWakeUp {
+	return &WaitForWakeUp{
+		gardenerClient: gardenerClient,
+		nextStep:       nextStep,
+		timeLimit:      timeLimit,
+	}
+}
+
+func (c *WaitForWakeUp) Name() model.OperationStage {
+	return model.WaitForWakeUp
+}
+
+func (c *WaitForWakeUp) TimeLimit() time.Duration {
+	return c.timeLimit
+}
+
+func (c *WaitForWakeUp) Run(cluster model.Cluster, operation model.Operation, log logrus.FieldLogger) (operations.StageResult, error) {
+
+	log.Debugf("Starting WaitForWakeUp stage for %s ...", cluster.ID)
+	shoot, err := c.gardenerClient.Get(context.Background(), cluster.ClusterConfig.Name, v1.GetOptions{})
+	if err != nil {
+		return operations.StageResult{}, err
+	}
+
+	lastOperation := shoot.Status.LastOperation
+	if lastOperation != nil && lastOperation.State == gardener_types.LastOperationStateFailed {
+		err := fmt.Errorf(fmt.Sprintf("Cluster wake up failed. Last Shoot state: %s, Shoot description: %s", lastOperation.State, lastOperation.Description))
+		return operations.StageResult{}, operations.NewNonRecoverableError(err)
+	}
+
+	// the shoot is reconciled when it is not hibernated anymore and the last operation succeeded
+	if !shoot.Status.IsHibernated && lastOperation != nil && lastOperation.State == gardener_types.LastOperationStateSucceeded {
+		log.Debugf("Cluster: %s is woken up, proceeding to the next stage ...", cluster.ID)
+		return operations.StageResult{
+			Stage: c.nextStep,
+			Delay: 0,
+		}, nil
+	}
+
+	log.Debugf("Cluster: %s is still hibernated ...", cluster.ID)
+
+	return operations.StageResult{
+		Stage: c.Name(),
+		Delay: 30 * time.Second,
+	}, nil
+}
@@ -141,6 +141,11 @@ func (c converter) UpgradeShootInputToGardenerConfig(input gqlschema.GardenerUpg
 		purpose = input.Purpose
 	}
 
+	targetSecret := config.TargetSecret
+	if util.NotNilOrEmpty(input.TargetSecret) {
+		targetSecret = *input.TargetSecret
+	}
+
 	return model.GardenerConfig{
 		ID:                        config.ID,
 		ClusterID:                 config.ClusterID,
@@ -148,7 +153,7 @@ func (c converter) UpgradeShootInputToGardenerConfig(input gqlschema.GardenerUpg
 		ProjectName:               config.ProjectName,
 		Provider:                  config.Provider,
 		Seed:                      config.Seed,
-		TargetSecret:              config.TargetSecret,
+		TargetSecret:              targetSecret,
 		Region:                    config.Region,
 		LicenceType:               config.LicenceType,
 		AllowPrivilegedContainers: config.AllowPrivilegedContainers,
@@ -618,6 +618,33 @@ func Test_UpgradeShootInputToGardenerConfig(t *testing.T) {
 				MaxUnavailable:    1,
 			},
 		},
+		{description: "shoot upgrade with new target secret",
+			upgradeInput: newUpgradeShootInputWithTargetSecret(testingPurpose, "new-secret"),
+			initialConfig: model.GardenerConfig{
+				KubernetesVersion: "version",
+				VolumeSizeGB:      1,
+				DiskType:          "ssd",
+				MachineType:       "1",
+				Purpose:           &evaluationPurpose,
+				TargetSecret:      "secret",
+				AutoScalerMin:     1,
+				AutoScalerMax:     2,
+				MaxSurge:          1,
+				MaxUnavailable:    1,
+			},
+			upgradedConfig: model.GardenerConfig{
+				KubernetesVersion: "version2",
+				VolumeSizeGB:      50,
+				DiskType:          "papyrus",
+				MachineType:       "new-machine",
+				Purpose:           &testingPurpose,
+				TargetSecret:      "new-secret",
+				AutoScalerMin:     2,
+				AutoScalerMax:     6,
+				MaxSurge:          2,
+				MaxUnavailable:    1,
+			},
+		},
 		{description: "shoot upgrade with nil values",
 			upgradeInput: newUpgradeShootInputWithNilValues(),
 			initialConfig: model.GardenerConfig{
@@ -730,6 +757,12 @@ func newUpgradeShootInput(newPurpose string) gqlschema.UpgradeShootInput {
 	}
 }
 
+func newUpgradeShootInputWithTargetSecret(newPurpose, targetSecret string) gqlschema.UpgradeShootInput {
+	input := newUpgradeShootInput(newPurpose)
+	input.GardenerConfig.TargetSecret = &targetSecret
+	return input
+}
+
 func newUpgradeShootInputWithNilValues() gqlschema.UpgradeShootInput {
 	return gqlschema.UpgradeShootInput{
 		GardenerConfig: &gqlschema.GardenerUpgradeInput{
@@ -76,6 +76,7 @@ func (ws writeSession) UpdateGardenerClusterConfig(config model.GardenerConfig)
 		Set("seed", config.Seed).
 		Set("region", config.Region).
 		Set("provider", config.Provider).
+		Set("target_secret", config.TargetSecret).
 		Set("machine_type", config.MachineType).
 		Set("disk_type", config.DiskType).
 		Set("volume_size_gb", config.VolumeSizeGB).
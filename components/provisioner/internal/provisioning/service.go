@@ -34,6 +34,7 @@ type Service interface {
 	RuntimeOperationStatus(id string) (*gqlschema.OperationStatus, apperrors.AppError)
 	RollBackLastUpgrade(runtimeID string) (*gqlschema.RuntimeStatus, apperrors.AppError)
 	HibernateCluster(clusterID string) (*gqlschema.OperationStatus, apperrors.AppError)
+	WakeUpCluster(clusterID string) (*gqlschema.OperationStatus, apperrors.AppError)
 }
 
 //go:generate mockery -name=Provisioner
@@ -43,6 +44,7 @@ type Provisioner interface {
 	UpgradeCluster(clusterID string, upgradeConfig model.GardenerConfig) apperrors.AppError
 	HibernateCluster(clusterID string, upgradeConfig model.GardenerConfig) apperrors.AppError
 	GetHibernationStatus(clusterID string, gardenerConfig model.GardenerConfig) (model.HibernationStatus, apperrors.AppError)
+	WakeUpCluster(clusterID string, gardenerConfig model.GardenerConfig) apperrors.AppError
 }
 
 type service struct {
@@ -59,6 +61,7 @@ type service struct {
 	upgradeQueue        queue.OperationQueue
 	shootUpgradeQueue   queue.OperationQueue
 	hibernationQueue    queue.OperationQueue
+	wakeUpQueue         queue.OperationQueue
 }
 
 func NewProvisioningService(
@@ -73,6 +76,7 @@ func NewProvisioningService(
 	upgradeQueue queue.OperationQueue,
 	shootUpgradeQueue queue.OperationQueue,
 	hibernationQueue queue.OperationQueue,
+	wakeUpQueue queue.OperationQueue,
 
 ) Service {
 	return &service{
@@ -87,6 +91,7 @@ func NewProvisioningService(
 		upgradeQueue:        upgradeQueue,
 		shootUpgradeQueue:   shootUpgradeQueue,
 		hibernationQueue:    hibernationQueue,
+		wakeUpQueue:         wakeUpQueue,
 	}
 }
 
@@ -270,6 +275,47 @@ func (r *service) HibernateCluster(runtimeID string) (*gqlschema.OperationStatus
 	return r.graphQLConverter.OperationStatusToGQLOperationStatus(operation), nil
 }
 
+func (r *service) WakeUpCluster(runtimeID string) (*gqlschema.OperationStatus, apperrors.AppError) {
+	log.Infof("Starting wake up for Runtime '%s'...", runtimeID)
+
+	session := r.dbSessionFactory.NewReadSession()
+
+	err := r.verifyLastOperationFinished(session, runtimeID)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, dberr := session.GetCluster(runtimeID)
+	if dberr != nil {
+		return nil, apperrors.Internal("Failed to find shoot cluster to wake up in database: %s", dberr.Error())
+	}
+
+	txSession, dbErr := r.dbSessionFactory.NewSessionWithinTransaction()
+	if dbErr != nil {
+		return nil, apperrors.Internal("Failed to start database transaction: %s", dbErr.Error())
+	}
+	defer txSession.RollbackUnlessCommitted()
+
+	operation, dbError := r.setWakeUpStarted(txSession, cluster)
+	if dbError != nil {
+		return nil, apperrors.Internal("Failed to set wake up started: %s", dbError.Error())
+	}
+
+	err = r.provisioner.WakeUpCluster(cluster.ID, cluster.ClusterConfig)
+	if err != nil {
+		return nil, apperrors.Internal("Failed to wake up Cluster: %s", err.Error())
+	}
+
+	dbErr = txSession.Commit()
+	if dbErr != nil {
+		return nil, apperrors.Internal("Failed to commit wake up transaction: %s", dbErr.Error())
+	}
+
+	r.wakeUpQueue.Add(operation.ID)
+
+	return r.graphQLConverter.OperationStatusToGQLOperationStatus(operation), nil
+}
+
 func (r *service) verifyLastOperationFinished(session dbsession.ReadSession, runtimeId string) apperrors.AppError {
 	lastOperation, dberr := session.GetLastOperation(runtimeId)
 	if dberr != nil {
@@ -507,6 +553,17 @@ func (r *service) setHibernationStarted(txSession dbsession.WriteSession, curren
 	return operation, nil
 }
 
+func (r *service) setWakeUpStarted(txSession dbsession.WriteSession, currentCluster model.Cluster) (model.Operation, dberrors.Error) {
+	log.Infof("Starting wake up operation")
+
+	operation, dbError := r.setOperationStarted(txSession, currentCluster.ID, model.WakeUp, model.WaitForWakeUp, time.Now(), "Starting wake up")
+	if dbError != nil {
+		return model.Operation{}, dbError.Append("Failed to start wake up operation")
+	}
+
+	return operation, nil
+}
+
 func (r *service) setOperationStarted(
 	dbSession dbsession.WriteSession,
 	runtimeID string,
@@ -64,6 +64,12 @@ func (ts *TestShoot) WithPurpose(purpose string) *TestShoot {
 	return ts
 }
 
+// WithSecretBindingName sets value of shoot.Spec.SecretBindingName field
+func (ts *TestShoot) WithSecretBindingName(secretBindingName string) *TestShoot {
+	ts.shoot.Spec.SecretBindingName = secretBindingName
+	return ts
+}
+
 // WithWorkers adds v1beta1 Workers to shoot.Spec.Provider.Workers.
 // See also testkit.TestWorker
 func (ts *TestShoot) WithWorkers(workers ...v1beta1.Worker) *TestShoot {
@@ -138,3 +144,12 @@ func (ts *TestShoot) WithHibernationState(hibernationPossible bool, hibernated b
 
 	return ts
 }
+
+// WithHibernationEnabled sets shoot.Spec.Hibernation.Enabled
+func (ts *TestShoot) WithHibernationEnabled(enabled bool) *TestShoot {
+	ts.shoot.Spec.Hibernation = &v1beta1.Hibernation{
+		Enabled: &enabled,
+	}
+
+	return ts
+}
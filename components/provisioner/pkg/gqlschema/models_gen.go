@@ -147,6 +147,7 @@ type GardenerUpgradeInput struct {
 	MaxSurge                            *int                   `json:"maxSurge"`
 	MaxUnavailable                      *int                   `json:"maxUnavailable"`
 	Purpose                             *string                `json:"purpose"`
+	TargetSecret                        *string                `json:"targetSecret"`
 	EnableKubernetesVersionAutoUpdate   *bool                  `json:"enableKubernetesVersionAutoUpdate"`
 	EnableMachineImageVersionAutoUpdate *bool                  `json:"enableMachineImageVersionAutoUpdate"`
 	ProviderSpecificConfig              *ProviderSpecificInput `json:"providerSpecificConfig"`
@@ -360,6 +361,7 @@ const (
 	OperationTypeDeprovision      OperationType = "Deprovision"
 	OperationTypeReconnectRuntime OperationType = "ReconnectRuntime"
 	OperationTypeHibernate        OperationType = "Hibernate"
+	OperationTypeWakeUp           OperationType = "WakeUp"
 )
 
 var AllOperationType = []OperationType{
@@ -369,11 +371,12 @@ var AllOperationType = []OperationType{
 	OperationTypeDeprovision,
 	OperationTypeReconnectRuntime,
 	OperationTypeHibernate,
+	OperationTypeWakeUp,
 }
 
 func (e OperationType) IsValid() bool {
 	switch e {
-	case OperationTypeProvision, OperationTypeUpgrade, OperationTypeUpgradeShoot, OperationTypeDeprovision, OperationTypeReconnectRuntime, OperationTypeHibernate:
+	case OperationTypeProvision, OperationTypeUpgrade, OperationTypeUpgradeShoot, OperationTypeDeprovision, OperationTypeReconnectRuntime, OperationTypeHibernate, OperationTypeWakeUp:
 		return true
 	}
 	return false
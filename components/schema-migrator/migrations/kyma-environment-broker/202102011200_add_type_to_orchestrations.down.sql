@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE orchestrations
+    DROP COLUMN type;
+
+COMMIT;
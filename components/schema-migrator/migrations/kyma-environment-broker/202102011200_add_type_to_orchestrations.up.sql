@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE orchestrations
+    ADD COLUMN type varchar(32) NOT NULL DEFAULT 'upgradeKyma';
+
+COMMIT;
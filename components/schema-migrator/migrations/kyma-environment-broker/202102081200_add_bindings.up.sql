@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS bindings (
+    binding_id varchar(255) PRIMARY KEY,
+    instance_id varchar(255) NOT NULL,
+    operation varchar(32) NOT NULL,
+    state varchar(32) NOT NULL,
+    description text,
+    kubeconfig text,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS bindings_by_instance_id ON bindings USING btree (instance_id);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE instances
+    DROP COLUMN plan_history;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE instances
+    ADD COLUMN plan_history text NOT NULL DEFAULT '[]';
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE instances
+    DROP COLUMN expires_at,
+    DROP COLUMN expiration_warned_at;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE instances
+    ADD COLUMN expires_at TIMESTAMPTZ NOT NULL DEFAULT '0001-01-01 00:00:00+00',
+    ADD COLUMN expiration_warned_at TIMESTAMPTZ NOT NULL DEFAULT '0001-01-01 00:00:00+00';
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS operation_events (
+    id varchar(255) PRIMARY KEY,
+    operation_id varchar(255) NOT NULL,
+    instance_id varchar(255),
+    step_name varchar(255) NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ NOT NULL,
+    duration bigint NOT NULL,
+    requeue_delay bigint NOT NULL,
+    error text,
+    old_state varchar(32),
+    new_state varchar(32),
+    description text
+);
+
+CREATE INDEX IF NOT EXISTS operation_events_by_operation_id ON operation_events USING btree (operation_id);
+
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id varchar(255) PRIMARY KEY,
+    type varchar(255) NOT NULL,
+    payload bytea NOT NULL,
+    subscriber varchar(255) NOT NULL,
+    state varchar(32) NOT NULL,
+    attempts integer NOT NULL,
+    last_error text,
+    created_at TIMESTAMPTZ NOT NULL,
+    next_attempt_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS outbox_events_by_subscriber_state_and_next_attempt_at ON outbox_events USING btree (subscriber, state, next_attempt_at);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE webhook_deliveries;
+DROP TABLE webhook_subscriptions;
+
+COMMIT;
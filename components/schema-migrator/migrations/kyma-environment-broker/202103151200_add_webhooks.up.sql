@@ -0,0 +1,23 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id varchar(255) PRIMARY KEY,
+    url text NOT NULL,
+    secret text NOT NULL,
+    filter text NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id varchar(255) PRIMARY KEY,
+    subscription_id varchar(255) NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    notification_id varchar(255) NOT NULL,
+    event_type varchar(255) NOT NULL,
+    status_code integer NOT NULL,
+    error text,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_by_subscription_id ON webhook_deliveries USING btree (subscription_id, created_at);
+
+COMMIT;
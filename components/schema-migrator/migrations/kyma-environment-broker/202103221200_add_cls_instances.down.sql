@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE cls_instance_references;
+DROP TABLE cls_instances;
+
+COMMIT;
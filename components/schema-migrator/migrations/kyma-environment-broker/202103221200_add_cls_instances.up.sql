@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS cls_instances (
+    id varchar(255) PRIMARY KEY,
+    version integer NOT NULL,
+    global_account_id varchar(255) NOT NULL UNIQUE,
+    region varchar(12) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS cls_instance_references (
+    cls_instance_id varchar(255) NOT NULL REFERENCES cls_instances (id) ON DELETE CASCADE,
+    skr_instance_id varchar(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (cls_instance_id, skr_instance_id)
+);
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS runtime_states_by_operation_id;
+DROP INDEX IF EXISTS operations_by_instance_id_and_created_at;
+DROP TABLE instance_archives;
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS instance_archives (
+    instance_id varchar(255) PRIMARY KEY,
+    runtime_id varchar(255),
+    global_account_id varchar(255) NOT NULL,
+    sub_account_id varchar(255),
+    service_plan_id varchar(255),
+    service_plan_name varchar(255),
+    provider_region varchar(32),
+    dashboard_url varchar(255),
+    data text NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    archived_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS operations_by_instance_id_and_created_at ON operations USING btree (instance_id, created_at);
+CREATE INDEX IF NOT EXISTS runtime_states_by_operation_id ON runtime_states USING btree (operation_id);
+
+COMMIT;
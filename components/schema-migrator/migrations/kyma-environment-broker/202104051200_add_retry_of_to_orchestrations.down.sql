@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP INDEX orchestrations_retry_of_idx;
+
+ALTER TABLE orchestrations
+    DROP COLUMN retry_of;
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE orchestrations
+    ADD COLUMN retry_of varchar(255);
+
+-- only one retry of an orchestration can be unfinished at a time
+CREATE UNIQUE INDEX orchestrations_retry_of_idx ON orchestrations (retry_of)
+    WHERE state IN ('pending', 'in progress', 'paused', 'canceling');
+
+COMMIT;
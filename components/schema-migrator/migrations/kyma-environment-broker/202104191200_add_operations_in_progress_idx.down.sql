@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP INDEX operations_in_progress_idx;
+
+COMMIT;
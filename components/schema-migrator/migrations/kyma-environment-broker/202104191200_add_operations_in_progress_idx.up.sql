@@ -0,0 +1,9 @@
+BEGIN;
+
+-- only one operation requested by the user can change an instance at a time, the upgrades started by
+-- the maintenance info are pending until they are picked up from the queue
+CREATE UNIQUE INDEX operations_in_progress_idx ON operations (instance_id)
+    WHERE (type = 'update' AND state = 'in progress')
+       OR (type = 'upgradeKyma' AND COALESCE(orchestration_id, '') = '' AND state IN ('pending', 'in progress'));
+
+COMMIT;
@@ -0,0 +1 @@
+ALTER TYPE operation_type ADD VALUE 'WAKE_UP' AFTER 'HIBERNATE';
\ No newline at end of file
@@ -0,0 +1,165 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/operation"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// OperationCommand represents an execution of the kcp operations command
+type OperationCommand struct {
+	cobraCmd *cobra.Command
+	log      logger.Logger
+	client   operation.Client
+	output   string
+	events   bool
+}
+
+var operationEventColumns = []printer.Column{
+	{
+		Header:    "STEP",
+		FieldSpec: "{.StepName}",
+	},
+	{
+		Header:         "STARTED AT",
+		FieldFormatter: operationEventStartedAt,
+	},
+	{
+		Header:    "DURATION",
+		FieldSpec: "{.Duration}",
+	},
+	{
+		Header:    "REQUEUE",
+		FieldSpec: "{.RequeueDelay}",
+	},
+	{
+		Header:         "STATE",
+		FieldFormatter: operationEventState,
+	},
+	{
+		Header:    "ERROR",
+		FieldSpec: "{.Error}",
+	},
+}
+
+var operationStateTpl = `Operation ID:       {{.OperationID}}
+Instance ID:        {{.InstanceID}}
+{{- if .OrchestrationID }}
+Orchestration ID:   {{.OrchestrationID}}
+{{- end }}
+Created At:         {{.CreatedAt}}
+Updated At:         {{.UpdatedAt}}
+State:              {{.State}}
+Description:        {{.Description}}
+`
+
+// NewOperationCmd constructs a new instance of OperationCommand and configures it in terms of a cobra.Command
+func NewOperationCmd() *cobra.Command {
+	cmd := OperationCommand{}
+	cobraCmd := &cobra.Command{
+		Use:     "operations id",
+		Aliases: []string{"operation", "op"},
+		Short:   "Displays Kyma Runtime operations.",
+		Long: `Displays the state of the given Kyma Runtime operation, such as provisioning, deprovisioning, or upgrade.
+If the --events option is provided, the command displays the event log of the operation instead. Every event describes a single processing of the operation step:
+when the step started, how long it took, after which time it is retried, the error it returned, and the state of the operation after it.`,
+		Example: `  kcp operations 0c4357f5-83e0-4b72-9472-49b5cd417c00           Display the state of the given operation.
+  kcp operations 0c4357f5-83e0-4b72-9472-49b5cd417c00 --events  Display the processed steps of the given operation.`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(_ *cobra.Command, _ []string) error { return cmd.Validate() },
+		RunE:    func(_ *cobra.Command, args []string) error { return cmd.Run(args[0]) },
+	}
+	cmd.cobraCmd = cobraCmd
+
+	SetOutputOpt(cobraCmd, &cmd.output)
+	cobraCmd.Flags().BoolVar(&cmd.events, "events", false, "Option that displays the event log of the operation.")
+	return cobraCmd
+}
+
+// Run executes the operations command
+func (cmd *OperationCommand) Run(operationID string) error {
+	cmd.log = logger.New()
+	cmd.client = operation.NewClient(cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLICredentialManager(cmd.log))
+
+	if cmd.events {
+		return cmd.showEvents(operationID)
+	}
+	return cmd.showOperation(operationID)
+}
+
+// Validate checks the input parameters of the operations command
+func (cmd *OperationCommand) Validate() error {
+	err := ValidateOutputOpt(cmd.output)
+	if err != nil {
+		return err
+	}
+	if cmd.output != tableOutput && cmd.output != jsonOutput {
+		return fmt.Errorf("unsupported output type: %s", cmd.output)
+	}
+	return nil
+}
+
+func (cmd *OperationCommand) showOperation(operationID string) error {
+	op, err := cmd.client.GetOperation(operationID)
+	if err != nil {
+		return errors.Wrap(err, "while getting operation")
+	}
+
+	switch cmd.output {
+	case tableOutput:
+		tmpl, err := template.New("operationState").Parse(operationStateTpl)
+		if err != nil {
+			return errors.Wrap(err, "while parsing operation template")
+		}
+		err = tmpl.Execute(os.Stdout, op)
+		if err != nil {
+			return errors.Wrap(err, "while printing operation")
+		}
+	case jsonOutput:
+		jp := printer.NewJSONPrinter("  ")
+		jp.PrintObj(op)
+	}
+
+	return nil
+}
+
+func (cmd *OperationCommand) showEvents(operationID string) error {
+	events, err := cmd.client.ListEvents(operationID)
+	if err != nil {
+		return errors.Wrap(err, "while listing operation events")
+	}
+
+	switch cmd.output {
+	case tableOutput:
+		tp, err := printer.NewTablePrinter(operationEventColumns, false)
+		if err != nil {
+			return err
+		}
+		return tp.PrintObj(events.Data)
+	case jsonOutput:
+		jp := printer.NewJSONPrinter("  ")
+		jp.PrintObj(events)
+	}
+
+	return nil
+}
+
+func operationEventStartedAt(obj interface{}) string {
+	e := obj.(operation.EventDTO)
+	return e.StartedAt.Format("2006/01/02 15:04:05")
+}
+
+// operationEventState returns the state of the operation after the step, together with the previous one if the step changed it
+func operationEventState(obj interface{}) string {
+	e := obj.(operation.EventDTO)
+	if e.OldState == e.NewState {
+		return e.NewState
+	}
+	return fmt.Sprintf("%s -> %s", e.OldState, e.NewState)
+}
@@ -18,6 +18,8 @@ import (
 
 const (
 	cancelCommand     = "cancel"
+	resumeCommand     = "resume"
+	retryCommand      = "retry"
 	operationsCommand = "operations"
 	opsCommand        = "ops"
 )
@@ -41,6 +43,7 @@ var cliStates = map[string]string{
 	"inprogress": orchestration.InProgress,
 	"canceled":   orchestration.Canceled,
 	"canceling":  orchestration.Canceling,
+	"paused":     orchestration.Paused,
 }
 
 var orchestrationColumns = []printer.Column{
@@ -90,7 +93,7 @@ var operationColumns = []printer.Column{
 }
 
 var orchestrationDetailsTpl = `Orchestration ID: {{.OrchestrationID}}
-Type:             kyma upgrade
+Type:             {{ orchestrationType . }}
 Created At:       {{.CreatedAt}}
 Updated At:       {{.UpdatedAt}}
 Dry Run:          {{.Parameters.DryRun}}
@@ -99,6 +102,28 @@ Description:      {{.Description}}
 Strategy:         {{.Parameters.Strategy.Type}}
 Schedule:         {{.Parameters.Strategy.Schedule}}
 Workers:          {{.Parameters.Strategy.Parallel.Workers}}
+{{- if eq .Parameters.Strategy.Type "staged" }}
+Canary Size:      {{.Parameters.Strategy.Staged.CanarySize}}
+Canary Percent:   {{.Parameters.Strategy.Staged.CanaryPercentage}}
+Wave Size:        {{.Parameters.Strategy.Staged.WaveSize}}
+Wave Percent:     {{.Parameters.Strategy.Staged.WavePercentage}}
+Soak Time:        {{.Parameters.Strategy.Staged.SoakTime}}
+{{- end }}
+{{- if .Parameters.Strategy.MaxFailures }}
+Pause Failures:   {{.Parameters.Strategy.MaxFailures}}
+{{- end }}
+{{- if .Parameters.Strategy.MaxFailureRatio }}
+Pause Ratio:      {{.Parameters.Strategy.MaxFailureRatio}}
+{{- end }}
+{{- if .Parameters.Strategy.StartTime }}
+Start Time:       {{.Parameters.Strategy.StartTime}}
+{{- end }}
+{{- if gt (len .Parameters.Strategy.Blackouts) 0 }}
+Blackouts:
+{{- range $i, $b := .Parameters.Strategy.Blackouts }}
+  {{ $b.Start }} - {{ $b.End }}
+{{- end }}
+{{- end }}
 Targets:
 {{- range $i, $t := .Parameters.Targets.Include }}
   {{ orchestrationTarget $t }}
@@ -129,15 +154,34 @@ Service Plan:       {{.ServicePlanName}}
 Maintenance Window: {{.MaintenanceWindowBegin}} - {{.MaintenanceWindowEnd}}
 State:              {{.State}}
 Description:        {{.Description}}
+{{- if .RetryOf }}
+Retry Of:           {{.RetryOf}}
+{{- end }}
 Kubernetes Version: {{.ClusterConfig.KubernetesVersion}}
 Kyma Version:       {{.KymaConfig.Version}}
+{{- with .KymaConfigDiff }}
+Kyma Config Diff:
+  Version: {{.CurrentVersion}} -> {{.TargetVersion}}
+{{- if .Configuration }}
+  Global Overrides:
+{{- range $i, $e := .Configuration }}
+    {{ configEntryDiff $e }}
+{{- end }}
+{{- end }}
+{{- range $i, $c := .Components }}
+  Component {{ $c.Component }} ({{ $c.Change }})
+{{- range $j, $e := $c.Configuration }}
+    {{ configEntryDiff $e }}
+{{- end }}
+{{- end }}
+{{- end }}
 `
 
 // NewOrchestrationCmd constructs a new instance of OrchestrationCommand and configures it in terms of a cobra.Command
 func NewOrchestrationCmd() *cobra.Command {
 	cmd := OrchestrationCommand{}
 	cobraCmd := &cobra.Command{
-		Use:     "orchestrations [id] [ops|operations] [cancel]",
+		Use:     "orchestrations [id] [ops|operations] [cancel|resume] [retry [operation-id...]]",
 		Aliases: []string{"orchestration", "o"},
 		Short:   "Displays Kyma Control Plane (KCP) orchestrations.",
 		Long: `Displays KCP orchestrations and their primary attributes, such as identifiers, type, state, parameters, or Runtime operations.
@@ -146,15 +190,20 @@ The command has the following modes:
   - When specifying an orchestration ID as an argument. In this mode, the command displays details about the specific orchestration.
       If the optional --operation flag is provided, it displays details of the specified Runtime operation within the orchestration.
   - When specifying an orchestration ID and ` + "`operations` or `ops`" + ` as arguments. In this mode, the command displays the Runtime operations for the given orchestration.
-  - When specifying an orchestration ID and ` + "`cancel`" + ` as arguments. In this mode, the command cancels the orchestration and all pending Runtime operations.`,
+  - When specifying an orchestration ID and ` + "`cancel`" + ` as arguments. In this mode, the command cancels the orchestration and all pending Runtime operations.
+  - When specifying an orchestration ID and ` + "`resume`" + ` as arguments. In this mode, the command resumes the paused orchestration and its pending Runtime operations.
+  - When specifying an orchestration ID and ` + "`retry`" + ` as arguments. In this mode, the command schedules a new orchestration with the same parameters, which retries the failed and canceled Runtime operations of the given orchestration.
+      If operation IDs are given after ` + "`retry`" + `, only these Runtime operations are retried.`,
 		Example: `  kcp orchestrations --state inprogress                                   Display all orchestrations which are in progress.
   kcp orchestration -o custom="Orchestration ID:{.OrchestrationID},STATE:{.State},CREATED AT:{.createdAt}"
                                                                           Display all orchestations with specific custom fields.
   kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00                  Display details about a specific orchestration.
   kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00 --operation OID  Display details of the specified Runtime operation within the orchestration.
   kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00 operations       Display the operations of the given orchestration.
-  kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00 cancel           Cancel the given orchestration.`,
-		Args:    cobra.MaximumNArgs(2),
+  kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00 cancel           Cancel the given orchestration.
+  kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00 resume           Resume the given paused orchestration.
+  kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00 retry            Retry the failed and canceled operations of the given orchestration.
+  kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00 retry OID1 OID2  Retry the specified operations of the given orchestration.`,
 		PreRunE: func(_ *cobra.Command, args []string) error { return cmd.Validate(args) },
 		RunE:    func(_ *cobra.Command, args []string) error { return cmd.Run(args) },
 	}
@@ -213,11 +262,15 @@ func (cmd *OrchestrationCommand) Run(args []string) error {
 			return cmd.showOneOrchestration(args[0])
 		}
 		return cmd.showOperationDetails(args[0])
-	case 2:
+	default:
 		// Called with orchestration ID and subcommand
 		switch cmd.subCommand {
+		case retryCommand:
+			return cmd.retryOrchestration(args[0], args[2:])
 		case cancelCommand:
 			return cmd.cancelOrchestration(args[0])
+		case resumeCommand:
+			return cmd.resumeOrchestration(args[0])
 		case operationsCommand, opsCommand:
 			return cmd.showOperations(args[0])
 		}
@@ -245,10 +298,14 @@ func (cmd *OrchestrationCommand) Validate(args []string) error {
 		return errors.New("--state should not be used together with --operation")
 	}
 
-	if len(args) == 2 {
+	if len(args) >= 2 {
 		cmd.subCommand = args[1]
 		switch cmd.subCommand {
-		case cancelCommand, operationsCommand, opsCommand:
+		case cancelCommand, resumeCommand, operationsCommand, opsCommand:
+			if len(args) > 2 {
+				return fmt.Errorf("too many arguments for subcommand: %s", cmd.subCommand)
+			}
+		case retryCommand:
 		default:
 			return fmt.Errorf("invalid subcommand: %s", cmd.subCommand)
 		}
@@ -299,6 +356,7 @@ func (cmd *OrchestrationCommand) showOneOrchestration(orchestrationID string) er
 	case tableOutput:
 		// Print orchestration details via template
 		funcMap := template.FuncMap{
+			"orchestrationType":   orchestrationType,
 			"orchestrationTarget": orchestrationTarget,
 			"orchestrationStates": orchestrationStates,
 		}
@@ -350,7 +408,10 @@ func (cmd *OrchestrationCommand) showOperationDetails(orchestrationID string) er
 
 	switch cmd.output {
 	case tableOutput:
-		tmpl, err := template.New("operationDetails").Parse(operationDetailsTpl)
+		funcMap := template.FuncMap{
+			"configEntryDiff": configEntryDiff,
+		}
+		tmpl, err := template.New("operationDetails").Funcs(funcMap).Parse(operationDetailsTpl)
 		if err != nil {
 			return errors.Wrap(err, "while parsing operation details template")
 		}
@@ -392,10 +453,87 @@ func (cmd *OrchestrationCommand) cancelOrchestration(orchestrationID string) err
 
 }
 
-// Currently only orchestrations of type "kyma upgrade" are supported,
-// and the type is not reflected in the StatusResponse object
+func (cmd *OrchestrationCommand) resumeOrchestration(orchestrationID string) error {
+	sr, err := cmd.client.GetOrchestration(orchestrationID)
+	if err != nil {
+		return errors.Wrap(err, "while getting orchestration")
+	}
+	switch sr.State {
+	case orchestration.Pending, orchestration.InProgress:
+		fmt.Println("Orchestration is not paused.")
+		return nil
+	case orchestration.Paused:
+	default:
+		return fmt.Errorf("orchestration is already %s", sr.State)
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	fmt.Printf("%d failed operation(s) paused the orchestration, %d pending operation(s) will be resumed.\n", sr.OperationStats[orchestration.Failed], sr.OperationStats[orchestration.Pending])
+	fmt.Print("Do you want to continue? (Y/N) ")
+	scanner.Scan()
+	if scanner.Text() != "Y" {
+		fmt.Println("Aborted.")
+		return nil
+	}
+
+	return cmd.client.ResumeOrchestration(orchestrationID)
+}
+
+func (cmd *OrchestrationCommand) retryOrchestration(orchestrationID string, operationIDs []string) error {
+	sr, err := cmd.client.GetOrchestration(orchestrationID)
+	if err != nil {
+		return errors.Wrap(err, "while getting orchestration")
+	}
+	switch sr.State {
+	case orchestration.Failed, orchestration.Succeeded, orchestration.Canceled:
+	default:
+		return fmt.Errorf("orchestration is still %s, only finished orchestrations can be retried", sr.State)
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if len(operationIDs) == 0 {
+		fmt.Printf("%d failed and %d canceled operation(s) will be retried in a new orchestration.\n", sr.OperationStats[orchestration.Failed], sr.OperationStats[orchestration.Canceled])
+	} else {
+		fmt.Printf("%d operation(s) will be retried in a new orchestration.\n", len(operationIDs))
+	}
+	fmt.Print("Do you want to continue? (Y/N) ")
+	scanner.Scan()
+	if scanner.Text() != "Y" {
+		fmt.Println("Aborted.")
+		return nil
+	}
+
+	ur, err := cmd.client.RetryOrchestration(orchestrationID, operationIDs)
+	if err != nil {
+		return errors.Wrap(err, "while retrying orchestration")
+	}
+	fmt.Println("OrchestrationID:", ur.OrchestrationID)
+
+	return nil
+}
+
+// orchestrationType returns the human readable type of the orchestration,
+// orchestrations created before the type was introduced are kyma upgrades
 func orchestrationType(obj interface{}) string {
-	return "kyma upgrade"
+	sr := obj.(orchestration.StatusResponse)
+	switch sr.Type {
+	case orchestration.UpgradeClusterOrchestration:
+		return "cluster upgrade"
+	default:
+		return "kyma upgrade"
+	}
+}
+
+// configEntryDiff returns the human readable change of the override in the Kyma configuration diff
+func configEntryDiff(e orchestration.ConfigEntryDiff) string {
+	switch e.Change {
+	case orchestration.DiffAdded:
+		return fmt.Sprintf("+ %s: %s", e.Key, e.Target)
+	case orchestration.DiffRemoved:
+		return fmt.Sprintf("- %s: %s", e.Key, e.Current)
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", e.Key, e.Current, e.Target)
+	}
 }
 
 func orchestrationCreatedAt(obj interface{}) string {
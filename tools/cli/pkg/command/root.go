@@ -52,7 +52,9 @@ See the **Global Options** section of each command for the description of these
 		NewLoginCmd(),
 		NewRuntimeCmd(),
 		NewOrchestrationCmd(),
+		NewOperationCmd(),
 		NewKubeconfigCmd(),
+		NewWakeUpCmd(),
 		NewUpgradeCmd(),
 		NewTaskRunCmd(),
 		NewCompletionCommand(),
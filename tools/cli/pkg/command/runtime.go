@@ -174,6 +174,8 @@ func operationStatusToString(op runtime.Operation, t runtime.OperationType) stri
 			return "provisioning"
 		case runtime.Unsuspension:
 			return "provisioning (unsuspending)"
+		case runtime.WakeUp:
+			return "waking up"
 		case runtime.Deprovision:
 			return "deprovisioning"
 		case runtime.Suspension:
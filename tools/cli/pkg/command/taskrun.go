@@ -47,6 +47,7 @@ type RuntimeLister struct {
 // RuntimeTask is the runtime operation executed by RuntimeTaskMakager via strategy.
 type RuntimeTask struct {
 	operation orchestration.RuntimeOperation
+	started   bool
 	result    error
 }
 
@@ -119,7 +120,7 @@ func (cmd *TaskRunCommand) Run(args []string) error {
 	}
 
 	mgr := NewRuntimeTaskMakager(cmd, operations)
-	strategy := strategies.NewParallelOrchestrationStrategy(mgr, cmd.log)
+	strategy := strategies.NewParallelOrchestrationStrategy(mgr, mgr, cmd.log)
 	execID, err := strategy.Execute(operations, orchestration.StrategySpec{
 		Type:     orchestration.ParallelStrategy,
 		Schedule: orchestration.Immediate,
@@ -249,6 +250,7 @@ func NewRuntimeTaskMakager(cmd *TaskRunCommand, operations []orchestration.Runti
 // Execute runs the task on the runtime identified by the operationID
 func (mgr *RuntimeTaskMakager) Execute(operationID string) (time.Duration, error) {
 	task := mgr.tasks[operationID]
+	task.started = true
 	log := mgr.cmd.log.WithField("shoot", task.operation.ShootName)
 
 	kubeconfigPath, err := mgr.getKubeconfig(task)
@@ -320,6 +322,24 @@ func (mgr *RuntimeTaskMakager) Execute(operationID string) (time.Duration, error
 	return 0, err
 }
 
+// State returns the state of the task identified by the operationID
+func (mgr *RuntimeTaskMakager) State(operationID string) (string, error) {
+	task := mgr.tasks[operationID]
+	switch {
+	case !task.started:
+		return orchestration.Pending, nil
+	case task.result != nil:
+		return orchestration.Failed, nil
+	default:
+		return orchestration.InProgress, nil
+	}
+}
+
+// Cancel does nothing, the tasks are not canceled
+func (mgr *RuntimeTaskMakager) Cancel(operationID, description string) error {
+	return nil
+}
+
 func (mgr *RuntimeTaskMakager) getKubeconfig(task *RuntimeTask) (string, error) {
 	path := ""
 	if !mgr.cmd.noKubeconfig {
@@ -2,7 +2,10 @@ package command
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
@@ -16,6 +19,8 @@ type UpgradeCommand struct {
 	targetExcludeInputs []string
 	strategy            string
 	schedule            string
+	startTime           string
+	blackouts           []string
 	orchestrationParams orchestration.Parameters
 }
 
@@ -35,15 +40,25 @@ func NewUpgradeCmd() *cobra.Command {
 	}
 
 	cobraCmd.AddCommand(NewUpgradeKymaCmd())
+	cobraCmd.AddCommand(NewUpgradeClusterCmd())
 	return cobraCmd
 }
 
 // SetUpgradeOpts configures the upgrade specific options on the given command
 func (cmd *UpgradeCommand) SetUpgradeOpts(cobraCmd *cobra.Command) {
 	SetRuntimeTargetOpts(cobraCmd, &cmd.targetInputs, &cmd.targetExcludeInputs)
-	cobraCmd.Flags().StringVar(&cmd.strategy, "strategy", string(orchestration.ParallelStrategy), "Orchestration strategy to use.")
+	cobraCmd.Flags().StringVar(&cmd.strategy, "strategy", string(orchestration.ParallelStrategy), "Orchestration strategy to use. Possible values: \"parallel\", \"staged\".")
 	cobraCmd.Flags().IntVar(&cmd.orchestrationParams.Strategy.Parallel.Workers, "parallel-workers", 0, "Number of parallel workers to use in parallel orchestration strategy. By default the amount of workers will be auto-selected on control plane server side.")
+	cobraCmd.Flags().IntVar(&cmd.orchestrationParams.Strategy.Staged.CanarySize, "canary-size", 0, "Number of Runtimes upgraded in the canary stage of the staged orchestration strategy. By default, one Runtime is upgraded in the canary stage.")
+	cobraCmd.Flags().IntVar(&cmd.orchestrationParams.Strategy.Staged.CanaryPercentage, "canary-percentage", 0, "Percentage of Runtimes upgraded in the canary stage of the staged orchestration strategy. Used when --canary-size is not set.")
+	cobraCmd.Flags().IntVar(&cmd.orchestrationParams.Strategy.Staged.WaveSize, "wave-size", 0, "Number of Runtimes upgraded in each wave following the canary stage of the staged orchestration strategy. By default, all remaining Runtimes are upgraded in a single wave.")
+	cobraCmd.Flags().IntVar(&cmd.orchestrationParams.Strategy.Staged.WavePercentage, "wave-percentage", 0, "Percentage of Runtimes upgraded in each wave following the canary stage of the staged orchestration strategy. Used when --wave-size is not set.")
+	cobraCmd.Flags().StringVar(&cmd.orchestrationParams.Strategy.Staged.SoakTime, "soak-time", "", "Time to wait after a stage of the staged orchestration strategy finishes before the next stage starts, e.g. \"30m\".")
+	cobraCmd.Flags().IntVar(&cmd.orchestrationParams.Strategy.MaxFailures, "max-failures", 0, "Number of failed upgrade operations above which the orchestration is paused. By default, the number of failures is not limited, the staged orchestration is paused on the first failure.")
+	cobraCmd.Flags().Float64Var(&cmd.orchestrationParams.Strategy.MaxFailureRatio, "max-failure-ratio", 0, "Ratio (0-1) of failed to finished upgrade operations above which the orchestration is paused. By default, the ratio of failures is not limited, the staged orchestration is paused on the first failure.")
 	cobraCmd.Flags().StringVar(&cmd.schedule, "schedule", "", "Orchestration schedule to use. Possible values: \"immediate\", \"maintenancewindow\". By default the schedule will be auto-selected on control plane server side.")
+	cobraCmd.Flags().StringVar(&cmd.startTime, "start-time", "", "Time in the RFC 3339 format before which the orchestration is not started, e.g. \"2021-03-09T02:00:00Z\". By default, the orchestration is started immediately.")
+	cobraCmd.Flags().StringArrayVar(&cmd.blackouts, "blackout", nil, "Period of time in which no upgrade operations are started, in the format \"<start>/<end>\" with times in the RFC 3339 format, e.g. \"2021-03-25T00:00:00Z/2021-04-01T00:00:00Z\". Multiple blackout periods can be specified.")
 	cobraCmd.Flags().BoolVar(&cmd.orchestrationParams.DryRun, "dry-run", false, "Perform the orchestration without executing the actual upgrage operations for the Runtimes. The details can be obtained using the \"kcp orchestrations\" command.")
 }
 
@@ -61,13 +76,67 @@ func (cmd *UpgradeCommand) ValidateTransformUpgradeOpts() error {
 		return fmt.Errorf("invalid value for schedule: %s. Check kcp upgrade --help for more information", cmd.schedule)
 	}
 
+	// Validate start time and blackout periods
+	if cmd.startTime != "" {
+		startTime, err := time.Parse(time.RFC3339, cmd.startTime)
+		if err != nil {
+			return fmt.Errorf("invalid value for start-time: %s", cmd.startTime)
+		}
+		cmd.orchestrationParams.Strategy.StartTime = &startTime
+	}
+	for _, b := range cmd.blackouts {
+		blackout, err := parseBlackout(b)
+		if err != nil {
+			return errors.Wrapf(err, "invalid value for blackout: %s", b)
+		}
+		cmd.orchestrationParams.Strategy.Blackouts = append(cmd.orchestrationParams.Strategy.Blackouts, blackout)
+	}
+
+	// Validate failure threshold
+	if cmd.orchestrationParams.Strategy.MaxFailures < 0 {
+		return fmt.Errorf("invalid value for max-failures: %d", cmd.orchestrationParams.Strategy.MaxFailures)
+	}
+	if ratio := cmd.orchestrationParams.Strategy.MaxFailureRatio; ratio < 0 || ratio > 1 {
+		return fmt.Errorf("invalid value for max-failure-ratio: %v", ratio)
+	}
+
 	// Validate strategy type
 	switch cmd.strategy {
 	case string(orchestration.ParallelStrategy):
 		cmd.orchestrationParams.Strategy.Type = orchestration.StrategyType(cmd.strategy)
+		if cmd.orchestrationParams.Strategy.Staged != (orchestration.StagedStrategySpec{}) {
+			return fmt.Errorf("staged strategy options can be used only with the %s strategy", orchestration.StagedStrategy)
+		}
+	case string(orchestration.StagedStrategy):
+		cmd.orchestrationParams.Strategy.Type = orchestration.StrategyType(cmd.strategy)
+		if soakTime := cmd.orchestrationParams.Strategy.Staged.SoakTime; soakTime != "" {
+			if _, err := time.ParseDuration(soakTime); err != nil {
+				return fmt.Errorf("invalid value for soak-time: %s", soakTime)
+			}
+		}
 	default:
 		return fmt.Errorf("invalid value for strategy: %s", cmd.strategy)
 	}
 
 	return nil
 }
+
+// parseBlackout parses the blackout period in the "<start>/<end>" format
+func parseBlackout(value string) (orchestration.BlackoutPeriod, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) != 2 {
+		return orchestration.BlackoutPeriod{}, errors.New("the format must be <start>/<end>")
+	}
+	start, err := time.Parse(time.RFC3339, parts[0])
+	if err != nil {
+		return orchestration.BlackoutPeriod{}, errors.Wrap(err, "while parsing start")
+	}
+	end, err := time.Parse(time.RFC3339, parts[1])
+	if err != nil {
+		return orchestration.BlackoutPeriod{}, errors.Wrap(err, "while parsing end")
+	}
+	if !end.After(start) {
+		return orchestration.BlackoutPeriod{}, errors.New("the end must be after the start")
+	}
+	return orchestration.BlackoutPeriod{Start: start, End: end}, nil
+}
@@ -0,0 +1,60 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// UpgradeClusterCommand represents an execution of the kcp upgrade cluster command. Inherits fields and methods of UpgradeCommand
+type UpgradeClusterCommand struct {
+	UpgradeCommand
+	cobraCmd *cobra.Command
+}
+
+// NewUpgradeClusterCmd constructs a new instance of UpgradeClusterCommand and configures it in terms of a cobra.Command
+func NewUpgradeClusterCmd() *cobra.Command {
+	cmd := UpgradeClusterCommand{UpgradeCommand: UpgradeCommand{}}
+	cobraCmd := &cobra.Command{
+		Use:   "cluster --target {TARGET SPEC} ... [--target-exclude {TARGET SPEC} ...]",
+		Short: "Upgrades the Kubernetes cluster of one or more Kyma Runtimes.",
+		Long: `Upgrades the Kubernetes and machine image versions of the clusters on targets of Runtimes.
+The upgrade is performed by Kyma Control Plane (KCP) within a new orchestration asynchronously. The ID of the orchestration is returned by the command upon success.
+The targets of Runtimes are specified via the --target and --target-exclude options. At least one --target must be specified.
+The Kubernetes and machine image versions to use for the upgrade are taken from Kyma Control Plane during the processing of the orchestration.`,
+		Example: `  kcp upgrade cluster --target all --schedule maintenancewindow     Upgrade clusters of all Runtimes in their next respective maintenance window hours.
+  kcp upgrade cluster --target "account=CA.*"                       Upgrade clusters of Runtimes of all global accounts starting with CA.
+  kcp upgrade cluster --target all --target-exclude "account=CA.*"  Upgrade clusters of Runtimes of all global accounts not starting with CA.
+  kcp upgrade cluster --target "region=europe|eu|uk"                Upgrade clusters of Runtimes whose region belongs to Europe.`,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return cmd.Validate() },
+		RunE:    func(_ *cobra.Command, _ []string) error { return cmd.Run() },
+	}
+	cmd.cobraCmd = cobraCmd
+
+	cmd.SetUpgradeOpts(cobraCmd)
+	return cobraCmd
+}
+
+// Run executes the upgrade cluster command
+func (cmd *UpgradeClusterCommand) Run() error {
+	cmd.log = logger.New()
+	client := orchestration.NewClient(cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLICredentialManager(cmd.log))
+	ur, err := client.UpgradeCluster(cmd.orchestrationParams)
+	if err != nil {
+		return errors.Wrap(err, "while triggering cluster upgrade")
+	}
+	fmt.Println("OrchestrationID:", ur.OrchestrationID)
+	return nil
+}
+
+// Validate checks the input parameters of the upgrade cluster command
+func (cmd *UpgradeClusterCommand) Validate() error {
+	err := cmd.ValidateTransformUpgradeOpts()
+	if err != nil {
+		return err
+	}
+	return nil
+}
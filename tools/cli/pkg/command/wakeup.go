@@ -0,0 +1,61 @@
+package command
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// WakeUpCommand represents an execution of the kcp wakeup command
+type WakeUpCommand struct {
+	cobraCmd *cobra.Command
+	log      logger.Logger
+	client   runtime.Client
+}
+
+// NewWakeUpCmd constructs a new instance of WakeUpCommand and configures it in terms of a cobra.Command
+func NewWakeUpCmd() *cobra.Command {
+	cmd := WakeUpCommand{}
+	cobraCmd := &cobra.Command{
+		Use:   "wakeup instance-id",
+		Short: "Wakes up the hibernated Kyma Runtime of a trial instance.",
+		Long: `Wakes up the hibernated Kyma Runtime of the given trial instance.
+Use the command when the wake-up triggered by the unsuspension of the instance failed. The command schedules a new wake-up operation
+and displays its ID, which can be used to follow the progress with the ` + "`kcp operations`" + ` command.
+The wake-up is rejected if the instance is suspended, its Kyma Runtime is not hibernated, or the previous wake-up has not failed.`,
+		Example: `  kcp wakeup 0c4357f5-83e0-4b72-9472-49b5cd417c00    Wake up the hibernated Kyma Runtime of the given trial instance.`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    func(_ *cobra.Command, args []string) error { return cmd.Run(args[0]) },
+	}
+	cmd.cobraCmd = cobraCmd
+
+	return cobraCmd
+}
+
+// Run executes the wakeup command
+func (cmd *WakeUpCommand) Run(instanceID string) error {
+	cmd.log = logger.New()
+	cmd.client = runtime.NewClient(cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLICredentialManager(cmd.log))
+
+	scanner := bufio.NewScanner(os.Stdin)
+	fmt.Printf("The hibernated Kyma Runtime of the instance %s will be woken up.\n", instanceID)
+	fmt.Print("Do you want to continue? (Y/N) ")
+	scanner.Scan()
+	if scanner.Text() != "Y" {
+		fmt.Println("Aborted.")
+		return nil
+	}
+
+	wr, err := cmd.client.WakeUpRuntime(instanceID)
+	if err != nil {
+		return errors.Wrap(err, "while waking up runtime")
+	}
+	fmt.Println("OperationID:", wr.OperationID)
+
+	return nil
+}